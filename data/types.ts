@@ -26,6 +26,8 @@ export interface Meta {
   sshAddress: string;
   /** URL of the source code repository. */
   sourceRepo: string;
+  /** Date the content was last revised (YYYY-MM-DD). */
+  lastUpdated?: string;
 }
 
 // ---------------------------------------------------------------------------
@@ -46,16 +48,28 @@ export interface Education {
 // About
 // ---------------------------------------------------------------------------
 
+/** Whether the owner is open to work, for which roles, and from when. */
+export interface Availability {
+  /** Whether the owner is open to work. */
+  openToWork: boolean;
+  /** Roles the owner is looking for. */
+  roles?: string[];
+  /** Earliest start date (YYYY-MM-DD). Omit to start right away. */
+  start?: string;
+}
+
 /** Biographical information, availability status, contact, education, and interests. */
 export interface About {
   /** Short biography paragraph. */
   bio: string;
-  /** Current availability or professional status. */
-  status: string;
+  /** Hiring status, shown as a badge on Home and CV. */
+  availability?: Availability;
   /** Contact email address. */
   email: string;
   /** SSH hostname for the CLI portfolio. */
   cli: string;
+  /** Scheduling link (Cal.com, Calendly) offered on Home and by the :book command. */
+  booking?: string;
   /** List of educational credentials. */
   education?: Education[];
   /** List of professional or personal interests. */
@@ -159,3 +173,161 @@ export interface Links {
   /** List of external links. */
   links: Link[];
 }
+
+// ---------------------------------------------------------------------------
+// Experiments
+// ---------------------------------------------------------------------------
+
+/** One alternative value for an experiment's field. */
+export interface ExperimentVariant {
+  /** Variant name recorded in analytics events (e.g. "a", "b"). */
+  name: string;
+  /** Replacement text for the field. */
+  value: string;
+}
+
+/** An A/B experiment swapping a single text field between variants. */
+export interface Experiment {
+  /** Unique experiment identifier recorded in analytics events. */
+  id: string;
+  /** Content field the variants replace. */
+  field: "about.bio" | "meta.oneLiner" | "cv.summary";
+  /** Alternative values for the field (at least two). */
+  variants: ExperimentVariant[];
+}
+
+/** Optional A/B content experiments from experiments.json. */
+export interface Experiments {
+  /** List of running experiments. */
+  experiments: Experiment[];
+}
+
+// ---------------------------------------------------------------------------
+// Keys
+// ---------------------------------------------------------------------------
+
+/** One of the owner's public keys, given inline or as a file in data/. */
+export interface PublicKey {
+  /** Display name of the key (e.g. "Laptop", "Signing"). */
+  label: string;
+  /** Kind of key. */
+  type: "pgp" | "ssh";
+  /** ASCII-armored PGP public key block or authorized_keys line. */
+  key?: string;
+  /** Path of a file holding the key, relative to the data directory. */
+  file?: string;
+}
+
+/** Optional public keys from keys.json, shown by the :keys command. */
+export interface Keys {
+  /** List of public keys. */
+  keys: PublicKey[];
+}
+
+// ---------------------------------------------------------------------------
+// Uses
+// ---------------------------------------------------------------------------
+
+/** One tool in the owner's setup. */
+export interface UsesItem {
+  /** Tool name (e.g. "Neovim", "ThinkPad X1"). */
+  name: string;
+  /** What it is used for. */
+  description?: string;
+  /** Where to find it; the name links here. */
+  url?: string;
+}
+
+/** Tools of one kind, such as an editor or hardware. */
+export interface UsesCategory {
+  /** Category name (e.g. "Terminal"). */
+  category: string;
+  /** Tools in the category (at least one). */
+  items: UsesItem[];
+}
+
+/** Optional hardware and software setup from uses.json, shown in the uses section. */
+export interface Uses {
+  /** List of categories (at least one). */
+  categories: UsesCategory[];
+}
+
+// ---------------------------------------------------------------------------
+// Talks
+// ---------------------------------------------------------------------------
+
+/** One talk, interview, or podcast appearance. */
+export interface Talk {
+  /** Name of the talk. */
+  title: string;
+  /** Where it was given (e.g. "GopherCon 2025"). */
+  event?: string;
+  /** When it was given, as YYYY-MM-DD. */
+  date: string;
+  /** URL of the recording. */
+  video?: string;
+  /** URL of the slides. */
+  slides?: string;
+  /** PNG or JPEG shown as braille art, relative to the data directory. */
+  thumbnail?: string;
+}
+
+/** Optional talks from talks.json, listed newest first in the talks section. */
+export interface Talks {
+  /** List of talks (at least one). */
+  talks: Talk[];
+}
+
+// ---------------------------------------------------------------------------
+// Boot messages
+// ---------------------------------------------------------------------------
+
+/** One line of the boot sequence. */
+export interface BootMessage {
+  /** Line shown. */
+  text: string;
+  /** Color category. */
+  type: "system" | "info" | "success" | "accent";
+  /** Milliseconds to wait before showing the line (0 to 5000); omitted keeps the usual pace. */
+  delayMs?: number;
+}
+
+/** Optional boot sequence from assets/boot-messages.json, shown by the intro. */
+export interface BootMessages {
+  /** Lines in the order they appear (at least one). */
+  messages: BootMessage[];
+}
+
+// ---------------------------------------------------------------------------
+// Theme
+// ---------------------------------------------------------------------------
+
+/** Overrides for one theme's colors, each a #rgb or #rrggbb hex color. */
+export interface ThemeColors {
+  /** Background. */
+  bg?: string;
+  /** Body text. */
+  fg?: string;
+  /** Titles, the active section, and errors. */
+  accent?: string;
+  /** Secondary text and hints. */
+  muted?: string;
+  /** Borders and rules. */
+  border?: string;
+  /** Good states, such as a passing check. */
+  success?: string;
+  /** Cautionary states. */
+  warning?: string;
+  /** Status bar background; the border color when unset. */
+  statusBarBg?: string;
+  /** Status bar text; the muted color when unset. */
+  statusBarFg?: string;
+}
+
+/** Optional color overrides from theme.json for the terminal themes. */
+export interface Theme {
+  /** Colors of the dark theme. */
+  dark?: ThemeColors;
+  /** Colors of the light theme. */
+  light?: ThemeColors;
+}
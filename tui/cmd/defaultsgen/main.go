@@ -0,0 +1,55 @@
+// Command defaultsgen copies the files the content loader reads from a
+// data directory into the content package's embedded defaults, after
+// checking that they load. It runs from go generate:
+//
+//	defaultsgen <data dir> <output dir>
+package main
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/buntingszn/terminal-portfolio/tui/internal/content"
+)
+
+func main() {
+	if len(os.Args) != 3 {
+		fmt.Fprintln(os.Stderr, "usage: defaultsgen <data dir> <output dir>")
+		os.Exit(2)
+	}
+	if err := run(os.Args[1], os.Args[2]); err != nil {
+		fmt.Fprintf(os.Stderr, "defaultsgen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(dataDir, outDir string) error {
+	c, err := content.LoadAll(dataDir)
+	if err != nil {
+		return err
+	}
+	files, err := content.DataFiles(os.DirFS(dataDir), c)
+	if err != nil {
+		return err
+	}
+	// Start over, so files removed from the data directory go too.
+	if err := os.RemoveAll(outDir); err != nil {
+		return err
+	}
+	for _, name := range files {
+		data, err := fs.ReadFile(os.DirFS(dataDir), name)
+		if err != nil {
+			return err
+		}
+		dst := filepath.Join(outDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(dst, data, 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
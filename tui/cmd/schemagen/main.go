@@ -0,0 +1,42 @@
+// Command schemagen writes the content JSON Schemas generated from the
+// content package's types. It runs from go generate:
+//
+//	schemagen <content package dir> <output dir>...
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/buntingszn/terminal-portfolio/tui/internal/content"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/content/schemagen"
+)
+
+func main() {
+	if len(os.Args) < 3 {
+		fmt.Fprintln(os.Stderr, "usage: schemagen <content package dir> <output dir>...")
+		os.Exit(2)
+	}
+	schemas, err := schemagen.Generate(os.Args[1])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "schemagen: %v\n", err)
+		os.Exit(1)
+	}
+	for _, dir := range os.Args[2:] {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			fmt.Fprintf(os.Stderr, "schemagen: %v\n", err)
+			os.Exit(1)
+		}
+		for file, s := range schemas {
+			data, err := schemagen.Marshal(s)
+			if err == nil {
+				err = os.WriteFile(filepath.Join(dir, content.SchemaFileName(file)), data, 0o644)
+			}
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "schemagen: %v\n", err)
+				os.Exit(1)
+			}
+		}
+	}
+}
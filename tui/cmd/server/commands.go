@@ -0,0 +1,540 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/buntingszn/terminal-portfolio/tui/internal/analytics"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/braille"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/config"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/content"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/notify"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/source"
+	"github.com/charmbracelet/lipgloss"
+	"golang.org/x/term"
+)
+
+// subcommands maps CLI subcommand names to their handlers. Each handler
+// receives the arguments after the subcommand name and returns the process
+// exit code. Running the binary without arguments starts the SSH server.
+var subcommands = map[string]func(args []string) int{
+	"report":      runReport,
+	"journeys":    runJourneys,
+	"experiments": runExperiments,
+	"keygen":      runKeygen,
+	"sign":        runSign,
+	"summary":     runSummary,
+	"schema":      runSchema,
+	"validate":    runValidate,
+	"init":        runInit,
+	"braille":     runBraille,
+}
+
+// runSubcommand dispatches to the named subcommand, reporting unknown names
+// on stderr with exit code 2.
+func runSubcommand(name string, args []string) int {
+	run, ok := subcommands[name]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n", name)
+		return 2
+	}
+	return run(args)
+}
+
+// runReport prints a content coverage summary: word counts and read time
+// per section, plus any optional fields left empty.
+func runReport(args []string) int {
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "report: %v\n", err)
+		return 1
+	}
+
+	fs := flag.NewFlagSet("report", flag.ContinueOnError)
+	dataDir := fs.String("data", cfg.DataDir, "path or remote source of the data directory")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	src, err := source.New(*dataDir, cfg.ContentCache, cfg.ContentSHA256, cfg.ContentPublicKey)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "report: %v\n", err)
+		return 1
+	}
+	dir, err := source.SyncOnce(context.Background(), src)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "report: %v\n", err)
+		return 1
+	}
+	c, err := content.LoadAll(dir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "report: %v\n", err)
+		return 1
+	}
+
+	fmt.Printf("Content report for %s (%s)\n\n", c.Meta.Name, *dataDir)
+	if err := content.BuildReport(c).Write(os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "report: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// runJourneys prints anonymized visitor journeys reconstructed from the
+// analytics log: the order sections were viewed in, how long each held
+// the visitor, and a proportional timeline bar.
+func runJourneys(args []string) int {
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "journeys: %v\n", err)
+		return 1
+	}
+
+	fs := flag.NewFlagSet("journeys", flag.ContinueOnError)
+	file := fs.String("f", cfg.AnalyticsFile, "analytics JSONL file")
+	dsn := fs.String("dsn", cfg.AnalyticsDSN, "analytics SQLite database, read instead of -f")
+	limit := fs.Int("n", 20, "show the most recent N journeys (0 for all)")
+	width := fs.Int("width", 60, "timeline bar width in columns")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	journeys, err := readJourneys(eventSource{*file, *dsn})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "journeys: %v\n", err)
+		return 1
+	}
+	if *limit > 0 && len(journeys) > *limit {
+		journeys = journeys[len(journeys)-*limit:]
+	}
+	if err := analytics.WriteJourneys(os.Stdout, journeys, *width); err != nil {
+		fmt.Fprintf(os.Stderr, "journeys: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// runExperiments compares A/B experiment variants from the analytics log:
+// sessions per variant, average session length and its difference from
+// the first variant, and average dwell per section.
+func runExperiments(args []string) int {
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "experiments: %v\n", err)
+		return 1
+	}
+
+	fs := flag.NewFlagSet("experiments", flag.ContinueOnError)
+	file := fs.String("f", cfg.AnalyticsFile, "analytics JSONL file")
+	dsn := fs.String("dsn", cfg.AnalyticsDSN, "analytics SQLite database, read instead of -f")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	journeys, err := readJourneys(eventSource{*file, *dsn})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "experiments: %v\n", err)
+		return 1
+	}
+	if err := analytics.WriteVariantStats(os.Stdout, analytics.CompareVariants(journeys)); err != nil {
+		fmt.Fprintf(os.Stderr, "experiments: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// runKeygen creates an ed25519 key pair for signing content bundles. The
+// private key is written to the given file, readable only by its owner,
+// and the public key is printed for TERMINAL_PORTFOLIO_CONTENT_PUBLIC_KEY.
+func runKeygen(args []string) int {
+	fs := flag.NewFlagSet("keygen", flag.ContinueOnError)
+	out := fs.String("o", "content-signing.key", "file to write the private key to")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "keygen: %v\n", err)
+		return 1
+	}
+	seed := base64.StdEncoding.EncodeToString(priv.Seed()) + "\n"
+	f, err := os.OpenFile(*out, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "keygen: %v\n", err)
+		return 1
+	}
+	if _, err := f.WriteString(seed); err != nil {
+		f.Close()
+		fmt.Fprintf(os.Stderr, "keygen: %v\n", err)
+		return 1
+	}
+	if err := f.Close(); err != nil {
+		fmt.Fprintf(os.Stderr, "keygen: %v\n", err)
+		return 1
+	}
+	fmt.Println(base64.StdEncoding.EncodeToString(pub))
+	return 0
+}
+
+// runSign signs a content bundle with a private key from keygen, writing
+// the signature next to the bundle as <bundle>.sig for upload alongside it.
+func runSign(args []string) int {
+	fs := flag.NewFlagSet("sign", flag.ContinueOnError)
+	keyFile := fs.String("key", "content-signing.key", "private key file written by keygen")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "usage: sign [-key file] bundle.tar.gz")
+		return 2
+	}
+
+	b, err := os.ReadFile(*keyFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "sign: %v\n", err)
+		return 1
+	}
+	seed, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(b)))
+	if err != nil || len(seed) != ed25519.SeedSize {
+		fmt.Fprintf(os.Stderr, "sign: %s is not a key written by keygen\n", *keyFile)
+		return 1
+	}
+	bundle := fs.Arg(0)
+	data, err := os.ReadFile(bundle)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "sign: %v\n", err)
+		return 1
+	}
+	sig := ed25519.Sign(ed25519.NewKeyFromSeed(seed), data)
+	if err := os.WriteFile(bundle+".sig", []byte(base64.StdEncoding.EncodeToString(sig)+"\n"), 0o644); err != nil {
+		fmt.Fprintf(os.Stderr, "sign: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// runSummary prints the analytics summary for the last complete day or
+// week, and with -send also delivers it as the scheduled summary would.
+func runSummary(args []string) int {
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "summary: %v\n", err)
+		return 1
+	}
+
+	fs := flag.NewFlagSet("summary", flag.ContinueOnError)
+	file := fs.String("f", cfg.AnalyticsFile, "analytics JSONL file")
+	dsn := fs.String("dsn", cfg.AnalyticsDSN, "analytics SQLite database, read instead of -f")
+	period := fs.String("period", "daily", "period to summarize: daily or weekly")
+	send := fs.Bool("send", false, "deliver the summary to the configured webhook and email")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	p := analytics.Period(*period)
+	if p != analytics.Daily && p != analytics.Weekly {
+		fmt.Fprintf(os.Stderr, "summary: period must be daily or weekly, got %q\n", *period)
+		return 2
+	}
+
+	if *send {
+		n, err := summaryNotifier(cfg)
+		if err == nil && len(n.(notify.All)) == 0 {
+			err = fmt.Errorf("no webhook or email configured")
+		}
+		if err == nil {
+			err = sendSummary(context.Background(), eventSource{*file, *dsn}, p, time.Now(), n)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "summary: %v\n", err)
+			return 1
+		}
+		return 0
+	}
+
+	s, err := summarize(eventSource{*file, *dsn}, p, time.Now())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "summary: %v\n", err)
+		return 1
+	}
+	if err := s.Write(os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "summary: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// runSchema prints the JSON Schema of a content file, such as cv.json, so
+// editors can check and complete it. Without a file it lists the files
+// that have a schema; with -o it writes every schema into a directory.
+func runSchema(args []string) int {
+	fs := flag.NewFlagSet("schema", flag.ContinueOnError)
+	out := fs.String("o", "", "directory to write every schema into")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	switch {
+	case *out != "":
+		if err := os.MkdirAll(*out, 0o755); err != nil {
+			fmt.Fprintf(os.Stderr, "schema: %v\n", err)
+			return 1
+		}
+		for _, file := range content.SchemaFiles() {
+			data, _ := content.SchemaJSON(file)
+			if err := os.WriteFile(filepath.Join(*out, content.SchemaFileName(file)), data, 0o644); err != nil {
+				fmt.Fprintf(os.Stderr, "schema: %v\n", err)
+				return 1
+			}
+		}
+	case fs.NArg() == 0:
+		for _, file := range content.SchemaFiles() {
+			fmt.Println(file)
+		}
+	case fs.NArg() == 1:
+		file := strings.TrimSuffix(fs.Arg(0), ".json") + ".json"
+		data, ok := content.SchemaJSON(file)
+		if !ok {
+			fmt.Fprintf(os.Stderr, "schema: no schema for %q; use one of %s\n", fs.Arg(0), strings.Join(content.SchemaFiles(), ", "))
+			return 1
+		}
+		_, _ = os.Stdout.Write(data)
+	default:
+		fmt.Fprintln(os.Stderr, "usage: schema [-o dir] [file.json]")
+		return 2
+	}
+	return 0
+}
+
+// runValidate checks the content in a data directory as the server loads
+// it, then lints it for mistakes loading accepts, printing each problem
+// with its file, position, and field. It exits 1 when any is an error, so
+// CI can run it on every change to the content.
+func runValidate(args []string) int {
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "validate: %v\n", err)
+		return 1
+	}
+
+	fs := flag.NewFlagSet("validate", flag.ContinueOnError)
+	dataDir := fs.String("data", cfg.DataDir, "path or remote source of the data directory")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	src, err := source.New(*dataDir, cfg.ContentCache, cfg.ContentSHA256, cfg.ContentPublicKey)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "validate: %v\n", err)
+		return 1
+	}
+	dir, err := source.SyncOnce(context.Background(), src)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "validate: %v\n", err)
+		return 1
+	}
+	data, ok := content.DataSource(dir)
+	if !ok {
+		fmt.Fprintf(os.Stderr, "validate: no content directory in %s\n", *dataDir)
+		return 1
+	}
+	issues, err := content.Validate(data)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "validate: %v\n", err)
+		return 1
+	}
+
+	r := lipgloss.NewRenderer(os.Stdout)
+	loc := r.NewStyle().Bold(true)
+	field := r.NewStyle().Faint(true)
+	severity := map[content.Severity]lipgloss.Style{
+		content.SeverityError:   r.NewStyle().Foreground(lipgloss.Color("1")).Bold(true),
+		content.SeverityWarning: r.NewStyle().Foreground(lipgloss.Color("3")).Bold(true),
+	}
+	errs, warnings := 0, 0
+	for _, is := range issues {
+		if is.Severity == content.SeverityError {
+			errs++
+		} else {
+			warnings++
+		}
+		pos := is.File
+		if is.Line > 0 {
+			pos = fmt.Sprintf("%s:%d:%d", is.File, is.Line, is.Column)
+		}
+		line := loc.Render(pos) + " " + severity[is.Severity].Render(is.Severity.String()+":")
+		if is.Path != "" {
+			line += " " + field.Render(is.Path+":")
+		}
+		fmt.Println(line + " " + is.Msg)
+	}
+
+	if len(issues) == 0 {
+		fmt.Printf("%s: content is valid\n", *dataDir)
+	} else {
+		fmt.Printf("\n%s: %s, %s\n", *dataDir, plural(errs, "error"), plural(warnings, "warning"))
+	}
+	if errs > 0 {
+		return 1
+	}
+	return 0
+}
+
+// plural returns n and noun, adding an s unless n is 1.
+func plural(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
+
+// runInit writes a starter data directory with every content file filled
+// in from the owner's details, asking for the ones not given as flags when
+// run in a terminal.
+func runInit(args []string) int {
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "init: %v\n", err)
+		return 1
+	}
+
+	fs := flag.NewFlagSet("init", flag.ContinueOnError)
+	dataDir := fs.String("data", cfg.DataDir, "data directory to create")
+	var p content.Profile
+	fs.StringVar(&p.Name, "name", "", "your full name")
+	fs.StringVar(&p.Email, "email", "", "contact email address")
+	fs.StringVar(&p.Title, "title", "", "job title")
+	fs.StringVar(&p.Location, "location", "", "city or region")
+	fs.StringVar(&p.Website, "website", "", "personal website URL")
+	fs.StringVar(&p.GitHub, "github", "", "GitHub username")
+	force := fs.Bool("force", false, "overwrite existing content files")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if source.Remote(*dataDir) {
+		fmt.Fprintf(os.Stderr, "init: %s is remote; give a local -data directory\n", *dataDir)
+		return 1
+	}
+
+	if term.IsTerminal(int(os.Stdin.Fd())) {
+		in := bufio.NewReader(os.Stdin)
+		set := make(map[string]bool)
+		fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+		for _, q := range []struct {
+			flag, label string
+			value       *string
+			required    bool
+		}{
+			{"name", "Name", &p.Name, true},
+			{"title", "Job title", &p.Title, false},
+			{"email", "Email", &p.Email, true},
+			{"location", "Location", &p.Location, false},
+			{"website", "Website", &p.Website, false},
+			{"github", "GitHub username", &p.GitHub, false},
+		} {
+			if set[q.flag] {
+				continue
+			}
+			for {
+				fmt.Printf("%s: ", q.label)
+				line, err := in.ReadString('\n')
+				*q.value = strings.TrimSpace(line)
+				if *q.value != "" || !q.required || err != nil {
+					break
+				}
+			}
+		}
+	}
+	if p.Name == "" || p.Email == "" {
+		fmt.Fprintln(os.Stderr, "init: -name and -email are required")
+		return 2
+	}
+
+	files, err := content.WriteStarter(*dataDir, p, *force)
+	if err != nil {
+		msg := err.Error()
+		if !*force && strings.HasSuffix(msg, "already exists") {
+			msg += "; use -force to replace it"
+		}
+		fmt.Fprintf(os.Stderr, "init: %s\n", msg)
+		return 1
+	}
+	for _, file := range files {
+		fmt.Printf("wrote %s\n", file)
+	}
+	fmt.Printf("\nReplace the placeholder text, then check it with: terminal-portfolio validate -data %s\n", *dataDir)
+	return 0
+}
+
+// eventSource is where the analytics commands read events from: the
+// SQLite store at DSN when set, otherwise the JSONL log at File.
+type eventSource struct {
+	File string
+	DSN  string
+}
+
+// events loads the events in [start, end) from src, where a zero time
+// leaves that side open. A JSONL log, including its rotated archives, is
+// read whole, so its events still need filtering.
+func (src eventSource) events(start, end time.Time) ([]analytics.Event, error) {
+	if src.DSN == "" {
+		return analytics.ReadLog(src.File)
+	}
+	st, err := analytics.OpenStore(src.DSN)
+	if err != nil {
+		return nil, err
+	}
+	defer st.Close()
+	return st.Events(context.Background(), start, end)
+}
+
+// readJourneys loads analytics events and reconstructs their journeys.
+func readJourneys(src eventSource) ([]analytics.Journey, error) {
+	events, err := src.events(time.Time{}, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+	return analytics.Journeys(events), nil
+}
+
+// runBraille prints a PNG or JPEG as Braille halftone art, for the home
+// portrait or any other text art.
+func runBraille(args []string) int {
+	opts := braille.DefaultOptions()
+	fs := flag.NewFlagSet("braille", flag.ContinueOnError)
+	fs.IntVar(&opts.Width, "width", opts.Width, "output width in characters")
+	fs.IntVar(&opts.Rows, "rows", 0, "crop to this many lines (0 keeps the aspect ratio)")
+	fs.BoolVar(&opts.Invert, "invert", false, "fill the dots for light pixels, for dark backgrounds")
+	dither := fs.String("dither", opts.Dither.String(), "dithering algorithm: atkinson or floyd-steinberg")
+	fs.Float64Var(&opts.Contrast, "contrast", opts.Contrast, "local contrast clip limit")
+	fs.Float64Var(&opts.Sharpen, "sharpen", opts.Sharpen, "unsharp mask strength (0 to skip)")
+	fs.Float64Var(&opts.Gamma, "gamma", opts.Gamma, "gamma correction: <1 brightens, >1 darkens")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "usage: braille [flags] image.png")
+		return 2
+	}
+	var ok bool
+	if opts.Dither, ok = braille.ParseDither(*dither); !ok {
+		fmt.Fprintf(os.Stderr, "braille: unknown dither %q\n", *dither)
+		return 2
+	}
+
+	art, err := braille.Load(fs.Arg(0), opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "braille: %v\n", err)
+		return 1
+	}
+	fmt.Println(art)
+	return 0
+}
@@ -8,21 +8,18 @@ import (
 	"syscall"
 	"time"
 
-	"github.com/charmbracelet/lipgloss"
-	"github.com/muesli/termenv"
-
+	"github.com/buntingszn/terminal-portfolio/tui/internal/analytics"
 	"github.com/buntingszn/terminal-portfolio/tui/internal/config"
-	"github.com/buntingszn/terminal-portfolio/tui/internal/content"
 	"github.com/buntingszn/terminal-portfolio/tui/internal/server"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/source"
 )
 
 func main() {
-	// Force true-color rendering on the global lipgloss default renderer.
-	// This server process runs headless (no TTY), so termenv auto-detects
-	// Ascii (no colors). All clients connect through ttyd/xterm.js or modern
-	// terminals that support full 24-bit color.
-	lipgloss.DefaultRenderer().SetColorProfile(termenv.TrueColor)
-	lipgloss.DefaultRenderer().SetHasDarkBackground(true)
+	// Subcommands (e.g. "report") run offline tooling and exit without
+	// starting the server.
+	if len(os.Args) > 1 {
+		os.Exit(runSubcommand(os.Args[1], os.Args[2:]))
+	}
 
 	// Load configuration from environment variables.
 	cfg, err := config.Load()
@@ -31,11 +28,9 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Set up structured logging.
-	level := slog.LevelInfo
-	if cfg.Debug {
-		level = slog.LevelDebug
-	}
+	// Set up structured logging. The level can change on reload.
+	level := new(slog.LevelVar)
+	level.Set(cfg.Level())
 	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: level}))
 	slog.SetDefault(logger)
 
@@ -46,13 +41,20 @@ func main() {
 		"max_sessions", cfg.MaxSessions,
 	)
 
-	// Load content from JSON data files.
-	c, err := content.LoadAll(cfg.DataDir)
+	// Fetch the data directory, if remote, and load content from its JSON
+	// data files.
+	loader, c, err := source.Open(context.Background(), cfg)
 	if err != nil {
 		logger.Error("failed to load content", "err", err)
 		os.Exit(1)
 	}
 
+	// Custom palette commands must be registered before sessions start.
+	if err := registerPlugins(); err != nil {
+		logger.Error("failed to register plugins", "err", err)
+		os.Exit(1)
+	}
+
 	// Create SSH server.
 	srv, err := server.New(cfg, c)
 	if err != nil {
@@ -60,6 +62,40 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Background jobs run until shutdown.
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+
+	// Keep remote content up to date.
+	go loader.Poll(jobsCtx, srv.SetContent)
+
+	// SIGHUP reloads the configuration and content; open sessions keep
+	// running.
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	go func() {
+		r := &reloader{srv: srv, level: level, content: loader, logger: logger, running: cfg}
+		for {
+			select {
+			case <-jobsCtx.Done():
+				return
+			case <-hup:
+				logger.Info("reload signal received")
+				r.reload(jobsCtx)
+			}
+		}
+	}()
+
+	// Send the owner periodic analytics summaries.
+	if cfg.Summary != "off" {
+		n, err := summaryNotifier(cfg)
+		if err != nil {
+			logger.Error("invalid summary destination", "err", err)
+			os.Exit(1)
+		}
+		go scheduleSummaries(jobsCtx, eventSource{cfg.AnalyticsFile, cfg.AnalyticsDSN}, analytics.Period(cfg.Summary), n, logger)
+	}
+
 	// Start server in a goroutine.
 	go func() {
 		if err := srv.Start(); err != nil {
@@ -68,12 +104,33 @@ func main() {
 		}
 	}()
 
-	// Wait for SIGINT or SIGTERM for graceful shutdown.
+	// Wait for SIGINT or SIGTERM for graceful shutdown. SIGUSR1 drains
+	// for a deploy: the port is freed at once for the server taking over,
+	// and open sessions get the drain timeout to finish.
 	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM, syscall.SIGUSR1)
 	sig := <-quit
 
 	logger.Info("shutdown signal received", "signal", sig.String())
+	if sig == syscall.SIGUSR1 {
+		srv.StopListening()
+	}
+	stopJobs()
+
+	// Give open sessions a countdown before closing them; a second signal
+	// cuts it short.
+	if cfg.DrainTimeout > 0 {
+		drainCtx, stopDrain := context.WithCancel(context.Background())
+		go func() {
+			select {
+			case <-quit:
+				stopDrain()
+			case <-drainCtx.Done():
+			}
+		}()
+		srv.Drain(drainCtx, cfg.DrainTimeout)
+		stopDrain()
+	}
 
 	// Graceful shutdown with 10-second timeout.
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -0,0 +1,22 @@
+package main
+
+// registerPlugins adds this deployment's custom palette commands, so
+// visitors can type them after ":" like the built-in ones. Add commands
+// here with plugins.Register; for example, to let visitors ping a webhook
+// with :coffee:
+//
+//	return plugins.Register(plugins.Webhook("coffee", "Buy me a coffee",
+//		"https://hooks.example.com/coffee", "Thanks for the coffee!"))
+//
+// Sections are added the same way with app.RegisterSection, which gives
+// them a navbar tab, a number key and a palette command:
+//
+//	_, err := app.RegisterSection(app.SectionDef{
+//		Name:    "talks",
+//		Aliases: []string{"tk"},
+//		New:     newTalksSection,
+//	})
+//	return err
+func registerPlugins() error {
+	return nil
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/buntingszn/terminal-portfolio/tui/internal/config"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/server"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/source"
+)
+
+// reloader reapplies the configuration and content when the server is
+// sent SIGHUP, without a restart.
+type reloader struct {
+	srv     *server.SSHServer
+	level   *slog.LevelVar
+	content *source.Loader
+	logger  *slog.Logger
+
+	// running is the configuration in effect.
+	running *config.Config
+}
+
+// reload loads the configuration again and applies what the server can
+// change while it runs. Changed settings that only apply on a restart are
+// logged and keep their old values. The content is re-read too, so a
+// local data directory edited in place is served without a restart. A
+// configuration that fails to load is logged and the old one kept.
+func (r *reloader) reload(ctx context.Context) {
+	next, err := config.Load()
+	if err != nil {
+		r.logger.Error("reloaded config rejected", "err", err)
+	} else {
+		applied := r.srv.Reload(next)
+		r.level.Set(applied.Level())
+		if changed := r.running.Diff(applied); len(changed) > 0 {
+			r.logger.Info("config reloaded", "changed", changed)
+		}
+		if restart := applied.Diff(next); len(restart) > 0 {
+			r.logger.Warn("config changes need a restart to apply", "settings", restart)
+		}
+		r.running = applied
+	}
+
+	// The content poller may be syncing too; Reload waits for it.
+	if err := r.content.Reload(ctx, r.srv.SetContent); err != nil {
+		r.logger.Error("failed to fetch content", "err", err)
+	}
+}
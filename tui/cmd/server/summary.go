@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/buntingszn/terminal-portfolio/tui/internal/analytics"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/config"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/notify"
+)
+
+// summaryNotifier returns the destinations configured for analytics
+// summaries.
+func summaryNotifier(cfg *config.Config) (notify.Notifier, error) {
+	var all notify.All
+	if cfg.SummaryWebhook != "" {
+		all = append(all, &notify.Webhook{URL: cfg.SummaryWebhook})
+	}
+	if cfg.SummaryEmail != "" {
+		email, err := notify.ParseEmail(cfg.SMTPURL, cfg.SummaryEmail)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, email)
+	}
+	return all, nil
+}
+
+// summarize reads the analytics events and summarizes the last complete
+// period before now.
+func summarize(src eventSource, period analytics.Period, now time.Time) (analytics.Summary, error) {
+	start, end := period.Last(now)
+	events, err := src.events(start, end)
+	if err != nil {
+		return analytics.Summary{}, err
+	}
+	return analytics.Summarize(events, start, end), nil
+}
+
+// sendSummary summarizes the last complete period before now and
+// delivers it.
+func sendSummary(ctx context.Context, src eventSource, period analytics.Period, now time.Time, n notify.Notifier) error {
+	s, err := summarize(src, period, now)
+	if err != nil {
+		return err
+	}
+	var body strings.Builder
+	if err := s.Write(&body); err != nil {
+		return err
+	}
+	return n.Notify(ctx, s.Title(), body.String())
+}
+
+// scheduleSummaries sends a summary each time a period ends, until ctx is
+// done. Failures are logged and the next period is tried as usual.
+func scheduleSummaries(ctx context.Context, src eventSource, period analytics.Period, n notify.Notifier, logger *slog.Logger) {
+	// Each summary covers the period ending at its boundary, so a timer
+	// firing a little early by the wall clock still reports the period
+	// that ended and is not sent twice.
+	for due := period.Next(time.Now()); ; due = period.Next(due) {
+		timer := time.NewTimer(time.Until(due))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		sctx, cancel := context.WithTimeout(ctx, time.Minute)
+		err := sendSummary(sctx, src, period, due, n)
+		cancel()
+		if err != nil {
+			logger.Error("analytics summary failed", "err", err)
+			continue
+		}
+		logger.Info("analytics summary sent", "period", string(period))
+	}
+}
@@ -0,0 +1,116 @@
+// Command webserver serves the TUI to browsers: an xterm.js page and the
+// WebSocket behind it, on TERMINAL_PORTFOLIO_WEB_ADDR. It reads the same
+// configuration as the SSH server and runs each browser session through
+// the same middleware, so it replaces a ttyd bridge in front of SSH.
+package main
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/buntingszn/terminal-portfolio/tui/internal/config"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/server"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/source"
+)
+
+func main() {
+	cfg, err := config.Load()
+	if err != nil {
+		slog.Error("failed to load config", "err", err)
+		os.Exit(1)
+	}
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: cfg.Level()}))
+	slog.SetDefault(logger)
+
+	// Browser sessions have no way to sign in, so the web terminal would
+	// open a private deployment to anyone.
+	if cfg.Auth != "open" {
+		logger.Error("the web terminal only serves open deployments", "auth", cfg.Auth)
+		os.Exit(1)
+	}
+
+	logger.Info("starting terminal-portfolio web terminal",
+		"web_addr", cfg.WebAddr,
+		"data_dir", cfg.DataDir,
+		"max_sessions", cfg.MaxSessions,
+	)
+
+	loader, c, err := source.Open(context.Background(), cfg)
+	if err != nil {
+		logger.Error("failed to load content", "err", err)
+		os.Exit(1)
+	}
+
+	if err := registerPlugins(); err != nil {
+		logger.Error("failed to register plugins", "err", err)
+		os.Exit(1)
+	}
+
+	// The server is only used for its sessions; it does not listen for
+	// SSH connections here.
+	srv, err := server.New(cfg, c)
+	if err != nil {
+		logger.Error("failed to create server", "err", err)
+		os.Exit(1)
+	}
+
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	go loader.Poll(jobsCtx, srv.SetContent)
+
+	httpSrv := &http.Server{
+		Addr:              cfg.WebAddr,
+		Handler:           srv.WebHandler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		logger.Info("web terminal listening", "addr", cfg.WebAddr)
+		if err := httpSrv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+			logger.Error("web server error", "err", err)
+			os.Exit(1)
+		}
+	}()
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	sig := <-quit
+
+	logger.Info("shutdown signal received", "signal", sig.String())
+	stopJobs()
+
+	// Give open sessions a countdown before closing them; a second signal
+	// cuts it short.
+	if cfg.DrainTimeout > 0 {
+		drainCtx, stopDrain := context.WithCancel(context.Background())
+		go func() {
+			select {
+			case <-quit:
+				stopDrain()
+			case <-drainCtx.Done():
+			}
+		}()
+		srv.Drain(drainCtx, cfg.DrainTimeout)
+		stopDrain()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Stop accepting pages first; the sessions' WebSockets are not the
+	// HTTP server's to close, so server Shutdown ends them.
+	if err := httpSrv.Shutdown(ctx); err != nil {
+		logger.Error("web shutdown error", "err", err)
+	}
+	if err := srv.Shutdown(ctx); err != nil {
+		logger.Error("shutdown error", "err", err)
+	}
+
+	logger.Info("server stopped")
+}
@@ -2,6 +2,7 @@ package analytics
 
 import (
 	"encoding/json"
+	"log/slog"
 	"os"
 	"sync"
 	"time"
@@ -24,18 +25,36 @@ type Event struct {
 	IP         string    `json:"ip,omitempty"`
 	Section    string    `json:"section,omitempty"`
 	DurationMs int64     `json:"duration_ms,omitempty"`
+	// Variants maps experiment ID to the variant the session was assigned.
+	Variants map[string]string `json:"variants,omitempty"`
 }
 
-// Logger writes analytics events as JSON Lines to a file.
+// Logger writes analytics events as JSON Lines to a file, optionally
+// rotating it into gzipped archives next to it.
 // A nil Logger is safe to use; all methods are no-ops.
 type Logger struct {
-	mu   sync.Mutex
-	file *os.File
+	mu       sync.Mutex
+	file     *os.File
+	path     string
+	rotation Rotation
+	size     int64
+	boundary time.Time // next time-based rotation; zero for none
+	retryAt  time.Time // no rotation before this, after a failure
+
+	// background tracks archive compression and pruning, so Close can
+	// wait for them.
+	background sync.WaitGroup
 }
 
 // NewLogger opens (or creates) the analytics file in append mode.
 // If path is empty, analytics are disabled and nil is returned.
 func NewLogger(path string) (*Logger, error) {
+	return NewRotatingLogger(path, Rotation{})
+}
+
+// NewRotatingLogger is NewLogger with a rotation policy. A time-based
+// rotation counts from when the logger opens the file.
+func NewRotatingLogger(path string, r Rotation) (*Logger, error) {
 	if path == "" {
 		return nil, nil
 	}
@@ -43,7 +62,14 @@ func NewLogger(path string) (*Logger, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &Logger{file: f}, nil
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	l := &Logger{file: f, path: path, rotation: r, size: info.Size()}
+	l.boundary = l.nextBoundary(time.Now())
+	return l, nil
 }
 
 // Log writes a single event as a JSON line. No-op on nil Logger.
@@ -58,7 +84,13 @@ func (l *Logger) Log(e Event) {
 	data = append(data, '\n')
 	l.mu.Lock()
 	defer l.mu.Unlock()
-	_, _ = l.file.Write(data)
+	if now := time.Now(); l.due(len(data), now) {
+		if err := l.rotate(now); err != nil {
+			slog.Warn("analytics log not rotated", "path", l.path, "err", err)
+		}
+	}
+	n, _ := l.file.Write(data)
+	l.size += int64(n)
 }
 
 // Close closes the underlying file. No-op on nil Logger.
@@ -68,5 +100,6 @@ func (l *Logger) Close() error {
 	}
 	l.mu.Lock()
 	defer l.mu.Unlock()
+	l.background.Wait()
 	return l.file.Close()
 }
@@ -0,0 +1,118 @@
+package analytics
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// VariantStats aggregates the sessions assigned to one experiment variant.
+type VariantStats struct {
+	Experiment string
+	Variant    string
+	Sessions   int
+	AvgSession time.Duration
+	// AvgDwell is the mean time per session spent on each section,
+	// counting sessions that never opened the section as zero.
+	AvgDwell map[string]time.Duration
+}
+
+// CompareVariants groups journeys by experiment variant, ordered by
+// experiment ID and then variant name. Journeys without assignments are
+// ignored.
+func CompareVariants(journeys []Journey) []VariantStats {
+	type key struct{ exp, variant string }
+	type acc struct {
+		sessions int
+		total    time.Duration
+		dwell    map[string]time.Duration
+	}
+	groups := map[key]*acc{}
+
+	for _, j := range journeys {
+		for exp, variant := range j.Variants {
+			k := key{exp, variant}
+			a, ok := groups[k]
+			if !ok {
+				a = &acc{dwell: map[string]time.Duration{}}
+				groups[k] = a
+			}
+			a.sessions++
+			a.total += j.Total
+			for _, s := range j.Steps {
+				a.dwell[s.Section] += s.Dwell
+			}
+		}
+	}
+
+	stats := make([]VariantStats, 0, len(groups))
+	for k, a := range groups {
+		avg := make(map[string]time.Duration, len(a.dwell))
+		for section, d := range a.dwell {
+			avg[section] = d / time.Duration(a.sessions)
+		}
+		stats = append(stats, VariantStats{
+			Experiment: k.exp,
+			Variant:    k.variant,
+			Sessions:   a.sessions,
+			AvgSession: a.total / time.Duration(a.sessions),
+			AvgDwell:   avg,
+		})
+	}
+	sort.Slice(stats, func(a, b int) bool {
+		if stats[a].Experiment != stats[b].Experiment {
+			return stats[a].Experiment < stats[b].Experiment
+		}
+		return stats[a].Variant < stats[b].Variant
+	})
+	return stats
+}
+
+// WriteVariantStats prints one row per variant with its average session
+// length, the difference from the experiment's first variant, and the
+// average dwell per section.
+func WriteVariantStats(w io.Writer, stats []VariantStats) error {
+	if len(stats) == 0 {
+		_, err := fmt.Fprintln(w, "No sessions tagged with experiment variants.")
+		return err
+	}
+
+	sectionSet := map[string]bool{}
+	for _, s := range stats {
+		for section := range s.AvgDwell {
+			sectionSet[section] = true
+		}
+	}
+	sections := make([]string, 0, len(sectionSet))
+	for section := range sectionSet {
+		sections = append(sections, section)
+	}
+	sort.Strings(sections)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "EXPERIMENT\tVARIANT\tSESSIONS\tAVG SESSION\tΔ\t%s\n", strings.ToUpper(strings.Join(sections, "\t")))
+
+	var baseline time.Duration
+	for i, s := range stats {
+		if i == 0 || stats[i-1].Experiment != s.Experiment {
+			baseline = s.AvgSession
+		}
+		delta := s.AvgSession - baseline
+		sign := "+"
+		if delta < 0 {
+			sign = "-"
+			delta = -delta
+		}
+		cols := make([]string, len(sections))
+		for j, section := range sections {
+			cols[j] = formatDwell(s.AvgDwell[section])
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%s\t%s%s\t%s\n",
+			s.Experiment, s.Variant, s.Sessions, formatDwell(s.AvgSession),
+			sign, formatDwell(delta), strings.Join(cols, "\t"))
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,51 @@
+package analytics
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCompareVariants(t *testing.T) {
+	journeys := []Journey{
+		{Total: 10 * time.Second, Variants: map[string]string{"bio": "a"},
+			Steps: []JourneyStep{{Section: "home", Dwell: 10 * time.Second}}},
+		{Total: 30 * time.Second, Variants: map[string]string{"bio": "a"},
+			Steps: []JourneyStep{{Section: "home", Dwell: 20 * time.Second}, {Section: "cv", Dwell: 10 * time.Second}}},
+		{Total: time.Minute, Variants: map[string]string{"bio": "b"},
+			Steps: []JourneyStep{{Section: "home", Dwell: time.Minute}}},
+		{Total: time.Hour}, // untagged sessions are ignored
+	}
+
+	stats := CompareVariants(journeys)
+	if len(stats) != 2 {
+		t.Fatalf("got %d variant rows, want 2", len(stats))
+	}
+	a := stats[0]
+	if a.Variant != "a" || a.Sessions != 2 || a.AvgSession != 20*time.Second {
+		t.Errorf("variant a = %+v", a)
+	}
+	if a.AvgDwell["home"] != 15*time.Second || a.AvgDwell["cv"] != 5*time.Second {
+		t.Errorf("variant a dwell = %v", a.AvgDwell)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteVariantStats(&buf, stats); err != nil {
+		t.Fatalf("WriteVariantStats: %v", err)
+	}
+	if !strings.Contains(buf.String(), "+40s") {
+		t.Errorf("variant b should be reported 40s above variant a:\n%s", buf.String())
+	}
+}
+
+func TestJourneysCarryVariants(t *testing.T) {
+	events := []Event{
+		{SessionID: "s", Type: EventSessionStart, Variants: map[string]string{"bio": "b"}},
+		{SessionID: "s", Type: EventSectionView, Section: "home", DurationMs: 1000},
+	}
+	js := Journeys(events)
+	if len(js) != 1 || js[0].Variants["bio"] != "b" {
+		t.Errorf("journeys = %+v, want variant b carried from session_start", js)
+	}
+}
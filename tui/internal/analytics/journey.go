@@ -0,0 +1,184 @@
+package analytics
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ReadEvents parses a JSON Lines analytics stream. Blank lines are skipped;
+// a malformed line aborts with its line number so a truncated log is noticed.
+func ReadEvents(r io.Reader) ([]Event, error) {
+	var events []Event
+	sc := bufio.NewScanner(r)
+	line := 0
+	for sc.Scan() {
+		line++
+		b := sc.Bytes()
+		if len(strings.TrimSpace(string(b))) == 0 {
+			continue
+		}
+		var e Event
+		if err := json.Unmarshal(b, &e); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		events = append(events, e)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// JourneyStep is one section visit within a session.
+type JourneyStep struct {
+	Section string
+	Dwell   time.Duration
+}
+
+// Journey is a single visitor session reconstructed from analytics events.
+// It carries no IP or session ID; Visitor is an ordinal assigned by start
+// time so journeys can be discussed without identifying anyone.
+type Journey struct {
+	Visitor int
+	Start   time.Time
+	Steps   []JourneyStep
+	Total   time.Duration
+	Ended   bool // a session_end event was seen
+
+	// Variants are the session's A/B experiment assignments, if any.
+	Variants map[string]string
+}
+
+// Journeys groups events by session and returns one Journey per session,
+// ordered by start time. Section views are emitted when the visitor leaves
+// a section, so each view's duration is its dwell time.
+func Journeys(events []Event) []Journey {
+	bySession := map[string]*Journey{}
+	var order []string
+
+	for _, e := range events {
+		j, ok := bySession[e.SessionID]
+		if !ok {
+			j = &Journey{Start: e.Timestamp}
+			bySession[e.SessionID] = j
+			order = append(order, e.SessionID)
+		}
+		if e.Timestamp.Before(j.Start) {
+			j.Start = e.Timestamp
+		}
+		if j.Variants == nil && len(e.Variants) > 0 {
+			j.Variants = e.Variants
+		}
+
+		switch e.Type {
+		case EventSectionView:
+			j.Steps = append(j.Steps, JourneyStep{
+				Section: e.Section,
+				Dwell:   time.Duration(e.DurationMs) * time.Millisecond,
+			})
+		case EventSessionEnd:
+			j.Ended = true
+			j.Total = time.Duration(e.DurationMs) * time.Millisecond
+		}
+	}
+
+	journeys := make([]Journey, 0, len(order))
+	for _, sid := range order {
+		j := bySession[sid]
+		if !j.Ended {
+			for _, s := range j.Steps {
+				j.Total += s.Dwell
+			}
+		}
+		journeys = append(journeys, *j)
+	}
+	sort.SliceStable(journeys, func(a, b int) bool {
+		return journeys[a].Start.Before(journeys[b].Start)
+	})
+	for i := range journeys {
+		journeys[i].Visitor = i + 1
+	}
+	return journeys
+}
+
+// Path renders the journey as "home 12s → work 45s → cv 1m2s".
+func (j Journey) Path() string {
+	if len(j.Steps) == 0 {
+		return "(no sections viewed)"
+	}
+	parts := make([]string, len(j.Steps))
+	for i, s := range j.Steps {
+		parts[i] = s.Section + " " + formatDwell(s.Dwell)
+	}
+	return strings.Join(parts, " → ")
+}
+
+// Timeline renders the journey as a bar width cells wide, where each
+// section fills a share proportional to its dwell time using the section's
+// initial letter. Every visited section gets at least one cell.
+func (j Journey) Timeline(width int) string {
+	if width <= 0 || len(j.Steps) == 0 {
+		return ""
+	}
+	var total time.Duration
+	for _, s := range j.Steps {
+		total += s.Dwell
+	}
+
+	cells := make([]int, len(j.Steps))
+	used := 0
+	for i, s := range j.Steps {
+		n := 1
+		if total > 0 {
+			n = max(1, int(int64(width)*int64(s.Dwell)/int64(total)))
+		}
+		cells[i] = n
+		used += n
+	}
+	// Hand leftover or excess cells to the longest stop so the bar is
+	// exactly width cells when possible.
+	longest := 0
+	for i := range cells {
+		if cells[i] > cells[longest] {
+			longest = i
+		}
+	}
+	cells[longest] = max(1, cells[longest]+width-used)
+
+	var b strings.Builder
+	for i, s := range j.Steps {
+		glyph := "?"
+		if s.Section != "" {
+			glyph = s.Section[:1]
+		}
+		b.WriteString(strings.Repeat(glyph, cells[i]))
+	}
+	return b.String()
+}
+
+// WriteJourneys prints each journey as a header line, its path, and a
+// timeline bar of the given width.
+func WriteJourneys(w io.Writer, journeys []Journey, width int) error {
+	for _, j := range journeys {
+		status := ""
+		if !j.Ended {
+			status = " (no session_end)"
+		}
+		if _, err := fmt.Fprintf(w, "visitor %d  %s  %s%s\n  %s\n  %s\n\n",
+			j.Visitor, j.Start.Format("2006-01-02 15:04"), formatDwell(j.Total), status,
+			j.Path(), j.Timeline(width)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// formatDwell renders a duration at second precision, e.g. "1m2s".
+func formatDwell(d time.Duration) string {
+	return d.Round(time.Second).String()
+}
@@ -0,0 +1,100 @@
+package analytics
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func journeyEvents() []Event {
+	t0 := time.Date(2026, 10, 1, 10, 0, 0, 0, time.UTC)
+	return []Event{
+		{Timestamp: t0.Add(5 * time.Minute), SessionID: "late", Type: EventSessionStart, IP: "5.6.7.8"},
+		{Timestamp: t0.Add(6 * time.Minute), SessionID: "late", Type: EventSectionView, Section: "home", DurationMs: 60000},
+		{Timestamp: t0, SessionID: "early", Type: EventSessionStart, IP: "1.2.3.4"},
+		{Timestamp: t0.Add(10 * time.Second), SessionID: "early", Type: EventSectionView, Section: "home", DurationMs: 10000},
+		{Timestamp: t0.Add(40 * time.Second), SessionID: "early", Type: EventSectionView, Section: "cv", DurationMs: 30000},
+		{Timestamp: t0.Add(40 * time.Second), SessionID: "early", Type: EventSessionEnd, DurationMs: 41000},
+	}
+}
+
+func TestReadEvents(t *testing.T) {
+	in := `{"ts":"2026-10-01T10:00:00Z","sid":"a","type":"session_start"}
+
+{"ts":"2026-10-01T10:00:05Z","sid":"a","type":"section_view","section":"home","duration_ms":5000}
+`
+	events, err := ReadEvents(strings.NewReader(in))
+	if err != nil {
+		t.Fatalf("ReadEvents: %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("got %d events, want 2", len(events))
+	}
+	if events[1].Section != "home" || events[1].DurationMs != 5000 {
+		t.Errorf("second event = %+v", events[1])
+	}
+
+	if _, err := ReadEvents(strings.NewReader("{\"sid\":\"a\"}\n{broken")); err == nil {
+		t.Error("expected error for malformed line")
+	} else if !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("error should name the line, got %v", err)
+	}
+}
+
+func TestJourneys(t *testing.T) {
+	js := Journeys(journeyEvents())
+	if len(js) != 2 {
+		t.Fatalf("got %d journeys, want 2", len(js))
+	}
+
+	first := js[0]
+	if first.Visitor != 1 || !first.Ended {
+		t.Errorf("first journey = %+v, want visitor 1 with session_end", first)
+	}
+	if first.Total != 41*time.Second {
+		t.Errorf("Total = %v, want 41s from session_end", first.Total)
+	}
+	if got := first.Path(); got != "home 10s → cv 30s" {
+		t.Errorf("Path = %q", got)
+	}
+
+	// A session without session_end sums its dwell times.
+	if js[1].Ended || js[1].Total != time.Minute {
+		t.Errorf("open journey = %+v, want Total 1m and Ended false", js[1])
+	}
+}
+
+func TestJourneyTimeline(t *testing.T) {
+	j := Journey{Steps: []JourneyStep{
+		{Section: "home", Dwell: 10 * time.Second},
+		{Section: "cv", Dwell: 30 * time.Second},
+	}}
+	if got := j.Timeline(8); got != "hhcccccc" {
+		t.Errorf("Timeline(8) = %q, want %q", got, "hhcccccc")
+	}
+
+	// Very short visits still get a cell.
+	j.Steps = append(j.Steps, JourneyStep{Section: "links", Dwell: 0})
+	if got := j.Timeline(8); !strings.HasSuffix(got, "l") || len(got) != 8 {
+		t.Errorf("Timeline(8) = %q, want 8 cells ending in a links cell", got)
+	}
+
+	if got := (Journey{}).Timeline(8); got != "" {
+		t.Errorf("empty journey timeline = %q, want empty", got)
+	}
+}
+
+func TestWriteJourneysOmitsIPs(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteJourneys(&buf, Journeys(journeyEvents()), 20); err != nil {
+		t.Fatalf("WriteJourneys: %v", err)
+	}
+	out := buf.String()
+	if strings.Contains(out, "1.2.3.4") || strings.Contains(out, "early") {
+		t.Errorf("journeys output should be anonymized:\n%s", out)
+	}
+	if !strings.Contains(out, "visitor 2") || !strings.Contains(out, "no session_end") {
+		t.Errorf("unexpected journeys output:\n%s", out)
+	}
+}
@@ -0,0 +1,266 @@
+package analytics
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Rotation controls when a Logger starts a new file. The zero value never
+// rotates.
+type Rotation struct {
+	// MaxSize rotates the file before a write would take it past this many
+	// bytes. Zero means no size limit.
+	MaxSize int64
+	// Every rotates the file when a day or week ends. Empty means no
+	// time-based rotation.
+	Every Period
+	// Retention deletes archives older than this after each rotation.
+	// Zero keeps archives forever.
+	Retention time.Duration
+}
+
+// archiveStamp formats the rotation time in archive names. It sorts in
+// time order and has no characters that need quoting in a shell.
+const archiveStamp = "20060102T150405.000"
+
+// archiveName returns the name the log at path is archived under when
+// rotated at t, before compression: analytics.jsonl becomes
+// analytics-20261014T000000.000.jsonl.
+func archiveName(path string, t time.Time) string {
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + "-" + t.UTC().Format(archiveStamp) + ext
+}
+
+// Archive is one rotated analytics log.
+type Archive struct {
+	Path    string
+	Rotated time.Time
+	// Compressed is false while the archive waits to be gzipped.
+	Compressed bool
+}
+
+// Archives lists the archives of the log at path, oldest first. An archive
+// seen both gzipped and not is mid-compression and listed once, by its
+// uncompressed file.
+func Archives(path string) ([]Archive, error) {
+	ext := filepath.Ext(path)
+	prefix := filepath.Base(strings.TrimSuffix(path, ext)) + "-"
+	entries, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		return nil, err
+	}
+	byStamp := map[string]Archive{}
+	for _, e := range entries {
+		name, ok := strings.CutPrefix(e.Name(), prefix)
+		if !ok || e.IsDir() {
+			continue
+		}
+		compressed := strings.HasSuffix(name, ext+".gz")
+		stamp, ok := strings.CutSuffix(strings.TrimSuffix(name, ".gz"), ext)
+		if !ok {
+			continue
+		}
+		t, err := time.Parse(archiveStamp, stamp)
+		if err != nil {
+			continue
+		}
+		if prev, seen := byStamp[stamp]; seen && !prev.Compressed {
+			continue
+		}
+		byStamp[stamp] = Archive{Path: filepath.Join(filepath.Dir(path), e.Name()), Rotated: t, Compressed: compressed}
+	}
+	archives := make([]Archive, 0, len(byStamp))
+	for _, a := range byStamp {
+		archives = append(archives, a)
+	}
+	sort.Slice(archives, func(i, j int) bool { return archives[i].Rotated.Before(archives[j].Rotated) })
+	return archives, nil
+}
+
+// ReadLog reads every event of the log at path: its archives oldest first,
+// then the current file.
+func ReadLog(path string) ([]Event, error) {
+	archives, err := Archives(path)
+	if err != nil {
+		return nil, err
+	}
+	var events []Event
+	for _, a := range archives {
+		e, err := readFile(a.Path, a.Compressed)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, e...)
+	}
+	e, err := readFile(path, false)
+	if err != nil {
+		return nil, err
+	}
+	return append(events, e...), nil
+}
+
+// readFile reads the events in one log file, gunzipping it if compressed.
+func readFile(path string, compressed bool) ([]Event, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var r io.Reader = f
+	if compressed {
+		zr, err := gzip.NewReader(f)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		defer zr.Close()
+		r = zr
+	}
+	events, err := ReadEvents(r)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return events, nil
+}
+
+// due reports whether writing n more bytes at now should rotate first.
+func (l *Logger) due(n int, now time.Time) bool {
+	if l.size == 0 {
+		// Never rotate an empty file; a single oversized event still
+		// gets written.
+		return false
+	}
+	if now.Before(l.retryAt) {
+		return false
+	}
+	if l.rotation.MaxSize > 0 && l.size+int64(n) > l.rotation.MaxSize {
+		return true
+	}
+	return !l.boundary.IsZero() && !now.Before(l.boundary)
+}
+
+// rotateRetry is how long a Logger keeps writing to its current file after
+// a failed rotation before trying again.
+const rotateRetry = time.Minute
+
+// rotate archives the current file and opens a fresh one. Compression and
+// pruning continue in the background. If rotation fails, events keep going
+// to the current file. The caller holds l.mu.
+func (l *Logger) rotate(now time.Time) error {
+	archive := archiveName(l.path, now)
+	for stamp := now; archiveExists(archive); {
+		// Rotations within the same millisecond must not overwrite
+		// each other's archives.
+		stamp = stamp.Add(time.Millisecond)
+		archive = archiveName(l.path, stamp)
+	}
+	renamed := true
+	if err := os.Rename(l.path, archive); errors.Is(err, os.ErrNotExist) {
+		// An earlier rotation moved the file but could not open a new
+		// one; the archive it writes to stays uncompressed.
+		renamed = false
+	} else if err != nil {
+		l.retryAt = now.Add(rotateRetry)
+		return err
+	}
+	f, err := os.OpenFile(l.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		// Keep writing to the moved file rather than lose events.
+		l.retryAt = now.Add(rotateRetry)
+		return err
+	}
+	_ = l.file.Close()
+	l.file, l.size = f, 0
+	l.boundary = l.nextBoundary(now)
+
+	l.background.Add(1)
+	go func() {
+		defer l.background.Done()
+		if renamed {
+			if err := compress(archive); err != nil {
+				slog.Warn("analytics archive not compressed", "path", archive, "err", err)
+			}
+		}
+		if l.rotation.Retention > 0 {
+			if err := prune(l.path, now.Add(-l.rotation.Retention)); err != nil {
+				slog.Warn("analytics archives not pruned", "err", err)
+			}
+		}
+	}()
+	return nil
+}
+
+// archiveExists reports whether an archive named archive exists, gzipped
+// or not.
+func archiveExists(archive string) bool {
+	for _, name := range []string{archive, archive + ".gz"} {
+		if _, err := os.Stat(name); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// nextBoundary returns when the file opened at now is next rotated by
+// time, or zero without time-based rotation.
+func (l *Logger) nextBoundary(now time.Time) time.Time {
+	if l.rotation.Every == "" {
+		return time.Time{}
+	}
+	return l.rotation.Every.Next(now)
+}
+
+// compress gzips path into path.gz and removes path. The .gz file only
+// appears once complete, so readers never see a partial archive.
+func compress(path string) error {
+	in, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	tmp := path + ".gz.tmp"
+	out, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	zw := gzip.NewWriter(out)
+	_, err = io.Copy(zw, in)
+	if cerr := zw.Close(); err == nil {
+		err = cerr
+	}
+	if cerr := out.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(tmp, path+".gz")
+	}
+	if err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	return os.Remove(path)
+}
+
+// prune deletes the archives of the log at path rotated before cutoff.
+func prune(path string, cutoff time.Time) error {
+	archives, err := Archives(path)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, a := range archives {
+		if a.Rotated.Before(cutoff) {
+			if err := os.Remove(a.Path); err != nil && !errors.Is(err, os.ErrNotExist) {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
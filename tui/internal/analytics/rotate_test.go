@@ -0,0 +1,139 @@
+package analytics
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoggerRotatesBySize(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "analytics.jsonl")
+	l, err := NewRotatingLogger(path, Rotation{MaxSize: 200})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range 10 {
+		l.Log(Event{Timestamp: time.Unix(int64(i), 0), SessionID: "s", Type: EventSessionStart})
+	}
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	archives, err := Archives(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(archives) < 2 {
+		t.Fatalf("got %d archives, want several", len(archives))
+	}
+	for _, a := range archives {
+		if !a.Compressed || !strings.HasSuffix(a.Path, ".jsonl.gz") {
+			t.Errorf("archive %s should be gzipped", a.Path)
+		}
+	}
+	if info, err := os.Stat(path); err != nil || info.Size() > 200 {
+		t.Errorf("current file = %v, %v; want at most 200 bytes", info, err)
+	}
+
+	events, err := ReadLog(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 10 {
+		t.Fatalf("ReadLog returned %d events, want 10", len(events))
+	}
+	for i, e := range events {
+		if e.Timestamp.Unix() != int64(i) {
+			t.Errorf("event %d has timestamp %d; archives should read in order", i, e.Timestamp.Unix())
+		}
+	}
+}
+
+func TestLoggerRotatesByTime(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "analytics.jsonl")
+	l, err := NewRotatingLogger(path, Rotation{Every: Daily})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := Daily.Next(time.Now()); !l.boundary.Equal(want) {
+		t.Errorf("boundary = %s, want %s", l.boundary, want)
+	}
+	l.Log(Event{SessionID: "a", Type: EventSessionStart})
+	l.Log(Event{SessionID: "b", Type: EventSessionStart})
+	if archives, _ := Archives(path); len(archives) != 0 {
+		t.Fatalf("rotated before the day ended: %v", archives)
+	}
+
+	l.mu.Lock()
+	l.boundary = time.Now().Add(-time.Second)
+	l.mu.Unlock()
+	l.Log(Event{SessionID: "c", Type: EventSessionStart})
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if archives, _ := Archives(path); len(archives) != 1 {
+		t.Fatalf("got %d archives after the day ended, want 1", len(archives))
+	}
+	events, err := ReadLog(path)
+	if err != nil || len(events) != 3 || events[2].SessionID != "c" {
+		t.Errorf("ReadLog = %v, %v", events, err)
+	}
+}
+
+func TestLoggerPrunesOldArchives(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "analytics.jsonl")
+	old := archiveName(path, time.Now().AddDate(0, 0, -40)) + ".gz"
+	recent := archiveName(path, time.Now().AddDate(0, 0, -2)) + ".gz"
+	unrelated := filepath.Join(dir, "analytics-notes.jsonl")
+	for _, name := range []string{old, recent, unrelated} {
+		if err := os.WriteFile(name, nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	l, err := NewRotatingLogger(path, Rotation{MaxSize: 1, Retention: 30 * 24 * time.Hour})
+	if err != nil {
+		t.Fatal(err)
+	}
+	l.Log(Event{SessionID: "a", Type: EventSessionStart})
+	l.Log(Event{SessionID: "b", Type: EventSessionStart})
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := os.Stat(old); !os.IsNotExist(err) {
+		t.Error("an archive past retention should be deleted")
+	}
+	for _, keep := range []string{recent, unrelated} {
+		if _, err := os.Stat(keep); err != nil {
+			t.Errorf("%s should be kept: %v", filepath.Base(keep), err)
+		}
+	}
+}
+
+func TestArchivesPrefersUncompressed(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "analytics.jsonl")
+	name := archiveName(path, time.Now())
+	for _, p := range []string{name + ".gz", name, name + ".gz.tmp"} {
+		if err := os.WriteFile(p, nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	archives, err := Archives(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(archives) != 1 || archives[0].Path != name || archives[0].Compressed {
+		t.Errorf("Archives = %+v, want only the uncompressed %s", archives, name)
+	}
+}
+
+func TestReadLogMissing(t *testing.T) {
+	if _, err := ReadLog(filepath.Join(t.TempDir(), "analytics.jsonl")); !os.IsNotExist(err) {
+		t.Errorf("ReadLog of a missing log: err = %v, want not exist", err)
+	}
+}
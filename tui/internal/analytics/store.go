@@ -0,0 +1,234 @@
+package analytics
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"time"
+
+	// Registers the cgo-free "sqlite" driver Stores open.
+	_ "modernc.org/sqlite"
+)
+
+// Sink receives analytics events. *Logger writes them to a JSONL file and
+// *Store to a SQLite database.
+type Sink interface {
+	Log(e Event)
+	Close() error
+}
+
+// Driver is the database/sql driver name a Store opens its DSN with,
+// registered by modernc.org/sqlite.
+const Driver = "sqlite"
+
+// storeTimeout bounds each write, so a locked database cannot stall a
+// session for long.
+const storeTimeout = 5 * time.Second
+
+// storeSchema creates the events table. Timestamps are Unix nanoseconds
+// and variants a JSON object, so no driver-specific types are needed.
+const storeSchema = `
+CREATE TABLE IF NOT EXISTS events (
+	ts          INTEGER NOT NULL,
+	sid         TEXT    NOT NULL,
+	type        TEXT    NOT NULL,
+	ip          TEXT    NOT NULL DEFAULT '',
+	section     TEXT    NOT NULL DEFAULT '',
+	duration_ms INTEGER NOT NULL DEFAULT 0,
+	variants    TEXT    NOT NULL DEFAULT ''
+);
+CREATE INDEX IF NOT EXISTS events_ts ON events (ts);
+CREATE INDEX IF NOT EXISTS events_sid ON events (sid);
+`
+
+// Store keeps analytics events in a SQLite database, where they can be
+// queried by time range without reading the whole log.
+// A nil Store is safe to use as a Sink; Log and Close are no-ops.
+type Store struct {
+	db     *sql.DB
+	insert *sql.Stmt
+}
+
+// OpenStore opens the database at dsn and creates its table if needed.
+// If dsn is empty, analytics are disabled and nil is returned.
+func OpenStore(dsn string) (*Store, error) {
+	if dsn == "" {
+		return nil, nil
+	}
+	db, err := sql.Open(Driver, dsn)
+	if err != nil {
+		return nil, err
+	}
+	// SQLite allows one writer at a time; a single connection queues
+	// writes here rather than failing them with "database is locked".
+	db.SetMaxOpenConns(1)
+	if _, err := db.Exec(storeSchema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("create analytics tables: %w", err)
+	}
+	insert, err := db.Prepare(`INSERT INTO events (ts, sid, type, ip, section, duration_ms, variants) VALUES (?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &Store{db: db, insert: insert}, nil
+}
+
+// Log inserts a single event. Failures are logged and the event dropped,
+// as with a Logger. No-op on nil Store.
+func (s *Store) Log(e Event) {
+	if s == nil {
+		return
+	}
+	variants := ""
+	if len(e.Variants) > 0 {
+		data, err := json.Marshal(e.Variants)
+		if err != nil {
+			return
+		}
+		variants = string(data)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+	defer cancel()
+	_, err := s.insert.ExecContext(ctx, e.Timestamp.UnixNano(), e.SessionID, string(e.Type),
+		e.IP, e.Section, e.DurationMs, variants)
+	if err != nil {
+		slog.Warn("analytics event not stored", "type", e.Type, "err", err)
+	}
+}
+
+// Close closes the database. No-op on nil Store.
+func (s *Store) Close() error {
+	if s == nil {
+		return nil
+	}
+	s.insert.Close()
+	return s.db.Close()
+}
+
+// Events returns the events timestamped in [start, end) in the order they
+// were logged. A zero start or end leaves that side of the range open.
+func (s *Store) Events(ctx context.Context, start, end time.Time) ([]Event, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT ts, sid, type, ip, section, duration_ms, variants FROM events
+		 WHERE ts >= ? AND ts < ? ORDER BY ts, rowid`,
+		lowerBound(start), upperBound(end))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var events []Event
+	for rows.Next() {
+		var (
+			e        Event
+			ts       int64
+			typ      string
+			variants string
+		)
+		if err := rows.Scan(&ts, &e.SessionID, &typ, &e.IP, &e.Section, &e.DurationMs, &variants); err != nil {
+			return nil, err
+		}
+		e.Timestamp = time.Unix(0, ts)
+		e.Type = EventType(typ)
+		if variants != "" {
+			if err := json.Unmarshal([]byte(variants), &e.Variants); err != nil {
+				return nil, fmt.Errorf("event of session %s: variants: %w", e.SessionID, err)
+			}
+		}
+		events = append(events, e)
+	}
+	return events, rows.Err()
+}
+
+// Session is one visit recorded in a Store.
+type Session struct {
+	ID    string
+	IP    string
+	Start time.Time
+	// End is zero while the session is open, or if it ended without a
+	// session_end event.
+	End      time.Time
+	Duration time.Duration
+}
+
+// SessionsBetween returns the sessions that started in [start, end),
+// oldest first, as Events bounds them.
+func (s *Store) SessionsBetween(ctx context.Context, start, end time.Time) ([]Session, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT st.sid, st.ip, st.ts, en.ts, en.duration_ms
+		 FROM events st
+		 LEFT JOIN events en ON en.sid = st.sid AND en.type = ?
+		 WHERE st.type = ? AND st.ts >= ? AND st.ts < ?
+		 ORDER BY st.ts, st.rowid`,
+		string(EventSessionEnd), string(EventSessionStart), lowerBound(start), upperBound(end))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var sessions []Session
+	for rows.Next() {
+		var (
+			sess     Session
+			started  int64
+			ended    sql.NullInt64
+			duration sql.NullInt64
+		)
+		if err := rows.Scan(&sess.ID, &sess.IP, &started, &ended, &duration); err != nil {
+			return nil, err
+		}
+		sess.Start = time.Unix(0, started)
+		if ended.Valid {
+			sess.End = time.Unix(0, ended.Int64)
+			sess.Duration = time.Duration(duration.Int64) * time.Millisecond
+		}
+		sessions = append(sessions, sess)
+	}
+	return sessions, rows.Err()
+}
+
+// SectionViewCounts returns how many times each section was viewed in
+// [start, end), as Events bounds them.
+func (s *Store) SectionViewCounts(ctx context.Context, start, end time.Time) (map[string]int, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT section, COUNT(*) FROM events
+		 WHERE type = ? AND ts >= ? AND ts < ?
+		 GROUP BY section`,
+		string(EventSectionView), lowerBound(start), upperBound(end))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	counts := map[string]int{}
+	for rows.Next() {
+		var (
+			section string
+			n       int
+		)
+		if err := rows.Scan(&section, &n); err != nil {
+			return nil, err
+		}
+		counts[section] = n
+	}
+	return counts, rows.Err()
+}
+
+// lowerBound returns the start of a range as stored in the events table;
+// the zero time comes before every event.
+func lowerBound(t time.Time) int64 {
+	if t.IsZero() {
+		return math.MinInt64
+	}
+	return t.UnixNano()
+}
+
+// upperBound returns the end of a range as stored in the events table;
+// the zero time comes after every event.
+func upperBound(t time.Time) int64 {
+	if t.IsZero() {
+		return math.MaxInt64
+	}
+	return t.UnixNano()
+}
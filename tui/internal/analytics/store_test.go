@@ -0,0 +1,84 @@
+package analytics
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+var (
+	_ Sink = (*Logger)(nil)
+	_ Sink = (*Store)(nil)
+)
+
+func TestOpenStoreDisabled(t *testing.T) {
+	st, err := OpenStore("")
+	if st != nil || err != nil {
+		t.Fatalf("OpenStore(\"\") = %v, %v; want nil, nil", st, err)
+	}
+	// A nil Store is a no-op sink.
+	st.Log(Event{SessionID: "a", Type: EventSessionStart})
+	if err := st.Close(); err != nil {
+		t.Errorf("Close on nil Store: %v", err)
+	}
+}
+
+// openTestStore opens a Store in a temporary directory.
+func openTestStore(t *testing.T) *Store {
+	t.Helper()
+	st, err := OpenStore("file:" + filepath.Join(t.TempDir(), "analytics.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { st.Close() })
+	return st
+}
+
+func TestStoreQueries(t *testing.T) {
+	st := openTestStore(t)
+	day := time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC)
+	at := func(h int) time.Time { return day.Add(time.Duration(h) * time.Hour) }
+	for _, e := range []Event{
+		{Timestamp: at(-2), SessionID: "old", Type: EventSessionStart, IP: "1.1.1.1"},
+		{Timestamp: at(1), SessionID: "a", Type: EventSessionStart, IP: "2.2.2.2", Variants: map[string]string{"hero": "b"}},
+		{Timestamp: at(2), SessionID: "a", Type: EventSectionView, Section: "work", DurationMs: 4000},
+		{Timestamp: at(2), SessionID: "b", Type: EventSessionStart, IP: "3.3.3.3"},
+		{Timestamp: at(3), SessionID: "a", Type: EventSectionView, Section: "work", DurationMs: 1000},
+		{Timestamp: at(3), SessionID: "a", Type: EventSectionView, Section: "cv", DurationMs: 2000},
+		{Timestamp: at(4), SessionID: "a", Type: EventSessionEnd, DurationMs: 3 * 3600 * 1000},
+	} {
+		st.Log(e)
+	}
+	ctx := context.Background()
+
+	events, err := st.Events(ctx, time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 7 || events[1].Variants["hero"] != "b" || !events[6].Timestamp.Equal(at(4)) {
+		t.Errorf("Events = %+v", events)
+	}
+
+	sessions, err := st.SessionsBetween(ctx, day, day.AddDate(0, 0, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sessions) != 2 {
+		t.Fatalf("SessionsBetween returned %d sessions, want 2: %+v", len(sessions), sessions)
+	}
+	if a := sessions[0]; a.ID != "a" || a.IP != "2.2.2.2" || !a.End.Equal(at(4)) || a.Duration != 3*time.Hour {
+		t.Errorf("session a = %+v", a)
+	}
+	if b := sessions[1]; b.ID != "b" || !b.End.IsZero() {
+		t.Errorf("open session b = %+v, want a zero End", b)
+	}
+
+	counts, err := st.SectionViewCounts(ctx, day, day.AddDate(0, 0, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(counts) != 2 || counts["work"] != 2 || counts["cv"] != 1 {
+		t.Errorf("SectionViewCounts = %v", counts)
+	}
+}
@@ -0,0 +1,140 @@
+package analytics
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+	"time"
+)
+
+// Period is the span covered by a scheduled summary.
+type Period string
+
+const (
+	Daily  Period = "daily"
+	Weekly Period = "weekly"
+)
+
+// Last returns the most recent complete period before now: yesterday for
+// Daily, and the previous Monday-to-Monday week for Weekly, in now's
+// location.
+func (p Period) Last(now time.Time) (start, end time.Time) {
+	end = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	if p == Weekly {
+		end = end.AddDate(0, 0, -((int(end.Weekday()) + 6) % 7))
+		return end.AddDate(0, 0, -7), end
+	}
+	return end.AddDate(0, 0, -1), end
+}
+
+// Next returns when the period containing now ends, which is when its
+// summary can be sent.
+func (p Period) Next(now time.Time) time.Time {
+	_, end := p.Last(now)
+	if p == Weekly {
+		return end.AddDate(0, 0, 7)
+	}
+	return end.AddDate(0, 0, 1)
+}
+
+// SectionStats counts the views of one section over a summary period.
+type SectionStats struct {
+	Section string
+	Views   int
+	Dwell   time.Duration
+}
+
+// Summary aggregates visitor activity over a period.
+type Summary struct {
+	Start, End time.Time
+	// Visitors counts distinct client IPs; Sessions counts every session
+	// that started in the period.
+	Visitors   int
+	Sessions   int
+	AvgSession time.Duration
+	// Sections is ordered by views, then by total dwell.
+	Sections []SectionStats
+}
+
+// Summarize aggregates the events timestamped in [start, end).
+func Summarize(events []Event, start, end time.Time) Summary {
+	s := Summary{Start: start, End: end}
+	var inRange []Event
+	ips := map[string]bool{}
+	for _, e := range events {
+		if e.Timestamp.Before(start) || !e.Timestamp.Before(end) {
+			continue
+		}
+		inRange = append(inRange, e)
+		if e.Type == EventSessionStart {
+			s.Sessions++
+			if e.IP != "" {
+				ips[e.IP] = true
+			}
+		}
+	}
+	s.Visitors = len(ips)
+
+	journeys := Journeys(inRange)
+	bySection := map[string]*SectionStats{}
+	var total time.Duration
+	for _, j := range journeys {
+		total += j.Total
+		for _, step := range j.Steps {
+			st, ok := bySection[step.Section]
+			if !ok {
+				st = &SectionStats{Section: step.Section}
+				bySection[step.Section] = st
+			}
+			st.Views++
+			st.Dwell += step.Dwell
+		}
+	}
+	if len(journeys) > 0 {
+		s.AvgSession = total / time.Duration(len(journeys))
+	}
+	for _, st := range bySection {
+		s.Sections = append(s.Sections, *st)
+	}
+	sort.Slice(s.Sections, func(a, b int) bool {
+		x, y := s.Sections[a], s.Sections[b]
+		if x.Views != y.Views {
+			return x.Views > y.Views
+		}
+		if x.Dwell != y.Dwell {
+			return x.Dwell > y.Dwell
+		}
+		return x.Section < y.Section
+	})
+	return s
+}
+
+// Title names the summary's period, such as "Visitors 2026-10-12".
+func (s Summary) Title() string {
+	last := s.End.AddDate(0, 0, -1)
+	if last.Format(time.DateOnly) == s.Start.Format(time.DateOnly) {
+		return "Visitors " + s.Start.Format(time.DateOnly)
+	}
+	return fmt.Sprintf("Visitors %s to %s", s.Start.Format(time.DateOnly), last.Format(time.DateOnly))
+}
+
+// Write prints the summary totals followed by one row per section.
+func (s Summary) Write(w io.Writer) error {
+	if _, err := fmt.Fprintf(w, "%s\n\nVisitors: %d\nSessions: %d\nAverage session: %s\n",
+		s.Title(), s.Visitors, s.Sessions, formatDwell(s.AvgSession)); err != nil {
+		return err
+	}
+	if len(s.Sections) == 0 {
+		_, err := fmt.Fprintln(w, "\nNo sections viewed.")
+		return err
+	}
+
+	fmt.Fprintln(w)
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "SECTION\tVIEWS\tAVG DWELL")
+	for _, st := range s.Sections {
+		fmt.Fprintf(tw, "%s\t%d\t%s\n", st.Section, st.Views, formatDwell(st.Dwell/time.Duration(st.Views)))
+	}
+	return tw.Flush()
+}
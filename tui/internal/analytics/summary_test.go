@@ -0,0 +1,76 @@
+package analytics
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPeriod(t *testing.T) {
+	// Wednesday afternoon.
+	now := time.Date(2026, 10, 14, 15, 4, 0, 0, time.UTC)
+	day := func(d int) time.Time { return time.Date(2026, 10, d, 0, 0, 0, 0, time.UTC) }
+
+	if start, end := Daily.Last(now); !start.Equal(day(13)) || !end.Equal(day(14)) {
+		t.Errorf("Daily.Last = %v, %v", start, end)
+	}
+	if next := Daily.Next(now); !next.Equal(day(15)) {
+		t.Errorf("Daily.Next = %v", next)
+	}
+	if start, end := Weekly.Last(now); !start.Equal(day(5)) || !end.Equal(day(12)) {
+		t.Errorf("Weekly.Last = %v, %v", start, end)
+	}
+	if next := Weekly.Next(now); !next.Equal(day(19)) {
+		t.Errorf("Weekly.Next = %v", next)
+	}
+	// A boundary belongs to the period it starts.
+	if next := Daily.Next(day(15)); !next.Equal(day(16)) {
+		t.Errorf("Daily.Next(midnight) = %v", next)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	day := time.Date(2026, 10, 13, 0, 0, 0, 0, time.UTC)
+	at := func(h int) time.Time { return day.Add(time.Duration(h) * time.Hour) }
+	events := []Event{
+		{Timestamp: at(-1), SessionID: "old", Type: EventSessionStart, IP: "192.0.2.9"},
+		{Timestamp: at(1), SessionID: "a", Type: EventSessionStart, IP: "192.0.2.1"},
+		{Timestamp: at(1), SessionID: "a", Type: EventSectionView, Section: "home", DurationMs: 10000},
+		{Timestamp: at(1), SessionID: "a", Type: EventSectionView, Section: "cv", DurationMs: 30000},
+		{Timestamp: at(1), SessionID: "a", Type: EventSessionEnd, DurationMs: 40000},
+		{Timestamp: at(2), SessionID: "b", Type: EventSessionStart, IP: "192.0.2.1"},
+		{Timestamp: at(2), SessionID: "b", Type: EventSectionView, Section: "home", DurationMs: 20000},
+		{Timestamp: at(2), SessionID: "b", Type: EventSessionEnd, DurationMs: 20000},
+		{Timestamp: at(3), SessionID: "c", Type: EventSessionStart, IP: "192.0.2.2"},
+		{Timestamp: at(3), SessionID: "c", Type: EventSessionEnd, DurationMs: 0},
+		{Timestamp: at(25), SessionID: "new", Type: EventSessionStart, IP: "192.0.2.8"},
+	}
+
+	s := Summarize(events, day, day.AddDate(0, 0, 1))
+	if s.Visitors != 2 || s.Sessions != 3 {
+		t.Errorf("visitors, sessions = %d, %d; want 2, 3", s.Visitors, s.Sessions)
+	}
+	if s.AvgSession != 20*time.Second {
+		t.Errorf("AvgSession = %v, want 20s", s.AvgSession)
+	}
+	if len(s.Sections) != 2 || s.Sections[0] != (SectionStats{"home", 2, 30 * time.Second}) {
+		t.Errorf("Sections = %+v", s.Sections)
+	}
+
+	var buf bytes.Buffer
+	if err := s.Write(&buf); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{"Visitors 2026-10-13", "Visitors: 2", "Sessions: 3", "home", "15s"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("summary missing %q:\n%s", want, out)
+		}
+	}
+
+	weekly := Summarize(nil, day, day.AddDate(0, 0, 7))
+	if got := weekly.Title(); got != "Visitors 2026-10-13 to 2026-10-19" {
+		t.Errorf("weekly Title = %q", got)
+	}
+}
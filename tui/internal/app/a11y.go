@@ -0,0 +1,35 @@
+package app
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/buntingszn/terminal-portfolio/tui/internal/i18n"
+)
+
+// A11yUser is the SSH user name that starts a session in accessible text
+// mode, as in ssh a11y@host.
+const A11yUser = "a11y"
+
+// SetA11ySwitch enables :a11y, which ends the TUI for the screen-reader
+// friendly text mode. start is called with the tag of the session's
+// locale just before the program quits, so the server can carry on the
+// session in that mode, in the same language, once the terminal is
+// restored. Without it :a11y only says the mode is unavailable.
+func (m Model) SetA11ySwitch(start func(locale string)) Model {
+	m.a11ySwitch = start
+	return m
+}
+
+// startA11y runs :a11y.
+func (m Model) startA11y() (tea.Model, tea.Cmd) {
+	if m.a11ySwitch == nil {
+		return m.showNotice("Accessible mode is not available here")
+	}
+	tag := i18n.DefaultTag
+	if m.locale != nil {
+		tag = m.locale.Tag
+	}
+	m.a11ySwitch(tag)
+	m.logSessionEnd()
+	return m, m.quitCmd()
+}
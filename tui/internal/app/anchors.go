@@ -0,0 +1,100 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Anchor is a named heading in a section's content, such as the CV's
+// SKILLS, and the content line it is on.
+type Anchor struct {
+	Name string
+	Line int
+}
+
+// Anchorer is an optional interface for sections whose content has
+// headings to jump between with [ and ], or to by name with :goto.
+type Anchorer interface {
+	// Anchors returns the headings in the order they appear.
+	Anchors() []Anchor
+	// TopLine returns the content line at the top of the view.
+	TopLine() int
+	// ScrollToLine scrolls the content so that line is at the top of the
+	// view, or as near as it goes.
+	ScrollToLine(line int)
+}
+
+// jumpToAnchor scrolls the active section to its first heading below the
+// top of the view for ], or its last one above it for [. It reports false
+// when there is none that way, or the view cannot scroll any nearer to
+// it, which leaves the key to change section.
+func (m Model) jumpToAnchor(key string) bool {
+	an, ok := m.sections[m.activeSection].(Anchorer)
+	if !ok {
+		return false
+	}
+	top, anchors := an.TopLine(), an.Anchors()
+	var target *Anchor
+	switch key {
+	case "]":
+		for i := range anchors {
+			if anchors[i].Line > top {
+				target = &anchors[i]
+				break
+			}
+		}
+	case "[":
+		for i := len(anchors) - 1; i >= 0; i-- {
+			if anchors[i].Line < top {
+				target = &anchors[i]
+				break
+			}
+		}
+	}
+	if target == nil {
+		return false
+	}
+	an.ScrollToLine(target.Line)
+	return an.TopLine() != top
+}
+
+// findAnchor returns the heading named name, ignoring case, looking in the
+// active section first and then in the other visible ones in order.
+func (m Model) findAnchor(name string) (Section, Anchor, bool) {
+	order := []Section{m.activeSection}
+	for i := range m.hidden {
+		if s := Section(i); s != m.activeSection && !m.hidden[s] {
+			order = append(order, s)
+		}
+	}
+	for _, s := range order {
+		an, ok := m.sections[s].(Anchorer)
+		if !ok {
+			continue
+		}
+		for _, a := range an.Anchors() {
+			if strings.EqualFold(a.Name, name) {
+				return s, a, true
+			}
+		}
+	}
+	return 0, Anchor{}, false
+}
+
+// gotoAnchor scrolls to the heading the palette named. A heading in
+// another section is scrolled to once that section has been brought in
+// and focused, since focusing scrolls it back to the top.
+func (m Model) gotoAnchor(msg PaletteResultMsg) (tea.Model, tea.Cmd) {
+	s, a, ok := m.findAnchor(msg.Target)
+	if !ok {
+		return m.paletteFailed(msg, fmt.Sprintf("goto: no section or heading %q", msg.Target))
+	}
+	if s == m.activeSection {
+		m.sections[s].(Anchorer).ScrollToLine(a.Line)
+		return m, nil
+	}
+	m.pendingAnchor = &a
+	return m.navigateTo(s)
+}
@@ -0,0 +1,104 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// anchorSection shows 40 numbered lines with headings on lines 5, 15, and
+// 25, and scrolls back to the top when focused, as the CV does.
+type anchorSection struct {
+	placeholderSection
+	viewport Viewport
+}
+
+func (s *anchorSection) Update(msg tea.Msg) (SectionModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		s.viewport.SetSize(msg.Width, msg.Height)
+		var lines []string
+		for i := range 40 {
+			lines = append(lines, fmt.Sprintf("line %d", i))
+		}
+		s.viewport.SetContent(strings.Join(lines, "\n"))
+	case FocusMsg:
+		s.viewport.ScrollToTop()
+	}
+	return s, nil
+}
+
+func (s *anchorSection) View() string { return s.viewport.View() }
+
+func (s *anchorSection) Anchors() []Anchor {
+	return []Anchor{{"ONE", 5}, {"TWO", 15}, {"THREE", 25}}
+}
+
+func (s *anchorSection) TopLine() int { return s.viewport.YOffset() }
+
+func (s *anchorSection) ScrollToLine(line int) { s.viewport.SetYOffset(line) }
+
+func anchorModel(t *testing.T) (Model, *anchorSection) {
+	t.Helper()
+	sec := &anchorSection{placeholderSection: placeholderSection{name: "work", theme: DarkTheme()}}
+	m := New(testContent(), newPlaceholderSection("home", DarkTheme()), sec)
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	m = result.(Model)
+	result, _ = m.Update(IntroDoneMsg{})
+	return result.(Model), sec
+}
+
+func TestBracketsJumpBetweenAnchors(t *testing.T) {
+	m, sec := anchorModel(t)
+	m.activeSection = SectionWork
+	press := func(key string) {
+		t.Helper()
+		result, _ := m.Update(keyMsg(key))
+		m = result.(Model)
+	}
+
+	for _, want := range []int{5, 15} {
+		press("]")
+		if got := sec.viewport.YOffset(); got != want || m.activeSection != SectionWork {
+			t.Fatalf("] scrolled to line %d in section %d, want line %d in work", got, m.activeSection, want)
+		}
+	}
+	press("[")
+	if got := sec.viewport.YOffset(); got != 5 {
+		t.Errorf("[ scrolled to line %d, want 5", got)
+	}
+
+	// The last heading cannot reach the top of a view this tall, so once
+	// the view is at the bottom ] changes section.
+	sec.viewport.ScrollToBottom()
+	if m.jumpToAnchor("]") {
+		t.Error("] should not jump once the view can scroll no further")
+	}
+	sec.viewport.ScrollToTop()
+	if m.jumpToAnchor("[") {
+		t.Error("[ should not jump with no heading above the view")
+	}
+}
+
+func TestGotoAnchor(t *testing.T) {
+	m, sec := anchorModel(t)
+
+	// A heading in another section is scrolled to once it is focused.
+	result, _ := m.Update(PaletteResultMsg{Action: PaletteAnchor, Target: "two", Input: "goto two"})
+	m = drainTransition(t, result.(Model))
+	if m.activeSection != SectionWork || sec.viewport.YOffset() != 15 {
+		t.Errorf("after :goto two, section %d at line %d; want work at line 15", m.activeSection, sec.viewport.YOffset())
+	}
+
+	result, _ = m.Update(PaletteResultMsg{Action: PaletteAnchor, Target: "one", Input: "goto one"})
+	if m = result.(Model); sec.viewport.YOffset() != 5 {
+		t.Errorf("after :goto one, line %d; want 5", sec.viewport.YOffset())
+	}
+
+	result, _ = m.Update(PaletteResultMsg{Action: PaletteAnchor, Target: "four", Input: "goto four"})
+	if m = result.(Model); !m.showPalette || m.palette.err != `goto: no section or heading "four"` {
+		t.Errorf("palette error = %q, want it to say there is no heading four", m.palette.err)
+	}
+}
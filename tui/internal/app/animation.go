@@ -6,7 +6,8 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 )
 
-// animationTickInterval is the frame rate for transition animations.
+// animationTickInterval is the frame rate of transition animations, and
+// of the AnimationScheduler that runs every animation in a session.
 const animationTickInterval = 16 * time.Millisecond // ~60fps
 
 // AnimationTickMsg advances a running animation by one frame.
@@ -20,9 +21,9 @@ type AnimationFrame struct {
 	Done     bool
 }
 
-// animationTick returns a tea.Cmd that fires an AnimationTickMsg after one frame interval.
+// animationTick returns a tea.Cmd that fires an AnimationTickMsg on the next frame.
 func animationTick(id string) tea.Cmd {
-	return tea.Tick(animationTickInterval, func(_ time.Time) tea.Msg {
+	return SubscribeAnimation("animation:"+id, animationTickInterval, func(_ time.Time) tea.Msg {
 		return AnimationTickMsg{ID: id}
 	})
 }
@@ -34,3 +35,9 @@ func easeInOut(t float64) float64 {
 	}
 	return 1 - (-2*t+2)*(-2*t+2)*(-2*t+2)/2
 }
+
+// easeOut applies a decelerating curve (cubic), moving fastest at the
+// start so the first frame already answers the key press.
+func easeOut(t float64) float64 {
+	return 1 - (1-t)*(1-t)*(1-t)
+}
@@ -1,7 +1,11 @@
 package app
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"log/slog"
+	"slices"
 	"strings"
 	"time"
 
@@ -9,11 +13,13 @@ import (
 	"github.com/charmbracelet/lipgloss"
 	"github.com/buntingszn/terminal-portfolio/tui/internal/analytics"
 	"github.com/buntingszn/terminal-portfolio/tui/internal/content"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/i18n"
 )
 
 // ChromeHeight is the number of terminal lines consumed by the root model's
-// chrome (navbar + blank line + statusbar). Sections receive a WindowSizeMsg
-// with Height already reduced by this value.
+// chrome (navbar + indicator row + statusbar). Sections receive a WindowSizeMsg
+// with Height already reduced by this value, except in the split layout,
+// where the navbar gives way to a sidebar and only the status bar remains.
 const ChromeHeight = 3
 
 // MinWidth and MinHeight define the minimum terminal dimensions required to
@@ -32,11 +38,19 @@ type SectionModel interface {
 	View() string
 }
 
+// InputCapturer is implemented by sections that accept free text input.
+// While CapturingInput reports true, the root model forwards every key
+// except ctrl+c to the section instead of applying global bindings, so
+// typing "q" or "t" enters text rather than quitting or switching themes.
+type InputCapturer interface {
+	CapturingInput() bool
+}
+
 // Model is the root Bubbletea model that manages section routing,
 // global key bindings, and theme state.
 type Model struct {
 	activeSection Section
-	sections      [SectionCount]SectionModel
+	sections      []SectionModel
 	theme         Theme
 	content       *content.Content
 	statusBar     StatusBar
@@ -49,6 +63,51 @@ type Model struct {
 	width         int
 	height        int
 	showHelp      bool
+	showKeys      bool
+
+	// linkHints are the URLs of the links labeled in the active section
+	// while link hints are shown, or nil; hintInput is the part of a
+	// label's number typed so far.
+	linkHints []string
+	hintInput string
+
+	// pendingAnchor is the heading :goto named in another section, to
+	// scroll to once that section is focused, or nil.
+	pendingAnchor *Anchor
+
+	// scrollDrag is the scrollbar thumb being dragged with the mouse, or
+	// nil.
+	scrollDrag *scrollDrag
+
+	// keys binds keys to the global and scrolling actions.
+	keys KeyMap
+
+	// reducedMotion skips the intro and the slide between sections, and
+	// has sections skip their animations.
+	reducedMotion bool
+	// slowLink is set once the session's link is known to be slow; it
+	// keeps the screensaver off.
+	slowLink bool
+
+	// a11ySwitch hands the session over to accessible text mode for
+	// :a11y; nil when the server offers no such mode.
+	a11ySwitch func(locale string)
+
+	// navWrap controls whether next/prev navigation cycles past the first
+	// and last sections. When false, navigation stops at the ends.
+	navWrap bool
+
+	// reorderRTL puts right-to-left text in display order before frames
+	// are drawn.
+	reorderRTL bool
+
+	// hidden marks sections left out of this session: they have no tab and
+	// cannot be navigated to.
+	hidden []bool
+
+	// itemNumbers is set while sections implementing ItemNumberer show
+	// item numbers and take 1-9 as picks rather than section jumps.
+	itemNumbers bool
 
 	// Idle timeout fields. When idleTimeout > 0, the model tracks user
 	// activity and shows a warning before disconnecting idle sessions.
@@ -58,40 +117,233 @@ type Model struct {
 	showIdleWarning bool
 	idleRemaining   time.Duration
 
+	// locale translates the chrome; nil is English. localized returns the
+	// content in the locale tagged, or nil when :lang only switches the
+	// chrome.
+	locale    *i18n.Locale
+	localized func(tag string) *content.Content
+
+	// screensaver covers the screen once the session has been idle for
+	// screensaverAfter, until a key is pressed; nil while it is hidden.
+	// screensaverGen tells its ticks from those of earlier screensavers.
+	screensaverAfter time.Duration
+	screensaver      *screensaver
+	screensaverGen   int
+
+	// shutdownPending is set once the server starts draining for a
+	// restart, with shutdownRemaining the time left before the session is
+	// closed. showShutdown is set while the countdown overlay covers the
+	// screen, until a key dismisses it to a banner.
+	shutdownPending   bool
+	shutdownRemaining time.Duration
+	showShutdown      bool
+
+	// game is the mini-game open in a full-screen overlay, or nil.
+	// gameGen tells its ticks from those of earlier games, and secretPos
+	// counts how much of the Konami code has been typed.
+	game      miniGame
+	gameGen   int
+	secretPos int
+
+	// resume is the state Resume restores once the first WindowSizeMsg
+	// has laid out the sections; nil otherwise. quit is set once the
+	// visitor ends the session, so it is not offered for resuming.
+	// welcomeBack is false when the session only started at a section
+	// by StartAt, which has nothing to welcome the visitor back to.
+	resume      *ResumeState
+	welcomeBack bool
+	quit        bool
+
+	// noSplit turns off the split layout wide terminals otherwise get.
+	// focus is the split layout's focused pane, and sidebarCursor the
+	// section picked in its section list.
+	noSplit       bool
+	focus         FocusRing
+	sidebarCursor Section
+
 	// Analytics fields. When analyticsLog is non-nil, the model emits
-	// session_start, section_view, and session_end events to the JSONL log.
-	analyticsLog  *analytics.Logger
+	// session_start, section_view, and session_end events to it.
+	analyticsLog  analytics.Sink
 	sessionID     string
 	sessionIP     string
 	sessionStart  time.Time
 	sectionStart  time.Time
+	variants      map[string]string // experiment ID → assigned variant
+
+	// logger logs the visitor's navigations, palette commands and errors,
+	// tagged with the session's fields. Nil logs to the default logger.
+	logger *slog.Logger
+
+	// debug collects frame timings for the :debug overlay. It is a pointer
+	// so View can record render durations through value copies.
+	debug *debugStats
+
+	// animation runs every animation in the session off one tick. Shared
+	// through value copies like debug.
+	animation *AnimationScheduler
+
+	// guard truncates over-wide component output before it reaches the
+	// terminal. Shared through value copies like debug.
+	guard *frameGuard
+
+	// chaos injects faults for resilience testing. Nil disables it.
+	chaos *chaosState
+
+	// noticeGen ties each status bar notice clear to the notice it was
+	// scheduled for.
+	noticeGen int
+
+	// bell rings the terminal bell when a broadcast arrives.
+	bell bool
+
+	// output receives out-of-band writes requested by sections: OSC 52
+	// clipboard copies and terminal setup sequences. When nil, they are
+	// dropped.
+	output io.Writer
 }
 
-// New creates a new root Model with the given content data.
-// It initializes all sections with placeholder implementations and starts
-// with the dark theme, home section active, and the intro boot sequence.
+// New creates a new root Model with the given content data, with a
+// section for each registered one: secs in registry order, then those the
+// registry builds, or placeholders. It starts with the dark theme, home
+// section active, and the intro boot sequence.
 func New(c *content.Content, secs ...SectionModel) Model {
 	theme := DarkTheme()
-	var sections [SectionCount]SectionModel
-	for i := range SectionCount {
-		if i < len(secs) {
+	defs := registeredSections()
+	sections := make([]SectionModel, len(defs))
+	for i, def := range defs {
+		switch {
+		case i < len(secs):
 			sections[i] = secs[i]
-		} else {
-			sections[i] = newPlaceholderSection(SectionName(Section(i)), theme)
+		case def.New != nil:
+			sections[i] = def.New(c, theme)
+		default:
+			sections[i] = newPlaceholderSection(def.Name, theme)
 		}
 	}
+	hidden := defaultHidden()
+	hidden[SectionNotes] = c == nil || len(c.Notes) == 0
+	hidden[SectionUses] = c == nil || len(c.Uses) == 0
+	hidden[SectionTalks] = c == nil || len(c.Talks) == 0
+	var boot []content.BootMessage
+	if c != nil {
+		boot = c.BootMessages
+	}
+	navBar := NewNavBar(theme, 0)
+	navBar.SetHidden(hidden)
+	palette := NewPaletteModel(theme)
+	palette.SetHidden(hidden)
 	return Model{
 		activeSection: SectionHome,
 		sections:      sections,
 		theme:      theme,
 		content:    c,
 		statusBar:  NewStatusBar(theme, 0),
-		navBar:     NewNavBar(theme, 0),
-		intro:      NewIntroModel(theme),
+		navBar:     navBar,
+		intro:      NewIntroModel(theme, boot),
 		showIntro:  true,
 		transition: NewTransitionManager(),
-		palette:    NewPaletteModel(theme),
+		palette:    palette,
+		debug:      &debugStats{},
+		animation:  NewAnimationScheduler(animationTickInterval),
+		guard:      newFrameGuard(),
+		navWrap:    true,
+		reorderRTL: true,
+		hidden:     hidden,
+		focus:      NewFocusRing(paneCount, paneContent),
+	}
+}
+
+// defaultHidden returns the sections hidden until a session reveals them,
+// as the registry marks them. New reveals notes to content that has some.
+func defaultHidden() []bool {
+	defs := registeredSections()
+	hidden := make([]bool, len(defs))
+	for i, def := range defs {
+		hidden[i] = def.Hidden
 	}
+	return hidden
+}
+
+// SetSectionHidden hides or reveals section s. A hidden section has no tab
+// and is skipped by navigation; home cannot be hidden. This should be
+// called before Init().
+func (m Model) SetSectionHidden(s Section, hidden bool) Model {
+	if s == SectionHome || s < 0 || int(s) >= len(m.hidden) {
+		return m
+	}
+	// Copies of the model share the slice, so it is replaced rather than
+	// changed in place.
+	m.hidden = slices.Clone(m.hidden)
+	m.hidden[s] = hidden
+	m.navBar.SetHidden(m.hidden)
+	m.palette.SetHidden(m.hidden)
+	return m
+}
+
+// SetNavWrap configures whether next/prev navigation wraps around from the
+// last section to the first and vice versa. Wrapping is on by default.
+func (m Model) SetNavWrap(wrap bool) Model {
+	m.navWrap = wrap
+	m.navBar.SetWrap(wrap)
+	return m
+}
+
+// SetStatusBarMode chooses the status bar's mode, StatusBarHints (the
+// default) or StatusBarProgress.
+func (m Model) SetStatusBarMode(mode string) Model {
+	m.statusBar.SetMode(mode)
+	return m
+}
+
+// SetKeyMap replaces the default key bindings with km, and tells every
+// section with a KeyMapChangedMsg.
+func (m Model) SetKeyMap(km KeyMap) Model {
+	m.keys = km
+	msg := KeyMapChangedMsg{Keys: km}
+	for i := range m.sections {
+		m.sections[i], _ = m.sections[i].Update(msg)
+	}
+	return m
+}
+
+// SetReorderRTL configures whether right-to-left text, such as Hebrew or
+// Arabic, is put in display order by the TUI, for terminals that draw
+// text in the order it arrives. Reordering is on by default.
+func (m Model) SetReorderRTL(on bool) Model {
+	m.reorderRTL = on
+	return m
+}
+
+// SetTheme switches the model, its chrome, and every section to theme.
+// It is meant for choosing a session's initial theme before Init().
+func (m Model) SetTheme(theme Theme) Model {
+	result, _ := m.applyTheme(theme)
+	return result.(Model)
+}
+
+// SetFrameCheck enables whole-frame size assertions: each frame must be
+// exactly the terminal height with no line wider than the terminal, and
+// violations are logged with per-component sizes. Meant for debug mode.
+func (m Model) SetFrameCheck(on bool) Model {
+	if m.guard != nil {
+		m.guard.checkSize = on
+	}
+	return m
+}
+
+// SetOutput sets the writer that clipboard and other out-of-band sequences
+// are sent to, normally the session's output stream. They are written from
+// command goroutines while the renderer flushes frames, so w must be the
+// program's own output, made safe for concurrent writes.
+func (m Model) SetOutput(w io.Writer) Model {
+	m.output = w
+	return m
+}
+
+// SetPaletteCommands adds custom commands to the command palette.
+func (m Model) SetPaletteCommands(cmds []PaletteCommand) Model {
+	m.palette.SetCommands(cmds)
+	return m
 }
 
 // SetIdleTimeout configures the idle timeout duration for the model.
@@ -104,9 +356,39 @@ func (m Model) SetIdleTimeout(d time.Duration) Model {
 	return m
 }
 
+// SetLocale translates the help overlay, status bar, and idle messages
+// into l, and tells every section with a LocaleChangedMsg. This should be
+// called before Init().
+func (m Model) SetLocale(l *i18n.Locale) Model {
+	m.locale = l
+	m.statusBar.SetHints(l.T("status.hints"))
+	msg := LocaleChangedMsg{Locale: l}
+	for i := range m.sections {
+		m.sections[i], _ = m.sections[i].Update(msg)
+	}
+	return m
+}
+
+// SetContentLocales lets :lang switch the content along with the chrome,
+// to what localized returns for the locale's tag.
+func (m Model) SetContentLocales(localized func(tag string) *content.Content) Model {
+	m.localized = localized
+	return m
+}
+
+// SetScreensaver shows the screensaver after d without input. A value of
+// 0 disables it. This should be called before Init().
+func (m Model) SetScreensaver(d time.Duration) Model {
+	m.screensaverAfter = d
+	if d > 0 {
+		m.lastActivity = time.Now()
+	}
+	return m
+}
+
 // SetAnalytics configures analytics logging for the model.
-// A nil logger disables analytics. This should be called before Init().
-func (m Model) SetAnalytics(l *analytics.Logger, sid, ip string) Model {
+// A nil sink disables analytics. This should be called before Init().
+func (m Model) SetAnalytics(l analytics.Sink, sid, ip string) Model {
 	m.analyticsLog = l
 	m.sessionID = sid
 	m.sessionIP = ip
@@ -115,6 +397,31 @@ func (m Model) SetAnalytics(l *analytics.Logger, sid, ip string) Model {
 	return m
 }
 
+// SetLogger sets the logger for the session's UI events and errors, which
+// the server creates with the session's fields attached so one visitor's
+// activity can be followed through the log. This should be called before
+// Init().
+func (m Model) SetLogger(l *slog.Logger) Model {
+	m.logger = l
+	m.guard.logger = l
+	return m
+}
+
+// log returns the session's logger, falling back to the default.
+func (m Model) log() *slog.Logger {
+	if m.logger == nil {
+		return slog.Default()
+	}
+	return m.logger
+}
+
+// SetVariants records the session's A/B experiment assignments so that
+// analytics events are tagged with them. This should be called before Init().
+func (m Model) SetVariants(v map[string]string) Model {
+	m.variants = v
+	return m
+}
+
 // logSectionView emits a section_view event for the current section and
 // returns the current time for use as the next sectionStart.
 func (m *Model) logSectionView() time.Time {
@@ -128,12 +435,15 @@ func (m *Model) logSectionView() time.Time {
 		Type:       analytics.EventSectionView,
 		Section:    SectionName(m.activeSection),
 		DurationMs: now.Sub(m.sectionStart).Milliseconds(),
+		Variants:   m.variants,
 	})
 	return now
 }
 
-// logSessionEnd emits the final section_view and session_end events.
+// logSessionEnd marks the session as ended by the visitor and emits the
+// final section_view and session_end events.
 func (m *Model) logSessionEnd() {
+	m.quit = true
 	if m.analyticsLog == nil {
 		return
 	}
@@ -143,48 +453,132 @@ func (m *Model) logSessionEnd() {
 		SessionID:  m.sessionID,
 		Type:       analytics.EventSessionEnd,
 		DurationMs: time.Since(m.sessionStart).Milliseconds(),
+		Variants:   m.variants,
 	})
 }
 
 // Init implements tea.Model. It starts the intro boot sequence and, if
-// idle timeout is configured, begins the periodic idle check.
+// an idle timeout or screensaver is configured, begins the periodic idle
+// check.
 func (m Model) Init() tea.Cmd {
 	var cmds []tea.Cmd
 	cmds = append(cmds, tea.SetWindowTitle(m.content.Meta.Name+" — "+m.content.Meta.Title))
-	if m.showIntro {
+	switch {
+	case m.showIntro && m.reducedMotion:
+		cmds = append(cmds, func() tea.Msg { return IntroDoneMsg{} })
+	case m.showIntro:
 		cmds = append(cmds, m.intro.Init())
-	} else {
+	default:
 		cmds = append(cmds, m.sections[m.activeSection].Init())
 	}
-	if m.idleTimeout > 0 {
+	if m.idleTimeout > 0 || m.screensaverAfter > 0 {
 		cmds = append(cmds, idleCheckTick())
 	}
+	if m.chaos != nil && m.chaos.Shrink > 0 {
+		cmds = append(cmds, m.chaosShrinkTick())
+	}
+	if m.reorderRTL {
+		cmds = append(cmds, writeOutput(m.output, bidiExplicitMode))
+	}
 	return tea.Batch(cmds...)
 }
 
 // Update implements tea.Model. It handles global keys before delegating to sections.
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if m.debug != nil {
+		m.debug.messages++
+	}
+	// Number-row presses read as digits, except where they are typed.
+	if seq, ok := csiSequence(msg); ok {
+		if k, ok := parseKittyKey(seq); ok {
+			msg = k.keyMsg(!m.showPalette && !(m.contentFocused() && m.capturingInput()))
+		}
+	}
+
 	switch msg := msg.(type) {
+	case AnimationRequestMsg:
+		return m, m.animation.request(msg, time.Now())
+	case animationFrameMsg:
+		return m.handleAnimationFrame(msg)
 	case idleCheckMsg:
 		return m.handleIdleCheck()
+	case screensaverTickMsg:
+		return m.handleScreensaverTick(msg)
+	case SlowLinkMsg:
+		return m.handleSlowLink()
+	case debugTickMsg:
+		return m.handleDebugTick()
 	case tea.WindowSizeMsg:
+		if m.chaos != nil {
+			m.chaos.width, m.chaos.height = msg.Width, msg.Height
+			m.chaos.shrunk = false
+		}
 		return m.handleWindowSize(msg)
+	case chaosShrinkMsg:
+		return m.handleChaosShrink()
+	case chaosRestoreMsg:
+		return m.handleChaosRestore()
 	case IntroDoneMsg:
 		return m.handleIntroDone()
 	case TransitionDoneMsg:
 		return m.handleTransitionDone()
 	case AnimationTickMsg:
+		if msg.ID != transitionID {
+			// Other animations, such as animated scrolls, belong to a
+			// section; each ignores the ticks that are not its own.
+			var cmds []tea.Cmd
+			for i := range m.sections {
+				var cmd tea.Cmd
+				m.sections[i], cmd = m.sections[i].Update(msg)
+				if cmd != nil {
+					cmds = append(cmds, cmd)
+				}
+			}
+			return m, tea.Batch(cmds...)
+		}
 		if m.transition.Active() {
-			return m, m.transition.Update(msg)
+			cmd := m.transition.Update(msg)
+			m.navBar.SetSlide(m.transition.Progress())
+			return m, cmd
 		}
 		return m, nil
 	case PaletteResultMsg:
 		return m.handlePaletteResult(msg)
+	case paletteCommandDoneMsg:
+		text := msg.text
+		if msg.err != nil {
+			m.log().Warn("palette command failed", "command", msg.name, "err", msg.err)
+			text = msg.name + " failed"
+		}
+		return m.showNotice(text)
+	case noticeClearMsg:
+		if msg.gen == m.noticeGen {
+			m.statusBar.SetNotice("")
+			m.statusBar.SetFlash(false)
+		}
+		return m, nil
+	case BroadcastMsg:
+		return m.handleBroadcast(msg)
+	case broadcastFlashMsg:
+		return m.handleBroadcastFlash(msg)
+	case ShutdownCountdownMsg:
+		return m.handleShutdownCountdown(msg)
+	case gameTickMsg:
+		return m.handleGameTick(msg)
 	case NavigateMsg:
 		return m.navigateTo(msg.Section)
+	case ClipboardMsg:
+		if msg.Text == "" {
+			return m, nil
+		}
+		return m, writeOutput(m.output, OSC52Sequence(msg.Text))
+	case TerminalWriteMsg:
+		return m, writeOutput(m.output, msg.Seq)
 	case tea.MouseMsg:
+		m.chaosDelay()
 		return m.handleMouse(msg)
 	case tea.KeyMsg:
+		m.chaosDelay()
 		return m.handleKey(msg)
 	}
 
@@ -210,20 +604,13 @@ func (m Model) handleWindowSize(msg tea.WindowSizeMsg) (tea.Model, tea.Cmd) {
 	m.palette.SetWidth(msg.Width)
 	m.intro.SetSize(msg.Width, msg.Height)
 
-	sectionHeight := msg.Height - ChromeHeight
-	if sectionHeight < 1 {
-		sectionHeight = 1
-	}
-	sectionMsg := tea.WindowSizeMsg{Width: msg.Width, Height: sectionHeight}
-	var cmds []tea.Cmd
-	for i := range m.sections {
-		var cmd tea.Cmd
-		m.sections[i], cmd = m.sections[i].Update(sectionMsg)
-		if cmd != nil {
-			cmds = append(cmds, cmd)
-		}
+	m, cmd := m.resizeSections()
+	if m.resume != nil {
+		var resumeCmd tea.Cmd
+		m, resumeCmd = m.finishResume()
+		cmd = tea.Batch(cmd, resumeCmd)
 	}
-	return m, tea.Batch(cmds...)
+	return m, cmd
 }
 
 // handleIntroDone transitions from the boot sequence to the active section.
@@ -241,6 +628,10 @@ func (m Model) handleIntroDone() (tea.Model, tea.Cmd) {
 func (m Model) handleTransitionDone() (tea.Model, tea.Cmd) {
 	var focusCmd tea.Cmd
 	m.sections[m.activeSection], focusCmd = m.sections[m.activeSection].Update(FocusMsg{})
+	if an, ok := m.sections[m.activeSection].(Anchorer); ok && m.pendingAnchor != nil {
+		an.ScrollToLine(m.pendingAnchor.Line)
+	}
+	m.pendingAnchor = nil
 	return m, focusCmd
 }
 
@@ -248,40 +639,240 @@ func (m Model) handleTransitionDone() (tea.Model, tea.Cmd) {
 func (m Model) handlePaletteResult(msg PaletteResultMsg) (tea.Model, tea.Cmd) {
 	m.showPalette = false
 	m.palette.Close()
+	if msg.Action != PaletteNone {
+		// Only the command's name: custom commands may be given the
+		// visitor's own words.
+		name, _, _ := strings.Cut(msg.Input, " ")
+		m.log().Info("palette command", "command", name)
+	}
 	switch msg.Action {
 	case PaletteNavigate:
 		return m.navigateTo(msg.Section)
 	case PaletteQuit:
 		m.logSessionEnd()
-		return m, tea.Quit
+		return m, m.quitCmd()
 	case PaletteHelp:
 		m.showHelp = true
 		return m, nil
+	case PaletteDebug:
+		return m.toggleDebug()
+	case PaletteTheme:
+		if msg.Target == m.theme.Name {
+			return m.showNotice("Already using the " + m.theme.Name + " theme")
+		}
+		return m.applyTheme(m.theme.Toggled())
+	case PaletteDownload:
+		d, ok := m.sections[msg.Section].(Downloader)
+		if !ok {
+			return m, nil
+		}
+		// Go to the section too, so its confirmation is on screen.
+		download := d.Download(msg.Format)
+		next, navCmd := m.navigateTo(msg.Section)
+		return next, tea.Batch(download, navCmd)
+	case PaletteBook:
+		return m.copyBooking()
+	case PaletteKeys:
+		return m.openKeys()
+	case PalettePlay:
+		return m.startGame()
+	case PaletteSplit:
+		return m.toggleSplit()
+	case PaletteStatusBar:
+		return m.toggleStatusBar()
+	case PaletteMotion:
+		return m.setMotion(msg.Target)
+	case PaletteA11y:
+		return m.startA11y()
+	case PaletteIntro:
+		return m.replayIntro()
+	case PaletteOpen:
+		return m.openProject(msg)
+	case PaletteCopy:
+		return m.copyField(msg)
+	case PaletteLang:
+		return m.switchLocale(msg.Target)
+	case PaletteAnchor:
+		return m.gotoAnchor(msg)
+	case PaletteCustom:
+		return m, runPaletteCommand(msg.Command, PaletteInvocation{
+			SessionID: m.sessionID,
+			IP:        m.sessionIP,
+			Args:      msg.Args,
+		})
 	default:
 		return m, nil
 	}
 }
 
-// handleMouse delegates mouse events to the active section for scroll handling.
+// copyBooking copies the owner's booking link, confirming in the status
+// bar, or says there is none.
+func (m Model) copyBooking() (tea.Model, tea.Cmd) {
+	if m.content == nil || m.content.About.Booking == "" {
+		return m.showNotice("No booking link")
+	}
+	next, notice := m.showNotice("Booking link copied!")
+	return next, tea.Batch(CopyToClipboard(m.content.About.Booking), notice)
+}
+
+// paletteCommandTimeout bounds how long a custom palette command may run.
+const paletteCommandTimeout = 10 * time.Second
+
+// noticeDuration is how long a notice replaces the status bar hints.
+const noticeDuration = 3 * time.Second
+
+// paletteCommandDoneMsg carries the outcome of a custom palette command.
+type paletteCommandDoneMsg struct {
+	name string
+	text string
+	err  error
+}
+
+// noticeClearMsg clears the notice it was scheduled for.
+type noticeClearMsg struct {
+	gen int
+}
+
+// runPaletteCommand runs a custom palette command in the background.
+// Panics are contained so a faulty command cannot end the session.
+func runPaletteCommand(c *PaletteCommand, inv PaletteInvocation) tea.Cmd {
+	return func() (msg tea.Msg) {
+		defer func() {
+			if r := recover(); r != nil {
+				msg = paletteCommandDoneMsg{name: c.Name, err: fmt.Errorf("panic: %v", r)}
+			}
+		}()
+		ctx, cancel := context.WithTimeout(context.Background(), paletteCommandTimeout)
+		defer cancel()
+		text, err := c.Run(ctx, inv)
+		return paletteCommandDoneMsg{name: c.Name, text: text, err: err}
+	}
+}
+
+// showNotice shows text in the status bar for noticeDuration. Empty text
+// shows nothing.
+func (m Model) showNotice(text string) (tea.Model, tea.Cmd) {
+	if text == "" {
+		return m, nil
+	}
+	m.statusBar.SetNotice(text)
+	m.noticeGen++
+	gen := m.noticeGen
+	return m, tea.Tick(noticeDuration, func(time.Time) tea.Msg {
+		return noticeClearMsg{gen: gen}
+	})
+}
+
+// applyTheme switches every component to theme. Chrome is updated directly;
+// sections receive a ThemeChangedMsg so they can rebuild their content.
+func (m Model) applyTheme(theme Theme) (tea.Model, tea.Cmd) {
+	m.theme = theme
+	m.navBar.SetTheme(theme)
+	m.statusBar.SetTheme(theme)
+	m.palette.SetTheme(theme)
+	m.intro.SetTheme(theme)
+
+	msg := ThemeChangedMsg{Theme: theme}
+	var cmds []tea.Cmd
+	for i := range m.sections {
+		var cmd tea.Cmd
+		m.sections[i], cmd = m.sections[i].Update(msg)
+		if cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// switchLocale changes the language of the chrome and, when a translation
+// is available, of the content, telling every section about the new
+// content.
+func (m Model) switchLocale(tag string) (tea.Model, tea.Cmd) {
+	l, ok := i18n.Lookup(tag)
+	if !ok {
+		return m, nil
+	}
+	m = m.SetLocale(l)
+	var cmds []tea.Cmd
+	if m.localized != nil {
+		if c := m.localized(l.Tag); c != nil {
+			m.content = c
+			msg := ContentChangedMsg{Content: c}
+			for i := range m.sections {
+				var cmd tea.Cmd
+				m.sections[i], cmd = m.sections[i].Update(msg)
+				if cmd != nil {
+					cmds = append(cmds, cmd)
+				}
+			}
+		}
+	}
+	next, notice := m.showNotice(l.T("lang.switched", l.Name))
+	return next, tea.Batch(append(cmds, notice)...)
+}
+
+// handleMouse handles clicks on the chrome and drags of a scrollbar
+// thumb, and delegates other mouse events, such as the wheel, to the
+// active section. Sections get
+// positions relative to their own top-left corner.
 func (m Model) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
 	m.resetIdleTimer()
-	if m.showIntro || m.transition.Active() || m.showPalette || m.showHelp {
+	if m.screensaver != nil {
+		return m.stopScreensaver(), nil
+	}
+	if m.showIntro || m.transition.Active() || m.showPalette || m.showHelp || m.showKeys || m.linkHints != nil {
 		return m, nil
 	}
+	x, y := m.sectionOrigin()
+	if m.scrollDrag != nil {
+		if msg.Action == tea.MouseActionMotion {
+			return m.dragScrollbar(msg.Y - y), nil
+		}
+		m.scrollDrag = nil
+		if msg.Action == tea.MouseActionRelease {
+			return m, nil
+		}
+	}
+	if msg.Action == tea.MouseActionPress && msg.Button == tea.MouseButtonLeft {
+		return m.handleClick(msg)
+	}
+	msg.X, msg.Y = msg.X-x, msg.Y-y
 	var cmd tea.Cmd
 	m.sections[m.activeSection], cmd = m.sections[m.activeSection].Update(msg)
 	return m, cmd
 }
 
+// capturingInput reports whether the active section is taking typed text,
+// such as a guestbook entry, so global keys must pass through to it.
+func (m Model) capturingInput() bool {
+	ic, ok := m.sections[m.activeSection].(InputCapturer)
+	return ok && ic.CapturingInput()
+}
+
 // handleKey processes global key bindings and delegates to overlays or sections.
 func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	m.resetIdleTimer()
 
+	if m.showShutdown {
+		m.showShutdown = false
+		return m, nil
+	}
+	if m.screensaver != nil {
+		return m.stopScreensaver(), nil
+	}
 	if m.showIntro {
 		var cmd tea.Cmd
 		m.intro, cmd = m.intro.Update(msg)
 		return m, cmd
 	}
+	if m.game != nil {
+		return m.handleGameKey(msg)
+	}
+	// The code is tracked before the transition check, since its left and
+	// right presses start transitions that would swallow the next ones.
+	if !m.showPalette && !m.showHelp && !m.showKeys && m.linkHints == nil && !m.capturingInput() && m.trackSecret(msg.String()) {
+		return m.startGame()
+	}
 	if m.transition.Active() {
 		return m, nil
 	}
@@ -294,35 +885,87 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.showHelp = false
 		return m, nil
 	}
+	if m.showKeys {
+		return m.handleKeysKey(msg)
+	}
+	if m.linkHints != nil {
+		return m.handleLinkHintKey(msg)
+	}
+	if m.contentFocused() && m.capturingInput() && msg.String() != "ctrl+c" {
+		var cmd tea.Cmd
+		m.sections[m.activeSection], cmd = m.sections[m.activeSection].Update(msg)
+		return m, cmd
+	}
+	if m.splitActive() {
+		if next, cmd, ok := m.handlePaneKey(msg); ok {
+			return next, cmd
+		}
+	}
+	if _, ok := ItemNumber(msg); ok && m.picksItems() {
+		var cmd tea.Cmd
+		m.sections[m.activeSection], cmd = m.sections[m.activeSection].Update(msg)
+		return m, cmd
+	}
+	// The heading keys step through the headings of a section that has
+	// them before changing section.
+	if m.contentFocused() && (m.keys.Matches(msg, KeyHeadingNext) && m.jumpToAnchor("]") ||
+		m.keys.Matches(msg, KeyHeadingPrev) && m.jumpToAnchor("[")) {
+		return m, nil
+	}
+	if m.contentFocused() && m.scrollsSideways(msg) {
+		return m.forwardKey(msg)
+	}
 
-	switch msg.String() {
-	case "q", "ctrl+c":
+	key := msg.String()
+	if d, ok := Digit(msg); ok {
+		key = string(d)
+	}
+	switch {
+	case key == "ctrl+c" || m.keys.bound(key, KeyQuit):
 		m.logSessionEnd()
-		return m, tea.Quit
-	case "?":
+		return m, m.quitCmd()
+	case m.keys.bound(key, KeyHelp):
 		m.showHelp = true
 		return m, nil
-	case ":":
+	case m.keys.bound(key, KeyPalette):
 		m.showPalette = true
 		m.palette.Open()
 		return m, nil
-	case "tab", "right":
-		next := Section((int(m.activeSection) + 1) % SectionCount)
-		return m.navigateTo(next)
-	case "shift+tab", "left":
-		prev := Section((int(m.activeSection) - 1 + SectionCount) % SectionCount)
-		return m.navigateTo(prev)
-	case "1":
-		return m.navigateTo(SectionHome)
-	case "2":
-		return m.navigateTo(SectionWork)
-	case "3":
-		return m.navigateTo(SectionCV)
-	case "4":
-		return m.navigateTo(SectionLinks)
-	}
-
-	// Delegate unmatched keys to the active section (j/k/g/G/pgup/etc).
+	case m.keys.bound(key, KeyNavNext), m.keys.bound(key, KeyHeadingNext):
+		return m.navigateTo(stepSection(m.activeSection, 1, m.navWrap, m.hidden))
+	case m.keys.bound(key, KeyNavPrev), m.keys.bound(key, KeyHeadingPrev):
+		return m.navigateTo(stepSection(m.activeSection, -1, m.navWrap, m.hidden))
+	case m.keys.bound(key, KeyTheme):
+		return m.applyTheme(m.theme.Toggled())
+	case m.keys.bound(key, KeyLinkHints):
+		return m.openLinkHints()
+	case m.keys.bound(key, KeyItemNumbers):
+		if _, ok := m.sections[m.activeSection].(ItemNumberer); ok {
+			return m.toggleItemNumbers(), nil
+		}
+	}
+	// 1-9 jump to the tab with that number, its place in the bar.
+	if len(key) == 1 && key >= "1" && key <= "9" {
+		if s, ok := sectionAtTab(int(key[0]-'0'), m.hidden); ok {
+			return m.navigateTo(s)
+		}
+	}
+
+	// Delegate unmatched keys to the active section (j/k/g/G/pgup/etc),
+	// unless another pane has focus.
+	if !m.contentFocused() {
+		return m, nil
+	}
+	return m.forwardKey(msg)
+}
+
+// forwardKey passes a key to the active section in the form it knows it
+// by, under the key map, dropping default keys the map has moved away.
+func (m Model) forwardKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	msg, ok := m.keys.forSection(msg)
+	if !ok {
+		return m, nil
+	}
 	var cmd tea.Cmd
 	m.sections[m.activeSection], cmd = m.sections[m.activeSection].Update(msg)
 	return m, cmd
@@ -330,55 +973,145 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 // View implements tea.Model.
 func (m Model) View() string {
+	if m.debug != nil {
+		start := time.Now()
+		defer func() { m.debug.lastFrame = time.Since(start) }()
+	}
+
+	if frame, ok := m.chaos.droppedFrame(); ok {
+		return frame
+	}
+
+	m.guard.beginFrame()
+	frame := m.render()
+	if m.reorderRTL {
+		frame = visualFrame(frame)
+	}
+	// The boot sequence grows line by line, so it may be shorter than the
+	// terminal; every other screen fills it exactly.
+	m.guard.checkFrame(frame, m.width, m.height, !m.showIntro)
+	if m.chaos != nil {
+		m.chaos.lastFrame = frame
+	}
+	return frame
+}
+
+// render composes the frame for the current state.
+func (m Model) render() string {
 	if m.width < MinWidth || m.height < MinHeight {
-		title := m.theme.Accent.Render("Terminal too small")
-		body := m.theme.Body.Render(fmt.Sprintf("Please resize to at least %d\u00d7%d", MinWidth, MinHeight))
-		msg := title + "\n" + body
-		return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, msg)
+		title := "Terminal too small"
+		if lipgloss.Width(title) > m.width {
+			title = "Terminal\ntoo small"
+		}
+		lines := []string{m.theme.Accent.Render(title)}
+		for _, line := range WrapText(fmt.Sprintf("Please resize to at least %d\u00d7%d", MinWidth, MinHeight), m.width) {
+			lines = append(lines, m.theme.Body.Render(line))
+		}
+		msg := lipgloss.JoinVertical(lipgloss.Center, lines...)
+		// Place does not shrink a message larger than the terminal.
+		placed := lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, msg)
+		return lipgloss.NewStyle().MaxWidth(m.width).MaxHeight(m.height).Render(placed)
+	}
+
+	// Every component passes through the frame guard, which re-truncates
+	// lines wider than the terminal and logs the component responsible.
+	fit := func(component, s string) string {
+		return m.guard.fit(component, s, m.width)
+	}
+
+	if m.showShutdown {
+		return fit("shutdown", m.shutdownView())
+	}
+
+	if m.screensaver != nil {
+		return fit("screensaver", m.screensaverView())
+	}
+
+	if m.game != nil {
+		return fit("game", m.game.View(m.width, m.height))
 	}
 
 	if m.showIntro {
-		return m.intro.View()
+		return fit("intro", m.intro.View())
 	}
 
 	if m.showHelp {
-		return m.helpView()
+		return fit("help", m.helpView())
 	}
 
-	var b strings.Builder
-	b.WriteString(m.navBar.View())
-	b.WriteString("\n\n")
+	if m.showKeys {
+		return fit("keys", m.keysView())
+	}
 
-	if m.transition.Active() {
-		fromView := m.sections[m.transition.from].View()
-		toView := m.sections[m.transition.to].View()
-		b.WriteString(m.transition.View(fromView, toView, m.width))
+	var b strings.Builder
+	if m.splitActive() {
+		width, height := m.sectionSize()
+		views := []string{paneSidebar: m.sidebarView(), paneContent: m.hintedSectionView(width)}
+		b.WriteString(fit("panes", m.splitLayout().Render(m.theme, m.width, height+1, m.focus.Current(), views)))
 	} else {
-		b.WriteString(m.sections[m.activeSection].View())
+		b.WriteString(fit("navbar", m.navBar.View()))
+		b.WriteString("\n")
+		b.WriteString(fit("indicator", m.navBar.IndicatorView()))
+		b.WriteString("\n")
+		component := SectionName(m.activeSection)
+		if m.transition.Active() {
+			component = "transition"
+		}
+		b.WriteString(fit(component, m.hintedSectionView(m.width)))
 	}
 
-	b.WriteString("\n")
-	b.WriteString(m.statusView())
-
+	// The palette and banners sit below the status bar, over the bottom
+	// of the section. Only their lines are taken from it, so a section
+	// taller than its size still shows as overflow.
+	bottom := []string{fit("statusbar", m.statusView())}
 	if m.showPalette {
-		b.WriteString("\n")
-		b.WriteString(m.palette.View())
+		bottom = append(bottom, fit("palette", m.palette.View()))
 	}
-
 	if m.showIdleWarning {
-		b.WriteString("\n")
-		b.WriteString(m.idleWarningView())
+		bottom = append(bottom, fit("idle", m.idleWarningView()))
+	}
+	if m.shutdownPending {
+		bottom = append(bottom, fit("shutdown", m.shutdownBannerView()))
+	}
+	if m.debug != nil && m.debug.visible {
+		bottom = append(bottom, fit("debug", m.debugView()))
 	}
+	tail := strings.Join(bottom, "\n")
+	body := strings.Split(b.String(), "\n")
+	overlaid := lipgloss.Height(tail) - lipgloss.Height(bottom[0])
+	if over := min(overlaid, len(body)+lipgloss.Height(tail)-m.height); over > 0 {
+		body = body[:max(0, len(body)-over)]
+	}
+	return strings.Join(append(body, tail), "\n")
+}
+
+// sectionView renders the active section, or the transition into it, in
+// width columns.
+func (m Model) sectionView(width int) string {
+	if m.transition.Active() {
+		fromView := m.sections[m.transition.from].View()
+		toView := m.sections[m.transition.to].View()
+		return m.transition.View(fromView, toView, width)
+	}
+	return m.sections[m.activeSection].View()
+}
 
-	return b.String()
+// hintedSectionView is sectionView with the link hints labeled while
+// they are shown.
+func (m Model) hintedSectionView(width int) string {
+	if m.linkHints == nil {
+		return m.sectionView(width)
+	}
+	return m.withLinkHints(m.sectionView(width), width)
 }
 
 // navigateTo switches to the target section with a transition animation.
 // FocusMsg is deferred until the transition completes (TransitionDoneMsg).
-// Navigating to the already-active section is a no-op, and navigation
-// during an active transition is ignored to prevent duplicate processing.
+// Navigating to the already-active section or a hidden one is a no-op, and
+// navigation during an active transition is ignored to prevent duplicate
+// processing.
 func (m Model) navigateTo(target Section) (tea.Model, tea.Cmd) {
-	if target == m.activeSection {
+	if target == m.activeSection || m.hidden[target] {
 		return m, nil
 	}
 	if m.transition.Active() {
@@ -397,26 +1130,82 @@ func (m Model) navigateTo(target Section) (tea.Model, tea.Cmd) {
 		cmds = append(cmds, blurCmd)
 	}
 
-	// Start transition animation (step count varies by section distance).
+	// Switch active section and update navbar. With reduced motion the
+	// section is focused at once.
 	from := m.activeSection
+	m.log().Info("section opened", "section", SectionName(target), "from", SectionName(from))
+	m.activeSection = target
+	m.sidebarCursor = target
+	m.navBar.SetActive(target)
+	if m.reducedMotion {
+		next, focusCmd := m.handleTransitionDone()
+		return next, tea.Batch(append(cmds, focusCmd)...)
+	}
+
+	// Start transition animation (step count varies by section distance).
+	// FocusMsg is sent later when TransitionDoneMsg fires.
 	transCmd := m.transition.Start(from, target)
 	if transCmd != nil {
 		cmds = append(cmds, transCmd)
 	}
-
-	// Switch active section and update navbar.
-	// FocusMsg is sent later when TransitionDoneMsg fires.
-	m.activeSection = target
-	m.navBar.SetActive(target)
+	m.navBar.StartSlide(from)
 
 	return m, tea.Batch(cmds...)
 }
 
+// stepSection returns the visible section delta positions away from s,
+// skipping hidden sections. With wrap enabled the result cycles through the
+// visible sections; otherwise it is clamped to the first and last, so
+// stepping past an end returns s unchanged.
+func stepSection(s Section, delta int, wrap bool, hidden []bool) Section {
+	var visible []Section
+	pos := 0
+	for i := range hidden {
+		if hidden[i] {
+			continue
+		}
+		if Section(i) == s {
+			pos = len(visible)
+		}
+		visible = append(visible, Section(i))
+	}
+	n, count := pos+delta, len(visible)
+	if wrap {
+		return visible[((n%count)+count)%count]
+	}
+	return visible[max(0, min(n, count-1))]
+}
+
+// visibleEnds returns the first and last sections not hidden.
+func visibleEnds(hidden []bool) (first, last Section) {
+	first, last = Section(len(hidden)-1), 0
+	for i := range hidden {
+		if !hidden[i] {
+			first, last = min(first, Section(i)), Section(i)
+		}
+	}
+	return first, last
+}
+
+// scrollsSideways reports whether msg scrolls left or right and the
+// active section's content is wider than the screen, with more of it that
+// way. The arrows, which also change section, scroll such content up to
+// its edge and change section once there.
+func (m Model) scrollsSideways(msg tea.KeyMsg) bool {
+	sr, ok := m.sections[m.activeSection].(ScrollReporter)
+	if !ok {
+		return false
+	}
+	scroll := sr.ScrollInfo()
+	return m.keys.Matches(msg, KeyScrollLeft) && scroll.ScrollsLeft() ||
+		m.keys.Matches(msg, KeyScrollRight) && scroll.ScrollsRight()
+}
+
 // statusView renders the bottom status bar.
 func (m Model) statusView() string {
 	var hints string
 	if kh, ok := m.sections[m.activeSection].(KeyHinter); ok {
-		hints = kh.KeyHints()
+		hints = FormatKeyHints(kh.KeyHints(), m.keys, tabCount(m.hidden))
 	}
 	var scroll ScrollInfo
 	if sr, ok := m.sections[m.activeSection].(ScrollReporter); ok {
@@ -435,39 +1224,70 @@ type helpShortcut struct {
 
 // helpShortcuts returns the full list of keyboard shortcuts displayed in the
 // help overlay. The key column width is chosen so that the longest key label
-// fits comfortably with trailing padding.
-func helpShortcuts() []helpShortcut {
-	return []helpShortcut{
-		{"\u2190 / \u2192", "Previous / next section"},
-		{"1-4", "Jump to section"},
-		{"j / k", "Scroll down / up"},
-		{"g / G", "Jump to top / bottom"},
-		{"PgUp", "Page up"},
-		{"PgDn", "Page down"},
-		{"^u / ^d", "Half-page up / down"},
-		{":", "Command palette"},
-		{"q", "Quit"},
-		{"?", "Toggle help"},
+// fits comfortably with trailing padding. tabs is the number of tabs shown,
+// whose digit keys the jump range names. Keys are labeled as km binds them
+// and descriptions are looked up in l.
+func helpShortcuts(tabs int, l *i18n.Locale, km KeyMap) []helpShortcut {
+	shortcuts := []helpShortcut{
+		{km.label(KeyNavPrev, KeyNavNext), "help.sections"},
+		{km.label(KeyHeadingPrev, KeyHeadingNext), "help.headings"},
+		{km.label(KeyPaneNext), "help.pane"},
+		{jumpRange(tabs), "help.jump"},
+		{km.label(KeyItemNumbers), "help.numbers"},
+		{km.label(KeyMark), "help.mark"},
+		{km.label(KeyScrollDown, KeyScrollUp), "help.scroll"},
+		{km.label(KeyScrollLeft, KeyScrollRight), "help.columns"},
+		{km.label(KeyTop, KeyBottom), "help.ends"},
+		{km.label(KeyPageUp), "help.pgup"},
+		{km.label(KeyPageDown), "help.pgdn"},
+		{km.label(KeyHalfPageUp, KeyHalfPageDown), "help.halfpage"},
+		{km.label(KeyPalette), "help.palette"},
+		{km.label(KeyTheme), "help.theme"},
+		{km.label(KeyDownload), "help.download"},
+		{km.label(KeyTimeline), "help.timeline"},
+		{km.label(KeyBooking), "help.booking"},
+		{km.label(KeyLinkHints), "help.hints"},
+		{":keys", "help.keys"},
+		{":open <n>", "help.open"},
+		{":copy <x>", "help.copy"},
+		{":split", "help.split"},
+		{":statusbar", "help.statusbar"},
+		{":motion", "help.motion"},
+		{":a11y", "help.a11y"},
+		{":intro", "help.intro"},
+		{":lang <x>", "help.lang"},
+		{km.label(KeyQuit), "help.quit"},
+		{km.label(KeyHelp), "help.help"},
+	}
+	for i := range shortcuts {
+		shortcuts[i].desc = l.T(shortcuts[i].desc)
 	}
+	return shortcuts
 }
 
 // helpView renders the help overlay.
 func (m Model) helpView() string {
-	shortcuts := helpShortcuts()
+	shortcuts := helpShortcuts(tabCount(m.hidden), m.locale, m.keys)
+	for _, c := range m.palette.custom {
+		if c.Description != "" {
+			shortcuts = append(shortcuts, helpShortcut{":" + c.Name, c.Description})
+		}
+	}
 
 	// Build two-column aligned help text. Key column is right-padded to a
 	// fixed width so descriptions line up neatly.
 	const keyColWidth = 10
-	var lines []string
-	for _, sc := range shortcuts {
-		keyStr := fmt.Sprintf("%-*s", keyColWidth, sc.key)
-		line := m.theme.Accent.Render(keyStr) + m.theme.Body.Render(sc.desc)
-		lines = append(lines, line)
+	helpText := func(shortcuts []helpShortcut) string {
+		var lines []string
+		for _, sc := range shortcuts {
+			keyStr := fmt.Sprintf("%-*s", keyColWidth, sc.key)
+			line := m.theme.Accent.Render(keyStr) + m.theme.Body.Render(sc.desc)
+			lines = append(lines, line)
+		}
+		lines = append(lines, "")
+		lines = append(lines, m.theme.Muted.Render(m.locale.T("help.dismiss")))
+		return strings.Join(lines, "\n")
 	}
-	lines = append(lines, "")
-	lines = append(lines, m.theme.Muted.Render("Press any key to dismiss"))
-
-	helpLines := strings.Join(lines, "\n")
 
 	// Determine card width: cap at 50, but don't exceed terminal width.
 	cardWidth := 50
@@ -477,11 +1297,16 @@ func (m Model) helpView() string {
 
 	// If terminal is too small for a card, render plain text without centering.
 	if cardWidth < 10 || m.width < 10 || m.height < 10 {
-		title := m.theme.Title.Render("Keyboard Shortcuts")
-		return title + "\n\n" + helpLines
+		title := m.theme.Title.Render(m.locale.T("help.title"))
+		return lipgloss.NewStyle().MaxHeight(m.height).Render(title + "\n\n" + helpText(shortcuts))
 	}
 
-	card := RenderCard(m.theme, "Keyboard Shortcuts", helpLines, cardWidth)
+	// Shortcuts that do not fit the terminal's height are left off the end.
+	card := RenderCard(m.theme, m.locale.T("help.title"), helpText(shortcuts), cardWidth)
+	for len(shortcuts) > 0 && lipgloss.Height(card) > m.height {
+		shortcuts = shortcuts[:len(shortcuts)-1]
+		card = RenderCard(m.theme, m.locale.T("help.title"), helpText(shortcuts), cardWidth)
+	}
 	return lipgloss.Place(
 		m.width, m.height,
 		lipgloss.Center, lipgloss.Center,
@@ -507,7 +1332,10 @@ func (p *placeholderSection) Init() tea.Cmd {
 	return nil
 }
 
-func (p *placeholderSection) Update(_ tea.Msg) (SectionModel, tea.Cmd) {
+func (p *placeholderSection) Update(msg tea.Msg) (SectionModel, tea.Cmd) {
+	if tc, ok := msg.(ThemeChangedMsg); ok {
+		p.theme = tc.Theme
+	}
 	return p, nil
 }
 
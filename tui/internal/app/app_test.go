@@ -1,11 +1,19 @@
 package app
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 	"github.com/buntingszn/terminal-portfolio/tui/internal/content"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/i18n"
 )
 
 // testContent returns minimal content for testing.
@@ -147,6 +155,192 @@ func TestNavigateByKeyMsg(t *testing.T) {
 	}
 }
 
+func TestNavigateWrapsByDefault(t *testing.T) {
+	m := skipIntro(t)
+
+	result, _ := m.Update(tea.KeyMsg{Type: tea.KeyShiftTab})
+	m = drainTransition(t, result.(Model))
+	if m.activeSection != SectionGuestbook {
+		t.Errorf("shift+tab from home: activeSection = %d, want %d (wrap)", m.activeSection, SectionGuestbook)
+	}
+
+	result, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("]")})
+	m = drainTransition(t, result.(Model))
+	if m.activeSection != SectionHome {
+		t.Errorf("] from guestbook: activeSection = %d, want %d (wrap)", m.activeSection, SectionHome)
+	}
+}
+
+func TestNavigateNoWrapStopsAtEnds(t *testing.T) {
+	m := skipIntro(t).SetNavWrap(false)
+
+	result, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("[")})
+	m = result.(Model)
+	if m.activeSection != SectionHome || m.transition.Active() {
+		t.Errorf("[ at home without wrap should be a no-op, got section %d", m.activeSection)
+	}
+
+	for range SectionCount() {
+		result, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+		m = drainTransition(t, result.(Model))
+	}
+	if m.activeSection != SectionGuestbook {
+		t.Errorf("repeated tab without wrap: activeSection = %d, want %d", m.activeSection, SectionGuestbook)
+	}
+}
+
+func TestNotesSectionShownWithNotes(t *testing.T) {
+	if m := New(testContent()); !m.hidden[SectionNotes] {
+		t.Error("the notes section should be hidden without notes")
+	}
+
+	c := testContent()
+	c.Notes = []content.Note{{Slug: "hello", Title: "Hello", Body: "Hi."}}
+	m := New(c)
+	if m.hidden[SectionNotes] {
+		t.Fatal("the notes section should be shown when there are notes")
+	}
+	if nb := New(c).navBar; nb.hidden[SectionNotes] {
+		t.Error("the navbar should have a notes tab when there are notes")
+	}
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	result, _ = result.(Model).Update(IntroDoneMsg{})
+	// Its tab is the sixth, after the five always shown.
+	result, _ = result.(Model).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("6")})
+	if m = drainTransition(t, result.(Model)); m.activeSection != SectionNotes {
+		t.Errorf("6: activeSection = %d, want %d", m.activeSection, SectionNotes)
+	}
+}
+
+func TestUsesSectionShownWithUses(t *testing.T) {
+	if m := New(testContent()); !m.hidden[SectionUses] {
+		t.Error("the uses section should be hidden without uses.json")
+	}
+
+	c := testContent()
+	c.Uses = []content.UsesCategory{{Category: "Editor", Items: []content.UsesItem{{Name: "Neovim"}}}}
+	m := New(c)
+	if m.hidden[SectionUses] || m.navBar.hidden[SectionUses] {
+		t.Fatal("the uses section should have a tab when there is a setup")
+	}
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 100, Height: 24})
+	result, _ = result.(Model).Update(IntroDoneMsg{})
+	result, _ = result.(Model).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("6")})
+	if m = drainTransition(t, result.(Model)); m.activeSection != SectionUses {
+		t.Errorf("6: activeSection = %d, want %d", m.activeSection, SectionUses)
+	}
+}
+
+func TestTalksSectionShownWithTalks(t *testing.T) {
+	if m := New(testContent()); !m.hidden[SectionTalks] {
+		t.Error("the talks section should be hidden without talks.json")
+	}
+	c := testContent()
+	c.Talks = []content.Talk{{Title: "Hello", Date: "2025-01-02"}}
+	m := New(c)
+	if m.hidden[SectionTalks] || m.navBar.hidden[SectionTalks] {
+		t.Fatal("the talks section should have a tab when there are talks")
+	}
+	// With the sections between guestbook and talks hidden, its tab is
+	// the sixth and 6 reaches it.
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 100, Height: 24})
+	result, _ = result.(Model).Update(IntroDoneMsg{})
+	if view := stripANSI(result.(Model).navBar.View()); !strings.Contains(view, "6:talks") {
+		t.Errorf("navbar = %q, want a 6:talks tab", view)
+	}
+	result, _ = result.(Model).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("6")})
+	if m = drainTransition(t, result.(Model)); m.activeSection != SectionTalks {
+		t.Errorf("6: activeSection = %d, want %d", m.activeSection, SectionTalks)
+	}
+}
+
+func TestStepSection(t *testing.T) {
+	tests := []struct {
+		from  Section
+		delta int
+		wrap  bool
+		want  Section
+	}{
+		{SectionHome, 1, true, SectionWork},
+		{SectionGuestbook, 1, true, SectionHome},
+		{SectionHome, -1, true, SectionGuestbook},
+		{SectionGuestbook, 1, false, SectionGuestbook},
+		{SectionHome, -1, false, SectionHome},
+		{SectionCV, -1, false, SectionWork},
+		{SectionLinks, 1, false, SectionGuestbook},
+	}
+	hidden := defaultHidden()
+	for _, tt := range tests {
+		if got := stepSection(tt.from, tt.delta, tt.wrap, hidden); got != tt.want {
+			t.Errorf("stepSection(%d, %d, %v) = %d, want %d", tt.from, tt.delta, tt.wrap, got, tt.want)
+		}
+	}
+
+	none := make([]bool, SectionCount())
+	none[SectionAdmin] = true
+	none[SectionNotes] = true
+	none[SectionUses] = true
+	none[SectionTalks] = true
+	if got := stepSection(SectionGuestbook, 1, false, none); got != SectionStatus {
+		t.Errorf("stepSection past guestbook with status shown = %d, want %d", got, SectionStatus)
+	}
+	if got := stepSection(SectionStatus, 1, true, none); got != SectionHome {
+		t.Errorf("stepSection past status with wrap = %d, want %d", got, SectionHome)
+	}
+
+	hidden[SectionCV] = true
+	hiddenTests := []struct {
+		from  Section
+		delta int
+		wrap  bool
+		want  Section
+	}{
+		{SectionWork, 1, false, SectionLinks},
+		{SectionLinks, -1, false, SectionWork},
+		{SectionGuestbook, 1, true, SectionHome},
+		{SectionHome, -1, true, SectionGuestbook},
+		{SectionGuestbook, 1, false, SectionGuestbook},
+	}
+	for _, tt := range hiddenTests {
+		if got := stepSection(tt.from, tt.delta, tt.wrap, hidden); got != tt.want {
+			t.Errorf("stepSection(%d, %d, %v) with cv and status hidden = %d, want %d", tt.from, tt.delta, tt.wrap, got, tt.want)
+		}
+	}
+}
+
+func TestSectionHidden(t *testing.T) {
+	m := skipIntro(t)
+	if !m.hidden[SectionStatus] || strings.Contains(m.navBar.View(), "status") {
+		t.Fatal("the status section should be hidden by default")
+	}
+	result, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("6")})
+	if m = result.(Model); m.activeSection != SectionHome || m.transition.Active() {
+		t.Errorf("6 with status hidden should be a no-op, got section %d", m.activeSection)
+	}
+
+	m = m.SetSectionHidden(SectionStatus, false)
+	if !strings.Contains(stripANSI(m.navBar.View()), "6:status") {
+		t.Errorf("navbar should show the revealed status tab: %q", stripANSI(m.navBar.View()))
+	}
+	result, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("6")})
+	if m = drainTransition(t, result.(Model)); m.activeSection != SectionStatus {
+		t.Errorf("6 with status shown: activeSection = %d, want %d", m.activeSection, SectionStatus)
+	}
+
+	m = m.SetSectionHidden(SectionWork, true).SetSectionHidden(SectionHome, true)
+	if m.hidden[SectionHome] {
+		t.Error("home must not be hideable")
+	}
+	view := stripANSI(m.navBar.View())
+	if strings.Contains(view, "work") || !strings.Contains(view, "1:home  2:cv") {
+		t.Errorf("navbar should skip the hidden work tab and renumber the rest: %q", view)
+	}
+	result, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("2")})
+	if m = drainTransition(t, result.(Model)); m.activeSection != SectionCV {
+		t.Errorf("2 with work hidden: activeSection = %d, want %d", m.activeSection, SectionCV)
+	}
+}
+
 func TestNavigateToSameSection(t *testing.T) {
 	m := skipIntro(t)
 	// Already on home, pressing 1 should be a no-op.
@@ -262,10 +456,12 @@ func TestWindowSizeMsgMinimumHeight(t *testing.T) {
 	}
 }
 
-// spySection captures WindowSizeMsg dimensions for testing.
+// spySection captures WindowSizeMsg dimensions and theme changes for testing.
 type spySection struct {
 	lastWidth  int
 	lastHeight int
+	themeName  string
+	keys       int
 }
 
 func (s *spySection) Init() tea.Cmd { return nil }
@@ -275,6 +471,12 @@ func (s *spySection) Update(msg tea.Msg) (SectionModel, tea.Cmd) {
 		s.lastWidth = wsm.Width
 		s.lastHeight = wsm.Height
 	}
+	if tc, ok := msg.(ThemeChangedMsg); ok {
+		s.themeName = tc.Theme.Name
+	}
+	if _, ok := msg.(tea.KeyMsg); ok {
+		s.keys++
+	}
 	return s, nil
 }
 
@@ -289,6 +491,8 @@ func TestSectionName(t *testing.T) {
 		{SectionWork, "work"},
 		{SectionCV, "cv"},
 		{SectionLinks, "links"},
+		{SectionGuestbook, "guestbook"},
+		{SectionStatus, "status"},
 		{Section(99), "unknown"},
 	}
 	for _, tt := range tests {
@@ -299,6 +503,20 @@ func TestSectionName(t *testing.T) {
 	}
 }
 
+func TestSectionByName(t *testing.T) {
+	for _, name := range []string{"cv", "CV", "guestbook"} {
+		s, ok := SectionByName(name)
+		if !ok || !strings.EqualFold(SectionName(s), name) {
+			t.Errorf("SectionByName(%q) = %v, %v", name, s, ok)
+		}
+	}
+	for _, name := range []string{"", "testuser", "unknown", "de"} {
+		if s, ok := SectionByName(name); ok {
+			t.Errorf("SectionByName(%q) = %v, want none", name, s)
+		}
+	}
+}
+
 func TestStatusViewContainsHints(t *testing.T) {
 	m := skipIntro(t)
 	m.width = 80
@@ -432,24 +650,24 @@ func TestNavBarViewFullLabelsWide(t *testing.T) {
 
 func TestNavBarViewShortLabels(t *testing.T) {
 	theme := DarkTheme()
-	nb := NewNavBar(theme, 30)
+	nb := NewNavBar(theme, 36)
 	nb.SetActive(SectionHome)
 	view := nb.View()
 
 	if !strings.Contains(view, "hm") {
-		t.Error("navbar at width 30 should contain short label 'hm'")
+		t.Error("navbar at width 36 should contain short label 'hm'")
 	}
 	if !strings.Contains(view, "wk") {
-		t.Error("navbar at width 30 should contain short label 'wk'")
+		t.Error("navbar at width 36 should contain short label 'wk'")
 	}
 	if !strings.Contains(view, "lk") {
-		t.Error("navbar at width 30 should contain short label 'lk'")
+		t.Error("navbar at width 36 should contain short label 'lk'")
 	}
 	if strings.Contains(view, "home") {
-		t.Error("navbar at width 30 should NOT contain full label 'home'")
+		t.Error("navbar at width 36 should NOT contain full label 'home'")
 	}
 	if strings.Contains(view, "links") {
-		t.Error("navbar at width 30 should NOT contain full label 'links'")
+		t.Error("navbar at width 36 should NOT contain full label 'links'")
 	}
 }
 
@@ -484,25 +702,128 @@ func TestNavBarViewNarrowNoPanic(t *testing.T) {
 	}
 }
 
+func TestNavBarViewEdgeMarkers(t *testing.T) {
+	theme := DarkTheme()
+	nb := NewNavBar(theme, 80)
+
+	if strings.Contains(nb.View(), navEdgeLeft) {
+		t.Error("navbar with wrap should not show edge markers")
+	}
+
+	nb.SetWrap(false)
+	view := nb.View()
+	if !strings.Contains(view, navEdgeLeft) || !strings.Contains(view, navEdgeRight) {
+		t.Error("navbar without wrap should show both edge markers")
+	}
+
+	// Every label format must still fit its width bracket with markers added.
+	for _, w := range []int{20, 25, 40} {
+		nb.SetWidth(w)
+		if got := lipgloss.Width(nb.View()); got > w {
+			t.Errorf("navbar width %d with edge markers exceeds %d", got, w)
+		}
+	}
+}
+
+func TestNavBarIndicatorUnderActiveTab(t *testing.T) {
+	nb := NewNavBar(DarkTheme(), 80)
+	nb.SetActive(SectionWork)
+
+	x, w := nb.tabSpan(SectionWork, navLabelFull)
+	want := strings.Repeat(" ", x) + strings.Repeat(navUnderline, w)
+	if got := stripANSI(nb.IndicatorView()); got != want {
+		t.Errorf("indicator = %q, want %q", got, want)
+	}
+}
+
+func TestNavBarIndicatorSlides(t *testing.T) {
+	nb := NewNavBar(DarkTheme(), 80)
+	nb.StartSlide(SectionHome)
+	nb.SetActive(SectionLinks)
+
+	startX, _ := nb.tabSpan(SectionHome, navLabelFull)
+	endX, _ := nb.tabSpan(SectionLinks, navLabelFull)
+	indent := func() int {
+		view := stripANSI(nb.IndicatorView())
+		return len(view) - len(strings.TrimLeft(view, " "))
+	}
+
+	if got := indent(); got != startX {
+		t.Errorf("slide start indent = %d, want %d", got, startX)
+	}
+	nb.SetSlide(0.5)
+	if got := indent(); got <= startX || got >= endX {
+		t.Errorf("mid-slide indent = %d, want strictly between %d and %d", got, startX, endX)
+	}
+	nb.SetSlide(1)
+	if got := indent(); got != endX {
+		t.Errorf("slide end indent = %d, want %d", got, endX)
+	}
+}
+
+func TestNavBarSlideFollowsTransition(t *testing.T) {
+	m := skipIntro(t)
+	result, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("4")})
+	m = result.(Model)
+	if !m.navBar.sliding {
+		t.Fatal("navbar should slide while the transition runs")
+	}
+
+	result, _ = m.Update(AnimationTickMsg{ID: transitionID})
+	m = result.(Model)
+	if m.navBar.slide != m.transition.Progress() {
+		t.Errorf("navbar slide = %v, want transition progress %v", m.navBar.slide, m.transition.Progress())
+	}
+
+	m = drainTransition(t, m)
+	if m.navBar.sliding {
+		t.Error("navbar slide should end with the transition")
+	}
+}
+
 func TestNavLabelForWidth(t *testing.T) {
 	tests := []struct {
 		width int
 		want  navLabelFormat
 	}{
 		{80, navLabelFull},
-		{40, navLabelFull},
-		{39, navLabelShort},
-		{25, navLabelShort},
-		{24, navLabelNumOnly},
+		{42, navLabelFull}, // "1:home  2:work  3:cv  4:links  5:guestbook"
+		{41, navLabelShort},
+		{28, navLabelShort}, // "1:hm  2:wk  3:cv  4:lk  5:gb"
+		{27, navLabelNumOnly},
 		{10, navLabelNumOnly},
 		{0, navLabelNumOnly},
 	}
+	hidden := defaultHidden()
 	for _, tt := range tests {
-		got := navLabelForWidth(tt.width)
+		got := navLabelForWidth(tt.width, hidden)
 		if got != tt.want {
 			t.Errorf("navLabelForWidth(%d) = %d, want %d", tt.width, got, tt.want)
 		}
 	}
+
+	// "1:home  2:work  3:cv  4:links  5:guestbook  6:status"
+	status := make([]bool, SectionCount())
+	status[SectionAdmin] = true
+	status[SectionNotes] = true
+	status[SectionUses] = true
+	status[SectionTalks] = true
+	if got := navLabelForWidth(52, status); got != navLabelFull {
+		t.Errorf("navLabelForWidth(52) with status = %d, want full", got)
+	}
+	if got := navLabelForWidth(51, status); got != navLabelShort {
+		t.Errorf("navLabelForWidth(51) with status = %d, want short", got)
+	}
+
+	// "1:home  2:work  3:cv  4:links  5:guestbook  6:status  7:admin  8:notes  9:uses  talks":
+	// the tenth tab has no digit key, so no number.
+	none := make([]bool, SectionCount())
+	if got := navLabelForWidth(85, none); got != navLabelFull {
+		t.Errorf("navLabelForWidth(85) with every tab = %d, want full", got)
+	}
+	if got := navLabelForWidth(84, none); got != navLabelShort {
+		t.Errorf("navLabelForWidth(84) with every tab = %d, want short", got)
+	}
 }
 
 func TestTransitionManagerStartAndComplete(t *testing.T) {
@@ -789,6 +1110,249 @@ func TestViewportGetScrollInfo(t *testing.T) {
 	})
 }
 
+func TestViewportReadingInfo(t *testing.T) {
+	lines := make([]string, 40)
+	for i := range lines {
+		lines[i] = "five words on each line"
+	}
+	long := strings.Join(lines, "\n")
+
+	vp := NewViewport(80, 10)
+	vp.SetContent(long)
+	info := vp.GetScrollInfo()
+	if want := content.ReadTime(200); info.ReadTime != want {
+		t.Errorf("ReadTime = %v, want %v for 200 words", info.ReadTime, want)
+	}
+	if info.Progress != 0.25 {
+		t.Errorf("Progress at top = %v, want 0.25 (10 of 40 lines visible)", info.Progress)
+	}
+
+	vp.ScrollToBottom()
+	if got := vp.GetScrollInfo().Progress; got != 1 {
+		t.Errorf("Progress at bottom = %v, want 1", got)
+	}
+
+	// Content under longContentScreens screens gets no estimate.
+	vp.SetSize(80, 20)
+	if got := vp.GetScrollInfo().ReadTime; got != 0 {
+		t.Errorf("ReadTime = %v for two-screen content, want 0", got)
+	}
+}
+
+func TestStatusBarReadingIndicator(t *testing.T) {
+	sb := NewStatusBar(DarkTheme(), 80)
+
+	atTop := ScrollInfo{AtTop: true, ReadTime: 3 * time.Minute, Progress: 0.1}
+	if out := stripANSI(sb.Render(SectionCV, "", atTop)); !strings.Contains(out, "~3 min read") {
+		t.Errorf("status bar at top should show read time, got %q", out)
+	}
+
+	scrolled := ScrollInfo{ReadTime: 3 * time.Minute, Progress: 0.42}
+	if out := stripANSI(sb.Render(SectionCV, "", scrolled)); !strings.Contains(out, "42% read") {
+		t.Errorf("status bar mid-scroll should show progress, got %q", out)
+	}
+
+	if out := stripANSI(sb.Render(SectionCV, "", ScrollInfo{AtTop: true})); strings.Contains(out, "read") {
+		t.Errorf("short content should not show a reading indicator, got %q", out)
+	}
+
+	narrow := NewStatusBar(DarkTheme(), 24)
+	if got := lipgloss.Width(narrow.Render(SectionCV, "", atTop)); got != 24 {
+		t.Errorf("narrow status bar width = %d, want 24", got)
+	}
+}
+
+func TestStatusBarColumnIndicator(t *testing.T) {
+	sb := NewStatusBar(DarkTheme(), 80)
+	tests := []struct {
+		scroll ScrollInfo
+		want   string
+	}{
+		{ScrollInfo{Fits: true, Cols: 160, ColsShown: 80}, "1-80/160 ▶"},
+		{ScrollInfo{Fits: true, Cols: 160, ColOffset: 40, ColsShown: 80}, "◀ 41-120/160 ▶"},
+		{ScrollInfo{Fits: true, Cols: 160, ColOffset: 80, ColsShown: 80}, "◀ 81-160/160"},
+	}
+	for _, tt := range tests {
+		out := stripANSI(sb.Render(SectionNotes, "j/k scroll", tt.scroll))
+		if !strings.HasPrefix(out, " "+tt.want+" ") {
+			t.Errorf("status bar = %q, want it to start with %q", out, tt.want)
+		}
+	}
+	if out := stripANSI(sb.Render(SectionNotes, "j/k scroll", ScrollInfo{Fits: true})); strings.Contains(out, "▶") || strings.Contains(out, "1-") {
+		t.Errorf("status bar = %q, want no column indicator when lines fit", out)
+	}
+}
+
+func TestStatusBarProgressMode(t *testing.T) {
+	sb := NewStatusBar(DarkTheme(), 100)
+	mid := ScrollInfo{Percent: " 50%", Position: 0.5}
+	if out := stripANSI(sb.Render(SectionWork, "", mid)); strings.Contains(out, "~/work") || strings.Contains(out, "▰") {
+		t.Errorf("hints mode = %q, want no breadcrumb or gauge", out)
+	}
+
+	sb.SetMode(StatusBarProgress)
+	tests := []struct {
+		scroll ScrollInfo
+		want   string
+	}{
+		{ScrollInfo{AtTop: true, Percent: "  0%"}, "▱▱▱▱▱▱ TOP"},
+		{mid, "▰▰▰▱▱▱ 50%"},
+		{ScrollInfo{AtBottom: true, Percent: "100%", Position: 1}, "▰▰▰▰▰▰ BOT"},
+	}
+	for _, tt := range tests {
+		out := stripANSI(sb.Render(SectionWork, "", tt.scroll))
+		if !strings.HasPrefix(out, " ~/work ") || !strings.HasSuffix(out, " "+tt.want+" ") {
+			t.Errorf("progress mode = %q, want ~/work and %q", out, tt.want)
+		}
+	}
+	if out := stripANSI(sb.Render(SectionCV, "", ScrollInfo{Fits: true})); !strings.HasPrefix(out, " ~/cv ") || strings.Contains(out, "▱") {
+		t.Errorf("progress mode = %q, want the breadcrumb alone for content that fits", out)
+	}
+
+	// :statusbar switches back and forth.
+	m := New(testContent(), newPlaceholderSection("home", DarkTheme()))
+	m = m.SetStatusBarMode(StatusBarProgress)
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+	result, _ = result.(Model).Update(PaletteResultMsg{Action: PaletteStatusBar})
+	if m = result.(Model); m.statusBar.Mode() != StatusBarHints || !strings.Contains(m.statusView(), "Scroll progress off") {
+		t.Errorf(":statusbar left mode %q", m.statusBar.Mode())
+	}
+	result, _ = m.Update(PaletteResultMsg{Action: PaletteStatusBar})
+	if m = result.(Model); m.statusBar.Mode() != StatusBarProgress {
+		t.Errorf(":statusbar left mode %q, want progress again", m.statusBar.Mode())
+	}
+}
+
+func TestReducedMotion(t *testing.T) {
+	m := New(testContent()).SetReducedMotion(true)
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+	m = result.(Model)
+
+	// The intro ends at once rather than playing.
+	var introDone bool
+	for _, cmd := range m.Init()().(tea.BatchMsg) {
+		if cmd == nil {
+			continue
+		}
+		if _, ok := cmd().(IntroDoneMsg); ok {
+			introDone = true
+		}
+	}
+	if !introDone {
+		t.Fatal("Init should end the intro straight away with reduced motion")
+	}
+	result, _ = m.Update(IntroDoneMsg{})
+	m = result.(Model)
+
+	// Sections change without a transition.
+	result, _ = m.Update(tea.KeyMsg{Type: tea.KeyRight})
+	if m = result.(Model); m.activeSection != SectionWork || m.transition.Active() {
+		t.Errorf("right went to %v with transition active %v, want work at once", m.activeSection, m.transition.Active())
+	}
+
+	// :motion switches back, and says so when there is nothing to do.
+	result, _ = m.Update(PaletteResultMsg{Action: PaletteMotion, Target: "on"})
+	if m = result.(Model); m.reducedMotion || !strings.Contains(m.statusView(), "Animations on") {
+		t.Errorf(":motion on left reducedMotion %v", m.reducedMotion)
+	}
+	result, _ = m.Update(PaletteResultMsg{Action: PaletteMotion, Target: "on"})
+	if m = result.(Model); !strings.Contains(m.statusView(), "Animations already on") {
+		t.Errorf(":motion on again shows %q", stripANSI(m.statusView()))
+	}
+	result, _ = m.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	if m = result.(Model); !m.transition.Active() {
+		t.Error("with motion on, changing section should slide")
+	}
+}
+
+func TestSlowLink(t *testing.T) {
+	m := skipIntro(t).SetScreensaver(time.Minute)
+	m = idleFor(t, m, 2*time.Minute)
+	if m.screensaver == nil {
+		t.Fatal("screensaver should start after 1m idle")
+	}
+
+	// A slow link turns motion off, dismisses the screensaver, and says so.
+	result, _ := m.Update(SlowLinkMsg{})
+	m = result.(Model)
+	if !m.reducedMotion || m.screensaver != nil {
+		t.Errorf("after SlowLinkMsg reducedMotion = %v, screensaver = %v", m.reducedMotion, m.screensaver)
+	}
+	if !strings.Contains(m.statusView(), "Slow connection") {
+		t.Errorf("status bar should explain the change: %q", stripANSI(m.statusView()))
+	}
+	if m = idleFor(t, m, 4*time.Minute); m.screensaver != nil {
+		t.Error("screensaver should stay off on a slow link")
+	}
+
+	// Once known, the link is not reported again, even with motion back on.
+	result, _ = m.Update(PaletteResultMsg{Action: PaletteMotion, Target: "on"})
+	m = result.(Model)
+	result, _ = m.Update(SlowLinkMsg{})
+	if m = result.(Model); m.reducedMotion {
+		t.Error("a repeated SlowLinkMsg should leave :motion on alone")
+	}
+
+	if m := New(testContent()).SetSlowLink(true); !m.reducedMotion || !m.slowLink {
+		t.Error("SetSlowLink should reduce motion from the start")
+	}
+}
+
+// sidewaysSection shows content wider than the screen and scrolls it
+// sideways like the real sections do.
+type sidewaysSection struct {
+	placeholderSection
+	viewport Viewport
+}
+
+func (s *sidewaysSection) Update(msg tea.Msg) (SectionModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		s.viewport.SetSize(msg.Width, msg.Height)
+		s.viewport.SetContent(strings.Repeat("x", msg.Width+10))
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "h", "left":
+			s.viewport.ScrollLeft(8)
+		case "l", "right":
+			s.viewport.ScrollRight(8)
+		}
+	}
+	return s, nil
+}
+
+func (s *sidewaysSection) View() string { return s.viewport.ViewWithScrollbar(s.theme) }
+
+func (s *sidewaysSection) ScrollInfo() ScrollInfo { return s.viewport.GetScrollInfo() }
+
+func TestArrowsScrollWideContentBeforeChangingSection(t *testing.T) {
+	wide := &sidewaysSection{placeholderSection: placeholderSection{name: "home", theme: DarkTheme()}}
+	m := New(testContent(), wide)
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	m = result.(Model)
+	result, _ = m.Update(IntroDoneMsg{})
+	m = result.(Model)
+
+	// At the left edge, left still goes to the previous section; right
+	// scrolls until the last column is in view.
+	if m.scrollsSideways(keyMsg("left")) {
+		t.Error("left should not scroll content already at its left edge")
+	}
+	for range 2 {
+		result, _ = m.Update(tea.KeyMsg{Type: tea.KeyRight})
+		m = result.(Model)
+		if m.activeSection != SectionHome {
+			t.Fatalf("right changed section with %d columns still out of view", wide.viewport.maxXOffset()-wide.viewport.XOffset())
+		}
+	}
+	if wide.viewport.XOffset() != wide.viewport.maxXOffset() {
+		t.Errorf("XOffset = %d, want the right edge %d", wide.viewport.XOffset(), wide.viewport.maxXOffset())
+	}
+	if m.scrollsSideways(keyMsg("right")) || !m.scrollsSideways(keyMsg("left")) {
+		t.Error("at the right edge, right should change section and left should scroll")
+	}
+}
+
 func TestTransitionStepsVaryByDistance(t *testing.T) {
 	tests := []struct {
 		from, to  Section
@@ -835,7 +1399,7 @@ func TestNavigateDuringTransitionIsNoop(t *testing.T) {
 func TestFocusDeferredToTransitionDone(t *testing.T) {
 	// Use a spy to track Focus/Blur messages.
 	spy := &focusSpy{}
-	secs := [SectionCount]SectionModel{}
+	secs := make([]SectionModel, SectionCount())
 	for i := range secs {
 		secs[i] = newPlaceholderSection(SectionName(Section(i)), DarkTheme())
 	}
@@ -887,3 +1451,617 @@ func (s *focusSpy) Update(msg tea.Msg) (SectionModel, tea.Cmd) {
 }
 
 func (s *focusSpy) View() string { return "" }
+
+func TestThemeToggleKey(t *testing.T) {
+	spy := &spySection{}
+	m := New(testContent(), spy)
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	m = result.(Model)
+	result, _ = m.Update(IntroDoneMsg{})
+	m = result.(Model)
+
+	result, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'t'}})
+	m = result.(Model)
+	for name, got := range map[string]string{
+		"model":     m.theme.Name,
+		"navbar":    m.navBar.theme.Name,
+		"statusbar": m.statusBar.theme.Name,
+		"palette":   m.palette.theme.Name,
+		"intro":     m.intro.theme.Name,
+		"section":   spy.themeName,
+	} {
+		if got != ThemeLight {
+			t.Errorf("%s theme = %q after t, want %q", name, got, ThemeLight)
+		}
+	}
+
+	result, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'t'}})
+	m = result.(Model)
+	if m.theme.Name != ThemeDark || spy.themeName != ThemeDark {
+		t.Errorf("second t: model %q, section %q, want %q", m.theme.Name, spy.themeName, ThemeDark)
+	}
+}
+
+func TestPaletteThemeCommand(t *testing.T) {
+	m := skipIntro(t)
+	result, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{':'}})
+	m = result.(Model)
+	for _, r := range "theme" {
+		result, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		m = result.(Model)
+	}
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("expected cmd from :theme")
+	}
+	msg, ok := cmd().(PaletteResultMsg)
+	if !ok || msg.Action != PaletteTheme {
+		t.Fatalf("got %#v, want PaletteResultMsg{Action: PaletteTheme}", msg)
+	}
+
+	result, _ = m.Update(msg)
+	m = result.(Model)
+	if m.theme.Name != ThemeLight {
+		t.Errorf("theme = %q after :theme, want %q", m.theme.Name, ThemeLight)
+	}
+	if m.showPalette {
+		t.Error("palette should close after :theme")
+	}
+}
+
+func TestPaletteBookCommand(t *testing.T) {
+	m := skipIntro(t)
+	result, _ := m.Update(PaletteResultMsg{Action: PaletteBook})
+	m = result.(Model)
+	if !strings.Contains(m.statusView(), "No booking link") {
+		t.Errorf("status bar = %q, want it to say there is no booking link", stripANSI(m.statusView()))
+	}
+
+	m.content.About.Booking = "https://cal.com/test/intro"
+	result, cmd := m.Update(PaletteResultMsg{Action: PaletteBook})
+	m = result.(Model)
+	if !strings.Contains(m.statusView(), "Booking link copied!") {
+		t.Errorf("status bar = %q, want the copy confirmed", stripANSI(m.statusView()))
+	}
+	// The first command is the copy; the second clears the notice later.
+	if msg, ok := cmd().(tea.BatchMsg)[0]().(ClipboardMsg); !ok || msg.Text != "https://cal.com/test/intro" {
+		t.Errorf("first command = %#v, want a copy of the booking link", msg)
+	}
+}
+
+func TestPaletteKeysCommand(t *testing.T) {
+	m := skipIntro(t)
+	result, _ := m.Update(PaletteResultMsg{Action: PaletteKeys})
+	m = result.(Model)
+	if m.showKeys || !strings.Contains(m.statusView(), "No public keys") {
+		t.Errorf("status bar = %q, want it to say there are no keys", stripANSI(m.statusView()))
+	}
+
+	m.content.Keys = []content.PublicKey{
+		{Label: "PGP", Type: content.KeyPGP, Key: "-----BEGIN PGP PUBLIC KEY BLOCK-----", Fingerprint: "0E5C EE0B 3B27 D772 1977 01B2 867D FB07 8EDB B945"},
+		{Label: "Laptop", Type: content.KeySSH, Key: "ssh-ed25519 AAAA kyle@laptop", Fingerprint: "SHA256:1i2PfStljwQyDrBpZz2XbgNBEcmeX+jOqAa59ghyXw8"},
+	}
+	result, _ = m.Update(PaletteResultMsg{Action: PaletteKeys})
+	m = result.(Model)
+	view := stripANSI(m.View())
+	for _, want := range []string{"Public Keys", "0E5C EE0B 3B27 D772 1977 01B2 867D FB07 8EDB B945", "SSH ed25519", "SHA256:1i2Pf"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("keys overlay missing %q", want)
+		}
+	}
+
+	result, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'2'}})
+	m = result.(Model)
+	if m.showKeys {
+		t.Error("copying a key should close the overlay")
+	}
+	if m.activeSection != SectionHome {
+		t.Errorf("active section = %v, the pick must not jump sections", m.activeSection)
+	}
+	if msg, ok := cmd().(tea.BatchMsg)[0]().(ClipboardMsg); !ok || msg.Text != "ssh-ed25519 AAAA kyle@laptop\n" {
+		t.Errorf("first command = %#v, want a copy of the SSH key", msg)
+	}
+	if !strings.Contains(m.statusView(), "Laptop key copied!") {
+		t.Errorf("status bar = %q, want the copy confirmed", stripANSI(m.statusView()))
+	}
+}
+
+func TestPaletteCustomCommand(t *testing.T) {
+	var got PaletteInvocation
+	m := skipIntro(t).SetAnalytics(nil, "sid", "1.2.3.4").SetPaletteCommands([]PaletteCommand{
+		{Name: "coffee", Description: "Buy me a coffee", Run: func(_ context.Context, inv PaletteInvocation) (string, error) {
+			got = inv
+			return "Thanks for the coffee!", nil
+		}},
+		{Name: "theme", Run: func(context.Context, PaletteInvocation) (string, error) {
+			t.Error("a custom command must not shadow a built-in")
+			return "", nil
+		}},
+	})
+	m.showHelp, m.width, m.height = true, 80, 40
+	if !strings.Contains(stripANSI(m.helpView()), ":coffee") {
+		t.Error("help should list custom commands with a description")
+	}
+	m.showHelp = false
+
+	result, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{':'}})
+	m = result.(Model)
+	for _, r := range "coffee  oat milk " {
+		result, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		m = result.(Model)
+	}
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	msg, ok := cmd().(PaletteResultMsg)
+	if !ok || msg.Action != PaletteCustom || msg.Args != "oat milk" {
+		t.Fatalf("got %#v, want PaletteResultMsg{Action: PaletteCustom}", msg)
+	}
+
+	result, cmd = m.Update(msg)
+	m = result.(Model)
+	result, _ = m.Update(cmd())
+	m = result.(Model)
+	if got.SessionID != "sid" || got.IP != "1.2.3.4" || got.Args != "oat milk" {
+		t.Errorf("invocation = %+v", got)
+	}
+	if !strings.Contains(m.statusView(), "Thanks for the coffee!") {
+		t.Errorf("status bar should show the command output: %q", stripANSI(m.statusView()))
+	}
+	result, _ = m.Update(noticeClearMsg{gen: m.noticeGen})
+	if m = result.(Model); strings.Contains(m.statusView(), "coffee") {
+		t.Error("the notice should clear")
+	}
+
+	// A failing or panicking command reports itself without ending the session.
+	bad := &PaletteCommand{Name: "boom", Run: func(context.Context, PaletteInvocation) (string, error) { panic("boom") }}
+	result, _ = m.Update(runPaletteCommand(bad, PaletteInvocation{})())
+	if m = result.(Model); !strings.Contains(m.statusView(), "boom failed") {
+		t.Errorf("status bar should report the failure: %q", stripANSI(m.statusView()))
+	}
+}
+
+func TestSessionLogger(t *testing.T) {
+	var logs bytes.Buffer
+	coffee := &PaletteCommand{Name: "coffee", Run: func(context.Context, PaletteInvocation) (string, error) {
+		return "", errors.New("sold out")
+	}}
+	m := skipIntro(t).SetLogger(slog.New(slog.NewTextHandler(&logs, nil)))
+
+	result, _ := m.navigateTo(SectionWork)
+	m = drainTransition(t, result.(Model))
+	result, cmd := m.Update(PaletteResultMsg{Action: PaletteCustom, Command: coffee, Args: "oat milk", Input: "coffee oat milk"})
+	m = result.(Model)
+	m.Update(cmd())
+
+	for _, want := range []string{
+		`level=INFO msg="section opened" section=work from=home`,
+		`level=INFO msg="palette command" command=coffee`,
+		`level=WARN msg="palette command failed" command=coffee err="sold out"`,
+	} {
+		if !strings.Contains(logs.String(), want) {
+			t.Errorf("log is missing %s:\n%s", want, logs.String())
+		}
+	}
+	if strings.Contains(logs.String(), "oat milk") {
+		t.Errorf("the visitor's arguments should stay out of the log:\n%s", logs.String())
+	}
+}
+
+func TestPaletteAliases(t *testing.T) {
+	tests := []struct {
+		input  string
+		action PaletteAction
+		format string
+	}{
+		{"w", PaletteNavigate, ""},
+		{"h", PaletteHelp, ""},
+		{"dl pdf", PaletteDownload, "pdf"},
+		{" t ", PaletteTheme, ""},
+	}
+	for _, tt := range tests {
+		p := NewPaletteModel(DarkTheme())
+		p.Open()
+		p.input = tt.input
+		_, cmd := p.execute()
+		if cmd == nil {
+			t.Errorf(":%s did not resolve: %s", tt.input, p.err)
+			continue
+		}
+		msg := cmd().(PaletteResultMsg)
+		if msg.Action != tt.action || msg.Format != tt.format {
+			t.Errorf(":%s = %+v, want action %d format %q", tt.input, msg, tt.action, tt.format)
+		}
+	}
+	if !PaletteBuiltin("w") {
+		t.Error("aliases should count as built-in names")
+	}
+}
+
+func TestPaletteSuggestions(t *testing.T) {
+	custom := []PaletteCommand{{Name: "coffee"}}
+	tests := []struct {
+		input, want string
+	}{
+		{"wrok", "work"},
+		{"thme", "theme"},
+		{"guestbok", "guestbook"},
+		{"cofee", "coffee"},
+		{"downlaod pdf", "download pdf"},
+		{"xyzzy", ""},
+		{"z", ""},
+	}
+	for _, tt := range tests {
+		if got := suggestCommand(tt.input, custom); got != tt.want {
+			t.Errorf("suggestCommand(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+
+	p := NewPaletteModel(DarkTheme())
+	p.Open()
+	p.SetWidth(80)
+	p.input = "wrok"
+	p, _ = p.execute()
+	if !strings.Contains(stripANSI(p.View()), "did you mean work?") {
+		t.Errorf("palette should suggest work: %q", stripANSI(p.View()))
+	}
+}
+
+// typePalette types text into the palette one key at a time.
+func typePalette(p PaletteModel, text string) PaletteModel {
+	for _, r := range text {
+		p, _ = p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	return p
+}
+
+func TestPaletteHistory(t *testing.T) {
+	p := NewPaletteModel(DarkTheme())
+	for _, cmd := range []string{"work", "theme", "theme", "cv"} {
+		p.Open()
+		p = typePalette(p, cmd)
+		p, _ = p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	}
+	p.Open()
+	p.input = "bogus"
+	p, _ = p.execute()
+	if len(p.history) != 3 {
+		t.Fatalf("history = %q, want repeats and unknown commands left out", p.history)
+	}
+
+	p.Open()
+	p = typePalette(p, "li")
+	up := tea.KeyMsg{Type: tea.KeyUp}
+	down := tea.KeyMsg{Type: tea.KeyDown}
+	for _, want := range []string{"cv", "theme", "work", "work"} {
+		if p, _ = p.Update(up); p.input != want {
+			t.Errorf("up: input = %q, want %q", p.input, want)
+		}
+	}
+	for _, want := range []string{"theme", "cv", "li", "li"} {
+		if p, _ = p.Update(down); p.input != want {
+			t.Errorf("down: input = %q, want %q", p.input, want)
+		}
+	}
+
+	p, _ = p.Update(up)
+	p, cmd := p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if msg := cmd().(PaletteResultMsg); msg.Action != PaletteNavigate || msg.Section != SectionCV {
+		t.Errorf("a recalled command should run: got %+v", msg)
+	}
+}
+
+func TestPaletteTabCompletion(t *testing.T) {
+	tab := tea.KeyMsg{Type: tea.KeyTab}
+	p := NewPaletteModel(DarkTheme())
+	p.SetCommands([]PaletteCommand{{Name: "weather"}})
+	p.Open()
+
+	tests := []struct {
+		typed string
+		tabs  []string // input after each tab
+	}{
+		{"gu", []string{"guestbook"}},
+		{"do", []string{"download", "download pdf", "download txt", "download"}},
+		{"we", []string{"weather"}},
+		{"gstbk", []string{"guestbook"}}, // no prefix match: the closest fuzzy one
+		{"zz", []string{"zz"}},
+	}
+	for _, tt := range tests {
+		p.Open()
+		p = typePalette(p, tt.typed)
+		for i, want := range tt.tabs {
+			if p, _ = p.Update(tab); p.input != want {
+				t.Errorf("%q tab %d: input = %q, want %q", tt.typed, i+1, p.input, want)
+			}
+		}
+	}
+
+	// Hidden sections are not offered.
+	p.Open()
+	p = typePalette(p, "adm")
+	if p, _ = p.Update(tab); p.input != "adm" {
+		t.Errorf("the hidden admin section should not complete, got %q", p.input)
+	}
+	hidden := p.hidden
+	hidden[SectionAdmin] = false
+	p.SetHidden(hidden)
+	if p, _ = p.Update(tab); p.input != "admin" {
+		t.Errorf("a shown admin section should complete, got %q", p.input)
+	}
+}
+
+func TestPaletteLiveSuggestions(t *testing.T) {
+	p := NewPaletteModel(DarkTheme())
+	p.SetWidth(80)
+	p.SetCommands([]PaletteCommand{{Name: "weather", Description: "Local forecast"}})
+	p.Open()
+
+	p = typePalette(p, "d")
+	view := stripANSI(p.View())
+	if !strings.Contains(view, "debug  download  download pdf  download txt") || strings.Contains(view, "home work") {
+		t.Errorf("typing should replace the hints with matches:\n%s", view)
+	}
+	p = typePalette(p, "bg")
+	if view = stripANSI(p.View()); !strings.Contains(view, "debug") || strings.Contains(view, "download") {
+		t.Errorf("fuzzy matches should narrow as you type:\n%s", view)
+	}
+
+	p.Open()
+	p = typePalette(p, "weather tomorrow")
+	if view = stripANSI(p.View()); !strings.Contains(view, "weather \u2014 Local forecast") {
+		t.Errorf("a custom command with arguments should show its description:\n%s", view)
+	}
+
+	p.Open()
+	p = typePalette(p, "wrok")
+	if view = stripANSI(p.View()); !strings.Contains(view, "did you mean work?") {
+		t.Errorf("no match should still suggest a typo fix:\n%s", view)
+	}
+}
+
+func TestSplitPaletteArgs(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string
+		err  bool
+	}{
+		{"", nil, false},
+		{"  2 ", []string{"2"}, false},
+		{"a  b", []string{"a", "b"}, false},
+		{`"personal site" x`, []string{"personal site", "x"}, false},
+		{`""`, []string{""}, false},
+		{`"open`, nil, true},
+	}
+	for _, tt := range tests {
+		got, err := splitPaletteArgs(tt.in)
+		if (err != nil) != tt.err || !slices.Equal(got, tt.want) {
+			t.Errorf("splitPaletteArgs(%q) = %q, %v; want %q", tt.in, got, err, tt.want)
+		}
+	}
+}
+
+func TestPaletteArgCommands(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    PaletteResultMsg
+		wantErr string
+	}{
+		{input: "open 2", want: PaletteResultMsg{Action: PaletteOpen, Index: 1}},
+		{input: "open", wantErr: "open: expected a project number"},
+		{input: "open two", wantErr: `open: "two" is not a project number`},
+		{input: "open 0", wantErr: `open: "0" is not a project number`},
+		{input: "copy Email", want: PaletteResultMsg{Action: PaletteCopy, Target: "email"}},
+		{input: `copy "my blog"`, want: PaletteResultMsg{Action: PaletteCopy, Target: "my blog"}},
+		{input: "copy", wantErr: "copy: expected what to copy, such as email"},
+		{input: "goto cv", want: PaletteResultMsg{Action: PaletteNavigate, Section: SectionCV}},
+		{input: "goto w", want: PaletteResultMsg{Action: PaletteNavigate, Section: SectionWork}},
+		{input: "goto 4", want: PaletteResultMsg{Action: PaletteNavigate, Section: SectionLinks}},
+		{input: "goto admin", wantErr: `goto: no section "admin"`},
+		{input: "goto Skills", want: PaletteResultMsg{Action: PaletteAnchor, Target: "skills"}},
+		{input: "goto cv work", wantErr: "goto: expected only a section"},
+		{input: "theme light", want: PaletteResultMsg{Action: PaletteTheme, Target: ThemeLight}},
+		{input: "t DARK", want: PaletteResultMsg{Action: PaletteTheme, Target: ThemeDark}},
+		{input: "theme blue", wantErr: `theme: no theme "blue"; use dark or light`},
+		{input: `copy "email`, wantErr: "copy: unclosed quote"},
+		{input: "lang DE", want: PaletteResultMsg{Action: PaletteLang, Target: "de"}},
+		{input: "lang fr", wantErr: `lang: no language "fr"; use de, en`},
+	}
+	for _, tt := range tests {
+		p := NewPaletteModel(DarkTheme())
+		p.Open()
+		p.input = tt.input
+		p, cmd := p.execute()
+		if tt.wantErr != "" {
+			if cmd != nil || p.err != tt.wantErr || p.input != tt.input || !p.Visible() {
+				t.Errorf(":%s: err = %q, input %q; want %q with the input kept", tt.input, p.err, p.input, tt.wantErr)
+			}
+			continue
+		}
+		if cmd == nil {
+			t.Errorf(":%s did not resolve: %s", tt.input, p.err)
+			continue
+		}
+		got := cmd().(PaletteResultMsg)
+		tt.want.Input = tt.input
+		if got != tt.want {
+			t.Errorf(":%s = %+v, want %+v", tt.input, got, tt.want)
+		}
+	}
+	if !PaletteBuiltin("open") {
+		t.Error("commands with arguments should count as built-in names")
+	}
+}
+
+func TestPaletteOpenAndCopy(t *testing.T) {
+	m := skipIntro(t)
+	m.content.Work.Projects = []content.WorkProject{
+		{Title: "Old", URL: "https://old.example"},
+		{Title: "Star", Repo: "https://github.com/test/star", Featured: true},
+		{Title: "Bare"},
+	}
+	m.content.Links.Links = []content.Link{{Label: "GitHub", URL: "https://github.com/test"}}
+
+	copied := func(t *testing.T, cmd tea.Cmd) string {
+		t.Helper()
+		msg, _ := cmd().(tea.BatchMsg)[0]().(ClipboardMsg)
+		return msg.Text
+	}
+
+	// Projects are numbered featured first, as the work section lists them.
+	result, cmd := m.Update(PaletteResultMsg{Action: PaletteOpen, Index: 0, Input: "open 1"})
+	m = result.(Model)
+	if got := copied(t, cmd); got != "https://github.com/test/star" {
+		t.Errorf(":open 1 copied %q, want the featured project's repo", got)
+	}
+	if !strings.Contains(m.statusView(), "Star link copied!") {
+		t.Errorf("status bar = %q", stripANSI(m.statusView()))
+	}
+
+	result, cmd = m.Update(PaletteResultMsg{Action: PaletteCopy, Target: "email", Input: "copy email"})
+	if got := copied(t, cmd); got != "test@example.com" {
+		t.Errorf(":copy email copied %q", got)
+	}
+	result, cmd = result.(Model).Update(PaletteResultMsg{Action: PaletteCopy, Target: "github", Input: "copy github"})
+	if got := copied(t, cmd); got != "https://github.com/test" {
+		t.Errorf(":copy github copied %q", got)
+	}
+	m = result.(Model)
+
+	// Arguments the content cannot satisfy reopen the palette with why.
+	for _, tt := range []struct {
+		msg  PaletteResultMsg
+		want string
+	}{
+		{PaletteResultMsg{Action: PaletteOpen, Index: 5, Input: "open 6"}, "open: no project 6; pick 1-3"},
+		{PaletteResultMsg{Action: PaletteOpen, Index: 2, Input: "open 3"}, "open: Bare has no link"},
+		{PaletteResultMsg{Action: PaletteCopy, Target: "fax", Input: "copy fax"}, `copy: no "fax"`},
+		{PaletteResultMsg{Action: PaletteCopy, Target: "book", Input: "copy book"}, "copy: no booking link given"},
+	} {
+		result, _ := m.Update(tt.msg)
+		got := result.(Model)
+		if !got.showPalette || got.palette.input != tt.msg.Input || !strings.HasPrefix(got.palette.err, tt.want) {
+			t.Errorf(":%s: palette shown %v, input %q, err %q; want %q", tt.msg.Input, got.showPalette, got.palette.input, got.palette.err, tt.want)
+		}
+	}
+
+	result, _ = m.Update(PaletteResultMsg{Action: PaletteTheme, Target: ThemeDark})
+	if m = result.(Model); m.theme.Name != ThemeDark || !strings.Contains(m.statusView(), "Already using the dark theme") {
+		t.Errorf(":theme dark on dark = %q, %q", m.theme.Name, stripANSI(m.statusView()))
+	}
+	result, _ = m.Update(PaletteResultMsg{Action: PaletteTheme, Target: ThemeLight})
+	if m = result.(Model); m.theme.Name != ThemeLight {
+		t.Errorf(":theme light = %q", m.theme.Name)
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"work", "work", 0},
+		{"", "cv", 2},
+		{"wrok", "work", 1},
+		{"kitten", "sitting", 3},
+		{"thme", "theme", 1},
+	}
+	for _, tt := range tests {
+		if got := editDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestThemeByName(t *testing.T) {
+	for _, name := range []string{ThemeDark, ThemeLight} {
+		th, ok := ThemeByName(name)
+		if !ok || th.Name != name {
+			t.Errorf("ThemeByName(%q) = %q, %v", name, th.Name, ok)
+		}
+	}
+	if _, ok := ThemeByName("solarized"); ok {
+		t.Error("ThemeByName accepted an unknown name")
+	}
+	if DarkTheme().Colors == LightTheme().Colors {
+		t.Error("light and dark themes share a palette")
+	}
+	if got := DarkTheme().Toggled().Toggled().Name; got != ThemeDark {
+		t.Errorf("toggling twice = %q, want %q", got, ThemeDark)
+	}
+}
+
+func TestSetTheme(t *testing.T) {
+	spy := &spySection{}
+	m := New(testContent(), spy).SetTheme(LightTheme())
+	if m.theme.Name != ThemeLight || m.navBar.theme.Name != ThemeLight || spy.themeName != ThemeLight {
+		t.Errorf("SetTheme(light): model %q, navbar %q, section %q",
+			m.theme.Name, m.navBar.theme.Name, spy.themeName)
+	}
+}
+
+// captureSection is a spySection that takes text input while capturing.
+type captureSection struct {
+	spySection
+	capturing bool
+	keys      string
+}
+
+func (s *captureSection) CapturingInput() bool { return s.capturing }
+
+func (s *captureSection) Update(msg tea.Msg) (SectionModel, tea.Cmd) {
+	if k, ok := msg.(tea.KeyMsg); ok {
+		s.keys += k.String()
+	}
+	return s, nil
+}
+
+func TestInputCapturerReceivesGlobalKeys(t *testing.T) {
+	sec := &captureSection{capturing: true}
+	m := New(testContent(), sec)
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	m = result.(Model)
+	result, _ = m.Update(IntroDoneMsg{})
+	m = result.(Model)
+
+	for _, r := range "qt2:" {
+		result, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		m = result.(Model)
+		if cmd != nil {
+			if _, quit := cmd().(tea.QuitMsg); quit {
+				t.Fatalf("%q quit while the section was capturing input", r)
+			}
+		}
+	}
+	if sec.keys != "qt2:" {
+		t.Errorf("section received %q, want all keys", sec.keys)
+	}
+	if m.activeSection != SectionHome || m.showPalette || m.theme.Name != ThemeDark {
+		t.Error("global bindings fired while the section was capturing input")
+	}
+
+	// ctrl+c always quits.
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlC})
+	if cmd == nil {
+		t.Fatal("ctrl+c should quit even while capturing")
+	}
+}
+
+func TestPaletteA11y(t *testing.T) {
+	m := skipIntro(t)
+	result, _ := m.Update(PaletteResultMsg{Action: PaletteA11y})
+	m = result.(Model)
+	if !strings.Contains(m.statusView(), "not available") {
+		t.Errorf("without a switch :a11y should say so: %q", stripANSI(m.statusView()))
+	}
+
+	started := ""
+	de, _ := i18n.Lookup("de")
+	m = skipIntro(t).SetLocale(de).SetA11ySwitch(func(locale string) { started = locale })
+	_, cmd := m.Update(PaletteResultMsg{Action: PaletteA11y})
+	if started != "de" || cmd == nil {
+		t.Fatalf("started in %q, cmd = %v; want the switch in de and a quit", started, cmd)
+	}
+	if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Error(":a11y should quit the program")
+	}
+}
@@ -0,0 +1,35 @@
+package app
+
+import (
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/buntingszn/terminal-portfolio/tui/internal/content"
+)
+
+// AvailabilityBadge renders a as a solid badge followed by its roles and
+// start date in muted text, truncated to width. The badge is green when
+// the owner is open to work now, amber when only from a later date, and
+// muted when not looking.
+func AvailabilityBadge(theme Theme, a content.Availability, now time.Time, width int) string {
+	color := theme.Colors.Muted
+	if a.OpenToWork {
+		color = theme.Colors.Success
+		if _, later := a.StartsAfter(now); later {
+			color = theme.Colors.Warning
+		}
+	}
+	badge := theme.NewStyle().
+		Background(color).
+		Foreground(theme.Colors.Bg).
+		Bold(true).
+		Render(" " + a.Label() + " ")
+
+	details := a.Details(now)
+	room := width - lipgloss.Width(badge) - 1
+	if details == "" || room <= 0 {
+		return badge
+	}
+	return badge + " " + theme.Muted.Render(TruncateWithEllipsis(details, room))
+}
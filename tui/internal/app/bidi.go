@@ -0,0 +1,308 @@
+package app
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"golang.org/x/text/unicode/bidi"
+)
+
+// Bidi mode sequences (ECMA-48 BDSM). Most terminals draw text in the
+// order it arrives, so the TUI puts right-to-left runs in display order
+// itself; terminals that reorder them too are switched to explicit mode,
+// which leaves the order to the TUI, and back to their default implicit
+// mode when the session quits.
+const (
+	bidiExplicitMode = "\x1b[8l"
+	bidiImplicitMode = "\x1b[8h"
+)
+
+// quitCmd ends the session, first returning the terminal to implicit bidi
+// mode if the TUI reordered its text.
+func (m Model) quitCmd() tea.Cmd {
+	restore := writeOutput(m.output, bidiImplicitMode)
+	if !m.reorderRTL || restore == nil {
+		return tea.Quit
+	}
+	return func() tea.Msg {
+		restore()
+		return tea.Quit()
+	}
+}
+
+// mirrored pairs the brackets that swap when drawn right to left.
+var mirrored = map[rune]rune{
+	'(': ')', ')': '(', '[': ']', ']': '[', '{': '}', '}': '{',
+	'<': '>', '>': '<', '«': '»', '»': '«',
+}
+
+// hasRTL reports whether s holds a right-to-left letter, such as Hebrew
+// or Arabic.
+func hasRTL(s string) bool {
+	for _, r := range s {
+		if r >= 0x0590 && isRTL(r) {
+			return true
+		}
+	}
+	return false
+}
+
+func isRTL(r rune) bool {
+	p, _ := bidi.LookupRune(r)
+	return p.Class() == bidi.R || p.Class() == bidi.AL
+}
+
+// visualFrame puts the right-to-left runs in each line of frame in the
+// order they are read, as visualLine does.
+func visualFrame(frame string) string {
+	if !hasRTL(frame) {
+		return frame
+	}
+	lines := strings.Split(frame, "\n")
+	for i, line := range lines {
+		if hasRTL(line) {
+			lines[i] = visualLine(line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// bidiCell is one character of a line: a rune with the marks that combine
+// with it, and the styles in effect from the SGR sequences before it.
+type bidiCell struct {
+	text  string
+	style string
+	class bidi.Class
+	level int
+}
+
+// visualLine reorders a left-to-right line holding right-to-left text
+// into display order, following the Unicode bidirectional algorithm
+// without explicit embeddings: each right-to-left run is reversed, with
+// any numbers in it kept left to right and its brackets mirrored. Styles
+// move with the characters they apply to. Lines with escape sequences
+// other than SGR, such as hyperlinks, are returned unchanged, since their
+// sequences cannot move with the text.
+func visualLine(line string) string {
+	var cells []bidiCell
+	style := ""
+	for i := 0; i < len(line); {
+		if line[i] == '\x1b' {
+			n, ok := sgrLen(line[i:])
+			if !ok {
+				return line
+			}
+			seq := line[i : i+n]
+			if seq == "\x1b[m" || seq == "\x1b[0m" {
+				style = ""
+			} else {
+				style += seq
+			}
+			i += n
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(line[i:])
+		text := line[i : i+size]
+		i += size
+		if len(cells) > 0 && (unicode.In(r, unicode.Mn, unicode.Me) || r == '\u200d') {
+			cells[len(cells)-1].text += text
+			continue
+		}
+		p, _ := bidi.LookupRune(r)
+		cells = append(cells, bidiCell{text: text, style: style, class: p.Class()})
+	}
+
+	resolveLevels(cells)
+	reorderCells(cells)
+
+	var b strings.Builder
+	current := ""
+	for _, c := range cells {
+		if c.style != current {
+			b.WriteString("\x1b[0m" + c.style)
+			current = c.style
+		}
+		text := c.text
+		if c.level%2 == 1 {
+			if r, size := utf8.DecodeRuneInString(text); mirrored[r] != 0 {
+				text = string(mirrored[r]) + text[size:]
+			}
+		}
+		b.WriteString(text)
+	}
+	// Leave the styles in effect at the end of the line as they were.
+	if current != style {
+		b.WriteString("\x1b[0m" + style)
+	}
+	return b.String()
+}
+
+// sgrLen returns the length of the SGR sequence at the start of s, or
+// false if s starts with another escape sequence.
+func sgrLen(s string) (int, bool) {
+	if len(s) < 3 || s[1] != '[' {
+		return 0, false
+	}
+	for i := 2; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == 'm':
+			return i + 1, true
+		case c >= '0' && c <= '9', c == ';', c == ':':
+		default:
+			return 0, false
+		}
+	}
+	return 0, false
+}
+
+// resolveLevels sets each cell's embedding level in a left-to-right
+// paragraph: 0 for left-to-right text, 1 for right-to-left, and 2 for
+// numbers within right-to-left text (rules W2-W7, N1-N2, I1, and L1).
+func resolveLevels(cells []bidiCell) {
+	types := make([]bidi.Class, len(cells))
+	for i, c := range cells {
+		types[i] = c.class
+		// The layout reads left to right: borders, bars, and the gaps of
+		// two or more spaces between columns stay where they are, so no
+		// right-to-left run reaches across them.
+		r, _ := utf8.DecodeRuneInString(c.text)
+		gap := r == ' ' && ((i > 0 && cells[i-1].text == " ") || (i+1 < len(cells) && cells[i+1].text == " "))
+		if gap || (r >= 0x2500 && r <= 0x259f) {
+			types[i] = bidi.L
+		}
+	}
+	// W2, W3: numbers after Arabic letters are Arabic numbers, and Arabic
+	// letters are right to left.
+	strong := bidi.L
+	for i, t := range types {
+		switch t {
+		case bidi.L, bidi.R, bidi.AL:
+			strong = t
+		case bidi.EN:
+			if strong == bidi.AL {
+				types[i] = bidi.AN
+			}
+		}
+		if types[i] == bidi.AL {
+			types[i] = bidi.R
+		}
+	}
+	// W4: a single separator between two numbers of one kind joins them.
+	for i := 1; i+1 < len(types); i++ {
+		prev, next := types[i-1], types[i+1]
+		switch {
+		case types[i] == bidi.ES && prev == bidi.EN && next == bidi.EN,
+			types[i] == bidi.CS && prev == next && (prev == bidi.EN || prev == bidi.AN):
+			types[i] = prev
+		}
+	}
+	// W5: terminators such as currency signs next to a number join it.
+	for i := 0; i < len(types); i++ {
+		if types[i] != bidi.ET {
+			continue
+		}
+		j := i
+		for j < len(types) && types[j] == bidi.ET {
+			j++
+		}
+		if (i > 0 && types[i-1] == bidi.EN) || (j < len(types) && types[j] == bidi.EN) {
+			for k := i; k < j; k++ {
+				types[k] = bidi.EN
+			}
+		}
+		i = j
+	}
+	// W6, W7: other separators are neutral, and numbers in left-to-right
+	// text are left to right.
+	strong = bidi.L
+	for i, t := range types {
+		switch t {
+		case bidi.ES, bidi.ET, bidi.CS:
+			types[i] = bidi.ON
+		case bidi.L, bidi.R:
+			strong = t
+		case bidi.EN:
+			if strong == bidi.L {
+				types[i] = bidi.L
+			}
+		}
+	}
+	// N1, N2: neutrals between text of one direction take it, counting
+	// numbers as right to left; others take the paragraph's.
+	dir := func(t bidi.Class) (bidi.Class, bool) {
+		switch t {
+		case bidi.L:
+			return bidi.L, true
+		case bidi.R, bidi.EN, bidi.AN:
+			return bidi.R, true
+		}
+		return 0, false
+	}
+	for i := 0; i < len(types); i++ {
+		if _, ok := dir(types[i]); ok {
+			continue
+		}
+		j := i
+		for j < len(types) {
+			if _, ok := dir(types[j]); ok {
+				break
+			}
+			j++
+		}
+		before, after := bidi.L, bidi.L
+		if i > 0 {
+			before, _ = dir(types[i-1])
+		}
+		if j < len(types) {
+			after, _ = dir(types[j])
+		}
+		resolved := bidi.L
+		if before == after {
+			resolved = before
+		}
+		for k := i; k < j; k++ {
+			types[k] = resolved
+		}
+		i = j
+	}
+	// I1, and L1 for trailing whitespace.
+	for i, t := range types {
+		switch t {
+		case bidi.R:
+			cells[i].level = 1
+		case bidi.EN, bidi.AN:
+			cells[i].level = 2
+		default:
+			cells[i].level = 0
+		}
+	}
+	for i := len(cells) - 1; i >= 0 && strings.TrimSpace(cells[i].text) == ""; i-- {
+		cells[i].level = 0
+	}
+}
+
+// reorderCells reverses every run of cells at each level or above, from
+// the highest level down to 1 (rule L2).
+func reorderCells(cells []bidiCell) {
+	highest := 0
+	for _, c := range cells {
+		highest = max(highest, c.level)
+	}
+	for level := highest; level >= 1; level-- {
+		for i := 0; i < len(cells); i++ {
+			if cells[i].level < level {
+				continue
+			}
+			j := i
+			for j < len(cells) && cells[j].level >= level {
+				j++
+			}
+			for a, b := i, j-1; a < b; a, b = a+1, b-1 {
+				cells[a], cells[b] = cells[b], cells[a]
+			}
+			i = j
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package app
+
+import (
+	"bytes"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestVisualLine(t *testing.T) {
+	for _, tt := range []struct {
+		name, line, want string
+	}{
+		{"reversed run", "Project שלום here", "Project םולש here"},
+		{"words in order", "Built שלום עולם", "Built םלוע םולש"},
+		{"numbers kept", "שלום 123 עולם", "םלוע 123 םולש"},
+		{"Arabic digits", "سلام 2024", "2024 مالس"},
+		{"brackets mirrored", "א(ב)ג", "ג(ב)א"},
+		{"column gap", "שלום  עולם", "םולש  םלוע"},
+		{"borders stay", "│ שלום │ עולם │", "│ םולש │ םלוע │"},
+		{"combining marks", "ש\u05b8\u05c1לו\u05b9ם", "םו\u05b9לש\u05b8\u05c1"},
+	} {
+		if got := visualLine(tt.line); got != tt.want {
+			t.Errorf("%s: visualLine(%q) = %q, want %q", tt.name, tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestVisualLineStyles(t *testing.T) {
+	got := visualLine("\x1b[1mab\x1b[0m \x1b[31mשל\x1b[0m")
+	want := "\x1b[0m\x1b[1mab\x1b[0m \x1b[0m\x1b[31mלש\x1b[0m"
+	if got != want {
+		t.Errorf("styled line = %q, want %q", got, want)
+	}
+}
+
+func TestVisualLineLeavesOtherSequences(t *testing.T) {
+	line := "\x1b]8;;https://example.com\x1b\\שלום\x1b]8;;\x1b\\"
+	if got := visualLine(line); got != line {
+		t.Errorf("a line with a hyperlink = %q, want it unchanged", got)
+	}
+}
+
+func TestVisualFrame(t *testing.T) {
+	frame := "plain\nשלום\n│ box │"
+	if got, want := visualFrame(frame), "plain\nםולש\n│ box │"; got != want {
+		t.Errorf("visualFrame = %q, want %q", got, want)
+	}
+	if got := visualFrame("left to right only"); got != "left to right only" {
+		t.Errorf("a frame without RTL text changed: %q", got)
+	}
+}
+
+func TestReorderRTLBidiMode(t *testing.T) {
+	var out bytes.Buffer
+	m := New(testContent()).SetOutput(&out)
+	// The bidi mode is the last of the commands Init starts.
+	init := m.Init()().(tea.BatchMsg)
+	init[len(init)-1]()
+	if out.String() != bidiExplicitMode {
+		t.Errorf("Init wrote %q, want the explicit bidi mode", out.String())
+	}
+	out.Reset()
+	if _, ok := m.quitCmd()().(tea.QuitMsg); !ok || out.String() != bidiImplicitMode {
+		t.Errorf("quitting wrote %q, want the implicit bidi mode restored before quitting", out.String())
+	}
+
+	out.Reset()
+	m = m.SetReorderRTL(false)
+	init = m.Init()().(tea.BatchMsg)
+	init[len(init)-1]()
+	if out.Len() > 0 {
+		t.Errorf("Init wrote %q with reordering off", out.String())
+	}
+	if _, ok := m.quitCmd()().(tea.QuitMsg); !ok || out.Len() > 0 {
+		t.Errorf("quitting wrote %q with reordering off", out.String())
+	}
+}
@@ -39,12 +39,23 @@ const (
 //
 // If width < 10, returns content without any border decoration.
 func RenderCard(theme Theme, title, content string, width int) string {
+	return renderCard(theme, title, content, width, theme.Colors.Border, theme.Colors.Accent)
+}
+
+// RenderWarningCard renders a card like RenderCard with its border and
+// title in the theme's warning color, for notices that need attention.
+func RenderWarningCard(theme Theme, title, content string, width int) string {
+	return renderCard(theme, title, content, width, theme.Colors.Warning, theme.Colors.Warning)
+}
+
+// renderCard draws a card with the given border and title colors.
+func renderCard(theme Theme, title, content string, width int, border, accent lipgloss.Color) string {
 	if width < 10 {
 		return content
 	}
 
-	borderStyle := lipgloss.NewStyle().Foreground(theme.Colors.Border)
-	accentStyle := lipgloss.NewStyle().Foreground(theme.Colors.Accent)
+	borderStyle := theme.NewStyle().Foreground(border)
+	accentStyle := theme.NewStyle().Foreground(accent)
 
 	// Inner width is total width minus two border columns and two padding spaces.
 	innerWidth := width - 4
@@ -108,7 +119,7 @@ func RenderDivider(theme Theme, width int) string {
 	if width <= 0 {
 		return ""
 	}
-	borderStyle := lipgloss.NewStyle().Foreground(theme.Colors.Border)
+	borderStyle := theme.NewStyle().Foreground(theme.Colors.Border)
 	return borderStyle.Render(strings.Repeat(borderHorizontal, width))
 }
 
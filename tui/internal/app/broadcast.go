@@ -0,0 +1,75 @@
+package app
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	// broadcastDuration is how long a broadcast stays in the status bar;
+	// longer than a notice, since it may arrive while no one is looking.
+	broadcastDuration = 10 * time.Second
+
+	// The status bar starts reversed and switches broadcastFlashes times,
+	// once every broadcastFlashInterval. The count is odd so the bar ends
+	// back to normal.
+	broadcastFlashInterval = 250 * time.Millisecond
+	broadcastFlashes       = 5
+)
+
+// broadcastFlashMsg switches the status bar flash for the broadcast
+// notice gen; left counts the switches still to come.
+type broadcastFlashMsg struct {
+	gen  int
+	left int
+}
+
+// SetBell configures whether broadcasts ring the terminal bell. The bell
+// is off by default; the flash is always shown.
+func (m Model) SetBell(on bool) Model {
+	m.bell = on
+	return m
+}
+
+// handleBroadcast shows a broadcast in the status bar, starts the flash,
+// and rings the bell if enabled.
+func (m Model) handleBroadcast(msg BroadcastMsg) (tea.Model, tea.Cmd) {
+	if msg.Text == "" {
+		return m, nil
+	}
+	m.statusBar.SetNotice(msg.Text)
+	m.statusBar.SetFlash(true)
+	m.noticeGen++
+	gen := m.noticeGen
+	cmds := []tea.Cmd{
+		tea.Tick(broadcastDuration, func(time.Time) tea.Msg {
+			return noticeClearMsg{gen: gen}
+		}),
+		broadcastFlashTick(gen, broadcastFlashes-1),
+	}
+	if m.bell {
+		cmds = append(cmds, writeOutput(m.output, "\a"))
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// handleBroadcastFlash switches the flash for the current broadcast,
+// ending with the bar back to normal.
+func (m Model) handleBroadcastFlash(msg broadcastFlashMsg) (tea.Model, tea.Cmd) {
+	if msg.gen != m.noticeGen {
+		return m, nil
+	}
+	m.statusBar.SetFlash(msg.left%2 == 1)
+	if msg.left == 0 {
+		return m, nil
+	}
+	return m, broadcastFlashTick(msg.gen, msg.left-1)
+}
+
+// broadcastFlashTick schedules the next flash switch.
+func broadcastFlashTick(gen, left int) tea.Cmd {
+	return tea.Tick(broadcastFlashInterval, func(time.Time) tea.Msg {
+		return broadcastFlashMsg{gen: gen, left: left}
+	})
+}
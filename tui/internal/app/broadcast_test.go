@@ -0,0 +1,55 @@
+package app
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestBroadcastFlashesStatusBar(t *testing.T) {
+	m := skipIntro(t)
+	result, cmd := m.Update(BroadcastMsg{Text: "Server restarting"})
+	m = result.(Model)
+	if !strings.Contains(m.statusBar.Render(m.activeSection, "", ScrollInfo{Fits: true}), "Server restarting") {
+		t.Error("a broadcast should show in the status bar")
+	}
+	if !m.statusBar.flash {
+		t.Error("a broadcast should start flashing the status bar")
+	}
+	if n := len(cmd().(tea.BatchMsg)); n != 2 {
+		t.Errorf("broadcast cmds = %d, want the clear and flash ticks without a bell", n)
+	}
+
+	var states []bool
+	for left := broadcastFlashes - 1; left >= 0; left-- {
+		result, _ = m.Update(broadcastFlashMsg{gen: m.noticeGen, left: left})
+		m = result.(Model)
+		states = append(states, m.statusBar.flash)
+	}
+	if want := []bool{false, true, false, true, false}; !slices.Equal(states, want) {
+		t.Errorf("flash states = %v, want %v", states, want)
+	}
+
+	// A stale flash from an earlier notice changes nothing.
+	result, _ = m.Update(broadcastFlashMsg{gen: m.noticeGen - 1, left: 1})
+	if result.(Model).statusBar.flash {
+		t.Error("a stale flash should be ignored")
+	}
+}
+
+func TestBroadcastBell(t *testing.T) {
+	var out bytes.Buffer
+	m := skipIntro(t).SetOutput(&out).SetBell(true)
+	_, cmd := m.Update(BroadcastMsg{Text: "Server restarting"})
+	cmds := cmd().(tea.BatchMsg)
+	if len(cmds) != 3 {
+		t.Fatalf("broadcast cmds = %d, want the bell too", len(cmds))
+	}
+	cmds[2]()
+	if out.String() != "\a" {
+		t.Errorf("bell wrote %q, want BEL", out.String())
+	}
+}
@@ -0,0 +1,123 @@
+package app
+
+import (
+	"math/rand/v2"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// chaosShrinkHold is how long a fake resize lasts before the real terminal
+// size is restored.
+const chaosShrinkHold = 2 * time.Second
+
+// Chaos describes faults injected into a session for manual resilience
+// testing: slow input, dropped frames, and a terminal that shrinks under
+// the layout. The zero value injects nothing. Meant for debug mode only.
+type Chaos struct {
+	// Latency delays the handling of each key and mouse event by a random
+	// duration around it, as a slow link would.
+	Latency time.Duration
+	// DropFrames is the probability, from 0 to 1, that a frame repeats
+	// the previous one instead of showing the current state.
+	DropFrames float64
+	// Shrink is the average interval between fake resizes to a random
+	// smaller size, possibly below MinWidth×MinHeight. Each lasts
+	// chaosShrinkHold before the real size returns.
+	Shrink time.Duration
+}
+
+// chaosState is the session's fault injection state. Model holds it by
+// pointer so View can remember the last frame through value copies.
+type chaosState struct {
+	Chaos
+
+	lastFrame     string
+	width, height int // real terminal size
+	shrunk        bool
+}
+
+// chaosShrinkMsg starts a fake resize.
+type chaosShrinkMsg struct{}
+
+// chaosRestoreMsg ends a fake resize.
+type chaosRestoreMsg struct{}
+
+// SetChaos enables fault injection for the session. It should be called
+// before Init().
+func (m Model) SetChaos(c Chaos) Model {
+	if c == (Chaos{}) {
+		m.chaos = nil
+		return m
+	}
+	m.chaos = &chaosState{Chaos: c}
+	return m
+}
+
+// jitter returns a random duration between d/2 and 3d/2.
+func jitter(d time.Duration) time.Duration {
+	return d/2 + rand.N(d+1)
+}
+
+// chaosShrinkTick schedules the next fake resize.
+func (m Model) chaosShrinkTick() tea.Cmd {
+	return tea.Tick(jitter(m.chaos.Shrink), func(time.Time) tea.Msg {
+		return chaosShrinkMsg{}
+	})
+}
+
+// chaosDelay sleeps for the configured input latency.
+func (m Model) chaosDelay() {
+	if m.chaos != nil && m.chaos.Latency > 0 {
+		time.Sleep(jitter(m.chaos.Latency))
+	}
+}
+
+// handleChaosShrink resizes the layout to a random size no larger than
+// the real terminal, then schedules the real size's return.
+func (m Model) handleChaosShrink() (tea.Model, tea.Cmd) {
+	c := m.chaos
+	if c.width == 0 || c.height == 0 {
+		return m, m.chaosShrinkTick()
+	}
+	fake := tea.WindowSizeMsg{
+		Width:  randomSize(MinWidth/2, c.width),
+		Height: randomSize(MinHeight/2, c.height),
+	}
+	c.shrunk = true
+	result, cmd := m.handleWindowSize(fake)
+	restore := tea.Tick(chaosShrinkHold, func(time.Time) tea.Msg {
+		return chaosRestoreMsg{}
+	})
+	return result, tea.Batch(cmd, restore)
+}
+
+// handleChaosRestore returns the layout to the real terminal size unless
+// a real resize already has, and schedules the next fake resize.
+func (m Model) handleChaosRestore() (tea.Model, tea.Cmd) {
+	c := m.chaos
+	next := m.chaosShrinkTick()
+	if !c.shrunk {
+		return m, next
+	}
+	c.shrunk = false
+	result, cmd := m.handleWindowSize(tea.WindowSizeMsg{Width: c.width, Height: c.height})
+	return result, tea.Batch(cmd, next)
+}
+
+// randomSize returns a random size between lo and n, or n when n is not
+// above lo.
+func randomSize(lo, n int) int {
+	if n <= lo {
+		return n
+	}
+	return lo + rand.IntN(n-lo+1)
+}
+
+// droppedFrame returns the previous frame when this one should be dropped.
+func (c *chaosState) droppedFrame() (string, bool) {
+	if c == nil || c.DropFrames <= 0 || c.lastFrame == "" {
+		return "", false
+	}
+	return c.lastFrame, rand.Float64() < c.DropFrames
+}
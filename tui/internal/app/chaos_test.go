@@ -0,0 +1,73 @@
+package app
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestSetChaosZeroDisables(t *testing.T) {
+	m := New(testContent()).SetChaos(Chaos{})
+	if m.chaos != nil {
+		t.Error("the zero Chaos should leave fault injection off")
+	}
+}
+
+func TestChaosShrinkAndRestore(t *testing.T) {
+	m := skipIntro(t).SetChaos(Chaos{Shrink: 1})
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	m = result.(Model)
+
+	for range 20 {
+		result, _ = m.Update(chaosShrinkMsg{})
+		m = result.(Model)
+		if m.width > 80 || m.height > 24 || m.width < MinWidth/2 || m.height < MinHeight/2 {
+			t.Fatalf("shrunk to %d×%d, want within %d×%d..80×24", m.width, m.height, MinWidth/2, MinHeight/2)
+		}
+		_ = m.View()
+
+		result, cmd := m.Update(chaosRestoreMsg{})
+		m = result.(Model)
+		if m.width != 80 || m.height != 24 {
+			t.Fatalf("restored to %d×%d, want 80×24", m.width, m.height)
+		}
+		if cmd == nil {
+			t.Fatal("restore should schedule the next shrink")
+		}
+	}
+}
+
+func TestChaosRealResizeWinsOverShrink(t *testing.T) {
+	m := skipIntro(t).SetChaos(Chaos{Shrink: 1})
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	m = result.(Model)
+	result, _ = m.Update(chaosShrinkMsg{})
+	m = result.(Model)
+
+	result, _ = m.Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+	m = result.(Model)
+	result, _ = m.Update(chaosRestoreMsg{})
+	m = result.(Model)
+	if m.width != 100 || m.height != 30 {
+		t.Errorf("size = %d×%d after a real resize, want 100×30", m.width, m.height)
+	}
+}
+
+func TestChaosDropFrames(t *testing.T) {
+	m := skipIntro(t).SetChaos(Chaos{DropFrames: 1})
+	first := m.View()
+
+	result, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("?")})
+	m = result.(Model)
+	if !m.showHelp {
+		t.Fatal("expected help to open")
+	}
+	if got := m.View(); got != first {
+		t.Error("a dropped frame should repeat the previous one")
+	}
+
+	m.chaos.DropFrames = 0
+	if got := m.View(); got == first {
+		t.Error("frames should render again once dropping stops")
+	}
+}
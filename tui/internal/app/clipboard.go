@@ -0,0 +1,51 @@
+package app
+
+import (
+	"io"
+	"log/slog"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ClipboardMsg asks the root model to copy Text to the visitor's clipboard.
+type ClipboardMsg struct {
+	Text string
+}
+
+// CopyToClipboard returns a command that requests a clipboard write. The
+// root model performs the write outside of View, so sections never embed
+// escape sequences in their rendered output.
+func CopyToClipboard(text string) tea.Cmd {
+	return func() tea.Msg {
+		return ClipboardMsg{Text: text}
+	}
+}
+
+// TerminalWriteMsg asks the root model to write Seq to the terminal
+// outside of the rendered frame, for setup sequences such as image uploads
+// that must not be repeated on every render.
+type TerminalWriteMsg struct {
+	Seq string
+}
+
+// WriteTerminal returns a command that requests an out-of-band write.
+func WriteTerminal(seq string) tea.Cmd {
+	return func() tea.Msg {
+		return TerminalWriteMsg{Seq: seq}
+	}
+}
+
+// writeOutput returns a command that writes seq to w in a single Write, so
+// the sequence is not split by a frame flush. It returns nil when there is
+// nothing to write or no output to write to.
+func writeOutput(w io.Writer, seq string) tea.Cmd {
+	if w == nil || seq == "" {
+		return nil
+	}
+	return func() tea.Msg {
+		if _, err := w.Write([]byte(seq)); err != nil {
+			slog.Debug("terminal write failed", "error", err)
+		}
+		return nil
+	}
+}
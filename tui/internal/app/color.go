@@ -1,11 +1,46 @@
 package app
 
 import (
+	"strings"
+
 	"github.com/charmbracelet/lipgloss"
 	"github.com/lucasb-eyer/go-colorful"
+	"github.com/muesli/termenv"
 )
 
 // HexToColorful converts a lipgloss.Color (hex string) to a go-colorful Color.
 func HexToColorful(c lipgloss.Color) (colorful.Color, error) {
 	return colorful.Hex(string(c))
 }
+
+// ColorProfile guesses the color support of a client terminal from its
+// TERM value and the environment variables the client sent. SSH forwards
+// only TERM by default, so COLORTERM upgrades the profile when a client
+// does send it.
+func ColorProfile(term string, environ []string) termenv.Profile {
+	var colorTerm string
+	for _, kv := range environ {
+		if v, ok := strings.CutPrefix(kv, "COLORTERM="); ok {
+			colorTerm = strings.ToLower(v)
+		}
+	}
+	term = strings.ToLower(term)
+
+	switch {
+	case term == "" || term == "dumb":
+		return termenv.Ascii
+	case colorTerm == "truecolor" || colorTerm == "24bit",
+		strings.Contains(term, "truecolor"), strings.Contains(term, "24bit"),
+		strings.HasSuffix(term, "-direct"),
+		term == "xterm-kitty", term == "xterm-ghostty", term == "wezterm", term == "alacritty":
+		return termenv.TrueColor
+	case strings.Contains(term, "256color"), colorTerm == "yes", colorTerm == "true":
+		return termenv.ANSI256
+	case strings.Contains(term, "color"), strings.Contains(term, "ansi"),
+		strings.HasPrefix(term, "xterm"), strings.HasPrefix(term, "screen"),
+		strings.HasPrefix(term, "tmux"), strings.HasPrefix(term, "rxvt"),
+		term == "linux":
+		return termenv.ANSI
+	}
+	return termenv.Ascii
+}
@@ -0,0 +1,100 @@
+package app
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/buntingszn/terminal-portfolio/tui/internal/content"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+func TestColorProfile(t *testing.T) {
+	tests := []struct {
+		term    string
+		environ []string
+		want    termenv.Profile
+	}{
+		{"", nil, termenv.Ascii},
+		{"dumb", []string{"COLORTERM=truecolor"}, termenv.Ascii},
+		{"xterm-256color", nil, termenv.ANSI256},
+		{"xterm-256color", []string{"COLORTERM=truecolor"}, termenv.TrueColor},
+		{"tmux-256color", []string{"LANG=C", "COLORTERM=24bit"}, termenv.TrueColor},
+		{"xterm-kitty", nil, termenv.TrueColor},
+		{"xterm-direct", nil, termenv.TrueColor},
+		{"screen", []string{"COLORTERM=yes"}, termenv.ANSI256},
+		{"xterm", nil, termenv.ANSI},
+		{"linux", nil, termenv.ANSI},
+		{"vt100", nil, termenv.Ascii},
+	}
+	for _, tt := range tests {
+		if got := ColorProfile(tt.term, tt.environ); got != tt.want {
+			t.Errorf("ColorProfile(%q, %v) = %v, want %v", tt.term, tt.environ, got, tt.want)
+		}
+	}
+}
+
+// rendererFor returns a renderer forced to profile.
+func rendererFor(profile termenv.Profile) *lipgloss.Renderer {
+	r := lipgloss.NewRenderer(io.Discard)
+	r.SetColorProfile(profile)
+	return r
+}
+
+func TestThemeForRenderer(t *testing.T) {
+	theme := DarkTheme().ForRenderer(rendererFor(termenv.ANSI256))
+	if theme.Colors != palettes[ThemeDark].ansi256 {
+		t.Errorf("256-color dark theme colors = %+v, want the ansi256 palette", theme.Colors)
+	}
+	if out := theme.Accent.Render("x"); !strings.Contains(out, "38;5;") {
+		t.Errorf("Accent.Render = %q, want a 256-color escape", out)
+	}
+
+	light := theme.Toggled()
+	if light.Colors != palettes[ThemeLight].ansi256 {
+		t.Errorf("toggled theme colors = %+v, want the light ansi256 palette", light.Colors)
+	}
+	if out := light.NewStyle().Foreground(light.Colors.Accent).Render("x"); !strings.Contains(out, "38;5;") {
+		t.Errorf("toggled theme style = %q, want it to keep the 256-color renderer", out)
+	}
+
+	ansi := LightTheme().ForRenderer(rendererFor(termenv.ANSI))
+	if ansi.Colors.Fg == ansi.Colors.Muted || ansi.Colors.Border == ansi.Colors.Bg {
+		t.Errorf("16-color light palette collapses colors: %+v", ansi.Colors)
+	}
+}
+
+func TestThemeWithOverrides(t *testing.T) {
+	o := &content.Theme{
+		Dark:  content.ThemeColors{Accent: "#00ff00", StatusBarBg: "#112233"},
+		Light: content.ThemeColors{Fg: "#123"},
+	}
+	dark := DarkTheme().WithOverrides(o)
+	want := darkColors
+	want.Accent, want.StatusBarBg = "#00ff00", "#112233"
+	if dark.Colors != want {
+		t.Errorf("dark colors = %+v, want %+v", dark.Colors, want)
+	}
+	if bg := dark.StatusBar.GetBackground(); bg != lipgloss.Color("#112233") {
+		t.Errorf("status bar background = %v, want the override", bg)
+	}
+	if fg := dark.StatusBar.GetForeground(); fg != darkColors.Muted {
+		t.Errorf("status bar text = %v, want the muted color when unset", fg)
+	}
+
+	// The overrides apply to every profile and survive toggling.
+	light := dark.ForRenderer(rendererFor(termenv.ANSI256)).Toggled()
+	want = palettes[ThemeLight].ansi256
+	want.Fg = "#123"
+	if light.Colors != want {
+		t.Errorf("toggled colors = %+v, want %+v", light.Colors, want)
+	}
+	if back := light.Toggled(); back.Colors.Accent != "#00ff00" {
+		t.Errorf("toggled back accent = %v, want the dark override", back.Colors.Accent)
+	}
+
+	if plain := dark.WithOverrides(nil); plain.Colors != darkColors {
+		t.Errorf("colors without overrides = %+v, want the built-in ones", plain.Colors)
+	}
+}
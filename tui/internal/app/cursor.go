@@ -34,7 +34,7 @@ func NewCursor(id string, theme Theme) Cursor {
 	return Cursor{
 		visible:  true,
 		interval: DefaultBlinkInterval,
-		style:    lipgloss.NewStyle().Foreground(theme.Colors.Accent),
+		style:    theme.NewStyle().Foreground(theme.Colors.Accent),
 		id:       id,
 	}
 }
@@ -71,7 +71,7 @@ func (c Cursor) Tick() tea.Cmd {
 // tick returns a tea.Cmd that fires a cursorBlinkMsg after the configured interval.
 func (c Cursor) tick() tea.Cmd {
 	id := c.id
-	return tea.Tick(c.interval, func(_ time.Time) tea.Msg {
+	return SubscribeAnimation("cursor:"+id, c.interval, func(_ time.Time) tea.Msg {
 		return cursorBlinkMsg{id: id}
 	})
 }
@@ -0,0 +1,123 @@
+package app
+
+import (
+	"fmt"
+	"runtime"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// debugSampleInterval is how often the debug overlay refreshes its
+// message rate and memory statistics.
+const debugSampleInterval = time.Second
+
+// debugTickMsg triggers a debug overlay sample while the overlay is visible.
+type debugTickMsg struct{}
+
+// debugTick returns a tea.Cmd that fires debugTickMsg after debugSampleInterval.
+func debugTick() tea.Cmd {
+	return tea.Tick(debugSampleInterval, func(_ time.Time) tea.Msg {
+		return debugTickMsg{}
+	})
+}
+
+// AnimationReporter is an optional interface that SectionModels can
+// implement to report how many animations they are currently running.
+// The count is shown in the debug overlay.
+type AnimationReporter interface {
+	ActiveAnimations() int
+}
+
+// debugStats accumulates per-session frame and message statistics. Model
+// holds it by pointer so that View, which has a value receiver, can record
+// render timings that survive across model copies.
+type debugStats struct {
+	visible bool
+
+	// lastFrame is the duration of the most recent View call.
+	lastFrame time.Duration
+
+	// messages counts Update calls since the last sample; msgRate is the
+	// rate computed at that sample.
+	messages   int
+	msgRate    float64
+	lastSample time.Time
+
+	// Memory statistics captured at the last sample.
+	heapAlloc uint64
+	numGC     uint32
+}
+
+// sample recomputes the message rate and memory statistics.
+func (d *debugStats) sample(now time.Time) {
+	if !d.lastSample.IsZero() {
+		if elapsed := now.Sub(d.lastSample).Seconds(); elapsed > 0 {
+			d.msgRate = float64(d.messages) / elapsed
+		}
+	}
+	d.messages = 0
+	d.lastSample = now
+
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+	d.heapAlloc = ms.HeapAlloc
+	d.numGC = ms.NumGC
+}
+
+// toggleDebug shows or hides the debug overlay. Opening it takes an
+// immediate sample and starts the periodic sampling tick.
+func (m Model) toggleDebug() (Model, tea.Cmd) {
+	if m.debug == nil {
+		return m, nil
+	}
+	m.debug.visible = !m.debug.visible
+	if !m.debug.visible {
+		return m, nil
+	}
+	m.debug.messages = 0
+	m.debug.lastSample = time.Time{}
+	m.debug.sample(time.Now())
+	return m, debugTick()
+}
+
+// handleDebugTick samples statistics and reschedules while the overlay is
+// visible. Ticks arriving after the overlay closes end the loop.
+func (m Model) handleDebugTick() (Model, tea.Cmd) {
+	if m.debug == nil || !m.debug.visible {
+		return m, nil
+	}
+	m.debug.sample(time.Now())
+	return m, debugTick()
+}
+
+// activeAnimations counts the animations currently running in the session:
+// the intro, a section transition, and any reported by the active section.
+func (m Model) activeAnimations() int {
+	n := 0
+	if m.showIntro {
+		n++
+	}
+	if m.transition.Active() {
+		n++
+	}
+	if ar, ok := m.sections[m.activeSection].(AnimationReporter); ok {
+		n += ar.ActiveAnimations()
+	}
+	return n
+}
+
+// debugView renders the single-line debug overlay.
+func (m Model) debugView() string {
+	d := m.debug
+	text := fmt.Sprintf("frame %.1fms · %.0f msg/s · %d×%d · heap %.1fMB · gc %d · anim %d",
+		float64(d.lastFrame.Microseconds())/1000,
+		d.msgRate,
+		m.width, m.height,
+		float64(d.heapAlloc)/(1<<20),
+		d.numGC,
+		m.activeAnimations(),
+	)
+	text = truncateRuneSafe(text, m.width)
+	return m.theme.NewStyle().Foreground(m.theme.Colors.Muted).Render(text)
+}
@@ -0,0 +1,119 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// animSpy is a SectionModel reporting a fixed number of active animations.
+type animSpy struct {
+	placeholderSection
+	n int
+}
+
+func (s *animSpy) ActiveAnimations() int { return s.n }
+
+func TestPaletteDebugCommand(t *testing.T) {
+	p := NewPaletteModel(DarkTheme())
+	p.Open()
+	for _, r := range "debug" {
+		p, _ = p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	_, cmd := p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("expected cmd from :debug")
+	}
+	msg, ok := cmd().(PaletteResultMsg)
+	if !ok || msg.Action != PaletteDebug {
+		t.Errorf("got %#v, want PaletteResultMsg{Action: PaletteDebug}", msg)
+	}
+}
+
+func TestDebugOverlayToggle(t *testing.T) {
+	m := skipIntro(t)
+
+	result, cmd := m.Update(PaletteResultMsg{Action: PaletteDebug})
+	m = result.(Model)
+	if !m.debug.visible {
+		t.Fatal("expected debug overlay visible after :debug")
+	}
+	if cmd == nil {
+		t.Error("expected sampling tick when opening the overlay")
+	}
+
+	view := m.View()
+	for _, want := range []string{"frame", "msg/s", "80×24", "heap", "anim"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("debug overlay missing %q", want)
+		}
+	}
+
+	result, _ = m.Update(PaletteResultMsg{Action: PaletteDebug})
+	m = result.(Model)
+	if m.debug.visible {
+		t.Error("expected debug overlay hidden after second :debug")
+	}
+	if strings.Contains(m.View(), "msg/s") {
+		t.Error("hidden debug overlay should not render")
+	}
+}
+
+func TestDebugTickStopsWhenHidden(t *testing.T) {
+	m := skipIntro(t)
+	_, cmd := m.Update(debugTickMsg{})
+	if cmd != nil {
+		t.Error("expected no reschedule while overlay is hidden")
+	}
+
+	m.debug.visible = true
+	_, cmd = m.Update(debugTickMsg{})
+	if cmd == nil {
+		t.Error("expected reschedule while overlay is visible")
+	}
+}
+
+func TestDebugStatsMessageRate(t *testing.T) {
+	m := skipIntro(t)
+	result, _ := m.Update(PaletteResultMsg{Action: PaletteDebug})
+	m = result.(Model)
+
+	for range 10 {
+		result, _ = m.Update(struct{}{})
+		m = result.(Model)
+	}
+	// Backdate the previous sample by one second so the rate is ~messages/s.
+	m.debug.lastSample = m.debug.lastSample.Add(-debugSampleInterval)
+	result, _ = m.Update(debugTickMsg{})
+	m = result.(Model)
+
+	if m.debug.msgRate < 5 {
+		t.Errorf("msgRate = %.1f, expected roughly 11 messages per second", m.debug.msgRate)
+	}
+	if m.debug.heapAlloc == 0 {
+		t.Error("expected heap statistics to be sampled")
+	}
+}
+
+func TestDebugRecordsFrameTime(t *testing.T) {
+	m := skipIntro(t)
+	_ = m.View()
+	if m.debug.lastFrame <= 0 {
+		t.Error("expected View to record a frame duration")
+	}
+}
+
+func TestActiveAnimationsCountsSection(t *testing.T) {
+	spy := &animSpy{placeholderSection: placeholderSection{name: "home", theme: DarkTheme()}, n: 2}
+	m := New(testContent(), spy)
+	m.showIntro = false
+	if got := m.activeAnimations(); got != 2 {
+		t.Errorf("activeAnimations() = %d, want 2", got)
+	}
+
+	m.showIntro = true
+	if got := m.activeAnimations(); got != 3 {
+		t.Errorf("activeAnimations() with intro = %d, want 3", got)
+	}
+}
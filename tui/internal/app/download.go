@@ -0,0 +1,28 @@
+package app
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Downloader is an optional interface that SectionModels can implement to
+// offer a file visitors can take with them. The root model calls Download
+// for the :download palette command; format names the file type requested
+// and is empty for the section's default.
+type Downloader interface {
+	Download(format string) tea.Cmd
+}
+
+// FileTransferSequence returns an iTerm2 OSC 1337 sequence that offers
+// data to the visitor's terminal as a download called name. iTerm2 and
+// WezTerm save it to the downloads folder; other terminals ignore it.
+// Both the name and the data are base64-encoded, so injection is not
+// possible. Like OSC52Sequence, it is written with WriteTerminal rather
+// than rendered in View.
+func FileTransferSequence(name string, data []byte) string {
+	return fmt.Sprintf("\x1b]1337;File=name=%s;size=%d;inline=0:%s\a",
+		base64.StdEncoding.EncodeToString([]byte(name)), len(data),
+		base64.StdEncoding.EncodeToString(data))
+}
@@ -0,0 +1,74 @@
+package app
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestFileTransferSequence(t *testing.T) {
+	seq := FileTransferSequence("cv.txt", []byte("hi\x1b"))
+	want := "\x1b]1337;File=name=" + base64.StdEncoding.EncodeToString([]byte("cv.txt")) +
+		";size=3;inline=0:" + base64.StdEncoding.EncodeToString([]byte("hi\x1b")) + "\a"
+	if seq != want {
+		t.Errorf("FileTransferSequence = %q, want %q", seq, want)
+	}
+	if strings.Count(seq, "\x1b") != 1 {
+		t.Error("payload escape characters should be encoded")
+	}
+}
+
+// downloadSection is a spySection that records Download calls.
+type downloadSection struct {
+	spySection
+	formats []string
+}
+
+func (s *downloadSection) Update(msg tea.Msg) (SectionModel, tea.Cmd) {
+	_, cmd := s.spySection.Update(msg)
+	return s, cmd
+}
+
+func (s *downloadSection) Download(format string) tea.Cmd {
+	s.formats = append(s.formats, format)
+	return WriteTerminal("file")
+}
+
+func TestPaletteDownloadCommand(t *testing.T) {
+	cv := &downloadSection{}
+	m := New(testContent(), &spySection{}, &spySection{}, cv)
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	m = result.(Model)
+	result, _ = m.Update(IntroDoneMsg{})
+	m = result.(Model)
+
+	result, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{':'}})
+	m = result.(Model)
+	for _, r := range "download txt" {
+		result, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		m = result.(Model)
+	}
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("expected cmd from :download txt")
+	}
+	msg, ok := cmd().(PaletteResultMsg)
+	if !ok || msg.Action != PaletteDownload || msg.Section != SectionCV || msg.Format != "txt" {
+		t.Fatalf("got %#v, want a txt download from the CV", msg)
+	}
+
+	result, cmd = m.Update(msg)
+	m = result.(Model)
+	if len(cv.formats) != 1 || cv.formats[0] != "txt" {
+		t.Errorf("Download calls = %q, want [txt]", cv.formats)
+	}
+	if cmd == nil {
+		t.Fatal("expected the download command to be returned")
+	}
+	m = drainTransition(t, m)
+	if m.activeSection != SectionCV {
+		t.Errorf("active section = %v, want the CV", m.activeSection)
+	}
+}
@@ -0,0 +1,83 @@
+package app
+
+import (
+	"math/rand/v2"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// miniGame is a game played in a full-screen overlay, reached through a
+// secret key sequence or :play. The model forwards every key to it and
+// calls Tick every Interval while it is open.
+type miniGame interface {
+	// Key handles a key press, returning true when the player leaves.
+	Key(msg tea.KeyMsg) (quit bool)
+	Tick()
+	Interval() time.Duration
+	View(width, height int) string
+}
+
+// konamiCode is the key sequence that starts a game from anywhere.
+var konamiCode = []string{"up", "up", "down", "down", "left", "right", "left", "right", "b", "a"}
+
+// gameTickMsg advances the open game; gen drops ticks from a game that
+// has since been closed.
+type gameTickMsg struct {
+	gen int
+}
+
+// trackSecret advances the Konami code detector with key and reports
+// whether it completed the code. Keys keep their usual effect, since the
+// code starts with arrows that scroll and switch sections.
+func (m *Model) trackSecret(key string) bool {
+	switch {
+	case key == konamiCode[m.secretPos]:
+		m.secretPos++
+	case key == konamiCode[0]:
+		// "up up up down ..." still counts: the last two ups start over.
+		if m.secretPos != 2 {
+			m.secretPos = 1
+		}
+	default:
+		m.secretPos = 0
+	}
+	if m.secretPos == len(konamiCode) {
+		m.secretPos = 0
+		return true
+	}
+	return false
+}
+
+// startGame opens a snake game sized to the terminal.
+func (m Model) startGame() (tea.Model, tea.Cmd) {
+	m.game = newSnakeGame(m.theme, m.width, m.height, rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64())))
+	m.gameGen++
+	return m, gameTick(m.gameGen, m.game.Interval())
+}
+
+// handleGameKey passes a key to the open game, closing it when the
+// player leaves.
+func (m Model) handleGameKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.game.Key(msg) {
+		m.game = nil
+		m.gameGen++
+	}
+	return m, nil
+}
+
+// handleGameTick advances the open game and schedules the next tick.
+func (m Model) handleGameTick(msg gameTickMsg) (tea.Model, tea.Cmd) {
+	if m.game == nil || msg.gen != m.gameGen {
+		return m, nil
+	}
+	m.game.Tick()
+	return m, gameTick(m.gameGen, m.game.Interval())
+}
+
+// gameTick schedules the next tick of game gen.
+func gameTick(gen int, interval time.Duration) tea.Cmd {
+	return tea.Tick(interval, func(time.Time) tea.Msg {
+		return gameTickMsg{gen: gen}
+	})
+}
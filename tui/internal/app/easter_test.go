@@ -0,0 +1,90 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func keyMsg(key string) tea.KeyMsg {
+	switch key {
+	case "up":
+		return tea.KeyMsg{Type: tea.KeyUp}
+	case "down":
+		return tea.KeyMsg{Type: tea.KeyDown}
+	case "left":
+		return tea.KeyMsg{Type: tea.KeyLeft}
+	case "right":
+		return tea.KeyMsg{Type: tea.KeyRight}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+}
+
+func TestKonamiCodeStartsGame(t *testing.T) {
+	m := skipIntro(t)
+	// An extra up first, then the code, with transitions left running.
+	for _, key := range append([]string{"up"}, konamiCode...) {
+		result, _ := m.Update(keyMsg(key))
+		m = result.(Model)
+	}
+	if m.game == nil {
+		t.Fatal("the Konami code should start a game")
+	}
+	if view := stripANSI(m.View()); !strings.Contains(view, "snake") || !strings.Contains(view, "score 0") {
+		t.Errorf("game view = %q", view)
+	}
+
+	// Ticks advance the game and keep coming; stale ones are dropped.
+	g := m.game.(*snakeGame)
+	head := g.body[0]
+	result, cmd := m.Update(gameTickMsg{gen: m.gameGen})
+	m = result.(Model)
+	if g.body[0] == head || cmd == nil {
+		t.Error("a tick should move the snake and schedule the next")
+	}
+	if _, cmd := m.Update(gameTickMsg{gen: m.gameGen - 1}); cmd != nil {
+		t.Error("a stale tick should not schedule another")
+	}
+
+	// q leaves the game instead of quitting the session.
+	result, cmd = m.Update(keyMsg("q"))
+	m = result.(Model)
+	if m.game != nil || cmd != nil {
+		t.Error("q should close the game and nothing else")
+	}
+}
+
+func TestKonamiCodeInterrupted(t *testing.T) {
+	m := skipIntro(t)
+	keys := append([]string{}, konamiCode[:5]...)
+	keys = append(keys, "x")
+	keys = append(keys, konamiCode[5:]...)
+	for _, key := range keys {
+		result, _ := m.Update(keyMsg(key))
+		m = result.(Model)
+	}
+	if m.game != nil {
+		t.Error("a broken sequence should not start a game")
+	}
+}
+
+func TestPalettePlay(t *testing.T) {
+	m := skipIntro(t)
+	result, cmd := m.Update(PaletteResultMsg{Action: PalettePlay})
+	if result.(Model).game == nil || cmd == nil {
+		t.Error(":play should start a game and its ticks")
+	}
+
+	p := NewPaletteModel(DarkTheme())
+	for _, name := range p.commandNames() {
+		if name == "play" {
+			t.Error(":play should not be suggested")
+		}
+	}
+	p.Open()
+	p.input = "play"
+	if _, cmd := p.execute(); cmd == nil || cmd().(PaletteResultMsg).Action != PalettePlay {
+		t.Error(":play should resolve when typed")
+	}
+}
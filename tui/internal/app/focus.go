@@ -0,0 +1,97 @@
+package app
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// FocusRing tracks which pane of a layout takes keys. Next and Prev cycle
+// through the panes in order, wrapping at the ends.
+type FocusRing struct {
+	count   int
+	current int
+}
+
+// NewFocusRing creates a ring of count panes with focus on pane current.
+func NewFocusRing(count, current int) FocusRing {
+	f := FocusRing{count: max(1, count)}
+	f.Focus(current)
+	return f
+}
+
+// Current returns the index of the focused pane.
+func (f FocusRing) Current() int {
+	return f.current
+}
+
+// Focus moves focus to pane i, if there is one.
+func (f *FocusRing) Focus(i int) {
+	if i >= 0 && i < f.count {
+		f.current = i
+	}
+}
+
+// Next moves focus to the following pane.
+func (f *FocusRing) Next() {
+	if f.count > 0 {
+		f.current = (f.current + 1) % f.count
+	}
+}
+
+// Prev moves focus to the preceding pane.
+func (f *FocusRing) Prev() {
+	if f.count > 0 {
+		f.current = (f.current + f.count - 1) % f.count
+	}
+}
+
+// Panes of the split layout, in focus order.
+const (
+	paneSidebar = iota
+	paneContent
+	paneCount
+)
+
+// contentFocused reports whether keys go to the active section: always
+// outside the split layout, and when its content pane has focus inside.
+func (m Model) contentFocused() bool {
+	return !m.splitActive() || m.focus.Current() == paneContent
+}
+
+// handlePaneKey routes a key in the split layout: the pane keys (tab and
+// shift+tab by default) move focus between the panes, and the sidebar
+// takes its own keys while it has focus. It reports false for keys left
+// to the global bindings.
+func (m Model) handlePaneKey(msg tea.KeyMsg) (tea.Model, tea.Cmd, bool) {
+	switch {
+	case m.keys.Matches(msg, KeyPaneNext):
+		m.focus.Next()
+		m.sidebarCursor = m.activeSection
+		return m, nil, true
+	case m.keys.Matches(msg, KeyPanePrev):
+		m.focus.Prev()
+		m.sidebarCursor = m.activeSection
+		return m, nil, true
+	}
+	if m.focus.Current() != paneSidebar {
+		return m, nil, false
+	}
+	first, last := visibleEnds(m.hidden)
+	switch {
+	case m.keys.Matches(msg, KeyScrollDown):
+		m.sidebarCursor = stepSection(m.sidebarCursor, 1, false, m.hidden)
+	case m.keys.Matches(msg, KeyScrollUp):
+		m.sidebarCursor = stepSection(m.sidebarCursor, -1, false, m.hidden)
+	case m.keys.Matches(msg, KeyTop):
+		m.sidebarCursor = first
+	case m.keys.Matches(msg, KeyBottom):
+		m.sidebarCursor = last
+	case m.keys.Matches(msg, KeySelect),
+		m.keys.Matches(msg, KeyScrollRight) && !m.keys.Matches(msg, KeyNavNext):
+		// Opening a section hands it the keys; the arrow still changes
+		// section.
+		m.focus.Focus(paneContent)
+		next, cmd := m.navigateTo(m.sidebarCursor)
+		return next, cmd, true
+	default:
+		return m, nil, false
+	}
+	return m, nil, true
+}
@@ -0,0 +1,74 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestFocusRing(t *testing.T) {
+	f := NewFocusRing(3, 1)
+	f.Next()
+	f.Next()
+	if f.Current() != 0 {
+		t.Errorf("Next should wrap to 0, got %d", f.Current())
+	}
+	f.Prev()
+	if f.Current() != 2 {
+		t.Errorf("Prev should wrap to 2, got %d", f.Current())
+	}
+	f.Focus(5)
+	if f.Current() != 2 {
+		t.Errorf("focusing a missing pane should change nothing, got %d", f.Current())
+	}
+	var zero FocusRing
+	zero.Next()
+	zero.Prev()
+}
+
+func TestSplitFocusRouting(t *testing.T) {
+	spy := &spySection{}
+	m := New(testContent(), spy)
+	result, _ := m.Update(tea.WindowSizeMsg{Width: SplitMinWidth, Height: 30})
+	result, _ = result.(Model).Update(IntroDoneMsg{})
+	m = result.(Model)
+	if !m.contentFocused() {
+		t.Fatal("the content pane should start with focus")
+	}
+
+	// tab moves focus to the sidebar instead of switching sections.
+	result, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	m = result.(Model)
+	if m.contentFocused() || m.activeSection != SectionHome {
+		t.Fatalf("after tab: content focused %v, section %v", m.contentFocused(), m.activeSection)
+	}
+	if !strings.Contains(stripANSI(m.View()), "tab switch pane") {
+		t.Error("the sidebar should show how to switch panes")
+	}
+
+	// The sidebar takes j and enter; the section sees neither.
+	spy.keys = 0
+	for _, key := range []tea.KeyMsg{{Type: tea.KeyRunes, Runes: []rune("j")}, {Type: tea.KeyRunes, Runes: []rune("j")}, {Type: tea.KeyRunes, Runes: []rune("x")}} {
+		result, _ = m.Update(key)
+		m = result.(Model)
+	}
+	if m.sidebarCursor != SectionCV || spy.keys != 0 {
+		t.Errorf("sidebar cursor = %v, section got %d keys; want cv and none", m.sidebarCursor, spy.keys)
+	}
+	result, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+	if m.activeSection != SectionCV || !m.contentFocused() {
+		t.Errorf("enter should open cv and focus it, got %v focused %v", m.activeSection, m.contentFocused())
+	}
+
+	// Outside the split layout tab switches sections as before.
+	m = drainTransition(t, m)
+	result, _ = m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	m = result.(Model)
+	m.focus.Focus(paneSidebar)
+	result, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if got := result.(Model).activeSection; got != SectionLinks {
+		t.Errorf("tab in the normal layout went to %v, want links", got)
+	}
+}
@@ -0,0 +1,158 @@
+package app
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// frameGuard is the last line of defense against frames wider than the
+// terminal. Some TERM values make width measurement disagree with the
+// client, and one over-wide line makes the terminal wrap it, shifting every
+// row below and leaving jagged borders. The guard re-truncates such lines
+// and logs the component that produced them.
+//
+// Overflows are logged once per component and width, so a persistent
+// problem does not flood the log at the frame rate.
+//
+// Size violations are logged once per layout while the terminal keeps its
+// size. A resize forgets them, and so does reaching maxFrameViolations
+// layouts, so the record stays small over a long session.
+type frameGuard struct {
+	logger   *slog.Logger
+	reported map[string]int // component → width at which overflow was logged
+
+	// checkSize enables whole-frame size assertions, used in debug mode.
+	// parts records each component of the frame being rendered so a
+	// violation can name its contributors; violations dedupes the logs
+	// for the terminal size in violationSize.
+	checkSize     bool
+	parts         []framePart
+	violations    map[string]bool
+	violationSize [2]int
+}
+
+// maxFrameViolations is how many distinct violating layouts the guard
+// remembers before it starts over.
+const maxFrameViolations = 64
+
+// framePart is one component's share of a rendered frame, measured before
+// truncation.
+type framePart struct {
+	component string
+	lines     int
+	widest    int
+}
+
+// newFrameGuard creates a guard that logs to the default slog logger.
+func newFrameGuard() *frameGuard {
+	return &frameGuard{reported: map[string]int{}, violations: map[string]bool{}}
+}
+
+// log returns the guard's logger, falling back to the default.
+func (g *frameGuard) log() *slog.Logger {
+	if g.logger == nil {
+		return slog.Default()
+	}
+	return g.logger
+}
+
+// fit returns s with every line truncated to width visible columns. A nil
+// guard, or a non-positive width, returns s unchanged.
+func (g *frameGuard) fit(component, s string, width int) string {
+	if g == nil || width <= 0 {
+		return s
+	}
+
+	lines := strings.Split(s, "\n")
+	if g.checkSize {
+		part := framePart{component: component, lines: len(lines)}
+		for _, line := range lines {
+			part.widest = max(part.widest, lipgloss.Width(line))
+		}
+		g.parts = append(g.parts, part)
+	}
+	overflowed, widest := 0, 0
+	for i, line := range lines {
+		w := lipgloss.Width(line)
+		if w <= width {
+			continue
+		}
+		overflowed++
+		widest = max(widest, w)
+		lines[i] = truncateLine(line, width)
+	}
+	if overflowed == 0 {
+		return s
+	}
+
+	if g.reported[component] != width {
+		g.reported[component] = width
+		g.log().Warn("render overflow truncated",
+			"component", component,
+			"width", width,
+			"widest", widest,
+			"lines", overflowed,
+		)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// beginFrame clears the component record ahead of a new frame.
+func (g *frameGuard) beginFrame() {
+	if g == nil || !g.checkSize {
+		return
+	}
+	g.parts = g.parts[:0]
+}
+
+// checkFrame asserts that frame fills the terminal: no line wider than
+// width, and exactly height lines, or at most height when exact is false.
+// A violation is logged with the size of every component recorded since
+// beginFrame; each distinct layout is logged once.
+func (g *frameGuard) checkFrame(frame string, width, height int, exact bool) {
+	if g == nil || !g.checkSize || width <= 0 || height <= 0 {
+		return
+	}
+
+	lines := strings.Split(frame, "\n")
+	widest := 0
+	for _, line := range lines {
+		widest = max(widest, lipgloss.Width(line))
+	}
+	n := len(lines)
+	if widest <= width && n <= height && (!exact || n == height) {
+		return
+	}
+
+	parts := make([]string, len(g.parts))
+	for i, p := range g.parts {
+		parts[i] = fmt.Sprintf("%s %d×%d", p.component, p.lines, p.widest)
+	}
+	components := strings.Join(parts, ", ")
+
+	if size := [2]int{width, height}; size != g.violationSize || len(g.violations) >= maxFrameViolations {
+		g.violationSize = size
+		clear(g.violations)
+	}
+	key := fmt.Sprintf("%d×%d %s", n, widest, components)
+	if g.violations[key] {
+		return
+	}
+	g.violations[key] = true
+	g.log().Warn("frame size violation",
+		"width", width,
+		"height", height,
+		"lines", n,
+		"widest", widest,
+		"components", components,
+	)
+}
+
+// truncateLine cuts a single line to width visible columns, keeping ANSI
+// escape sequences intact.
+func truncateLine(line string, width int) string {
+	return lipgloss.NewStyle().MaxWidth(width).Render(line)
+}
@@ -0,0 +1,201 @@
+package app
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestFrameGuardTruncatesAndLogsOnce(t *testing.T) {
+	var logs bytes.Buffer
+	g := newFrameGuard()
+	g.logger = slog.New(slog.NewTextHandler(&logs, nil))
+
+	in := "short\n" + strings.Repeat("x", 30) + "\nalso short"
+	out := g.fit("cv", in, 10)
+
+	lines := strings.Split(out, "\n")
+	if len(lines) != 3 {
+		t.Fatalf("fit changed line count: %d", len(lines))
+	}
+	for i, line := range lines {
+		if w := lipgloss.Width(line); w > 10 {
+			t.Errorf("line %d width %d exceeds 10", i, w)
+		}
+	}
+	if lines[0] != "short" {
+		t.Errorf("lines within width should be untouched, got %q", lines[0])
+	}
+	if !strings.Contains(logs.String(), "component=cv") {
+		t.Errorf("overflow should be logged with the component, got %q", logs.String())
+	}
+
+	// Same component and width: not logged again.
+	logs.Reset()
+	g.fit("cv", in, 10)
+	if logs.Len() != 0 {
+		t.Errorf("repeated overflow should not be re-logged, got %q", logs.String())
+	}
+
+	// A new width is a new condition worth logging.
+	g.fit("cv", in, 12)
+	if logs.Len() == 0 {
+		t.Error("overflow at a new width should be logged")
+	}
+}
+
+func TestFrameGuardPassThrough(t *testing.T) {
+	var g *frameGuard
+	if got := g.fit("x", "anything", 3); got != "anything" {
+		t.Errorf("nil guard should pass through, got %q", got)
+	}
+	if got := newFrameGuard().fit("x", "fits", 10); got != "fits" {
+		t.Errorf("fitting content should pass through, got %q", got)
+	}
+}
+
+// wideSection renders a single line far wider than any terminal.
+type wideSection struct{ placeholderSection }
+
+func (wideSection) View() string { return strings.Repeat("w", 500) }
+
+func TestModelViewNeverExceedsWidth(t *testing.T) {
+	m := New(testContent(), &wideSection{placeholderSection{name: "home", theme: DarkTheme()}})
+	m.guard.logger = slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil))
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	m = result.(Model)
+	m.showIntro = false
+
+	for i, line := range strings.Split(m.View(), "\n") {
+		if w := lipgloss.Width(line); w > 80 {
+			t.Errorf("frame line %d is %d wide, exceeds 80", i, w)
+		}
+	}
+}
+
+func TestFrameCheckLogsComponentSizes(t *testing.T) {
+	var logs bytes.Buffer
+	g := newFrameGuard()
+	g.logger = slog.New(slog.NewTextHandler(&logs, nil))
+	g.checkSize = true
+
+	g.beginFrame()
+	frame := g.fit("navbar", "nav", 10) + "\n" + g.fit("home", "a\nb\nc", 10)
+	g.checkFrame(frame, 10, 5, true)
+	out := logs.String()
+	if !strings.Contains(out, "frame size violation") || !strings.Contains(out, "lines=4") {
+		t.Fatalf("short frame should be reported, got %q", out)
+	}
+	if !strings.Contains(out, "navbar 1×3, home 3×1") {
+		t.Errorf("violation should list component sizes, got %q", out)
+	}
+
+	// The same layout is not logged twice.
+	logs.Reset()
+	g.beginFrame()
+	g.fit("navbar", "nav", 10)
+	g.fit("home", "a\nb\nc", 10)
+	g.checkFrame(frame, 10, 5, true)
+	if logs.Len() != 0 {
+		t.Errorf("repeated violation should not be re-logged, got %q", logs.String())
+	}
+
+	// A short frame is fine when exactness is not required.
+	g.checkFrame(frame, 10, 5, false)
+	if logs.Len() != 0 {
+		t.Errorf("non-exact check flagged a short frame: %q", logs.String())
+	}
+
+	// A resize forgets the layouts logged at the old size.
+	g.checkFrame(frame, 10, 6, true)
+	if len(g.violations) != 1 {
+		t.Errorf("remembered %d layouts after a resize, want 1", len(g.violations))
+	}
+	g.checkFrame(frame, 10, 5, true)
+	if !strings.Contains(logs.String(), "height=5") {
+		t.Errorf("a layout should be logged again after a resize, got %q", logs.String())
+	}
+}
+
+func TestFrameCheckForgetsViolations(t *testing.T) {
+	g := newFrameGuard()
+	g.logger = slog.New(slog.DiscardHandler)
+	g.checkSize = true
+	for i := range 3 * maxFrameViolations {
+		g.beginFrame()
+		g.fit("home", strings.Repeat("x\n", i), 10)
+		g.checkFrame(strings.Repeat("x\n", i+6), 10, 5, true)
+	}
+	if n := len(g.violations); n > maxFrameViolations {
+		t.Errorf("remembered %d layouts, want at most %d", n, maxFrameViolations)
+	}
+}
+
+// fillSection renders exactly the size it was given, like a real section.
+type fillSection struct {
+	placeholderSection
+	width, height int
+}
+
+func (s *fillSection) Update(msg tea.Msg) (SectionModel, tea.Cmd) {
+	if wsm, ok := msg.(tea.WindowSizeMsg); ok {
+		s.width, s.height = wsm.Width, wsm.Height
+	}
+	return s, nil
+}
+
+func (s *fillSection) View() string {
+	line := strings.Repeat(".", s.width)
+	return strings.TrimSuffix(strings.Repeat(line+"\n", s.height), "\n")
+}
+
+func TestModelFrameCheck(t *testing.T) {
+	var logs bytes.Buffer
+	fill := &fillSection{}
+	m := New(testContent(), fill).SetFrameCheck(true)
+	m.guard.logger = slog.New(slog.NewTextHandler(&logs, nil))
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	m = result.(Model)
+
+	m.View() // intro: shorter than the terminal is expected
+	result, _ = m.Update(IntroDoneMsg{})
+	m = result.(Model)
+	m.View()
+	if logs.Len() != 0 {
+		t.Fatalf("well-sized frames should not be reported, got %q", logs.String())
+	}
+
+	// The palette covers the bottom of the section rather than pushing the
+	// frame past the terminal.
+	m.showPalette = true
+	m.palette.Open()
+	m.View()
+	if logs.Len() != 0 {
+		t.Fatalf("the palette should fit the frame, got %q", logs.String())
+	}
+
+	// A section taller than it was sized overflows the frame.
+	m.showPalette = false
+	fill.height += 2
+	m.View()
+	if !strings.Contains(logs.String(), "home 23×80") {
+		t.Errorf("overflowing frame should name the section, got %q", logs.String())
+	}
+}
+
+func TestModelFrameCheckOffByDefault(t *testing.T) {
+	var logs bytes.Buffer
+	m := New(testContent())
+	m.guard.logger = slog.New(slog.NewTextHandler(&logs, nil))
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	m = result.(Model)
+	m.showIntro = false
+	m.View() // placeholder section leaves the frame short
+	if logs.Len() != 0 {
+		t.Errorf("frame check should be off unless enabled, got %q", logs.String())
+	}
+}
@@ -0,0 +1,43 @@
+package app
+
+import (
+	"fmt"
+	"time"
+)
+
+// RelativeTime formats t relative to now in coarse, human-friendly units
+// ("just now", "3 days ago", "2 months ago"). A zero t returns an empty
+// string so callers can omit the label entirely. Times in the future are
+// treated as "just now" to tolerate small clock skew.
+func RelativeTime(t, now time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	d := now.Sub(t)
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return pluralAgo(int(d/time.Minute), "minute")
+	case d < 24*time.Hour:
+		return pluralAgo(int(d/time.Hour), "hour")
+	case d < 48*time.Hour:
+		return "yesterday"
+	case d < 14*24*time.Hour:
+		return pluralAgo(int(d/(24*time.Hour)), "day")
+	case d < 60*24*time.Hour:
+		return pluralAgo(int(d/(7*24*time.Hour)), "week")
+	case d < 365*24*time.Hour:
+		return pluralAgo(int(d/(30*24*time.Hour)), "month")
+	default:
+		return pluralAgo(int(d/(365*24*time.Hour)), "year")
+	}
+}
+
+// pluralAgo renders "<n> <unit>s ago", dropping the plural for n == 1.
+func pluralAgo(n int, unit string) string {
+	if n == 1 {
+		return fmt.Sprintf("1 %s ago", unit)
+	}
+	return fmt.Sprintf("%d %ss ago", n, unit)
+}
@@ -0,0 +1,41 @@
+package app
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRelativeTime(t *testing.T) {
+	now := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		ago  time.Duration
+		want string
+	}{
+		{"seconds", 30 * time.Second, "just now"},
+		{"one_minute", time.Minute, "1 minute ago"},
+		{"minutes", 45 * time.Minute, "45 minutes ago"},
+		{"hours", 5 * time.Hour, "5 hours ago"},
+		{"yesterday", 30 * time.Hour, "yesterday"},
+		{"days", 3 * 24 * time.Hour, "3 days ago"},
+		{"weeks", 20 * 24 * time.Hour, "2 weeks ago"},
+		{"months", 95 * 24 * time.Hour, "3 months ago"},
+		{"one_year", 400 * 24 * time.Hour, "1 year ago"},
+		{"future", -time.Hour, "just now"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := RelativeTime(now.Add(-tt.ago), now)
+			if got != tt.want {
+				t.Errorf("RelativeTime(-%v) = %q, want %q", tt.ago, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRelativeTimeZero(t *testing.T) {
+	if got := RelativeTime(time.Time{}, time.Now()); got != "" {
+		t.Errorf("RelativeTime(zero) = %q, want empty", got)
+	}
+}
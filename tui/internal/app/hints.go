@@ -0,0 +1,192 @@
+package app
+
+import (
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// linkHint is a link found in a rendered view: its URL, the line it is
+// on, and the byte offset in the line where it starts. style holds the
+// SGR sequences in effect there, which are restored after its label.
+type linkHint struct {
+	url    string
+	line   int
+	offset int
+	style  string
+}
+
+// findLinks returns the links in view in reading order: each OSC 8
+// hyperlink, and each http or https URL written out as text outside one.
+// A URL cut short with an ellipsis is left out, since copying it would
+// give a broken link.
+func findLinks(view string) []linkHint {
+	var links []linkHint
+	for n, line := range strings.Split(view, "\n") {
+		style, inLink := "", false
+		for i := 0; i < len(line); {
+			if line[i] == '\x1b' {
+				seq, target, isLink := escapeAt(line[i:])
+				switch {
+				case isLink:
+					if target != "" {
+						links = append(links, linkHint{url: target, line: n, offset: i, style: style})
+					}
+					inLink = target != ""
+				case seq == "\x1b[m" || seq == "\x1b[0m":
+					style = ""
+				case strings.HasPrefix(seq, "\x1b[") && strings.HasSuffix(seq, "m"):
+					style += seq
+				}
+				i += len(seq)
+				continue
+			}
+			rest := line[i:]
+			if !inLink && (strings.HasPrefix(rest, "https://") || strings.HasPrefix(rest, "http://")) {
+				url, cut := plainURL(rest)
+				if !cut {
+					links = append(links, linkHint{url: url, line: n, offset: i, style: style})
+				}
+				i += len(url)
+				continue
+			}
+			i++
+		}
+	}
+	return links
+}
+
+// escapeAt returns the escape sequence s starts with. For an OSC 8
+// hyperlink it also returns the link's target, which is empty where a
+// link ends, and true.
+func escapeAt(s string) (seq, target string, isLink bool) {
+	if len(s) < 2 {
+		return s, "", false
+	}
+	switch s[1] {
+	case '[':
+		for i := 2; i < len(s); i++ {
+			if s[i] >= 0x40 && s[i] <= 0x7e {
+				return s[:i+1], "", false
+			}
+		}
+		return s, "", false
+	case ']':
+		body, end := s[2:], len(s)
+		if i := strings.IndexAny(body, "\a\x1b"); i >= 0 {
+			body, end = body[:i], 2+i+1
+			if s[2+i] == '\x1b' {
+				end++
+			}
+		}
+		end = min(end, len(s))
+		params, ok := strings.CutPrefix(body, "8;")
+		if !ok {
+			return s[:end], "", false
+		}
+		_, target, _ = strings.Cut(params, ";")
+		return s[:end], target, true
+	}
+	return s[:2], "", false
+}
+
+// plainURL returns the URL at the start of s, up to the first space or
+// escape sequence and without trailing punctuation, and whether it was
+// cut short with an ellipsis.
+func plainURL(s string) (url string, cut bool) {
+	end := strings.IndexAny(s, " \t\x1b\"<>")
+	if end < 0 {
+		end = len(s)
+	}
+	url = s[:end]
+	if i := strings.Index(url, "…"); i >= 0 {
+		return url[:i], true
+	}
+	for len(url) > 0 && strings.ContainsRune(".,;:!?'", rune(url[len(url)-1])) {
+		url = url[:len(url)-1]
+	}
+	if strings.HasSuffix(url, ")") && !strings.Contains(url, "(") {
+		url = url[:len(url)-1]
+	}
+	return url, false
+}
+
+// openLinkHints labels each link visible in the active section with a
+// number, which typed copies it, or says there are none.
+func (m Model) openLinkHints() (tea.Model, tea.Cmd) {
+	width, _ := m.sectionSize()
+	links := findLinks(m.sectionView(width))
+	if len(links) == 0 {
+		return m.showNotice("No links on screen")
+	}
+	m.linkHints = make([]string, len(links))
+	for i, l := range links {
+		m.linkHints[i] = l.url
+	}
+	m.hintInput = ""
+	return m, nil
+}
+
+// handleLinkHintKey reads the number of a link hint. A number no longer
+// one starts with picks its link at once; enter picks a shorter one, as
+// 1 among a dozen links. Backspace deletes a digit, and any other key
+// closes the hints.
+func (m Model) handleLinkHintKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if d, ok := Digit(msg); ok {
+		input := m.hintInput + string(d)
+		n, _ := strconv.Atoi(input)
+		if n < 1 || n > len(m.linkHints) {
+			m.linkHints, m.hintInput = nil, ""
+			return m.showNotice("No link " + input)
+		}
+		if n*10 > len(m.linkHints) {
+			return m.copyLinkHint(n)
+		}
+		m.hintInput = input
+		return m, nil
+	}
+	switch {
+	case msg.Type == tea.KeyEnter && m.hintInput != "":
+		n, _ := strconv.Atoi(m.hintInput)
+		return m.copyLinkHint(n)
+	case msg.Type == tea.KeyBackspace && m.hintInput != "":
+		m.hintInput = m.hintInput[:len(m.hintInput)-1]
+		return m, nil
+	}
+	m.linkHints, m.hintInput = nil, ""
+	return m, nil
+}
+
+// copyLinkHint copies the URL of link hint n, counted from 1, and closes
+// the hints.
+func (m Model) copyLinkHint(n int) (tea.Model, tea.Cmd) {
+	url := m.linkHints[n-1]
+	m.linkHints, m.hintInput = nil, ""
+	next, notice := m.showNotice("Link copied: " + url)
+	return next, tea.Batch(CopyToClipboard(url), notice)
+}
+
+// withLinkHints puts the label of each link in view before it, leaving
+// out those whose number does not start with the digits typed so far.
+// Lines made wider than width by their labels are cut back to it.
+func (m Model) withLinkHints(view string, width int) string {
+	links := findLinks(view)
+	lines := strings.Split(view, "\n")
+	labelStyle := m.theme.NewStyle().Background(m.theme.Colors.Accent).Foreground(m.theme.Colors.Bg).Bold(true)
+	changed := make(map[int]bool)
+	// Labels go in from the end, so the offsets of earlier links hold.
+	for i := min(len(links), len(m.linkHints)) - 1; i >= 0; i-- {
+		l, label := links[i], strconv.Itoa(i+1)
+		if !strings.HasPrefix(label, m.hintInput) {
+			continue
+		}
+		line := lines[l.line]
+		lines[l.line] = line[:l.offset] + labelStyle.Render(" "+label+" ") + l.style + line[l.offset:]
+		changed[l.line] = true
+	}
+	for n := range changed {
+		lines[n] = truncateLine(lines[n], width)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,121 @@
+package app
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestFindLinks(t *testing.T) {
+	muted := "\x1b[38;5;242m"
+	view := strings.Join([]string{
+		"Site: " + RenderHyperlink("https://example.com", muted+"example.com\x1b[0m"),
+		muted + "See https://go.dev/doc, or (https://pkg.go.dev)." + "\x1b[0m",
+		"Cut https://example.com/very/lo…",
+		"\x1b]8;;mailto:hi@example.com\x1b\\hi@example.com\x1b]8;;\x1b\\",
+		"No links here",
+	}, "\n")
+
+	links := findLinks(view)
+	var urls []string
+	for _, l := range links {
+		urls = append(urls, l.url)
+	}
+	want := []string{"https://example.com", "https://go.dev/doc", "https://pkg.go.dev", "mailto:hi@example.com"}
+	if !slices.Equal(urls, want) {
+		t.Fatalf("findLinks = %q, want %q", urls, want)
+	}
+	if l := links[1]; l.line != 1 || l.style != muted {
+		t.Errorf("plain URL found at line %d with style %q, want line 1 in the muted style", l.line, l.style)
+	}
+}
+
+// linkSection shows 12 links, one per line.
+type linkSection struct{ placeholderSection }
+
+func (s *linkSection) Update(tea.Msg) (SectionModel, tea.Cmd) { return s, nil }
+
+func (s *linkSection) View() string {
+	var lines []string
+	for i := range 12 {
+		lines = append(lines, fmt.Sprintf("Link %d: https://example.com/%d", i+1, i+1))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func TestLinkHints(t *testing.T) {
+	m := New(testContent(), &linkSection{placeholderSection{name: "home", theme: DarkTheme()}})
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	m = result.(Model)
+	result, _ = m.Update(IntroDoneMsg{})
+	m = result.(Model)
+	press := func(key string) tea.Cmd {
+		t.Helper()
+		result, cmd := m.Update(keyMsg(key))
+		m = result.(Model)
+		return cmd
+	}
+
+	press("f")
+	if len(m.linkHints) != 12 {
+		t.Fatalf("link hints = %d, want one for each of the 12 links", len(m.linkHints))
+	}
+	view := stripANSI(m.View())
+	for _, want := range []string{"Link 1:  1 https://example.com/1", "Link 12:  12 https://example.com/12"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing label %q:\n%s", want, view)
+		}
+	}
+	for i, line := range strings.Split(m.View(), "\n") {
+		if w := lipgloss.Width(line); w > 80 {
+			t.Errorf("line %d is %d wide with labels", i, w)
+		}
+	}
+
+	// 1 starts 10 to 12 too, so it waits; only those labels stay.
+	if cmd := press("1"); cmd != nil || m.hintInput != "1" {
+		t.Fatalf("after 1, input = %q and a command was returned; want it to wait for 10-12", m.hintInput)
+	}
+	if view := stripANSI(m.View()); strings.Contains(view, " 2 https") || !strings.Contains(view, " 11 https") {
+		t.Errorf("labels not starting with 1 should hide:\n%s", view)
+	}
+	cmd := press("1")
+	if m.linkHints != nil {
+		t.Error("picking a link should close the hints")
+	}
+	if msg, ok := cmd().(tea.BatchMsg)[0]().(ClipboardMsg); !ok || msg.Text != "https://example.com/11" {
+		t.Errorf("first command = %#v, want a copy of link 11", msg)
+	}
+
+	// Enter picks a number that starts others, and any other key closes
+	// the hints.
+	press("f")
+	press("1")
+	result, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m = result.(Model); cmd == nil || !strings.Contains(m.statusView(), "https://example.com/1") {
+		t.Errorf("enter after 1 should copy link 1, status %q", stripANSI(m.statusView()))
+	}
+	press("f")
+	press("q")
+	if m.linkHints != nil || m.quit {
+		t.Error("q should close the hints without quitting")
+	}
+	press("f")
+	press("0")
+	if m.linkHints != nil || !strings.Contains(m.statusView(), "No link 0") {
+		t.Errorf("an unknown number should close the hints and say so, status %q", stripANSI(m.statusView()))
+	}
+}
+
+func TestLinkHintsWithoutLinks(t *testing.T) {
+	m := skipIntro(t)
+	result, _ := m.Update(keyMsg("f"))
+	m = result.(Model)
+	if m.linkHints != nil || !strings.Contains(m.statusView(), "No links on screen") {
+		t.Errorf("status bar = %q, want it to say there are no links", stripANSI(m.statusView()))
+	}
+}
@@ -18,12 +18,11 @@ func RenderHyperlink(url, displayText string) string {
 // clipboard to the given text. The payload is base64-encoded, so injection
 // is not possible.
 //
-// Embed the returned string in View output to write to the clipboard on
-// the next render. Bubbletea v1 has no tea.Raw; prepending to View works
-// in alt screen mode because the terminal parses OSC sequences from the
-// byte stream regardless of interleaved cursor-positioning escapes.
-// Tested with iTerm2, Ghostty, and WezTerm. Unsupported terminals
-// silently ignore the sequence.
+// The sequence is written directly to the session output by the root
+// model (see CopyToClipboard) rather than embedded in View, where it would
+// count as part of the frame and could be re-emitted on repaint. Tested
+// with iTerm2, Ghostty, and WezTerm. Unsupported terminals silently ignore
+// the sequence.
 func OSC52Sequence(text string) string {
 	encoded := base64.StdEncoding.EncodeToString([]byte(text))
 	return fmt.Sprintf("\x1b]52;c;%s\a", encoded)
@@ -1,6 +1,7 @@
 package app
 
 import (
+	"bytes"
 	"encoding/base64"
 	"strings"
 	"testing"
@@ -53,3 +54,47 @@ func TestOSC52Sequence(t *testing.T) {
 		t.Errorf("OSC52Sequence(%q) = %q, want %q", text, got, want)
 	}
 }
+
+func TestClipboardMsgWritesOutsideView(t *testing.T) {
+	var out bytes.Buffer
+	m := skipIntro(t).SetOutput(&out)
+
+	result, cmd := m.Update(ClipboardMsg{Text: "https://example.com"})
+	m = result.(Model)
+	if cmd == nil {
+		t.Fatal("expected a write command for ClipboardMsg")
+	}
+	if msg := cmd(); msg != nil {
+		t.Errorf("write command returned %#v, want nil", msg)
+	}
+	if got, want := out.String(), OSC52Sequence("https://example.com"); got != want {
+		t.Errorf("clipboard output = %q, want %q", got, want)
+	}
+	if strings.Contains(m.View(), "\x1b]52;") {
+		t.Error("OSC 52 sequence must not appear in view")
+	}
+}
+
+func TestClipboardMsgWithoutOutput(t *testing.T) {
+	m := skipIntro(t)
+	if _, cmd := m.Update(ClipboardMsg{Text: "x"}); cmd != nil {
+		t.Error("expected no command when no clipboard output is set")
+	}
+}
+
+func TestTerminalWriteMsgWritesOutsideView(t *testing.T) {
+	var out bytes.Buffer
+	m := skipIntro(t).SetOutput(&out)
+
+	_, cmd := m.Update(TerminalWriteMsg{Seq: "\x1b_Ga=T;AAAA\x1b\\"})
+	if cmd == nil {
+		t.Fatal("expected a write command for TerminalWriteMsg")
+	}
+	cmd()
+	if got := out.String(); got != "\x1b_Ga=T;AAAA\x1b\\" {
+		t.Errorf("terminal output = %q", got)
+	}
+	if _, cmd := m.Update(TerminalWriteMsg{}); cmd != nil {
+		t.Error("an empty write should not produce a command")
+	}
+}
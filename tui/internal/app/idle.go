@@ -1,7 +1,6 @@
 package app
 
 import (
-	"fmt"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -27,36 +26,47 @@ func idleCheckTick() tea.Cmd {
 	})
 }
 
-// resetIdleTimer marks the current time as the last user activity,
-// dismisses any idle warning, and returns true if idle tracking is active.
+// resetIdleTimer marks the current time as the last user activity and
+// dismisses any idle warning, when idle tracking is active.
 func (m *Model) resetIdleTimer() {
-	if m.idleTimeout > 0 {
+	if m.idleTimeout > 0 || m.screensaverAfter > 0 {
 		m.lastActivity = time.Now()
 		m.showIdleWarning = false
 	}
 }
 
 // handleIdleCheck processes an idleCheckMsg: checks elapsed idle time,
-// shows a warning when approaching timeout, or quits on expiry.
-// Returns the updated model and any commands.
+// starts the screensaver, shows a warning when approaching timeout, or
+// quits on expiry. Returns the updated model and any commands.
 func (m Model) handleIdleCheck() (Model, tea.Cmd) {
-	if m.idleTimeout <= 0 {
+	if m.idleTimeout <= 0 && m.screensaverAfter <= 0 {
 		return m, nil
 	}
 
 	elapsed := time.Since(m.lastActivity)
 
-	// Timeout expired: quit the session.
-	if elapsed >= m.idleTimeout {
-		m.logSessionEnd()
-		return m, tea.Quit
+	if m.idleTimeout > 0 {
+		// Timeout expired: quit the session.
+		if elapsed >= m.idleTimeout {
+			m.logSessionEnd()
+			return m, m.quitCmd()
+		}
+
+		// Approaching timeout: show warning.
+		remaining := m.idleTimeout - elapsed
+		if remaining <= idleWarningBefore {
+			m.showIdleWarning = true
+			m.idleRemaining = remaining
+		}
 	}
 
-	// Approaching timeout: show warning.
-	remaining := m.idleTimeout - elapsed
-	if remaining <= idleWarningBefore {
-		m.showIdleWarning = true
-		m.idleRemaining = remaining
+	// The screensaver waits out the intro and an open game, which keep
+	// their own screens busy, and stays off on a slow link.
+	if m.screensaverAfter > 0 && elapsed >= m.screensaverAfter &&
+		m.screensaver == nil && !m.showIntro && m.game == nil && !m.slowLink {
+		var cmd tea.Cmd
+		m, cmd = m.startScreensaver()
+		return m, tea.Batch(idleCheckTick(), cmd)
 	}
 
 	return m, idleCheckTick()
@@ -69,9 +79,9 @@ func (m Model) idleWarningView() string {
 		secs = 0
 	}
 
-	msg := fmt.Sprintf("Idle timeout in %ds — press any key to stay connected", secs)
+	msg := m.locale.T("idle.warning", secs)
 
-	style := lipgloss.NewStyle().
+	style := m.theme.NewStyle().
 		Foreground(m.theme.Colors.Bg).
 		Background(m.theme.Colors.Accent).
 		Bold(true).
@@ -4,6 +4,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/buntingszn/terminal-portfolio/tui/internal/content"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
@@ -18,42 +19,25 @@ const introFinalDelay = 300 * time.Millisecond
 // transitioning out of the intro.
 const introPauseDuration = 500 * time.Millisecond
 
-// bootMessageType identifies the color category for a boot message.
-type bootMessageType string
-
+// Adaptive pacing. Each tick records when it fired; the gap between firing
+// and being handled measures how far the session's event loop (and the
+// renderer writing to the SSH channel) is behind. On slow links that lag is
+// added to the next delay so messages arrive one frame at a time instead of
+// flushing in bursts.
 const (
-	bootSystem  bootMessageType = "system"
-	bootInfo    bootMessageType = "info"
-	bootSuccess bootMessageType = "success"
-	bootAccent  bootMessageType = "accent"
+	// introMaxBackoff caps the extra delay lag can add to a single tick.
+	introMaxBackoff = 600 * time.Millisecond
+
+	// introLagSmoothing weights the newest lag sample in the running
+	// estimate (exponential moving average).
+	introLagSmoothing = 0.5
 )
 
-// bootMessage is a single line in the boot sequence.
-type bootMessage struct {
-	Text string
-	Type bootMessageType
-}
-
-// bootMessages is the embedded boot sequence, matching boot-messages.json.
-var bootMessages = []bootMessage{
-	{Text: "POST: System initialization...", Type: bootSystem},
-	{Text: "BIOS v1.0.0 — terminal-portfolio", Type: bootSystem},
-	{Text: "Memory test: 128GB OK", Type: bootInfo},
-	{Text: "Detecting hardware... AMD Ryzen AI MAX+ 395", Type: bootInfo},
-	{Text: "GPU: Radeon 8060S (gfx1151) — 124GB VRAM allocated", Type: bootInfo},
-	{Text: "Loading content modules...", Type: bootSystem},
-	{Text: "  [OK] about.json", Type: bootSuccess},
-	{Text: "  [OK] work.json", Type: bootSuccess},
-	{Text: "  [OK] cv.json", Type: bootSuccess},
-	{Text: "  [OK] links.json", Type: bootSuccess},
-	{Text: "  [OK] meta.json", Type: bootSuccess},
-	{Text: "Initializing theme engine... warm-minimalist loaded", Type: bootInfo},
-	{Text: "Starting SSH listener on :2222...", Type: bootSystem},
-	{Text: "All systems nominal. Welcome.", Type: bootAccent},
-}
-
-// introTickMsg advances the boot sequence by one message.
-type introTickMsg struct{}
+// introTickMsg advances the boot sequence by one message. sent is the time
+// the tick fired; a zero value means no lag information is available.
+type introTickMsg struct {
+	sent time.Time
+}
 
 // introPauseMsg signals that the post-reveal pause has elapsed.
 type introPauseMsg struct{}
@@ -63,7 +47,7 @@ type IntroDoneMsg struct{}
 
 // IntroModel manages the BIOS/POST boot sequence animation.
 type IntroModel struct {
-	messages []bootMessage
+	messages []content.BootMessage
 	revealed int // number of messages currently visible
 	done     bool
 	paused   bool // true after all messages revealed, waiting before IntroDoneMsg
@@ -71,12 +55,17 @@ type IntroModel struct {
 	theme    Theme
 	width    int
 	height   int
+	lag      time.Duration // smoothed tick handling lag
 }
 
-// NewIntroModel creates an IntroModel ready to animate the boot sequence.
-func NewIntroModel(theme Theme) IntroModel {
+// NewIntroModel creates an IntroModel ready to animate the boot sequence
+// msgs, or the built-in sequence when msgs is empty.
+func NewIntroModel(theme Theme, msgs []content.BootMessage) IntroModel {
+	if len(msgs) == 0 {
+		msgs = content.DefaultBootMessages()
+	}
 	return IntroModel{
-		messages: bootMessages,
+		messages: msgs,
 		theme:    theme,
 		cursor:   NewCursor("intro-cursor", theme),
 	}
@@ -84,18 +73,56 @@ func NewIntroModel(theme Theme) IntroModel {
 
 // Init returns the first tick command to start the boot sequence.
 func (m IntroModel) Init() tea.Cmd {
-	return tea.Tick(introTickInterval, func(_ time.Time) tea.Msg {
-		return introTickMsg{}
+	return introTick(m.delayBefore(0))
+}
+
+// delayBefore returns how long to wait before revealing message i: its
+// own delay when it sets one, otherwise introTickInterval, or
+// introFinalDelay for the last message.
+func (m IntroModel) delayBefore(i int) time.Duration {
+	switch {
+	case m.messages[i].DelayMs > 0:
+		return m.messages[i].Delay()
+	case i == len(m.messages)-1:
+		return introFinalDelay
+	default:
+		return introTickInterval
+	}
+}
+
+// introTick schedules the next boot message after d.
+func introTick(d time.Duration) tea.Cmd {
+	return SubscribeAnimation("intro", d, func(t time.Time) tea.Msg {
+		return introTickMsg{sent: t}
 	})
 }
 
+// observeLag folds the handling delay of a tick fired at sent into the
+// smoothed lag estimate.
+func (m *IntroModel) observeLag(sent time.Time) {
+	if sent.IsZero() {
+		return
+	}
+	sample := time.Since(sent)
+	if sample < 0 {
+		sample = 0
+	}
+	m.lag = time.Duration(float64(m.lag)*(1-introLagSmoothing) + float64(sample)*introLagSmoothing)
+}
+
+// paced stretches a base delay by the current lag estimate, capped at
+// introMaxBackoff, so a lagging session gets time to flush each frame.
+func (m IntroModel) paced(d time.Duration) time.Duration {
+	return d + min(m.lag, introMaxBackoff)
+}
+
 // Update handles tick messages and key presses (skip).
 func (m IntroModel) Update(msg tea.Msg) (IntroModel, tea.Cmd) {
 	if m.done {
 		return m, nil
 	}
 
-	switch msg.(type) {
+	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		// Any key skips the intro (works during both reveal and pause phases).
 		m.revealed = len(m.messages)
@@ -104,26 +131,20 @@ func (m IntroModel) Update(msg tea.Msg) (IntroModel, tea.Cmd) {
 		return m, func() tea.Msg { return IntroDoneMsg{} }
 
 	case introTickMsg:
+		m.observeLag(msg.sent)
 		m.revealed++
 		if m.revealed >= len(m.messages) {
 			// All messages revealed: enter the pause phase with a blinking cursor.
 			m.revealed = len(m.messages)
 			m.paused = true
 			return m, tea.Batch(
-				tea.Tick(introPauseDuration, func(_ time.Time) tea.Msg {
+				SubscribeAnimation("intro", m.paced(introPauseDuration), func(_ time.Time) tea.Msg {
 					return introPauseMsg{}
 				}),
 				m.cursor.Tick(),
 			)
 		}
-		// Use a longer delay before revealing the final message.
-		delay := introTickInterval
-		if m.revealed == len(m.messages)-1 {
-			delay = introFinalDelay
-		}
-		return m, tea.Tick(delay, func(_ time.Time) tea.Msg {
-			return introTickMsg{}
-		})
+		return m, introTick(m.paced(m.delayBefore(m.revealed)))
 
 	case introPauseMsg:
 		// Pause elapsed: complete the intro.
@@ -169,9 +190,7 @@ func (m IntroModel) View() string {
 	for i := startIdx; i < endIdx; i++ {
 		msg := m.messages[i]
 		text := truncateBootMsg(msg.Text, m.width)
-		truncated := bootMessage{Text: text, Type: msg.Type}
-		styled := m.styleMessage(truncated)
-		b.WriteString(styled)
+		b.WriteString(m.styleMessage(msg.Type, text))
 		// Append blinking cursor after the final message during the pause.
 		if m.paused && i == endIdx-1 {
 			b.WriteString(m.cursor.View())
@@ -183,6 +202,12 @@ func (m IntroModel) View() string {
 	return b.String()
 }
 
+// SetTheme replaces the intro's theme, including the blinking cursor color.
+func (m *IntroModel) SetTheme(theme Theme) {
+	m.theme = theme
+	m.cursor.style = theme.NewStyle().Foreground(theme.Colors.Accent)
+}
+
 // SetSize updates the intro model's known terminal dimensions.
 func (m *IntroModel) SetSize(width, height int) {
 	m.width = width
@@ -201,20 +226,44 @@ func truncateBootMsg(text string, maxWidth int) string {
 	return text[:maxWidth-3] + "..."
 }
 
-// styleMessage returns the styled text for a single boot message.
-func (m IntroModel) styleMessage(msg bootMessage) string {
+// styleMessage returns text styled for a boot message of type typ.
+func (m IntroModel) styleMessage(typ, text string) string {
 	var style lipgloss.Style
-	switch msg.Type {
-	case bootSystem:
-		style = lipgloss.NewStyle().Foreground(m.theme.Colors.Fg)
-	case bootInfo:
-		style = lipgloss.NewStyle().Foreground(m.theme.Colors.Muted)
-	case bootSuccess:
-		style = lipgloss.NewStyle().Foreground(m.theme.Colors.Accent)
-	case bootAccent:
-		style = lipgloss.NewStyle().Foreground(m.theme.Colors.Accent).Bold(true)
+	switch typ {
+	case content.BootSystem:
+		style = m.theme.NewStyle().Foreground(m.theme.Colors.Fg)
+	case content.BootInfo:
+		style = m.theme.NewStyle().Foreground(m.theme.Colors.Muted)
+	case content.BootSuccess:
+		style = m.theme.NewStyle().Foreground(m.theme.Colors.Accent)
+	case content.BootAccent:
+		style = m.theme.NewStyle().Foreground(m.theme.Colors.Accent).Bold(true)
 	default:
-		style = lipgloss.NewStyle().Foreground(m.theme.Colors.Fg)
+		style = m.theme.NewStyle().Foreground(m.theme.Colors.Fg)
+	}
+	return style.Render(text)
+}
+
+// SkipIntro starts the session at home without the boot sequence, for
+// visitors who have seen it before. This should be called before Init().
+func (m Model) SkipIntro() Model {
+	return m.StartAt(SectionHome)
+}
+
+// replayIntro plays the boot sequence again over the active section, for
+// :intro. Asking for it plays it even with reduced motion; any key skips
+// it as before, and the section is focused again once it ends.
+func (m Model) replayIntro() (tea.Model, tea.Cmd) {
+	var boot []content.BootMessage
+	if m.content != nil {
+		boot = m.content.BootMessages
 	}
-	return style.Render(msg.Text)
+	var blurCmd tea.Cmd
+	m.sections[m.activeSection], blurCmd = m.sections[m.activeSection].Update(BlurMsg{})
+	m.intro = NewIntroModel(m.theme, boot)
+	m.intro.SetSize(m.width, m.height)
+	m.showIntro = true
+	m.showHelp = false
+	m.linkHints = nil
+	return m, tea.Batch(blurCmd, m.intro.Init())
 }
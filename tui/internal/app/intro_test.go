@@ -0,0 +1,122 @@
+package app
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/buntingszn/terminal-portfolio/tui/internal/content"
+)
+
+func TestIntroPacedWithoutLag(t *testing.T) {
+	m := NewIntroModel(DarkTheme(), nil)
+	if got := m.paced(introTickInterval); got != introTickInterval {
+		t.Errorf("paced() = %v, want %v with no lag observed", got, introTickInterval)
+	}
+}
+
+func TestIntroZeroSentTickIgnoresLag(t *testing.T) {
+	m := NewIntroModel(DarkTheme(), nil)
+	m, _ = m.Update(introTickMsg{})
+	if m.lag != 0 {
+		t.Errorf("lag = %v, want 0 for tick without timestamp", m.lag)
+	}
+	if m.revealed != 1 {
+		t.Errorf("revealed = %d, want 1", m.revealed)
+	}
+}
+
+func TestIntroLagStretchesNextDelay(t *testing.T) {
+	m := NewIntroModel(DarkTheme(), nil)
+	// Simulate a tick that fired 400ms before it was handled.
+	m, _ = m.Update(introTickMsg{sent: time.Now().Add(-400 * time.Millisecond)})
+	if m.lag < 150*time.Millisecond {
+		t.Fatalf("lag = %v, expected smoothed lag of roughly 200ms", m.lag)
+	}
+	if got := m.paced(introTickInterval); got <= introTickInterval {
+		t.Errorf("paced() = %v, want more than base %v under lag", got, introTickInterval)
+	}
+	// Revealing still advances only one message per tick.
+	if m.revealed != 1 {
+		t.Errorf("revealed = %d, want 1", m.revealed)
+	}
+}
+
+func TestIntroBackoffCapped(t *testing.T) {
+	m := NewIntroModel(DarkTheme(), nil)
+	for range 5 {
+		m, _ = m.Update(introTickMsg{sent: time.Now().Add(-10 * time.Second)})
+	}
+	if got, want := m.paced(introTickInterval), introTickInterval+introMaxBackoff; got != want {
+		t.Errorf("paced() = %v, want cap %v", got, want)
+	}
+}
+
+func TestIntroLagRecovers(t *testing.T) {
+	m := NewIntroModel(DarkTheme(), nil)
+	m, _ = m.Update(introTickMsg{sent: time.Now().Add(-time.Second)})
+	high := m.lag
+	for range 6 {
+		m, _ = m.Update(introTickMsg{sent: time.Now()})
+	}
+	if m.lag >= high/10 {
+		t.Errorf("lag = %v, expected it to decay well below %v once ticks are prompt", m.lag, high)
+	}
+}
+
+func TestIntroDefaultMessages(t *testing.T) {
+	m := NewIntroModel(DarkTheme(), nil)
+	if len(m.messages) != len(content.DefaultBootMessages()) {
+		t.Errorf("messages = %d, want the %d built-in ones", len(m.messages), len(content.DefaultBootMessages()))
+	}
+}
+
+func TestIntroMessageDelays(t *testing.T) {
+	m := NewIntroModel(DarkTheme(), []content.BootMessage{
+		{Text: "one", Type: content.BootSystem},
+		{Text: "two", Type: content.BootInfo, DelayMs: 900},
+		{Text: "three", Type: content.BootSuccess},
+		{Text: "four", Type: content.BootAccent},
+	})
+	want := []time.Duration{introTickInterval, 900 * time.Millisecond, introTickInterval, introFinalDelay}
+	for i, d := range want {
+		if got := m.delayBefore(i); got != d {
+			t.Errorf("delayBefore(%d) = %v, want %v", i, got, d)
+		}
+	}
+}
+
+func TestModelSkipIntro(t *testing.T) {
+	m := New(testContent()).SkipIntro()
+	if m.showIntro {
+		t.Fatal("SkipIntro should leave the intro out")
+	}
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	m = result.(Model)
+	if m.activeSection != SectionHome || strings.Contains(stripANSI(m.statusView()), "Welcome back") {
+		t.Errorf("session starts at %v with %q, want home without a welcome back", m.activeSection, stripANSI(m.statusView()))
+	}
+}
+
+func TestPaletteIntroCommand(t *testing.T) {
+	m := skipIntro(t)
+	result, cmd := m.Update(PaletteResultMsg{Action: PaletteIntro})
+	m = result.(Model)
+	if !m.showIntro || cmd == nil {
+		t.Fatal(":intro should replay the boot sequence")
+	}
+	if m.intro.revealed != 0 || m.intro.width != 80 {
+		t.Errorf("replayed intro = %d revealed, width %d; want a fresh one sized to the terminal", m.intro.revealed, m.intro.width)
+	}
+
+	// A key skips the replay and returns to the section.
+	result, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(" ")})
+	m = result.(Model)
+	result, _ = m.Update(IntroDoneMsg{})
+	m = result.(Model)
+	if m.showIntro || m.activeSection != SectionHome {
+		t.Error("skipping the replay should return to the section")
+	}
+}
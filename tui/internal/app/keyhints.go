@@ -0,0 +1,98 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/buntingszn/terminal-portfolio/tui/internal/i18n"
+)
+
+// KeyHint is one entry of the key hints a section shows in the status
+// bar, such as "j/k scroll". Its keys are labeled as the key map binds
+// them, as in the help overlay, so a custom map shows in the hints too.
+type KeyHint struct {
+	// Actions are the hinted keys, labeled together, as in "j/k".
+	Actions []KeyAction
+	// Keys labels keys that are no action's, such as "1-9" for item picks.
+	Keys string
+	// Text says what the keys do. A hint without keys is shown alone, as
+	// feedback such as "Copied!" is.
+	Text string
+	// nav marks NavHint, whose range follows the tabs shown.
+	nav bool
+}
+
+// Hint returns the hint for the keys bound to actions.
+func Hint(text string, actions ...KeyAction) KeyHint {
+	return KeyHint{Actions: actions, Text: text}
+}
+
+// Feedback returns hints that show text alone, in place of the keys.
+func Feedback(text string) []KeyHint {
+	return []KeyHint{{Text: text}}
+}
+
+// NavHint returns the hint, worded in l, for jumping to a section by the
+// number on its tab: "1-5 nav" with five tabs shown.
+func NavHint(l *i18n.Locale) KeyHint {
+	return KeyHint{Text: l.T("hint.nav"), nav: true}
+}
+
+// HelpHint returns the hint, worded in l, for the help overlay.
+func HelpHint(l *i18n.Locale) KeyHint {
+	return Hint(l.T("hint.help"), KeyHelp)
+}
+
+// FormatKeyHints joins hints into the status bar's hint line, labeling
+// their keys as km binds them. tabs is the number of tabs shown, whose
+// digit keys NavHint names. A hint for an unbound action is left out.
+func FormatKeyHints(hints []KeyHint, km KeyMap, tabs int) string {
+	parts := make([]string, 0, len(hints))
+	for _, h := range hints {
+		keys := h.Keys
+		switch {
+		case h.nav:
+			if tabs < 2 {
+				continue
+			}
+			keys = jumpRange(tabs)
+		case len(h.Actions) > 0:
+			labels := make([]string, len(h.Actions))
+			for i, a := range h.Actions {
+				bound := km.Keys(a)
+				if len(bound) == 0 {
+					labels = nil
+					break
+				}
+				labels[i] = keyLabel(bound[0])
+			}
+			if labels == nil {
+				continue
+			}
+			keys = strings.Join(labels, "/")
+		}
+		if keys == "" {
+			parts = append(parts, h.Text)
+		} else {
+			parts = append(parts, keys+" "+h.Text)
+		}
+	}
+	return strings.Join(parts, " "+BorderVertical+" ")
+}
+
+// jumpRange labels the digit keys that reach the first tabs tabs, up to
+// the ninth, as "1-5".
+func jumpRange(tabs int) string {
+	return fmt.Sprintf("1-%d", min(tabs, 9))
+}
+
+// tabCount returns the number of tabs shown, those not hidden.
+func tabCount(hidden []bool) int {
+	n := 0
+	for _, h := range hidden {
+		if !h {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,34 @@
+package app
+
+import "testing"
+
+func TestFormatKeyHints(t *testing.T) {
+	hints := []KeyHint{
+		Hint("scroll", KeyScrollDown, KeyScrollUp),
+		Hint("download", KeyDownload),
+		{Keys: "1-9", Text: "pick"},
+		NavHint(nil),
+		HelpHint(nil),
+	}
+	remapped, _ := ParseKeyMap([]byte(`{"scroll-down": ["down"], "scroll-up": ["up"], "download": ["D"], "help": []}`))
+	tests := []struct {
+		name string
+		km   KeyMap
+		tabs int
+		want string
+	}{
+		{"defaults", DefaultKeyMap(), 5, "j/k scroll │ d download │ 1-9 pick │ 1-5 nav │ ? help"},
+		{"hidden tabs", DefaultKeyMap(), 3, "j/k scroll │ d download │ 1-9 pick │ 1-3 nav │ ? help"},
+		{"many tabs", DefaultKeyMap(), 11, "j/k scroll │ d download │ 1-9 pick │ 1-9 nav │ ? help"},
+		{"one tab", DefaultKeyMap(), 1, "j/k scroll │ d download │ 1-9 pick │ ? help"},
+		{"remapped", remapped, 5, "↓/↑ scroll │ D download │ 1-9 pick │ 1-5 nav"},
+	}
+	for _, tt := range tests {
+		if got := FormatKeyHints(hints, tt.km, tt.tabs); got != tt.want {
+			t.Errorf("%s: FormatKeyHints = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+	if got := FormatKeyHints(Feedback("Copied!"), DefaultKeyMap(), 5); got != "Copied!" {
+		t.Errorf("feedback = %q, want Copied!", got)
+	}
+}
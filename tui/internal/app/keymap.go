@@ -0,0 +1,261 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// KeyMapFile is the optional key binding file looked up in the data
+// directory.
+const KeyMapFile = "keymap.json"
+
+// KeyAction is a logical action keys are bound to, such as "scroll-down".
+type KeyAction string
+
+// Key actions. The global ones are handled by the root model; the rest
+// are the keys sections scroll and pick with, and the shortcuts of
+// single sections, such as the CV download.
+const (
+	KeyQuit         KeyAction = "quit"
+	KeyHelp         KeyAction = "help"
+	KeyPalette      KeyAction = "palette"
+	KeyTheme        KeyAction = "theme"
+	KeyLinkHints    KeyAction = "link-hints"
+	KeyItemNumbers  KeyAction = "item-numbers"
+	KeyNavNext      KeyAction = "nav-next"
+	KeyNavPrev      KeyAction = "nav-prev"
+	KeyPaneNext     KeyAction = "pane-next"
+	KeyPanePrev     KeyAction = "pane-prev"
+	KeyHeadingNext  KeyAction = "heading-next"
+	KeyHeadingPrev  KeyAction = "heading-prev"
+	KeyScrollDown   KeyAction = "scroll-down"
+	KeyScrollUp     KeyAction = "scroll-up"
+	KeyScrollLeft   KeyAction = "scroll-left"
+	KeyScrollRight  KeyAction = "scroll-right"
+	KeyTop          KeyAction = "top"
+	KeyBottom       KeyAction = "bottom"
+	KeyPageDown     KeyAction = "page-down"
+	KeyPageUp       KeyAction = "page-up"
+	KeyHalfPageDown KeyAction = "half-page-down"
+	KeyHalfPageUp   KeyAction = "half-page-up"
+	KeySelect       KeyAction = "select"
+	KeyBack         KeyAction = "back"
+	KeyMark         KeyAction = "mark"
+	KeyDownload     KeyAction = "download"
+	KeyTimeline     KeyAction = "timeline"
+	KeyBooking      KeyAction = "booking"
+	KeySign         KeyAction = "sign"
+	KeyReload       KeyAction = "reload"
+)
+
+// keyBinding is an action's default keys, in the form tea.KeyMsg.String
+// gives them. Section actions also have the key the sections are written
+// against, which keys bound to the action are delivered as.
+type keyBinding struct {
+	action  KeyAction
+	keys    []string
+	section *tea.KeyMsg
+}
+
+// runeKey returns the key message for typing r.
+func runeKey(r rune) *tea.KeyMsg {
+	return &tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}}
+}
+
+// typeKey returns the key message for the special key t.
+func typeKey(t tea.KeyType) *tea.KeyMsg {
+	return &tea.KeyMsg{Type: t}
+}
+
+// keyBindings lists every action with its defaults. Where a key is bound
+// to more than one section action, the first listed wins.
+var keyBindings = []keyBinding{
+	{KeyQuit, []string{"q", "ctrl+c"}, nil},
+	{KeyHelp, []string{"?"}, nil},
+	{KeyPalette, []string{":"}, nil},
+	{KeyTheme, []string{"t"}, nil},
+	{KeyLinkHints, []string{"f"}, nil},
+	{KeyItemNumbers, []string{"0"}, nil},
+	{KeyNavNext, []string{"right", "tab"}, nil},
+	{KeyNavPrev, []string{"left", "shift+tab"}, nil},
+	{KeyPaneNext, []string{"tab"}, nil},
+	{KeyPanePrev, []string{"shift+tab"}, nil},
+	{KeyHeadingNext, []string{"]"}, nil},
+	{KeyHeadingPrev, []string{"["}, nil},
+	{KeyScrollDown, []string{"j", "down"}, runeKey('j')},
+	{KeyScrollUp, []string{"k", "up"}, runeKey('k')},
+	{KeyScrollLeft, []string{"h", "left"}, runeKey('h')},
+	{KeyScrollRight, []string{"l", "right"}, runeKey('l')},
+	{KeyTop, []string{"g", "home"}, runeKey('g')},
+	{KeyBottom, []string{"G", "end"}, runeKey('G')},
+	{KeyPageDown, []string{"pgdown"}, typeKey(tea.KeyPgDown)},
+	{KeyPageUp, []string{"pgup"}, typeKey(tea.KeyPgUp)},
+	{KeyHalfPageDown, []string{"ctrl+d"}, typeKey(tea.KeyCtrlD)},
+	{KeyHalfPageUp, []string{"ctrl+u"}, typeKey(tea.KeyCtrlU)},
+	{KeySelect, []string{"enter"}, typeKey(tea.KeyEnter)},
+	{KeyBack, []string{"esc"}, typeKey(tea.KeyEsc)},
+	{KeyMark, []string{" "}, typeKey(tea.KeySpace)},
+	{KeyDownload, []string{"d"}, runeKey('d')},
+	{KeyTimeline, []string{"v"}, runeKey('v')},
+	{KeyBooking, []string{"b"}, runeKey('b')},
+	{KeySign, []string{"s"}, runeKey('s')},
+	{KeyReload, []string{"r"}, runeKey('r')},
+}
+
+// KeyMap binds keys to actions, like the bindings of bubbles/key, so a
+// deployment can swap the vim-style defaults for arrows-only or emacs-style
+// keys. The zero value has the default bindings.
+//
+// Sections are written against the default keys. The root model matches
+// global actions itself and hands sections any key bound to a section
+// action as that action's default key, so they follow the map too.
+type KeyMap struct {
+	// overrides replaces the default keys of the actions it has; an empty
+	// list unbinds the action.
+	overrides map[KeyAction][]string
+}
+
+// DefaultKeyMap returns the built-in bindings.
+func DefaultKeyMap() KeyMap {
+	return KeyMap{}
+}
+
+// ParseKeyMap reads a JSON object mapping action names to key lists, such
+// as {"scroll-down": ["down", "ctrl+n"]}. Actions it leaves out keep their
+// defaults.
+func ParseKeyMap(data []byte) (KeyMap, error) {
+	var raw map[string][]string
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return KeyMap{}, err
+	}
+	km := KeyMap{overrides: make(map[KeyAction][]string, len(raw))}
+	for name, keys := range raw {
+		action := KeyAction(name)
+		if !slices.ContainsFunc(keyBindings, func(b keyBinding) bool { return b.action == action }) {
+			return KeyMap{}, fmt.Errorf("unknown action %q; use one of %s", name, strings.Join(keyActionNames(), ", "))
+		}
+		for _, k := range keys {
+			if k == "" {
+				return KeyMap{}, fmt.Errorf("%s: empty key", name)
+			}
+		}
+		km.overrides[action] = keys
+	}
+	return km, nil
+}
+
+// LoadKeyMap reads the key map at path with ParseKeyMap. A missing file
+// returns the defaults with an error satisfying errors.Is(err,
+// os.ErrNotExist).
+func LoadKeyMap(path string) (KeyMap, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return DefaultKeyMap(), err
+	}
+	km, err := ParseKeyMap(data)
+	if err != nil {
+		return DefaultKeyMap(), fmt.Errorf("%s: %w", path, err)
+	}
+	return km, nil
+}
+
+// keyActionNames returns the action names in sorted order.
+func keyActionNames() []string {
+	names := make([]string, len(keyBindings))
+	for i, b := range keyBindings {
+		names[i] = string(b.action)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Keys returns the keys bound to action.
+func (k KeyMap) Keys(action KeyAction) []string {
+	if keys, ok := k.overrides[action]; ok {
+		return keys
+	}
+	for _, b := range keyBindings {
+		if b.action == action {
+			return b.keys
+		}
+	}
+	return nil
+}
+
+// Matches reports whether msg is one of the keys bound to action.
+func (k KeyMap) Matches(msg tea.KeyMsg, action KeyAction) bool {
+	return k.bound(msg.String(), action)
+}
+
+// bound reports whether key, named as tea.KeyMsg.String names it, is
+// bound to action.
+func (k KeyMap) bound(key string, action KeyAction) bool {
+	return slices.Contains(k.Keys(action), key)
+}
+
+// forSection returns msg as the sections know it: a key bound to a
+// section action becomes that action's default key. It reports false for
+// a default key the map has moved elsewhere, which no section should see.
+// Other keys, such as a section's own shortcuts, pass through as they are.
+func (k KeyMap) forSection(msg tea.KeyMsg) (tea.KeyMsg, bool) {
+	for _, b := range keyBindings {
+		if b.section != nil && k.Matches(msg, b.action) {
+			return *b.section, true
+		}
+	}
+	for _, b := range keyBindings {
+		if b.section != nil && slices.Contains(b.keys, msg.String()) {
+			return msg, false
+		}
+	}
+	return msg, true
+}
+
+// label returns the help overlay's name for the first key of each action,
+// separated by slashes, such as "j / k".
+func (k KeyMap) label(actions ...KeyAction) string {
+	names := make([]string, len(actions))
+	for i, a := range actions {
+		names[i] = "-"
+		if keys := k.Keys(a); len(keys) > 0 {
+			names[i] = keyLabel(keys[0])
+		}
+	}
+	return strings.Join(names, " / ")
+}
+
+// Label returns the name key hints give the first key bound to action,
+// such as "s" or "↑", for sections that mention a key in their text.
+func (k KeyMap) Label(action KeyAction) string {
+	return k.label(action)
+}
+
+// keyLabel returns the short name the help overlay shows for key.
+func keyLabel(key string) string {
+	switch key {
+	case "left":
+		return "←"
+	case "right":
+		return "→"
+	case "up":
+		return "↑"
+	case "down":
+		return "↓"
+	case "pgup":
+		return "PgUp"
+	case "pgdown":
+		return "PgDn"
+	case " ":
+		return "space"
+	}
+	if rest, ok := strings.CutPrefix(key, "ctrl+"); ok {
+		return "^" + rest
+	}
+	return key
+}
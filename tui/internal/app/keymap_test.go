@@ -0,0 +1,198 @@
+package app
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/i18n"
+)
+
+// keySection records the keys it is handed.
+type keySection struct {
+	placeholderSection
+	got []string
+}
+
+func (s *keySection) Update(msg tea.Msg) (SectionModel, tea.Cmd) {
+	if k, ok := msg.(tea.KeyMsg); ok {
+		s.got = append(s.got, k.String())
+	}
+	return s, nil
+}
+
+// keyMapModel returns a model past its intro with km and a keySection
+// as the home section.
+func keyMapModel(t *testing.T, km KeyMap) (Model, *keySection) {
+	t.Helper()
+	sec := &keySection{placeholderSection: placeholderSection{name: "home", theme: DarkTheme()}}
+	m := New(testContent(), sec).SetKeyMap(km)
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	m = result.(Model)
+	result, _ = m.Update(IntroDoneMsg{})
+	return result.(Model), sec
+}
+
+func TestParseKeyMap(t *testing.T) {
+	km, err := ParseKeyMap([]byte(`{"scroll-down": ["ctrl+n", "down"], "help": []}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := km.Keys(KeyScrollDown); strings.Join(got, ",") != "ctrl+n,down" {
+		t.Errorf("scroll-down keys = %q", got)
+	}
+	if got := km.Keys(KeyHelp); len(got) != 0 {
+		t.Errorf("help keys = %q, want unbound", got)
+	}
+	if got := km.Keys(KeyQuit); strings.Join(got, ",") != "q,ctrl+c" {
+		t.Errorf("quit keys = %q, want the defaults", got)
+	}
+
+	for _, bad := range []string{
+		`{"scroll-sideways": ["x"]}`,
+		`{"quit": [""]}`,
+		`{"quit": "q"}`,
+		`not json`,
+	} {
+		if _, err := ParseKeyMap([]byte(bad)); err == nil {
+			t.Errorf("ParseKeyMap(%s) should fail", bad)
+		}
+	}
+}
+
+func TestLoadKeyMap(t *testing.T) {
+	dir := t.TempDir()
+	km, err := LoadKeyMap(filepath.Join(dir, KeyMapFile))
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("missing file: err = %v, want os.ErrNotExist", err)
+	}
+	if got := km.Keys(KeyNavNext); strings.Join(got, ",") != "right,tab" {
+		t.Errorf("missing file: nav-next keys = %q, want the defaults", got)
+	}
+
+	path := filepath.Join(dir, KeyMapFile)
+	if err := os.WriteFile(path, []byte(`{"quit": ["x"]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if km, err = LoadKeyMap(path); err != nil || !km.Matches(keyMsg("x"), KeyQuit) {
+		t.Errorf("LoadKeyMap = %v, %v; want x to quit", km, err)
+	}
+}
+
+func TestKeyMapRemapsSectionKeys(t *testing.T) {
+	km, err := ParseKeyMap([]byte(`{"scroll-down": ["ctrl+n"], "scroll-up": ["ctrl+p"]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, sec := keyMapModel(t, km)
+	for _, k := range []tea.KeyMsg{
+		{Type: tea.KeyCtrlN},
+		{Type: tea.KeyCtrlP},
+		keyMsg("j"),
+		keyMsg("x"),
+	} {
+		result, _ := m.Update(k)
+		m = result.(Model)
+	}
+	// The new keys arrive as the keys sections know, j no longer scrolls,
+	// and keys the map does not name pass through.
+	if got := strings.Join(sec.got, ","); got != "j,k,x" {
+		t.Errorf("section got %q, want j,k,x", got)
+	}
+}
+
+// keyMapSpy records the key map of the last KeyMapChangedMsg.
+type keyMapSpy struct {
+	spySection
+	keys *KeyMap
+}
+
+func (s *keyMapSpy) Update(msg tea.Msg) (SectionModel, tea.Cmd) {
+	if kc, ok := msg.(KeyMapChangedMsg); ok {
+		s.keys = &kc.Keys
+	}
+	return s, nil
+}
+
+func TestKeyMapReachesSections(t *testing.T) {
+	km, err := ParseKeyMap([]byte(`{"sign": ["S"]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	spy := &keyMapSpy{}
+	New(testContent(), spy).SetKeyMap(km)
+	if spy.keys == nil {
+		t.Fatal("SetKeyMap did not tell the sections")
+	}
+	if got := spy.keys.Label(KeySign); got != "S" {
+		t.Errorf("sections see sign on %q, want S", got)
+	}
+}
+
+func TestKeyMapRemapsGlobalKeys(t *testing.T) {
+	km, err := ParseKeyMap([]byte(`{"quit": ["ctrl+q"], "nav-next": ["n"]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, _ := keyMapModel(t, km)
+	if _, cmd := m.Update(keyMsg("q")); cmd != nil {
+		t.Error("q should no longer quit")
+	}
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlQ}); cmd == nil {
+		t.Error("ctrl+q should quit")
+	}
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlC}); cmd == nil {
+		t.Error("ctrl+c should always quit")
+	}
+
+	result, _ := m.Update(keyMsg("n"))
+	m = drainTransition(t, result.(Model))
+	if m.activeSection != SectionWork {
+		t.Errorf("n went to %v, want work", m.activeSection)
+	}
+	result, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if m = drainTransition(t, result.(Model)); m.activeSection != SectionWork {
+		t.Errorf("tab went to %v, want it unbound", m.activeSection)
+	}
+}
+
+func TestHelpShortcutsFollowKeyMap(t *testing.T) {
+	// labels maps each shortcut's description to its key label.
+	var en *i18n.Locale
+	labels := func(km KeyMap) map[string]string {
+		got := make(map[string]string)
+		for _, sc := range helpShortcuts(5, en, km) {
+			got[sc.desc] = sc.key
+		}
+		return got
+	}
+	defaults := labels(DefaultKeyMap())
+	for desc, want := range map[string]string{
+		"help.sections": "← / →",
+		"help.scroll":   "j / k",
+		"help.halfpage": "^u / ^d",
+		"help.mark":     "space",
+		"help.pgdn":     "PgDn",
+		"help.jump":     "1-5",
+		"help.download": "d",
+	} {
+		if got := defaults[en.T(desc)]; got != want {
+			t.Errorf("default %s label = %q, want %q", desc, got, want)
+		}
+	}
+
+	km, _ := ParseKeyMap([]byte(`{"scroll-down": ["down"], "scroll-up": ["up"], "help": [], "download": ["D"]}`))
+	remapped := labels(km)
+	if got := remapped[en.T("help.scroll")]; got != "↓ / ↑" {
+		t.Errorf("remapped scroll label = %q", got)
+	}
+	if got := remapped[en.T("help.download")]; got != "D" {
+		t.Errorf("remapped download label = %q", got)
+	}
+	if got := remapped[en.T("help.help")]; got != "-" {
+		t.Errorf("unbound help label = %q, want -", got)
+	}
+}
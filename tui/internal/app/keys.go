@@ -0,0 +1,98 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/content"
+)
+
+// openKeys shows the public keys overlay, or says there are no keys.
+func (m Model) openKeys() (tea.Model, tea.Cmd) {
+	if m.content == nil || len(m.content.Keys) == 0 {
+		return m.showNotice("No public keys")
+	}
+	m.showKeys = true
+	return m, nil
+}
+
+// handleKeysKey copies the key a 1-9 press picks, or every key on enter,
+// and closes the overlay. Any other key just closes it.
+func (m Model) handleKeysKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.showKeys = false
+	keys := m.content.Keys
+	if i, ok := ItemNumber(msg); ok && i < len(keys) {
+		next, notice := m.showNotice(keys[i].Label + " key copied!")
+		return next, tea.Batch(CopyToClipboard(keys[i].Key+"\n"), notice)
+	}
+	if msg.Type == tea.KeyEnter {
+		all := make([]string, len(keys))
+		for i, k := range keys {
+			all[i] = k.Key + "\n"
+		}
+		next, notice := m.showNotice(fmt.Sprintf("%d keys copied!", len(keys)))
+		return next, tea.Batch(CopyToClipboard(strings.Join(all, "\n")), notice)
+	}
+	return m, nil
+}
+
+// keysView renders the public keys overlay: each key's label and type,
+// its fingerprint in bold accent so it can be checked at a glance, and
+// the start of the key itself.
+func (m Model) keysView() string {
+	cardWidth := 72
+	if m.width > 0 && m.width < cardWidth {
+		cardWidth = m.width
+	}
+	inner := cardWidth - 4
+	if inner < 10 {
+		inner = 10
+	}
+
+	fpStyle := m.theme.Accent.Bold(true)
+	var lines []string
+	for i, k := range m.content.Keys {
+		if i > 0 {
+			lines = append(lines, "")
+		}
+		head := fmt.Sprintf("%d ", i+1)
+		lines = append(lines, m.theme.Muted.Render(head)+m.theme.Body.Render(k.Label)+m.theme.Muted.Render(" · "+keyKind(k)))
+		lines = append(lines, fpStyle.Render(TruncateWithEllipsis(k.Fingerprint, inner)))
+		lines = append(lines, m.theme.Muted.Render(TruncateWithEllipsis(keyPreview(k), inner)))
+	}
+	lines = append(lines, "")
+	lines = append(lines, m.theme.Muted.Render("1-9 copy a key · enter copy all · any other key to dismiss"))
+
+	body := strings.Join(lines, "\n")
+	if cardWidth < 10 || m.width < 10 || m.height < 10 {
+		return m.theme.Title.Render("Public Keys") + "\n\n" + body
+	}
+	card := RenderCard(m.theme, "Public Keys", body, cardWidth)
+	return lipgloss.Place(
+		m.width, m.height,
+		lipgloss.Center, lipgloss.Center,
+		card,
+		lipgloss.WithWhitespaceChars("·"),
+		lipgloss.WithWhitespaceForeground(m.theme.Colors.Border),
+	)
+}
+
+// keyKind names the kind of key, such as "PGP" or "SSH ed25519".
+func keyKind(k content.PublicKey) string {
+	if k.Type != content.KeySSH {
+		return "PGP"
+	}
+	algo, _, _ := strings.Cut(k.Key, " ")
+	return "SSH " + strings.TrimPrefix(algo, "ssh-")
+}
+
+// keyPreview returns the line shown under a key's fingerprint: an SSH key
+// as is, since it fits on one line, or a PGP key's size in lines.
+func keyPreview(k content.PublicKey) string {
+	if k.Type == content.KeySSH {
+		return k.Key
+	}
+	return fmt.Sprintf("ASCII-armored public key, %d lines", strings.Count(k.Key, "\n")+1)
+}
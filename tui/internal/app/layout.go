@@ -0,0 +1,123 @@
+package app
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// layoutDigits maps what other keyboard layouts send from the number row
+// to the digit printed on the key: unshifted AZERTY, where the digits
+// need shift, and shifted US QWERTY, for visitors who hold it. '&' and '('
+// are on both rows; AZERTY's reading wins, since US visitors can press
+// the digits themselves.
+var layoutDigits = map[rune]rune{
+	'&': '1', 'é': '2', '"': '3', '\'': '4', '(': '5', '-': '6', 'è': '7', '_': '8', 'ç': '9', 'à': '0',
+	'!': '1', '@': '2', '#': '3', '$': '4', '%': '5', '^': '6', '*': '8', ')': '0',
+}
+
+// Digit returns the number-row digit a key press stands for, so digit
+// shortcuts work whatever the visitor's keyboard layout: a digit as
+// typed, or the digit of a symbol in layoutDigits. Keys typed with alt
+// are not digits.
+func Digit(msg tea.KeyMsg) (rune, bool) {
+	if msg.Type != tea.KeyRunes || len(msg.Runes) != 1 || msg.Alt {
+		return 0, false
+	}
+	r := msg.Runes[0]
+	if r >= '0' && r <= '9' {
+		return r, true
+	}
+	d, ok := layoutDigits[r]
+	return d, ok
+}
+
+// kittyKey is a key press reported with the kitty keyboard protocol
+// (CSI code[:shifted[:base]][;mods[:event]]u), which names the key's
+// position on a US layout as its base key.
+type kittyKey struct {
+	text rune // what the press types
+	base rune // the US layout key in the same place, or 0 if not reported
+}
+
+// csiSequence returns the escape sequence of msg if it is one bubbletea
+// could not read. Its type for them is unexported; the sequence is its
+// underlying []byte.
+func csiSequence(msg tea.Msg) (string, bool) {
+	if fmt.Sprintf("%T", msg) != "tea.unknownCSISequenceMsg" {
+		return "", false
+	}
+	return string(reflect.ValueOf(msg).Bytes()), true
+}
+
+// parseKittyKey reads seq as a kitty protocol key press. The server does
+// not ask for the protocol, whose other events bubbletea cannot read, but
+// terminals set to always use it, or left in it by another program, send
+// their presses this way, and bubbletea passes them on as sequences it
+// does not know. Releases, and presses with modifiers other than shift,
+// are not key presses the TUI acts on.
+func parseKittyKey(seq string) (kittyKey, bool) {
+	params, ok := strings.CutPrefix(seq, "\x1b[")
+	if !ok {
+		return kittyKey{}, false
+	}
+	if params, ok = strings.CutSuffix(params, "u"); !ok {
+		return kittyKey{}, false
+	}
+
+	fields := strings.Split(params, ";")
+	keys := strings.Split(fields[0], ":")
+	mods, event := 1, 1
+	if len(fields) > 1 {
+		m := strings.Split(fields[1], ":")
+		mods = atoiOr(m[0], 1)
+		if len(m) > 1 {
+			event = atoiOr(m[1], 1)
+		}
+	}
+	// mods is 1 plus a bit set in which shift is 1.
+	shift := (mods-1)&1 != 0
+	if (mods-1)&^1 != 0 || event == 3 {
+		return kittyKey{}, false
+	}
+
+	k := kittyKey{text: rune(atoiOr(keys[0], 0))}
+	if shift && len(keys) > 1 && keys[1] != "" {
+		k.text = rune(atoiOr(keys[1], 0))
+	}
+	if len(keys) > 2 {
+		k.base = rune(atoiOr(keys[2], 0))
+	}
+	// Control keys and the functional keys, which the protocol numbers in
+	// the private use area, are not text.
+	if k.text < ' ' || (k.text >= 0xe000 && k.text <= 0xf8ff) {
+		return kittyKey{}, false
+	}
+	return k, true
+}
+
+// keyMsg returns the press as bubbletea reports typed text. With digit
+// set, a press of a number-row key reports its digit wherever the
+// visitor's layout puts other characters there.
+func (k kittyKey) keyMsg(digit bool) tea.KeyMsg {
+	r := k.text
+	if digit && k.base >= '0' && k.base <= '9' {
+		r = k.base
+	}
+	if r == ' ' {
+		return tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{r}}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}}
+}
+
+// atoiOr parses s as a decimal number, or returns def if it is not one.
+func atoiOr(s string, def int) int {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return def
+	}
+	return n
+}
@@ -0,0 +1,118 @@
+package app
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestDigit(t *testing.T) {
+	for _, tt := range []struct {
+		key  tea.KeyMsg
+		want rune
+		ok   bool
+	}{
+		{tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("3")}, '3', true},
+		{tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("&")}, '1', true}, // AZERTY
+		{tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("é")}, '2', true}, // AZERTY
+		{tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("à")}, '0', true}, // AZERTY
+		{tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("@")}, '2', true}, // US with shift
+		{tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("3"), Alt: true}, 0, false},
+		{tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")}, 0, false},
+		{tea.KeyMsg{Type: tea.KeyEnter}, 0, false},
+	} {
+		if got, ok := Digit(tt.key); got != tt.want || ok != tt.ok {
+			t.Errorf("Digit(%q) = %q, %v; want %q, %v", tt.key, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestItemNumberLayouts(t *testing.T) {
+	if i, ok := ItemNumber(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("'")}); !ok || i != 3 {
+		t.Errorf("AZERTY 4 = %d, %v; want item 3", i, ok)
+	}
+	if _, ok := ItemNumber(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("à")}); ok {
+		t.Error("0 should not pick an item")
+	}
+}
+
+func TestParseKittyKey(t *testing.T) {
+	for _, tt := range []struct {
+		seq  string
+		want kittyKey
+		ok   bool
+	}{
+		{"\x1b[38::49u", kittyKey{text: '&', base: '1'}, true},
+		{"\x1b[38:49:49;2u", kittyKey{text: '1', base: '1'}, true}, // with shift
+		{"\x1b[283::50u", kittyKey{text: 'ě', base: '2'}, true},    // Czech
+		{"\x1b[97u", kittyKey{text: 'a'}, true},
+		{"\x1b[38::49;1:3u", kittyKey{}, false}, // release
+		{"\x1b[97;5u", kittyKey{}, false},       // ctrl+a
+		{"\x1b[27u", kittyKey{}, false},         // escape
+		{"\x1b[57399u", kittyKey{}, false},      // keypad 0
+		{"\x1b[1;2A", kittyKey{}, false},
+	} {
+		if got, ok := parseKittyKey(tt.seq); got != tt.want || ok != tt.ok {
+			t.Errorf("parseKittyKey(%q) = %+v, %v; want %+v, %v", tt.seq, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+// seqModel records the first message bubbletea could not read as a key.
+type seqModel struct{ got chan<- string }
+
+func (s seqModel) Init() tea.Cmd { return nil }
+
+func (s seqModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if seq, ok := csiSequence(msg); ok {
+		s.got <- seq
+		return s, tea.Quit
+	}
+	return s, nil
+}
+
+func (s seqModel) View() string { return "" }
+
+// TestCSISequenceFromBubbletea checks that kitty presses still reach the
+// model the way csiSequence expects, since it relies on bubbletea's
+// unexported message type.
+func TestCSISequenceFromBubbletea(t *testing.T) {
+	got := make(chan string, 1)
+	p := tea.NewProgram(seqModel{got},
+		tea.WithInput(strings.NewReader("\x1b[38::49u")),
+		tea.WithOutput(io.Discard),
+		tea.WithoutRenderer(),
+		tea.WithoutSignals())
+	go func() { _, _ = p.Run() }()
+	defer p.Kill()
+	select {
+	case seq := <-got:
+		if seq != "\x1b[38::49u" {
+			t.Errorf("sequence = %q", seq)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("bubbletea did not pass the kitty press on as an unknown sequence")
+	}
+}
+
+func TestKittyKeyMsg(t *testing.T) {
+	k := kittyKey{text: '&', base: '1'}
+	if got := k.keyMsg(true).String(); got != "1" {
+		t.Errorf("a number-row press = %q, want its digit", got)
+	}
+	if got := k.keyMsg(false).String(); got != "&" {
+		t.Errorf("a number-row press while typing = %q, want what it types", got)
+	}
+}
+
+func TestLayoutDigitsNavigate(t *testing.T) {
+	m := skipIntro(t)
+	result, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(`"`)})
+	m = drainTransition(t, result.(Model))
+	if m.activeSection != SectionCV {
+		t.Errorf("AZERTY 3 went to section %d, want the CV", m.activeSection)
+	}
+}
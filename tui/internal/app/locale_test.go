@@ -0,0 +1,105 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/buntingszn/terminal-portfolio/tui/internal/content"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/i18n"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// contentSpy records the content of the last ContentChangedMsg.
+type contentSpy struct {
+	spySection
+	content *content.Content
+}
+
+func (s *contentSpy) Update(msg tea.Msg) (SectionModel, tea.Cmd) {
+	if cc, ok := msg.(ContentChangedMsg); ok {
+		s.content = cc.Content
+	}
+	return s, nil
+}
+
+// localeSpy records the locale of the last LocaleChangedMsg.
+type localeSpy struct {
+	spySection
+	locale *i18n.Locale
+}
+
+func (s *localeSpy) Update(msg tea.Msg) (SectionModel, tea.Cmd) {
+	if lc, ok := msg.(LocaleChangedMsg); ok {
+		s.locale = lc.Locale
+	}
+	return s, nil
+}
+
+func TestLocaleReachesSections(t *testing.T) {
+	de, _ := i18n.Lookup("de")
+	spy := &localeSpy{}
+	m := New(testContent(), spy).SetLocale(de)
+	if spy.locale != de {
+		t.Fatalf("SetLocale gave sections %v, want de", spy.locale)
+	}
+	m.Update(PaletteResultMsg{Action: PaletteLang, Target: "en"})
+	if spy.locale == nil || spy.locale.Tag != "en" {
+		t.Errorf(":lang en gave sections %v, want en", spy.locale)
+	}
+}
+
+func TestSetLocaleTranslatesChrome(t *testing.T) {
+	de, _ := i18n.Lookup("de")
+	m := skipIntro(t).SetLocale(de)
+	if got := stripANSI(m.statusView()); !strings.Contains(got, "Hilfe") {
+		t.Errorf("status bar = %q, want the German hints", got)
+	}
+	m.showHelp = true
+	if view := stripANSI(m.View()); !strings.Contains(view, "Tastenkürzel") || !strings.Contains(view, "Befehlspalette") {
+		t.Errorf("help overlay should be in German:\n%s", view)
+	}
+	m.showHelp = false
+	m.showIdleWarning = true
+	if got := stripANSI(m.idleWarningView()); !strings.Contains(got, "Inaktivität") {
+		t.Errorf("idle warning = %q, want German", got)
+	}
+}
+
+func TestPaletteLangSwitchesContent(t *testing.T) {
+	base := testContent()
+	german := *base
+	german.Meta.Title = "Entwickler"
+	spy := &contentSpy{}
+	m := New(base, spy)
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	result, _ = result.(Model).Update(IntroDoneMsg{})
+	m = result.(Model).SetContentLocales(func(tag string) *content.Content {
+		if tag == "de" {
+			return &german
+		}
+		return base
+	})
+
+	result, _ = m.Update(PaletteResultMsg{Action: PaletteLang, Target: "de"})
+	m = result.(Model)
+	if m.content != &german || spy.content != &german {
+		t.Error(":lang de should switch the model and sections to the German content")
+	}
+	if got := stripANSI(m.statusView()); !strings.Contains(got, "Sprache: Deutsch") {
+		t.Errorf("status bar = %q, want the switch confirmed in German", got)
+	}
+
+	result, _ = m.Update(PaletteResultMsg{Action: PaletteLang, Target: "en"})
+	m = result.(Model)
+	if m.content != base || !strings.Contains(stripANSI(m.statusView()), "Language: English") {
+		t.Error(":lang en should switch back")
+	}
+
+	// Without translations :lang only changes the chrome.
+	m = skipIntro(t)
+	c := m.content
+	result, _ = m.Update(PaletteResultMsg{Action: PaletteLang, Target: "de"})
+	if m = result.(Model); m.content != c || m.locale.Tag != "de" {
+		t.Error(":lang without content locales should keep the content")
+	}
+}
@@ -0,0 +1,53 @@
+package app
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/glamour/styles"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// RenderMarkdown renders md for the terminal, wrapped to width. It starts
+// from glamour's dark or light style to match the theme and swaps its
+// colors for the theme's own, so notes sit with the rest of the UI; the
+// margins are dropped because sections pad their own content.
+func RenderMarkdown(theme Theme, md string, width int) (string, error) {
+	color := func(c lipgloss.Color) *string {
+		s := string(c)
+		return &s
+	}
+	noMargin := uint(0)
+
+	style := styles.DarkStyleConfig
+	if theme.Name == ThemeLight {
+		style = styles.LightStyleConfig
+	}
+	style.Document.Margin = &noMargin
+	style.Document.BlockPrefix, style.Document.BlockSuffix = "", ""
+	style.Document.Color = color(theme.Colors.Fg)
+	style.Heading.Color = color(theme.Colors.Accent)
+	style.H1.Color, style.H1.BackgroundColor = nil, nil
+	style.H1.Prefix, style.H1.Suffix = "# ", ""
+	style.H6.Color = color(theme.Colors.Muted)
+	style.BlockQuote.Color = color(theme.Colors.Muted)
+	style.HorizontalRule.Color = color(theme.Colors.Border)
+	style.Link.Color = color(theme.Colors.Muted)
+	style.LinkText.Color = color(theme.Colors.Accent)
+	style.Code.Color = color(theme.Colors.Accent)
+	style.Code.BackgroundColor = nil
+
+	r, err := glamour.NewTermRenderer(
+		glamour.WithStyles(style),
+		glamour.WithWordWrap(width),
+		glamour.WithColorProfile(theme.ColorProfile()),
+	)
+	if err != nil {
+		return "", err
+	}
+	out, err := r.Render(md)
+	if err != nil {
+		return "", err
+	}
+	return strings.Trim(out, "\n"), nil
+}
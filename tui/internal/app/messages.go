@@ -1,41 +1,75 @@
 package app
 
-// Section identifies a navigable section of the TUI.
+import (
+	"time"
+
+	"github.com/buntingszn/terminal-portfolio/tui/internal/content"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/i18n"
+)
+
+// Section identifies a navigable section of the TUI: its position in the
+// section registry.
 type Section int
 
+// The built-in sections, which the registry always starts with.
 const (
-	SectionHome  Section = 0
-	SectionWork  Section = 1
-	SectionCV    Section = 2
-	SectionLinks Section = 3
+	SectionHome      Section = 0
+	SectionWork      Section = 1
+	SectionCV        Section = 2
+	SectionLinks     Section = 3
+	SectionGuestbook Section = 4
+	SectionStatus    Section = 5
+	SectionAdmin     Section = 6
+	SectionNotes     Section = 7
+	SectionUses      Section = 8
+	SectionTalks     Section = 9
 )
 
-// SectionCount is the total number of navigable sections.
-const SectionCount = 4
-
-// SectionName returns the display name for a section.
-func SectionName(s Section) string {
-	switch s {
-	case SectionHome:
-		return "home"
-	case SectionWork:
-		return "work"
-	case SectionCV:
-		return "cv"
-	case SectionLinks:
-		return "links"
-	default:
-		return "unknown"
-	}
-}
-
 // NavigateMsg requests navigation to a specific section.
 type NavigateMsg struct {
 	Section Section
 }
 
+// BroadcastMsg is an announcement the server sends to every open session,
+// such as a restart warning. It shows in the status bar, which flashes to
+// draw the eye, and rings the terminal bell when SetBell enables it.
+type BroadcastMsg struct {
+	Text string
+}
+
+// ShutdownCountdownMsg is broadcast every second while the server drains
+// before a restart, with the time left until sessions are closed. The
+// first one opens a countdown overlay; later ones update it.
+type ShutdownCountdownMsg struct {
+	Remaining time.Duration
+}
+
 // FocusMsg is sent to a section when it becomes the active section.
 type FocusMsg struct{}
 
 // BlurMsg is sent to a section when it loses focus.
 type BlurMsg struct{}
+
+// ContentChangedMsg is sent to every section when the session switches to
+// a translation of the content, so sections showing it re-render.
+type ContentChangedMsg struct {
+	Content *content.Content
+}
+
+// LocaleChangedMsg is sent to every section when the session's language
+// is set or switched, so sections word their key hints and feedback in it.
+type LocaleChangedMsg struct {
+	Locale *i18n.Locale
+}
+
+// KeyMapChangedMsg is sent to every section when the session's key map is
+// set, so sections that name their keys in their text name the bound ones.
+type KeyMapChangedMsg struct {
+	Keys KeyMap
+}
+
+// ThemeChangedMsg is sent to every section when the active theme changes so
+// it can re-render its content with the new palette.
+type ThemeChangedMsg struct {
+	Theme Theme
+}
@@ -0,0 +1,92 @@
+package app
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// MotionChangedMsg is sent to every section when reduced motion is turned
+// on or off. Sections with animations of their own, such as the home
+// portrait's shimmer, settle them at once while Reduced is set and start
+// no new ones.
+type MotionChangedMsg struct {
+	Reduced bool
+}
+
+// SetReducedMotion turns off the intro boot sequence, the slide between
+// sections and the sections' own animations, for visitors with vestibular
+// sensitivity or slow links. Content is shown at once and everything else
+// works as usual. Visitors can switch with :motion. This should be called
+// before Init().
+func (m Model) SetReducedMotion(on bool) Model {
+	result, _ := m.applyMotion(on)
+	return result.(Model)
+}
+
+// applyMotion records whether motion is reduced and tells every section.
+func (m Model) applyMotion(reduced bool) (tea.Model, tea.Cmd) {
+	m.reducedMotion = reduced
+	msg := MotionChangedMsg{Reduced: reduced}
+	var cmds []tea.Cmd
+	for i := range m.sections {
+		var cmd tea.Cmd
+		m.sections[i], cmd = m.sections[i].Update(msg)
+		if cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// setMotion runs :motion, turning animations "on" or "off" as target
+// says, or toggling them when it is empty.
+func (m Model) setMotion(target string) (tea.Model, tea.Cmd) {
+	reduced := !m.reducedMotion
+	switch target {
+	case "on":
+		reduced = false
+	case "off":
+		reduced = true
+	}
+	if reduced == m.reducedMotion {
+		return m.showNotice("Animations already " + target)
+	}
+	next, cmd := m.applyMotion(reduced)
+	text := "Animations on"
+	if reduced {
+		text = "Animations off"
+	}
+	next, notice := next.(Model).showNotice(text)
+	return next, tea.Batch(cmd, notice)
+}
+
+// SlowLinkMsg tells the model its session's output is backing up, as on
+// high-latency or mobile connections, where every animation frame adds to
+// the queue.
+type SlowLinkMsg struct{}
+
+// SetSlowLink marks the session as on a slow link: motion is reduced as
+// with SetReducedMotion, and the screensaver stays off, since both redraw
+// the screen many times a second. This should be called before Init().
+func (m Model) SetSlowLink(on bool) Model {
+	m.slowLink = on
+	if on {
+		m = m.SetReducedMotion(true)
+	}
+	return m
+}
+
+// handleSlowLink throttles a session whose link turned out to be slow,
+// saying so once; :motion can turn the animations back on.
+func (m Model) handleSlowLink() (tea.Model, tea.Cmd) {
+	if m.slowLink {
+		return m, nil
+	}
+	m.slowLink = true
+	if m.screensaver != nil {
+		m = m.stopScreensaver()
+	}
+	if m.reducedMotion {
+		return m, nil
+	}
+	next, cmd := m.applyMotion(true)
+	next, notice := next.(Model).showNotice("Slow connection: animations off")
+	return next, tea.Batch(cmd, notice)
+}
@@ -0,0 +1,70 @@
+package app
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// sectionOrigin returns the screen column and row of the active section's
+// top-left corner: under the navbar and its indicator, or in the split
+// layout under the content pane's header, right of the sidebar and rule.
+func (m Model) sectionOrigin() (x, y int) {
+	if m.splitActive() {
+		return m.splitLayout().Widths(m.width)[paneSidebar] + 1, 1
+	}
+	return 0, ChromeHeight - 1
+}
+
+// handleClick handles a left click. A navbar tab or a section listed in
+// the sidebar goes to that section; a click on the section's scrollbar
+// starts dragging it, and one elsewhere inside the section is passed to
+// it, relative to its corner, to select or activate what is there.
+func (m Model) handleClick(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	x, y := m.sectionOrigin()
+	width, height := m.sectionSize()
+	switch {
+	case m.splitActive() && msg.X < x-1:
+		s, ok := m.sidebarSectionAt(msg.Y - y)
+		if !ok {
+			return m, nil
+		}
+		m.focus.Focus(paneContent)
+		m.sidebarCursor = s
+		return m.navigateTo(s)
+	case !m.splitActive() && msg.Y < y:
+		if s, ok := m.navBar.SectionAt(msg.X); ok {
+			return m.navigateTo(s)
+		}
+		return m, nil
+	case msg.X >= x && msg.X < x+width && msg.Y >= y && msg.Y < y+height:
+		if m.splitActive() {
+			m.focus.Focus(paneContent)
+		}
+		msg.X, msg.Y = msg.X-x, msg.Y-y
+		if m.grabScrollbar(msg.X, msg.Y) {
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.sections[m.activeSection], cmd = m.sections[m.activeSection].Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+// sidebarSectionAt returns the section on row of the sidebar view as
+// sidebarView lays it out: a blank line and, with content, the owner's
+// name, title, and another blank line above the sections.
+func (m Model) sidebarSectionAt(row int) (Section, bool) {
+	n := row - 1
+	if m.content != nil {
+		n -= 3
+	}
+	for i := range m.hidden {
+		s := Section(i)
+		if m.hidden[s] {
+			continue
+		}
+		if n == 0 {
+			return s, true
+		}
+		n--
+	}
+	return 0, false
+}
@@ -0,0 +1,173 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// clickSection records the mouse events it is given.
+type clickSection struct {
+	placeholderSection
+	mouse []tea.MouseMsg
+}
+
+func (s *clickSection) Update(msg tea.Msg) (SectionModel, tea.Cmd) {
+	if mm, ok := msg.(tea.MouseMsg); ok {
+		s.mouse = append(s.mouse, mm)
+	}
+	return s, nil
+}
+
+func leftPress(x, y int) tea.MouseMsg {
+	return tea.MouseMsg{X: x, Y: y, Action: tea.MouseActionPress, Button: tea.MouseButtonLeft}
+}
+
+func clickModel(t *testing.T, width int) (Model, *clickSection) {
+	t.Helper()
+	sec := &clickSection{placeholderSection: placeholderSection{name: "home", theme: DarkTheme()}}
+	m := New(testContent(), sec)
+	result, _ := m.Update(tea.WindowSizeMsg{Width: width, Height: 30})
+	m = result.(Model)
+	result, _ = m.Update(IntroDoneMsg{})
+	return result.(Model), sec
+}
+
+func TestClickNavbarTab(t *testing.T) {
+	m, _ := clickModel(t, 100)
+	x, _ := m.navBar.tabSpan(SectionCV, m.navBar.labelFormat())
+	result, _ := m.Update(leftPress(x+1, 0))
+	m = drainTransition(t, result.(Model))
+	if m.activeSection != SectionCV {
+		t.Errorf("clicking the cv tab went to section %d", m.activeSection)
+	}
+
+	// The gap between tabs goes nowhere.
+	gap, _ := m.navBar.tabSpan(SectionWork, m.navBar.labelFormat())
+	result, _ = m.Update(leftPress(gap-1, 0))
+	if m = result.(Model); m.transition.Active() {
+		t.Error("clicking between tabs should not navigate")
+	}
+}
+
+func TestClickInSection(t *testing.T) {
+	m, sec := clickModel(t, 100)
+	result, _ := m.Update(leftPress(7, ChromeHeight-1+4))
+	m = result.(Model)
+	if len(sec.mouse) != 1 || sec.mouse[0].X != 7 || sec.mouse[0].Y != 4 {
+		t.Fatalf("section got %+v, want one click at 7,4 in its own coordinates", sec.mouse)
+	}
+
+	// Wheel events are passed on in the same coordinates.
+	result, _ = m.Update(tea.MouseMsg{X: 3, Y: ChromeHeight - 1, Action: tea.MouseActionPress, Button: tea.MouseButtonWheelDown})
+	m = result.(Model)
+	if len(sec.mouse) != 2 || sec.mouse[1].Y != 0 {
+		t.Errorf("section got %+v, want the wheel event on its first row", sec.mouse)
+	}
+
+	// The status bar is outside the section.
+	result, _ = m.Update(leftPress(7, m.height-1))
+	if m = result.(Model); len(sec.mouse) != 2 {
+		t.Error("a click on the status bar should not reach the section")
+	}
+}
+
+func TestClickSplitLayout(t *testing.T) {
+	m, sec := clickModel(t, SplitMinWidth)
+	m.focus.Focus(paneSidebar)
+
+	// Rows under the pane header: a blank line, name, title, blank, then
+	// the sections from home.
+	result, _ := m.Update(leftPress(2, 1+4+2))
+	m = drainTransition(t, result.(Model))
+	if m.activeSection != SectionCV || !m.contentFocused() {
+		t.Errorf("clicking cv in the sidebar: section %d, content focused %v", m.activeSection, m.contentFocused())
+	}
+
+	result, _ = m.Update(leftPress(0, 1+4))
+	m = drainTransition(t, result.(Model))
+	x, y := m.sectionOrigin()
+	result, _ = m.Update(leftPress(x+2, y+3))
+	m = result.(Model)
+	if m.activeSection != SectionHome || len(sec.mouse) != 1 || sec.mouse[0].X != 2 || sec.mouse[0].Y != 3 {
+		t.Errorf("content pane click = %+v in section %d, want 2,3 in home", sec.mouse, m.activeSection)
+	}
+}
+
+// dragSection shows 200 numbered lines with a scrollbar, like the
+// viewport sections.
+type dragSection struct {
+	placeholderSection
+	viewport Viewport
+}
+
+func (s *dragSection) Update(msg tea.Msg) (SectionModel, tea.Cmd) {
+	if msg, ok := msg.(tea.WindowSizeMsg); ok {
+		s.viewport.SetSize(msg.Width, msg.Height)
+		var lines []string
+		for i := range 200 {
+			lines = append(lines, fmt.Sprintf("line %d", i))
+		}
+		s.viewport.SetContent(strings.Join(lines, "\n"))
+	}
+	return s, nil
+}
+
+func (s *dragSection) View() string { return s.viewport.ViewWithScrollbar(s.theme) }
+
+func (s *dragSection) ScrollInfo() ScrollInfo { return s.viewport.GetScrollInfo() }
+
+func (s *dragSection) ScrollToLine(line int) { s.viewport.SetYOffset(line) }
+
+func TestDragScrollbarThumb(t *testing.T) {
+	sec := &dragSection{placeholderSection: placeholderSection{name: "home", theme: DarkTheme()}}
+	m := New(testContent(), sec)
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 30})
+	result, _ = result.(Model).Update(IntroDoneMsg{})
+	m = result.(Model)
+	send := func(msg tea.MouseMsg) {
+		t.Helper()
+		result, _ := m.Update(msg)
+		m = result.(Model)
+	}
+	x, y := m.sectionOrigin()
+	bar := sec.viewport.Scrollbar()
+	col := x + bar.Column
+
+	// Grabbing the thumb and dragging it down scrolls in proportion.
+	send(leftPress(col, y))
+	if m.scrollDrag == nil || sec.viewport.YOffset() != 0 {
+		t.Fatalf("pressing the thumb: dragging %v at line %d", m.scrollDrag != nil, sec.viewport.YOffset())
+	}
+	send(tea.MouseMsg{X: col, Y: y + 5, Action: tea.MouseActionMotion, Button: tea.MouseButtonLeft})
+	if got, want := sec.viewport.YOffset(), bar.OffsetFor(5); got != want || got == 0 {
+		t.Errorf("dragging the thumb 5 rows scrolled to line %d, want %d", got, want)
+	}
+	send(tea.MouseMsg{X: col, Y: y + 100, Action: tea.MouseActionMotion, Button: tea.MouseButtonLeft})
+	if !sec.viewport.AtBottom() {
+		t.Error("dragging past the track should stop at the bottom")
+	}
+
+	// Once released, motion no longer scrolls.
+	send(tea.MouseMsg{X: col, Y: y + 100, Action: tea.MouseActionRelease})
+	send(tea.MouseMsg{X: col, Y: y, Action: tea.MouseActionMotion, Button: tea.MouseButtonLeft})
+	if m.scrollDrag != nil || !sec.viewport.AtBottom() {
+		t.Error("motion after the release should not move the view")
+	}
+
+	// A press on the track brings the thumb's middle to it.
+	row := bar.Rows / 2
+	send(leftPress(col, y+row))
+	if got := sec.viewport.Scrollbar(); row < got.ThumbStart || row >= got.ThumbStart+got.ThumbHeight {
+		t.Errorf("after pressing track row %d the thumb is on rows %d-%d", row, got.ThumbStart, got.ThumbStart+got.ThumbHeight-1)
+	}
+
+	// Left of the scrollbar is the section's own.
+	send(tea.MouseMsg{X: col, Y: y, Action: tea.MouseActionRelease})
+	send(leftPress(col-1, y))
+	if m.scrollDrag != nil {
+		t.Error("a click beside the scrollbar should not grab it")
+	}
+}
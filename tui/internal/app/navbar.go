@@ -2,6 +2,7 @@ package app
 
 import (
 	"fmt"
+	"math"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
@@ -9,20 +10,37 @@ import (
 
 // NavBar renders a horizontal tab navigation bar with plain text labels.
 // Active tab is styled with accent color + bold; inactive tabs use muted color.
+// When wraparound is disabled, edge markers flank the tabs and dim on the side
+// where navigation stops.
 type NavBar struct {
 	theme  Theme
 	width  int
 	active Section
+	noWrap bool
+	hidden []bool
+
+	// Slide state for the underline indicator. While sliding, the
+	// underline interpolates from slideFrom's tab to the active tab by
+	// slide, which follows the section transition's eased progress.
+	sliding   bool
+	slideFrom Section
+	slide     float64
 }
 
 // NewNavBar creates a NavBar with the given theme and terminal width.
 func NewNavBar(theme Theme, width int) NavBar {
 	return NavBar{
-		theme: theme,
-		width: width,
+		theme:  theme,
+		width:  width,
+		hidden: defaultHidden(),
 	}
 }
 
+// SetTheme replaces the NavBar's theme.
+func (n *NavBar) SetTheme(theme Theme) {
+	n.theme = theme
+}
+
 // SetWidth updates the NavBar's width.
 func (n *NavBar) SetWidth(width int) {
 	n.width = width
@@ -33,6 +51,42 @@ func (n *NavBar) SetActive(s Section) {
 	n.active = s
 }
 
+// StartSlide begins animating the underline from the given section's tab.
+// Call it before SetActive moves the highlight to the destination.
+func (n *NavBar) StartSlide(from Section) {
+	n.sliding = true
+	n.slideFrom = from
+	n.slide = 0
+}
+
+// SetSlide advances the underline to progress in [0, 1]. Reaching 1 ends
+// the slide and parks the underline beneath the active tab.
+func (n *NavBar) SetSlide(progress float64) {
+	n.slide = progress
+	if progress >= 1 {
+		n.sliding = false
+	}
+}
+
+// SetWrap records whether section navigation wraps around. The zero value
+// of NavBar assumes wrapping, matching the model default.
+func (n *NavBar) SetWrap(wrap bool) {
+	n.noWrap = !wrap
+}
+
+// SetHidden records which sections have no tab. Hidden tabs take no room
+// and the remaining tabs are numbered by their place in the bar.
+func (n *NavBar) SetHidden(hidden []bool) {
+	n.hidden = hidden
+}
+
+// Edge markers shown around the tabs when wraparound is disabled.
+const (
+	navEdgeLeft  = "\u2039" // ‹
+	navEdgeRight = "\u203a" // ›
+	navEdgeWidth = 4        // both markers plus their separating spaces
+)
+
 // navLabelFormat determines how section labels are rendered based on width.
 type navLabelFormat int
 
@@ -44,34 +98,45 @@ const (
 
 // navShortName returns the abbreviated name for a section.
 func navShortName(s Section) string {
-	switch s {
-	case SectionHome:
-		return "hm"
-	case SectionWork:
-		return "wk"
-	case SectionCV:
-		return "cv"
-	case SectionLinks:
-		return "lk"
-	default:
-		return "?"
+	if def, ok := sectionDef(s); ok {
+		return def.short()
 	}
+	return "?"
 }
 
-// navLabelForWidth returns the label format appropriate for the given width.
-func navLabelForWidth(width int) navLabelFormat {
-	if width >= 40 {
-		return navLabelFull
-	}
-	if width >= 25 {
-		return navLabelShort
+// navLabelForWidth returns the widest label format whose tabs, leaving out
+// hidden sections, fit in width.
+func navLabelForWidth(width int, hidden []bool) navLabelFormat {
+	for _, format := range []navLabelFormat{navLabelFull, navLabelShort} {
+		if navTabsWidth(format, hidden) <= width {
+			return format
+		}
 	}
 	return navLabelNumOnly
 }
 
+// navTabsWidth returns the width of the visible tab labels at format,
+// including the two-space gaps between them.
+func navTabsWidth(format navLabelFormat, hidden []bool) int {
+	w := -2
+	for i := range hidden {
+		if !hidden[i] {
+			w += lipgloss.Width(navTabLabel(Section(i), format, hidden)) + 2
+		}
+	}
+	return max(w, 0)
+}
+
 // navTabLabel returns the tab label string for a section at a given format.
-func navTabLabel(s Section, format navLabelFormat) string {
-	num := int(s) + 1
+// Tabs past the ninth have no digit key, so they go without a number.
+func navTabLabel(s Section, format navLabelFormat, hidden []bool) string {
+	num := tabNumber(s, hidden)
+	if num > 9 {
+		if format == navLabelFull {
+			return SectionName(s)
+		}
+		return navShortName(s)
+	}
 	switch format {
 	case navLabelFull:
 		return fmt.Sprintf("%d:%s", num, SectionName(s))
@@ -82,18 +147,104 @@ func navTabLabel(s Section, format navLabelFormat) string {
 	}
 }
 
+// tabNumber returns the number on section s's tab: its place among the
+// visible tabs, counting from 1.
+func tabNumber(s Section, hidden []bool) int {
+	num := 1
+	for i := range min(int(s), len(hidden)) {
+		if !hidden[i] {
+			num++
+		}
+	}
+	return num
+}
+
+// sectionAtTab returns the visible section whose tab is numbered num.
+func sectionAtTab(num int, hidden []bool) (Section, bool) {
+	for i := range hidden {
+		if !hidden[i] {
+			if num--; num == 0 {
+				return Section(i), true
+			}
+		}
+	}
+	return 0, false
+}
+
+// tabSpan returns the starting column and width of section s's label as
+// laid out by View, including the left edge marker when present.
+func (n NavBar) tabSpan(s Section, format navLabelFormat) (x, w int) {
+	if n.noWrap {
+		x = lipgloss.Width(navEdgeLeft) + 1
+	}
+	for i := range int(s) {
+		if !n.hidden[i] {
+			x += lipgloss.Width(navTabLabel(Section(i), format, n.hidden)) + 2
+		}
+	}
+	return x, lipgloss.Width(navTabLabel(s, format, n.hidden))
+}
+
+// SectionAt returns the section whose tab covers column x of the bar.
+func (n NavBar) SectionAt(x int) (Section, bool) {
+	format := n.labelFormat()
+	for i := range n.hidden {
+		s := Section(i)
+		if n.hidden[s] {
+			continue
+		}
+		if tx, w := n.tabSpan(s, format); x >= tx && x < tx+w {
+			return s, true
+		}
+	}
+	return 0, false
+}
+
+// labelFormat returns the label format View uses at the current width.
+func (n NavBar) labelFormat() navLabelFormat {
+	// Reserve room for the edge markers before choosing a label format.
+	avail := n.width
+	if n.noWrap {
+		avail -= navEdgeWidth
+	}
+	return navLabelForWidth(avail, n.hidden)
+}
+
+// IndicatorView renders the row beneath the tabs: an accent underline under
+// the active tab, or between the previous and active tabs mid-slide.
+func (n NavBar) IndicatorView() string {
+	format := n.labelFormat()
+	x, w := n.tabSpan(n.active, format)
+	if n.sliding {
+		fx, fw := n.tabSpan(n.slideFrom, format)
+		x = fx + int(math.Round(float64(x-fx)*n.slide))
+		w = fw + int(math.Round(float64(w-fw)*n.slide))
+	}
+	if w < 1 {
+		w = 1
+	}
+	style := n.theme.NewStyle().Foreground(n.theme.Colors.Accent)
+	return strings.Repeat(" ", x) + style.Render(strings.Repeat(navUnderline, w))
+}
+
+// navUnderline is the glyph used for the active tab indicator.
+const navUnderline = "\u2500" // ─
+
 // View renders the navigation bar as plain text tabs with spacing.
 // Active tab is accent + bold; inactive tabs are muted.
 func (n NavBar) View() string {
-	accentStyle := lipgloss.NewStyle().Foreground(n.theme.Colors.Accent).Bold(true)
-	mutedStyle := lipgloss.NewStyle().Foreground(n.theme.Colors.Muted)
+	accentStyle := n.theme.NewStyle().Foreground(n.theme.Colors.Accent).Bold(true)
+	mutedStyle := n.theme.NewStyle().Foreground(n.theme.Colors.Muted)
 
-	format := navLabelForWidth(n.width)
+	format := n.labelFormat()
 
 	var tabs []string
-	for i := range SectionCount {
+	for i := range n.hidden {
+		if n.hidden[i] {
+			continue
+		}
 		s := Section(i)
-		label := navTabLabel(s, format)
+		label := navTabLabel(s, format, n.hidden)
 
 		if s == n.active {
 			tabs = append(tabs, accentStyle.Render(label))
@@ -102,5 +253,22 @@ func (n NavBar) View() string {
 		}
 	}
 
-	return strings.Join(tabs, "  ")
+	bar := strings.Join(tabs, "  ")
+	if !n.noWrap {
+		return bar
+	}
+
+	// Edge markers are muted while there is somewhere to go in that
+	// direction and drop to the border color at the ends.
+	edgeStyle := n.theme.NewStyle().Foreground(n.theme.Colors.Border)
+	first, last := visibleEnds(n.hidden)
+	left := mutedStyle.Render(navEdgeLeft)
+	if n.active == first {
+		left = edgeStyle.Render(navEdgeLeft)
+	}
+	right := mutedStyle.Render(navEdgeRight)
+	if n.active == last {
+		right = edgeStyle.Render(navEdgeRight)
+	}
+	return left + " " + bar + " " + right
 }
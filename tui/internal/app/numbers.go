@@ -0,0 +1,39 @@
+package app
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// ItemNumberer is an optional interface for sections with a list of items
+// that can be picked by number. Pressing 0 toggles item numbers on every
+// such section; while they are shown, the root model forwards 1-9 to the
+// active section instead of jumping to another one.
+type ItemNumberer interface {
+	SetItemNumbers(on bool)
+}
+
+// ItemNumber returns the item index (0-8) a 1-9 key press picks, read
+// with Digit.
+func ItemNumber(msg tea.KeyMsg) (int, bool) {
+	r, ok := Digit(msg)
+	if !ok || r == '0' {
+		return 0, false
+	}
+	return int(r - '1'), true
+}
+
+// toggleItemNumbers shows or hides item numbers on every section that
+// implements ItemNumberer.
+func (m Model) toggleItemNumbers() Model {
+	m.itemNumbers = !m.itemNumbers
+	for _, s := range m.sections {
+		if n, ok := s.(ItemNumberer); ok {
+			n.SetItemNumbers(m.itemNumbers)
+		}
+	}
+	return m
+}
+
+// picksItems reports whether a 1-9 key press goes to the active section.
+func (m Model) picksItems() bool {
+	_, ok := m.sections[m.activeSection].(ItemNumberer)
+	return ok && m.itemNumbers
+}
@@ -0,0 +1,64 @@
+package app
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// numberSpy is a captureSection that records item number toggles.
+type numberSpy struct {
+	captureSection
+	numbers bool
+}
+
+func (s *numberSpy) SetItemNumbers(on bool) { s.numbers = on }
+
+func (s *numberSpy) Update(msg tea.Msg) (SectionModel, tea.Cmd) {
+	s.captureSection.Update(msg)
+	return s, nil
+}
+
+func TestItemNumbersTakeDigits(t *testing.T) {
+	theme := DarkTheme()
+	work := &numberSpy{}
+	m := New(testContent(), newPlaceholderSection("home", theme), work)
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	m = result.(Model)
+	result, _ = m.Update(IntroDoneMsg{})
+	m = result.(Model)
+	press := func(key string) {
+		t.Helper()
+		result, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+		m = drainTransition(t, result.(Model))
+	}
+
+	// On a section without numbered items, 0 does nothing.
+	press("0")
+	if m.itemNumbers {
+		t.Fatal("0 on home should not turn on item numbers")
+	}
+
+	press("2")
+	if m.activeSection != SectionWork {
+		t.Fatalf("active section = %v, want work", m.activeSection)
+	}
+	press("0")
+	if !work.numbers {
+		t.Fatal("0 should show item numbers")
+	}
+	press("3")
+	press("1")
+	if m.activeSection != SectionWork || work.keys != "31" {
+		t.Errorf("with numbers shown, digits should go to the section; got %q on %v", work.keys, m.activeSection)
+	}
+
+	press("0")
+	if work.numbers {
+		t.Fatal("a second 0 should hide item numbers")
+	}
+	press("1")
+	if m.activeSection != SectionHome {
+		t.Errorf("with numbers hidden, 1 should jump to home; active section = %v", m.activeSection)
+	}
+}
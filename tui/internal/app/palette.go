@@ -1,6 +1,8 @@
 package app
 
 import (
+	"context"
+	"slices"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -19,12 +21,82 @@ const (
 	PaletteQuit
 	// PaletteHelp means show the help overlay.
 	PaletteHelp
+	// PaletteDebug means toggle the debug statistics overlay.
+	PaletteDebug
+	// PaletteTheme means toggle between the light and dark themes, or
+	// switch to the one named in PaletteResultMsg.Target.
+	PaletteTheme
+	// PaletteDownload means offer the file of the section in
+	// PaletteResultMsg.Section, in PaletteResultMsg.Format.
+	PaletteDownload
+	// PaletteBook means copy the owner's booking link.
+	PaletteBook
+	// PaletteKeys means show the owner's public keys.
+	PaletteKeys
+	// PalettePlay means start the hidden mini-game.
+	PalettePlay
+	// PaletteSplit means toggle the split layout of wide terminals.
+	PaletteSplit
+	// PaletteStatusBar means toggle the status bar's scroll progress mode.
+	PaletteStatusBar
+	// PaletteMotion means toggle reduced motion, or turn animations "on"
+	// or "off" as PaletteResultMsg.Target says.
+	PaletteMotion
+	// PaletteA11y means switch the session to accessible text mode.
+	PaletteA11y
+	// PaletteIntro means replay the boot sequence.
+	PaletteIntro
+	// PaletteOpen means copy the link of the project numbered
+	// PaletteResultMsg.Index.
+	PaletteOpen
+	// PaletteCopy means copy the contact detail named in
+	// PaletteResultMsg.Target.
+	PaletteCopy
+	// PaletteLang means switch to the locale tagged
+	// PaletteResultMsg.Target.
+	PaletteLang
+	// PaletteAnchor means scroll to the heading named in
+	// PaletteResultMsg.Target, in whichever section has it.
+	PaletteAnchor
+	// PaletteCustom means run the custom command in
+	// PaletteResultMsg.Command with PaletteResultMsg.Args.
+	PaletteCustom
 )
 
 // PaletteResultMsg is sent when the command palette resolves a command.
 type PaletteResultMsg struct {
 	Action  PaletteAction
 	Section Section
+	Format  string
+	Command *PaletteCommand
+	Args    string
+	// Index and Target are the argument of a built-in command that takes
+	// one, parsed by the palette.
+	Index  int
+	Target string
+	// Input is the command as typed, to reopen the palette on if the
+	// command cannot run.
+	Input string
+}
+
+// PaletteCommand is a palette command added by a deployment, typed as
+// ":name" optionally followed by arguments. Built-in commands take
+// precedence over a custom command of the same name.
+type PaletteCommand struct {
+	Name        string
+	Description string
+	// Run carries out the command. It runs off the UI goroutine with a
+	// bounded context; the returned text, or the error, is shown briefly
+	// in the status bar.
+	Run func(ctx context.Context, inv PaletteInvocation) (string, error)
+}
+
+// PaletteInvocation describes one use of a custom palette command.
+type PaletteInvocation struct {
+	SessionID string
+	IP        string
+	// Args is the text typed after the command name, trimmed.
+	Args string
 }
 
 // PaletteModel implements the command palette overlay.
@@ -34,20 +106,44 @@ type PaletteModel struct {
 	err     string
 	theme   Theme
 	width   int
+	custom  []PaletteCommand
+	hidden  []bool
+
+	// history holds the commands run this session, oldest first. While
+	// up and down recall one, histPos is its index and draft keeps the
+	// input they replaced; otherwise histPos is len(history).
+	history []string
+	histPos int
+	draft   string
+
+	// completions are the names tab cycles through once the input stops
+	// narrowing them down, and compIdx is the one shown.
+	completions []string
+	compIdx     int
 }
 
+// paletteHistoryLimit caps how many commands the palette remembers.
+const paletteHistoryLimit = 50
+
+// paletteSuggestionLimit caps the names shown in the suggestions row.
+const paletteSuggestionLimit = 6
+
 // NewPaletteModel creates a PaletteModel with the given theme.
 func NewPaletteModel(theme Theme) PaletteModel {
 	return PaletteModel{
-		theme: theme,
+		theme:  theme,
+		hidden: defaultHidden(),
 	}
 }
 
-// Open makes the palette visible and clears any previous state.
+// Open makes the palette visible and clears any previous input. The
+// command history is kept for the whole session.
 func (p *PaletteModel) Open() {
 	p.visible = true
 	p.input = ""
 	p.err = ""
+	p.histPos = len(p.history)
+	p.completions = nil
 }
 
 // Close hides the palette.
@@ -57,16 +153,35 @@ func (p *PaletteModel) Close() {
 	p.err = ""
 }
 
+// Fail reopens the palette on input with reason shown as the error, for a
+// command whose arguments the model rejected.
+func (p *PaletteModel) Fail(input, reason string) {
+	p.Open()
+	p.input = input
+	p.err = reason
+}
+
 // Visible returns whether the palette is currently shown.
 func (p *PaletteModel) Visible() bool {
 	return p.visible
 }
 
+// SetTheme replaces the palette's theme.
+func (p *PaletteModel) SetTheme(theme Theme) {
+	p.theme = theme
+}
+
 // SetWidth updates the palette's rendering width.
 func (p *PaletteModel) SetWidth(width int) {
 	p.width = width
 }
 
+// SetHidden records which sections are hidden, so their commands are
+// neither completed nor suggested.
+func (p *PaletteModel) SetHidden(hidden []bool) {
+	p.hidden = hidden
+}
+
 // Update handles key input for the command palette.
 func (p PaletteModel) Update(msg tea.Msg) (PaletteModel, tea.Cmd) {
 	if !p.visible {
@@ -95,10 +210,21 @@ func (p PaletteModel) Update(msg tea.Msg) (PaletteModel, tea.Cmd) {
 		}
 		return p.execute()
 
+	case tea.KeyUp:
+		p.recall(-1)
+		return p, nil
+
+	case tea.KeyDown:
+		p.recall(1)
+		return p, nil
+
+	case tea.KeyTab:
+		p.complete()
+		return p, nil
+
 	case tea.KeyBackspace:
 		if len(p.input) > 0 {
-			p.input = p.input[:len(p.input)-1]
-			p.err = ""
+			p.edit(p.input[:len(p.input)-1])
 		}
 		return p, nil
 
@@ -106,55 +232,379 @@ func (p PaletteModel) Update(msg tea.Msg) (PaletteModel, tea.Cmd) {
 		// Append typed characters.
 		s := keyMsg.String()
 		if len(s) == 1 {
-			p.input += s
-			p.err = ""
+			p.edit(p.input + s)
 		}
 		return p, nil
 	}
 }
 
-// execute resolves the current input to an action.
-func (p PaletteModel) execute() (PaletteModel, tea.Cmd) {
-	cmd := strings.TrimSpace(p.input)
+// edit replaces the input with typed text, which ends any history recall
+// or completion cycle.
+func (p *PaletteModel) edit(input string) {
+	p.input = input
+	p.err = ""
+	p.histPos = len(p.history)
+	p.completions = nil
+}
+
+// recall steps through the history: -1 to an older command, 1 to a newer
+// one, and past the newest back to the input being typed.
+func (p *PaletteModel) recall(delta int) {
+	pos := p.histPos + delta
+	if pos < 0 || pos > len(p.history) {
+		return
+	}
+	if p.histPos == len(p.history) {
+		p.draft = p.input
+	}
+	p.histPos = pos
+	if pos == len(p.history) {
+		p.input = p.draft
+	} else {
+		p.input = p.history[pos]
+	}
+	p.err = ""
+	p.completions = nil
+}
+
+// remember adds a command that ran to the history, skipping an immediate
+// repeat.
+func (p *PaletteModel) remember(cmd string) {
+	if n := len(p.history); n == 0 || p.history[n-1] != cmd {
+		p.history = append(p.history, cmd)
+		if len(p.history) > paletteHistoryLimit {
+			p.history = slices.Delete(p.history, 0, len(p.history)-paletteHistoryLimit)
+		}
+	}
+	p.histPos = len(p.history)
+}
+
+// complete extends the input to the longest prefix shared by the names it
+// starts, or to the closest fuzzy match when it starts none. Once the
+// input can grow no further, repeated tabs cycle through the matches.
+func (p *PaletteModel) complete() {
+	if len(p.completions) > 0 {
+		p.compIdx = (p.compIdx + 1) % len(p.completions)
+		p.input = p.completions[p.compIdx]
+		return
+	}
+	var prefixed []string
+	for _, name := range p.commandNames() {
+		if strings.HasPrefix(name, p.input) {
+			prefixed = append(prefixed, name)
+		}
+	}
+	if len(prefixed) == 0 {
+		if matches := p.matches(); len(matches) > 0 {
+			p.edit(matches[0])
+		}
+		return
+	}
+	if len(prefixed) == 1 {
+		name := prefixed[0]
+		if _, takesArgs := paletteArgCommands()[name]; takesArgs {
+			if _, bare := builtinPaletteCommands()[name]; !bare {
+				name += " "
+			}
+		}
+		p.edit(name)
+		return
+	}
+	if common := commonPrefix(prefixed); common != p.input {
+		p.edit(common)
+		return
+	}
+	p.err = ""
+	p.completions = prefixed
+	p.compIdx = slices.Index(prefixed, p.input) + 1
+	if p.compIdx == len(prefixed) {
+		p.compIdx = 0
+	}
+	p.input = prefixed[p.compIdx]
+}
+
+// commonPrefix returns the longest prefix the names share.
+func commonPrefix(names []string) string {
+	prefix := names[0]
+	for _, name := range names[1:] {
+		for !strings.HasPrefix(name, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	return prefix
+}
+
+// commandNames returns the built-in and custom command names the palette
+// offers, sorted, leaving out aliases and the sections it cannot reach.
+func (p PaletteModel) commandNames() []string {
+	var names []string
+	for name, def := range builtinPaletteCommands() {
+		if len(name) == 1 || def.secret || (def.action == PaletteNavigate && p.hidden[def.section]) {
+			continue
+		}
+		names = append(names, name)
+	}
+	for name := range paletteArgCommands() {
+		if _, bare := builtinPaletteCommands()[name]; !bare {
+			names = append(names, name)
+		}
+	}
+	for _, c := range p.custom {
+		if !PaletteBuiltin(c.Name) {
+			names = append(names, c.Name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+// matches returns the command names the input could stand for: those it
+// starts first, then those containing its letters in order, closest
+// first.
+func (p PaletteModel) matches() []string {
+	type match struct {
+		name  string
+		score int
+	}
+	var found []match
+	for _, name := range p.commandNames() {
+		if strings.HasPrefix(name, p.input) {
+			found = append(found, match{name, -1})
+		} else if score, ok := fuzzyScore(p.input, name); ok {
+			found = append(found, match{name, score})
+		}
+	}
+	slices.SortStableFunc(found, func(a, b match) int { return a.score - b.score })
+	names := make([]string, len(found))
+	for i, m := range found {
+		names[i] = m.name
+	}
+	return names
+}
+
+// fuzzyScore reports whether the runes of pattern appear in name in
+// order, scoring the match by how many runes of name it skips before and
+// between them; lower is closer.
+func fuzzyScore(pattern, name string) (int, bool) {
+	pr := []rune(pattern)
+	if len(pr) == 0 {
+		return 0, false
+	}
+	score, i := 0, 0
+	for _, r := range name {
+		if i == len(pr) {
+			break
+		}
+		if r == pr[i] {
+			i++
+		} else {
+			score++
+		}
+	}
+	return score, i == len(pr)
+}
+
+// paletteCommandDef is what a built-in palette command resolves to.
+type paletteCommandDef struct {
+	action  PaletteAction
+	section Section
+	format  string
+	// secret commands work when typed but are never suggested.
+	secret bool
+}
+
+// builtinPaletteCommands returns the built-in commands by name, with one
+// going to each registered section.
+func builtinPaletteCommands() map[string]paletteCommandDef {
+	cmds := map[string]paletteCommandDef{
+		"quit":         {action: PaletteQuit},
+		"q":            {action: PaletteQuit},
+		"help":         {action: PaletteHelp},
+		"debug":        {action: PaletteDebug},
+		"theme":        {action: PaletteTheme},
+		"download":     {action: PaletteDownload, section: SectionCV},
+		"download pdf": {action: PaletteDownload, section: SectionCV, format: "pdf"},
+		"download txt": {action: PaletteDownload, section: SectionCV, format: "txt"},
+		"book":         {action: PaletteBook},
+		"keys":         {action: PaletteKeys},
+		"play":         {action: PalettePlay, secret: true},
+		"split":        {action: PaletteSplit},
+		"statusbar":    {action: PaletteStatusBar},
+		"motion":       {action: PaletteMotion},
+		"a11y":         {action: PaletteA11y},
+		"intro":        {action: PaletteIntro},
+	}
+	for i, def := range registeredSections() {
+		cmds[def.Name] = paletteCommandDef{action: PaletteNavigate, section: Section(i)}
+	}
+	return cmds
+}
+
+// paletteAliases maps shorthand to the built-in command it stands for.
+// The sections' aliases are registered with them.
+var paletteAliases = map[string]string{
+	"h":  "help",
+	"?":  "help",
+	"t":  "theme",
+	"d":  "download",
+	"dl": "download",
+}
+
+// paletteAlias returns the built-in command alias stands for, or false
+// if it is no alias.
+func paletteAlias(alias string) (string, bool) {
+	if full, ok := paletteAliases[alias]; ok {
+		return full, true
+	}
+	return sectionAlias(alias)
+}
+
+// resolveAlias expands an alias in the first word of cmd.
+func resolveAlias(cmd string) string {
+	name, args, hasArgs := strings.Cut(cmd, " ")
+	full, ok := paletteAlias(name)
+	if !ok {
+		return cmd
+	}
+	if hasArgs {
+		return full + " " + strings.TrimSpace(args)
+	}
+	return full
+}
 
-	type commandDef struct {
-		action  PaletteAction
-		section Section
+// suggestCommand returns the known command closest to cmd by edit
+// distance, or "" when none is close enough to be a likely typo.
+func suggestCommand(cmd string, custom []PaletteCommand) string {
+	names := make([]string, 0, len(custom)+16)
+	for name := range builtinPaletteCommands() {
+		names = append(names, name)
+	}
+	for _, c := range custom {
+		names = append(names, c.Name)
+	}
+	// Allow one edit in short input and up to two in longer input, but
+	// never so many that the input could be replaced outright.
+	n := len([]rune(cmd))
+	limit := min(2, max(1, n/3), n-1)
+	best, bestDist := "", limit+1
+	for _, name := range names {
+		d := editDistance(cmd, name)
+		if d < bestDist || d == bestDist && name < best {
+			best, bestDist = name, d
+		}
 	}
+	return best
+}
 
-	commands := map[string]commandDef{
-		"home":        {action: PaletteNavigate, section: SectionHome},
-		"work":        {action: PaletteNavigate, section: SectionWork},
-		"cv":          {action: PaletteNavigate, section: SectionCV},
-		"links":       {action: PaletteNavigate, section: SectionLinks},
-		"quit":        {action: PaletteQuit},
-		"q":           {action: PaletteQuit},
-		"help":        {action: PaletteHelp},
+// editDistance returns the edit distance between a and b, counting
+// insertions, deletions, substitutions, and swaps of adjacent runes.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	// rows[i][j] is the distance between ra[:i] and rb[:j].
+	rows := make([][]int, len(ra)+1)
+	for i := range rows {
+		rows[i] = make([]int, len(rb)+1)
+		rows[i][0] = i
+	}
+	for j := range rows[0] {
+		rows[0][j] = j
 	}
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			d := min(rows[i-1][j]+1, rows[i][j-1]+1, rows[i-1][j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				d = min(d, rows[i-2][j-2]+1)
+			}
+			rows[i][j] = d
+		}
+	}
+	return rows[len(ra)][len(rb)]
+}
+
+// PaletteBuiltin reports whether name is a built-in palette command or an
+// alias of one. A custom command with such a name can never be run.
+func PaletteBuiltin(name string) bool {
+	_, ok := builtinPaletteCommands()[name]
+	_, takesArgs := paletteArgCommands()[name]
+	_, alias := paletteAlias(name)
+	return ok || takesArgs || alias
+}
+
+// SetCommands replaces the palette's custom commands.
+func (p *PaletteModel) SetCommands(cmds []PaletteCommand) {
+	p.custom = cmds
+}
 
-	if def, ok := commands[cmd]; ok {
+// execute resolves the current input to an action.
+func (p PaletteModel) execute() (PaletteModel, tea.Cmd) {
+	cmd := resolveAlias(strings.TrimSpace(p.input))
+
+	input := strings.TrimSpace(p.input)
+	if def, ok := builtinPaletteCommands()[cmd]; ok {
+		p.remember(input)
 		p.visible = false
 		result := PaletteResultMsg{
 			Action:  def.action,
 			Section: def.section,
+			Format:  def.format,
+			Input:   input,
 		}
 		return p, func() tea.Msg { return result }
 	}
 
+	name, args, _ := strings.Cut(cmd, " ")
+	if ac, ok := paletteArgCommands()[name]; ok {
+		result, err := p.parseArgs(ac, args)
+		if err != nil {
+			// Keep the input so the arguments can be fixed.
+			p.err = name + ": " + err.Error()
+			return p, nil
+		}
+		p.remember(input)
+		p.visible = false
+		result.Input = input
+		return p, func() tea.Msg { return result }
+	}
+	for i := range p.custom {
+		if c := &p.custom[i]; c.Name == name {
+			p.remember(input)
+			p.visible = false
+			result := PaletteResultMsg{Action: PaletteCustom, Command: c, Args: strings.TrimSpace(args), Input: input}
+			return p, func() tea.Msg { return result }
+		}
+	}
+
 	// Unknown command.
 	p.err = "unknown: " + cmd
+	if guess := suggestCommand(cmd, p.custom); guess != "" {
+		p.err += " \u2014 did you mean " + guess + "?"
+	}
 	p.input = ""
 	return p, nil
 }
 
+// parseArgs tokenizes the arguments typed after a built-in command and
+// checks them with the command's parser.
+func (p PaletteModel) parseArgs(ac paletteArgCommand, args string) (PaletteResultMsg, error) {
+	tokens, err := splitPaletteArgs(args)
+	if err != nil {
+		return PaletteResultMsg{}, err
+	}
+	return ac.parse(p, tokens)
+}
+
 // View renders the command palette overlay.
 func (p PaletteModel) View() string {
 	if !p.visible {
 		return ""
 	}
 
-	fgStyle := lipgloss.NewStyle().Foreground(p.theme.Colors.Fg)
-	accentStyle := lipgloss.NewStyle().Foreground(p.theme.Colors.Accent)
+	fgStyle := p.theme.NewStyle().Foreground(p.theme.Colors.Fg)
+	accentStyle := p.theme.NewStyle().Foreground(p.theme.Colors.Accent)
 
 	prompt := accentStyle.Render(":") + fgStyle.Render(p.input) + accentStyle.Render("█")
 
@@ -168,8 +618,8 @@ func (p PaletteModel) View() string {
 		return prompt
 	}
 
-	borderStyle := lipgloss.NewStyle().Foreground(p.theme.Colors.Border)
-	mutedStyle := lipgloss.NewStyle().Foreground(p.theme.Colors.Muted)
+	borderStyle := p.theme.NewStyle().Foreground(p.theme.Colors.Border)
+	mutedStyle := p.theme.NewStyle().Foreground(p.theme.Colors.Muted)
 
 	innerWidth := width - 4
 	if innerWidth < 1 {
@@ -205,12 +655,19 @@ func (p PaletteModel) View() string {
 		return top + "\n" + middle + "\n" + bottom
 	}
 
-	// Error or hints line.
+	// Error, suggestions, or hints line.
 	var infoLine string
-	if p.err != "" {
-		infoLine = accentStyle.Render(p.err)
-	} else {
-		infoLine = mutedStyle.Render("home work cv links quit help")
+	switch {
+	case p.err != "":
+		infoLine = accentStyle.Render(TruncateWithEllipsis(p.err, innerWidth))
+	case p.input != "":
+		infoLine = p.suggestionsView(innerWidth)
+	default:
+		hints := "home work cv links download theme quit help"
+		for _, c := range p.custom {
+			hints += " " + c.Name
+		}
+		infoLine = mutedStyle.Render(TruncateWithEllipsis(hints, innerWidth))
 	}
 	infoPad := innerWidth - lipgloss.Width(infoLine) + 1
 	if infoPad < 0 {
@@ -222,3 +679,54 @@ func (p PaletteModel) View() string {
 
 	return top + "\n" + middle + "\n" + info + "\n" + bottom
 }
+
+// suggestionsView renders the live suggestions row for the input: the
+// names it matches, with the one tab would pick in the accent color, or a
+// custom command's description once its arguments are being typed.
+func (p PaletteModel) suggestionsView(width int) string {
+	accentStyle := p.theme.NewStyle().Foreground(p.theme.Colors.Accent)
+	mutedStyle := p.theme.NewStyle().Foreground(p.theme.Colors.Muted)
+
+	if name, _, hasArgs := strings.Cut(p.input, " "); hasArgs {
+		if ac, ok := paletteArgCommands()[resolveAlias(name)]; ok {
+			return mutedStyle.Render(TruncateWithEllipsis(ac.usage, width))
+		}
+		for _, c := range p.custom {
+			if c.Name == name && !PaletteBuiltin(name) {
+				return mutedStyle.Render(TruncateWithEllipsis(name+" \u2014 "+c.Description, width))
+			}
+		}
+	}
+
+	names, current := p.completions, p.compIdx
+	if len(names) == 0 {
+		names, current = p.matches(), 0
+	}
+	if len(names) == 0 {
+		text := "no matching command"
+		if guess := suggestCommand(strings.TrimSpace(p.input), p.custom); guess != "" {
+			text += " \u2014 did you mean " + guess + "?"
+		}
+		return mutedStyle.Render(TruncateWithEllipsis(text, width))
+	}
+	if current >= paletteSuggestionLimit {
+		// Keep the name being cycled to in view.
+		names, current = names[current-paletteSuggestionLimit+1:], paletteSuggestionLimit-1
+	}
+	names = names[:min(len(names), paletteSuggestionLimit)]
+
+	var parts []string
+	used := 0
+	for i, name := range names {
+		if used+len(name) > width {
+			break
+		}
+		used += len(name) + 2
+		if i == current {
+			parts = append(parts, accentStyle.Render(name))
+		} else {
+			parts = append(parts, mutedStyle.Render(name))
+		}
+	}
+	return strings.Join(parts, "  ")
+}
@@ -0,0 +1,223 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/i18n"
+)
+
+// paletteArgCommand is a built-in palette command that takes arguments,
+// such as ":open 2". The palette checks their syntax; the model checks
+// them against the content when it runs the command.
+type paletteArgCommand struct {
+	// usage is shown in the suggestions row while arguments are typed.
+	usage string
+	parse func(p PaletteModel, args []string) (PaletteResultMsg, error)
+}
+
+// paletteArgCommands returns the built-in commands that take arguments by
+// name. A bare "theme" or "motion" stays the toggle in
+// builtinPaletteCommands.
+func paletteArgCommands() map[string]paletteArgCommand {
+	return map[string]paletteArgCommand{
+		"open":   {"open <n> — copy the link of project n", parseOpenArgs},
+		"copy":   {"copy email|site|ssh|book|<link> — copy a contact detail", parseCopyArgs},
+		"goto":   {"goto <section>|<heading> — go to a section, or a heading such as skills", parseGotoArgs},
+		"theme":  {"theme dark|light — switch to a theme", parseThemeArgs},
+		"lang":   {"lang " + strings.Join(i18n.Tags(), "|") + " — switch the language", parseLangArgs},
+		"motion": {"motion on|off — turn animations on or off", parseMotionArgs},
+	}
+}
+
+// splitPaletteArgs splits arguments on spaces; double quotes group words
+// into one argument, as in :copy "personal site".
+func splitPaletteArgs(s string) ([]string, error) {
+	var args []string
+	var cur strings.Builder
+	inQuote, started := false, false
+	for _, r := range s {
+		switch {
+		case r == '"':
+			inQuote = !inQuote
+			started = true
+		case r == ' ' && !inQuote:
+			if started {
+				args = append(args, cur.String())
+				cur.Reset()
+				started = false
+			}
+		default:
+			cur.WriteRune(r)
+			started = true
+		}
+	}
+	if inQuote {
+		return nil, errors.New("unclosed quote")
+	}
+	if started {
+		args = append(args, cur.String())
+	}
+	return args, nil
+}
+
+// oneArg returns the only argument, or an error naming what was expected.
+func oneArg(args []string, want string) (string, error) {
+	switch len(args) {
+	case 0:
+		return "", fmt.Errorf("expected %s", want)
+	case 1:
+		return args[0], nil
+	default:
+		return "", fmt.Errorf("expected only %s", want)
+	}
+}
+
+func parseOpenArgs(_ PaletteModel, args []string) (PaletteResultMsg, error) {
+	arg, err := oneArg(args, "a project number")
+	if err != nil {
+		return PaletteResultMsg{}, err
+	}
+	n, err := strconv.Atoi(arg)
+	if err != nil || n < 1 {
+		return PaletteResultMsg{}, fmt.Errorf("%q is not a project number", arg)
+	}
+	return PaletteResultMsg{Action: PaletteOpen, Index: n - 1}, nil
+}
+
+func parseCopyArgs(_ PaletteModel, args []string) (PaletteResultMsg, error) {
+	if len(args) == 0 {
+		return PaletteResultMsg{}, errors.New("expected what to copy, such as email")
+	}
+	return PaletteResultMsg{Action: PaletteCopy, Target: strings.ToLower(strings.Join(args, " "))}, nil
+}
+
+func parseGotoArgs(p PaletteModel, args []string) (PaletteResultMsg, error) {
+	arg, err := oneArg(args, "a section")
+	if err != nil {
+		return PaletteResultMsg{}, err
+	}
+	name := strings.ToLower(arg)
+	if full, ok := paletteAlias(name); ok {
+		name = full
+	}
+	for i := range p.hidden {
+		s := Section(i)
+		if name == SectionName(s) || name == strconv.Itoa(int(s)+1) {
+			if p.hidden[s] {
+				return PaletteResultMsg{}, fmt.Errorf("no section %q", arg)
+			}
+			return PaletteResultMsg{Action: PaletteNavigate, Section: s}, nil
+		}
+	}
+	// Other names may be headings, which only the sections know.
+	return PaletteResultMsg{Action: PaletteAnchor, Target: name}, nil
+}
+
+func parseThemeArgs(_ PaletteModel, args []string) (PaletteResultMsg, error) {
+	arg, err := oneArg(args, "dark or light")
+	if err != nil {
+		return PaletteResultMsg{}, err
+	}
+	name := strings.ToLower(arg)
+	if name != ThemeDark && name != ThemeLight {
+		return PaletteResultMsg{}, fmt.Errorf("no theme %q; use dark or light", arg)
+	}
+	return PaletteResultMsg{Action: PaletteTheme, Target: name}, nil
+}
+
+func parseMotionArgs(_ PaletteModel, args []string) (PaletteResultMsg, error) {
+	arg, err := oneArg(args, "on or off")
+	if err != nil {
+		return PaletteResultMsg{}, err
+	}
+	target := strings.ToLower(arg)
+	if target != "on" && target != "off" {
+		return PaletteResultMsg{}, fmt.Errorf("%q is not on or off", arg)
+	}
+	return PaletteResultMsg{Action: PaletteMotion, Target: target}, nil
+}
+
+func parseLangArgs(_ PaletteModel, args []string) (PaletteResultMsg, error) {
+	tags := strings.Join(i18n.Tags(), ", ")
+	arg, err := oneArg(args, "a language: "+tags)
+	if err != nil {
+		return PaletteResultMsg{}, err
+	}
+	l, ok := i18n.Lookup(arg)
+	if !ok {
+		return PaletteResultMsg{}, fmt.Errorf("no language %q; use %s", arg, tags)
+	}
+	return PaletteResultMsg{Action: PaletteLang, Target: l.Tag}, nil
+}
+
+// paletteFailed reopens the palette on the command that could not run,
+// with the reason in place of the suggestions.
+func (m Model) paletteFailed(msg PaletteResultMsg, reason string) (tea.Model, tea.Cmd) {
+	m.showPalette = true
+	m.palette.Fail(msg.Input, reason)
+	return m, nil
+}
+
+// openProject copies the link of the project the palette numbered, in the
+// order the work section lists them.
+func (m Model) openProject(msg PaletteResultMsg) (tea.Model, tea.Cmd) {
+	var projects int
+	if m.content != nil {
+		projects = len(m.content.Work.Projects)
+	}
+	if msg.Index >= projects {
+		if projects == 0 {
+			return m.paletteFailed(msg, "open: there are no projects")
+		}
+		return m.paletteFailed(msg, fmt.Sprintf("open: no project %d; pick 1-%d", msg.Index+1, projects))
+	}
+	p := m.content.Work.FeaturedFirst()[msg.Index]
+	url := p.URL
+	if url == "" {
+		url = p.Repo
+	}
+	if url == "" {
+		return m.paletteFailed(msg, "open: "+p.Title+" has no link")
+	}
+	next, notice := m.showNotice(p.Title + " link copied!")
+	return next, tea.Batch(CopyToClipboard(url), notice)
+}
+
+// copyField copies the contact detail the palette named: the email, site,
+// SSH address, or booking link, or the URL of a link by its label.
+func (m Model) copyField(msg PaletteResultMsg) (tea.Model, tea.Cmd) {
+	if m.content == nil {
+		return m.paletteFailed(msg, "copy: nothing to copy")
+	}
+	c := m.content
+	var label, text string
+	switch msg.Target {
+	case "email", "mail":
+		label, text = "Email", c.About.Email
+	case "site", "web", "website":
+		label, text = "Site", c.Meta.SiteURL
+	case "ssh":
+		label, text = "SSH address", c.Meta.SSHAddress
+	case "book", "booking":
+		label, text = "Booking link", c.About.Booking
+	default:
+		for _, l := range c.Links.Links {
+			if strings.EqualFold(l.Label, msg.Target) {
+				label, text = l.Label, l.URL
+				break
+			}
+		}
+		if label == "" {
+			return m.paletteFailed(msg, fmt.Sprintf("copy: no %q; try email, site, ssh, book, or a link name", msg.Target))
+		}
+	}
+	if text == "" {
+		return m.paletteFailed(msg, "copy: no "+strings.ToLower(label)+" given")
+	}
+	next, notice := m.showNotice(label + " copied!")
+	return next, tea.Batch(CopyToClipboard(strings.TrimPrefix(text, "mailto:")), notice)
+}
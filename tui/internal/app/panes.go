@@ -0,0 +1,129 @@
+package app
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Split layout constants.
+const (
+	// SplitMinWidth is the terminal width from which the split layout is
+	// used: the sections listed down the left, the active one beside them.
+	SplitMinWidth = 160
+
+	// sidebarWidth is the width of the split layout's section list.
+	sidebarWidth = 24
+)
+
+// Pane is one column of a PaneLayout. A Width of 0 makes the pane share
+// whatever room the fixed-width panes leave.
+type Pane struct {
+	Name  string
+	Width int
+}
+
+// PaneLayout arranges views side by side in columns, separated by a
+// vertical rule in the theme's border color.
+type PaneLayout struct {
+	Panes []Pane
+}
+
+// Widths returns the width of each pane when the layout spans total
+// columns. Flexible panes split the remainder evenly, the first taking
+// any odd column; fixed panes are narrowed if there is not room for them.
+func (l PaneLayout) Widths(total int) []int {
+	widths := make([]int, len(l.Panes))
+	if len(l.Panes) == 0 {
+		return widths
+	}
+	avail := max(0, total-(len(l.Panes)-1))
+	flexible := 0
+	for i, p := range l.Panes {
+		if p.Width == 0 {
+			flexible++
+			continue
+		}
+		widths[i] = min(p.Width, avail)
+		avail -= widths[i]
+	}
+	for i, p := range l.Panes {
+		if p.Width != 0 || flexible == 0 {
+			continue
+		}
+		share := avail / flexible
+		if avail%flexible != 0 {
+			share++
+		}
+		widths[i] = share
+		avail -= share
+		flexible--
+	}
+	return widths
+}
+
+// Render joins views, one per pane, into a block total columns wide and
+// height lines tall. Each pane is headed by its name on a rule, drawn in
+// the accent color for the focused pane and the border color for the
+// rest; pass -1 to focus none. Each view is cut or padded to fit under
+// its header.
+func (l PaneLayout) Render(theme Theme, total, height, focused int, views []string) string {
+	widths := l.Widths(total)
+	columns := make([][]string, len(widths))
+	for i, w := range widths {
+		var view string
+		if i < len(views) {
+			view = views[i]
+		}
+		header := paneHeader(theme, l.Panes[i].Name, w, i == focused)
+		columns[i] = append([]string{header}, fitPane(view, w, max(0, height-1))...)
+	}
+
+	rule := theme.NewStyle().Foreground(theme.Colors.Border).Render(borderVertical)
+	lines := make([]string, height)
+	for y := range height {
+		var b strings.Builder
+		for i := range columns {
+			if i > 0 {
+				b.WriteString(rule)
+			}
+			b.WriteString(columns[i][y])
+		}
+		lines[y] = b.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// paneHeader renders a pane's "─ name ───" header width columns wide.
+func paneHeader(theme Theme, name string, width int, focused bool) string {
+	rule := theme.NewStyle().Foreground(theme.Colors.Border)
+	title := theme.Muted
+	if focused {
+		rule = theme.NewStyle().Foreground(theme.Colors.Accent)
+		title = rule.Bold(true)
+	}
+	nameWidth := lipgloss.Width(name)
+	if name == "" || width < nameWidth+4 {
+		return rule.Render(strings.Repeat(borderHorizontal, max(0, width)))
+	}
+	return rule.Render(borderHorizontal+" ") + title.Render(name) +
+		rule.Render(" "+strings.Repeat(borderHorizontal, width-nameWidth-3))
+}
+
+// fitPane cuts or pads view to exactly width columns and height lines.
+func fitPane(view string, width, height int) []string {
+	lines := strings.Split(view, "\n")
+	if len(lines) > height {
+		lines = lines[:height]
+	}
+	for len(lines) < height {
+		lines = append(lines, "")
+	}
+	for i, line := range lines {
+		if lipgloss.Width(line) > width {
+			line = truncateLine(line, width)
+		}
+		lines[i] = padRight(line, width)
+	}
+	return lines
+}
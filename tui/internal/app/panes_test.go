@@ -0,0 +1,83 @@
+package app
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestPaneLayoutWidths(t *testing.T) {
+	tests := []struct {
+		panes []Pane
+		total int
+		want  []int
+	}{
+		{[]Pane{{Width: 24}, {}}, 160, []int{24, 135}},
+		{[]Pane{{}, {}}, 100, []int{50, 49}},
+		{[]Pane{{Width: 20}, {}, {Width: 30}}, 100, []int{20, 48, 30}},
+		{[]Pane{{Width: 24}, {}}, 10, []int{9, 0}},
+		{nil, 80, []int{}},
+	}
+	for _, tt := range tests {
+		if got := (PaneLayout{Panes: tt.panes}).Widths(tt.total); !slices.Equal(got, tt.want) {
+			t.Errorf("Widths(%d) of %v = %v, want %v", tt.total, tt.panes, got, tt.want)
+		}
+	}
+}
+
+func TestPaneLayoutRender(t *testing.T) {
+	l := PaneLayout{Panes: []Pane{{Width: 4}, {}}}
+	l.Panes[1].Name = "main"
+	got := stripANSI(l.Render(DarkTheme(), 13, 4, 1, []string{"abcdef\nx", "right"}))
+	want := "────│─ main ─\nabcd│right   \nx   │        \n    │        "
+	if got != want {
+		t.Errorf("Render() =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestSplitLayout(t *testing.T) {
+	spy := &spySection{}
+	m := New(testContent(), spy)
+	result, _ := m.Update(IntroDoneMsg{})
+	m = result.(Model)
+
+	result, _ = m.Update(tea.WindowSizeMsg{Width: SplitMinWidth, Height: 30})
+	m = result.(Model)
+	if want := SplitMinWidth - sidebarWidth - 1; spy.lastWidth != want || spy.lastHeight != 28 {
+		t.Errorf("section size in the split layout = %dx%d, want %dx28", spy.lastWidth, spy.lastHeight, want)
+	}
+	view := m.View()
+	lines := strings.Split(view, "\n")
+	if len(lines) != 30 {
+		t.Errorf("split frame has %d lines, want 30", len(lines))
+	}
+	for i, line := range lines {
+		if w := lipgloss.Width(line); w != SplitMinWidth {
+			t.Fatalf("line %d is %d wide, want %d", i, w, SplitMinWidth)
+		}
+	}
+	plain := stripANSI(view)
+	if !strings.Contains(plain, "▸ 1  home") || !strings.Contains(plain, "2  work") {
+		t.Errorf("sidebar should list the sections:\n%s", plain)
+	}
+
+	// :split turns it off, handing the sections the full width again.
+	result, _ = m.Update(PaletteResultMsg{Action: PaletteSplit})
+	m = result.(Model)
+	if m.splitActive() || spy.lastWidth != SplitMinWidth || spy.lastHeight != 30-ChromeHeight {
+		t.Errorf("after :split the section is %dx%d", spy.lastWidth, spy.lastHeight)
+	}
+	if !strings.Contains(m.statusView(), "Split view off") {
+		t.Errorf("status bar = %q", stripANSI(m.statusView()))
+	}
+
+	// Narrower terminals keep the navbar.
+	result, _ = m.Update(PaletteResultMsg{Action: PaletteSplit})
+	result, _ = result.(Model).Update(tea.WindowSizeMsg{Width: SplitMinWidth - 1, Height: 30})
+	if result.(Model).splitActive() {
+		t.Errorf("the split layout should need %d columns", SplitMinWidth)
+	}
+}
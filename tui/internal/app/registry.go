@@ -0,0 +1,171 @@
+package app
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/buntingszn/terminal-portfolio/tui/internal/content"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/i18n"
+)
+
+// SectionDef describes a section of the TUI. Navigation, the tabs, the
+// palette, and SSH user names all work from the registered sections, so a
+// deployment adds one by registering it with RegisterSection.
+type SectionDef struct {
+	// Name names the section in its tab, as a palette command, and as the
+	// SSH user name that opens it, as in ssh notes@host. It is lower-case
+	// letters, digits, and dashes.
+	Name string
+	// Short is the tab label for terminals too narrow for the names, or
+	// the first two letters of Name when empty.
+	Short string
+	// Aliases are palette shorthands for going to the section.
+	Aliases []string
+	// Hidden leaves the section out of sessions until SetSectionHidden
+	// reveals it.
+	Hidden bool
+	// New builds the section for a session that New was not handed one
+	// for. Nil gets a placeholder.
+	New func(c *content.Content, theme Theme) SectionModel
+}
+
+// short returns the tab label for narrow terminals.
+func (d SectionDef) short() string {
+	if d.Short != "" {
+		return d.Short
+	}
+	return string([]rune(d.Name)[:min(2, len([]rune(d.Name)))])
+}
+
+var (
+	sectionsMu  sync.Mutex
+	sectionDefs = builtinSections()
+)
+
+// builtinSections returns the sections every session has, in tab order.
+func builtinSections() []SectionDef {
+	return []SectionDef{
+		SectionHome:      {Name: "home", Short: "hm"},
+		SectionWork:      {Name: "work", Short: "wk", Aliases: []string{"w"}},
+		SectionCV:        {Name: "cv", Short: "cv", Aliases: []string{"c"}},
+		SectionLinks:     {Name: "links", Short: "lk", Aliases: []string{"l"}},
+		SectionGuestbook: {Name: "guestbook", Short: "gb", Aliases: []string{"gb"}},
+		// Servers that run a monitor reveal the status section, and the
+		// admin section is revealed only to the owner.
+		SectionStatus: {Name: "status", Short: "st", Aliases: []string{"st"}, Hidden: true},
+		SectionAdmin:  {Name: "admin", Short: "ad", Hidden: true},
+		SectionNotes:  {Name: "notes", Short: "nt", Aliases: []string{"n"}, Hidden: true},
+		SectionUses:   {Name: "uses", Short: "us", Aliases: []string{"u"}, Hidden: true},
+		SectionTalks:  {Name: "talks", Short: "tk", Aliases: []string{"tk"}, Hidden: true},
+	}
+}
+
+// RegisterSection adds a section after those registered so far and
+// returns it. Sessions started afterwards have it. Its name and aliases
+// must not repeat a section's name or alias, a built-in palette command,
+// a locale tag, or A11yUser, since SSH user names pick all of these.
+// Register sections from main before the server starts.
+func RegisterSection(def SectionDef) (Section, error) {
+	names := append([]string{def.Name}, def.Aliases...)
+	for i, name := range names {
+		if !validSectionName(name) {
+			return 0, fmt.Errorf("section name %q must be lower-case letters, digits, or dashes", name)
+		}
+		if PaletteBuiltin(name) || slices.Contains(names[:i], name) {
+			return 0, fmt.Errorf("section name %s is taken", name)
+		}
+		if _, ok := i18n.Lookup(name); ok {
+			return 0, fmt.Errorf("section name %s is taken by a locale", name)
+		}
+		if name == A11yUser {
+			return 0, fmt.Errorf("section name %s is taken by accessible mode", name)
+		}
+	}
+	sectionsMu.Lock()
+	defer sectionsMu.Unlock()
+	for _, d := range sectionDefs {
+		for _, name := range names {
+			if name == d.Name || slices.Contains(d.Aliases, name) {
+				return 0, fmt.Errorf("section name %s is taken by the %s section", name, d.Name)
+			}
+		}
+	}
+	sectionDefs = append(slices.Clip(sectionDefs), def)
+	return Section(len(sectionDefs) - 1), nil
+}
+
+// ResetSections removes every registered section but the built-in ones.
+// It is meant for tests.
+func ResetSections() {
+	sectionsMu.Lock()
+	defer sectionsMu.Unlock()
+	sectionDefs = builtinSections()
+}
+
+// registeredSections returns the sections registered so far, in tab
+// order. The slice must not be modified.
+func registeredSections() []SectionDef {
+	sectionsMu.Lock()
+	defer sectionsMu.Unlock()
+	return sectionDefs
+}
+
+// SectionCount returns the number of registered sections.
+func SectionCount() int {
+	return len(registeredSections())
+}
+
+// sectionDef returns the definition of section s, or false if there is
+// none.
+func sectionDef(s Section) (SectionDef, bool) {
+	defs := registeredSections()
+	if s < 0 || int(s) >= len(defs) {
+		return SectionDef{}, false
+	}
+	return defs[s], true
+}
+
+// SectionName returns the display name for a section.
+func SectionName(s Section) string {
+	if def, ok := sectionDef(s); ok {
+		return def.Name
+	}
+	return "unknown"
+}
+
+// SectionByName returns the section named name, as SectionName spells
+// it in any case, or false if there is none.
+func SectionByName(name string) (Section, bool) {
+	for i, def := range registeredSections() {
+		if strings.EqualFold(name, def.Name) {
+			return Section(i), true
+		}
+	}
+	return 0, false
+}
+
+// sectionAlias returns the name of the section alias stands for, or
+// false if it stands for none.
+func sectionAlias(alias string) (string, bool) {
+	for _, def := range registeredSections() {
+		if slices.Contains(def.Aliases, alias) {
+			return def.Name, true
+		}
+	}
+	return "", false
+}
+
+// validSectionName reports whether name can name a section.
+func validSectionName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,109 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/buntingszn/terminal-portfolio/tui/internal/content"
+)
+
+func TestRegisterSection(t *testing.T) {
+	t.Cleanup(ResetSections)
+	built := 0
+	press, err := RegisterSection(SectionDef{
+		Name:    "press",
+		Aliases: []string{"pr"},
+		New: func(_ *content.Content, theme Theme) SectionModel {
+			built++
+			return newPlaceholderSection("press", theme)
+		},
+	})
+	if err != nil {
+		t.Fatalf("RegisterSection: %v", err)
+	}
+	if press != SectionTalks+1 || SectionCount() != int(press)+1 {
+		t.Errorf("press = %d of %d sections, want the one after talks", press, SectionCount())
+	}
+	for _, bad := range []SectionDef{
+		{Name: "press"},
+		{Name: "work"},
+		{Name: "theme"},
+		{Name: "slides", Aliases: []string{"w"}},
+		{Name: "Slides"},
+		{Name: ""},
+	} {
+		if _, err := RegisterSection(bad); err == nil {
+			t.Errorf("RegisterSection(%q, %v) should fail", bad.Name, bad.Aliases)
+		}
+	}
+	if s, ok := SectionByName("PRESS"); !ok || s != press {
+		t.Errorf("SectionByName(PRESS) = %d, %v; want %d", s, ok, press)
+	}
+
+	m := skipIntro(t)
+	if built != 1 {
+		t.Errorf("the section was built %d times for one session, want once", built)
+	}
+	// It follows the five tabs shown by default.
+	if !strings.Contains(stripANSI(m.navBar.View()), "6:press") {
+		t.Errorf("navbar = %q, want a press tab", stripANSI(m.navBar.View()))
+	}
+
+	// The palette goes to it by name and alias.
+	for _, input := range []string{"press", "pr", "goto press"} {
+		m.palette.Open()
+		m.palette.input = input
+		_, cmd := m.palette.execute()
+		if msg, ok := cmd().(PaletteResultMsg); !ok || msg.Action != PaletteNavigate || msg.Section != press {
+			t.Errorf(":%s = %#v, want to go to press", input, cmd())
+		}
+	}
+	result, _ := m.Update(NavigateMsg{Section: press})
+	if got := drainTransition(t, result.(Model)).activeSection; got != press {
+		t.Errorf("navigating went to %d, want press", got)
+	}
+
+	hidden := New(testContent()).SetSectionHidden(press, true)
+	if strings.Contains(stripANSI(hidden.navBar.View()), "press") {
+		t.Error("a hidden registered section should have no tab")
+	}
+}
+
+// TestRegisterSection_Taken verifies that each kind of name a new section
+// could collide with is refused.
+func TestRegisterSection_Taken(t *testing.T) {
+	t.Cleanup(ResetSections)
+	if _, err := RegisterSection(SectionDef{Name: "press", Aliases: []string{"pr"}}); err != nil {
+		t.Fatalf("RegisterSection: %v", err)
+	}
+	for _, tc := range []struct {
+		name string
+		def  SectionDef
+	}{
+		{"registered name", SectionDef{Name: "press"}},
+		{"registered alias", SectionDef{Name: "pr"}},
+		{"built-in name", SectionDef{Name: "slides", Aliases: []string{"notes"}}},
+		{"built-in alias", SectionDef{Name: "slides", Aliases: []string{"gb"}}},
+		{"alias of a registered section", SectionDef{Name: "slides", Aliases: []string{"pr"}}},
+		{"repeated alias", SectionDef{Name: "slides", Aliases: []string{"sl", "sl"}}},
+		{"locale tag", SectionDef{Name: "de"}},
+		{"locale tag alias", SectionDef{Name: "slides", Aliases: []string{"en"}}},
+		{"accessible mode", SectionDef{Name: A11yUser}},
+	} {
+		if _, err := RegisterSection(tc.def); err == nil {
+			t.Errorf("%s: RegisterSection(%q, %v) should fail", tc.name, tc.def.Name, tc.def.Aliases)
+		}
+	}
+	if got, want := SectionCount(), int(SectionTalks)+2; got != want {
+		t.Errorf("SectionCount() = %d, want %d with only press added", got, want)
+	}
+}
+
+func TestSectionDefShort(t *testing.T) {
+	if got := (SectionDef{Name: "press"}).short(); got != "pr" {
+		t.Errorf("short = %q, want the first two letters", got)
+	}
+	if got := (SectionDef{Name: "x"}).short(); got != "x" {
+		t.Errorf("short of a one-letter name = %q, want it whole", got)
+	}
+}
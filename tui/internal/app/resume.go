@@ -0,0 +1,88 @@
+package app
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// PositionRestorer is implemented by sections whose place can be carried
+// into a resumed session: a scroll offset, or the selected item in a list.
+type PositionRestorer interface {
+	Position() int
+	RestorePosition(pos int)
+}
+
+// ResumeState is the little a session needs to pick up where a dropped
+// one left off.
+type ResumeState struct {
+	Section     Section
+	Position    int
+	Theme       string
+	ItemNumbers bool
+}
+
+// ResumeState returns the session's place for resuming it later. It
+// reports false while the intro is still showing, or once the visitor has
+// quit, since there is nothing to come back to.
+func (m Model) ResumeState() (ResumeState, bool) {
+	if m.showIntro || m.quit {
+		return ResumeState{}, false
+	}
+	st := ResumeState{
+		Section:     m.activeSection,
+		Theme:       m.theme.Name,
+		ItemNumbers: m.itemNumbers,
+	}
+	if pr, ok := m.sections[m.activeSection].(PositionRestorer); ok {
+		st.Position = pr.Position()
+	}
+	return st, true
+}
+
+// Resume starts the session where st left off, skipping the intro. The
+// section is focused and its position restored once the first
+// WindowSizeMsg has laid it out. This should be called before Init().
+func (m Model) Resume(st ResumeState) Model {
+	if st.Section < 0 || int(st.Section) >= len(m.sections) || m.hidden[st.Section] {
+		st.Section = SectionHome
+	}
+	if st.Theme != m.theme.Name && (st.Theme == ThemeDark || st.Theme == ThemeLight) {
+		m = m.SetTheme(m.theme.Toggled())
+	}
+	m.showIntro = false
+	m.activeSection = st.Section
+	m.navBar.SetActive(st.Section)
+	if st.ItemNumbers {
+		m = m.toggleItemNumbers()
+	}
+	m.resume = &st
+	m.welcomeBack = true
+	return m
+}
+
+// StartAt starts the session on section s, skipping the intro, for
+// visitors who ask for it by SSH user name, as in ssh cv@host. A hidden
+// section leaves the session starting at home as usual. This should be
+// called before Init().
+func (m Model) StartAt(s Section) Model {
+	if s < 0 || int(s) >= len(m.sections) || m.hidden[s] {
+		return m
+	}
+	m = m.Resume(ResumeState{Section: s, Theme: m.theme.Name})
+	m.welcomeBack = false
+	return m
+}
+
+// finishResume focuses the resumed section, restores its position, and
+// welcomes the visitor back. It runs on the first WindowSizeMsg.
+func (m Model) finishResume() (Model, tea.Cmd) {
+	st := m.resume
+	m.resume = nil
+	var focusCmd tea.Cmd
+	m.sections[m.activeSection], focusCmd = m.sections[m.activeSection].Update(FocusMsg{})
+	if pr, ok := m.sections[m.activeSection].(PositionRestorer); ok {
+		pr.RestorePosition(st.Position)
+	}
+	if !m.welcomeBack {
+		return m, focusCmd
+	}
+	next, notice := m.showNotice("Welcome back — picked up where you left off")
+	return next.(Model), tea.Batch(focusCmd, notice)
+}
@@ -0,0 +1,105 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// positionSection is a placeholder section that remembers a position.
+type positionSection struct {
+	placeholderSection
+	pos, focused int
+}
+
+func (p *positionSection) Update(msg tea.Msg) (SectionModel, tea.Cmd) {
+	if _, ok := msg.(FocusMsg); ok {
+		p.focused++
+		p.pos = 0
+	}
+	return p, nil
+}
+func (p *positionSection) Position() int           { return p.pos }
+func (p *positionSection) RestorePosition(pos int) { p.pos = pos }
+
+func TestResumeState(t *testing.T) {
+	m := New(testContent())
+	if _, ok := m.ResumeState(); ok {
+		t.Error("a session still in its intro has nothing to resume")
+	}
+
+	m = skipIntro(t)
+	cv := &positionSection{placeholderSection: *newPlaceholderSection("cv", m.theme)}
+	m.sections[SectionCV] = cv
+	result, _ := m.navigateTo(SectionCV)
+	m = drainTransition(t, result.(Model))
+	cv.pos = 7
+	m = m.SetTheme(m.theme.Toggled()).toggleItemNumbers()
+
+	st, ok := m.ResumeState()
+	want := ResumeState{Section: SectionCV, Position: 7, Theme: ThemeLight, ItemNumbers: true}
+	if !ok || st != want {
+		t.Errorf("ResumeState() = %+v, %v; want %+v", st, ok, want)
+	}
+
+	m.logSessionEnd()
+	if _, ok := m.ResumeState(); ok {
+		t.Error("a session the visitor quit should not be resumed")
+	}
+}
+
+func TestResume(t *testing.T) {
+	m := New(testContent())
+	cv := &positionSection{placeholderSection: *newPlaceholderSection("cv", m.theme)}
+	m.sections[SectionCV] = cv
+	m = m.Resume(ResumeState{Section: SectionCV, Position: 7, Theme: ThemeLight, ItemNumbers: true})
+	if m.showIntro || m.activeSection != SectionCV || m.theme.Name != ThemeLight || !m.itemNumbers {
+		t.Fatalf("resumed model: intro %v, section %v, theme %s, numbers %v", m.showIntro, m.activeSection, m.theme.Name, m.itemNumbers)
+	}
+
+	// The section is focused, then put back in place, once it is sized.
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	m = result.(Model)
+	if cv.focused != 1 || cv.pos != 7 {
+		t.Errorf("cv focused %d times at %d, want once at 7", cv.focused, cv.pos)
+	}
+	if !strings.Contains(m.statusView(), "Welcome back") {
+		t.Errorf("status bar = %q", stripANSI(m.statusView()))
+	}
+	m.Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+	if cv.focused != 1 {
+		t.Error("later resizes should not focus the section again")
+	}
+}
+
+func TestResumeHiddenSection(t *testing.T) {
+	m := New(testContent()).Resume(ResumeState{Section: SectionAdmin, Theme: ThemeDark})
+	if m.activeSection != SectionHome {
+		t.Errorf("resuming into a hidden section should land on home, got %v", m.activeSection)
+	}
+}
+
+func TestStartAt(t *testing.T) {
+	m := New(testContent())
+	cv := &positionSection{placeholderSection: *newPlaceholderSection("cv", m.theme)}
+	m.sections[SectionCV] = cv
+	m = m.StartAt(SectionCV)
+	if m.showIntro || m.activeSection != SectionCV {
+		t.Fatalf("started model: intro %v, section %v", m.showIntro, m.activeSection)
+	}
+
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	m = result.(Model)
+	if cv.focused != 1 {
+		t.Errorf("cv focused %d times, want once", cv.focused)
+	}
+	if strings.Contains(m.statusView(), "Welcome back") {
+		t.Errorf("a fresh session should not be welcomed back: %q", stripANSI(m.statusView()))
+	}
+
+	hidden := New(testContent()).StartAt(SectionAdmin)
+	if !hidden.showIntro || hidden.activeSection != SectionHome {
+		t.Errorf("starting at a hidden section: intro %v, section %v; want the intro at home", hidden.showIntro, hidden.activeSection)
+	}
+}
@@ -0,0 +1,29 @@
+package app
+
+// ContentReviewer is an optional interface for sections that can render
+// placeholders for optional content blocks the owner has not filled in.
+// Sections that do not implement it render identically in review mode.
+type ContentReviewer interface {
+	SetContentReview(on bool)
+}
+
+// SetContentReview toggles owner-facing content review mode on every section
+// that implements ContentReviewer. This should be called before Init().
+func (m Model) SetContentReview(on bool) Model {
+	for _, s := range m.sections {
+		if cr, ok := s.(ContentReviewer); ok {
+			cr.SetContentReview(on)
+		}
+	}
+	return m
+}
+
+// MissingPlaceholder renders the dim "<field>: not provided" marker shown in
+// content review mode where an optional block would otherwise be omitted.
+func MissingPlaceholder(theme Theme, field string) string {
+	return theme.NewStyle().
+		Foreground(theme.Colors.Muted).
+		Faint(true).
+		Italic(true).
+		Render(field + ": not provided")
+}
@@ -0,0 +1,40 @@
+package app
+
+import (
+	"strings"
+	"testing"
+)
+
+// reviewSpy is a SectionModel that records content review toggles.
+type reviewSpy struct {
+	placeholderSection
+	review bool
+}
+
+func (s *reviewSpy) SetContentReview(on bool) { s.review = on }
+
+func TestSetContentReviewReachesSections(t *testing.T) {
+	theme := DarkTheme()
+	spy := &reviewSpy{placeholderSection: placeholderSection{name: "cv", theme: theme}}
+	m := New(testContent(),
+		newPlaceholderSection("home", theme),
+		newPlaceholderSection("work", theme),
+		spy,
+	)
+
+	m = m.SetContentReview(true)
+	if !spy.review {
+		t.Error("SetContentReview(true) should reach sections implementing ContentReviewer")
+	}
+	m.SetContentReview(false)
+	if spy.review {
+		t.Error("SetContentReview(false) should turn review mode back off")
+	}
+}
+
+func TestMissingPlaceholder(t *testing.T) {
+	got := stripANSI(MissingPlaceholder(DarkTheme(), "education"))
+	if !strings.Contains(got, "education: not provided") {
+		t.Errorf("MissingPlaceholder = %q, want it to name the field", got)
+	}
+}
@@ -0,0 +1,164 @@
+package app
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// AnimationRequestMsg asks the session's AnimationScheduler to deliver
+// fire's message once d has passed; a nil fire cancels the animation. The
+// root model handles it; sections only pass it on.
+type AnimationRequestMsg struct {
+	id   string
+	d    time.Duration
+	fire func(time.Time) tea.Msg
+}
+
+// Fire returns the message the subscriber is waiting for, as its frame at
+// t would deliver it, or nil for a cancellation. It lets a component be
+// stepped through its animation without a scheduler.
+func (r AnimationRequestMsg) Fire(t time.Time) tea.Msg {
+	if r.fire == nil {
+		return nil
+	}
+	return r.fire(t)
+}
+
+// animationFrameMsg is the scheduler's tick. gen drops ticks the scheduler
+// has since re-armed for an earlier frame.
+type animationFrameMsg struct {
+	gen int
+	at  time.Time
+}
+
+// SubscribeAnimation works like tea.Tick, delivering fire's message once
+// d has passed, but on a frame of the session's shared animation tick
+// rather than a timer of its own. id names the subscriber: a newer
+// subscription with the same id replaces an older one still waiting, so a
+// restarted animation never runs twice over.
+func SubscribeAnimation(id string, d time.Duration, fire func(time.Time) tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		return AnimationRequestMsg{id: id, d: d, fire: fire}
+	}
+}
+
+// UnsubscribeAnimation cancels id's waiting subscription, if any.
+func UnsubscribeAnimation(id string) tea.Cmd {
+	return func() tea.Msg {
+		return AnimationRequestMsg{id: id}
+	}
+}
+
+// animationSub is a subscriber waiting for its frame.
+type animationSub struct {
+	due  time.Time
+	fire func(time.Time) tea.Msg
+}
+
+// AnimationScheduler drives every animation in a session, from the intro
+// and shimmer to cursors and transitions, off a single tick. Frames fall
+// on a shared grid of interval, so subscribers due around the same time
+// wake together, and only one timer is pending however many are waiting.
+// The tick stops while nothing is subscribed.
+type AnimationScheduler struct {
+	interval time.Duration
+	epoch    time.Time // the frame grid's origin
+	subs     map[string]animationSub
+	gen      int
+	armed    time.Time // when the pending tick fires; zero when none is
+}
+
+// NewAnimationScheduler returns a scheduler with frames every interval.
+func NewAnimationScheduler(interval time.Duration) *AnimationScheduler {
+	return &AnimationScheduler{
+		interval: interval,
+		epoch:    time.Now(),
+		subs:     make(map[string]animationSub),
+	}
+}
+
+// Pending returns how many subscribers are waiting.
+func (s *AnimationScheduler) Pending() int {
+	return len(s.subs)
+}
+
+// request records req at now and returns the command for the tick, if it
+// must be armed or brought forward.
+func (s *AnimationScheduler) request(req AnimationRequestMsg, now time.Time) tea.Cmd {
+	if req.fire == nil {
+		delete(s.subs, req.id)
+		return nil
+	}
+	s.subs[req.id] = animationSub{due: now.Add(req.d), fire: req.fire}
+	return s.arm(now)
+}
+
+// frame takes the subscribers due by the tick msg and returns their
+// messages, with the command for the next tick. A stale tick takes none.
+func (s *AnimationScheduler) frame(msg animationFrameMsg) ([]tea.Msg, tea.Cmd) {
+	if msg.gen != s.gen {
+		return nil, nil
+	}
+	s.armed = time.Time{}
+	var ids []string
+	for id, sub := range s.subs {
+		if !sub.due.After(msg.at) {
+			ids = append(ids, id)
+		}
+	}
+	// The earliest first, so messages arrive in the order their ticks
+	// would have fired.
+	slices.SortFunc(ids, func(a, b string) int {
+		return cmp.Or(s.subs[a].due.Compare(s.subs[b].due), strings.Compare(a, b))
+	})
+	due := make([]tea.Msg, len(ids))
+	for i, id := range ids {
+		due[i] = s.subs[id].fire(msg.at)
+		delete(s.subs, id)
+	}
+	return due, s.arm(msg.at)
+}
+
+// arm schedules the tick for the first frame at or after the earliest
+// subscriber's due time, unless the pending tick already comes by then.
+func (s *AnimationScheduler) arm(now time.Time) tea.Cmd {
+	var next time.Time
+	for _, sub := range s.subs {
+		if next.IsZero() || sub.due.Before(next) {
+			next = sub.due
+		}
+	}
+	if next.IsZero() {
+		return nil
+	}
+	// Round up onto the frame grid.
+	if off := next.Sub(s.epoch) % s.interval; off > 0 {
+		next = next.Add(s.interval - off)
+	}
+	if !s.armed.IsZero() && !s.armed.After(next) {
+		return nil
+	}
+	s.gen++
+	s.armed = next
+	gen := s.gen
+	return tea.Tick(next.Sub(now), func(t time.Time) tea.Msg {
+		return animationFrameMsg{gen: gen, at: t}
+	})
+}
+
+// handleAnimationFrame delivers the messages of the subscribers due on a
+// frame, each through Update as if it had arrived on its own.
+func (m Model) handleAnimationFrame(msg animationFrameMsg) (tea.Model, tea.Cmd) {
+	due, next := m.animation.frame(msg)
+	cmds := []tea.Cmd{next}
+	for _, d := range due {
+		result, cmd := m.Update(d)
+		m = result.(Model)
+		cmds = append(cmds, cmd)
+	}
+	return m, tea.Batch(cmds...)
+}
@@ -0,0 +1,103 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// request subscribes id to fire msg after d, as SubscribeAnimation would.
+func request(id string, d time.Duration, msg tea.Msg) AnimationRequestMsg {
+	return SubscribeAnimation(id, d, func(time.Time) tea.Msg { return msg })().(AnimationRequestMsg)
+}
+
+// nextFrame returns the tick the scheduler has armed.
+func nextFrame(s *AnimationScheduler) animationFrameMsg {
+	return animationFrameMsg{gen: s.gen, at: s.armed}
+}
+
+func TestAnimationSchedulerCoalesces(t *testing.T) {
+	s := NewAnimationScheduler(16 * time.Millisecond)
+	now := s.epoch
+
+	if cmd := s.request(request("a", 20*time.Millisecond, "a"), now); cmd == nil {
+		t.Fatal("the first subscriber should arm the tick")
+	}
+	if got := s.armed.Sub(now); got != 32*time.Millisecond {
+		t.Errorf("armed for %v, want the next frame at 32ms", got)
+	}
+	// Due on the same frame, or later, it waits for the pending tick.
+	if cmd := s.request(request("b", 25*time.Millisecond, "b"), now); cmd != nil {
+		t.Error("a subscriber due on the armed frame should not arm another tick")
+	}
+	if cmd := s.request(request("c", 100*time.Millisecond, "c"), now); cmd != nil {
+		t.Error("a later subscriber should not arm another tick")
+	}
+
+	due, cmd := s.frame(nextFrame(s))
+	if len(due) != 2 || due[0] != "a" || due[1] != "b" {
+		t.Errorf("frame delivered %v, want [a b]", due)
+	}
+	if cmd == nil || s.armed.Sub(now) != 112*time.Millisecond {
+		t.Errorf("the tick should be re-armed for c at 112ms, got %v", s.armed.Sub(now))
+	}
+	due, cmd = s.frame(nextFrame(s))
+	if len(due) != 1 || cmd != nil || s.Pending() != 0 {
+		t.Errorf("frame delivered %v with %d waiting; the tick should stop", due, s.Pending())
+	}
+}
+
+func TestAnimationSchedulerReplacesAndCancels(t *testing.T) {
+	s := NewAnimationScheduler(16 * time.Millisecond)
+	now := s.epoch
+
+	s.request(request("shimmer", time.Second, "old"), now)
+	stale := nextFrame(s)
+	// An earlier frame brings the tick forward; the old tick is dropped.
+	if cmd := s.request(request("shimmer", 16*time.Millisecond, "new"), now); cmd == nil {
+		t.Fatal("an earlier subscriber should re-arm the tick")
+	}
+	if s.Pending() != 1 {
+		t.Errorf("a repeated id should replace its subscription, got %d waiting", s.Pending())
+	}
+	if due, _ := s.frame(stale); due != nil {
+		t.Errorf("a stale tick delivered %v", due)
+	}
+	if due, _ := s.frame(nextFrame(s)); len(due) != 1 || due[0] != "new" {
+		t.Errorf("frame delivered %v, want [new]", due)
+	}
+
+	s.request(request("cursor", time.Second, "blink"), now)
+	s.request(UnsubscribeAnimation("cursor")().(AnimationRequestMsg), now)
+	if due, _ := s.frame(nextFrame(s)); len(due) != 0 || s.Pending() != 0 {
+		t.Errorf("a cancelled subscriber delivered %v", due)
+	}
+}
+
+func TestAnimationSchedulerDrivesModel(t *testing.T) {
+	m := skipIntro(t)
+	sh := NewShimmer("test", DarkTheme())
+	req := sh.Start()().(AnimationRequestMsg)
+
+	result, cmd := m.Update(req)
+	m = result.(Model)
+	if cmd == nil || m.animation.Pending() != 1 {
+		t.Fatal("the model should hand subscriptions to its scheduler")
+	}
+	if got := req.Fire(time.Now()); got != (shimmerTickMsg{id: "test"}) {
+		t.Errorf("Fire = %#v, want the shimmer's tick", got)
+	}
+
+	// The frame delivers the tick through Update; the placeholder home
+	// section ignores it, leaving nothing waiting.
+	frame := nextFrame(m.animation)
+	frame.at = frame.at.Add(time.Second)
+	result, _ = m.Update(frame)
+	if m = result.(Model); m.animation.Pending() != 0 {
+		t.Errorf("%d subscribers still waiting after their frame", m.animation.Pending())
+	}
+	if UnsubscribeAnimation("x")().(AnimationRequestMsg).Fire(time.Now()) != nil {
+		t.Error("a cancellation should fire nothing")
+	}
+}
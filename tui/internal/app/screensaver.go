@@ -0,0 +1,157 @@
+package app
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	// screensaverInterval is the screensaver's frame interval. Idle
+	// sessions redraw at a fraction of the usual animation rate.
+	screensaverInterval = 100 * time.Millisecond
+
+	// screensaverShimmerStep is how many shimmer frames pass per
+	// screensaver frame, keeping the shimmer at its usual speed.
+	screensaverShimmerStep = int(screensaverInterval / shimmerTickInterval)
+
+	// screensaverDriftEvery is how many frames pass between each one-cell
+	// step of the logo across the screen.
+	screensaverDriftEvery = 4
+)
+
+// screensaverTickMsg advances the screensaver; gen drops ticks from one
+// that has since been dismissed.
+type screensaverTickMsg struct {
+	gen int
+}
+
+// screensaver drifts the owner's name and title around the screen under
+// a shimmer, bouncing off the edges so no cell stays lit for long.
+type screensaver struct {
+	shimmer Shimmer
+	logo    []string
+	width   int // columns of the widest logo line
+	frame   int
+	x, y    int
+	dx, dy  int
+}
+
+// newScreensaver builds the logo for name and title, spacing the name's
+// letters out in capitals.
+func newScreensaver(theme Theme, name, title string) *screensaver {
+	if name == "" {
+		name = "terminal-portfolio"
+	}
+	spaced := strings.Join(strings.Split(strings.ToUpper(name), ""), " ")
+	logo := []string{spaced, strings.Repeat("─", utf8.RuneCountInString(spaced))}
+	if title != "" {
+		logo = append(logo, title)
+	}
+	s := &screensaver{
+		shimmer: NewShimmer("screensaver", theme),
+		logo:    logo,
+		dx:      1,
+		dy:      1,
+	}
+	for _, line := range logo {
+		s.width = max(s.width, lipgloss.Width(line))
+	}
+	return s
+}
+
+// bounds returns the furthest the logo can sit from the top-left corner
+// of a width by height screen, leaving the bottom row for the hint.
+func (s *screensaver) bounds(width, height int) (maxX, maxY int) {
+	return max(0, width-s.width), max(0, height-1-len(s.logo))
+}
+
+// Tick advances the shimmer and, every few frames, moves the logo one
+// cell, turning back at the edges of the screen.
+func (s *screensaver) Tick(width, height int) {
+	s.frame++
+	s.shimmer.Advance(screensaverShimmerStep)
+	if s.frame%screensaverDriftEvery != 0 {
+		return
+	}
+	maxX, maxY := s.bounds(width, height)
+	if s.x+s.dx < 0 || s.x+s.dx > maxX {
+		s.dx = -s.dx
+	}
+	if s.y+s.dy < 0 || s.y+s.dy > maxY {
+		s.dy = -s.dy
+	}
+	s.x = max(0, min(maxX, s.x+s.dx))
+	s.y = max(0, min(maxY, s.y+s.dy))
+}
+
+// View draws the logo at its place on a width by height screen, with
+// footer on the bottom row.
+func (s *screensaver) View(width, height int, footer string) string {
+	maxX, maxY := s.bounds(width, height)
+	x, y := min(s.x, maxX), min(s.y, maxY)
+
+	var lines []string
+	for range y {
+		lines = append(lines, "")
+	}
+	lines = append(lines, strings.Split(s.shimmer.Render(strings.Join(s.logo, "\n"), s.width), "\n")...)
+	for i := y; i < y+len(s.logo); i++ {
+		lines[i] = strings.Repeat(" ", x) + lines[i]
+	}
+	for len(lines) < height-1 {
+		lines = append(lines, "")
+	}
+	return strings.Join(append(lines, footer), "\n")
+}
+
+// startScreensaver covers the screen with the screensaver and schedules
+// its first frame.
+func (m Model) startScreensaver() (Model, tea.Cmd) {
+	var name, title string
+	if m.content != nil {
+		name, title = m.content.Meta.Name, m.content.Meta.Title
+	}
+	m.screensaver = newScreensaver(m.theme, name, title)
+	m.screensaverGen++
+	return m, screensaverTick(m.screensaverGen)
+}
+
+// stopScreensaver dismisses the screensaver, back to the screen it
+// covered.
+func (m Model) stopScreensaver() Model {
+	m.screensaver = nil
+	m.screensaverGen++
+	return m
+}
+
+// handleScreensaverTick advances the screensaver and schedules the next
+// frame.
+func (m Model) handleScreensaverTick(msg screensaverTickMsg) (tea.Model, tea.Cmd) {
+	if m.screensaver == nil || msg.gen != m.screensaverGen {
+		return m, nil
+	}
+	m.screensaver.Tick(m.width, m.height)
+	return m, screensaverTick(m.screensaverGen)
+}
+
+// screensaverView renders the screensaver. Its bottom row says how to
+// leave it, until the last minute before an idle disconnect, when the
+// countdown takes its place.
+func (m Model) screensaverView() string {
+	footer := lipgloss.PlaceHorizontal(m.width, lipgloss.Center, m.theme.Muted.Render(m.locale.T("screensaver.hint")))
+	if m.showIdleWarning {
+		footer = m.idleWarningView()
+	}
+	return m.screensaver.View(m.width, m.height, footer)
+}
+
+// screensaverTick schedules the next frame of screensaver gen.
+func screensaverTick(gen int) tea.Cmd {
+	return SubscribeAnimation("screensaver", screensaverInterval, func(time.Time) tea.Msg {
+		return screensaverTickMsg{gen: gen}
+	})
+}
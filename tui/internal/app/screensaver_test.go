@@ -0,0 +1,119 @@
+package app
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// idleFor returns m after an idle check that finds it idle for d.
+func idleFor(t *testing.T, m Model, d time.Duration) Model {
+	t.Helper()
+	m.lastActivity = time.Now().Add(-d)
+	result, _ := m.Update(idleCheckMsg{})
+	return result.(Model)
+}
+
+func TestScreensaverStartsWhenIdle(t *testing.T) {
+	m := skipIntro(t).SetIdleTimeout(30 * time.Minute).SetScreensaver(5 * time.Minute)
+
+	if m = idleFor(t, m, 4*time.Minute); m.screensaver != nil {
+		t.Fatal("screensaver should wait for the full delay")
+	}
+	m = idleFor(t, m, 6*time.Minute)
+	if m.screensaver == nil {
+		t.Fatal("screensaver should start after 5m idle")
+	}
+	view := stripANSI(m.View())
+	if !strings.Contains(view, "T E S T   U S E R") || !strings.Contains(view, "press any key to return") {
+		t.Errorf("screensaver should show the logo and how to leave:\n%s", view)
+	}
+	if strings.Contains(view, "Idle timeout in") {
+		t.Error("the disconnect countdown should wait for the last minute")
+	}
+
+	// In the final minute the countdown replaces the hint.
+	m = idleFor(t, m, 29*time.Minute+30*time.Second)
+	view = stripANSI(m.View())
+	if m.screensaver == nil || !strings.Contains(view, "Idle timeout in") || strings.Contains(view, "press any key to return") {
+		t.Errorf("countdown should show over the screensaver in the last minute:\n%s", view)
+	}
+}
+
+func TestScreensaverWithoutIdleTimeout(t *testing.T) {
+	m := skipIntro(t).SetScreensaver(time.Minute)
+	if cmd := m.Init(); cmd == nil {
+		t.Fatal("Init should start the idle check for the screensaver")
+	}
+	if m = idleFor(t, m, 2*time.Minute); m.screensaver == nil {
+		t.Error("screensaver should start without an idle timeout")
+	}
+
+	m = skipIntro(t).SetIdleTimeout(5 * time.Minute)
+	if m = idleFor(t, m, 3*time.Minute); m.screensaver != nil {
+		t.Error("screensaver should stay off when its delay is 0")
+	}
+}
+
+func TestScreensaverExitsOnKey(t *testing.T) {
+	m := skipIntro(t).SetScreensaver(time.Minute)
+	m = idleFor(t, m, 2*time.Minute)
+	gen := m.screensaverGen
+
+	// The key only dismisses the screensaver; it does not switch sections.
+	result, _ := m.Update(tea.KeyMsg{Type: tea.KeyRight})
+	m = result.(Model)
+	if m.screensaver != nil {
+		t.Fatal("a key should dismiss the screensaver")
+	}
+	if m.activeSection != SectionHome || m.transition.Active() {
+		t.Errorf("dismissing key reached the app: section %v", m.activeSection)
+	}
+	if time.Since(m.lastActivity) > time.Second {
+		t.Error("the key should count as activity")
+	}
+	if result, cmd := m.Update(screensaverTickMsg{gen: gen}); cmd != nil || result.(Model).screensaver != nil {
+		t.Error("ticks from a dismissed screensaver should be dropped")
+	}
+}
+
+func TestScreensaverWaitsForGame(t *testing.T) {
+	m := skipIntro(t).SetScreensaver(time.Minute)
+	next, _ := m.startGame()
+	if m = idleFor(t, next.(Model), 2*time.Minute); m.screensaver != nil {
+		t.Error("screensaver should not cover an open game")
+	}
+}
+
+func TestScreensaverDrift(t *testing.T) {
+	s := newScreensaver(DarkTheme(), "Ada", "Engineer")
+	if s.width != len("Engineer") || len(s.logo) != 3 {
+		t.Fatalf("logo = %q (width %d)", s.logo, s.width)
+	}
+
+	// A 12x6 screen leaves room for 4 columns and 2 rows of travel.
+	var xs, ys []int
+	for range 8 * screensaverDriftEvery {
+		s.Tick(12, 6)
+		if s.frame%screensaverDriftEvery == 0 {
+			xs, ys = append(xs, s.x), append(ys, s.y)
+		}
+	}
+	wantX := []int{1, 2, 3, 4, 3, 2, 1, 0}
+	wantY := []int{1, 2, 1, 0, 1, 2, 1, 0}
+	for i := range wantX {
+		if xs[i] != wantX[i] || ys[i] != wantY[i] {
+			t.Fatalf("path = x%v y%v, want x%v y%v", xs, ys, wantX, wantY)
+		}
+	}
+
+	lines := strings.Split(stripANSI(s.View(12, 6, "footer")), "\n")
+	if len(lines) != 6 || lines[5] != "footer" {
+		t.Fatalf("view should fill the screen with the footer last: %q", lines)
+	}
+	if !strings.HasPrefix(lines[0], "A D A") {
+		t.Errorf("logo should be back in the corner: %q", lines[0])
+	}
+}
@@ -0,0 +1,79 @@
+package app
+
+// Scrollbar is where a viewport draws its scrollbar: the column it is in
+// and, counted in rows from the viewport's top, where its thumb is.
+// MaxOffset is the offset the view is at with the thumb at the bottom.
+// Rows is zero when there is no scrollbar.
+type Scrollbar struct {
+	Column      int
+	Rows        int
+	ThumbStart  int
+	ThumbHeight int
+	MaxOffset   int
+}
+
+// Track returns the rows the thumb can move through.
+func (s Scrollbar) Track() int {
+	return s.Rows - s.ThumbHeight
+}
+
+// OffsetFor returns the offset that puts the thumb's top on thumbStart,
+// clamped to the track. It rounds up, so the thumb is drawn where it was
+// put even when the track has more rows than there are offsets.
+func (s Scrollbar) OffsetFor(thumbStart int) int {
+	track := s.Track()
+	if track <= 0 {
+		return 0
+	}
+	thumbStart = max(0, min(thumbStart, track))
+	return (thumbStart*s.MaxOffset + track - 1) / track
+}
+
+// ScrollDragger is an optional interface for sections that draw a
+// scrollbar, reported by their ScrollInfo, so its thumb can be dragged
+// with the mouse.
+type ScrollDragger interface {
+	ScrollReporter
+	// ScrollToLine scrolls the content so that line is at the top of the
+	// view, or as near as it goes.
+	ScrollToLine(line int)
+}
+
+// scrollDrag is a scrollbar thumb held by the mouse, grabbed grab rows
+// below its top.
+type scrollDrag struct {
+	grab int
+}
+
+// grabScrollbar starts dragging the active section's scrollbar thumb for a
+// press at col, row of the section. A press on the track above or below
+// the thumb brings the thumb's middle to it first. It reports false when
+// the press is not on the scrollbar.
+func (m *Model) grabScrollbar(col, row int) bool {
+	sd, ok := m.sections[m.activeSection].(ScrollDragger)
+	if !ok {
+		return false
+	}
+	bar := sd.ScrollInfo().Scrollbar
+	if bar.Rows == 0 || col != bar.Column || row < 0 || row >= bar.Rows {
+		return false
+	}
+	grab := row - bar.ThumbStart
+	if grab < 0 || grab >= bar.ThumbHeight {
+		grab = bar.ThumbHeight / 2
+		sd.ScrollToLine(bar.OffsetFor(row - grab))
+	}
+	m.scrollDrag = &scrollDrag{grab: grab}
+	return true
+}
+
+// dragScrollbar moves the held thumb to the mouse on row of the section,
+// scrolling the content in proportion.
+func (m Model) dragScrollbar(row int) Model {
+	sd, ok := m.sections[m.activeSection].(ScrollDragger)
+	if !ok {
+		return m
+	}
+	sd.ScrollToLine(sd.ScrollInfo().Scrollbar.OffsetFor(row - m.scrollDrag.grab))
+	return m
+}
@@ -0,0 +1,319 @@
+package sections
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/buntingszn/terminal-portfolio/tui/internal/analytics"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/app"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/i18n"
+)
+
+// adminRefresh is how often the focused admin section re-reads the live
+// sessions. Summaries are only reloaded on focus or with r.
+const adminRefresh = 5 * time.Second
+
+// adminJourneys is how many of the latest visitor journeys the admin
+// section lists.
+const adminJourneys = 5
+
+// adminLoadTimeout is how long the admin section waits on the summaries
+// before saying so; r tries again.
+const adminLoadTimeout = 10 * time.Second
+
+// AdminSession is one live SSH session as the admin section lists it.
+type AdminSession struct {
+	User    string
+	IP      string
+	Started time.Time
+}
+
+// AdminSetting is one line of server configuration. Values must already
+// have secrets redacted.
+type AdminSetting struct {
+	Name  string
+	Value string
+}
+
+// AdminSource supplies what the admin section shows. The server
+// implements it for owner sessions.
+type AdminSource interface {
+	// Sessions lists the live sessions, oldest first.
+	Sessions() []AdminSession
+	// Analytics returns daily analytics summaries for the days up to and
+	// including now's, newest first, and the visitor journeys of those
+	// days, oldest first, as analytics.Journeys orders them. It may read
+	// the whole analytics log.
+	Analytics(now time.Time) ([]analytics.Summary, []analytics.Journey, error)
+	// Settings lists the server configuration.
+	Settings() []AdminSetting
+}
+
+// adminTickMsg re-reads the live sessions. gen ties it to one focus.
+type adminTickMsg struct {
+	gen int
+}
+
+// adminSummariesMsg carries summaries and journeys loaded in the
+// background.
+type adminSummariesMsg struct {
+	gen       int
+	summaries []analytics.Summary
+	journeys  []analytics.Journey
+	err       error
+}
+
+// AdminSection shows the owner live sessions, recent analytics and visitor
+// journeys, and the server configuration.
+type AdminSection struct {
+	source   AdminSource
+	theme    app.Theme
+	locale   *i18n.Locale
+	viewport app.Viewport
+	width    int
+	height   int
+	focused  bool
+	gen      int
+
+	summaries []analytics.Summary
+	journeys  []analytics.Journey
+	load      app.Spinner
+}
+
+// NewAdminSection creates an AdminSection reading from source. A nil
+// source renders the section as unavailable.
+func NewAdminSection(source AdminSource, theme app.Theme) *AdminSection {
+	return &AdminSection{
+		source:   source,
+		theme:    theme,
+		viewport: app.NewViewport(0, 0),
+		load:     app.NewSpinner("admin-summaries", theme, adminLoadTimeout),
+	}
+}
+
+// Init implements app.SectionModel.
+func (a *AdminSection) Init() tea.Cmd {
+	return nil
+}
+
+// Update implements app.SectionModel.
+func (a *AdminSection) Update(msg tea.Msg) (app.SectionModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		a.width = msg.Width
+		a.height = msg.Height
+		a.viewport.SetSize(a.width, a.height)
+		a.viewport.SetContentPreserveScroll(a.renderContent())
+
+	case tea.KeyMsg:
+		if !a.focused {
+			break
+		}
+		switch msg.String() {
+		case "j", "down":
+			a.viewport.ScrollDown(1)
+		case "k", "up":
+			a.viewport.ScrollUp(1)
+		case "h", "left":
+			a.viewport.ScrollLeft(columnStep)
+		case "l", "right":
+			a.viewport.ScrollRight(columnStep)
+		case "g", "home":
+			return a, a.viewport.ScrollToAnimated(0)
+		case "G", "end":
+			return a, a.viewport.ScrollToAnimated(a.viewport.TotalLines())
+		case "r":
+			cmd := a.loadSummaries()
+			a.viewport.SetContentPreserveScroll(a.renderContent())
+			return a, cmd
+		}
+
+	case tea.MouseMsg:
+		if !a.focused {
+			break
+		}
+		switch msg.Button {
+		case tea.MouseButtonWheelUp:
+			a.viewport.ScrollUp(scrollStep)
+		case tea.MouseButtonWheelDown:
+			a.viewport.ScrollDown(scrollStep)
+		}
+
+	case adminTickMsg:
+		if !a.focused || msg.gen != a.gen {
+			break
+		}
+		a.viewport.SetContentPreserveScroll(a.renderContent())
+		return a, a.tick()
+
+	case adminSummariesMsg:
+		if msg.gen != a.gen {
+			break
+		}
+		a.summaries, a.journeys = msg.summaries, msg.journeys
+		a.load.Finish(msg.err)
+		a.viewport.SetContentPreserveScroll(a.renderContent())
+
+	case app.AnimationTickMsg:
+		return a, a.viewport.Animate(msg)
+
+	case app.ThemeChangedMsg:
+		a.theme = msg.Theme
+		a.load.SetTheme(msg.Theme)
+		a.viewport.SetContentPreserveScroll(a.renderContent())
+
+	case app.LocaleChangedMsg:
+		a.locale = msg.Locale
+		a.viewport.SetContentPreserveScroll(a.renderContent())
+
+	case app.FocusMsg:
+		a.focused = true
+		a.gen++
+		load := a.loadSummaries()
+		a.viewport.SetContent(a.renderContent())
+		a.viewport.ScrollToTop()
+		return a, tea.Batch(a.tick(), load)
+
+	case app.BlurMsg:
+		a.focused = false
+
+	default:
+		if a.load.IsTick(msg) {
+			var cmd tea.Cmd
+			a.load, cmd = a.load.Update(msg)
+			a.viewport.SetContentPreserveScroll(a.renderContent())
+			return a, cmd
+		}
+	}
+
+	return a, nil
+}
+
+// tick schedules the next session refresh for the current focus.
+func (a *AdminSection) tick() tea.Cmd {
+	gen := a.gen
+	return tea.Tick(adminRefresh, func(time.Time) tea.Msg {
+		return adminTickMsg{gen: gen}
+	})
+}
+
+// loadSummaries starts reading the summaries and journeys in the
+// background.
+func (a *AdminSection) loadSummaries() tea.Cmd {
+	if a.source == nil {
+		return nil
+	}
+	gen, source := a.gen, a.source
+	return tea.Batch(a.load.Start(), func() tea.Msg {
+		summaries, journeys, err := source.Analytics(time.Now())
+		return adminSummariesMsg{gen: gen, summaries: summaries, journeys: journeys, err: err}
+	})
+}
+
+// View implements app.SectionModel.
+func (a *AdminSection) View() string {
+	return a.viewport.ViewWithScrollbar(a.theme)
+}
+
+// ScrollInfo implements app.ScrollReporter for the status bar scroll indicator.
+func (a *AdminSection) ScrollInfo() app.ScrollInfo {
+	return a.viewport.GetScrollInfo()
+}
+
+// ScrollToLine implements app.ScrollDragger.
+func (a *AdminSection) ScrollToLine(line int) {
+	a.viewport.SetYOffset(line)
+}
+
+// Position implements app.PositionRestorer with the scroll offset.
+func (a *AdminSection) Position() int {
+	return a.viewport.YOffset()
+}
+
+// RestorePosition implements app.PositionRestorer.
+func (a *AdminSection) RestorePosition(pos int) {
+	a.viewport.SetYOffset(pos)
+}
+
+// KeyHints implements app.KeyHinter for contextual status bar hints.
+func (a *AdminSection) KeyHints() []app.KeyHint {
+	return []app.KeyHint{scrollHint(a.locale), app.Hint(a.locale.T("hint.reload"), app.KeyReload), app.HelpHint(a.locale)}
+}
+
+// renderContent builds the live sessions, daily summaries, journeys, and
+// settings.
+func (a *AdminSection) renderContent() string {
+	if a.source == nil {
+		return app.ErrorState(a.theme, a.locale.T("admin.unavailable"), a.locale.T("admin.unavailable.hint"), a.viewport.ContentWidth())
+	}
+
+	textWidth := max(1, a.viewport.ContentWidth()-4)
+	line := func(style lipgloss.Style, s string) string {
+		return "  " + style.Render(app.TruncateWithEllipsis(s, textWidth)) + "\n"
+	}
+	var b strings.Builder
+	now := time.Now()
+
+	sessions := a.source.Sessions()
+	b.WriteString("\n" + line(a.theme.Title, fmt.Sprintf("Live sessions (%d)", len(sessions))) + "\n")
+	for _, s := range sessions {
+		b.WriteString(line(a.theme.Body, fmt.Sprintf("%-15s %-12s %s",
+			s.IP, app.TruncateWithEllipsis(s.User, 12), formatAge(now.Sub(s.Started)))))
+	}
+
+	b.WriteString("\n" + line(a.theme.Title, "Analytics") + "\n")
+	switch {
+	case a.load.Err() != nil || a.summaries == nil && a.load.Running():
+		b.WriteString("  " + a.load.View("Loading analytics") + "\n")
+	case len(a.summaries) == 0:
+		b.WriteString(line(a.theme.Muted, "Analytics are disabled."))
+	}
+	for i, s := range a.summaries {
+		day := s.Start.Format("Mon 01-02")
+		if i == 0 {
+			day = "Today"
+		}
+		row := fmt.Sprintf("%-10s %3d visitors  %3d sessions  avg %s", day, s.Visitors, s.Sessions, formatAge(s.AvgSession))
+		if len(s.Sections) > 0 {
+			row += "  top " + s.Sections[0].Section
+		}
+		b.WriteString(line(a.theme.Body, row))
+	}
+
+	if a.journeys != nil {
+		b.WriteString("\n" + line(a.theme.Title, "Latest journeys") + "\n")
+		if len(a.journeys) == 0 {
+			b.WriteString(line(a.theme.Muted, "No visits yet."))
+		}
+		for i := len(a.journeys) - 1; i >= max(0, len(a.journeys)-adminJourneys); i-- {
+			j := a.journeys[i]
+			b.WriteString(line(a.theme.Body, fmt.Sprintf("visitor %-4d %s  %s",
+				j.Visitor, j.Start.Format("Mon 01-02 15:04"), formatAge(j.Total))))
+			b.WriteString(line(a.theme.Muted, "  "+j.Path()))
+			b.WriteString(line(a.theme.Accent, "  "+j.Timeline(textWidth-2)))
+		}
+	}
+
+	b.WriteString("\n" + line(a.theme.Title, "Server") + "\n")
+	nameWidth := 0
+	settings := a.source.Settings()
+	for _, s := range settings {
+		nameWidth = max(nameWidth, lipgloss.Width(s.Name))
+	}
+	for _, s := range settings {
+		b.WriteString(line(a.theme.Muted, app.PadRight(s.Name, nameWidth)+"  "+s.Value))
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// formatAge formats a duration to the second, or as "-" when negative.
+func formatAge(d time.Duration) string {
+	if d < 0 {
+		return "-"
+	}
+	return d.Round(time.Second).String()
+}
@@ -0,0 +1,22 @@
+package sections
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// leftClick reports whether msg is a press of the left mouse button.
+func leftClick(msg tea.MouseMsg) bool {
+	return msg.Action == tea.MouseActionPress && msg.Button == tea.MouseButtonLeft
+}
+
+// itemAt returns the index of the list item on content line, given the
+// line each item starts on, in order. An item runs up to the next one, so
+// the blank line after it counts as its own. It returns -1 above the first.
+func itemAt(starts []int, line int) int {
+	item := -1
+	for i, start := range starts {
+		if line < start {
+			break
+		}
+		item = i
+	}
+	return item
+}
@@ -1,13 +1,18 @@
 package sections
 
 import (
+	"bytes"
 	"fmt"
 	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/buntingszn/terminal-portfolio/tui/internal/app"
 	"github.com/buntingszn/terminal-portfolio/tui/internal/content"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/i18n"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/resume"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/textmode"
 )
 
 // CVSection implements app.SectionModel to render CV data in a single-column
@@ -16,12 +21,38 @@ import (
 type CVSection struct {
 	content *content.Content
 	theme   app.Theme
+	locale  *i18n.Locale
 	viewport app.Viewport
 	width   int
 	height  int
 	focused bool
+	review  bool
+	// timeline draws experience and education as one timeline, toggled
+	// with v.
+	timeline bool
+	// downloadFeedback replaces the key hints after a download is sent.
+	downloadFeedback string
+	// anchors are the headings of the rendered content.
+	anchors []app.Anchor
+	memo    renderMemo[cvRenderKey]
 }
 
+// cvRenderKey is everything the CV's content is rendered from. The
+// header's relative times are read to the minute.
+type cvRenderKey struct {
+	content *content.Content
+	colors  app.Colors
+	width   int
+	density app.DensityLevel
+	review  bool
+	minute  time.Time
+	// timeline is whether the timeline layout is on.
+	timeline bool
+}
+
+// cvHeadings are the divider titles the CV can be jumped through by.
+var cvHeadings = []string{"EXPERIENCE", "TIMELINE", "SKILLS", "EDUCATION"}
+
 // NewCVSection creates a new CVSection with the given content and theme.
 func NewCVSection(c *content.Content, theme app.Theme) *CVSection {
 	return &CVSection{
@@ -30,6 +61,11 @@ func NewCVSection(c *content.Content, theme app.Theme) *CVSection {
 	}
 }
 
+// SetContentReview implements app.ContentReviewer.
+func (s *CVSection) SetContentReview(on bool) {
+	s.review = on
+}
+
 // Init implements app.SectionModel.
 func (s *CVSection) Init() tea.Cmd {
 	return nil
@@ -53,20 +89,32 @@ func (s *CVSection) Update(msg tea.Msg) (app.SectionModel, tea.Cmd) {
 			s.viewport.ScrollDown(1)
 		case "k", "up":
 			s.viewport.ScrollUp(1)
+		case "h", "left":
+			s.viewport.ScrollLeft(columnStep)
+		case "l", "right":
+			s.viewport.ScrollRight(columnStep)
 		case "g", "home":
-			s.viewport.ScrollToTop()
+			return s, s.viewport.ScrollToAnimated(0)
 		case "G", "end":
-			s.viewport.ScrollToBottom()
+			return s, s.viewport.ScrollToAnimated(s.viewport.TotalLines())
 		case "pgup":
-			s.viewport.ScrollUp(s.viewport.VisibleLines())
+			return s, s.viewport.ScrollByAnimated(-s.viewport.VisibleLines())
 		case "pgdown":
-			s.viewport.ScrollDown(s.viewport.VisibleLines())
+			return s, s.viewport.ScrollByAnimated(s.viewport.VisibleLines())
 		case "ctrl+u":
-			s.viewport.ScrollUp(s.viewport.VisibleLines() / 2)
+			return s, s.viewport.ScrollByAnimated(-s.viewport.VisibleLines() / 2)
 		case "ctrl+d":
-			s.viewport.ScrollDown(s.viewport.VisibleLines() / 2)
+			return s, s.viewport.ScrollByAnimated(s.viewport.VisibleLines() / 2)
+		case "d":
+			return s, s.Download("")
+		case "v":
+			s.timeline = !s.timeline
+			s.viewport.SetContentPreserveScroll(s.renderContent())
 		}
 
+	case clearCopyFeedbackMsg:
+		s.downloadFeedback = ""
+
 	case tea.MouseMsg:
 		if !s.focused {
 			break
@@ -78,6 +126,20 @@ func (s *CVSection) Update(msg tea.Msg) (app.SectionModel, tea.Cmd) {
 			s.viewport.ScrollDown(3)
 		}
 
+	case app.AnimationTickMsg:
+		return s, s.viewport.Animate(msg)
+
+	case app.ThemeChangedMsg:
+		s.theme = msg.Theme
+		s.viewport.SetContentPreserveScroll(s.renderContent())
+
+	case app.LocaleChangedMsg:
+		s.locale = msg.Locale
+
+	case app.ContentChangedMsg:
+		s.content = msg.Content
+		s.viewport.SetContentPreserveScroll(s.renderContent())
+
 	case app.FocusMsg:
 		s.focused = true
 		s.viewport.ScrollToTop()
@@ -99,22 +161,89 @@ func (s *CVSection) ScrollInfo() app.ScrollInfo {
 	return s.viewport.GetScrollInfo()
 }
 
+// Position implements app.PositionRestorer with the scroll offset.
+func (s *CVSection) Position() int {
+	return s.viewport.YOffset()
+}
+
+// RestorePosition implements app.PositionRestorer.
+func (s *CVSection) RestorePosition(pos int) {
+	s.viewport.SetYOffset(pos)
+}
+
+// Anchors implements app.Anchorer with the CV's divider headings.
+func (s *CVSection) Anchors() []app.Anchor {
+	return s.anchors
+}
+
+// TopLine implements app.Anchorer.
+func (s *CVSection) TopLine() int {
+	return s.viewport.YOffset()
+}
+
+// ScrollToLine implements app.Anchorer and app.ScrollDragger.
+func (s *CVSection) ScrollToLine(line int) {
+	s.viewport.SetYOffset(line)
+}
+
+// Download implements app.Downloader. It offers the CV to the terminal as
+// a PDF, or as plain text for the "txt" format, and points visitors whose
+// terminal ignores the transfer at the equivalent ssh command.
+func (s *CVSection) Download(format string) tea.Cmd {
+	name, data, command := resume.FileName, resume.PDF(s.content), "pdf"
+	if format == "txt" {
+		var b bytes.Buffer
+		_ = textmode.Run(&b, "cv", textmode.Source{Content: s.content})
+		name, data, command = "resume.txt", b.Bytes(), "cv"
+	}
+	s.downloadFeedback = s.locale.T("cv.sent", name) + " " + app.BorderVertical + " " +
+		s.locale.T("cv.unsaved", command, name)
+	return tea.Batch(
+		app.WriteTerminal(app.FileTransferSequence(name, data)),
+		tea.Tick(5*time.Second, func(time.Time) tea.Msg {
+			return clearCopyFeedbackMsg{}
+		}),
+	)
+}
+
 // KeyHints implements app.KeyHinter.
-func (s *CVSection) KeyHints() string {
-	return "j/k scroll " + app.BorderVertical + " pgup/dn page " + app.BorderVertical + " ^u/^d half " + app.BorderVertical + " 1-4 nav " + app.BorderVertical + " ? help"
+func (s *CVSection) KeyHints() []app.KeyHint {
+	if s.downloadFeedback != "" {
+		return app.Feedback(s.downloadFeedback)
+	}
+	layout := app.Hint(s.locale.T("hint.timeline"), app.KeyTimeline)
+	if s.timeline {
+		layout = app.Hint(s.locale.T("hint.list"), app.KeyTimeline)
+	}
+	return []app.KeyHint{scrollHint(s.locale), pageHint(s.locale), halfPageHint(s.locale), app.Hint(s.locale.T("hint.download"), app.KeyDownload), layout, app.NavHint(s.locale), app.HelpHint(s.locale)}
 }
 
 // sectionDivider renders a reverse-video section heading: accent background, bg foreground.
 func (s *CVSection) sectionDivider(title string) string {
-	style := lipgloss.NewStyle().
+	style := s.theme.NewStyle().
 		Background(s.theme.Colors.Accent).
 		Foreground(s.theme.Colors.Bg).
 		Bold(true)
 	return style.Render(" " + title + " ")
 }
 
-// renderContent builds the full single-column text layout.
+// renderContent returns the full single-column text layout, building it
+// only when something it depends on has changed.
 func (s *CVSection) renderContent() string {
+	key := cvRenderKey{
+		content:  s.content,
+		colors:   s.theme.Colors,
+		width:    s.viewport.ContentWidth(),
+		density:  app.DensityForHeight(s.height),
+		review:   s.review,
+		minute:   time.Now().Truncate(time.Minute),
+		timeline: s.timeline,
+	}
+	return s.memo.render(key, s.buildContent)
+}
+
+// buildContent builds the full single-column text layout.
+func (s *CVSection) buildContent() string {
 	cv := s.content.CV
 	meta := s.content.Meta
 	bodyStyle := s.theme.Body
@@ -131,7 +260,7 @@ func (s *CVSection) renderContent() string {
 	var sections []string
 
 	// Header: name in accent+bold.
-	nameStyle := lipgloss.NewStyle().Foreground(s.theme.Colors.Accent).Bold(true)
+	nameStyle := s.theme.NewStyle().Foreground(s.theme.Colors.Accent).Bold(true)
 	sections = append(sections, nameStyle.Render(meta.Name))
 
 	// Contact line: email · location in muted.
@@ -142,9 +271,21 @@ func (s *CVSection) renderContent() string {
 	}
 	if cv.Contact.Location != "" {
 		contactParts = append(contactParts, mutedStyle.Render(cv.Contact.Location))
+	} else if s.review {
+		contactParts = append(contactParts, app.MissingPlaceholder(s.theme, "location"))
 	}
+	var header []string
 	if len(contactParts) > 0 {
-		sections = append(sections, strings.Join(contactParts, mutedStyle.Render(" · ")))
+		header = append(header, strings.Join(contactParts, mutedStyle.Render(" · ")))
+	}
+	if a := s.content.About.Availability; a != nil {
+		header = append(header, app.AvailabilityBadge(s.theme, *a, time.Now(), contentWidth))
+	}
+	if rel := app.RelativeTime(s.content.UpdatedAt, time.Now()); rel != "" {
+		header = append(header, mutedStyle.Render("updated "+rel))
+	}
+	if len(header) > 0 {
+		sections = append(sections, strings.Join(header, "\n"))
 	}
 
 	// Summary.
@@ -153,20 +294,36 @@ func (s *CVSection) renderContent() string {
 		if dividerWidth < 10 {
 			dividerWidth = 10
 		}
-		wrapped := app.WrapText(cv.Summary, dividerWidth)
-		sections = append(sections, bodyStyle.Render(strings.Join(wrapped, "\n")))
+		wrapped := app.WrapMarked(s.theme, bodyStyle, cv.Summary, dividerWidth)
+		sections = append(sections, strings.Join(wrapped, "\n"))
 	}
 
-	sections = append(sections, s.renderExperience(contentWidth))
-	sections = append(sections, s.renderSkills(contentWidth))
-	sections = append(sections, s.renderEducation())
+	// The timeline needs room for its year gutter; narrower, it falls
+	// back to the list.
+	if s.timeline && contentWidth >= timelineMinWidth {
+		sections = append(sections, s.renderTimeline(contentWidth))
+		sections = append(sections, s.renderSkills(contentWidth))
+	} else {
+		sections = append(sections, s.renderExperience(contentWidth))
+		sections = append(sections, s.renderSkills(contentWidth))
+		sections = append(sections, s.renderEducation())
+	}
 
-	return app.PadLinesToWidth("\n"+strings.Join(sections, sep), contentWidth)
+	body := "\n" + strings.Join(sections, sep)
+	s.anchors = s.anchors[:0]
+	for i, line := range strings.Split(body, "\n") {
+		for _, title := range cvHeadings {
+			if strings.Contains(line, s.sectionDivider(title)) {
+				s.anchors = append(s.anchors, app.Anchor{Name: title, Line: i})
+			}
+		}
+	}
+	return app.PadLinesToWidth(body, contentWidth)
 }
 
 // renderExperience builds the experience block with reverse-video divider.
 func (s *CVSection) renderExperience(contentWidth int) string {
-	accentStyle := lipgloss.NewStyle().Foreground(s.theme.Colors.Accent).Bold(true)
+	accentStyle := s.theme.NewStyle().Foreground(s.theme.Colors.Accent).Bold(true)
 	bodyStyle := s.theme.Body
 	mutedStyle := s.theme.Muted
 
@@ -198,12 +355,12 @@ func (s *CVSection) renderExperience(contentWidth int) string {
 		b.WriteByte('\n')
 
 		for _, bullet := range exp.Bullets {
-			wrapped := app.WrapText(bullet, contentWidth-6)
+			wrapped := app.WrapMarked(s.theme, bodyStyle, bullet, contentWidth-6)
 			for j, line := range wrapped {
 				if j == 0 {
-					b.WriteString("    " + bodyStyle.Render("- "+line))
+					b.WriteString("    " + bodyStyle.Render("- ") + line)
 				} else {
-					b.WriteString("      " + bodyStyle.Render(line))
+					b.WriteString("      " + line)
 				}
 				b.WriteByte('\n')
 			}
@@ -257,10 +414,13 @@ func (s *CVSection) renderSkills(contentWidth int) string {
 func (s *CVSection) renderEducation() string {
 	education := s.content.CV.Education
 	if len(education) == 0 {
+		if s.review {
+			return s.sectionDivider("EDUCATION") + "\n\n  " + app.MissingPlaceholder(s.theme, "education") + "\n"
+		}
 		return ""
 	}
 
-	accentStyle := lipgloss.NewStyle().Foreground(s.theme.Colors.Accent).Bold(true)
+	accentStyle := s.theme.NewStyle().Foreground(s.theme.Colors.Accent).Bold(true)
 	mutedStyle := s.theme.Muted
 
 	var b strings.Builder
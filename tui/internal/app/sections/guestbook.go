@@ -0,0 +1,446 @@
+package sections
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/buntingszn/terminal-portfolio/tui/internal/app"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/content"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/guestbook"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/i18n"
+)
+
+// guestbookSignedMsg reports the outcome of a guestbook write.
+type guestbookSignedMsg struct {
+	entry guestbook.Entry
+	err   error
+}
+
+// clearGuestbookFeedbackMsg is sent after a delay to clear the feedback text.
+type clearGuestbookFeedbackMsg struct{}
+
+// GuestbookSection lists guestbook entries newest-first and lets the
+// visitor sign with a short message. Entries are signed with the visitor's
+// SSH username; their IP is passed to the store for rate limiting only.
+type GuestbookSection struct {
+	store      *guestbook.Store
+	author     string
+	ip         string
+	theme      app.Theme
+	locale     *i18n.Locale
+	keys       app.KeyMap
+	viewport   app.Viewport
+	width      int
+	height     int
+	focused    bool
+	entries    []guestbook.Entry
+	composing  bool
+	submitting bool
+	input      []rune
+	feedback   string
+	layout     *guestbookLayout // the entries' layout at the last render
+}
+
+// NewGuestbookSection creates a GuestbookSection reading from and writing
+// to store on behalf of the visitor author connecting from ip. A blank
+// author signs as guestbook.Anonymous. A nil store renders the section as
+// unavailable.
+func NewGuestbookSection(store *guestbook.Store, author, ip string, theme app.Theme) *GuestbookSection {
+	return &GuestbookSection{
+		store:    store,
+		author:   author,
+		ip:       ip,
+		theme:    theme,
+		viewport: app.NewViewport(0, 0),
+		entries:  store.Entries(),
+	}
+}
+
+// Init implements app.SectionModel.
+func (g *GuestbookSection) Init() tea.Cmd {
+	return nil
+}
+
+// CapturingInput implements app.InputCapturer while a message is being typed.
+func (g *GuestbookSection) CapturingInput() bool {
+	return g.composing
+}
+
+// Update implements app.SectionModel.
+func (g *GuestbookSection) Update(msg tea.Msg) (app.SectionModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		g.width = msg.Width
+		g.height = msg.Height
+		g.viewport.SetSize(g.width, g.height)
+		g.viewport.SetSourcePreserveScroll(g.renderContent())
+
+	case tea.KeyMsg:
+		if !g.focused {
+			break
+		}
+		if g.composing {
+			return g, g.handleComposeKey(msg)
+		}
+		switch msg.String() {
+		case "s", "enter":
+			if g.store == nil {
+				break
+			}
+			g.composing = true
+			g.viewport.SetSource(g.renderContent())
+			g.viewport.ScrollToTop()
+		case "j", "down":
+			g.viewport.ScrollDown(1)
+		case "k", "up":
+			g.viewport.ScrollUp(1)
+		case "h", "left":
+			g.viewport.ScrollLeft(columnStep)
+		case "l", "right":
+			g.viewport.ScrollRight(columnStep)
+		case "g", "home":
+			return g, g.viewport.ScrollToAnimated(0)
+		case "G", "end":
+			return g, g.viewport.ScrollToAnimated(g.viewport.TotalLines())
+		case "pgup":
+			return g, g.viewport.ScrollByAnimated(-g.viewport.VisibleLines())
+		case "pgdown":
+			return g, g.viewport.ScrollByAnimated(g.viewport.VisibleLines())
+		case "ctrl+u":
+			return g, g.viewport.ScrollByAnimated(-g.viewport.VisibleLines() / 2)
+		case "ctrl+d":
+			return g, g.viewport.ScrollByAnimated(g.viewport.VisibleLines() / 2)
+		}
+
+	case tea.MouseMsg:
+		if !g.focused {
+			break
+		}
+		switch msg.Button {
+		case tea.MouseButtonWheelUp:
+			g.viewport.ScrollUp(scrollStep)
+		case tea.MouseButtonWheelDown:
+			g.viewport.ScrollDown(scrollStep)
+		}
+
+	case guestbookSignedMsg:
+		g.submitting = false
+		switch {
+		case msg.err == nil:
+			g.composing = false
+			g.input = nil
+			g.feedback = g.locale.T("guestbook.signed")
+			g.entries = g.store.Entries()
+		case errors.Is(msg.err, guestbook.ErrEmpty):
+			g.feedback = g.locale.T("guestbook.empty")
+		case errors.Is(msg.err, guestbook.ErrRateLimited):
+			g.composing = false
+			g.feedback = g.locale.T("guestbook.limited")
+		default:
+			g.feedback = g.locale.T("guestbook.failed")
+		}
+		g.viewport.SetSource(g.renderContent())
+		g.viewport.ScrollToTop()
+		return g, tea.Tick(3*time.Second, func(time.Time) tea.Msg {
+			return clearGuestbookFeedbackMsg{}
+		})
+
+	case clearGuestbookFeedbackMsg:
+		g.feedback = ""
+
+	case app.AnimationTickMsg:
+		return g, g.viewport.Animate(msg)
+
+	case app.ThemeChangedMsg:
+		g.theme = msg.Theme
+		g.viewport.SetSourcePreserveScroll(g.renderContent())
+
+	case app.LocaleChangedMsg:
+		g.locale = msg.Locale
+		g.viewport.SetSourcePreserveScroll(g.renderContent())
+
+	case app.KeyMapChangedMsg:
+		g.keys = msg.Keys
+		g.viewport.SetSourcePreserveScroll(g.renderContent())
+
+	case app.FocusMsg:
+		g.focused = true
+		// Pick up entries signed by other sessions since the last visit.
+		g.entries = g.store.Entries()
+		g.viewport.SetSource(g.renderContent())
+		g.viewport.ScrollToTop()
+
+	case app.BlurMsg:
+		g.focused = false
+		g.composing = false
+		g.viewport.SetSource(g.renderContent())
+	}
+
+	return g, nil
+}
+
+// handleComposeKey edits the draft message and submits or cancels it.
+func (g *GuestbookSection) handleComposeKey(msg tea.KeyMsg) tea.Cmd {
+	switch msg.Type {
+	case tea.KeyEscape:
+		g.composing = false
+	case tea.KeyEnter:
+		if g.submitting {
+			return nil
+		}
+		g.submitting = true
+		store, ip, author, text := g.store, g.ip, g.author, string(g.input)
+		return func() tea.Msg {
+			e, err := store.Add(ip, author, text)
+			return guestbookSignedMsg{entry: e, err: err}
+		}
+	case tea.KeyBackspace:
+		if len(g.input) > 0 {
+			g.input = g.input[:len(g.input)-1]
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		room := guestbook.MaxMessageLen - len(g.input)
+		g.input = append(g.input, msg.Runes[:min(len(msg.Runes), room)]...)
+	default:
+		return nil
+	}
+	g.viewport.SetSource(g.renderContent())
+	return nil
+}
+
+// View implements app.SectionModel.
+func (g *GuestbookSection) View() string {
+	return g.viewport.ViewWithScrollbar(g.theme)
+}
+
+// ScrollInfo implements app.ScrollReporter for the status bar scroll indicator.
+func (g *GuestbookSection) ScrollInfo() app.ScrollInfo {
+	return g.viewport.GetScrollInfo()
+}
+
+// ScrollToLine implements app.ScrollDragger.
+func (g *GuestbookSection) ScrollToLine(line int) {
+	g.viewport.SetYOffset(line)
+}
+
+// Position implements app.PositionRestorer with the scroll offset.
+func (g *GuestbookSection) Position() int {
+	return g.viewport.YOffset()
+}
+
+// RestorePosition implements app.PositionRestorer.
+func (g *GuestbookSection) RestorePosition(pos int) {
+	g.viewport.SetYOffset(pos)
+}
+
+// KeyHints implements app.KeyHinter for contextual status bar hints.
+func (g *GuestbookSection) KeyHints() []app.KeyHint {
+	if g.feedback != "" {
+		return app.Feedback(g.feedback)
+	}
+	if g.composing {
+		// The message box takes every key, so these are not the key map's.
+		return []app.KeyHint{
+			{Keys: "enter", Text: g.locale.T("hint.sign")},
+			{Keys: "esc", Text: g.locale.T("hint.cancel")},
+			{Text: fmt.Sprintf("%d/%d", len(g.input), guestbook.MaxMessageLen)},
+		}
+	}
+	return []app.KeyHint{app.Hint(g.locale.T("hint.sign"), app.KeySign), scrollHint(g.locale), app.NavHint(g.locale), app.HelpHint(g.locale)}
+}
+
+// renderContent lays out the compose area followed by the entries. Only
+// the compose area is rendered up front: entries are styled as they scroll
+// into view, however many the guestbook has collected.
+func (g *GuestbookSection) renderContent() *guestbookLines {
+	if g.store == nil {
+		return &guestbookLines{
+			theme:  g.theme,
+			head:   strings.Split(app.ErrorState(g.theme, g.locale.T("guestbook.unavailable"), g.locale.T("state.try_later"), g.viewport.ContentWidth()), "\n"),
+			layout: &guestbookLayout{starts: []int{0}},
+		}
+	}
+
+	textWidth := max(1, g.viewport.ContentWidth()-4)
+	var b strings.Builder
+
+	// Top padding.
+	b.WriteByte('\n')
+
+	if g.composing {
+		prompt := g.theme.Accent.Render("> ")
+		lines := wrapHard(string(g.input)+"█", textWidth)
+		for i, line := range lines {
+			if i > 0 {
+				prompt = "  "
+			}
+			b.WriteString("  " + prompt + g.theme.Body.Render(line) + "\n")
+		}
+		name := guestbook.Sanitize(g.author, guestbook.MaxNameLen)
+		if name == "" {
+			name = guestbook.Anonymous
+		}
+		signer := g.locale.T("guestbook.signing_as", name)
+		b.WriteString("    " + g.theme.Muted.Render(app.TruncateWithEllipsis(signer, textWidth)))
+	} else {
+		prompt := g.locale.T("guestbook.prompt", g.keys.Label(app.KeySign))
+		b.WriteString("  " + g.theme.Muted.Render(app.TruncateWithEllipsis(prompt, textWidth+2)))
+	}
+
+	if len(g.entries) == 0 {
+		b.WriteString("\n" + app.EmptyState(g.theme, g.locale.T("guestbook.none"), g.locale.T("guestbook.none.hint"), g.viewport.ContentWidth()))
+	}
+
+	// The entries are laid out again only when they or the width change,
+	// not for every key typed into the compose area.
+	now := time.Now()
+	if l := g.layout; l == nil || l.textWidth != textWidth || !sameEntries(l.entries, g.entries) {
+		g.layout = newGuestbookLayout(g.entries, textWidth, now)
+	}
+	return &guestbookLines{
+		theme:  g.theme,
+		head:   strings.Split(b.String(), "\n"),
+		layout: g.layout,
+		now:    now,
+	}
+}
+
+// sameEntries reports whether a and b are the same slice, as returned by
+// one call to guestbook.Store.Entries.
+func sameEntries(a, b []guestbook.Entry) bool {
+	return len(a) == len(b) && (len(a) == 0 || &a[0] == &b[0])
+}
+
+// guestbookLayout is where each guestbook entry falls, keeping only the
+// line each starts on rather than its lines.
+type guestbookLayout struct {
+	entries   []guestbook.Entry
+	textWidth int
+	starts    []int // each entry's first line, then the line count
+	width     int
+	words     int
+}
+
+// newGuestbookLayout lays out entries wrapped to textWidth, with their
+// ages as of now.
+func newGuestbookLayout(entries []guestbook.Entry, textWidth int, now time.Time) *guestbookLayout {
+	l := &guestbookLayout{
+		entries:   entries,
+		textWidth: textWidth,
+		starts:    make([]int, len(entries)+1),
+	}
+	line := 0
+	for i, e := range entries {
+		l.starts[i] = line
+		name, when, message := entryText(e, textWidth, now)
+		l.width = max(l.width, 2+lipgloss.Width(name)+lipgloss.Width(when))
+		for _, m := range message {
+			l.width = max(l.width, 2+lipgloss.Width(m))
+		}
+		l.words += content.CountWords(e.Name, e.Message)
+		line += 2 + len(message)
+	}
+	l.starts[len(entries)] = line
+	return l
+}
+
+// entryText returns e's name, cut to fit beside its age as of now, the
+// age, and the message wrapped to textWidth, all without styles.
+func entryText(e guestbook.Entry, textWidth int, now time.Time) (name, when string, message []string) {
+	when = " · " + app.RelativeTime(e.Time, now)
+	nameWidth := max(1, textWidth-lipgloss.Width(when))
+	return app.TruncateWithEllipsis(e.Name, nameWidth), when, wrapHard(e.Message, textWidth)
+}
+
+// guestbookLines is an app.LineSource for the guestbook: the rendered
+// compose area, then a blank line, a header, and the wrapped message for
+// every entry, each rendered again whenever it is shown.
+type guestbookLines struct {
+	theme  app.Theme
+	head   []string
+	layout *guestbookLayout
+	now    time.Time
+}
+
+// Len implements app.LineSource.
+func (l *guestbookLines) Len() int {
+	return len(l.head) + l.layout.starts[len(l.layout.entries)]
+}
+
+// Width implements app.LineSource.
+func (l *guestbookLines) Width() int {
+	width := l.layout.width
+	for _, line := range l.head {
+		width = max(width, lipgloss.Width(line))
+	}
+	return width
+}
+
+// Words implements app.LineSource.
+func (l *guestbookLines) Words() int {
+	return content.CountWords(l.head...) + l.layout.words
+}
+
+// RenderLines implements app.LineSource.
+func (l *guestbookLines) RenderLines(start, end int) []string {
+	out := make([]string, 0, end-start)
+	for n := start; n < end && n < len(l.head); n++ {
+		out = append(out, l.head[n])
+	}
+	if len(out) == end-start {
+		return out
+	}
+	// From the entry holding the first line still wanted, counted from the
+	// first entry.
+	first := start + len(out) - len(l.head)
+	last := end - len(l.head)
+	i, found := slices.BinarySearch(l.layout.starts, first)
+	if !found {
+		i--
+	}
+	nameStyle := l.theme.NewStyle().Foreground(l.theme.Colors.Accent).Bold(true)
+	for ; first < last; i++ {
+		name, when, message := entryText(l.layout.entries[i], l.layout.textWidth, l.now)
+		lines := make([]string, 0, 2+len(message))
+		lines = append(lines, "", "  "+nameStyle.Render(name)+l.theme.Muted.Render(when))
+		for _, m := range message {
+			lines = append(lines, "  "+l.theme.Body.Render(m))
+		}
+		from, to := first-l.layout.starts[i], min(len(lines), last-l.layout.starts[i])
+		out = append(out, lines[from:to]...)
+		first += to - from
+	}
+	return out
+}
+
+// wrapHard word-wraps text to width and splits any word that is still too
+// long, so visitor text can never overflow the section.
+func wrapHard(text string, width int) []string {
+	var out []string
+	for _, line := range app.WrapText(text, width) {
+		if lipgloss.Width(line) <= width {
+			out = append(out, line)
+			continue
+		}
+		var cur strings.Builder
+		w := 0
+		for _, r := range line {
+			rw := lipgloss.Width(string(r))
+			if w+rw > width && w > 0 {
+				out = append(out, cur.String())
+				cur.Reset()
+				w = 0
+			}
+			cur.WriteRune(r)
+			w += rw
+		}
+		out = append(out, cur.String())
+	}
+	return out
+}
@@ -0,0 +1,27 @@
+package sections
+
+import (
+	"github.com/buntingszn/terminal-portfolio/tui/internal/app"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/i18n"
+)
+
+// scrollHint returns the hint, worded in l, for scrolling with j and k.
+func scrollHint(l *i18n.Locale) app.KeyHint {
+	return app.Hint(l.T("hint.scroll"), app.KeyScrollDown, app.KeyScrollUp)
+}
+
+// navigateHint returns the hint, worded in l, for moving a list's cursor
+// with j and k.
+func navigateHint(l *i18n.Locale) app.KeyHint {
+	return app.Hint(l.T("hint.navigate"), app.KeyScrollDown, app.KeyScrollUp)
+}
+
+// pageHint returns the hint, worded in l, for paging up and down.
+func pageHint(l *i18n.Locale) app.KeyHint {
+	return app.Hint(l.T("hint.page"), app.KeyPageUp, app.KeyPageDown)
+}
+
+// halfPageHint returns the hint, worded in l, for moving half a page.
+func halfPageHint(l *i18n.Locale) app.KeyHint {
+	return app.Hint(l.T("hint.half"), app.KeyHalfPageUp, app.KeyHalfPageDown)
+}
@@ -1,6 +1,7 @@
 package sections
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"time"
@@ -9,6 +10,9 @@ import (
 	"github.com/charmbracelet/lipgloss"
 	"github.com/buntingszn/terminal-portfolio/tui/internal/app"
 	"github.com/buntingszn/terminal-portfolio/tui/internal/content"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/fetch"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/graphics"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/i18n"
 )
 
 // portraitMinWidth is the minimum terminal width needed to show the ASCII
@@ -18,6 +22,10 @@ const portraitMinWidth = 80
 // scrollStep is how many lines to scroll per key press.
 const scrollStep = 3
 
+// columnStep is how many columns h/l and the arrows scroll content wider
+// than the section.
+const columnStep = 8
+
 const (
 	// revealLinesPerTick is how many content lines to reveal each tick.
 	revealLinesPerTick = 1
@@ -27,18 +35,43 @@ const (
 	revealTickInterval = 120 * time.Millisecond
 )
 
+// bookingOpenings is how many upcoming slots the booking callout lists.
+const bookingOpenings = 3
+
+// BookingSlots supplies the open slots previewed under the booking link.
+// The server implements it with a cached booking.Preview.
+type BookingSlots interface {
+	Slots(ctx context.Context, now time.Time) ([]time.Time, error)
+}
+
+// openingsTimeout bounds the booking slot fetch.
+const openingsTimeout = 10 * time.Second
+
+// openingsPolicy tries the slot source once: it retries and caches on
+// its own, for every session at once.
+var openingsPolicy = fetch.Policy{Timeout: openingsTimeout}
+
+// homeSlotsMsg carries the booking slots loaded in the background.
+type homeSlotsMsg struct {
+	slots []time.Time
+	err   error
+}
+
+// clearBookingCopiedMsg clears the booking link's copy confirmation.
+type clearBookingCopiedMsg struct{}
+
 // homeRevealTickMsg advances the line-by-line reveal animation.
 type homeRevealTickMsg struct{}
 
 // homeRevealTick schedules the next reveal tick.
 func homeRevealTick() tea.Cmd {
-	return tea.Tick(revealTickInterval, func(_ time.Time) tea.Msg {
+	return app.SubscribeAnimation("home-reveal", revealTickInterval, func(_ time.Time) tea.Msg {
 		return homeRevealTickMsg{}
 	})
 }
 
 // portrait is a Braille halftone developer portrait shown beside the bio text.
-// Generated from a headshot photo using scripts/img2braille.py with Atkinson
+// Generated from a headshot photo with the braille command's defaults: Atkinson
 // dithering and CLAHE preprocessing for facial feature preservation.
 const portrait = "" +
 	"⣿⣿⣿⢿⣿⣿⣿⠿⠿⣟⡻⢿⣿⡿⢿⣿⣽⣻⣿⣻⢬⣹\n" +
@@ -56,10 +89,18 @@ const portrait = "" +
 	"⡷⠌⠀⣹⡻⣦⡈⣿⢽⣿⢷⣖⣾⠟⠁⢀⣼⡿⢏⡛⠻⣷\n" +
 	"⣴⣾⡟⠁⣷⣌⠻⠌⠛⢿⠟⠚⢋⣐⣿⠿⡏⣀⠘⢿⡳⢮"
 
+// PortraitSize returns the braille portrait's size in cells. A photo shown
+// in its place is prepared at this size so the layout does not change.
+func PortraitSize() (cols, rows int) {
+	lines := strings.Split(portrait, "\n")
+	return lipgloss.Width(lines[0]), len(lines)
+}
+
 // HomeSection implements app.SectionModel and renders the bio/about view.
 type HomeSection struct {
 	content        *content.Content
 	theme          app.Theme
+	locale         *i18n.Locale
 	viewport       app.Viewport
 	portraitShimmer app.Shimmer
 	width          int
@@ -68,6 +109,28 @@ type HomeSection struct {
 	revealLines    int  // number of lines currently visible during reveal
 	revealDone     bool // true when reveal animation is complete
 	hasRevealed    bool // true after first reveal finishes (prevents replay)
+	review         bool // render placeholders for missing optional fields
+	reducedMotion  bool // no shimmer or reveal, from app.MotionChangedMsg
+
+	// art is the braille portrait: the built-in one, or art made from the
+	// data directory's photo.
+	art string
+
+	// image replaces the braille portrait with the photo on terminals that
+	// support an image protocol; imageSent records its one-time setup.
+	image     *graphics.Placement
+	imageSent bool
+
+	// lastVisit is when a returning visitor was last here, for the
+	// greeting above the bio; zero for everyone else.
+	lastVisit time.Time
+
+	// bookingSlots previews openings under the booking link; nil shows
+	// the link alone.
+	bookingSlots  BookingSlots
+	openings      []time.Time
+	openingsLoad  app.Spinner
+	bookingCopied bool
 }
 
 // NewHomeSection creates a new HomeSection with the given content and theme.
@@ -75,12 +138,49 @@ func NewHomeSection(c *content.Content, theme app.Theme) *HomeSection {
 	return &HomeSection{
 		content:        c,
 		theme:          theme,
+		art:            portrait,
 		viewport:       app.NewViewport(0, 0),
 		portraitShimmer: app.NewShimmer("portrait-shimmer", theme),
+		openingsLoad:   app.NewSpinner("home-openings", theme, openingsTimeout),
 		revealDone:     true, // safe default until first FocusMsg
 	}
 }
 
+// SetContentReview implements app.ContentReviewer.
+func (h *HomeSection) SetContentReview(on bool) {
+	h.review = on
+}
+
+// SetPortraitArt shows art, braille made from the portrait photo, in place
+// of the built-in portrait. Empty art keeps the built-in one.
+func (h *HomeSection) SetPortraitArt(art string) {
+	if art != "" {
+		h.art = art
+	}
+}
+
+// SetPortraitImage shows p in place of the braille portrait. A nil
+// placement keeps the braille art.
+func (h *HomeSection) SetPortraitImage(p *graphics.Placement) {
+	h.image = p
+}
+
+// SetLastVisit greets a returning visitor with when they were last here,
+// and skips the bio reveal they have already watched. A zero t greets no
+// one.
+func (h *HomeSection) SetLastVisit(t time.Time) {
+	h.lastVisit = t
+	if !t.IsZero() {
+		h.hasRevealed = true
+	}
+}
+
+// SetBookingSlots previews the open slots from src under the booking
+// link. A nil src shows the link alone.
+func (h *HomeSection) SetBookingSlots(src BookingSlots) {
+	h.bookingSlots = src
+}
+
 // Init implements app.SectionModel.
 func (h *HomeSection) Init() tea.Cmd {
 	return nil
@@ -105,18 +205,24 @@ func (h *HomeSection) Update(msg tea.Msg) (app.SectionModel, tea.Cmd) {
 			h.viewport.ScrollDown(scrollStep)
 		case "k", "up":
 			h.viewport.ScrollUp(scrollStep)
+		case "h", "left":
+			h.viewport.ScrollLeft(columnStep)
+		case "l", "right":
+			h.viewport.ScrollRight(columnStep)
 		case "g", "home":
-			h.viewport.ScrollToTop()
+			return h, h.viewport.ScrollToAnimated(0)
 		case "G", "end":
-			h.viewport.ScrollToBottom()
+			return h, h.viewport.ScrollToAnimated(h.viewport.TotalLines())
 		case "pgup":
-			h.viewport.ScrollUp(h.viewport.VisibleLines())
+			return h, h.viewport.ScrollByAnimated(-h.viewport.VisibleLines())
 		case "pgdown":
-			h.viewport.ScrollDown(h.viewport.VisibleLines())
+			return h, h.viewport.ScrollByAnimated(h.viewport.VisibleLines())
 		case "ctrl+u":
-			h.viewport.ScrollUp(h.viewport.VisibleLines() / 2)
+			return h, h.viewport.ScrollByAnimated(-h.viewport.VisibleLines() / 2)
 		case "ctrl+d":
-			h.viewport.ScrollDown(h.viewport.VisibleLines() / 2)
+			return h, h.viewport.ScrollByAnimated(h.viewport.VisibleLines() / 2)
+		case "b":
+			return h, h.copyBooking()
 		}
 
 	case tea.MouseMsg:
@@ -131,22 +237,74 @@ func (h *HomeSection) Update(msg tea.Msg) (app.SectionModel, tea.Cmd) {
 			h.viewport.ScrollDown(scrollStep)
 		}
 
+	case app.AnimationTickMsg:
+		return h, h.viewport.Animate(msg)
+
+	case app.ThemeChangedMsg:
+		h.theme = msg.Theme
+		h.portraitShimmer.SetTheme(msg.Theme)
+		h.openingsLoad.SetTheme(msg.Theme)
+		h.viewport.SetContentPreserveScroll(h.buildContent())
+
+	case app.LocaleChangedMsg:
+		h.locale = msg.Locale
+
+	case app.ContentChangedMsg:
+		h.content = msg.Content
+		h.viewport.SetContentPreserveScroll(h.buildContent())
+
+	case app.MotionChangedMsg:
+		h.reducedMotion = msg.Reduced
+		if !msg.Reduced {
+			if h.focused && h.image == nil {
+				return h, h.portraitShimmer.Start()
+			}
+			break
+		}
+		// Settle mid-animation: the shimmer stops and the bio shows whole.
+		stop := h.portraitShimmer.Stop()
+		h.completeReveal()
+		h.hasRevealed = true
+		h.viewport.SetContentPreserveScroll(h.buildContent())
+		return h, stop
+
 	case app.FocusMsg:
 		h.focused = true
-		h.viewport.ScrollToTop()
-		cmds := []tea.Cmd{h.portraitShimmer.Start()}
-		if !h.hasRevealed {
+		var cmds []tea.Cmd
+		if h.image == nil && !h.reducedMotion {
+			cmds = append(cmds, h.portraitShimmer.Start())
+		} else if h.image != nil && !h.imageSent {
+			h.imageSent = true
+			cmds = append(cmds, app.WriteTerminal(h.image.Setup()))
+		}
+		if !h.hasRevealed && !h.reducedMotion {
 			h.revealLines = 1
 			h.revealDone = false
-			h.viewport.SetContent(h.buildContent())
 			cmds = append(cmds, homeRevealTick())
 		}
+		cmds = append(cmds, h.loadOpenings())
+		h.viewport.SetContent(h.buildContent())
 		return h, tea.Batch(cmds...)
 
+	case homeSlotsMsg:
+		h.openingsLoad.Finish(msg.err)
+		if msg.err == nil {
+			h.openings = msg.slots
+		}
+		h.viewport.SetContentPreserveScroll(h.buildContent())
+
+	case clearBookingCopiedMsg:
+		h.bookingCopied = false
+		h.viewport.SetContentPreserveScroll(h.buildContent())
+
 	case app.BlurMsg:
 		h.focused = false
-		h.portraitShimmer.Stop()
+		stop := h.portraitShimmer.Stop()
 		h.completeReveal()
+		// A portrait painted over the frame gives way to braille before the
+		// section slides out.
+		h.viewport.SetContentPreserveScroll(h.buildContent())
+		return h, stop
 
 	case homeRevealTickMsg:
 		if h.revealDone {
@@ -158,13 +316,21 @@ func (h *HomeSection) Update(msg tea.Msg) (app.SectionModel, tea.Cmd) {
 		if h.revealLines >= totalLines {
 			h.revealDone = true
 			h.hasRevealed = true
-			h.viewport.SetContentPreserveScroll(full)
+			// Rebuild rather than reuse full: a settled section may swap
+			// the braille portrait for the photo.
+			h.viewport.SetContentPreserveScroll(h.buildFullContent())
 			return h, nil
 		}
 		h.viewport.SetContentPreserveScroll(h.buildContent())
 		return h, homeRevealTick()
 
 	default:
+		if h.openingsLoad.IsTick(msg) {
+			var cmd tea.Cmd
+			h.openingsLoad, cmd = h.openingsLoad.Update(msg)
+			h.viewport.SetContentPreserveScroll(h.buildContent())
+			return h, cmd
+		}
 		// Delegate shimmer ticks.
 		var cmd tea.Cmd
 		h.portraitShimmer, cmd = h.portraitShimmer.Update(msg)
@@ -177,6 +343,38 @@ func (h *HomeSection) Update(msg tea.Msg) (app.SectionModel, tea.Cmd) {
 	return h, nil
 }
 
+// copyBooking copies the booking link, confirming beside it until a tick
+// clears the confirmation. It returns nil when there is no link.
+func (h *HomeSection) copyBooking() tea.Cmd {
+	if h.content == nil || h.content.About.Booking == "" {
+		return nil
+	}
+	link := h.content.About.Booking
+	h.bookingCopied = true
+	h.viewport.SetContentPreserveScroll(h.buildContent())
+	return tea.Batch(
+		app.CopyToClipboard(link),
+		tea.Tick(2*time.Second, func(time.Time) tea.Msg {
+			return clearBookingCopiedMsg{}
+		}),
+	)
+}
+
+// loadOpenings fetches the booking slots in the background. The source
+// caches them, so this runs on every focus. A spinner stands in for the
+// slots until the first fetch returns. A failed fetch keeps the slots
+// already shown and shows no error, since visitors cannot act on it.
+func (h *HomeSection) loadOpenings() tea.Cmd {
+	if h.bookingSlots == nil || h.content == nil || h.content.About.Booking == "" {
+		return nil
+	}
+	src := h.bookingSlots
+	return tea.Batch(h.openingsLoad.Start(), fetch.Cmd(openingsPolicy,
+		func(ctx context.Context) ([]time.Time, error) { return src.Slots(ctx, time.Now()) },
+		func(slots []time.Time, err error) tea.Msg { return homeSlotsMsg{slots: slots, err: err} },
+	))
+}
+
 // completeReveal finishes any running line-by-line reveal animation immediately.
 func (h *HomeSection) completeReveal() {
 	if h.revealDone {
@@ -197,9 +395,39 @@ func (h *HomeSection) ScrollInfo() app.ScrollInfo {
 	return h.viewport.GetScrollInfo()
 }
 
+// ScrollToLine implements app.ScrollDragger.
+func (h *HomeSection) ScrollToLine(line int) {
+	h.viewport.SetYOffset(line)
+}
+
+// Position implements app.PositionRestorer with the scroll offset.
+func (h *HomeSection) Position() int {
+	return h.viewport.YOffset()
+}
+
+// RestorePosition implements app.PositionRestorer.
+func (h *HomeSection) RestorePosition(pos int) {
+	h.viewport.SetYOffset(pos)
+}
+
+// ActiveAnimations implements app.AnimationReporter for the debug overlay.
+func (h *HomeSection) ActiveAnimations() int {
+	n := 0
+	if h.portraitShimmer.Active() {
+		n++
+	}
+	if !h.revealDone {
+		n++
+	}
+	return n
+}
+
 // KeyHints implements app.KeyHinter for contextual status bar hints.
-func (h *HomeSection) KeyHints() string {
-	return "j/k scroll " + app.BorderVertical + " pgup/dn page " + app.BorderVertical + " ^u/^d half " + app.BorderVertical + " 1-4 nav " + app.BorderVertical + " ? help"
+func (h *HomeSection) KeyHints() []app.KeyHint {
+	if h.content != nil && h.content.About.Booking != "" {
+		return []app.KeyHint{scrollHint(h.locale), app.Hint(h.locale.T("hint.booking"), app.KeyBooking), app.NavHint(h.locale), app.HelpHint(h.locale)}
+	}
+	return []app.KeyHint{scrollHint(h.locale), pageHint(h.locale), halfPageHint(h.locale), app.NavHint(h.locale), app.HelpHint(h.locale)}
 }
 
 // buildFullContent builds the complete section text regardless of reveal state.
@@ -214,7 +442,7 @@ func (h *HomeSection) buildFullContent() string {
 		contentWidth = 1
 	}
 
-	if contentWidth >= portraitMinWidth && portrait != "" {
+	if contentWidth >= portraitMinWidth && h.art != "" {
 		return h.renderNeofetch(about, contentWidth)
 	}
 	return h.renderStacked(about, contentWidth)
@@ -234,14 +462,34 @@ func (h *HomeSection) buildContent() string {
 	return strings.Join(lines[:h.revealLines], "\n")
 }
 
+// showImage reports whether the photo is displayed instead of braille.
+// Kitty placeholders are ordinary cells and are always safe to show. Sixel
+// and iTerm2 images are painted over the frame and survive only while it
+// stays still, so they are shown only once the focused section has
+// finished revealing and has nothing to scroll.
+func (h *HomeSection) showImage() bool {
+	if h.image == nil {
+		return false
+	}
+	if h.image.OverFrame() {
+		return h.focused && h.revealDone &&
+			h.viewport.TotalLines() <= h.viewport.VisibleLines()
+	}
+	return true
+}
+
 // styledPortrait returns the portrait text with shimmer or muted styling.
+// When the terminal can show the photo, its cells are returned instead.
 func (h *HomeSection) styledPortrait() string {
+	if h.showImage() {
+		return h.image.Cells()
+	}
 	if h.portraitShimmer.Active() {
-		firstLine := strings.SplitN(portrait, "\n", 2)[0]
+		firstLine := strings.SplitN(h.art, "\n", 2)[0]
 		pw := lipgloss.Width(firstLine)
-		return h.portraitShimmer.Render(portrait, pw)
+		return h.portraitShimmer.Render(h.art, pw)
 	}
-	return h.theme.Muted.Render(portrait)
+	return h.theme.Muted.Render(h.art)
 }
 
 // renderNeofetch renders the side-by-side neofetch-style layout.
@@ -265,26 +513,31 @@ func (h *HomeSection) renderNeofetch(about content.About, contentWidth int) stri
 
 	var lines []string
 
+	if greeting := h.renderGreeting(rightColWidth); greeting != "" {
+		lines = append(lines, greeting, "")
+	}
+
 	// Bio word-wrapped.
 	if about.Bio != "" {
 		bioWidth := rightColWidth
 		if bioWidth < 10 {
 			bioWidth = 10
 		}
-		wrapped := app.WrapText(about.Bio, bioWidth)
-		for _, wl := range wrapped {
-			lines = append(lines, h.theme.Body.Render(wl))
-		}
+		lines = append(lines, app.WrapMarked(h.theme, h.theme.Body, about.Bio, bioWidth)...)
 	}
 
 	// Blank line before info fields.
 	lines = append(lines, "")
 
-	infoBlock := h.renderInfo(about)
+	infoBlock := h.renderInfo(about, rightColWidth)
 	if infoBlock != "" {
 		lines = append(lines, infoBlock)
 	}
 
+	if updated := h.renderUpdated(); updated != "" {
+		lines = append(lines, "", updated)
+	}
+
 	rightBlock := strings.Join(lines, "\n")
 	gapStr := strings.Repeat(" ", gap)
 
@@ -298,34 +551,81 @@ func (h *HomeSection) renderStacked(about content.About, contentWidth int) strin
 
 	var sections []string
 
+	if greeting := h.renderGreeting(contentWidth); greeting != "" {
+		sections = append(sections, greeting)
+	}
+
 	// Bio.
 	if about.Bio != "" {
-		wrapped := app.WrapText(about.Bio, contentWidth)
-		sections = append(sections, h.theme.Body.Render(strings.Join(wrapped, "\n")))
+		wrapped := app.WrapMarked(h.theme, h.theme.Body, about.Bio, contentWidth)
+		sections = append(sections, strings.Join(wrapped, "\n"))
 	}
 
-	// Info fields (status, email, CLI).
-	infoBlock := h.renderInfo(about)
+	// Info fields (availability, email, web).
+	infoBlock := h.renderInfo(about, contentWidth)
 	if infoBlock != "" {
 		sections = append(sections, infoBlock)
 	}
 
+	if updated := h.renderUpdated(); updated != "" {
+		sections = append(sections, updated)
+	}
+
 	return strings.Join(sections, sep)
 }
 
-// renderInfo renders status, email, and CLI with accent-colored labels.
-func (h *HomeSection) renderInfo(about content.About) string {
+// renderGreeting renders the welcome back line for a returning visitor,
+// truncated to width, or returns "" for everyone else.
+func (h *HomeSection) renderGreeting(width int) string {
+	rel := app.RelativeTime(h.lastVisit, time.Now())
+	if rel == "" {
+		return ""
+	}
+	return h.theme.Accent.Render(app.TruncateWithEllipsis("Welcome back, last visit "+rel, width))
+}
+
+// renderUpdated renders the muted "content updated N ago" footer, or an
+// empty string when the content freshness is unknown.
+func (h *HomeSection) renderUpdated() string {
+	rel := app.RelativeTime(h.content.UpdatedAt, time.Now())
+	if rel == "" {
+		return ""
+	}
+	return h.theme.Muted.Render("content updated " + rel)
+}
+
+// renderOpenings lists the next few booking slots in muted text, truncated
+// to width, or returns "" when there are none. A spinner shows while the
+// first slots load.
+func (h *HomeSection) renderOpenings(width int) string {
+	if width <= 0 {
+		return ""
+	}
+	if len(h.openings) == 0 {
+		if h.openingsLoad.Running() {
+			return h.openingsLoad.View("checking openings")
+		}
+		return ""
+	}
+	var times []string
+	for _, t := range h.openings[:min(len(h.openings), bookingOpenings)] {
+		times = append(times, t.Format("Mon 2 Jan 15:04 MST"))
+	}
+	return h.theme.Muted.Render(app.TruncateWithEllipsis("next: "+strings.Join(times, " · "), width))
+}
+
+// renderInfo renders the availability badge, then booking, email and web
+// with accent-colored labels.
+func (h *HomeSection) renderInfo(about content.About, width int) string {
 	var lines []string
 
 	labelStyle := h.theme.Accent
 	valueStyle := h.theme.Body
 
-	if about.Status != "" {
-		lines = append(lines, fmt.Sprintf(
-			"%s %s",
-			labelStyle.Render("Status"),
-			valueStyle.Render(about.Status),
-		))
+	if about.Availability != nil {
+		lines = append(lines, app.AvailabilityBadge(h.theme, *about.Availability, time.Now(), width))
+	} else if h.review {
+		lines = append(lines, app.MissingPlaceholder(h.theme, "availability"))
 	}
 	if about.Email != "" {
 		lines = append(lines, fmt.Sprintf(
@@ -334,6 +634,22 @@ func (h *HomeSection) renderInfo(about content.About) string {
 			valueStyle.Render(about.Email),
 		))
 	}
+	if booking := about.Booking; booking != "" {
+		hint := "b to copy"
+		if h.bookingCopied {
+			hint = "copied!"
+		}
+		display := strings.TrimPrefix(strings.TrimPrefix(booking, "https://"), "http://")
+		lines = append(lines, fmt.Sprintf(
+			"%s %s %s",
+			labelStyle.Render("Book"),
+			app.RenderHyperlink(booking, valueStyle.Render(display)),
+			h.theme.Muted.Render("("+hint+")"),
+		))
+		if openings := h.renderOpenings(width - 5); openings != "" {
+			lines = append(lines, "     "+openings)
+		}
+	}
 	if siteURL := h.content.Meta.SiteURL; siteURL != "" {
 		display := strings.TrimPrefix(siteURL, "https://")
 		lines = append(lines, fmt.Sprintf(
@@ -341,6 +657,8 @@ func (h *HomeSection) renderInfo(about content.About) string {
 			labelStyle.Render("Web"),
 			valueStyle.Render(display),
 		))
+	} else if h.review {
+		lines = append(lines, app.MissingPlaceholder(h.theme, "siteUrl"))
 	}
 
 	return strings.Join(lines, "\n")
@@ -0,0 +1,221 @@
+package sections
+
+import (
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"strings"
+	"testing"
+
+	"github.com/buntingszn/terminal-portfolio/tui/internal/app"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/content"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/guestbook"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/testutil"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// fuzzSizes are the terminal sizes the input tests run at: below the
+// minimum, the minimum, common sizes, and the split layout.
+var fuzzSizes = [][2]int{
+	{10, 4}, {app.MinWidth, app.MinHeight}, {40, 12}, {80, 24}, {120, 40}, {app.SplitMinWidth, 50},
+}
+
+// fuzzKeyTypes returns every key type bubbletea reports: the named keys
+// from KeyF20 to KeyRunes, the control characters, and backspace.
+func fuzzKeyTypes() []tea.KeyType {
+	var types []tea.KeyType
+	for t := tea.KeyF20; t <= tea.KeyCtrlUnderscore; t++ {
+		types = append(types, t)
+	}
+	return append(types, tea.KeyBackspace)
+}
+
+// fuzzSession is a full model, with every section as the server builds
+// them, and the terminal size it was last given.
+type fuzzSession struct {
+	m             app.Model
+	width, height int
+	history       []tea.Msg
+}
+
+// fuzzFixture is what every session of a test shares: the content and a
+// guestbook.
+type fuzzFixture struct {
+	content   *content.Content
+	guestbook *guestbook.Store
+}
+
+func newFuzzFixture(t *testing.T) fuzzFixture {
+	return fuzzFixture{testutil.FixtureContent(), newTestGuestbook(t)}
+}
+
+// session starts a session past the intro at section s and the given
+// size. Status and admin are shown, although nothing backs them, so their
+// unavailable states get input too.
+func (fx fuzzFixture) session(t *testing.T, s app.Section, width, height int) *fuzzSession {
+	t.Helper()
+	c := fx.content
+	theme := testutil.FixtureTheme()
+	m := app.New(c,
+		NewHomeSection(c, theme),
+		NewWorkSection(c, theme),
+		NewCVSection(c, theme),
+		NewLinksSection(c, theme),
+		NewGuestbookSection(fx.guestbook, "visitor", "192.0.2.1", theme),
+		NewStatusSection(nil, theme),
+		NewAdminSection(nil, theme),
+		NewNotesSection(c, theme),
+		NewUsesSection(c, theme),
+		NewTalksSection(c, theme),
+	)
+	m = m.SetSectionHidden(app.SectionStatus, false).SetSectionHidden(app.SectionAdmin, false)
+	if s != app.SectionHome {
+		m = m.StartAt(s)
+	}
+	f := &fuzzSession{m: m}
+	for _, msg := range []tea.Msg{tea.WindowSizeMsg{Width: width, Height: height}, tea.KeyMsg{Type: tea.KeySpace}, app.IntroDoneMsg{}} {
+		f.send(t, msg)
+	}
+	f.history = nil
+	return f
+}
+
+// send updates the model with msg and renders it, failing the test with
+// the messages that led there if either panics or the frame does not fit
+// the terminal.
+func (f *fuzzSession) send(t *testing.T, msg tea.Msg) {
+	t.Helper()
+	f.history = append(f.history, msg)
+	if size, ok := msg.(tea.WindowSizeMsg); ok {
+		f.width, f.height = size.Width, size.Height
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			t.Fatalf("%dx%d: panic %v after %s", f.width, f.height, r, f.describe())
+		}
+	}()
+	result, _ := f.m.Update(msg)
+	f.m = result.(app.Model)
+
+	lines := strings.Split(f.m.View(), "\n")
+	if len(lines) > f.height {
+		t.Fatalf("%dx%d: frame is %d lines after %s", f.width, f.height, len(lines), f.describe())
+	}
+	for i, line := range lines {
+		if w := lipgloss.Width(line); w > f.width {
+			t.Fatalf("%dx%d: line %d is %d columns after %s", f.width, f.height, i, w, f.describe())
+		}
+	}
+}
+
+// describe lists the last messages sent, enough to reproduce most
+// failures by hand.
+func (f *fuzzSession) describe() string {
+	msgs := f.history[max(0, len(f.history)-12):]
+	parts := make([]string, len(msgs))
+	for i, msg := range msgs {
+		switch msg := msg.(type) {
+		case tea.KeyMsg:
+			parts[i] = fmt.Sprintf("key %q", msg.String())
+		case tea.MouseMsg:
+			parts[i] = fmt.Sprintf("mouse %s at %d,%d", msg.String(), msg.X, msg.Y)
+		default:
+			parts[i] = fmt.Sprintf("%T%+v", msg, msg)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// quietRenderLog drops the frame guard's logs for the rest of the test.
+// It truncates what overflows the terminal and logs it; the tests check
+// what it lets through.
+func quietRenderLog(t *testing.T) {
+	prev := slog.Default()
+	slog.SetDefault(slog.New(slog.DiscardHandler))
+	t.Cleanup(func() { slog.SetDefault(prev) })
+}
+
+// TestEveryKeyInEverySection sends each key type, plain and with alt, in
+// every section at the minimum size, a common size, and the split
+// layout, or only the common size in short mode. Each key is followed by
+// escape, which closes what it opened.
+func TestEveryKeyInEverySection(t *testing.T) {
+	quietRenderLog(t)
+	fx := newFuzzFixture(t)
+	escape := tea.KeyMsg{Type: tea.KeyEscape}
+	sizes := [][2]int{{app.MinWidth, app.MinHeight}, {80, 24}, {app.SplitMinWidth, 50}}
+	if testing.Short() {
+		sizes = sizes[1:2]
+	}
+	for _, size := range sizes {
+		for s := range app.SectionCount() {
+			f := fx.session(t, app.Section(s), size[0], size[1])
+			for _, kt := range fuzzKeyTypes() {
+				for _, alt := range []bool{false, true} {
+					key := tea.KeyMsg{Type: kt, Alt: alt}
+					if kt == tea.KeyRunes {
+						key.Runes = []rune("x")
+					}
+					f.send(t, key)
+					f.send(t, escape)
+				}
+			}
+		}
+	}
+}
+
+// randomInput returns a random key, mouse event, resize, or transition
+// tick, the messages a session mostly sees.
+func randomInput(r *rand.Rand) tea.Msg {
+	switch n := r.IntN(20); {
+	case n < 8:
+		types := fuzzKeyTypes()
+		return tea.KeyMsg{Type: types[r.IntN(len(types))], Alt: r.IntN(8) == 0}
+	case n < 14:
+		// Mostly printable ASCII, where the bindings are, with some wide
+		// and combining characters and pastes.
+		runes := []rune{rune(' ' + r.IntN(95))}
+		switch r.IntN(10) {
+		case 0:
+			runes = []rune{[]rune("é漢字🙂́ש")[r.IntN(6)]}
+		case 1:
+			runes = []rune("pasted text, with punctuation; and 漢字")
+		}
+		return tea.KeyMsg{Type: tea.KeyRunes, Runes: runes, Alt: r.IntN(10) == 0, Paste: len(runes) > 1}
+	case n < 18:
+		buttons := []tea.MouseButton{
+			tea.MouseButtonNone, tea.MouseButtonLeft, tea.MouseButtonMiddle, tea.MouseButtonRight,
+			tea.MouseButtonWheelUp, tea.MouseButtonWheelDown, tea.MouseButtonWheelLeft, tea.MouseButtonWheelRight,
+		}
+		return tea.MouseMsg{
+			X: r.IntN(app.SplitMinWidth+10) - 2, Y: r.IntN(60) - 2,
+			Button: buttons[r.IntN(len(buttons))],
+			Action: tea.MouseAction(r.IntN(3)),
+			Shift:  r.IntN(6) == 0, Alt: r.IntN(6) == 0, Ctrl: r.IntN(6) == 0,
+		}
+	case n < 19:
+		size := fuzzSizes[r.IntN(len(fuzzSizes))]
+		return tea.WindowSizeMsg{Width: size[0], Height: size[1]}
+	default:
+		return app.AnimationTickMsg{ID: "section-transition"}
+	}
+}
+
+// TestRandomInput sends seeded runs of random input to the model,
+// starting at every size.
+func TestRandomInput(t *testing.T) {
+	quietRenderLog(t)
+	fx := newFuzzFixture(t)
+	steps := 600
+	if testing.Short() {
+		steps = 100
+	}
+	for i, size := range fuzzSizes {
+		r := rand.New(rand.NewPCG(uint64(i), 1))
+		f := fx.session(t, app.SectionHome, size[0], size[1])
+		for range steps {
+			f.send(t, randomInput(r))
+		}
+	}
+}
@@ -8,6 +8,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/buntingszn/terminal-portfolio/tui/internal/app"
 	"github.com/buntingszn/terminal-portfolio/tui/internal/content"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/i18n"
 )
 
 // clearCopyFeedbackMsg is sent after a delay to clear the copy feedback text.
@@ -15,15 +16,17 @@ type clearCopyFeedbackMsg struct{}
 
 // LinksSection implements app.SectionModel and renders a navigable links list.
 type LinksSection struct {
-	content          *content.Content
-	theme            app.Theme
-	viewport         app.Viewport
-	width            int
-	height           int
-	cursor           int
-	focused          bool
-	copyFeedback     string
-	pendingClipboard string
+	content      *content.Content
+	theme        app.Theme
+	locale       *i18n.Locale
+	viewport     app.Viewport
+	width        int
+	height       int
+	cursor       int
+	focused      bool
+	copyFeedback string
+	picker       itemPicker
+	marks        itemMarks
 }
 
 // NewLinksSection creates a new LinksSection with the given content and theme.
@@ -32,9 +35,16 @@ func NewLinksSection(c *content.Content, theme app.Theme) *LinksSection {
 		content:  c,
 		theme:    theme,
 		viewport: app.NewViewport(0, 0),
+		picker:   newItemPicker(),
 	}
 }
 
+// SetItemNumbers implements app.ItemNumberer.
+func (l *LinksSection) SetItemNumbers(on bool) {
+	l.picker.shown = on
+	l.viewport.SetContentPreserveScroll(l.renderContent())
+}
+
 // Init implements app.SectionModel.
 func (l *LinksSection) Init() tea.Cmd {
 	return nil
@@ -42,10 +52,6 @@ func (l *LinksSection) Init() tea.Cmd {
 
 // Update implements app.SectionModel.
 func (l *LinksSection) Update(msg tea.Msg) (app.SectionModel, tea.Cmd) {
-	// Clear pending clipboard after each render cycle so the OSC 52
-	// sequence is emitted exactly once.
-	l.pendingClipboard = ""
-
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		l.width = msg.Width
@@ -57,11 +63,29 @@ func (l *LinksSection) Update(msg tea.Msg) (app.SectionModel, tea.Cmd) {
 		if !l.focused {
 			break
 		}
+		count := 0
+		if l.content != nil {
+			count = len(l.content.Links.Links)
+		}
+		if i, activate, ok := l.picker.pick(msg, count); ok {
+			if i < 0 {
+				break
+			}
+			if activate {
+				return l, l.copySelected()
+			}
+			l.moveCursor(i - l.cursor)
+			break
+		}
 		switch msg.String() {
 		case "j", "down":
 			l.moveCursor(1)
 		case "k", "up":
 			l.moveCursor(-1)
+		case "h", "left":
+			l.viewport.ScrollLeft(columnStep)
+		case "l", "right":
+			l.viewport.ScrollRight(columnStep)
 		case "g", "home":
 			l.cursor = 0
 			l.viewport.SetContent(l.renderContent())
@@ -72,27 +96,29 @@ func (l *LinksSection) Update(msg tea.Msg) (app.SectionModel, tea.Cmd) {
 			}
 			l.viewport.SetContent(l.renderContent())
 			l.viewport.ScrollToBottom()
+		case " ":
+			if count > 0 {
+				l.marks.toggle(l.cursor)
+				l.viewport.SetContentPreserveScroll(l.renderContent())
+			}
+		case "esc":
+			if len(l.marks) > 0 {
+				l.marks = nil
+				l.viewport.SetContentPreserveScroll(l.renderContent())
+			}
 		case "enter":
-			if l.content != nil && l.cursor < len(l.content.Links.Links) {
-				url := l.content.Links.Links[l.cursor].URL
-				if url == "" {
-					break
-				}
-				l.pendingClipboard = app.OSC52Sequence(url)
-				l.copyFeedback = "Copied!"
-				l.viewport.SetContent(l.renderContent())
-				return l, tea.Tick(2*time.Second, func(time.Time) tea.Msg {
-					return clearCopyFeedbackMsg{}
-				})
+			if len(l.marks) > 0 {
+				return l, l.copyMarked()
 			}
+			return l, l.copySelected()
 		case "pgup":
-			l.viewport.ScrollUp(l.viewport.VisibleLines())
+			return l, l.viewport.ScrollByAnimated(-l.viewport.VisibleLines())
 		case "pgdown":
-			l.viewport.ScrollDown(l.viewport.VisibleLines())
+			return l, l.viewport.ScrollByAnimated(l.viewport.VisibleLines())
 		case "ctrl+u":
-			l.viewport.ScrollUp(l.viewport.VisibleLines() / 2)
+			return l, l.viewport.ScrollByAnimated(-l.viewport.VisibleLines() / 2)
 		case "ctrl+d":
-			l.viewport.ScrollDown(l.viewport.VisibleLines() / 2)
+			return l, l.viewport.ScrollByAnimated(l.viewport.VisibleLines() / 2)
 		}
 
 	case clearCopyFeedbackMsg:
@@ -103,6 +129,9 @@ func (l *LinksSection) Update(msg tea.Msg) (app.SectionModel, tea.Cmd) {
 		if !l.focused {
 			break
 		}
+		if leftClick(msg) {
+			return l, l.click(msg.Y)
+		}
 		switch msg.Button {
 		case tea.MouseButtonWheelUp:
 			l.moveCursor(-1)
@@ -110,6 +139,22 @@ func (l *LinksSection) Update(msg tea.Msg) (app.SectionModel, tea.Cmd) {
 			l.moveCursor(1)
 		}
 
+	case app.AnimationTickMsg:
+		return l, l.viewport.Animate(msg)
+
+	case app.ThemeChangedMsg:
+		l.theme = msg.Theme
+		l.viewport.SetContentPreserveScroll(l.renderContent())
+
+	case app.LocaleChangedMsg:
+		l.locale = msg.Locale
+		l.viewport.SetContentPreserveScroll(l.renderContent())
+
+	case app.ContentChangedMsg:
+		l.content = msg.Content
+		l.viewport.SetContentPreserveScroll(l.renderContent())
+		l.moveCursor(0)
+
 	case app.FocusMsg:
 		l.focused = true
 		l.cursor = 0
@@ -126,7 +171,7 @@ func (l *LinksSection) Update(msg tea.Msg) (app.SectionModel, tea.Cmd) {
 
 // View implements app.SectionModel.
 func (l *LinksSection) View() string {
-	return l.pendingClipboard + l.viewport.ViewWithScrollbar(l.theme)
+	return l.viewport.ViewWithScrollbar(l.theme)
 }
 
 // ScrollInfo implements app.ScrollReporter for the status bar scroll indicator.
@@ -134,12 +179,96 @@ func (l *LinksSection) ScrollInfo() app.ScrollInfo {
 	return l.viewport.GetScrollInfo()
 }
 
+// ScrollToLine implements app.ScrollDragger.
+func (l *LinksSection) ScrollToLine(line int) {
+	l.viewport.SetYOffset(line)
+}
+
+// Position implements app.PositionRestorer with the selected item.
+func (l *LinksSection) Position() int {
+	return l.cursor
+}
+
+// RestorePosition implements app.PositionRestorer, selecting item pos and
+// scrolling it into view.
+func (l *LinksSection) RestorePosition(pos int) {
+	l.moveCursor(pos - l.cursor)
+}
+
+// copySelected copies the selected link's URL to the clipboard and shows
+// feedback until a tick clears it.
+func (l *LinksSection) copySelected() tea.Cmd {
+	if l.content == nil || l.cursor >= len(l.content.Links.Links) {
+		return nil
+	}
+	url := l.content.Links.Links[l.cursor].URL
+	if url == "" {
+		return nil
+	}
+	return l.copied(app.CopyToClipboard(url), l.locale.T("feedback.copied"))
+}
+
+// click selects the link on row of the view, or copies it when it is
+// selected already.
+func (l *LinksSection) click(row int) tea.Cmd {
+	if l.content == nil {
+		return nil
+	}
+	starts := make([]int, len(l.content.Links.Links))
+	for i := range starts {
+		starts[i] = topPadLines + i*linesPerLink
+	}
+	line, ok := l.viewport.LineAt(row)
+	i := itemAt(starts, line)
+	if !ok || i < 0 {
+		return nil
+	}
+	if i == l.cursor {
+		return l.copySelected()
+	}
+	l.moveCursor(i - l.cursor)
+	return nil
+}
+
+// copyMarked copies the URLs of every marked link at once and clears the
+// marks.
+func (l *LinksSection) copyMarked() tea.Cmd {
+	urls := make([]string, len(l.content.Links.Links))
+	for i, link := range l.content.Links.Links {
+		urls[i] = link.URL
+	}
+	cmd, feedback := l.marks.copy(urls, l.locale)
+	if cmd == nil {
+		return nil
+	}
+	l.marks = nil
+	return l.copied(cmd, feedback)
+}
+
+// copied shows feedback for the clipboard write cmd until a tick clears it.
+func (l *LinksSection) copied(cmd tea.Cmd, feedback string) tea.Cmd {
+	l.copyFeedback = feedback
+	l.viewport.SetContent(l.renderContent())
+	return tea.Batch(
+		cmd,
+		tea.Tick(2*time.Second, func(time.Time) tea.Msg {
+			return clearCopyFeedbackMsg{}
+		}),
+	)
+}
+
 // KeyHints implements app.KeyHinter for contextual status bar hints.
-func (l *LinksSection) KeyHints() string {
+func (l *LinksSection) KeyHints() []app.KeyHint {
 	if l.copyFeedback != "" {
-		return l.copyFeedback
+		return app.Feedback(l.copyFeedback)
+	}
+	if len(l.marks) > 0 {
+		return marksHints(l.locale)
+	}
+	if l.picker.shown {
+		return pickerHints(l.locale)
 	}
-	return "j/k navigate " + app.BorderVertical + " enter copy URL " + app.BorderVertical + " 1-4 nav " + app.BorderVertical + " ? help"
+	return []app.KeyHint{navigateHint(l.locale), app.Hint(l.locale.T("hint.copy.url"), app.KeySelect), app.NavHint(l.locale), app.HelpHint(l.locale)}
 }
 
 // linesPerLink is the number of rendered lines each link entry occupies
@@ -183,12 +312,12 @@ func (l *LinksSection) moveCursor(delta int) {
 // renderContent builds the full rendered text for the viewport.
 func (l *LinksSection) renderContent() string {
 	if l.content == nil {
-		return l.theme.Muted.Render("No links loaded.")
+		return app.EmptyState(l.theme, l.locale.T("links.unloaded"), l.locale.T("links.unloaded.hint"), l.viewport.ContentWidth())
 	}
 
 	links := l.content.Links.Links
 	if len(links) == 0 {
-		return l.theme.Muted.Render("No links to display.")
+		return app.EmptyState(l.theme, l.locale.T("links.none"), l.locale.T("links.none.hint"), l.viewport.ContentWidth())
 	}
 
 	var b strings.Builder
@@ -215,9 +344,13 @@ func (l *LinksSection) renderContent() string {
 		var line strings.Builder
 		if selected {
 			line.WriteString(l.theme.Accent.Render("> "))
+			line.WriteString(l.marks.label(l.theme, i))
+			line.WriteString(l.picker.label(l.theme, i))
 			line.WriteString(l.theme.Accent.Render(label))
 		} else {
 			line.WriteString("  ")
+			line.WriteString(l.marks.label(l.theme, i))
+			line.WriteString(l.picker.label(l.theme, i))
 			line.WriteString(l.theme.Body.Render(label))
 		}
 
@@ -0,0 +1,68 @@
+package sections
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/app"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/i18n"
+)
+
+// itemMarks tracks the items of a list section marked with space, so their
+// URLs can be copied together.
+type itemMarks map[int]bool
+
+// toggle marks item i, or unmarks it if already marked.
+func (m *itemMarks) toggle(i int) {
+	if *m == nil {
+		*m = itemMarks{}
+	}
+	if (*m)[i] {
+		delete(*m, i)
+	} else {
+		(*m)[i] = true
+	}
+}
+
+// label returns the mark column shown before item i while any item is
+// marked, or "" otherwise.
+func (m itemMarks) label(theme app.Theme, i int) string {
+	if len(m) == 0 {
+		return ""
+	}
+	if m[i] {
+		return theme.Accent.Render("✓") + " "
+	}
+	return "  "
+}
+
+// copy returns a command copying the non-empty URLs of the marked items,
+// in list order and one per line, as a single clipboard write, with the
+// feedback to show, worded in l. It returns nil when no marked item has a
+// URL.
+func (m itemMarks) copy(urls []string, l *i18n.Locale) (tea.Cmd, string) {
+	var marked []string
+	for i, url := range urls {
+		if m[i] && url != "" {
+			marked = append(marked, url)
+		}
+	}
+	switch len(marked) {
+	case 0:
+		return nil, ""
+	case 1:
+		return app.CopyToClipboard(marked[0]), l.T("feedback.copied")
+	default:
+		return app.CopyToClipboard(strings.Join(marked, "\n")), l.T("feedback.copied.urls", len(marked))
+	}
+}
+
+// marksHints returns the key hints, worded in l, of a list section while
+// items are marked.
+func marksHints(l *i18n.Locale) []app.KeyHint {
+	return []app.KeyHint{
+		app.Hint(l.T("hint.mark"), app.KeyMark),
+		app.Hint(l.T("hint.marks.copy"), app.KeySelect),
+		app.Hint(l.T("hint.marks.clear"), app.KeyBack),
+	}
+}
@@ -0,0 +1,53 @@
+package sections
+
+// Sections re-render on every cursor move, resize and feedback change,
+// though most of what they draw is the same as last time. Content is never
+// changed in place, only replaced, so a content pointer in a key stands
+// for a hash of what it holds.
+
+// renderMemo keeps a section's last render, reusing it while the key, all
+// the render depends on, stays the same.
+type renderMemo[K comparable] struct {
+	key K
+	out string
+	ok  bool
+}
+
+// render returns the output for key, calling build only when key differs
+// from the last one.
+func (m *renderMemo[K]) render(key K, build func() string) string {
+	if !m.ok || m.key != key {
+		m.key, m.out, m.ok = key, build(), true
+	}
+	return m.out
+}
+
+// blockCache keeps the rendered blocks of a list, such as one per project,
+// so a render rebuilds only those whose key changed, as the two a cursor
+// moves between. Blocks the last render did not ask for are dropped, so
+// it holds at most two renders' worth.
+type blockCache[K comparable] struct {
+	cur, prev map[K]string
+}
+
+// next starts a render.
+func (c *blockCache[K]) next() {
+	c.prev, c.cur = c.cur, make(map[K]string, len(c.cur))
+}
+
+// block returns the block for key, calling build unless this render or the
+// last one already built it.
+func (c *blockCache[K]) block(key K, build func() string) string {
+	if s, ok := c.cur[key]; ok {
+		return s
+	}
+	s, ok := c.prev[key]
+	if !ok {
+		s = build()
+	}
+	if c.cur == nil {
+		c.cur = make(map[K]string)
+	}
+	c.cur[key] = s
+	return s
+}
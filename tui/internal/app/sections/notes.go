@@ -0,0 +1,315 @@
+package sections
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/app"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/content"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/i18n"
+)
+
+// noteDateFormat is how note dates are shown in the list and articles.
+const noteDateFormat = "2 Jan 2006"
+
+// NotesSection lists the markdown notes from data/notes/ and opens one at
+// a time as an article rendered with glamour.
+type NotesSection struct {
+	content  *content.Content
+	theme    app.Theme
+	locale   *i18n.Locale
+	viewport app.Viewport
+	width    int
+	height   int
+	focused  bool
+	cursor   int
+	// open is the index of the note shown as an article, or -1 for the
+	// list.
+	open        int
+	noteOffsets []int // line offset of each note in the rendered list
+}
+
+// NewNotesSection creates a new notes section from the loaded content.
+func NewNotesSection(c *content.Content, theme app.Theme) *NotesSection {
+	return &NotesSection{
+		content: c,
+		theme:   theme,
+		open:    -1,
+	}
+}
+
+// Init implements app.SectionModel.
+func (n *NotesSection) Init() tea.Cmd {
+	return nil
+}
+
+// notes returns the loaded notes, newest first.
+func (n *NotesSection) notes() []content.Note {
+	if n.content == nil {
+		return nil
+	}
+	return n.content.Notes
+}
+
+// Update implements app.SectionModel.
+func (n *NotesSection) Update(msg tea.Msg) (app.SectionModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		n.width = msg.Width
+		n.height = msg.Height
+		n.viewport.SetSize(n.width, n.height)
+		n.viewport.SetContentPreserveScroll(n.renderContent())
+
+	case tea.KeyMsg:
+		if !n.focused {
+			break
+		}
+		if n.open >= 0 {
+			return n, n.handleArticleKey(msg)
+		}
+		n.handleListKey(msg)
+
+	case tea.MouseMsg:
+		if !n.focused {
+			break
+		}
+		if leftClick(msg) && n.open < 0 {
+			n.click(msg.Y)
+			break
+		}
+		switch msg.Button {
+		case tea.MouseButtonWheelUp:
+			if n.open >= 0 {
+				n.viewport.ScrollUp(1)
+			} else {
+				n.moveCursor(-1)
+			}
+		case tea.MouseButtonWheelDown:
+			if n.open >= 0 {
+				n.viewport.ScrollDown(1)
+			} else {
+				n.moveCursor(1)
+			}
+		}
+
+	case app.AnimationTickMsg:
+		return n, n.viewport.Animate(msg)
+
+	case app.ThemeChangedMsg:
+		n.theme = msg.Theme
+		n.viewport.SetContentPreserveScroll(n.renderContent())
+
+	case app.LocaleChangedMsg:
+		n.locale = msg.Locale
+		n.viewport.SetContentPreserveScroll(n.renderContent())
+
+	case app.FocusMsg:
+		n.focused = true
+		n.cursor = 0
+		n.open = -1
+		n.viewport.SetContent(n.renderContent())
+		n.viewport.ScrollToTop()
+
+	case app.BlurMsg:
+		n.focused = false
+	}
+
+	return n, nil
+}
+
+// handleListKey selects and opens notes.
+func (n *NotesSection) handleListKey(msg tea.KeyMsg) {
+	switch msg.String() {
+	case "j", "down":
+		n.moveCursor(1)
+	case "k", "up":
+		n.moveCursor(-1)
+	case "g", "home":
+		n.moveCursor(-n.cursor)
+	case "G", "end":
+		n.moveCursor(len(n.notes()) - 1 - n.cursor)
+	case "enter":
+		n.openSelected()
+	}
+}
+
+// openSelected opens the selected note.
+func (n *NotesSection) openSelected() {
+	if n.cursor < len(n.notes()) {
+		n.open = n.cursor
+		n.viewport.SetContent(n.renderContent())
+		n.viewport.ScrollToTop()
+	}
+}
+
+// click selects the note listed on row of the view, or opens it when it
+// is selected already.
+func (n *NotesSection) click(row int) {
+	line, ok := n.viewport.LineAt(row)
+	i := itemAt(n.noteOffsets, line)
+	if !ok || i < 0 {
+		return
+	}
+	if i == n.cursor {
+		n.openSelected()
+		return
+	}
+	n.moveCursor(i - n.cursor)
+}
+
+// handleArticleKey scrolls the open note and returns to the list on esc.
+// It returns the command animating a longer scroll.
+func (n *NotesSection) handleArticleKey(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc", "backspace":
+		n.open = -1
+		n.moveCursor(0)
+	case "j", "down":
+		n.viewport.ScrollDown(1)
+	case "k", "up":
+		n.viewport.ScrollUp(1)
+	case "h", "left":
+		n.viewport.ScrollLeft(columnStep)
+	case "l", "right":
+		n.viewport.ScrollRight(columnStep)
+	case "g", "home":
+		return n.viewport.ScrollToAnimated(0)
+	case "G", "end":
+		return n.viewport.ScrollToAnimated(n.viewport.TotalLines())
+	case "pgup":
+		return n.viewport.ScrollByAnimated(-n.viewport.VisibleLines())
+	case "pgdown", " ":
+		return n.viewport.ScrollByAnimated(n.viewport.VisibleLines())
+	case "ctrl+u":
+		return n.viewport.ScrollByAnimated(-n.viewport.VisibleLines() / 2)
+	case "ctrl+d":
+		return n.viewport.ScrollByAnimated(n.viewport.VisibleLines() / 2)
+	}
+	return nil
+}
+
+// moveCursor moves the list selection by delta, re-renders, and scrolls
+// the selected note into view.
+func (n *NotesSection) moveCursor(delta int) {
+	count := len(n.notes())
+	if count == 0 {
+		return
+	}
+	n.cursor = max(0, min(count-1, n.cursor+delta))
+	n.viewport.SetContent(n.renderContent())
+
+	if n.cursor < len(n.noteOffsets) {
+		target := n.noteOffsets[n.cursor]
+		if visible := n.viewport.VisibleLines(); visible > 0 && n.viewport.TotalLines() > visible {
+			n.viewport.ScrollToTop()
+			n.viewport.ScrollDown(target)
+		}
+	}
+}
+
+// View implements app.SectionModel.
+func (n *NotesSection) View() string {
+	return n.viewport.ViewWithScrollbar(n.theme)
+}
+
+// ScrollInfo implements app.ScrollReporter for the status bar scroll indicator.
+func (n *NotesSection) ScrollInfo() app.ScrollInfo {
+	return n.viewport.GetScrollInfo()
+}
+
+// ScrollToLine implements app.ScrollDragger.
+func (n *NotesSection) ScrollToLine(line int) {
+	n.viewport.SetYOffset(line)
+}
+
+// Position implements app.PositionRestorer with the selected item.
+func (n *NotesSection) Position() int {
+	return n.cursor
+}
+
+// RestorePosition implements app.PositionRestorer, selecting item pos and
+// scrolling it into view.
+func (n *NotesSection) RestorePosition(pos int) {
+	n.moveCursor(pos - n.cursor)
+}
+
+// KeyHints implements app.KeyHinter for contextual status bar hints.
+func (n *NotesSection) KeyHints() []app.KeyHint {
+	if n.open >= 0 {
+		return []app.KeyHint{scrollHint(n.locale), app.Hint(n.locale.T("hint.notes.back"), app.KeyBack), app.HelpHint(n.locale)}
+	}
+	return []app.KeyHint{app.Hint(n.locale.T("hint.select"), app.KeyScrollDown, app.KeyScrollUp), app.Hint(n.locale.T("hint.read"), app.KeySelect), app.HelpHint(n.locale)}
+}
+
+// contentWidth returns the text width, capped for readable line lengths
+// like the work section's cards.
+func (n *NotesSection) contentWidth() int {
+	return max(10, min(78, n.viewport.ContentWidth()))
+}
+
+// renderContent builds the list or the open article for the viewport.
+func (n *NotesSection) renderContent() string {
+	notes := n.notes()
+	if len(notes) == 0 {
+		return app.EmptyState(n.theme, n.locale.T("notes.none"), n.locale.T("notes.none.hint"), n.viewport.ContentWidth())
+	}
+	if n.open >= 0 && n.open < len(notes) {
+		return n.renderArticle(notes[n.open])
+	}
+	return n.renderList(notes)
+}
+
+// renderList formats each note as its title, date, and wrapped summary.
+func (n *NotesSection) renderList(notes []content.Note) string {
+	width := n.contentWidth()
+	indent := "    "
+	lines := []string{""}
+	n.noteOffsets = n.noteOffsets[:0]
+	for i, note := range notes {
+		if i > 0 {
+			lines = append(lines, "")
+		}
+		n.noteOffsets = append(n.noteOffsets, len(lines))
+
+		prefix := "  "
+		if i == n.cursor {
+			prefix = n.theme.Accent.Render("▸") + " "
+		}
+		lines = append(lines, prefix+n.theme.Accent.Render(app.TruncateWithEllipsis(note.Title, width-len(prefix))))
+		if !note.Date.IsZero() {
+			lines = append(lines, indent+n.theme.Muted.Render(note.Date.Format(noteDateFormat)))
+		}
+		if note.Summary != "" {
+			for _, wl := range app.WrapText(note.Summary, width-len(indent)) {
+				lines = append(lines, indent+n.theme.Body.Render(wl))
+			}
+		}
+	}
+	return app.PadLinesToWidth(strings.Join(lines, "\n"), width)
+}
+
+// renderArticle formats an open note: its date, then the markdown body.
+// The body's own heading serves as the title when it starts with one.
+func (n *NotesSection) renderArticle(note content.Note) string {
+	width := n.contentWidth()
+	var b strings.Builder
+	b.WriteByte('\n')
+	if !strings.HasPrefix(note.Body, "# ") {
+		b.WriteString(n.theme.Accent.Bold(true).Render(note.Title))
+		b.WriteByte('\n')
+	}
+	if !note.Date.IsZero() {
+		b.WriteString(n.theme.Muted.Render(note.Date.Format(noteDateFormat)))
+		b.WriteByte('\n')
+	}
+	body, err := app.RenderMarkdown(n.theme, note.Body, width)
+	if err != nil {
+		// Fall back to the plain markdown rather than hiding the note.
+		body = n.theme.Body.Render(strings.Join(app.WrapText(note.Body, width), "\n"))
+	}
+	if b.Len() > 1 {
+		b.WriteByte('\n')
+	}
+	b.WriteString(body)
+	return app.PadLinesToWidth(b.String(), width)
+}
@@ -0,0 +1,64 @@
+package sections
+
+import (
+	"strconv"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/app"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/i18n"
+)
+
+// itemPicker implements numeric quick-open for a list section: while item
+// numbers are shown, the first press of 1-9 moves the cursor to that item
+// and pressing the same number again activates it.
+type itemPicker struct {
+	shown bool
+	last  int // item picked by the previous key press, or -1
+}
+
+// newItemPicker returns a picker with numbers hidden.
+func newItemPicker() itemPicker {
+	return itemPicker{last: -1}
+}
+
+// pick handles a key press for a list of count items. handled reports
+// whether the key was a pick; index is -1 when it named no item.
+func (p *itemPicker) pick(msg tea.KeyMsg, count int) (index int, activate, handled bool) {
+	n, ok := app.ItemNumber(msg)
+	if !ok || !p.shown {
+		p.last = -1
+		return -1, false, false
+	}
+	if n >= count {
+		p.last = -1
+		return -1, false, true
+	}
+	activate = p.last == n
+	p.last = n
+	if activate {
+		p.last = -1
+	}
+	return n, activate, true
+}
+
+// label returns the number shown before item i, padded so unnumbered items
+// beyond the ninth stay aligned, or "" while numbers are hidden.
+func (p *itemPicker) label(theme app.Theme, i int) string {
+	if !p.shown {
+		return ""
+	}
+	if i >= 9 {
+		return "  "
+	}
+	return theme.Muted.Render(strconv.Itoa(i+1)) + " "
+}
+
+// pickerHints returns the key hints, worded in l, of a list section while
+// item numbers are shown.
+func pickerHints(l *i18n.Locale) []app.KeyHint {
+	return []app.KeyHint{
+		{Keys: "1-9", Text: l.T("hint.pick")},
+		app.Hint(l.T("hint.numbers.hide"), app.KeyItemNumbers),
+		app.HelpHint(l),
+	}
+}
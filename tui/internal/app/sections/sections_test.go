@@ -1,11 +1,31 @@
 package sections
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/analytics"
 	"github.com/buntingszn/terminal-portfolio/tui/internal/app"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/content"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/graphics"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/i18n"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/repostats"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/resume"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/guestbook"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/status"
 	"github.com/buntingszn/terminal-portfolio/tui/internal/testutil"
 )
 
@@ -29,6 +49,12 @@ func initSection(t *testing.T, s app.SectionModel, width, height int) app.Sectio
 	return s
 }
 
+// hintText returns the status bar text of h's key hints under the default
+// key map with five tabs shown.
+func hintText(h app.KeyHinter) string {
+	return app.FormatKeyHints(h.KeyHints(), app.DefaultKeyMap(), 5)
+}
+
 // drainHomeReveal sends homeRevealTickMsg until the reveal animation completes.
 func drainHomeReveal(s app.SectionModel) app.SectionModel {
 	for range 200 {
@@ -100,6 +126,126 @@ func TestHomeSection_PortraitVisibility(t *testing.T) {
 	})
 }
 
+func TestHomeSection_PortraitArt(t *testing.T) {
+	h := NewHomeSection(testutil.FixtureContent(), testutil.FixtureTheme())
+	art := strings.TrimSuffix(strings.Repeat(strings.Repeat("⡇", 22)+"\n", 14), "\n")
+	h.SetPortraitArt(art)
+	view := drainHomeReveal(initSection(t, h, 100, 24)).View()
+	if strings.Contains(view, "⣿⣿⣿⢿") || !strings.Contains(view, "⡇⡇⡇⡇") {
+		t.Error("the art made from the photo should replace the built-in portrait")
+	}
+
+	h.SetPortraitArt("")
+	if h.art != art {
+		t.Error("empty art should keep the art already shown")
+	}
+}
+
+func TestHomeSection_ReducedMotion(t *testing.T) {
+	h := NewHomeSection(testutil.FixtureContent(), testutil.FixtureTheme())
+	h.Update(app.MotionChangedMsg{Reduced: true})
+	initSection(t, h, 100, 24)
+	if h.portraitShimmer.Active() || !h.revealDone {
+		t.Error("with reduced motion, focus should start neither the shimmer nor the reveal")
+	}
+
+	// Turning motion back on starts the shimmer; turning it off mid-reveal
+	// shows the bio whole.
+	if _, cmd := h.Update(app.MotionChangedMsg{}); cmd == nil || !h.portraitShimmer.Active() {
+		t.Error("turning motion on should start the shimmer")
+	}
+	h = NewHomeSection(testutil.FixtureContent(), testutil.FixtureTheme())
+	initSection(t, h, 100, 24)
+	h.Update(app.MotionChangedMsg{Reduced: true})
+	if h.portraitShimmer.Active() || !h.revealDone {
+		t.Error("reducing motion should settle the running animations")
+	}
+}
+
+// testPortrait returns a photo placement sized like the braille portrait.
+func testPortrait(t *testing.T, p graphics.Protocol) *graphics.Placement {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, 40, 40))
+	draw.Draw(img, img.Bounds(), image.NewUniform(color.RGBA{R: 200, A: 255}), image.Point{}, draw.Src)
+	cols, rows := PortraitSize()
+	photo, err := graphics.New(img, cols, rows)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return photo.Place(graphics.Capabilities{Protocol: p}, 42)
+}
+
+func TestHomeSection_KittyPortrait(t *testing.T) {
+	c := testutil.FixtureContent()
+	theme := testutil.FixtureTheme()
+
+	h := NewHomeSection(c, theme)
+	h.SetPortraitImage(testPortrait(t, graphics.Kitty))
+	s, _ := h.Update(tea.WindowSizeMsg{Width: 100, Height: 24})
+	s, cmd := s.Update(app.FocusMsg{})
+	if seq := awaitMsg[app.TerminalWriteMsg](t, cmd).Seq; !strings.HasPrefix(seq, "\x1b_G") {
+		t.Errorf("first focus should upload the image, got %.20q", seq)
+	}
+	s = drainHomeReveal(s)
+
+	view := s.View()
+	if strings.Contains(view, "⣿⣿⣿⢿") {
+		t.Error("braille portrait should be replaced by the photo")
+	}
+	cols, rows := PortraitSize()
+	if n := strings.Count(view, "\U0010EEEE"); n != cols*rows {
+		t.Errorf("expected a %d×%d block of placeholders, got %d", cols, rows, n)
+	}
+	for i, line := range strings.Split(view, "\n") {
+		if w := lipgloss.Width(line); w != 100 {
+			t.Errorf("line %d is %d wide, want 100", i, w)
+		}
+	}
+
+	// The upload is sent once per session.
+	s, _ = s.Update(app.BlurMsg{})
+	_, cmd = s.Update(app.FocusMsg{})
+	if cmd != nil {
+		t.Error("refocusing should not upload the image again")
+	}
+}
+
+func TestHomeSection_SixelPortraitOnlyWhenSettled(t *testing.T) {
+	c := testutil.FixtureContent()
+	theme := testutil.FixtureTheme()
+	const sixelStart = "\x1bP0;1;0q"
+
+	h := NewHomeSection(c, theme)
+	h.SetPortraitImage(testPortrait(t, graphics.Sixel))
+	s := initSection(t, h, 100, 24)
+	if strings.Contains(s.View(), sixelStart) {
+		t.Error("sixel should not be drawn while the section is revealing")
+	}
+
+	s = drainHomeReveal(s)
+	view := s.View()
+	if strings.Count(view, sixelStart) != 1 {
+		t.Fatal("sixel should be drawn once the reveal settles")
+	}
+	if strings.Contains(view, "⣿⣿⣿⢿") {
+		t.Error("braille portrait should be replaced by the photo")
+	}
+
+	s, _ = s.Update(app.BlurMsg{})
+	view = s.View()
+	if strings.Contains(view, sixelStart) || !strings.Contains(view, "⣿⣿⣿⢿") {
+		t.Error("blurring should fall back to braille so the sixel does not slide")
+	}
+
+	// A viewport with something to scroll keeps the braille portrait.
+	h2 := NewHomeSection(c, theme)
+	h2.SetPortraitImage(testPortrait(t, graphics.Sixel))
+	s2 := drainHomeReveal(initSection(t, h2, 100, 8))
+	if strings.Contains(s2.View(), sixelStart) {
+		t.Error("sixel should not be drawn in a scrolling viewport")
+	}
+}
+
 func TestHomeSection_BioAndInfoContent(t *testing.T) {
 	c := testutil.FixtureContent()
 	theme := testutil.FixtureTheme()
@@ -109,7 +255,8 @@ func TestHomeSection_BioAndInfoContent(t *testing.T) {
 	s = drainHomeReveal(s)
 	view := s.View()
 	testutil.RequireContains(t, view, "software engineer")
-	testutil.RequireContains(t, view, "Status")
+	testutil.RequireContains(t, view, "Open to work")
+	testutil.RequireContains(t, view, "Software engineer")
 }
 
 func TestHomeSection_BioVisibleAfterReveal(t *testing.T) {
@@ -142,7 +289,7 @@ func TestHomeSection_TextWrapsAtNarrow(t *testing.T) {
 			s = drainHomeReveal(s)
 			view := s.View()
 			testutil.RequireNotEmpty(t, view)
-			testutil.RequireContains(t, view, "Status")
+			testutil.RequireContains(t, view, "Open to work")
 		})
 	}
 }
@@ -156,14 +303,14 @@ func TestHomeSection_RevealStreamsLines(t *testing.T) {
 
 	// Immediately after focus, info fields should not be visible (only first line revealed).
 	initialView := s.View()
-	if strings.Contains(initialView, "Status") {
-		t.Error("status should not be visible during initial reveal")
+	if strings.Contains(initialView, "Open to work") {
+		t.Error("availability should not be visible during initial reveal")
 	}
 
 	// After draining, all content should be visible.
 	s = drainHomeReveal(s)
 	fullView := s.View()
-	testutil.RequireContains(t, fullView, "Status")
+	testutil.RequireContains(t, fullView, "Open to work")
 }
 
 func TestHomeSection_RevealSkippedOnKeyPress(t *testing.T) {
@@ -177,7 +324,7 @@ func TestHomeSection_RevealSkippedOnKeyPress(t *testing.T) {
 	s, _ = s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
 	view := s.View()
 	testutil.RequireContains(t, view, "software engineer")
-	testutil.RequireContains(t, view, "Status")
+	testutil.RequireContains(t, view, "Open to work")
 }
 
 func TestHomeSection_RevealDoesNotReplayOnRefocus(t *testing.T) {
@@ -193,7 +340,108 @@ func TestHomeSection_RevealDoesNotReplayOnRefocus(t *testing.T) {
 	s, _ = s.Update(app.FocusMsg{})
 	view := s.View()
 	testutil.RequireContains(t, view, "software engineer")
-	testutil.RequireContains(t, view, "Status")
+	testutil.RequireContains(t, view, "Open to work")
+}
+
+func TestHomeSection_LastVisit(t *testing.T) {
+	c := testutil.FixtureContent()
+	theme := testutil.FixtureTheme()
+
+	h := NewHomeSection(c, theme)
+	h.SetLastVisit(time.Now().Add(-3 * 24 * time.Hour))
+	s := initSection(t, h, 80, 24)
+
+	// A returning visitor is greeted and sees the bio whole at once.
+	view := s.View()
+	testutil.RequireContains(t, view, "Welcome back, last visit 3 days ago")
+	testutil.RequireContains(t, view, "Open to work")
+
+	s = initSection(t, NewHomeSection(c, theme), 80, 24)
+	if view := drainHomeReveal(s).View(); strings.Contains(view, "Welcome back") {
+		t.Error("a new visitor should not be greeted")
+	}
+}
+
+func TestHomeSection_UpdatedFooter(t *testing.T) {
+	c := testutil.FixtureContent()
+	theme := testutil.FixtureTheme()
+
+	t.Run("shown_when_known", func(t *testing.T) {
+		withDate := *c
+		withDate.UpdatedAt = time.Now().Add(-3 * 24 * time.Hour)
+		h := NewHomeSection(&withDate, theme)
+		s := initSection(t, h, 60, 60)
+		s = drainHomeReveal(s)
+		testutil.RequireContains(t, s.View(), "content updated 3 days ago")
+	})
+
+	t.Run("hidden_when_unknown", func(t *testing.T) {
+		noDate := *c
+		noDate.UpdatedAt = time.Time{}
+		h := NewHomeSection(&noDate, theme)
+		s := initSection(t, h, 60, 60)
+		s = drainHomeReveal(s)
+		if strings.Contains(s.View(), "content updated") {
+			t.Error("footer should be omitted when UpdatedAt is zero")
+		}
+	})
+}
+
+// fixedSlots is a BookingSlots returning the same slots every time.
+type fixedSlots []time.Time
+
+func (f fixedSlots) Slots(context.Context, time.Time) ([]time.Time, error) {
+	return f, nil
+}
+
+func TestHomeSection_BookingCallout(t *testing.T) {
+	c := testutil.FixtureContent()
+	h := NewHomeSection(c, testutil.FixtureTheme())
+	day := time.Date(2026, 10, 15, 9, 30, 0, 0, time.UTC)
+	h.SetBookingSlots(fixedSlots{day, day.Add(time.Hour), day.AddDate(0, 0, 1), day.AddDate(0, 0, 2)})
+
+	h.Update(tea.WindowSizeMsg{Width: 100, Height: 40})
+	s, cmd := h.Update(app.FocusMsg{})
+	s, _ = s.Update(awaitMsg[homeSlotsMsg](t, cmd))
+	s = drainHomeReveal(s)
+	view := s.View()
+	testutil.RequireContains(t, view, "cal.com/kpm/intro")
+	testutil.RequireContains(t, view, "Thu 15 Oct 09:30 UTC")
+	if strings.Contains(view, "Sat 17 Oct") {
+		t.Errorf("callout should list only %d openings:\n%s", bookingOpenings, view)
+	}
+
+	s, cmd = s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'b'}})
+	if got := clipboardRequest(t, cmd); got != "https://cal.com/kpm/intro" {
+		t.Errorf("b copied %q, want the booking link", got)
+	}
+	testutil.RequireContains(t, s.View(), "copied!")
+
+	c.About.Booking = ""
+	h = NewHomeSection(c, testutil.FixtureTheme())
+	h.SetBookingSlots(fixedSlots{day})
+	s = drainHomeReveal(initSection(t, h, 100, 40))
+	if strings.Contains(s.View(), "Book") {
+		t.Error("home should omit the callout without a booking link")
+	}
+	if _, cmd := s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'b'}}); cmd != nil {
+		t.Error("b should do nothing without a booking link")
+	}
+}
+
+func TestHomeSection_ContentReviewPlaceholders(t *testing.T) {
+	c := testutil.FixtureContentWith(func(c *content.Content) {
+		c.About.Availability = nil
+		c.Meta.SiteURL = ""
+	})
+	theme := testutil.FixtureTheme()
+
+	home := NewHomeSection(c, theme)
+	home.SetContentReview(true)
+	s := drainHomeReveal(initSection(t, home, 100, 40))
+	view := s.View()
+	testutil.RequireContains(t, view, "availability: not provided")
+	testutil.RequireContains(t, view, "siteUrl: not provided")
 }
 
 // --- WorkSection tests ---
@@ -256,6 +504,30 @@ func TestWorkSection_NilContent(t *testing.T) {
 	testutil.RequireContains(t, view, "No projects")
 }
 
+// fixedRepoStats serves stats for the repo URLs it lists.
+type fixedRepoStats map[string]repostats.Stats
+
+func (f fixedRepoStats) Stats(repo string) (repostats.Stats, bool) {
+	s, ok := f[repo]
+	return s, ok
+}
+
+func TestWorkSection_RepoStatsBadges(t *testing.T) {
+	c := testutil.FixtureContent()
+	w := NewWorkSection(c, testutil.FixtureTheme())
+	w.SetRepoStats(fixedRepoStats{
+		"https://github.com/buntingszn/terminal-portfolio": {
+			Stars: 128, Language: "Go", LastCommit: time.Now().Add(-3 * 24 * time.Hour),
+		},
+	})
+	s := initSection(t, w, 80, 200)
+	view := s.View()
+	testutil.RequireContains(t, view, "★ 128 · Go · updated 3 days ago")
+	if strings.Count(view, "★") != 1 {
+		t.Error("only projects with fetched stats should show badges")
+	}
+}
+
 func TestWorkSection_CursorNavigation(t *testing.T) {
 	c := testutil.FixtureContent()
 	theme := testutil.FixtureTheme()
@@ -276,6 +548,31 @@ func TestWorkSection_CursorNavigation(t *testing.T) {
 	}
 }
 
+func TestWorkSection_CachedBlocksMatch(t *testing.T) {
+	c := testutil.FixtureContent()
+	theme := testutil.FixtureTheme()
+
+	w := NewWorkSection(c, theme)
+	s := initSection(t, w, 80, 24)
+	want := w.renderContent()
+
+	// Down and back up rebuilds the blocks the cursor crossed; the rest
+	// come from the cache and must render as they did.
+	s, _ = s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("k")})
+	if got := w.renderContent(); got != want {
+		t.Error("content after moving the cursor down and back differs from the first render")
+	}
+
+	// A new theme replaces every block.
+	w.Update(app.ThemeChangedMsg{Theme: app.LightTheme()})
+	fresh := NewWorkSection(c, app.LightTheme())
+	initSection(t, fresh, 80, 24)
+	if w.renderContent() != fresh.renderContent() {
+		t.Error("cached blocks survived a theme change")
+	}
+}
+
 func TestWorkSection_CursorBounds(t *testing.T) {
 	c := testutil.FixtureContent()
 	theme := testutil.FixtureTheme()
@@ -309,20 +606,73 @@ func TestWorkSection_EnterCopyURL(t *testing.T) {
 		t.Fatal("expected non-nil cmd after Enter press")
 	}
 
-	// View should contain the OSC 52 escape sequence prefix.
-	view := s.View()
-	if !strings.Contains(view, "\x1b]52;c;") {
-		t.Error("expected OSC 52 sequence in view after Enter")
+	// The copy is requested through a command, never embedded in the view.
+	if got := clipboardRequest(t, cmd); got == "" {
+		t.Error("expected a non-empty clipboard request after Enter")
+	}
+	if strings.Contains(s.View(), "\x1b]52;") {
+		t.Error("OSC 52 sequence must not appear in view")
 	}
 
 	// KeyHints should show the copy feedback.
 	ws := s.(*WorkSection)
-	hints := ws.KeyHints()
+	hints := hintText(ws)
 	if hints != "Copied!" {
 		t.Errorf("expected KeyHints() = %q, got %q", "Copied!", hints)
 	}
 }
 
+func TestWorkSection_NumberPicksProject(t *testing.T) {
+	c := testutil.FixtureContent()
+	theme := testutil.FixtureTheme()
+
+	w := NewWorkSection(c, theme)
+	s := initSection(t, w, 80, 24)
+	w.SetItemNumbers(true)
+	first := c.Work.FeaturedFirst()[0]
+	testutil.RequireContains(t, s.View(), "1 "+first.Title)
+
+	one := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("1")}
+	s, _ = s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("2")})
+	s, cmd := s.Update(one)
+	if cmd != nil || w.cursor != 0 {
+		t.Fatalf("first 1 should select project 1 without copying; cursor = %d", w.cursor)
+	}
+	_, cmd = s.Update(one)
+	if got := clipboardRequest(t, cmd); got == "" {
+		t.Error("second 1 should copy the project URL")
+	}
+
+	w.SetItemNumbers(false)
+	if strings.Contains(s.View(), "1 "+first.Title) {
+		t.Error("numbers should disappear once hidden")
+	}
+}
+
+func TestWorkSection_CopyMarked(t *testing.T) {
+	c := testutil.FixtureContent()
+	theme := testutil.FixtureTheme()
+
+	w := NewWorkSection(c, theme)
+	s := initSection(t, w, 80, 24)
+	space := tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")}
+	s, _ = s.Update(space)
+	s, _ = s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("G")})
+	s, _ = s.Update(space)
+
+	_, cmd := s.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	got := clipboardRequest(t, cmd)
+	var want []string
+	for i, url := range w.projectURLs {
+		if (i == 0 || i == len(w.projectURLs)-1) && url != "" {
+			want = append(want, url)
+		}
+	}
+	if got != strings.Join(want, "\n") {
+		t.Errorf("copied %q, want %q", got, strings.Join(want, "\n"))
+	}
+}
+
 func TestWorkSection_CopyFeedbackClears(t *testing.T) {
 	c := testutil.FixtureContent()
 	theme := testutil.FixtureTheme()
@@ -337,7 +687,7 @@ func TestWorkSection_CopyFeedbackClears(t *testing.T) {
 	s, _ = s.Update(clearWorkCopyMsg{})
 
 	ws := s.(*WorkSection)
-	hints := ws.KeyHints()
+	hints := hintText(ws)
 	if strings.Contains(hints, "Copied!") {
 		t.Error("expected feedback to be cleared after clearWorkCopyMsg")
 	}
@@ -362,6 +712,22 @@ func TestWorkSection_ScrollToTopAndBottom(t *testing.T) {
 	testutil.RequireNotEmpty(t, s.View())
 }
 
+func TestWorkSection_ContentReviewPlaceholders(t *testing.T) {
+	c := testutil.FixtureContentWith(func(c *content.Content) {
+		c.Work.Projects = c.Work.Projects[:1]
+		c.Work.Projects[0].Tags = nil
+		c.Work.Projects[0].URL = ""
+		c.Work.Projects[0].Repo = ""
+	})
+	theme := testutil.FixtureTheme()
+
+	work := NewWorkSection(c, theme)
+	work.SetContentReview(true)
+	view := initSection(t, work, 80, 40).View()
+	testutil.RequireContains(t, view, "tags: not provided")
+	testutil.RequireContains(t, view, "url/repo: not provided")
+}
+
 // --- CVSection tests ---
 
 func TestCVSection_RenderAtSizes(t *testing.T) {
@@ -412,6 +778,45 @@ func TestCVSection_SkillsVisibleAfterScroll(t *testing.T) {
 	testutil.RequireContains(t, view, "Languages")
 }
 
+func TestCVSection_Anchors(t *testing.T) {
+	c := testutil.FixtureContent()
+	theme := testutil.FixtureTheme()
+
+	cv := NewCVSection(c, theme)
+	initSection(t, cv, 80, 10)
+	anchors := cv.Anchors()
+	if len(anchors) != 3 || anchors[0].Name != "EXPERIENCE" || anchors[1].Name != "SKILLS" || anchors[2].Name != "EDUCATION" {
+		t.Fatalf("anchors = %+v, want EXPERIENCE, SKILLS, and EDUCATION", anchors)
+	}
+	cv.ScrollToLine(anchors[1].Line)
+	if first := strings.Split(cv.View(), "\n")[0]; !strings.Contains(first, "SKILLS") {
+		t.Errorf("after scrolling to SKILLS, the top line is %q", first)
+	}
+}
+
+func TestCVSection_PageDownAnimates(t *testing.T) {
+	c := testutil.FixtureContent()
+	theme := testutil.FixtureTheme()
+
+	cv := NewCVSection(c, theme)
+	s := initSection(t, cv, 80, 10)
+	s, cmd := s.Update(tea.KeyMsg{Type: tea.KeyPgDown})
+	if cmd == nil || cv.TopLine() != 0 {
+		t.Fatalf("page down should start an animation at line 0, not jump to %d", cv.TopLine())
+	}
+	for frames := 0; cmd != nil; frames++ {
+		if frames > 20 {
+			t.Fatal("page down animation did not finish")
+		}
+		// Each frame asks the scheduler for the next; stand in for it.
+		req := cmd().(app.AnimationRequestMsg)
+		s, cmd = s.Update(req.Fire(time.Now()))
+	}
+	if got := cv.TopLine(); got != cv.viewport.VisibleLines() {
+		t.Errorf("page down ended at line %d, want %d", got, cv.viewport.VisibleLines())
+	}
+}
+
 func TestCVSection_BulletsWrapAtNarrow(t *testing.T) {
 	c := testutil.FixtureContent()
 	theme := testutil.FixtureTheme()
@@ -434,6 +839,182 @@ func TestCVSection_SkillsWrapAtNarrow(t *testing.T) {
 	testutil.RequireContains(t, view, "SKILLS")
 }
 
+func TestCVSection_UpdatedHeader(t *testing.T) {
+	c := testutil.FixtureContent()
+	theme := testutil.FixtureTheme()
+
+	withDate := *c
+	withDate.UpdatedAt = time.Now().Add(-2 * time.Hour)
+	cv := NewCVSection(&withDate, theme)
+	s := initSection(t, cv, 80, 24)
+	testutil.RequireContains(t, s.View(), "updated 2 hours ago")
+}
+
+func TestCVSection_AvailabilityBadge(t *testing.T) {
+	c := testutil.FixtureContentWith(func(c *content.Content) {
+		c.About.Availability = &content.Availability{OpenToWork: true, Start: time.Now().AddDate(0, 1, 0).Format("2006-01-02")}
+	})
+	cv := NewCVSection(c, testutil.FixtureTheme())
+	view := initSection(t, cv, 80, 24).View()
+	testutil.RequireContains(t, view, "Open to work")
+	testutil.RequireContains(t, view, "from ")
+
+	c.About.Availability = nil
+	view = initSection(t, NewCVSection(c, testutil.FixtureTheme()), 80, 24).View()
+	if strings.Contains(view, "Open to work") || strings.Contains(view, "Not looking") {
+		t.Error("CV should omit the badge when availability is not given")
+	}
+}
+
+func TestCVSection_DownloadKey(t *testing.T) {
+	c := testutil.FixtureContent()
+	theme := testutil.FixtureTheme()
+
+	cv := NewCVSection(c, theme)
+	s := initSection(t, cv, 80, 24)
+	s, cmd := s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	write := awaitMsg[app.TerminalWriteMsg](t, cmd)
+	want := app.FileTransferSequence(resume.FileName, resume.PDF(c))
+	if write.Seq != want {
+		t.Errorf("d should offer the PDF, got %.60q", write.Seq)
+	}
+	hinter := s.(app.KeyHinter)
+	testutil.RequireContains(t, hintText(hinter), "Sent resume.pdf")
+
+	s, _ = s.Update(clearCopyFeedbackMsg{})
+	if strings.Contains(hintText(hinter), "Sent") {
+		t.Error("download feedback should clear")
+	}
+
+	write = awaitMsg[app.TerminalWriteMsg](t, cv.Download("txt"))
+	if !strings.Contains(write.Seq, "inline=0:") || !strings.Contains(hintText(cv), "ssh <host> cv > resume.txt") {
+		t.Errorf("txt download: seq %.60q, hints %q", write.Seq, hintText(cv))
+	}
+}
+
+func TestCVSection_ContentReviewPlaceholders(t *testing.T) {
+	c := testutil.FixtureContentWith(testutil.WithoutEducation(), func(c *content.Content) {
+		c.CV.Contact.Location = ""
+	})
+	theme := testutil.FixtureTheme()
+
+	plain := initSection(t, NewCVSection(c, theme), 80, 200)
+	if strings.Contains(plain.View(), "not provided") {
+		t.Error("placeholders should only render in content review mode")
+	}
+
+	cv := NewCVSection(c, theme)
+	cv.SetContentReview(true)
+	s := initSection(t, cv, 80, 200)
+	view := s.View()
+	testutil.RequireContains(t, view, "education: not provided")
+	testutil.RequireContains(t, view, "location: not provided")
+}
+
+func TestCVSection_TimelineToggle(t *testing.T) {
+	c := testutil.FixtureContent()
+	theme := testutil.FixtureTheme()
+
+	cv := NewCVSection(c, theme)
+	s := initSection(t, cv, 80, 200)
+	s, _ = s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'v'}})
+	view := s.View()
+	testutil.RequireContains(t, view, "TIMELINE")
+	testutil.RequireContains(t, view, "SKILLS")
+	if strings.Contains(view, "EXPERIENCE") || strings.Contains(view, "EDUCATION") {
+		t.Error("the timeline should replace the experience and education blocks")
+	}
+	testutil.RequireContains(t, hintText(cv), "v list")
+
+	// Experience and education are merged newest first, the year shown
+	// once, and the last entry closes the line.
+	var order []string
+	for _, line := range strings.Split(view, "\n") {
+		for _, name := range []string{"Independent", "FortyAU", "Nashville Software School", "Middle Tennessee"} {
+			if strings.Contains(line, name) {
+				order = append(order, strings.TrimSpace(line[:strings.Index(line, name)]))
+			}
+		}
+	}
+	want := []string{
+		"2021 ┌─ Software Engineer @",
+		"2015 ├─ Senior Software Developer @",
+		"├─ Full-Stack Web Development @",
+		"2011 └─ Music Business @",
+	}
+	if !slices.Equal(order, want) {
+		t.Errorf("timeline entries = %q, want %q", order, want)
+	}
+	testutil.RequireContains(t, view, "│  - ")
+
+	s, _ = s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'v'}})
+	testutil.RequireContains(t, s.View(), "EXPERIENCE")
+	testutil.RequireContains(t, hintText(cv), "v timeline")
+}
+
+func TestCVSection_TimelineNarrowFallsBack(t *testing.T) {
+	c := testutil.FixtureContent()
+	theme := testutil.FixtureTheme()
+
+	cv := NewCVSection(c, theme)
+	s := initSection(t, cv, 40, 200)
+	s, _ = s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'v'}})
+	view := s.View()
+	if strings.Contains(view, "TIMELINE") {
+		t.Error("a narrow CV should keep the single-column layout")
+	}
+	testutil.RequireContains(t, view, "EXPERIENCE")
+	testutil.RequireContains(t, view, "EDUCATION")
+}
+
+// manyProjects returns the fixture with its projects repeated n times.
+func manyProjects(n int) *content.Content {
+	return testutil.FixtureContentWith(func(c *content.Content) {
+		projects := c.Work.Projects
+		c.Work.Projects = nil
+		for len(c.Work.Projects) < n {
+			c.Work.Projects = append(c.Work.Projects, projects...)
+		}
+	})
+}
+
+// manyExperiences returns the fixture with its CV experience repeated n
+// times.
+func manyExperiences(n int) *content.Content {
+	return testutil.FixtureContentWith(func(c *content.Content) {
+		exp := c.CV.Experience
+		c.CV.Experience = nil
+		for len(c.CV.Experience) < n {
+			c.CV.Experience = append(c.CV.Experience, exp...)
+		}
+	})
+}
+
+func BenchmarkWorkSection_MoveCursor(b *testing.B) {
+	w := NewWorkSection(manyProjects(200), testutil.FixtureTheme())
+	w.Update(tea.WindowSizeMsg{Width: 100, Height: 40})
+	w.Update(app.FocusMsg{})
+	keys := []tea.KeyMsg{
+		{Type: tea.KeyRunes, Runes: []rune("j")},
+		{Type: tea.KeyRunes, Runes: []rune("k")},
+	}
+	b.ReportAllocs()
+	for i := 0; b.Loop(); i++ {
+		w.Update(keys[i%2])
+	}
+}
+
+func BenchmarkCVSection_Resize(b *testing.B) {
+	cv := NewCVSection(manyExperiences(100), testutil.FixtureTheme())
+	cv.Update(app.FocusMsg{})
+	// A terminal dragged taller at the same width.
+	sizes := []tea.WindowSizeMsg{{Width: 100, Height: 40}, {Width: 100, Height: 41}}
+	b.ReportAllocs()
+	for i := 0; b.Loop(); i++ {
+		cv.Update(sizes[i%2])
+	}
+}
+
 // --- LinksSection tests ---
 
 func TestLinksSection_RenderAtSizes(t *testing.T) {
@@ -547,62 +1128,187 @@ func TestLinksSection_EnterCopyURL(t *testing.T) {
 		t.Fatal("expected non-nil cmd after Enter press")
 	}
 
-	// View should contain the OSC 52 escape sequence prefix.
-	view := s.View()
-	if !strings.Contains(view, "\x1b]52;c;") {
-		t.Error("expected OSC 52 sequence in view after Enter")
+	// The copy is requested through a command, never embedded in the view.
+	if got := clipboardRequest(t, cmd); got != "https://github.com/buntingszn" {
+		t.Errorf("clipboard request = %q, want the GitHub URL", got)
+	}
+	if strings.Contains(s.View(), "\x1b]52;") {
+		t.Error("OSC 52 sequence must not appear in view")
 	}
 
 	// KeyHints should show the copy feedback.
 	ls := s.(*LinksSection)
-	hints := ls.KeyHints()
+	hints := hintText(ls)
 	if hints != "Copied!" {
 		t.Errorf("expected KeyHints() = %q, got %q", "Copied!", hints)
 	}
 }
 
-func TestLinksSection_CopyFeedbackClears(t *testing.T) {
+func TestLinksSection_NumberPicksLink(t *testing.T) {
 	c := testutil.FixtureContent()
 	theme := testutil.FixtureTheme()
 
 	l := NewLinksSection(c, theme)
 	s := initSection(t, l, 80, 24)
+	two := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("2")}
 
-	// Press Enter to set feedback.
-	s, _ = s.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	// Numbers do nothing until they are shown.
+	s, cmd := s.Update(two)
+	if cmd != nil || l.cursor != 0 {
+		t.Fatalf("2 with numbers hidden moved to %d", l.cursor)
+	}
 
-	// Send the clear message (simulates the 2s timer firing).
-	s, _ = s.Update(clearCopyFeedbackMsg{})
+	l.SetItemNumbers(true)
+	testutil.RequireContains(t, s.View(), "1 "+c.Links.Links[0].Label)
 
-	ls := s.(*LinksSection)
-	hints := ls.KeyHints()
-	if strings.Contains(hints, "Copied!") {
-		t.Error("expected feedback to be cleared after clearCopyFeedbackMsg")
+	s, cmd = s.Update(two)
+	if cmd != nil || l.cursor != 1 {
+		t.Fatalf("first 2 should select link 2 without copying; cursor = %d", l.cursor)
 	}
-	if !strings.Contains(hints, "enter copy URL") {
-		t.Errorf("expected default hints after clearing, got %q", hints)
+	_, cmd = s.Update(two)
+	if got := clipboardRequest(t, cmd); got != c.Links.Links[1].URL {
+		t.Errorf("second 2 copied %q, want %q", got, c.Links.Links[1].URL)
+	}
+
+	// A number past the end is ignored, and any other key starts over.
+	s, _ = s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("9")})
+	if l.cursor != 1 {
+		t.Errorf("9 with %d links moved the cursor to %d", len(c.Links.Links), l.cursor)
+	}
+	s, _ = s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("1")})
+	s, _ = s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	if _, cmd = s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("1")}); cmd != nil {
+		t.Error("a 1 after j should select link 1 again, not copy it")
 	}
 }
 
-func TestLinksSection_EnterClearsClipboardOnNextUpdate(t *testing.T) {
+func TestLinksSection_CopyMarked(t *testing.T) {
 	c := testutil.FixtureContent()
 	theme := testutil.FixtureTheme()
 
 	l := NewLinksSection(c, theme)
 	s := initSection(t, l, 80, 24)
+	space := tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")}
+	down := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")}
+
+	// Mark the third and first links, then unmark and remark the second.
+	s, _ = s.Update(down)
+	s, _ = s.Update(down)
+	s, _ = s.Update(space)
+	testutil.RequireContains(t, s.View(), "✓")
+	s, _ = s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("g")})
+	s, _ = s.Update(space)
+	s, _ = s.Update(down)
+	s, _ = s.Update(space)
+	s, _ = s.Update(space)
 
-	// Press Enter.
-	s, _ = s.Update(tea.KeyMsg{Type: tea.KeyEnter})
-	view1 := s.View()
-	if !strings.Contains(view1, "\x1b]52;c;") {
-		t.Fatal("expected OSC 52 in first view")
+	s, cmd := s.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	want := c.Links.Links[0].URL + "\n" + c.Links.Links[2].URL
+	if got := clipboardRequest(t, cmd); got != want {
+		t.Errorf("copied %q, want the marked URLs in list order %q", got, want)
+	}
+	if hints := hintText(l); hints != "Copied 2 URLs!" {
+		t.Errorf("KeyHints() = %q after copying two links", hints)
+	}
+	if strings.Contains(s.View(), "✓") {
+		t.Error("marks should clear once copied")
 	}
 
-	// Any subsequent update should clear the pending clipboard.
-	s, _ = s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
-	view2 := s.View()
-	if strings.Contains(view2, "\x1b]52;c;") {
-		t.Error("OSC 52 should be cleared after next update")
+	// Without marks, enter copies the selected link again.
+	_, cmd = s.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if got := clipboardRequest(t, cmd); got != c.Links.Links[1].URL {
+		t.Errorf("enter without marks copied %q, want the selected link", got)
+	}
+}
+
+func TestLinksSection_EscClearsMarks(t *testing.T) {
+	l := NewLinksSection(testutil.FixtureContent(), testutil.FixtureTheme())
+	s := initSection(t, l, 80, 24)
+	s, _ = s.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")})
+	s, _ = s.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if len(l.marks) != 0 || strings.Contains(s.View(), "✓") {
+		t.Error("esc should clear the marks")
+	}
+}
+
+func TestLinksSection_CopyFeedbackClears(t *testing.T) {
+	c := testutil.FixtureContent()
+	theme := testutil.FixtureTheme()
+
+	l := NewLinksSection(c, theme)
+	s := initSection(t, l, 80, 24)
+
+	// Press Enter to set feedback.
+	s, _ = s.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	// Send the clear message (simulates the 2s timer firing).
+	s, _ = s.Update(clearCopyFeedbackMsg{})
+
+	ls := s.(*LinksSection)
+	hints := hintText(ls)
+	if strings.Contains(hints, "Copied!") {
+		t.Error("expected feedback to be cleared after clearCopyFeedbackMsg")
+	}
+	if !strings.Contains(hints, "enter copy URL") {
+		t.Errorf("expected default hints after clearing, got %q", hints)
+	}
+}
+
+func TestLinksSection_CopyKeepsFrameHeight(t *testing.T) {
+	c := testutil.FixtureContent()
+	theme := testutil.FixtureTheme()
+
+	l := NewLinksSection(c, theme)
+	s := initSection(t, l, 80, 24)
+	before := s.View()
+
+	// Copying must not prefix the view with an escape-only "line".
+	s, _ = s.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	view := s.View()
+	if strings.HasPrefix(view, "\x1b") && !strings.HasPrefix(before, "\x1b") {
+		t.Errorf("view gained an escape prefix after copy: %q", view[:min(len(view), 40)])
+	}
+	if got := strings.Count(view, "\n") + 1; got != 24 {
+		t.Errorf("view has %d lines after copy, want 24", got)
+	}
+}
+
+// clipboardRequest runs cmd, descending into batches, and returns the text
+// of the first ClipboardMsg it produces. Commands that block, such as the
+// copy feedback timers, are left running in the background.
+func clipboardRequest(t *testing.T, cmd tea.Cmd) string {
+	t.Helper()
+	return awaitMsg[app.ClipboardMsg](t, cmd).Text
+}
+
+// awaitMsg runs cmd, descending into batches, and returns the first message
+// of type T it produces, failing the test if none arrives within a second.
+func awaitMsg[T tea.Msg](t *testing.T, cmd tea.Cmd) T {
+	t.Helper()
+	msgs := make(chan tea.Msg, 8)
+	run := func(c tea.Cmd) {
+		if c != nil {
+			go func() { msgs <- c() }()
+		}
+	}
+	run(cmd)
+	timeout := time.After(time.Second)
+	for {
+		select {
+		case msg := <-msgs:
+			if want, ok := msg.(T); ok {
+				return want
+			}
+			if batch, ok := msg.(tea.BatchMsg); ok {
+				for _, c := range batch {
+					run(c)
+				}
+			}
+		case <-timeout:
+			var zero T
+			t.Fatalf("cmd produced no %T", zero)
+			return zero
+		}
 	}
 }
 
@@ -721,3 +1427,758 @@ func TestAllSections_MouseScrollIgnoredWhenNotFocused(t *testing.T) {
 		})
 	}
 }
+
+func TestAllSections_EdgeCaseContent(t *testing.T) {
+	theme := testutil.FixtureTheme()
+
+	variants := []struct {
+		name string
+		muts []testutil.ContentMutator
+	}{
+		{"empty_lists", []testutil.ContentMutator{testutil.WithoutProjects(), testutil.WithoutLinks(), testutil.WithoutEducation()}},
+		{"long_text", []testutil.ContentMutator{testutil.WithLongText(2000)}},
+	}
+
+	for _, v := range variants {
+		c := testutil.FixtureContentWith(v.muts...)
+		makers := []struct {
+			name string
+			fn   func() app.SectionModel
+		}{
+			{"home", func() app.SectionModel { return NewHomeSection(c, theme) }},
+			{"work", func() app.SectionModel { return NewWorkSection(c, theme) }},
+			{"cv", func() app.SectionModel { return NewCVSection(c, theme) }},
+			{"links", func() app.SectionModel { return NewLinksSection(c, theme) }},
+		}
+		for _, m := range makers {
+			for _, sz := range testSizes {
+				t.Run(v.name+"/"+m.name+"/"+sz.name, func(t *testing.T) {
+					s := initSection(t, m.fn(), sz.width, sz.height)
+					s, _ = s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+					s, _ = s.Update(tea.KeyMsg{Type: tea.KeyEnter})
+					view := s.View()
+					testutil.RequireNotEmpty(t, view)
+					lines := strings.Split(view, "\n")
+					if len(lines) > sz.height {
+						t.Errorf("view has %d lines, exceeds height %d", len(lines), sz.height)
+					}
+					for i, line := range lines {
+						if w := lipgloss.Width(line); w > sz.width {
+							t.Errorf("line %d is %d wide, exceeds width %d", i, w, sz.width)
+							break
+						}
+					}
+				})
+			}
+		}
+	}
+}
+
+func TestAllSections_ThemeChangedPreservesScroll(t *testing.T) {
+	c := testutil.FixtureContent()
+	theme := testutil.FixtureTheme()
+	light := app.LightTheme()
+
+	makers := []struct {
+		name  string
+		fn    func() app.SectionModel
+		theme func(app.SectionModel) app.Theme
+	}{
+		{"home", func() app.SectionModel { return NewHomeSection(c, theme) },
+			func(s app.SectionModel) app.Theme { return s.(*HomeSection).theme }},
+		{"work", func() app.SectionModel { return NewWorkSection(c, theme) },
+			func(s app.SectionModel) app.Theme { return s.(*WorkSection).theme }},
+		{"cv", func() app.SectionModel { return NewCVSection(c, theme) },
+			func(s app.SectionModel) app.Theme { return s.(*CVSection).theme }},
+		{"links", func() app.SectionModel { return NewLinksSection(c, theme) },
+			func(s app.SectionModel) app.Theme { return s.(*LinksSection).theme }},
+	}
+
+	for _, m := range makers {
+		t.Run(m.name, func(t *testing.T) {
+			s := drainHomeReveal(initSection(t, m.fn(), 80, 10))
+			s, _ = s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+			before := s.View()
+
+			s, _ = s.Update(app.ThemeChangedMsg{Theme: light})
+			if got := m.theme(s).Name; got != app.ThemeLight {
+				t.Errorf("theme = %q after ThemeChangedMsg, want %q", got, app.ThemeLight)
+			}
+			if after := s.View(); after != before {
+				t.Errorf("view changed beyond colors after theme switch:\nbefore:\n%s\nafter:\n%s", before, after)
+			}
+		})
+	}
+}
+
+// --- Guestbook tests ---
+
+// newTestGuestbook opens an empty guestbook store in a temp dir.
+func newTestGuestbook(t testing.TB) *guestbook.Store {
+	t.Helper()
+	store, err := guestbook.Open(filepath.Join(t.TempDir(), guestbook.FileName), time.Hour)
+	if err != nil {
+		t.Fatalf("open guestbook: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+	return store
+}
+
+// typeText sends text to s one key at a time.
+func typeText(s app.SectionModel, text string) app.SectionModel {
+	for _, r := range text {
+		key := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}}
+		if r == ' ' {
+			key = tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{r}}
+		}
+		s, _ = s.Update(key)
+	}
+	return s
+}
+
+func TestGuestbookSection_SignFlow(t *testing.T) {
+	store := newTestGuestbook(t)
+	s := initSection(t, NewGuestbookSection(store, "alice", "1.1.1.1", testutil.FixtureTheme()), 80, 24)
+
+	s, _ = s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	gb := s.(*GuestbookSection)
+	if !gb.CapturingInput() {
+		t.Fatal("s should start composing")
+	}
+
+	// Global keys like q are plain text while composing.
+	s = typeText(s, "hi q")
+	s, _ = s.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	s = typeText(s, "there")
+	testutil.RequireContains(t, s.View(), "hi there")
+
+	s, cmd := s.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("enter should submit the message")
+	}
+	s, _ = s.Update(cmd())
+	if gb.CapturingInput() {
+		t.Error("composing should end after a successful sign")
+	}
+	if got := hintText(gb); got != "Signed. Thank you!" {
+		t.Errorf("KeyHints() = %q after signing", got)
+	}
+	view := s.View()
+	testutil.RequireContains(t, view, "alice")
+	testutil.RequireContains(t, view, "hi there")
+	if got := len(store.Entries()); got != 1 {
+		t.Errorf("store has %d entries, want 1", got)
+	}
+}
+
+func TestGuestbookSection_RateLimited(t *testing.T) {
+	store := newTestGuestbook(t)
+	if _, err := store.Add("1.1.1.1", "alice", "earlier"); err != nil {
+		t.Fatal(err)
+	}
+	s := initSection(t, NewGuestbookSection(store, "alice", "1.1.1.1", testutil.FixtureTheme()), 80, 24)
+
+	s, _ = s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	s = typeText(s, "again")
+	s, cmd := s.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	s, _ = s.Update(cmd())
+
+	gb := s.(*GuestbookSection)
+	if !strings.Contains(hintText(gb), "Already signed") {
+		t.Errorf("KeyHints() = %q, want rate-limit feedback", hintText(gb))
+	}
+	if got := len(store.Entries()); got != 1 {
+		t.Errorf("store has %d entries, want 1", got)
+	}
+}
+
+func TestSectionsEmptyStateInLocale(t *testing.T) {
+	de, _ := i18n.Lookup("de")
+	theme := testutil.FixtureTheme()
+	for _, tc := range []struct {
+		section app.SectionModel
+		want    string
+	}{
+		{NewWorkSection(nil, theme), "Keine Projekte geladen"},
+		{NewLinksSection(testutil.FixtureContentWith(testutil.WithoutLinks()), theme), "Keine Links vorhanden"},
+		{NewNotesSection(testutil.FixtureContent(), theme), "Noch keine Notizen"},
+		{NewGuestbookSection(newTestGuestbook(t), "alice", "1.1.1.1", theme), "Noch keine Einträge"},
+		{NewAdminSection(nil, theme), "Der Admin-Bereich ist nicht verfügbar"},
+	} {
+		s := initSection(t, tc.section, 100, 24)
+		s, _ = s.Update(app.LocaleChangedMsg{Locale: de})
+		testutil.RequireContains(t, s.View(), tc.want)
+	}
+}
+
+func TestSectionsHintInLocale(t *testing.T) {
+	de, _ := i18n.Lookup("de")
+	theme := testutil.FixtureTheme()
+	cv := initSection(t, NewCVSection(testutil.FixtureContent(), theme), 80, 24)
+	cv, _ = cv.Update(app.LocaleChangedMsg{Locale: de})
+	testutil.RequireContains(t, hintText(cv.(app.KeyHinter)), "d herunterladen")
+	cv, cmd := cv.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	awaitMsg[app.TerminalWriteMsg](t, cmd)
+	testutil.RequireContains(t, hintText(cv.(app.KeyHinter)), "resume.pdf gesendet")
+
+	store := newTestGuestbook(t)
+	s := initSection(t, NewGuestbookSection(store, "alice", "1.1.1.1", theme), 80, 24)
+	s, _ = s.Update(app.LocaleChangedMsg{Locale: de})
+	s, _ = s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	s = typeText(s, "hallo")
+	s, cmd = s.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	s, _ = s.Update(cmd())
+	if got := hintText(s.(app.KeyHinter)); got != "Unterschrieben. Danke!" {
+		t.Errorf("KeyHints() = %q after signing in German", got)
+	}
+}
+
+func TestGuestbookSection_Prompt(t *testing.T) {
+	store := newTestGuestbook(t)
+	s := initSection(t, NewGuestbookSection(store, "alice", "1.1.1.1", testutil.FixtureTheme()), 80, 24)
+	testutil.RequireContains(t, s.View(), "Press s to sign the guestbook.")
+
+	km, err := app.ParseKeyMap([]byte(`{"sign": ["ctrl+s"]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	de, _ := i18n.Lookup("de")
+	s, _ = s.Update(app.KeyMapChangedMsg{Keys: km})
+	s, _ = s.Update(app.LocaleChangedMsg{Locale: de})
+	testutil.RequireContains(t, s.View(), "Drücke ^s, um ins Gästebuch zu schreiben.")
+}
+
+func TestGuestbookSection_Anonymous(t *testing.T) {
+	store := newTestGuestbook(t)
+	s := initSection(t, NewGuestbookSection(store, "", "1.1.1.1", testutil.FixtureTheme()), 80, 24)
+	s, _ = s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	testutil.RequireContains(t, s.View(), "signing as anonymous")
+	s = typeText(s, "hello")
+	s, cmd := s.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	s.Update(cmd())
+	if entries := store.Entries(); len(entries) != 1 || entries[0].Name != guestbook.Anonymous {
+		t.Errorf("entries = %+v, want one signed %s", entries, guestbook.Anonymous)
+	}
+}
+
+func TestGuestbookSection_EscCancels(t *testing.T) {
+	s := initSection(t, NewGuestbookSection(newTestGuestbook(t), "alice", "ip", testutil.FixtureTheme()), 80, 24)
+	s, _ = s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	s, _ = s.Update(tea.KeyMsg{Type: tea.KeyEscape})
+	if s.(*GuestbookSection).CapturingInput() {
+		t.Error("esc should stop composing")
+	}
+}
+
+func TestGuestbookSection_Unavailable(t *testing.T) {
+	s := initSection(t, NewGuestbookSection(nil, "alice", "ip", testutil.FixtureTheme()), 80, 24)
+	testutil.RequireContains(t, s.View(), "unavailable")
+	s, _ = s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	if s.(*GuestbookSection).CapturingInput() {
+		t.Error("an unavailable guestbook should not accept input")
+	}
+}
+
+func TestGuestbookSection_LongEntriesFitWidth(t *testing.T) {
+	store := newTestGuestbook(t)
+	long := strings.Repeat("x", guestbook.MaxMessageLen)
+	if _, err := store.Add("1", strings.Repeat("n", guestbook.MaxNameLen), long); err != nil {
+		t.Fatal(err)
+	}
+	s := initSection(t, NewGuestbookSection(store, "alice", "2", testutil.FixtureTheme()), 40, 15)
+	s, _ = s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	s = typeText(s, long)
+
+	for i, line := range strings.Split(s.View(), "\n") {
+		if w := lipgloss.Width(line); w > 40 {
+			t.Errorf("line %d is %d wide, exceeds 40", i, w)
+		}
+	}
+}
+
+// signedGuestbook returns a guestbook with n entries, each from its own IP.
+func signedGuestbook(tb testing.TB, n int) *guestbook.Store {
+	tb.Helper()
+	store := newTestGuestbook(tb)
+	for i := range n {
+		msg := fmt.Sprintf("entry %d: %s", i, strings.Repeat("lovely portfolio ", i%8+1))
+		if _, err := store.Add(fmt.Sprint(i), fmt.Sprint("visitor", i), msg); err != nil {
+			tb.Fatal(err)
+		}
+	}
+	return store
+}
+
+func TestGuestbookSection_LineWindows(t *testing.T) {
+	g := NewGuestbookSection(signedGuestbook(t, 40), "alice", "x", testutil.FixtureTheme())
+	initSection(t, g, 60, 20)
+
+	lines := g.renderContent()
+	all := lines.RenderLines(0, lines.Len())
+	if len(all) != g.viewport.TotalLines() {
+		t.Fatalf("rendered %d lines, the viewport counts %d", len(all), g.viewport.TotalLines())
+	}
+	// Any window matches the same lines of the whole, from the compose
+	// area into the entries and on to the last line.
+	for _, w := range [][2]int{{0, 1}, {1, 5}, {3, 9}, {len(all) / 2, len(all)/2 + 20}, {len(all) - 7, len(all)}} {
+		got := lines.RenderLines(w[0], w[1])
+		if strings.Join(got, "\n") != strings.Join(all[w[0]:w[1]], "\n") {
+			t.Errorf("lines %d-%d: got %q, want %q", w[0], w[1], got, all[w[0]:w[1]])
+		}
+	}
+	testutil.RequireContains(t, strings.Join(all, "\n"), "visitor0")
+
+	g.viewport.ScrollToBottom()
+	testutil.RequireContains(t, g.View(), "entry 0:")
+}
+
+func BenchmarkGuestbookSection_Resize(b *testing.B) {
+	g := NewGuestbookSection(signedGuestbook(b, 500), "alice", "x", testutil.FixtureTheme())
+	g.Update(app.FocusMsg{})
+	sizes := []tea.WindowSizeMsg{{Width: 80, Height: 40}, {Width: 79, Height: 40}}
+	b.ReportAllocs()
+	for i := 0; b.Loop(); i++ {
+		g.Update(sizes[i%2])
+		_ = g.View()
+	}
+}
+
+func BenchmarkGuestbookSection_Compose(b *testing.B) {
+	g := NewGuestbookSection(signedGuestbook(b, 500), "alice", "x", testutil.FixtureTheme())
+	g.Update(tea.WindowSizeMsg{Width: 100, Height: 40})
+	g.Update(app.FocusMsg{})
+	g.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	keys := []tea.KeyMsg{{Type: tea.KeyRunes, Runes: []rune("a")}, {Type: tea.KeyBackspace}}
+	b.ReportAllocs()
+	for i := 0; b.Loop(); i++ {
+		g.Update(keys[i%2])
+		_ = g.View()
+	}
+}
+
+// --- Status tests ---
+
+// newTestMonitor returns a monitor with one healthy and one failing service
+// that has not checked them yet.
+func newTestMonitor(t *testing.T) *status.Monitor {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ok", func(http.ResponseWriter, *http.Request) {})
+	mux.HandleFunc("/broken", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return status.NewMonitor([]status.Check{
+		{Name: "api", Target: srv.URL + "/ok"},
+		{Name: "shop", Target: srv.URL + "/broken"},
+	}, time.Minute)
+}
+
+func TestStatusSection_Rows(t *testing.T) {
+	m := newTestMonitor(t)
+	s := initSection(t, NewStatusSection(m, testutil.FixtureTheme()), 80, 24)
+	view := s.View()
+	testutil.RequireContains(t, view, "Checking services")
+	testutil.RequireContains(t, view, "api")
+	testutil.RequireContains(t, view, "checking")
+
+	m.CheckAll(context.Background())
+	s, _ = s.Update(statusTickMsg{gen: s.(*StatusSection).gen})
+	view = s.View()
+	testutil.RequireContains(t, view, "1 service is down")
+	testutil.RequireContains(t, view, "up · ")
+	testutil.RequireContains(t, view, "down, HTTP 502")
+	testutil.RequireContains(t, view, "Checked just now")
+}
+
+func TestStatusSection_StaleTicksStop(t *testing.T) {
+	s := initSection(t, NewStatusSection(newTestMonitor(t), testutil.FixtureTheme()), 80, 24)
+	old := s.(*StatusSection).gen
+	s, _ = s.Update(app.BlurMsg{})
+	if _, cmd := s.Update(statusTickMsg{gen: old}); cmd != nil {
+		t.Error("a tick after blur should not schedule another")
+	}
+	s, _ = s.Update(app.FocusMsg{})
+	if _, cmd := s.Update(statusTickMsg{gen: old}); cmd != nil {
+		t.Error("a tick from an earlier focus should not schedule another")
+	}
+	if _, cmd := s.Update(statusTickMsg{gen: s.(*StatusSection).gen}); cmd == nil {
+		t.Error("the current tick should schedule the next refresh")
+	}
+}
+
+func TestStatusSection_Unavailable(t *testing.T) {
+	s := initSection(t, NewStatusSection(nil, testutil.FixtureTheme()), 80, 24)
+	testutil.RequireContains(t, s.View(), "unavailable")
+}
+
+func TestStatusSection_FitsWidth(t *testing.T) {
+	m := status.NewMonitor([]status.Check{
+		{Name: strings.Repeat("n", 60), Target: "tcp://localhost:1"},
+	}, time.Minute)
+	for _, size := range testSizes {
+		s := initSection(t, NewStatusSection(m, testutil.FixtureTheme()), size.width, size.height)
+		for i, line := range strings.Split(s.View(), "\n") {
+			if w := lipgloss.Width(line); w > size.width {
+				t.Errorf("%s: line %d is %d wide", size.name, i, w)
+			}
+		}
+	}
+}
+
+// fakeAdmin is an AdminSource with fixed data.
+type fakeAdmin struct {
+	summaries []analytics.Summary
+	journeys  []analytics.Journey
+	err       error
+}
+
+func (fakeAdmin) Sessions() []AdminSession {
+	return []AdminSession{{User: "visitor", IP: "203.0.113.7", Started: time.Now().Add(-90 * time.Second)}}
+}
+
+func (f fakeAdmin) Analytics(time.Time) ([]analytics.Summary, []analytics.Journey, error) {
+	return f.summaries, f.journeys, f.err
+}
+
+func (fakeAdmin) Settings() []AdminSetting {
+	return []AdminSetting{{Name: "Address", Value: "127.0.0.1:2222"}}
+}
+
+func TestAdminSection_Content(t *testing.T) {
+	src := fakeAdmin{summaries: []analytics.Summary{{
+		Visitors: 3, Sessions: 4, AvgSession: 2 * time.Minute,
+		Sections: []analytics.SectionStats{{Section: "work", Views: 5}},
+	}}}
+	var s app.SectionModel = NewAdminSection(src, testutil.FixtureTheme())
+	s, _ = s.Update(tea.WindowSizeMsg{Width: 100, Height: 40})
+	s, cmd := s.Update(app.FocusMsg{})
+	view := s.View()
+	testutil.RequireContains(t, view, "Live sessions (1)")
+	testutil.RequireContains(t, view, "203.0.113.7")
+	testutil.RequireContains(t, view, "1m30s")
+	testutil.RequireContains(t, view, "Loading analytics")
+	testutil.RequireContains(t, view, "127.0.0.1:2222")
+
+	s, _ = s.Update(awaitMsg[adminSummariesMsg](t, cmd))
+	view = s.View()
+	testutil.RequireContains(t, view, "Today")
+	testutil.RequireContains(t, view, "3 visitors")
+	testutil.RequireContains(t, view, "top work")
+	if strings.Contains(view, "journeys") {
+		t.Error("journeys should be left out while there are none to show")
+	}
+}
+
+func TestAdminSection_Journeys(t *testing.T) {
+	start := time.Date(2026, 10, 14, 9, 30, 0, 0, time.UTC)
+	src := fakeAdmin{summaries: []analytics.Summary{{Visitors: 1, Sessions: 1}}}
+	for i := range adminJourneys + 2 {
+		src.journeys = append(src.journeys, analytics.Journey{
+			Visitor: i + 1,
+			Start:   start.Add(time.Duration(i) * time.Minute),
+			Steps:   []analytics.JourneyStep{{Section: "home", Dwell: 12 * time.Second}, {Section: "cv", Dwell: time.Minute}},
+			Total:   72 * time.Second,
+		})
+	}
+	var s app.SectionModel = NewAdminSection(src, testutil.FixtureTheme())
+	s, _ = s.Update(tea.WindowSizeMsg{Width: 100, Height: 60})
+	s, cmd := s.Update(app.FocusMsg{})
+	s, _ = s.Update(awaitMsg[adminSummariesMsg](t, cmd))
+	view := s.View()
+	testutil.RequireContains(t, view, "Latest journeys")
+	testutil.RequireContains(t, view, "home 12s → cv 1m0s")
+	// Each has a timeline filling the text width past its indent.
+	timeline := src.journeys[0].Timeline(s.(*AdminSection).viewport.ContentWidth() - 6)
+	testutil.RequireContains(t, view, timeline)
+	// The newest come first, and only adminJourneys of them.
+	newest := strings.Index(view, fmt.Sprintf("visitor %d ", adminJourneys+2))
+	older := strings.Index(view, fmt.Sprintf("visitor %d ", adminJourneys+1))
+	if newest < 0 || older < newest {
+		t.Errorf("journeys should list the newest first:\n%s", view)
+	}
+	if strings.Contains(view, "visitor 2 ") {
+		t.Errorf("only the latest %d journeys should be listed:\n%s", adminJourneys, view)
+	}
+
+	src.journeys = []analytics.Journey{}
+	s = NewAdminSection(src, testutil.FixtureTheme())
+	s, _ = s.Update(tea.WindowSizeMsg{Width: 100, Height: 60})
+	s, cmd = s.Update(app.FocusMsg{})
+	s, _ = s.Update(awaitMsg[adminSummariesMsg](t, cmd))
+	testutil.RequireContains(t, s.View(), "No visits yet.")
+}
+
+func TestAdminSection_SummaryError(t *testing.T) {
+	var s app.SectionModel = NewAdminSection(fakeAdmin{err: errors.New("log unreadable")}, testutil.FixtureTheme())
+	s, _ = s.Update(tea.WindowSizeMsg{Width: 100, Height: 40})
+	s, cmd := s.Update(app.FocusMsg{})
+	s, _ = s.Update(awaitMsg[adminSummariesMsg](t, cmd))
+	testutil.RequireContains(t, s.View(), "log unreadable")
+
+	// A reload from an earlier focus is dropped.
+	s, _ = s.Update(app.FocusMsg{})
+	s, _ = s.Update(adminSummariesMsg{gen: 1, summaries: []analytics.Summary{{Visitors: 9}}})
+	if strings.Contains(s.View(), "9 visitors") {
+		t.Error("summaries from an earlier focus should be ignored")
+	}
+}
+
+func TestAdminSection_Unavailable(t *testing.T) {
+	s := initSection(t, NewAdminSection(nil, testutil.FixtureTheme()), 80, 24)
+	testutil.RequireContains(t, s.View(), "unavailable")
+}
+
+func notesContent() *content.Content {
+	return testutil.FixtureContentWith(func(c *content.Content) {
+		c.Notes = []content.Note{
+			{Slug: "ssh", Title: "Shipping a TUI over SSH", Date: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC),
+				Summary: "What it took.", Body: "## Why\n\nBecause **terminals** are fun.\n\n- one\n- two"},
+			{Slug: "hello", Title: "Hello", Body: "# Hello\n\nFirst note."},
+		}
+	})
+}
+
+func TestNotesSection_ListAndArticle(t *testing.T) {
+	n := NewNotesSection(notesContent(), testutil.FixtureTheme())
+	s := initSection(t, n, 80, 40)
+	view := s.View()
+	testutil.RequireContains(t, view, "Shipping a TUI over SSH")
+	testutil.RequireContains(t, view, "1 Feb 2026")
+	testutil.RequireContains(t, view, "What it took.")
+	testutil.RequireContains(t, view, "Hello")
+
+	s, _ = s.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	view = s.View()
+	testutil.RequireContains(t, view, "Shipping a TUI over SSH")
+	testutil.RequireContains(t, view, "Why")
+	testutil.RequireContains(t, view, "terminals")
+	if strings.Contains(view, "**") || strings.Contains(view, "What it took.") {
+		t.Error("the article should render its markdown, without the list")
+	}
+	testutil.RequireContains(t, hintText(s.(*NotesSection)), "esc")
+
+	s, _ = s.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	s, _ = s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	s, _ = s.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	view = s.View()
+	testutil.RequireContains(t, view, "First")
+	if strings.Count(view, "Hello") != 1 {
+		t.Error("a note starting with its heading should not repeat the title")
+	}
+}
+
+func TestNotesSection_Empty(t *testing.T) {
+	n := NewNotesSection(testutil.FixtureContent(), testutil.FixtureTheme())
+	s := initSection(t, n, 80, 24)
+	testutil.RequireContains(t, s.View(), "No notes yet")
+	s, _ = s.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	testutil.RequireContains(t, s.View(), "No notes yet")
+}
+
+// rowOfLine returns the view row showing content line, failing the test
+// when it is off screen.
+func rowOfLine(t *testing.T, vp *app.Viewport, line int) int {
+	t.Helper()
+	for row := range vp.VisibleLines() {
+		if got, ok := vp.LineAt(row); ok && got == line {
+			return row
+		}
+	}
+	t.Fatalf("content line %d is not on screen", line)
+	return 0
+}
+
+func leftPress(row int) tea.MouseMsg {
+	return tea.MouseMsg{X: 4, Y: row, Action: tea.MouseActionPress, Button: tea.MouseButtonLeft}
+}
+
+func TestWorkSection_ClickSelectsThenCopies(t *testing.T) {
+	c := testutil.FixtureContent()
+	w := NewWorkSection(c, testutil.FixtureTheme())
+	initSection(t, w, 80, 60)
+
+	// Each project's offset is the line its title is on.
+	lines := strings.Split(w.renderContent(), "\n")
+	for i, p := range c.Work.Projects {
+		if off := w.projectOffsets[i]; off >= len(lines) || !strings.Contains(lines[off], p.Title) {
+			t.Fatalf("project %d offset %d is not on its title %q", i, w.projectOffsets[i], p.Title)
+		}
+	}
+
+	row := rowOfLine(t, &w.viewport, w.projectOffsets[1]+1)
+	if _, cmd := w.Update(leftPress(row)); cmd != nil || w.cursor != 1 {
+		t.Fatalf("first click: cursor %d, cmd %v; want project 1 selected", w.cursor, cmd != nil)
+	}
+	_, cmd := w.Update(leftPress(row))
+	if got := clipboardRequest(t, cmd); got != w.projectURLs[1] {
+		t.Errorf("second click copied %q, want %q", got, w.projectURLs[1])
+	}
+}
+
+func TestLinksSection_ClickSelectsThenCopies(t *testing.T) {
+	c := testutil.FixtureContent()
+	l := NewLinksSection(c, testutil.FixtureTheme())
+	initSection(t, l, 80, 40)
+
+	row := rowOfLine(t, &l.viewport, topPadLines+linesPerLink)
+	if _, cmd := l.Update(leftPress(row)); cmd != nil || l.cursor != 1 {
+		t.Fatalf("first click: cursor %d; want link 1 selected", l.cursor)
+	}
+	_, cmd := l.Update(leftPress(row))
+	if got := clipboardRequest(t, cmd); got != c.Links.Links[1].URL {
+		t.Errorf("second click copied %q, want %q", got, c.Links.Links[1].URL)
+	}
+
+	// Rows above the first link select nothing.
+	l.Update(leftPress(rowOfLine(t, &l.viewport, 0)))
+	if l.cursor != 1 {
+		t.Errorf("a click above the links moved the cursor to %d", l.cursor)
+	}
+}
+
+func TestNotesSection_ClickOpens(t *testing.T) {
+	n := NewNotesSection(notesContent(), testutil.FixtureTheme())
+	initSection(t, n, 80, 40)
+
+	row := rowOfLine(t, &n.viewport, n.noteOffsets[1])
+	n.Update(leftPress(row))
+	if n.cursor != 1 || n.open >= 0 {
+		t.Fatalf("first click: cursor %d, open %d; want note 1 selected", n.cursor, n.open)
+	}
+	n.Update(leftPress(row))
+	if n.open != 1 {
+		t.Fatalf("second click opened note %d, want 1", n.open)
+	}
+	testutil.RequireContains(t, n.View(), "First")
+}
+
+// --- Uses tests ---
+
+// usesColumn returns the display column substr starts at on the first
+// line of view that has it, or -1.
+func usesColumn(view, substr string) int {
+	for _, line := range strings.Split(view, "\n") {
+		if i := strings.Index(line, substr); i >= 0 {
+			return lipgloss.Width(line[:i])
+		}
+	}
+	return -1
+}
+
+func TestUsesSection_Columns(t *testing.T) {
+	c := testutil.FixtureContent()
+	s := initSection(t, NewUsesSection(c, testutil.FixtureTheme()), 100, 40)
+	view := s.View()
+	testutil.RequireContains(t, view, "USES")
+	if !strings.Contains(view, "\x1b]8;;https://ghostty.org\a") {
+		t.Error("expected an OSC 8 hyperlink for a tool with a URL")
+	}
+
+	// Categories, names, and descriptions each line up.
+	if a, b := usesColumn(view, "Editor"), usesColumn(view, "Hardware"); a < 0 || a != b {
+		t.Errorf("categories at columns %d and %d, want them aligned", a, b)
+	}
+	if a, b := usesColumn(view, "Neovim"), usesColumn(view, "Keychron K3"); a < 0 || a != b {
+		t.Errorf("names at columns %d and %d, want them aligned", a, b)
+	}
+	if a, b := usesColumn(view, "Daily driver"), usesColumn(view, "Sessions that"); a < 0 || a != b {
+		t.Errorf("descriptions at columns %d and %d, want them aligned", a, b)
+	}
+	if a, b := usesColumn(view, "Terminal"), usesColumn(view, "Ghostty"); a >= b {
+		t.Error("a category's first tool should be on its line")
+	}
+}
+
+func TestUsesSection_NarrowPutsDescriptionsBelow(t *testing.T) {
+	c := testutil.FixtureContent()
+	s := initSection(t, NewUsesSection(c, testutil.FixtureTheme()), 30, 40)
+	view := s.View()
+	for _, line := range strings.Split(view, "\n") {
+		if strings.Contains(line, "Neovim") && strings.Contains(line, "Everything") {
+			t.Fatalf("at 30 columns the description should be below the name: %q", line)
+		}
+		if w := lipgloss.Width(line); w > 30 {
+			t.Errorf("line is %d columns wide at 30: %q", w, line)
+		}
+	}
+	testutil.RequireContains(t, view, "Everything")
+}
+
+func TestUsesSection_Empty(t *testing.T) {
+	c := testutil.FixtureContent()
+	c.Uses = nil
+	s := initSection(t, NewUsesSection(c, testutil.FixtureTheme()), 80, 24)
+	if strings.Contains(s.View(), "USES") {
+		t.Error("the uses page should be empty without uses.json")
+	}
+}
+
+// --- Talks tests ---
+
+func TestTalksSection_NewestFirstAndCopy(t *testing.T) {
+	c := testutil.FixtureContent()
+	s := initSection(t, NewTalksSection(c, testutil.FixtureTheme()), 80, 40)
+	view := s.View()
+	if a, b := strings.Index(view, "Serving a TUI"), strings.Index(view, "Terminals Are"); a < 0 || b < a {
+		t.Error("the newest talk should be listed first")
+	}
+	testutil.RequireContains(t, view, "27 Aug 2025")
+	if !strings.Contains(view, "\x1b]8;;https://www.youtube.com/watch?v=example\a") {
+		t.Error("expected an OSC 8 hyperlink for the video")
+	}
+
+	_, cmd := s.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("enter should copy the selected talk's video")
+	}
+	testutil.RequireContains(t, hintText(s.(*TalksSection)), "Copied!")
+
+	// A talk without a video copies its slides, and one with neither
+	// copies nothing.
+	s, _ = s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	if _, cmd := s.Update(tea.KeyMsg{Type: tea.KeyEnter}); cmd == nil {
+		t.Error("enter should copy the slides of a talk without a video")
+	}
+	s, _ = s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	if _, cmd := s.Update(tea.KeyMsg{Type: tea.KeyEnter}); cmd != nil {
+		t.Error("enter on a talk without links should copy nothing")
+	}
+}
+
+func TestTalksSection_Thumbnail(t *testing.T) {
+	c := testutil.FixtureContent()
+	cols, rows := ThumbnailSize()
+	row := strings.Repeat("⣿", cols)
+	art := strings.TrimSuffix(strings.Repeat(row+"\n", rows), "\n")
+
+	talks := NewTalksSection(c, testutil.FixtureTheme())
+	talks.SetThumbnails(map[string]string{"assets/ssh-tui.png": art})
+	view := initSection(t, talks, 80, 40).View()
+	if got := strings.Count(view, row); got != rows {
+		t.Errorf("thumbnail rows = %d, want %d", got, rows)
+	}
+	for _, line := range strings.Split(view, "\n") {
+		if strings.Contains(line, "GopherCon") && !strings.Contains(line, row) {
+			t.Error("the event should be beside the thumbnail")
+		}
+	}
+
+	// Too narrow for both, the talk shows without it.
+	view = initSection(t, talks, 40, 40).View()
+	if strings.Contains(view, row) {
+		t.Error("the thumbnail should be left out at 40 columns")
+	}
+	testutil.RequireContains(t, view, "GopherCon")
+}
+
+func TestTalksSection_Empty(t *testing.T) {
+	c := testutil.FixtureContent()
+	c.Talks = nil
+	s := initSection(t, NewTalksSection(c, testutil.FixtureTheme()), 80, 24)
+	testutil.RequireContains(t, s.View(), "No talks yet")
+	if _, cmd := s.Update(tea.KeyMsg{Type: tea.KeyEnter}); cmd != nil {
+		t.Error("enter with no talks should do nothing")
+	}
+}
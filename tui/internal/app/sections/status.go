@@ -0,0 +1,247 @@
+package sections
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/buntingszn/terminal-portfolio/tui/internal/app"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/i18n"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/status"
+)
+
+// statusRefresh is how often the focused status section re-reads the
+// monitor's results. It only reads; the monitor checks on its own schedule.
+const statusRefresh = 5 * time.Second
+
+// statusTickMsg re-renders the status section. gen ties it to one focus, so
+// ticks from an earlier visit stop rather than piling up.
+type statusTickMsg struct {
+	gen int
+}
+
+// StatusSection shows the latest health of the owner's services, one row
+// per service with a green, amber, or red dot.
+type StatusSection struct {
+	monitor  *status.Monitor
+	theme    app.Theme
+	locale   *i18n.Locale
+	viewport app.Viewport
+	width    int
+	height   int
+	focused  bool
+	gen      int
+}
+
+// NewStatusSection creates a StatusSection reporting monitor's results. A
+// nil monitor renders the section as unavailable.
+func NewStatusSection(monitor *status.Monitor, theme app.Theme) *StatusSection {
+	return &StatusSection{
+		monitor:  monitor,
+		theme:    theme,
+		viewport: app.NewViewport(0, 0),
+	}
+}
+
+// Init implements app.SectionModel.
+func (s *StatusSection) Init() tea.Cmd {
+	return nil
+}
+
+// Update implements app.SectionModel.
+func (s *StatusSection) Update(msg tea.Msg) (app.SectionModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		s.width = msg.Width
+		s.height = msg.Height
+		s.viewport.SetSize(s.width, s.height)
+		s.viewport.SetContentPreserveScroll(s.renderContent())
+
+	case tea.KeyMsg:
+		if !s.focused {
+			break
+		}
+		switch msg.String() {
+		case "j", "down":
+			s.viewport.ScrollDown(1)
+		case "k", "up":
+			s.viewport.ScrollUp(1)
+		case "h", "left":
+			s.viewport.ScrollLeft(columnStep)
+		case "l", "right":
+			s.viewport.ScrollRight(columnStep)
+		case "g", "home":
+			return s, s.viewport.ScrollToAnimated(0)
+		case "G", "end":
+			return s, s.viewport.ScrollToAnimated(s.viewport.TotalLines())
+		}
+
+	case tea.MouseMsg:
+		if !s.focused {
+			break
+		}
+		switch msg.Button {
+		case tea.MouseButtonWheelUp:
+			s.viewport.ScrollUp(scrollStep)
+		case tea.MouseButtonWheelDown:
+			s.viewport.ScrollDown(scrollStep)
+		}
+
+	case statusTickMsg:
+		if !s.focused || msg.gen != s.gen {
+			break
+		}
+		s.viewport.SetContentPreserveScroll(s.renderContent())
+		return s, s.tick()
+
+	case app.AnimationTickMsg:
+		return s, s.viewport.Animate(msg)
+
+	case app.ThemeChangedMsg:
+		s.theme = msg.Theme
+		s.viewport.SetContentPreserveScroll(s.renderContent())
+
+	case app.LocaleChangedMsg:
+		s.locale = msg.Locale
+		s.viewport.SetContentPreserveScroll(s.renderContent())
+
+	case app.FocusMsg:
+		s.focused = true
+		s.gen++
+		s.viewport.SetContent(s.renderContent())
+		s.viewport.ScrollToTop()
+		return s, s.tick()
+
+	case app.BlurMsg:
+		s.focused = false
+	}
+
+	return s, nil
+}
+
+// tick schedules the next refresh for the current focus.
+func (s *StatusSection) tick() tea.Cmd {
+	gen := s.gen
+	return tea.Tick(statusRefresh, func(time.Time) tea.Msg {
+		return statusTickMsg{gen: gen}
+	})
+}
+
+// View implements app.SectionModel.
+func (s *StatusSection) View() string {
+	return s.viewport.ViewWithScrollbar(s.theme)
+}
+
+// ScrollInfo implements app.ScrollReporter for the status bar scroll indicator.
+func (s *StatusSection) ScrollInfo() app.ScrollInfo {
+	return s.viewport.GetScrollInfo()
+}
+
+// ScrollToLine implements app.ScrollDragger.
+func (s *StatusSection) ScrollToLine(line int) {
+	s.viewport.SetYOffset(line)
+}
+
+// Position implements app.PositionRestorer with the scroll offset.
+func (s *StatusSection) Position() int {
+	return s.viewport.YOffset()
+}
+
+// RestorePosition implements app.PositionRestorer.
+func (s *StatusSection) RestorePosition(pos int) {
+	s.viewport.SetYOffset(pos)
+}
+
+// KeyHints implements app.KeyHinter for contextual status bar hints.
+func (s *StatusSection) KeyHints() []app.KeyHint {
+	return []app.KeyHint{scrollHint(s.locale), app.NavHint(s.locale), app.HelpHint(s.locale)}
+}
+
+// stateColor returns the dot color for a service state.
+func stateColor(theme app.Theme, state status.State) lipgloss.Color {
+	switch state {
+	case status.Up:
+		return theme.Colors.Success
+	case status.Degraded:
+		return theme.Colors.Warning
+	case status.Down:
+		return theme.Colors.Accent
+	default:
+		return theme.Colors.Muted
+	}
+}
+
+// statusHeadline summarizes every service in one line.
+func statusHeadline(results []status.Result) string {
+	var down, degraded, unknown int
+	for _, r := range results {
+		switch r.State {
+		case status.Down:
+			down++
+		case status.Degraded:
+			degraded++
+		case status.Unknown:
+			unknown++
+		}
+	}
+	switch {
+	case down == 1:
+		return "1 service is down"
+	case down > 1:
+		return fmt.Sprintf("%d services are down", down)
+	case degraded > 0:
+		return "Some services are degraded"
+	case unknown > 0:
+		return "Checking services…"
+	default:
+		return "All services operational"
+	}
+}
+
+// renderContent builds the headline, one row per service, and when the
+// results were last updated.
+func (s *StatusSection) renderContent() string {
+	results := s.monitor.Results()
+	if len(results) == 0 {
+		return app.ErrorState(s.theme, s.locale.T("status.unavailable"), s.locale.T("state.try_later"), s.viewport.ContentWidth())
+	}
+
+	textWidth := max(1, s.viewport.ContentWidth()-4)
+	var b strings.Builder
+	b.WriteString("\n  " + s.theme.Title.Render(app.TruncateWithEllipsis(statusHeadline(results), textWidth)) + "\n\n")
+
+	nameWidth := 0
+	var checked time.Time
+	for _, r := range results {
+		nameWidth = max(nameWidth, lipgloss.Width(r.Name))
+		if r.Checked.After(checked) {
+			checked = r.Checked
+		}
+	}
+	nameWidth = min(nameWidth, max(1, textWidth/2))
+
+	for _, r := range results {
+		dot := s.theme.NewStyle().Foreground(stateColor(s.theme, r.State)).Render("●")
+		name := app.TruncateWithEllipsis(r.Name, nameWidth)
+		name += strings.Repeat(" ", nameWidth-lipgloss.Width(name))
+
+		detail := r.State.String()
+		if r.Detail != "" {
+			detail += ", " + r.Detail
+		}
+		if r.State != status.Unknown && r.State != status.Down {
+			detail += fmt.Sprintf(" · %dms", r.Latency.Milliseconds())
+		}
+		room := max(1, textWidth-nameWidth-4)
+		b.WriteString("  " + dot + " " + s.theme.Body.Render(name) + "  " +
+			s.theme.Muted.Render(app.TruncateWithEllipsis(detail, room)) + "\n")
+	}
+
+	if when := app.RelativeTime(checked, time.Now()); when != "" {
+		b.WriteString("\n  " + s.theme.Muted.Render(app.TruncateWithEllipsis("Checked "+when, textWidth)))
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
@@ -0,0 +1,394 @@
+package sections
+
+import (
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/app"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/content"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/i18n"
+)
+
+// thumbnailCols and thumbnailRows are the size in cells of a talk's
+// braille thumbnail, about 16:9 in a terminal's tall cells.
+const thumbnailCols, thumbnailRows = 24, 7
+
+// ThumbnailSize returns the size in cells that talk thumbnails are
+// converted to braille at.
+func ThumbnailSize() (cols, rows int) {
+	return thumbnailCols, thumbnailRows
+}
+
+// clearTalksCopyMsg is sent after a delay to clear the copy feedback text.
+type clearTalksCopyMsg struct{}
+
+// TalksSection lists the owner's talks from talks.json, newest first, each
+// with its recording and slides and an optional braille thumbnail. Enter
+// copies the selected talk's link, as on the work section.
+type TalksSection struct {
+	content      *content.Content
+	theme        app.Theme
+	locale       *i18n.Locale
+	viewport     app.Viewport
+	width        int
+	height       int
+	focused      bool
+	cursor       int
+	copyFeedback string
+	talkOffsets  []int // line offset of each talk in the rendered content
+	picker       itemPicker
+	marks        itemMarks
+	// thumbnails is braille art by the thumbnail path talks.json gives.
+	thumbnails map[string]string
+}
+
+// NewTalksSection creates a new talks section from the loaded content.
+func NewTalksSection(c *content.Content, theme app.Theme) *TalksSection {
+	return &TalksSection{
+		content: c,
+		theme:   theme,
+		picker:  newItemPicker(),
+	}
+}
+
+// SetThumbnails shows art, braille made from the talks' thumbnail images
+// by their paths, beside the talks. Talks without art show none.
+func (t *TalksSection) SetThumbnails(art map[string]string) {
+	t.thumbnails = art
+}
+
+// SetItemNumbers implements app.ItemNumberer.
+func (t *TalksSection) SetItemNumbers(on bool) {
+	t.picker.shown = on
+	t.viewport.SetContentPreserveScroll(t.renderContent())
+}
+
+// Init implements app.SectionModel.
+func (t *TalksSection) Init() tea.Cmd {
+	return nil
+}
+
+// talks returns the loaded talks, newest first.
+func (t *TalksSection) talks() []content.Talk {
+	if t.content == nil {
+		return nil
+	}
+	return t.content.Talks
+}
+
+// Update implements app.SectionModel.
+func (t *TalksSection) Update(msg tea.Msg) (app.SectionModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		t.width = msg.Width
+		t.height = msg.Height
+		t.viewport.SetSize(t.width, t.height)
+		t.viewport.SetContentPreserveScroll(t.renderContent())
+
+	case tea.KeyMsg:
+		if !t.focused {
+			break
+		}
+		count := len(t.talks())
+		if i, activate, ok := t.picker.pick(msg, count); ok {
+			if i < 0 {
+				break
+			}
+			if activate {
+				return t, t.copySelected()
+			}
+			t.moveCursor(i - t.cursor)
+			break
+		}
+		switch msg.String() {
+		case "j", "down":
+			t.moveCursor(1)
+		case "k", "up":
+			t.moveCursor(-1)
+		case "h", "left":
+			t.viewport.ScrollLeft(columnStep)
+		case "l", "right":
+			t.viewport.ScrollRight(columnStep)
+		case "g", "home":
+			t.cursor = 0
+			t.viewport.SetContent(t.renderContent())
+			t.viewport.ScrollToTop()
+		case "G", "end":
+			t.cursor = max(0, count-1)
+			t.viewport.SetContent(t.renderContent())
+			t.viewport.ScrollToBottom()
+		case " ":
+			if count > 0 {
+				t.marks.toggle(t.cursor)
+				t.viewport.SetContentPreserveScroll(t.renderContent())
+			}
+		case "esc":
+			if len(t.marks) > 0 {
+				t.marks = nil
+				t.viewport.SetContentPreserveScroll(t.renderContent())
+			}
+		case "enter":
+			if len(t.marks) > 0 {
+				return t, t.copyMarked()
+			}
+			return t, t.copySelected()
+		case "pgup":
+			return t, t.viewport.ScrollByAnimated(-t.viewport.VisibleLines())
+		case "pgdown":
+			return t, t.viewport.ScrollByAnimated(t.viewport.VisibleLines())
+		case "ctrl+u":
+			return t, t.viewport.ScrollByAnimated(-t.viewport.VisibleLines() / 2)
+		case "ctrl+d":
+			return t, t.viewport.ScrollByAnimated(t.viewport.VisibleLines() / 2)
+		}
+
+	case clearTalksCopyMsg:
+		t.copyFeedback = ""
+		t.viewport.SetContent(t.renderContent())
+
+	case tea.MouseMsg:
+		if !t.focused {
+			break
+		}
+		if leftClick(msg) {
+			return t, t.click(msg.Y)
+		}
+		switch msg.Button {
+		case tea.MouseButtonWheelUp:
+			t.moveCursor(-1)
+		case tea.MouseButtonWheelDown:
+			t.moveCursor(1)
+		}
+
+	case app.AnimationTickMsg:
+		return t, t.viewport.Animate(msg)
+
+	case app.ThemeChangedMsg:
+		t.theme = msg.Theme
+		t.viewport.SetContentPreserveScroll(t.renderContent())
+
+	case app.LocaleChangedMsg:
+		t.locale = msg.Locale
+		t.viewport.SetContentPreserveScroll(t.renderContent())
+
+	case app.ContentChangedMsg:
+		t.content = msg.Content
+		t.viewport.SetContentPreserveScroll(t.renderContent())
+		t.moveCursor(0)
+
+	case app.FocusMsg:
+		t.focused = true
+		t.cursor = 0
+		t.viewport.SetContent(t.renderContent())
+		t.viewport.ScrollToTop()
+
+	case app.BlurMsg:
+		t.focused = false
+	}
+	return t, nil
+}
+
+// View implements app.SectionModel.
+func (t *TalksSection) View() string {
+	return t.viewport.ViewWithScrollbar(t.theme)
+}
+
+// ScrollInfo implements app.ScrollReporter for the status bar scroll indicator.
+func (t *TalksSection) ScrollInfo() app.ScrollInfo {
+	return t.viewport.GetScrollInfo()
+}
+
+// ScrollToLine implements app.ScrollDragger.
+func (t *TalksSection) ScrollToLine(line int) {
+	t.viewport.SetYOffset(line)
+}
+
+// Position implements app.PositionRestorer with the selected talk.
+func (t *TalksSection) Position() int {
+	return t.cursor
+}
+
+// RestorePosition implements app.PositionRestorer, selecting talk pos and
+// scrolling it into view.
+func (t *TalksSection) RestorePosition(pos int) {
+	t.moveCursor(pos - t.cursor)
+}
+
+// KeyHints implements app.KeyHinter for contextual status bar hints.
+func (t *TalksSection) KeyHints() []app.KeyHint {
+	if t.copyFeedback != "" {
+		return app.Feedback(t.copyFeedback)
+	}
+	if len(t.marks) > 0 {
+		return marksHints(t.locale)
+	}
+	if t.picker.shown {
+		return pickerHints(t.locale)
+	}
+	return []app.KeyHint{navigateHint(t.locale), app.Hint(t.locale.T("hint.copy.link"), app.KeySelect), app.HelpHint(t.locale)}
+}
+
+// links returns the link copied for each talk, by position.
+func (t *TalksSection) links() []string {
+	talks := t.talks()
+	links := make([]string, len(talks))
+	for i, talk := range talks {
+		links[i] = talk.Link()
+	}
+	return links
+}
+
+// copySelected copies the selected talk's link to the clipboard and shows
+// feedback until a tick clears it.
+func (t *TalksSection) copySelected() tea.Cmd {
+	talks := t.talks()
+	if t.cursor >= len(talks) {
+		return nil
+	}
+	link := talks[t.cursor].Link()
+	if link == "" {
+		return nil
+	}
+	return t.copied(app.CopyToClipboard(link), t.locale.T("feedback.copied"))
+}
+
+// copyMarked copies the links of every marked talk at once and clears the
+// marks.
+func (t *TalksSection) copyMarked() tea.Cmd {
+	cmd, feedback := t.marks.copy(t.links(), t.locale)
+	if cmd == nil {
+		return nil
+	}
+	t.marks = nil
+	return t.copied(cmd, feedback)
+}
+
+// copied shows feedback for the clipboard write cmd until a tick clears it.
+func (t *TalksSection) copied(cmd tea.Cmd, feedback string) tea.Cmd {
+	t.copyFeedback = feedback
+	t.viewport.SetContent(t.renderContent())
+	return tea.Batch(
+		cmd,
+		tea.Tick(2*time.Second, func(time.Time) tea.Msg {
+			return clearTalksCopyMsg{}
+		}),
+	)
+}
+
+// click selects the talk on row of the view, or copies its link when it
+// is selected already.
+func (t *TalksSection) click(row int) tea.Cmd {
+	line, ok := t.viewport.LineAt(row)
+	i := itemAt(t.talkOffsets, line)
+	if !ok || i < 0 || i >= len(t.talks()) {
+		return nil
+	}
+	if i == t.cursor {
+		return t.copySelected()
+	}
+	t.moveCursor(i - t.cursor)
+	return nil
+}
+
+// moveCursor moves the selection by delta and scrolls the selected talk
+// into view.
+func (t *TalksSection) moveCursor(delta int) {
+	count := len(t.talks())
+	if count == 0 {
+		return
+	}
+	t.cursor = min(max(t.cursor+delta, 0), count-1)
+	t.viewport.SetContent(t.renderContent())
+	if t.cursor < len(t.talkOffsets) && t.viewport.TotalLines() > t.viewport.VisibleLines() {
+		t.viewport.ScrollToTop()
+		t.viewport.ScrollDown(t.talkOffsets[t.cursor])
+	}
+}
+
+// renderContent builds the talk list for the viewport.
+func (t *TalksSection) renderContent() string {
+	talks := t.talks()
+	if len(talks) == 0 {
+		return app.EmptyState(t.theme, t.locale.T("talks.none"), t.locale.T("talks.none.hint"), t.viewport.ContentWidth())
+	}
+	contentWidth := min(max(t.viewport.ContentWidth(), 10), 78)
+
+	var b strings.Builder
+	blank := app.PadRight("", contentWidth)
+	b.WriteString(blank)
+	lineCount := 1
+	t.talkOffsets = t.talkOffsets[:0]
+	for i, talk := range talks {
+		t.talkOffsets = append(t.talkOffsets, lineCount)
+		labels := t.marks.label(t.theme, i) + t.picker.label(t.theme, i)
+		rendered := app.PadLinesToWidth(t.renderTalk(talk, contentWidth, i == t.cursor, labels), contentWidth)
+		b.WriteString("\n" + rendered)
+		lineCount += strings.Count(rendered, "\n") + 1
+		if i < len(talks)-1 {
+			b.WriteString("\n" + blank)
+			lineCount++
+		}
+	}
+	return b.String()
+}
+
+// renderTalk formats one talk: the title with its date on the right, then
+// the event, and beneath them the links, beside the thumbnail when there
+// is one and room for it.
+func (t *TalksSection) renderTalk(talk content.Talk, width int, selected bool, labels string) string {
+	prefix := "  "
+	if selected {
+		prefix = t.theme.Accent.Render("▸") + " "
+	}
+	title := prefix + labels + t.theme.Accent.Render(talk.Title)
+	date := talk.Date
+	if d, err := time.Parse(time.DateOnly, talk.Date); err == nil {
+		date = d.Format(noteDateFormat)
+	}
+	date = t.theme.Muted.Render(date)
+	gap := max(2, width-lipgloss.Width(title)-lipgloss.Width(date))
+	lines := []string{title + strings.Repeat(" ", gap) + date}
+
+	const indent = "    "
+	var details []string
+	if talk.Event != "" {
+		details = append(details, t.theme.Body.Render(talk.Event))
+	}
+	art := t.thumbnails[talk.Thumbnail]
+	textWidth := width - len(indent)
+	if art != "" && textWidth >= thumbnailCols+2+20 {
+		textWidth -= thumbnailCols + 2
+	} else {
+		art = ""
+	}
+	for _, link := range []struct{ label, url string }{{"video ", talk.Video}, {"slides", talk.Slides}} {
+		if link.url == "" {
+			continue
+		}
+		url := app.TruncateWithEllipsis(link.url, textWidth-len(link.label)-1)
+		details = append(details, t.theme.Muted.Render(link.label+" ")+app.RenderHyperlink(link.url, t.theme.Muted.Render(url)))
+	}
+
+	if art == "" {
+		for _, d := range details {
+			lines = append(lines, indent+d)
+		}
+		return strings.Join(lines, "\n")
+	}
+	// The details run down the right of the thumbnail.
+	rows := strings.Split(art, "\n")
+	for i := range max(len(rows), len(details)) {
+		row := ""
+		if i < len(rows) {
+			row = rows[i]
+		}
+		line := indent + t.theme.Muted.Render(app.PadRight(row, thumbnailCols))
+		if i < len(details) {
+			line += "  " + details[i]
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
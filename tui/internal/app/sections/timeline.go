@@ -0,0 +1,140 @@
+package sections
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/app"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/content"
+)
+
+// timelineMinWidth is the narrowest content width the CV timeline is drawn
+// at. Narrower terminals get the single-column layout, since the gutter
+// would leave too little room for the bullets.
+const timelineMinWidth = 50
+
+// timelineGutter is the width of what comes before an entry's text: the
+// indent, the year, and the connector.
+const timelineGutter = 10
+
+// timelineEntry is one experience or education entry on the CV timeline.
+type timelineEntry struct {
+	year    string // the year it started, or "" when it has none
+	title   string // role or degree
+	place   string // company or institution
+	dates   string
+	bullets []string
+}
+
+// timelineEntries merges the CV's experience and education into one list,
+// newest first by the year each started. Entries starting the same year
+// keep their order, experience before education, and those without a year
+// go last.
+func timelineEntries(cv content.CV) []timelineEntry {
+	var entries []timelineEntry
+	for _, exp := range cv.Experience {
+		dates := exp.Start
+		if exp.End != "" {
+			dates += " - " + exp.End
+		}
+		entries = append(entries, timelineEntry{
+			year:    leadingYear(exp.Start),
+			title:   exp.Role,
+			place:   exp.Company,
+			dates:   dates,
+			bullets: exp.Bullets,
+		})
+	}
+	for _, edu := range cv.Education {
+		entries = append(entries, timelineEntry{
+			year:  leadingYear(edu.Year),
+			title: edu.Degree,
+			place: edu.Institution,
+			dates: edu.Year,
+		})
+	}
+	slices.SortStableFunc(entries, func(a, b timelineEntry) int {
+		switch {
+		case a.year == b.year:
+			return 0
+		case a.year == "":
+			return 1
+		case b.year == "":
+			return -1
+		}
+		return strings.Compare(b.year, a.year)
+	})
+	return entries
+}
+
+// leadingYear returns the four-digit year s starts with, such as 2021 for
+// 2021-03, or "" when it starts with none.
+func leadingYear(s string) string {
+	if len(s) < 4 {
+		return ""
+	}
+	for _, r := range s[:4] {
+		if r < '0' || r > '9' {
+			return ""
+		}
+	}
+	return s[:4]
+}
+
+// renderTimeline draws the CV's experience and education as a vertical
+// timeline: each entry hangs off a line of box-drawing characters, with
+// the year it started in a gutter on the left. A year is shown once, by
+// the first entry starting in it.
+func (s *CVSection) renderTimeline(contentWidth int) string {
+	accentStyle := s.theme.NewStyle().Foreground(s.theme.Colors.Accent).Bold(true)
+	bodyStyle := s.theme.Body
+	mutedStyle := s.theme.Muted
+
+	var b strings.Builder
+	b.WriteByte('\n')
+	b.WriteString(s.sectionDivider("TIMELINE"))
+	b.WriteString("\n\n")
+
+	entries := timelineEntries(s.content.CV)
+	shown := ""
+	for i, e := range entries {
+		last := i == len(entries)-1
+		node, rail := "├─ ", "│  "
+		switch {
+		case last && i == 0:
+			node, rail = "── ", "   "
+		case i == 0:
+			node = "┌─ "
+		case last:
+			node, rail = "└─ ", "   "
+		}
+		year := "    "
+		if e.year != shown {
+			year, shown = accentStyle.Render(e.year), e.year
+		}
+		left := "  " + year + " " + mutedStyle.Render(node) + accentStyle.Render(e.title)
+		if e.place != "" {
+			left += mutedStyle.Render(" @ " + e.place)
+		}
+		dates := mutedStyle.Render(e.dates)
+		gap := max(2, contentWidth-lipgloss.Width(left)-lipgloss.Width(dates))
+		b.WriteString(left + strings.Repeat(" ", gap) + dates + "\n")
+
+		gutter := strings.Repeat(" ", timelineGutter-3) + mutedStyle.Render(rail)
+		for _, bullet := range e.bullets {
+			wrapped := app.WrapMarked(s.theme, bodyStyle, bullet, contentWidth-timelineGutter-2)
+			for j, line := range wrapped {
+				if j == 0 {
+					b.WriteString(gutter + bodyStyle.Render("- ") + line + "\n")
+				} else {
+					b.WriteString(gutter + "  " + line + "\n")
+				}
+			}
+		}
+		if !last {
+			b.WriteString(gutter + "\n")
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,223 @@
+package sections
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/app"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/content"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/i18n"
+)
+
+// UsesSection renders the owner's setup from uses.json as a /uses page:
+// categories down the left like the CV's skills, each tool beside its
+// category with what it is used for.
+type UsesSection struct {
+	content  *content.Content
+	theme    app.Theme
+	locale   *i18n.Locale
+	viewport app.Viewport
+	width    int
+	height   int
+	focused  bool
+	memo     renderMemo[usesRenderKey]
+}
+
+// usesRenderKey is everything the uses page is rendered from.
+type usesRenderKey struct {
+	content *content.Content
+	colors  app.Colors
+	width   int
+}
+
+// NewUsesSection creates a new uses section from the loaded content.
+func NewUsesSection(c *content.Content, theme app.Theme) *UsesSection {
+	return &UsesSection{
+		content: c,
+		theme:   theme,
+	}
+}
+
+// Init implements app.SectionModel.
+func (s *UsesSection) Init() tea.Cmd {
+	return nil
+}
+
+// Update implements app.SectionModel.
+func (s *UsesSection) Update(msg tea.Msg) (app.SectionModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		s.width = msg.Width
+		s.height = msg.Height
+		s.viewport.SetSize(s.width, s.height)
+		s.viewport.SetContentPreserveScroll(s.renderContent())
+
+	case tea.KeyMsg:
+		if !s.focused {
+			break
+		}
+		switch msg.String() {
+		case "j", "down":
+			s.viewport.ScrollDown(1)
+		case "k", "up":
+			s.viewport.ScrollUp(1)
+		case "h", "left":
+			s.viewport.ScrollLeft(columnStep)
+		case "l", "right":
+			s.viewport.ScrollRight(columnStep)
+		case "g", "home":
+			return s, s.viewport.ScrollToAnimated(0)
+		case "G", "end":
+			return s, s.viewport.ScrollToAnimated(s.viewport.TotalLines())
+		case "pgup":
+			return s, s.viewport.ScrollByAnimated(-s.viewport.VisibleLines())
+		case "pgdown":
+			return s, s.viewport.ScrollByAnimated(s.viewport.VisibleLines())
+		case "ctrl+u":
+			return s, s.viewport.ScrollByAnimated(-s.viewport.VisibleLines() / 2)
+		case "ctrl+d":
+			return s, s.viewport.ScrollByAnimated(s.viewport.VisibleLines() / 2)
+		}
+
+	case tea.MouseMsg:
+		if !s.focused {
+			break
+		}
+		switch msg.Button {
+		case tea.MouseButtonWheelUp:
+			s.viewport.ScrollUp(3)
+		case tea.MouseButtonWheelDown:
+			s.viewport.ScrollDown(3)
+		}
+
+	case app.AnimationTickMsg:
+		return s, s.viewport.Animate(msg)
+
+	case app.ThemeChangedMsg:
+		s.theme = msg.Theme
+		s.viewport.SetContentPreserveScroll(s.renderContent())
+
+	case app.LocaleChangedMsg:
+		s.locale = msg.Locale
+
+	case app.ContentChangedMsg:
+		s.content = msg.Content
+		s.viewport.SetContentPreserveScroll(s.renderContent())
+
+	case app.FocusMsg:
+		s.focused = true
+		s.viewport.ScrollToTop()
+
+	case app.BlurMsg:
+		s.focused = false
+	}
+	return s, nil
+}
+
+// View implements app.SectionModel.
+func (s *UsesSection) View() string {
+	return s.viewport.ViewWithScrollbar(s.theme)
+}
+
+// ScrollInfo implements app.ScrollReporter for the status bar scroll indicator.
+func (s *UsesSection) ScrollInfo() app.ScrollInfo {
+	return s.viewport.GetScrollInfo()
+}
+
+// Position implements app.PositionRestorer with the scroll offset.
+func (s *UsesSection) Position() int {
+	return s.viewport.YOffset()
+}
+
+// RestorePosition implements app.PositionRestorer.
+func (s *UsesSection) RestorePosition(pos int) {
+	s.viewport.SetYOffset(pos)
+}
+
+// KeyHints implements app.KeyHinter.
+func (s *UsesSection) KeyHints() []app.KeyHint {
+	return []app.KeyHint{scrollHint(s.locale), pageHint(s.locale), app.Hint(s.locale.T("hint.follow"), app.KeyLinkHints), app.HelpHint(s.locale)}
+}
+
+// renderContent returns the page, building it only when something it
+// depends on has changed.
+func (s *UsesSection) renderContent() string {
+	key := usesRenderKey{
+		content: s.content,
+		colors:  s.theme.Colors,
+		width:   s.viewport.ContentWidth(),
+	}
+	return s.memo.render(key, s.buildContent)
+}
+
+// buildContent lays the tools out in three columns: category, name, and
+// description. A tool whose name overflows its column, or every tool on a
+// terminal too narrow for three columns, has its description on the
+// lines below its name.
+func (s *UsesSection) buildContent() string {
+	if s.content == nil || len(s.content.Uses) == 0 {
+		return ""
+	}
+	contentWidth := max(s.viewport.ContentWidth(), 10)
+	divider := s.theme.NewStyle().
+		Background(s.theme.Colors.Accent).
+		Foreground(s.theme.Colors.Bg).
+		Bold(true).
+		Render(" USES ")
+
+	catWidth, nameWidth := 0, 0
+	for _, cat := range s.content.Uses {
+		catWidth = max(catWidth, lipgloss.Width(cat.Category))
+		for _, item := range cat.Items {
+			nameWidth = max(nameWidth, lipgloss.Width(item.Name))
+		}
+	}
+	// Names get up to half of what the category leaves, so a long one
+	// does not squeeze every description.
+	nameWidth = min(nameWidth, max(10, (contentWidth-catWidth-4)/2))
+	descWidth := contentWidth - catWidth - nameWidth - 6
+	// Descriptions below their names line up under the names.
+	belowWidth := max(10, contentWidth-catWidth-6)
+
+	var b strings.Builder
+	b.WriteString("\n" + divider + "\n\n")
+	for i, cat := range s.content.Uses {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		for j, item := range cat.Items {
+			label := ""
+			if j == 0 {
+				label = cat.Category
+			}
+			b.WriteString("  " + s.theme.Accent.Render(app.PadRight(label, catWidth)) + "  ")
+			name := s.theme.Body.Bold(true).Render(item.Name)
+			if item.URL != "" {
+				name = app.RenderHyperlink(item.URL, name)
+			}
+			b.WriteString(name)
+			if item.Description == "" {
+				b.WriteByte('\n')
+				continue
+			}
+			indent := strings.Repeat(" ", catWidth+4)
+			if w := lipgloss.Width(item.Name); descWidth >= 10 && w <= nameWidth {
+				for k, line := range app.WrapText(item.Description, descWidth) {
+					if k > 0 {
+						b.WriteString(indent + strings.Repeat(" ", nameWidth))
+					} else {
+						b.WriteString(strings.Repeat(" ", nameWidth-w))
+					}
+					b.WriteString("  " + s.theme.Muted.Render(line) + "\n")
+				}
+				continue
+			}
+			b.WriteByte('\n')
+			for _, line := range app.WrapText(item.Description, belowWidth) {
+				b.WriteString(indent + "  " + s.theme.Muted.Render(line) + "\n")
+			}
+		}
+	}
+	return app.PadLinesToWidth(b.String(), contentWidth)
+}
@@ -1,31 +1,56 @@
 package sections
 
 import (
-	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/buntingszn/terminal-portfolio/tui/internal/app"
 	"github.com/buntingszn/terminal-portfolio/tui/internal/content"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/i18n"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/repostats"
 )
 
+// RepoStats supplies the live stats shown on projects with a repo. The
+// server implements it with a repostats.Fetcher.
+type RepoStats interface {
+	Stats(repoURL string) (repostats.Stats, bool)
+}
+
 // clearWorkCopyMsg is sent after a delay to clear the copy feedback text.
 type clearWorkCopyMsg struct{}
 
 // WorkSection displays the projects list sorted featured-first.
 type WorkSection struct {
-	content          *content.Content
-	theme            app.Theme
-	viewport         app.Viewport
-	width            int
-	height           int
-	focused          bool
-	cursor           int
-	copyFeedback     string
-	pendingClipboard string
-	projectOffsets   []int    // line offset for each project in rendered content
-	projectURLs      []string // URL for each project (URL or Repo)
+	content        *content.Content
+	theme          app.Theme
+	locale         *i18n.Locale
+	viewport       app.Viewport
+	width          int
+	height         int
+	focused        bool
+	cursor         int
+	copyFeedback   string
+	projectOffsets []int    // line offset for each project in rendered content
+	projectURLs    []string // URL for each project (URL or Repo)
+	review         bool     // render placeholders for missing optional fields
+	picker         itemPicker
+	marks          itemMarks
+	repoStats      RepoStats
+	blocks         blockCache[workBlockKey] // padded project blocks
+}
+
+// workBlockKey is everything one project's block is rendered from.
+type workBlockKey struct {
+	content  *content.Content
+	colors   app.Colors
+	width    int
+	review   bool
+	project  int
+	selected bool
+	labels   string
+	badges   string
 }
 
 // NewWorkSection creates a new work section from the loaded content.
@@ -33,9 +58,27 @@ func NewWorkSection(c *content.Content, theme app.Theme) *WorkSection {
 	return &WorkSection{
 		content: c,
 		theme:   theme,
+		picker:  newItemPicker(),
 	}
 }
 
+// SetRepoStats shows badges from src on projects with a repo. A nil src
+// shows none.
+func (w *WorkSection) SetRepoStats(src RepoStats) {
+	w.repoStats = src
+}
+
+// SetItemNumbers implements app.ItemNumberer.
+func (w *WorkSection) SetItemNumbers(on bool) {
+	w.picker.shown = on
+	w.viewport.SetContentPreserveScroll(w.renderContent())
+}
+
+// SetContentReview implements app.ContentReviewer.
+func (w *WorkSection) SetContentReview(on bool) {
+	w.review = on
+}
+
 // Init implements app.SectionModel.
 func (w *WorkSection) Init() tea.Cmd {
 	return nil
@@ -43,10 +86,6 @@ func (w *WorkSection) Init() tea.Cmd {
 
 // Update implements app.SectionModel.
 func (w *WorkSection) Update(msg tea.Msg) (app.SectionModel, tea.Cmd) {
-	// Clear pending clipboard after each render cycle so the OSC 52
-	// sequence is emitted exactly once.
-	w.pendingClipboard = ""
-
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		w.width = msg.Width
@@ -59,6 +98,16 @@ func (w *WorkSection) Update(msg tea.Msg) (app.SectionModel, tea.Cmd) {
 		if !w.focused {
 			return w, nil
 		}
+		if i, activate, ok := w.picker.pick(msg, len(w.projectURLs)); ok {
+			if i < 0 {
+				return w, nil
+			}
+			if activate {
+				return w, w.copySelected()
+			}
+			w.moveCursor(i - w.cursor)
+			return w, nil
+		}
 		switch msg.String() {
 		case "j", "down":
 			w.moveCursor(1)
@@ -66,6 +115,12 @@ func (w *WorkSection) Update(msg tea.Msg) (app.SectionModel, tea.Cmd) {
 		case "k", "up":
 			w.moveCursor(-1)
 			return w, nil
+		case "h", "left":
+			w.viewport.ScrollLeft(columnStep)
+			return w, nil
+		case "l", "right":
+			w.viewport.ScrollRight(columnStep)
+			return w, nil
 		case "g", "home":
 			w.cursor = 0
 			w.viewport.SetContent(w.renderContent())
@@ -78,31 +133,31 @@ func (w *WorkSection) Update(msg tea.Msg) (app.SectionModel, tea.Cmd) {
 			w.viewport.SetContent(w.renderContent())
 			w.viewport.ScrollToBottom()
 			return w, nil
+		case " ":
+			if len(w.projectURLs) > 0 {
+				w.marks.toggle(w.cursor)
+				w.viewport.SetContentPreserveScroll(w.renderContent())
+			}
+			return w, nil
+		case "esc":
+			if len(w.marks) > 0 {
+				w.marks = nil
+				w.viewport.SetContentPreserveScroll(w.renderContent())
+			}
+			return w, nil
 		case "enter":
-			if len(w.projectURLs) > 0 && w.cursor < len(w.projectURLs) {
-				url := w.projectURLs[w.cursor]
-				if url == "" {
-					break
-				}
-				w.pendingClipboard = app.OSC52Sequence(url)
-				w.copyFeedback = "Copied!"
-				w.viewport.SetContent(w.renderContent())
-				return w, tea.Tick(2*time.Second, func(time.Time) tea.Msg {
-					return clearWorkCopyMsg{}
-				})
+			if len(w.marks) > 0 {
+				return w, w.copyMarked()
 			}
+			return w, w.copySelected()
 		case "pgup":
-			w.viewport.ScrollUp(w.viewport.VisibleLines())
-			return w, nil
+			return w, w.viewport.ScrollByAnimated(-w.viewport.VisibleLines())
 		case "pgdown":
-			w.viewport.ScrollDown(w.viewport.VisibleLines())
-			return w, nil
+			return w, w.viewport.ScrollByAnimated(w.viewport.VisibleLines())
 		case "ctrl+u":
-			w.viewport.ScrollUp(w.viewport.VisibleLines() / 2)
-			return w, nil
+			return w, w.viewport.ScrollByAnimated(-w.viewport.VisibleLines() / 2)
 		case "ctrl+d":
-			w.viewport.ScrollDown(w.viewport.VisibleLines() / 2)
-			return w, nil
+			return w, w.viewport.ScrollByAnimated(w.viewport.VisibleLines() / 2)
 		}
 
 	case clearWorkCopyMsg:
@@ -114,6 +169,9 @@ func (w *WorkSection) Update(msg tea.Msg) (app.SectionModel, tea.Cmd) {
 		if !w.focused {
 			return w, nil
 		}
+		if leftClick(msg) {
+			return w, w.click(msg.Y)
+		}
 		switch msg.Button {
 		case tea.MouseButtonWheelUp:
 			w.moveCursor(-1)
@@ -122,6 +180,23 @@ func (w *WorkSection) Update(msg tea.Msg) (app.SectionModel, tea.Cmd) {
 		}
 		return w, nil
 
+	case app.AnimationTickMsg:
+		return w, w.viewport.Animate(msg)
+
+	case app.ThemeChangedMsg:
+		w.theme = msg.Theme
+		w.viewport.SetContentPreserveScroll(w.renderContent())
+
+	case app.LocaleChangedMsg:
+		w.locale = msg.Locale
+		w.viewport.SetContentPreserveScroll(w.renderContent())
+
+	case app.ContentChangedMsg:
+		// A translation may list fewer projects; keep the cursor on one.
+		w.content = msg.Content
+		w.viewport.SetContentPreserveScroll(w.renderContent())
+		w.moveCursor(0)
+
 	case app.FocusMsg:
 		w.focused = true
 		w.cursor = 0
@@ -139,7 +214,7 @@ func (w *WorkSection) Update(msg tea.Msg) (app.SectionModel, tea.Cmd) {
 
 // View implements app.SectionModel.
 func (w *WorkSection) View() string {
-	return w.pendingClipboard + w.viewport.ViewWithScrollbar(w.theme)
+	return w.viewport.ViewWithScrollbar(w.theme)
 }
 
 // ScrollInfo implements app.ScrollReporter for the status bar scroll indicator.
@@ -147,12 +222,85 @@ func (w *WorkSection) ScrollInfo() app.ScrollInfo {
 	return w.viewport.GetScrollInfo()
 }
 
+// ScrollToLine implements app.ScrollDragger.
+func (w *WorkSection) ScrollToLine(line int) {
+	w.viewport.SetYOffset(line)
+}
+
+// Position implements app.PositionRestorer with the selected item.
+func (w *WorkSection) Position() int {
+	return w.cursor
+}
+
+// RestorePosition implements app.PositionRestorer, selecting item pos and
+// scrolling it into view.
+func (w *WorkSection) RestorePosition(pos int) {
+	w.moveCursor(pos - w.cursor)
+}
+
+// copySelected copies the selected project's URL to the clipboard and
+// shows feedback until a tick clears it.
+func (w *WorkSection) copySelected() tea.Cmd {
+	if w.cursor >= len(w.projectURLs) {
+		return nil
+	}
+	url := w.projectURLs[w.cursor]
+	if url == "" {
+		return nil
+	}
+	return w.copied(app.CopyToClipboard(url), w.locale.T("feedback.copied"))
+}
+
+// click selects the project on row of the view, or copies its URL when
+// it is selected already.
+func (w *WorkSection) click(row int) tea.Cmd {
+	line, ok := w.viewport.LineAt(row)
+	i := itemAt(w.projectOffsets, line)
+	if !ok || i < 0 || i >= len(w.projectURLs) {
+		return nil
+	}
+	if i == w.cursor {
+		return w.copySelected()
+	}
+	w.moveCursor(i - w.cursor)
+	return nil
+}
+
+// copyMarked copies the URLs of every marked project at once and clears
+// the marks.
+func (w *WorkSection) copyMarked() tea.Cmd {
+	cmd, feedback := w.marks.copy(w.projectURLs, w.locale)
+	if cmd == nil {
+		return nil
+	}
+	w.marks = nil
+	return w.copied(cmd, feedback)
+}
+
+// copied shows feedback for the clipboard write cmd until a tick clears it.
+func (w *WorkSection) copied(cmd tea.Cmd, feedback string) tea.Cmd {
+	w.copyFeedback = feedback
+	w.viewport.SetContent(w.renderContent())
+	return tea.Batch(
+		cmd,
+		tea.Tick(2*time.Second, func(time.Time) tea.Msg {
+			return clearWorkCopyMsg{}
+		}),
+	)
+}
+
 // KeyHints implements app.KeyHinter for contextual status bar hints.
-func (w *WorkSection) KeyHints() string {
+func (w *WorkSection) KeyHints() []app.KeyHint {
 	if w.copyFeedback != "" {
-		return w.copyFeedback
+		return app.Feedback(w.copyFeedback)
+	}
+	if len(w.marks) > 0 {
+		return marksHints(w.locale)
 	}
-	return "j/k navigate " + app.BorderVertical + " enter copy URL " + app.BorderVertical + " 1-4 nav " + app.BorderVertical + " ? help"
+	if w.picker.shown {
+		return pickerHints(w.locale)
+	}
+	return []app.KeyHint{navigateHint(w.locale), app.Hint(w.locale.T("hint.copy.url"), app.KeySelect), app.NavHint(w.locale), app.HelpHint(w.locale)}
 }
 
 // moveCursor moves the selection cursor by delta and re-renders.
@@ -186,28 +334,15 @@ func (w *WorkSection) moveCursor(delta int) {
 	}
 }
 
-// sortedProjects returns a copy of projects sorted featured-first (stable).
-func sortedProjects(projects []content.WorkProject) []content.WorkProject {
-	sorted := make([]content.WorkProject, len(projects))
-	copy(sorted, projects)
-	sort.SliceStable(sorted, func(i, j int) bool {
-		if sorted[i].Featured == sorted[j].Featured {
-			return false
-		}
-		return sorted[i].Featured
-	})
-	return sorted
-}
-
 // renderContent builds the full rendered text for the viewport.
 func (w *WorkSection) renderContent() string {
 	if w.content == nil {
-		return w.theme.Muted.Render("No projects loaded.")
+		return app.EmptyState(w.theme, w.locale.T("work.unloaded"), w.locale.T("work.unloaded.hint"), w.viewport.ContentWidth())
 	}
 
-	projects := sortedProjects(w.content.Work.Projects)
+	projects := w.content.Work.FeaturedFirst()
 	if len(projects) == 0 {
-		return w.theme.Muted.Render("No projects to display.")
+		return app.EmptyState(w.theme, w.locale.T("work.none"), w.locale.T("work.none.hint"), w.viewport.ContentWidth())
 	}
 
 	contentWidth := w.viewport.ContentWidth()
@@ -219,22 +354,16 @@ func (w *WorkSection) renderContent() string {
 	}
 
 	var b strings.Builder
+	blank := app.PadRight("", contentWidth)
 
 	// Reset tracking slices.
 	w.projectOffsets = nil
 	w.projectURLs = nil
-	lineCount := 0
-
-	countLines := func(s string) int {
-		if s == "" {
-			return 0
-		}
-		return strings.Count(s, "\n") + 1
-	}
+	w.blocks.next()
 
 	// Top padding.
-	b.WriteByte('\n')
-	lineCount++
+	b.WriteString(blank)
+	lineCount := 1
 
 	for i, p := range projects {
 		w.projectOffsets = append(w.projectOffsets, lineCount)
@@ -244,22 +373,37 @@ func (w *WorkSection) renderContent() string {
 		}
 		w.projectURLs = append(w.projectURLs, url)
 
-		selected := i == w.cursor
-		rendered := w.renderProjectInline(p, contentWidth, selected)
+		key := workBlockKey{
+			content:  w.content,
+			colors:   w.theme.Colors,
+			width:    contentWidth,
+			review:   w.review,
+			project:  i,
+			selected: i == w.cursor,
+			labels:   w.marks.label(w.theme, i) + w.picker.label(w.theme, i),
+			badges:   w.repoBadges(p.Repo),
+		}
+		rendered := w.blocks.block(key, func() string {
+			return app.PadLinesToWidth(w.renderProjectInline(p, contentWidth, key.selected, key.labels), contentWidth)
+		})
+		b.WriteByte('\n')
 		b.WriteString(rendered)
-		lineCount += countLines(rendered)
+		lineCount += strings.Count(rendered, "\n") + 1
 
 		if i < len(projects)-1 {
-			b.WriteString("\n\n")
-			lineCount += 2
+			// One blank line between projects.
+			b.WriteByte('\n')
+			b.WriteString(blank)
+			lineCount++
 		}
 	}
 
-	return app.PadLinesToWidth(b.String(), contentWidth)
+	return b.String()
 }
 
 // renderProjectInline formats a single project: title → description → tags.
-func (w *WorkSection) renderProjectInline(p content.WorkProject, width int, selected bool) string {
+// labels holds the mark and number columns shown before the title.
+func (w *WorkSection) renderProjectInline(p content.WorkProject, width int, selected bool, labels string) string {
 	accentStyle := w.theme.Accent
 	bodyStyle := w.theme.Body
 	mutedStyle := w.theme.Muted
@@ -271,7 +415,7 @@ func (w *WorkSection) renderProjectInline(p content.WorkProject, width int, sele
 	if selected {
 		prefix = accentStyle.Render("▸") + " "
 	}
-	title := prefix + accentStyle.Render(p.Title)
+	title := prefix + labels + accentStyle.Render(p.Title)
 	lines = append(lines, title)
 
 	// Indent for sub-lines (description, tags, URL).
@@ -293,6 +437,13 @@ func (w *WorkSection) renderProjectInline(p content.WorkProject, width int, sele
 	if len(p.Tags) > 0 {
 		tagStr := mutedStyle.Render(strings.Join(p.Tags, " · "))
 		lines = append(lines, indent+tagStr)
+	} else if w.review {
+		lines = append(lines, indent+app.MissingPlaceholder(w.theme, "tags"))
+	}
+
+	// Repo stats: live badges below the tags, once they have been fetched.
+	if badges := w.repoBadges(p.Repo); badges != "" {
+		lines = append(lines, indent+mutedStyle.Render(app.TruncateWithEllipsis(badges, width-len(indent))))
 	}
 
 	// URL: indented, OSC 8 hyperlink, muted.
@@ -307,5 +458,29 @@ func (w *WorkSection) renderProjectInline(p content.WorkProject, width int, sele
 		lines = append(lines, indent+app.RenderHyperlink(p.Repo, mutedStyle.Render(repo)))
 	}
 
+	if p.URL == "" && p.Repo == "" && w.review {
+		lines = append(lines, indent+app.MissingPlaceholder(w.theme, "url/repo"))
+	}
+
 	return strings.Join(lines, "\n")
 }
+
+// repoBadges formats the live stats of repo, such as
+// "★ 12 · Go · updated 3 days ago", or "" when there are none.
+func (w *WorkSection) repoBadges(repo string) string {
+	if w.repoStats == nil || repo == "" {
+		return ""
+	}
+	stats, ok := w.repoStats.Stats(repo)
+	if !ok {
+		return ""
+	}
+	badges := []string{"★ " + strconv.Itoa(stats.Stars)}
+	if stats.Language != "" {
+		badges = append(badges, stats.Language)
+	}
+	if rel := app.RelativeTime(stats.LastCommit, time.Now()); rel != "" {
+		badges = append(badges, "updated "+rel)
+	}
+	return strings.Join(badges, " · ")
+}
@@ -53,9 +53,17 @@ const (
 	shimmerBreathFreq      = 0.010
 )
 
-// shimmerMinBrightness is the threshold below which a cell uses the
-// base color directly, avoiding invisible per-char styling overhead.
-const shimmerMinBrightness = 0.005
+// shimmerLevels is how many steps of brightness the shimmer draws. Next
+// to each other they differ by well under one CIE L* unit, finer than the
+// eye or a 256-color palette can tell apart, so each step's style can be
+// rendered once and reused for every cell and frame.
+const shimmerLevels = 64
+
+// shimmerStyle is the escape sequences that open and close a run of cells
+// at one brightness level.
+type shimmerStyle struct {
+	open, close string
+}
 
 // shimmerTickMsg advances the shimmer animation by one frame.
 type shimmerTickMsg struct {
@@ -68,12 +76,17 @@ type shimmerTickMsg struct {
 // a natural, varied effect with irregular blob sizes.
 type Shimmer struct {
 	id     string
+	theme  Theme // renders the greys
 	active bool
 	frame  int // monotonic frame counter
 
 	// Base and peak lightness (CIE L*) for pure grey output.
 	baseL float64
 	peakL float64
+
+	// styles holds each brightness level's style, from base to peak. It
+	// is shared by copies and rebuilt when the theme changes.
+	styles []shimmerStyle
 }
 
 // greyFromL returns a pure achromatic grey lipgloss.Color for a CIE L* value.
@@ -95,11 +108,32 @@ func shimmerLightness(c lipgloss.Color) float64 {
 
 // NewShimmer creates a Shimmer with default parameters.
 func NewShimmer(id string, theme Theme) Shimmer {
-	return Shimmer{
-		id:    id,
-		baseL: shimmerLightness(theme.Colors.Muted),
-		peakL: shimmerLightness(theme.Colors.Fg),
+	s := Shimmer{id: id}
+	s.SetTheme(theme)
+	return s
+}
+
+// SetTheme rescales the shimmer's brightness range to the theme's muted
+// and foreground colors without interrupting a running animation.
+func (s *Shimmer) SetTheme(theme Theme) {
+	s.theme = theme
+	s.baseL = shimmerLightness(theme.Colors.Muted)
+	s.peakL = shimmerLightness(theme.Colors.Fg)
+	s.styles = shimmerStyles(theme, s.baseL, s.peakL)
+}
+
+// shimmerStyles renders the style of every brightness level between baseL
+// and peakL, split around a placeholder cell so runs of any length can be
+// wrapped in it.
+func shimmerStyles(theme Theme, baseL, peakL float64) []shimmerStyle {
+	const cell = "x"
+	styles := make([]shimmerStyle, shimmerLevels)
+	for i := range styles {
+		l := baseL + (peakL-baseL)*float64(i)/(shimmerLevels-1)
+		before, after, _ := strings.Cut(theme.NewStyle().Foreground(greyFromL(l)).Render(cell), cell)
+		styles[i] = shimmerStyle{open: before, close: after}
 	}
+	return styles
 }
 
 // Start begins the shimmer animation and returns the first tick command.
@@ -109,9 +143,17 @@ func (s *Shimmer) Start() tea.Cmd {
 	return s.tick()
 }
 
-// Stop halts the shimmer animation.
-func (s *Shimmer) Stop() {
+// Stop halts the shimmer animation and returns the command that drops
+// its waiting tick.
+func (s *Shimmer) Stop() tea.Cmd {
 	s.active = false
+	return UnsubscribeAnimation(s.tickID())
+}
+
+// Advance moves the shimmer on by frames without waiting for ticks, for
+// owners that redraw it at their own frame rate.
+func (s *Shimmer) Advance(frames int) {
+	s.frame += frames
 }
 
 // Active returns whether the shimmer is currently animating.
@@ -128,7 +170,8 @@ func (s Shimmer) Update(msg tea.Msg) (Shimmer, tea.Cmd) {
 	return s, nil
 }
 
-// Render applies the shimmer to text, returning per-character styled output.
+// Render applies the shimmer to text, styling each run of cells at one
+// brightness level from the precomputed styles.
 // textWidth is the number of columns in the widest line.
 func (s Shimmer) Render(text string, textWidth int) string {
 	if textWidth <= 0 {
@@ -137,36 +180,43 @@ func (s Shimmer) Render(text string, textWidth int) string {
 
 	lines := strings.Split(text, "\n")
 	var b strings.Builder
-	b.Grow(len(text) * 3)
-
-	baseColor := greyFromL(s.baseL)
-	baseStyle := lipgloss.NewStyle().Foreground(baseColor)
+	b.Grow(len(text) * 8)
 
 	for li, line := range lines {
 		if li > 0 {
 			b.WriteByte('\n')
 		}
+		// Neighboring cells often share a level, so a run of them is
+		// styled once. Each line closes its last run, keeping lines whole
+		// for the viewport.
+		level := -1
 		col := 0
 		for i := 0; i < len(line); {
 			r, size := utf8.DecodeRuneInString(line[i:])
+			cell := line[i : i+size]
 			i += size
+			col++
 
-			// Skip empty Braille (U+2800) — no visual dots to highlight.
+			// Empty Braille (U+2800) has no dots to highlight; it joins
+			// whatever run it is in.
 			if r == '\u2800' {
-				b.WriteRune(r)
-				col++
+				b.WriteString(cell)
 				continue
 			}
 
-			brightness := s.brightnessAt(li, col, textWidth)
-			if brightness > shimmerMinBrightness {
-				l := s.baseL + (s.peakL-s.baseL)*brightness
-				style := lipgloss.NewStyle().Foreground(greyFromL(l))
-				b.WriteString(style.Render(string(r)))
-			} else {
-				b.WriteString(baseStyle.Render(string(r)))
+			brightness := s.brightnessAt(li, col-1, textWidth)
+			l := min(max(int(brightness*(shimmerLevels-1)+0.5), 0), shimmerLevels-1)
+			if l != level {
+				if level >= 0 {
+					b.WriteString(s.styles[level].close)
+				}
+				b.WriteString(s.styles[l].open)
+				level = l
 			}
-			col++
+			b.WriteString(cell)
+		}
+		if level >= 0 {
+			b.WriteString(s.styles[level].close)
 		}
 	}
 
@@ -321,7 +371,12 @@ func lerp(a, b, t float64) float64 {
 // tick returns a tea.Cmd that fires a shimmerTickMsg after one frame interval.
 func (s Shimmer) tick() tea.Cmd {
 	id := s.id
-	return tea.Tick(shimmerTickInterval, func(_ time.Time) tea.Msg {
+	return SubscribeAnimation(s.tickID(), shimmerTickInterval, func(_ time.Time) tea.Msg {
 		return shimmerTickMsg{id: id}
 	})
 }
+
+// tickID is the shimmer's AnimationScheduler subscriber.
+func (s Shimmer) tickID() string {
+	return "shimmer:" + s.id
+}
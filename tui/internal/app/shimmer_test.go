@@ -1,8 +1,12 @@
 package app
 
 import (
+	"io"
 	"strings"
 	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
 )
 
 func TestShimmerNewDefaults(t *testing.T) {
@@ -158,6 +162,32 @@ func TestShimmerOutputIsAchromatic(t *testing.T) {
 	}
 }
 
+func TestShimmerRenderRuns(t *testing.T) {
+	r := lipgloss.NewRenderer(io.Discard)
+	r.SetColorProfile(termenv.TrueColor)
+	s := NewShimmer("test", DarkTheme().ForRenderer(r))
+	s.Start()
+	for range 30 {
+		s, _ = s.Update(shimmerTickMsg{id: "test"})
+	}
+
+	text := "⣿⣿⠀⣿⢿⣿⣿⣿⣿⣿⣿\n⠀⣿⡟⡼⢠⣈⣿⣿⣿⣿⣿"
+	out := s.Render(text, 11)
+	if got := stripANSI(out); got != text {
+		t.Errorf("stripped render = %q, want the text back", got)
+	}
+	// Neighbors at the same level share one style, and every line closes
+	// what it opens.
+	if opens := strings.Count(out, "\x1b[38;2;"); opens == 0 || opens >= 21 {
+		t.Errorf("%d styled runs for 21 cells", opens)
+	}
+	for _, line := range strings.Split(out, "\n") {
+		if strings.Count(line, "\x1b[38;2;") != strings.Count(line, "\x1b[0m") {
+			t.Errorf("line %q leaves a style open", line)
+		}
+	}
+}
+
 func isHexDigits(s string) bool {
 	for _, c := range s {
 		if (c < '0' || c > '9') && (c < 'a' || c > 'f') && (c < 'A' || c > 'F') {
@@ -166,3 +196,20 @@ func isHexDigits(s string) bool {
 	}
 	return true
 }
+
+// BenchmarkShimmerRender renders a portrait-sized block of braille, 14
+// lines of 28 cells like the home section's, a frame at a time, in true
+// color as most sessions get it.
+func BenchmarkShimmerRender(b *testing.B) {
+	r := lipgloss.NewRenderer(io.Discard)
+	r.SetColorProfile(termenv.TrueColor)
+	s := NewShimmer("bench", DarkTheme().ForRenderer(r))
+	s.Start()
+	line := strings.Repeat("⣿⡟⢿⣈", 7)
+	art := strings.TrimSuffix(strings.Repeat(line+"\n", 14), "\n")
+	b.ReportAllocs()
+	for b.Loop() {
+		s, _ = s.Update(shimmerTickMsg{id: "bench"})
+		_ = s.Render(art, 28)
+	}
+}
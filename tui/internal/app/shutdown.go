@@ -0,0 +1,77 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// handleShutdownCountdown records the time left before a restart. The
+// first countdown message opens the overlay; once dismissed, later ones
+// only update the banner under the status bar.
+func (m Model) handleShutdownCountdown(msg ShutdownCountdownMsg) (tea.Model, tea.Cmd) {
+	if !m.shutdownPending {
+		m.shutdownPending = true
+		m.showShutdown = true
+	}
+	m.shutdownRemaining = max(0, msg.Remaining)
+	return m, nil
+}
+
+// shutdownCountdown formats the time left as "restarting in 1:32", or
+// "restarting now" once it has run out.
+func (m Model) shutdownCountdown() string {
+	secs := int(m.shutdownRemaining.Round(time.Second).Seconds())
+	if secs <= 0 {
+		return "restarting now"
+	}
+	return fmt.Sprintf("restarting in %d:%02d", secs/60, secs%60)
+}
+
+// shutdownView renders the countdown overlay: a warning card centered on
+// the screen, like the help and keys overlays.
+func (m Model) shutdownView() string {
+	headline := m.theme.NewStyle().Foreground(m.theme.Colors.Warning).Bold(true).
+		Render("Server " + m.shutdownCountdown())
+	lines := []string{
+		headline,
+		m.theme.Body.Render("Your session will end when the server restarts. Reconnect in a minute to pick up again."),
+		"",
+		m.theme.Muted.Render("Press any key to keep browsing"),
+	}
+	body := strings.Join(lines, "\n")
+
+	cardWidth := 50
+	if m.width > 0 && m.width < cardWidth {
+		cardWidth = m.width
+	}
+	if cardWidth < 10 || m.width < 10 || m.height < 10 {
+		return body
+	}
+	card := RenderWarningCard(m.theme, "Restarting", body, cardWidth)
+	return lipgloss.Place(
+		m.width, m.height,
+		lipgloss.Center, lipgloss.Center,
+		card,
+		lipgloss.WithWhitespaceChars("·"),
+		lipgloss.WithWhitespaceForeground(m.theme.Colors.Border),
+	)
+}
+
+// shutdownBannerView renders the countdown as a one-line banner, shown
+// once the overlay has been dismissed.
+func (m Model) shutdownBannerView() string {
+	style := m.theme.NewStyle().
+		Foreground(m.theme.Colors.Bg).
+		Background(m.theme.Colors.Warning).
+		Bold(true).
+		Padding(0, 1)
+	rendered := style.Render("Server " + m.shutdownCountdown() + " — your session will end")
+	if m.width > 0 {
+		return lipgloss.PlaceHorizontal(m.width, lipgloss.Center, rendered)
+	}
+	return rendered
+}
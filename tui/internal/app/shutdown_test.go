@@ -0,0 +1,43 @@
+package app
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestShutdownCountdown(t *testing.T) {
+	m := skipIntro(t)
+	result, _ := m.Update(ShutdownCountdownMsg{Remaining: 92 * time.Second})
+	m = result.(Model)
+	if !m.showShutdown {
+		t.Fatal("the first countdown should open the overlay")
+	}
+	if view := stripANSI(m.View()); !strings.Contains(view, "restarting in 1:32") || !strings.Contains(view, "session will end") {
+		t.Errorf("overlay = %q", view)
+	}
+
+	// A key dismisses the overlay without reaching the section...
+	result, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'q'}})
+	m = result.(Model)
+	if m.showShutdown {
+		t.Fatal("a key should dismiss the overlay")
+	}
+
+	// ...and later updates count down in a banner instead.
+	result, _ = m.Update(ShutdownCountdownMsg{Remaining: 59*time.Second + 600*time.Millisecond})
+	m = result.(Model)
+	if m.showShutdown {
+		t.Error("later countdowns should not reopen the overlay")
+	}
+	if view := stripANSI(m.View()); !strings.Contains(view, "restarting in 1:00 — your session will end") {
+		t.Errorf("banner missing from view:\n%s", view)
+	}
+
+	result, _ = m.Update(ShutdownCountdownMsg{Remaining: -time.Second})
+	if got := result.(Model).shutdownCountdown(); got != "restarting now" {
+		t.Errorf("countdown at zero = %q", got)
+	}
+}
@@ -0,0 +1,75 @@
+package app
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// scrollAnimationSteps is the frame count of an animated scroll; at one
+// frame per animationTickInterval it takes about 130ms.
+const scrollAnimationSteps = 8
+
+// scrollAnimations numbers animated scrolls, so each gets its own tick ID
+// and the ticks of one that was interrupted are ignored.
+var scrollAnimations atomic.Int64
+
+// scrollAnimation is a running eased scroll from one offset to another.
+type scrollAnimation struct {
+	id       string
+	from, to int
+	step     int
+}
+
+// ScrollToAnimated scrolls to line target, clamped to the content, over a
+// few animation frames instead of at once. The returned command drives the
+// frames; pass their AnimationTickMsgs to Animate. Any other scroll, by a
+// key or new content, interrupts the animation where it is.
+func (v *Viewport) ScrollToAnimated(target int) tea.Cmd {
+	target = max(0, min(target, v.maxOffset()))
+	if target == v.yOffset {
+		v.anim = nil
+		return nil
+	}
+	id := fmt.Sprintf("viewport-scroll-%d", scrollAnimations.Add(1))
+	v.anim = &scrollAnimation{id: id, from: v.yOffset, to: target}
+	return animationTick(id)
+}
+
+// ScrollByAnimated scrolls n lines, down for positive n, like
+// ScrollToAnimated. It counts from where a running animation is headed, so
+// pressing page down twice scrolls two pages however quickly it is done.
+func (v *Viewport) ScrollByAnimated(n int) tea.Cmd {
+	from := v.yOffset
+	if v.anim != nil {
+		from = v.anim.to
+	}
+	return v.ScrollToAnimated(from + n)
+}
+
+// Animate advances the running animated scroll by one frame for its
+// AnimationTickMsg, returning the command for the next one. Ticks of
+// other animations are ignored.
+func (v *Viewport) Animate(msg AnimationTickMsg) tea.Cmd {
+	a := v.anim
+	if a == nil || msg.ID != a.id {
+		return nil
+	}
+	a.step++
+	if a.step >= scrollAnimationSteps {
+		v.yOffset = a.to
+		v.anim = nil
+		v.clampOffset()
+		return nil
+	}
+	eased := easeOut(float64(a.step) / scrollAnimationSteps)
+	v.yOffset = a.from + int(float64(a.to-a.from)*eased+0.5)
+	v.clampOffset()
+	return animationTick(a.id)
+}
+
+// Animating reports whether an animated scroll is running.
+func (v *Viewport) Animating() bool {
+	return v.anim != nil
+}
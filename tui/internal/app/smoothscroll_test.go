@@ -0,0 +1,91 @@
+package app
+
+import (
+	"strings"
+	"testing"
+)
+
+// scrollViewport returns a 10-line viewport over 100 lines.
+func scrollViewport() Viewport {
+	vp := NewViewport(40, 10)
+	vp.SetContent(strings.Repeat("line\n", 99) + "line")
+	return vp
+}
+
+// runAnimation feeds the viewport the ticks cmd asks for until the
+// animation ends, returning the offsets of each frame.
+func runAnimation(t *testing.T, vp *Viewport, id string) []int {
+	t.Helper()
+	var offsets []int
+	for range 2 * scrollAnimationSteps {
+		cmd := vp.Animate(AnimationTickMsg{ID: id})
+		offsets = append(offsets, vp.YOffset())
+		if cmd == nil {
+			return offsets
+		}
+	}
+	t.Fatalf("animation still running after %d frames", len(offsets))
+	return nil
+}
+
+func TestScrollToAnimated(t *testing.T) {
+	vp := scrollViewport()
+	if vp.ScrollToAnimated(40) == nil || vp.YOffset() != 0 || !vp.Animating() {
+		t.Fatal("ScrollToAnimated should return a command and leave the view in place until ticks arrive")
+	}
+	offsets := runAnimation(t, &vp, vp.anim.id)
+	if len(offsets) != scrollAnimationSteps {
+		t.Errorf("animation took %d frames, want %d", len(offsets), scrollAnimationSteps)
+	}
+	for i := 1; i < len(offsets); i++ {
+		if offsets[i] < offsets[i-1] {
+			t.Errorf("offsets %v are not increasing", offsets)
+			break
+		}
+	}
+	if offsets[0] == 0 || offsets[0] >= 40 || vp.YOffset() != 40 || vp.Animating() {
+		t.Errorf("offsets = %v, want eased steps ending at 40", offsets)
+	}
+
+	// The target is clamped, and scrolling to where the view is does not
+	// animate.
+	vp.ScrollToAnimated(1000)
+	runAnimation(t, &vp, vp.anim.id)
+	if vp.YOffset() != vp.maxOffset() {
+		t.Errorf("YOffset = %d, want the bottom %d", vp.YOffset(), vp.maxOffset())
+	}
+	if vp.ScrollToAnimated(1000) != nil {
+		t.Error("scrolling to the current offset should not animate")
+	}
+}
+
+func TestScrollAnimationInterrupted(t *testing.T) {
+	vp := scrollViewport()
+	vp.ScrollToAnimated(40)
+	old := vp.anim.id
+	vp.Animate(AnimationTickMsg{ID: old})
+	mid := vp.YOffset()
+
+	// A direct scroll stops the animation where it is.
+	vp.ScrollDown(1)
+	if vp.Animating() || vp.YOffset() != mid+1 {
+		t.Errorf("after j mid-animation, YOffset = %d and animating %v; want %d, stopped", vp.YOffset(), vp.Animating(), mid+1)
+	}
+	if vp.Animate(AnimationTickMsg{ID: old}) != nil || vp.YOffset() != mid+1 {
+		t.Error("ticks of a stopped animation should be ignored")
+	}
+
+	// A second animated scroll counts from where the first was headed.
+	vp.ScrollToTop()
+	vp.ScrollByAnimated(10)
+	first := vp.anim.id
+	vp.Animate(AnimationTickMsg{ID: first})
+	vp.ScrollByAnimated(10)
+	if vp.Animate(AnimationTickMsg{ID: first}) != nil {
+		t.Error("ticks of the replaced animation should be ignored")
+	}
+	runAnimation(t, &vp, vp.anim.id)
+	if vp.YOffset() != 20 {
+		t.Errorf("two animated scrolls of 10 ended at %d, want 20", vp.YOffset())
+	}
+}
@@ -0,0 +1,202 @@
+package app
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	// snakeInterval is how often the snake moves one cell.
+	snakeInterval = 120 * time.Millisecond
+
+	// The board is at most snakeMaxCols by snakeMaxRows cells, so games
+	// on large terminals still take a while to fill.
+	snakeMaxCols = 32
+	snakeMaxRows = 16
+
+	// snakeStartLength is how many cells the snake starts with.
+	snakeStartLength = 3
+)
+
+// snakePoint is a cell on the board, from the top left.
+type snakePoint struct{ x, y int }
+
+// snakeGame is the classic snake: steer with the arrows, hjkl, or wasd,
+// eat the food to grow, and don't run into the walls or yourself. Each
+// cell is drawn two columns wide so the board looks square.
+type snakeGame struct {
+	theme      Theme
+	cols, rows int
+	rng        *rand.Rand
+
+	body   []snakePoint // head first
+	dir    snakePoint
+	next   snakePoint // direction taken on the next step
+	food   snakePoint
+	score  int
+	over   bool
+	paused bool
+}
+
+// newSnakeGame starts a snake game sized to fit a width by height
+// terminal.
+func newSnakeGame(theme Theme, width, height int, rng *rand.Rand) *snakeGame {
+	g := &snakeGame{
+		theme: theme,
+		cols:  max(snakeStartLength+2, min(snakeMaxCols, (width-4)/2)),
+		rows:  max(3, min(snakeMaxRows, height-5)),
+		rng:   rng,
+	}
+	g.reset()
+	return g
+}
+
+// reset starts a new round: a short snake heading right from the middle.
+func (g *snakeGame) reset() {
+	mid := snakePoint{g.cols / 2, g.rows / 2}
+	g.body = g.body[:0]
+	for i := range snakeStartLength {
+		g.body = append(g.body, snakePoint{mid.x - i, mid.y})
+	}
+	g.dir = snakePoint{1, 0}
+	g.next = g.dir
+	g.score = 0
+	g.over = false
+	g.paused = false
+	g.placeFood()
+}
+
+// placeFood puts the food on a random free cell, ending the game as won
+// when the snake fills the board.
+func (g *snakeGame) placeFood() {
+	free := g.cols*g.rows - len(g.body)
+	if free <= 0 {
+		g.over = true
+		return
+	}
+	n := g.rng.IntN(free)
+	for y := range g.rows {
+		for x := range g.cols {
+			if slices.Contains(g.body, snakePoint{x, y}) {
+				continue
+			}
+			if n == 0 {
+				g.food = snakePoint{x, y}
+				return
+			}
+			n--
+		}
+	}
+}
+
+// Interval implements miniGame.
+func (g *snakeGame) Interval() time.Duration {
+	return snakeInterval
+}
+
+// Key implements miniGame.
+func (g *snakeGame) Key(msg tea.KeyMsg) bool {
+	turn := func(d snakePoint) {
+		// Reversing onto the neck would end the game on the spot.
+		if d.x != -g.dir.x || d.y != -g.dir.y {
+			g.next = d
+		}
+	}
+	switch msg.String() {
+	case "q", "esc", "ctrl+c":
+		return true
+	case "up", "k", "w":
+		turn(snakePoint{0, -1})
+	case "down", "j", "s":
+		turn(snakePoint{0, 1})
+	case "left", "h", "a":
+		turn(snakePoint{-1, 0})
+	case "right", "l", "d":
+		turn(snakePoint{1, 0})
+	case "p", " ":
+		if !g.over {
+			g.paused = !g.paused
+		}
+	case "r", "enter":
+		if g.over {
+			g.reset()
+		}
+	}
+	return false
+}
+
+// Tick implements miniGame: the snake moves one cell.
+func (g *snakeGame) Tick() {
+	if g.over || g.paused {
+		return
+	}
+	g.dir = g.next
+	head := snakePoint{g.body[0].x + g.dir.x, g.body[0].y + g.dir.y}
+	eating := head == g.food
+	// The tail moves out of the way unless the snake grows this step.
+	body := g.body
+	if !eating {
+		body = body[:len(body)-1]
+	}
+	if head.x < 0 || head.x >= g.cols || head.y < 0 || head.y >= g.rows || slices.Contains(body, head) {
+		g.over = true
+		return
+	}
+	g.body = append([]snakePoint{head}, body...)
+	if eating {
+		g.score++
+		g.placeFood()
+	}
+}
+
+// View implements miniGame.
+func (g *snakeGame) View(width, height int) string {
+	border := g.theme.NewStyle().Foreground(g.theme.Colors.Border)
+	snake := g.theme.NewStyle().Foreground(g.theme.Colors.Accent)
+	head := snake.Bold(true)
+	food := g.theme.NewStyle().Foreground(g.theme.Colors.Warning)
+
+	var b strings.Builder
+	title := g.theme.Title.Render("snake") + g.theme.Muted.Render(fmt.Sprintf("  score %d", g.score))
+	b.WriteString(title + "\n")
+	b.WriteString(border.Render(borderTopLeft+strings.Repeat(borderHorizontal, g.cols*2)+borderTopRight) + "\n")
+	for y := range g.rows {
+		b.WriteString(border.Render(borderVertical))
+		for x := range g.cols {
+			p := snakePoint{x, y}
+			switch {
+			case p == g.body[0]:
+				b.WriteString(head.Render("██"))
+			case slices.Contains(g.body, p):
+				b.WriteString(snake.Render("▓▓"))
+			case p == g.food && !g.over:
+				b.WriteString(food.Render("◆ "))
+			default:
+				b.WriteString("  ")
+			}
+		}
+		b.WriteString(border.Render(borderVertical) + "\n")
+	}
+	b.WriteString(border.Render(borderBottomLeft+strings.Repeat(borderHorizontal, g.cols*2)+borderBottomRight) + "\n")
+
+	var hint string
+	switch {
+	case g.over && len(g.body) == g.cols*g.rows:
+		hint = "You filled the board! r to play again · q to leave"
+	case g.over:
+		hint = fmt.Sprintf("Game over with %d. r to play again · q to leave", g.score)
+	case g.paused:
+		hint = "Paused · p to resume · q to leave"
+	default:
+		hint = "arrows/hjkl steer · p pause · q leave"
+	}
+	b.WriteString(g.theme.Muted.Render(hint))
+
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, b.String())
+}
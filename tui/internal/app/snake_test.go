@@ -0,0 +1,114 @@
+package app
+
+import (
+	"math/rand/v2"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func testSnake() *snakeGame {
+	return newSnakeGame(DarkTheme(), 80, 24, rand.New(rand.NewPCG(1, 2)))
+}
+
+func snakeKey(g *snakeGame, key string) bool {
+	msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+	switch key {
+	case "up":
+		msg = tea.KeyMsg{Type: tea.KeyUp}
+	case "down":
+		msg = tea.KeyMsg{Type: tea.KeyDown}
+	case "left":
+		msg = tea.KeyMsg{Type: tea.KeyLeft}
+	case "esc":
+		msg = tea.KeyMsg{Type: tea.KeyEsc}
+	}
+	return g.Key(msg)
+}
+
+func TestSnakeMovesAndTurns(t *testing.T) {
+	g := testSnake()
+	if g.cols != snakeMaxCols || g.rows != snakeMaxRows {
+		t.Fatalf("board = %dx%d, want the %dx%d cap", g.cols, g.rows, snakeMaxCols, snakeMaxRows)
+	}
+	g.food = snakePoint{0, 0}
+	start := g.body[0]
+
+	g.Tick()
+	if g.body[0] != (snakePoint{start.x + 1, start.y}) || len(g.body) != snakeStartLength {
+		t.Errorf("after a step head = %v, length %d", g.body[0], len(g.body))
+	}
+
+	// Reversing onto the neck is ignored; turning is not.
+	snakeKey(g, "left")
+	g.Tick()
+	if g.over || g.body[0].x != start.x+2 {
+		t.Errorf("a reversal should be ignored, head = %v, over %v", g.body[0], g.over)
+	}
+	snakeKey(g, "up")
+	g.Tick()
+	if g.body[0] != (snakePoint{start.x + 2, start.y - 1}) {
+		t.Errorf("after turning up head = %v", g.body[0])
+	}
+
+	snakeKey(g, "p")
+	g.Tick()
+	if g.body[0] != (snakePoint{start.x + 2, start.y - 1}) {
+		t.Error("a paused snake should not move")
+	}
+}
+
+func TestSnakeEatsAndGrows(t *testing.T) {
+	g := testSnake()
+	head := g.body[0]
+	g.food = snakePoint{head.x + 1, head.y}
+	g.Tick()
+	if g.score != 1 || len(g.body) != snakeStartLength+1 {
+		t.Errorf("after eating score = %d, length %d", g.score, len(g.body))
+	}
+	for _, b := range g.body {
+		if b == g.food {
+			t.Fatalf("new food %v was placed on the snake", g.food)
+		}
+	}
+}
+
+func TestSnakeGameOverAndRestart(t *testing.T) {
+	g := testSnake()
+	g.food = snakePoint{0, 0}
+	for range g.cols {
+		g.Tick()
+	}
+	if !g.over {
+		t.Fatal("running into the wall should end the game")
+	}
+	if view := stripANSI(g.View(80, 24)); !strings.Contains(view, "Game over") {
+		t.Errorf("view should say the game is over:\n%s", view)
+	}
+
+	snakeKey(g, "r")
+	if g.over || g.score != 0 || len(g.body) != snakeStartLength {
+		t.Errorf("r should restart, got over %v score %d length %d", g.over, g.score, len(g.body))
+	}
+	if !snakeKey(g, "esc") || !snakeKey(g, "q") {
+		t.Error("esc and q should leave the game")
+	}
+}
+
+func TestSnakeRunsIntoItself(t *testing.T) {
+	g := testSnake()
+	head := g.body[0]
+	// A long snake coiled so that turning up, left, then down hits it.
+	g.body = []snakePoint{head, {head.x - 1, head.y}, {head.x - 2, head.y}, {head.x - 2, head.y + 1}, {head.x - 1, head.y + 1}, {head.x, head.y + 1}}
+	g.food = snakePoint{0, 0}
+	snakeKey(g, "up")
+	g.Tick()
+	snakeKey(g, "left")
+	g.Tick()
+	snakeKey(g, "down")
+	g.Tick()
+	if !g.over {
+		t.Errorf("the snake should have hit itself, head = %v", g.body[0])
+	}
+}
@@ -0,0 +1,136 @@
+package app
+
+import (
+	"errors"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/muesli/termenv"
+)
+
+// spinnerInterval is the delay between spinner frames.
+const spinnerInterval = 100 * time.Millisecond
+
+// Spinner frames: braille dots where the terminal draws color, and plain
+// ASCII on terminals without it, which are also the ones most likely to
+// lack the glyphs.
+var (
+	spinnerFrames      = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+	spinnerASCIIFrames = []string{"|", "/", "-", "\\"}
+)
+
+// ErrSpinnerTimeout is the error a Spinner reports once its command has
+// run longer than its timeout.
+var ErrSpinnerTimeout = errors.New("timed out")
+
+// spinnerTickMsg advances the spinner id by one frame; gen drops ticks
+// from a run that has since finished or restarted.
+type spinnerTickMsg struct {
+	id  string
+	gen int
+}
+
+// Spinner shows the state of a command running in the background, such
+// as a fetch from another service: an animated frame while it runs, and
+// its error once it fails or outlasts its timeout. Sections own one per
+// fetch, start it with the command, and pass it the result.
+type Spinner struct {
+	id      string
+	theme   Theme
+	timeout time.Duration // 0 waits for as long as the command takes
+
+	running bool
+	gen     int
+	frame   int
+	started time.Time
+	err     error
+}
+
+// NewSpinner creates an idle Spinner. id tells its ticks from those of
+// other spinners; a timeout above 0 gives up waiting after that long.
+func NewSpinner(id string, theme Theme, timeout time.Duration) Spinner {
+	return Spinner{id: id, theme: theme, timeout: timeout}
+}
+
+// SetTheme restyles the spinner without interrupting it.
+func (s *Spinner) SetTheme(theme Theme) {
+	s.theme = theme
+}
+
+// Start marks a command as running, clearing the last error, and returns
+// the first tick.
+func (s *Spinner) Start() tea.Cmd {
+	s.running = true
+	s.gen++
+	s.frame = 0
+	s.started = time.Now()
+	s.err = nil
+	return s.tick()
+}
+
+// Finish records the command's result. It is accepted after a timeout,
+// so a slow command that does return still clears the error.
+func (s *Spinner) Finish(err error) {
+	s.running = false
+	s.gen++
+	s.err = err
+}
+
+// Running reports whether the command is still awaited.
+func (s Spinner) Running() bool {
+	return s.running
+}
+
+// Err returns the command's error, ErrSpinnerTimeout if it outlasted the
+// timeout, or nil.
+func (s Spinner) Err() error {
+	return s.err
+}
+
+// IsTick reports whether msg is a tick of the spinner's current run, after
+// which its view may have changed.
+func (s Spinner) IsTick(msg tea.Msg) bool {
+	tick, ok := msg.(spinnerTickMsg)
+	return ok && tick.id == s.id && tick.gen == s.gen
+}
+
+// Update advances the spinner on its own ticks, giving up once the
+// timeout passes.
+func (s Spinner) Update(msg tea.Msg) (Spinner, tea.Cmd) {
+	if !s.IsTick(msg) || !s.running {
+		return s, nil
+	}
+	if s.timeout > 0 && time.Since(s.started) >= s.timeout {
+		s.running = false
+		s.err = ErrSpinnerTimeout
+		return s, nil
+	}
+	s.frame++
+	return s, s.tick()
+}
+
+// View renders the current frame before label while the command runs,
+// label's error once it failed, or "" when it succeeded or never ran.
+func (s Spinner) View(label string) string {
+	switch {
+	case s.running:
+		frames := spinnerFrames
+		if s.theme.ColorProfile() == termenv.Ascii {
+			frames = spinnerASCIIFrames
+		}
+		return s.theme.Accent.Render(frames[s.frame%len(frames)]) + " " + s.theme.Muted.Render(label+"…")
+	case errors.Is(s.err, ErrSpinnerTimeout):
+		return s.theme.NewStyle().Foreground(s.theme.Colors.Warning).Render(label + " timed out")
+	case s.err != nil:
+		return s.theme.Muted.Render("Unavailable: " + s.err.Error())
+	}
+	return ""
+}
+
+// tick schedules the next frame of the current run.
+func (s Spinner) tick() tea.Cmd {
+	id, gen := s.id, s.gen
+	return SubscribeAnimation("spinner:"+id, spinnerInterval, func(time.Time) tea.Msg {
+		return spinnerTickMsg{id: id, gen: gen}
+	})
+}
@@ -0,0 +1,78 @@
+package app
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+func TestSpinnerRunAndFinish(t *testing.T) {
+	s := NewSpinner("test", DarkTheme(), 0)
+	if s.Running() || s.View("Loading") != "" {
+		t.Fatal("a new spinner should be idle and draw nothing")
+	}
+	if s.Start() == nil || !s.Running() {
+		t.Fatal("Start should run the spinner and schedule a tick")
+	}
+	first := stripANSI(s.View("Loading"))
+	if first != "⠋ Loading…" {
+		t.Errorf("View = %q, want the first frame and label", first)
+	}
+
+	tick := spinnerTickMsg{id: "test", gen: s.gen}
+	s, cmd := s.Update(tick)
+	if cmd == nil || stripANSI(s.View("Loading")) == first {
+		t.Error("a tick should advance the frame and schedule the next")
+	}
+	if _, cmd := s.Update(spinnerTickMsg{id: "other", gen: s.gen}); cmd != nil {
+		t.Error("another spinner's tick should be ignored")
+	}
+
+	s.Finish(nil)
+	if s.Running() || s.View("Loading") != "" || s.Err() != nil {
+		t.Error("a finished spinner should draw nothing")
+	}
+	if _, cmd := s.Update(tick); cmd != nil {
+		t.Error("ticks from a finished run should be dropped")
+	}
+
+	s.Start()
+	s.Finish(errors.New("connection refused"))
+	if got := stripANSI(s.View("Loading")); got != "Unavailable: connection refused" {
+		t.Errorf("failed View = %q", got)
+	}
+}
+
+func TestSpinnerTimeout(t *testing.T) {
+	s := NewSpinner("test", DarkTheme(), time.Second)
+	s.Start()
+	s.started = time.Now().Add(-2 * time.Second)
+	s, cmd := s.Update(spinnerTickMsg{id: "test", gen: s.gen})
+	if cmd != nil || s.Running() || !errors.Is(s.Err(), ErrSpinnerTimeout) {
+		t.Fatalf("spinner past its timeout: running %v, err %v", s.Running(), s.Err())
+	}
+	if got := stripANSI(s.View("Loading stats")); got != "Loading stats timed out" {
+		t.Errorf("timed out View = %q", got)
+	}
+
+	// A result that arrives late still replaces the timeout.
+	s.Finish(nil)
+	if s.Err() != nil {
+		t.Error("a late result should clear the timeout")
+	}
+}
+
+func TestSpinnerASCIIFrames(t *testing.T) {
+	r := lipgloss.NewRenderer(io.Discard)
+	r.SetColorProfile(termenv.Ascii)
+	s := NewSpinner("test", DarkTheme().ForRenderer(r), 0)
+	s.Start()
+	if got := s.View("Loading"); !strings.HasPrefix(got, "| ") {
+		t.Errorf("plain terminals should get ASCII frames, got %q", got)
+	}
+}
@@ -0,0 +1,98 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// splitActive reports whether the frame uses the split layout: the
+// terminal is wide enough and the visitor has not turned it off.
+func (m Model) splitActive() bool {
+	return !m.noSplit && m.width >= SplitMinWidth
+}
+
+// splitLayout is the split layout's panes: the section list, then the
+// active section, in focus order.
+func (m Model) splitLayout() PaneLayout {
+	return PaneLayout{Panes: []Pane{
+		paneSidebar: {Name: "sections", Width: sidebarWidth},
+		paneContent: {Name: SectionName(m.activeSection)},
+	}}
+}
+
+// sectionSize returns the room sections get: below the navbar normally,
+// or beside the section list in the split layout, under the pane headers
+// and above the status bar.
+func (m Model) sectionSize() (width, height int) {
+	if m.splitActive() {
+		return m.splitLayout().Widths(m.width)[paneContent], max(1, m.height-2)
+	}
+	return m.width, max(1, m.height-ChromeHeight)
+}
+
+// resizeSections sends every section its size for the current layout.
+func (m Model) resizeSections() (Model, tea.Cmd) {
+	width, height := m.sectionSize()
+	sectionMsg := tea.WindowSizeMsg{Width: width, Height: height}
+	var cmds []tea.Cmd
+	for i := range m.sections {
+		var cmd tea.Cmd
+		m.sections[i], cmd = m.sections[i].Update(sectionMsg)
+		if cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// toggleSplit turns the split layout off or back on for wide terminals.
+func (m Model) toggleSplit() (tea.Model, tea.Cmd) {
+	m.noSplit = !m.noSplit
+	m, resize := m.resizeSections()
+	text := "Split view off"
+	switch {
+	case !m.noSplit && m.width >= SplitMinWidth:
+		text = "Split view on"
+	case !m.noSplit:
+		text = fmt.Sprintf("Split view on from %d columns", SplitMinWidth)
+	}
+	next, notice := m.showNotice(text)
+	return next, tea.Batch(resize, notice)
+}
+
+// sidebarView renders the split layout's left column: the owner's name
+// and title, then the sections to pick from, numbered for their keys.
+func (m Model) sidebarView() string {
+	inner := sidebarWidth - 2
+	lines := []string{""}
+	if m.content != nil {
+		lines = append(lines,
+			" "+m.theme.Title.Render(TruncateWithEllipsis(m.content.Meta.Name, inner)),
+			" "+m.theme.Muted.Render(TruncateWithEllipsis(m.content.Meta.Title, inner)),
+			"",
+		)
+	}
+	active := m.theme.NewStyle().Foreground(m.theme.Colors.Accent).Bold(true)
+	focused := m.focus.Current() == paneSidebar
+	for i := range m.hidden {
+		s := Section(i)
+		if m.hidden[s] {
+			continue
+		}
+		label := fmt.Sprintf("%d  %s", i+1, SectionName(s))
+		style := m.theme.Muted
+		prefix := "  "
+		if s == m.activeSection {
+			style, prefix = active, "▸ "
+		}
+		if focused && s == m.sidebarCursor {
+			// The cursor is only drawn while the sidebar takes the keys.
+			style = style.Reverse(true)
+		}
+		lines = append(lines, " "+style.Render(prefix+label))
+	}
+	lines = append(lines, "", " "+m.theme.Muted.Render("tab switch pane  ? help"))
+	return strings.Join(lines, "\n")
+}
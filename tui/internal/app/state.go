@@ -0,0 +1,56 @@
+package app
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// State glyphs, with ASCII stand-ins for terminals without color, which
+// are also the ones most likely to lack the glyphs.
+const (
+	emptyGlyph      = "○"
+	emptyASCIIGlyph = "o"
+	errorGlyph      = "✕"
+	errorASCIIGlyph = "x"
+)
+
+// EmptyState renders what a section shows when it has nothing to list: a
+// muted glyph and title, with hint below saying what would fill it.
+//
+// Layout:
+//
+//	○ No projects loaded
+//	  Projects come from work.json in the data directory.
+//
+// Each line is indented two columns and, at widths of eight columns or
+// more, fits in width.
+func EmptyState(theme Theme, title, hint string, width int) string {
+	return renderState(theme, emptyGlyph, emptyASCIIGlyph, theme.Colors.Muted, title, hint, width)
+}
+
+// ErrorState renders what a section shows when it cannot show its
+// content, laid out like EmptyState with the glyph in the warning color.
+// hint should say what the visitor can do about it, if anything.
+func ErrorState(theme Theme, title, hint string, width int) string {
+	return renderState(theme, errorGlyph, errorASCIIGlyph, theme.Colors.Warning, title, hint, width)
+}
+
+// renderState lays out a state with the given glyph and its color.
+func renderState(theme Theme, glyph, asciiGlyph string, color lipgloss.Color, title, hint string, width int) string {
+	if theme.ColorProfile() == termenv.Ascii {
+		glyph = asciiGlyph
+	}
+	textWidth := max(1, width-4)
+
+	var b strings.Builder
+	b.WriteString("\n  " + theme.NewStyle().Foreground(color).Render(glyph) + " ")
+	b.WriteString(theme.Title.Render(TruncateWithEllipsis(title, textWidth)) + "\n")
+	if hint != "" {
+		for _, line := range WrapText(hint, textWidth) {
+			b.WriteString("    " + theme.Muted.Render(TruncateWithEllipsis(line, textWidth)) + "\n")
+		}
+	}
+	return b.String()
+}
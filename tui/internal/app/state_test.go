@@ -0,0 +1,51 @@
+package app
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// plainTheme renders without color, so views can be compared as text.
+func plainTheme() Theme {
+	r := lipgloss.NewRenderer(io.Discard)
+	r.SetColorProfile(termenv.Ascii)
+	return DarkTheme().ForRenderer(r)
+}
+
+func TestEmptyStateLayout(t *testing.T) {
+	got := EmptyState(plainTheme(), "No projects loaded", "Projects come from work.json.", 80)
+	want := "\n  o No projects loaded\n    Projects come from work.json.\n"
+	if got != want {
+		t.Errorf("EmptyState = %q, want %q", got, want)
+	}
+	if got := ErrorState(plainTheme(), "Unavailable", "", 80); got != "\n  x Unavailable\n" {
+		t.Errorf("ErrorState without a hint = %q", got)
+	}
+}
+
+func TestStateGlyphs(t *testing.T) {
+	r := lipgloss.NewRenderer(io.Discard)
+	r.SetColorProfile(termenv.TrueColor)
+	theme := DarkTheme().ForRenderer(r)
+	if got := EmptyState(theme, "Empty", "", 80); !strings.Contains(got, emptyGlyph) {
+		t.Errorf("color terminals should get the empty glyph, got %q", got)
+	}
+	if got := ErrorState(theme, "Broken", "", 80); !strings.Contains(got, errorGlyph) {
+		t.Errorf("color terminals should get the error glyph, got %q", got)
+	}
+}
+
+func TestStateFitsWidth(t *testing.T) {
+	hint := "A hint long enough that it has to wrap onto several lines at this width."
+	for _, width := range []int{8, 12, 30} {
+		for i, line := range strings.Split(strings.TrimSuffix(EmptyState(plainTheme(), "A rather long title", hint, width), "\n"), "\n") {
+			if w := lipgloss.Width(line); w > width {
+				t.Errorf("width %d: line %d is %d columns: %q", width, i, w, line)
+			}
+		}
+	}
+}
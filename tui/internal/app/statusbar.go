@@ -1,15 +1,21 @@
 package app
 
 import (
+	"fmt"
 	"strings"
+	"time"
 
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/content"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/i18n"
 )
 
 // KeyHinter is an optional interface that SectionModels can implement to
 // provide contextual key hints displayed in the center of the status bar.
+// The root model formats them with FormatKeyHints.
 type KeyHinter interface {
-	KeyHints() string
+	KeyHints() []KeyHint
 }
 
 // ScrollInfo holds viewport scroll state for the status bar.
@@ -21,6 +27,38 @@ type ScrollInfo struct {
 	AtBottom bool
 	Percent  string // e.g., " 45%"; empty if content fits
 	Fits     bool   // true if all content fits without scrolling
+
+	// ReadTime is the estimated time to read the whole section. It is only
+	// set for content spanning several screens; zero hides the reading
+	// indicator.
+	ReadTime time.Duration
+	// Progress is the fraction of content scrolled into view, in [0, 1].
+	Progress float64
+
+	// Cols is the width of content wider than the viewport, and zero when
+	// every line fits. ColOffset is the first column in view, counted from
+	// 0, and ColsShown how many are in view.
+	Cols      int
+	ColOffset int
+	ColsShown int
+
+	// Scrollbar is where the scrollbar is drawn, for dragging its thumb.
+	Scrollbar Scrollbar
+	// Position is how far the view is scrolled, from 0 at the top to 1 at
+	// the bottom, for content that does not fit.
+	Position float64
+}
+
+// ScrollsLeft reports whether wide content has columns out of view to the
+// left.
+func (s ScrollInfo) ScrollsLeft() bool {
+	return s.ColOffset > 0
+}
+
+// ScrollsRight reports whether wide content has columns out of view to the
+// right.
+func (s ScrollInfo) ScrollsRight() bool {
+	return s.ColOffset+s.ColsShown < s.Cols
 }
 
 // ScrollReporter is an optional interface that SectionModels can implement
@@ -29,13 +67,26 @@ type ScrollReporter interface {
 	ScrollInfo() ScrollInfo
 }
 
-// staticHints is the fixed center text shown in the status bar.
-const staticHints = "\u2190/\u2192 nav \u00b7 ? help"
+// Status bar modes, chosen by config and toggled with :statusbar. The
+// hints mode shows the static hints with the reading and column
+// indicators; the progress mode adds the section's name at the left and
+// a gauge of the scroll position at the right.
+const (
+	StatusBarHints    = "hints"
+	StatusBarProgress = "progress"
+)
+
+// gaugeCells is the length of the progress mode's scroll gauge.
+const gaugeCells = 6
 
 // StatusBar renders a centered status bar with static hints.
 type StatusBar struct {
-	theme Theme
-	width int
+	theme  Theme
+	width  int
+	hints  string
+	notice string
+	flash  bool   // draw the bar in reverse video
+	mode   string // StatusBarHints or StatusBarProgress
 }
 
 // NewStatusBar creates a StatusBar with the given theme and terminal width.
@@ -43,14 +94,49 @@ func NewStatusBar(theme Theme, width int) StatusBar {
 	return StatusBar{
 		theme: theme,
 		width: width,
+		hints: i18n.Default().T("status.hints"),
+		mode:  StatusBarHints,
+	}
+}
+
+// SetMode switches between StatusBarHints and StatusBarProgress. Any other
+// mode is ignored.
+func (s *StatusBar) SetMode(mode string) {
+	if mode == StatusBarHints || mode == StatusBarProgress {
+		s.mode = mode
 	}
 }
 
+// Mode returns the status bar's mode.
+func (s StatusBar) Mode() string {
+	return s.mode
+}
+
+// SetHints replaces the fixed center text, as when the language changes.
+func (s *StatusBar) SetHints(hints string) {
+	s.hints = hints
+}
+
+// SetTheme replaces the status bar's theme.
+func (s *StatusBar) SetTheme(theme Theme) {
+	s.theme = theme
+}
+
 // SetWidth updates the status bar's width.
 func (s *StatusBar) SetWidth(width int) {
 	s.width = width
 }
 
+// SetNotice shows text in place of the hints until it is cleared with "".
+func (s *StatusBar) SetNotice(text string) {
+	s.notice = text
+}
+
+// SetFlash switches the bar's reverse video on or off.
+func (s *StatusBar) SetFlash(on bool) {
+	s.flash = on
+}
+
 // truncateRuneSafe truncates a string to fit within maxWidth visual columns,
 // cutting at rune boundaries to avoid splitting multi-byte UTF-8 characters.
 func truncateRuneSafe(s string, maxWidth int) string {
@@ -69,7 +155,10 @@ func truncateRuneSafe(s string, maxWidth int) string {
 
 // Render returns the styled status bar string with centered static hints.
 func (s StatusBar) Render(section Section, hints string, scroll ScrollInfo) string {
-	content := staticHints
+	content := s.hints
+	if s.notice != "" {
+		content = s.notice
+	}
 
 	hintsW := lipgloss.Width(content)
 
@@ -90,6 +179,104 @@ func (s StatusBar) Render(section Section, hints string, scroll ScrollInfo) stri
 		rightPad = 0
 	}
 
-	bar := strings.Repeat(" ", leftPad) + content + strings.Repeat(" ", rightPad)
+	// The reading indicator, or in progress mode the scroll gauge, sits
+	// at the right edge when the centered hints leave room for it, with
+	// one cell of margin on each side. The horizontal position of wide
+	// content mirrors it at the left edge, after the section's name in
+	// progress mode.
+	leftText, rightText := columnIndicator(scroll), readingIndicator(scroll)
+	if s.mode == StatusBarProgress {
+		leftText, rightText = breadcrumb(section), progressGauge(scroll)
+		if columns := columnIndicator(scroll); columns != "" {
+			leftText += "  " + columns
+		}
+	}
+
+	right := strings.Repeat(" ", rightPad)
+	if rightText != "" {
+		if w := lipgloss.Width(rightText); rightPad >= w+2 {
+			right = strings.Repeat(" ", rightPad-w-1) + rightText + " "
+		}
+	}
+
+	left := strings.Repeat(" ", leftPad)
+	if leftText != "" {
+		if w := lipgloss.Width(leftText); leftPad >= w+2 {
+			left = " " + leftText + strings.Repeat(" ", leftPad-w-1)
+		}
+	}
+
+	bar := left + content + right
+	if s.flash {
+		return s.theme.StatusBar.Reverse(true).Render(bar)
+	}
 	return s.theme.StatusBar.Render(bar)
 }
+
+// readingIndicator returns the status bar reading hint for long sections:
+// the estimated read time while at the top, then the percentage read once
+// the visitor starts scrolling. It is empty for short content.
+func readingIndicator(scroll ScrollInfo) string {
+	if scroll.ReadTime <= 0 {
+		return ""
+	}
+	if scroll.AtTop {
+		return "~" + content.FormatReadTime(scroll.ReadTime) + " read"
+	}
+	return fmt.Sprintf("%d%% read", int(scroll.Progress*100))
+}
+
+// columnIndicator returns the status bar hint for content wider than the
+// viewport: the columns in view out of the total, such as "41-120/160",
+// with an arrow on each side that has more to scroll to. It is empty when
+// every line fits.
+func columnIndicator(scroll ScrollInfo) string {
+	if scroll.Cols <= 0 {
+		return ""
+	}
+	ind := fmt.Sprintf("%d-%d/%d", scroll.ColOffset+1, scroll.ColOffset+scroll.ColsShown, scroll.Cols)
+	if scroll.ScrollsLeft() {
+		ind = "◀ " + ind
+	}
+	if scroll.ScrollsRight() {
+		ind += " ▶"
+	}
+	return ind
+}
+
+// breadcrumb returns the progress mode's name for section, written as a
+// path, such as "~/work".
+func breadcrumb(section Section) string {
+	return "~/" + SectionName(section)
+}
+
+// progressGauge returns the progress mode's scroll gauge, such as
+// "▰▰▰▱▱▱ 45%", with TOP or BOT in place of the percentage at either end.
+// It is empty for content that fits.
+func progressGauge(scroll ScrollInfo) string {
+	if scroll.Fits {
+		return ""
+	}
+	filled := max(0, min(gaugeCells, int(scroll.Position*gaugeCells+0.5)))
+	pos := strings.TrimSpace(scroll.Percent)
+	switch {
+	case scroll.AtTop:
+		pos = "TOP"
+	case scroll.AtBottom:
+		pos = "BOT"
+	}
+	return strings.Repeat("▰", filled) + strings.Repeat("▱", gaugeCells-filled) + " " + pos
+}
+
+// toggleStatusBar switches the status bar between the hints and progress
+// modes.
+func (m Model) toggleStatusBar() (tea.Model, tea.Cmd) {
+	text := "Scroll progress on"
+	if m.statusBar.Mode() == StatusBarProgress {
+		m.statusBar.SetMode(StatusBarHints)
+		text = "Scroll progress off"
+	} else {
+		m.statusBar.SetMode(StatusBarProgress)
+	}
+	return m.showNotice(text)
+}
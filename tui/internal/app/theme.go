@@ -1,20 +1,46 @@
 package app
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"github.com/buntingszn/terminal-portfolio/tui/internal/content"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
 
-// Colors holds the 5-color palette.
+// Colors holds the 5-color palette plus the semantic colors for good and
+// cautionary states. Bad states reuse Accent, which is red in both themes.
 type Colors struct {
-	Bg     lipgloss.Color
-	Fg     lipgloss.Color
-	Accent lipgloss.Color
-	Muted  lipgloss.Color
-	Border lipgloss.Color
+	Bg      lipgloss.Color
+	Fg      lipgloss.Color
+	Accent  lipgloss.Color
+	Muted   lipgloss.Color
+	Border  lipgloss.Color
+	Success lipgloss.Color
+	Warning lipgloss.Color
+
+	// StatusBarBg and StatusBarFg color the status bar; empty uses Border
+	// and Muted.
+	StatusBarBg lipgloss.Color
+	StatusBarFg lipgloss.Color
 }
 
+// Theme names accepted by ThemeByName.
+const (
+	ThemeDark  = "dark"
+	ThemeLight = "light"
+)
+
 // Theme holds colors and pre-built styles.
 type Theme struct {
+	Name   string
 	Colors Colors
 
+	// renderer renders the styles, or nil for lipgloss's default renderer.
+	renderer *lipgloss.Renderer
+
+	// overrides are the data directory's theme.json colors, which replace
+	// the built-in ones, or nil for none.
+	overrides *content.Theme
+
 	// Pre-built styles
 	Title       lipgloss.Style
 	Body        lipgloss.Style
@@ -26,29 +52,211 @@ type Theme struct {
 	NavInactive lipgloss.Style
 }
 
+// palette holds a theme's colors for each color profile. The renderer maps
+// every color down to what the client supports, but matched automatically
+// the border lands on the background's color, so the 256- and 16-color
+// variants are picked by hand from those tables.
+type palette struct {
+	trueColor Colors
+	ansi256   Colors
+	ansi      Colors
+}
+
+// colors returns the palette variant for profile. Ascii renders no color,
+// so any variant will do.
+func (p palette) colors(profile termenv.Profile) Colors {
+	switch profile {
+	case termenv.TrueColor:
+		return p.trueColor
+	case termenv.ANSI256:
+		return p.ansi256
+	default:
+		return p.ansi
+	}
+}
+
 var darkColors = Colors{
 	Bg:     lipgloss.Color("#0d0d0d"),
 	Fg:     lipgloss.Color("#c8c0b8"),
 	Accent: lipgloss.Color("#e8536d"),
 	Muted:  lipgloss.Color("#555250"),
 	Border: lipgloss.Color("#2a2826"),
+
+	Success: lipgloss.Color("#5fb35f"),
+	Warning: lipgloss.Color("#d7a13a"),
 }
 
-func newTheme(colors Colors) Theme {
-	return Theme{
-		Colors:      colors,
-		Title:       lipgloss.NewStyle().Foreground(colors.Accent).Bold(true),
-		Body:        lipgloss.NewStyle().Foreground(colors.Fg),
-		Accent:      lipgloss.NewStyle().Foreground(colors.Accent),
-		Muted:       lipgloss.NewStyle().Foreground(colors.Muted),
-		Border:      lipgloss.NewStyle().Foreground(colors.Border),
-		StatusBar:   lipgloss.NewStyle().Background(colors.Border).Foreground(colors.Muted),
-		NavActive:   lipgloss.NewStyle().Foreground(colors.Accent).Bold(true),
-		NavInactive: lipgloss.NewStyle().Foreground(colors.Muted),
+var lightColors = Colors{
+	Bg:     lipgloss.Color("#f4f1ec"),
+	Fg:     lipgloss.Color("#3a3633"),
+	Accent: lipgloss.Color("#c8304f"),
+	Muted:  lipgloss.Color("#8c8782"),
+	Border: lipgloss.Color("#dcd6ce"),
+
+	Success: lipgloss.Color("#2f8a3b"),
+	Warning: lipgloss.Color("#b07a12"),
+}
+
+var palettes = map[string]palette{
+	ThemeDark: {
+		trueColor: darkColors,
+		ansi256: Colors{
+			Bg:      lipgloss.Color("#080808"),
+			Fg:      lipgloss.Color("#c6c6c6"),
+			Accent:  lipgloss.Color("#ff5f87"),
+			Muted:   lipgloss.Color("#626262"),
+			Border:  lipgloss.Color("#303030"),
+			Success: lipgloss.Color("#5faf5f"),
+			Warning: lipgloss.Color("#d7af5f"),
+		},
+		ansi: Colors{
+			Bg:      lipgloss.Color("#000000"),
+			Fg:      lipgloss.Color("#c0c0c0"),
+			Accent:  lipgloss.Color("#ff0000"),
+			Muted:   lipgloss.Color("#808080"),
+			Border:  lipgloss.Color("#808080"),
+			Success: lipgloss.Color("#008000"),
+			Warning: lipgloss.Color("#808000"),
+		},
+	},
+	ThemeLight: {
+		trueColor: lightColors,
+		ansi256: Colors{
+			Bg:      lipgloss.Color("#eeeeee"),
+			Fg:      lipgloss.Color("#3a3a3a"),
+			Accent:  lipgloss.Color("#d7005f"),
+			Muted:   lipgloss.Color("#8a8a8a"),
+			Border:  lipgloss.Color("#d0d0d0"),
+			Success: lipgloss.Color("#008700"),
+			Warning: lipgloss.Color("#af8700"),
+		},
+		ansi: Colors{
+			Bg:      lipgloss.Color("#ffffff"),
+			Fg:      lipgloss.Color("#000000"),
+			Accent:  lipgloss.Color("#800000"),
+			Muted:   lipgloss.Color("#808080"),
+			Border:  lipgloss.Color("#c0c0c0"),
+			Success: lipgloss.Color("#008000"),
+			Warning: lipgloss.Color("#808000"),
+		},
+	},
+}
+
+// withOverrides returns c with the colors o sets replacing its own. They
+// replace every profile's variant, and the renderer downsamples them.
+func (c Colors) withOverrides(o content.ThemeColors) Colors {
+	for _, f := range []struct {
+		dst *lipgloss.Color
+		hex string
+	}{
+		{&c.Bg, o.Bg}, {&c.Fg, o.Fg}, {&c.Accent, o.Accent}, {&c.Muted, o.Muted}, {&c.Border, o.Border},
+		{&c.Success, o.Success}, {&c.Warning, o.Warning}, {&c.StatusBarBg, o.StatusBarBg}, {&c.StatusBarFg, o.StatusBarFg},
+	} {
+		if f.hex != "" {
+			*f.dst = lipgloss.Color(f.hex)
+		}
 	}
+	return c
+}
+
+func newTheme(name string, colors Colors, r *lipgloss.Renderer) Theme {
+	t := Theme{Name: name, Colors: colors, renderer: r}
+	t.Title = t.NewStyle().Foreground(colors.Accent).Bold(true)
+	t.Body = t.NewStyle().Foreground(colors.Fg)
+	t.Accent = t.NewStyle().Foreground(colors.Accent)
+	t.Muted = t.NewStyle().Foreground(colors.Muted)
+	t.Border = t.NewStyle().Foreground(colors.Border)
+	bar, barText := colors.StatusBarBg, colors.StatusBarFg
+	if bar == "" {
+		bar = colors.Border
+	}
+	if barText == "" {
+		barText = colors.Muted
+	}
+	t.StatusBar = t.NewStyle().Background(bar).Foreground(barText)
+	t.NavActive = t.NewStyle().Foreground(colors.Accent).Bold(true)
+	t.NavInactive = t.NewStyle().Foreground(colors.Muted)
+	return t
 }
 
 // DarkTheme returns the dark theme.
 func DarkTheme() Theme {
-	return newTheme(darkColors)
+	return newTheme(ThemeDark, darkColors, nil)
+}
+
+// LightTheme returns the light theme, for terminals with a pale background.
+func LightTheme() Theme {
+	return newTheme(ThemeLight, lightColors, nil)
+}
+
+// ForRenderer returns the theme rendering through r, with its palette
+// picked for r's color profile. Each SSH session passes a renderer for
+// its own client.
+func (t Theme) ForRenderer(r *lipgloss.Renderer) Theme {
+	colors := palettes[t.Name].trueColor
+	if r != nil {
+		colors = palettes[t.Name].colors(r.ColorProfile())
+	}
+	if t.overrides != nil {
+		if t.Name == ThemeLight {
+			colors = colors.withOverrides(t.overrides.Light)
+		} else {
+			colors = colors.withOverrides(t.overrides.Dark)
+		}
+	}
+	theme := newTheme(t.Name, colors, r)
+	theme.overrides = t.overrides
+	return theme
+}
+
+// WithOverrides returns the theme with the colors o sets in place of the
+// built-in ones, its dark colors for the dark theme and its light colors
+// for the light one. Toggling carries them over. A nil o restores the
+// built-in colors.
+func (t Theme) WithOverrides(o *content.Theme) Theme {
+	t.overrides = o
+	return t.ForRenderer(t.renderer)
+}
+
+// NewStyle returns an empty style rendering through the theme's renderer.
+// Styles with colors must be built with it rather than lipgloss.NewStyle,
+// so they are downsampled to the client's color profile.
+func (t Theme) NewStyle() lipgloss.Style {
+	if t.renderer == nil {
+		return lipgloss.NewStyle()
+	}
+	return t.renderer.NewStyle()
+}
+
+// ColorProfile returns the color profile the theme renders with: its
+// renderer's, or true color without one.
+func (t Theme) ColorProfile() termenv.Profile {
+	if t.renderer == nil {
+		return termenv.TrueColor
+	}
+	return t.renderer.ColorProfile()
+}
+
+// ThemeByName returns the theme with the given name, or false if the name
+// is not one of ThemeDark or ThemeLight.
+func ThemeByName(name string) (Theme, bool) {
+	switch name {
+	case ThemeDark:
+		return DarkTheme(), true
+	case ThemeLight:
+		return LightTheme(), true
+	default:
+		return Theme{}, false
+	}
+}
+
+// Toggled returns the opposite theme: light for dark and dark for light.
+// The renderer and the overrides carry over.
+func (t Theme) Toggled() Theme {
+	next := LightTheme()
+	if t.Name == ThemeLight {
+		next = DarkTheme()
+	}
+	next.overrides = t.overrides
+	return next.ForRenderer(t.renderer)
 }
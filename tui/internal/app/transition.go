@@ -4,7 +4,6 @@ import (
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
-	"github.com/charmbracelet/lipgloss"
 )
 
 const (
@@ -78,6 +77,16 @@ func (t *TransitionManager) Active() bool {
 	return t.active
 }
 
+// Progress returns the eased completion of the running transition in
+// [0, 1]. It reports 1 when no transition is active so followers such as
+// the navbar indicator settle on the destination.
+func (t *TransitionManager) Progress() float64 {
+	if !t.active || t.steps <= 0 {
+		return 1
+	}
+	return easeInOut(float64(t.step) / float64(t.steps))
+}
+
 // Update handles AnimationTickMsg to advance the transition.
 func (t *TransitionManager) Update(msg tea.Msg) tea.Cmd {
 	if !t.active {
@@ -172,12 +181,12 @@ func shiftLine(line string, offset, direction, width int) string {
 		return line
 	}
 
-	clamp := lipgloss.NewStyle().Width(width).MaxWidth(width)
-
+	// Lines are cut and padded rather than given a style Width, which
+	// would wrap a line too wide onto more lines.
 	if direction > 0 {
 		// Shift right: prepend spaces to push content rightward,
 		// then clamp to width (lipgloss handles ANSI truncation).
-		return clamp.Render(strings.Repeat(" ", offset) + line)
+		return padRight(truncateLine(strings.Repeat(" ", offset)+line, width), width)
 	}
 
 	// Shift left: content slides off the left edge.
@@ -189,6 +198,5 @@ func shiftLine(line string, offset, direction, width int) string {
 		return strings.Repeat(" ", width)
 	}
 
-	truncated := lipgloss.NewStyle().Width(remaining).MaxWidth(remaining).Render(line)
-	return truncated + strings.Repeat(" ", offset)
+	return padRight(truncateLine(line, remaining), remaining) + strings.Repeat(" ", offset)
 }
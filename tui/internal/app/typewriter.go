@@ -78,7 +78,7 @@ func (tw Typewriter) View() string {
 // Tick returns a tea.Cmd that schedules the next typewriter tick.
 func (tw Typewriter) Tick() tea.Cmd {
 	id := tw.id
-	return tea.Tick(defaultTickDuration, func(_ time.Time) tea.Msg {
+	return SubscribeAnimation("typewriter:"+id, defaultTickDuration, func(_ time.Time) tea.Msg {
 		return typewriterTickMsg{id: id}
 	})
 }
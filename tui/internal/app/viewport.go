@@ -3,8 +3,11 @@ package app
 import (
 	"fmt"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/content"
 )
 
 const (
@@ -22,18 +25,43 @@ const (
 	// 88 = comfortable reading width (80 content + card borders), leaving
 	// ~16 cols margin per side on a 120-col terminal.
 	MaxContentWidth = 88
+
+	// longContentScreens is how many viewport heights content must exceed
+	// before GetScrollInfo reports a read time estimate.
+	longContentScreens = 3
 )
 
 // Viewport is a scrollable content viewer. It slices pre-rendered text into a
-// visible window and provides scroll position indicators. It is a pure
-// rendering utility — it does not implement tea.Model and has no bubbletea
-// dependency.
+// visible window and provides scroll position indicators. It is a rendering
+// utility — it does not implement tea.Model, and bubbletea only comes in to
+// drive its animated scrolls.
 type Viewport struct {
 	content string
 	lines   []string
+	source  LineSource // supplies the lines in place of content, or nil
 	width   int
 	height  int
 	yOffset int
+	xOffset int              // columns scrolled right, for lines wider than the viewport
+	cols    int              // width of the widest line
+	words   int              // word count of content, for read time estimates
+	anim    *scrollAnimation // running animated scroll, or nil
+}
+
+// LineSource supplies a viewport's lines a window at a time, for content
+// too long to render whole. The viewport asks only for the lines it shows,
+// so rendering, styling, and memory stay proportional to its height rather
+// than to the length of the content.
+type LineSource interface {
+	// Len returns how many lines there are.
+	Len() int
+	// Width returns the width of the widest line.
+	Width() int
+	// Words returns how many words there are, for read time estimates.
+	Words() int
+	// RenderLines returns the lines from start up to, but not including,
+	// end, with 0 <= start < end <= Len().
+	RenderLines(start, end int) []string
 }
 
 // NewViewport creates a Viewport with the given dimensions.
@@ -47,9 +75,59 @@ func NewViewport(width, height int) Viewport {
 // SetContent loads rendered text into the viewport and resets the scroll
 // position to the top.
 func (v *Viewport) SetContent(content string) {
+	v.load(content)
+	v.resetScroll()
+}
+
+// SetSource loads lines supplied by src into the viewport, in place of
+// any content, and resets the scroll position to the top.
+func (v *Viewport) SetSource(src LineSource) {
+	v.loadSource(src)
+	v.resetScroll()
+}
+
+// resetScroll moves back to the top left, stopping any animated scroll.
+func (v *Viewport) resetScroll() {
+	v.yOffset = 0
+	v.xOffset = 0
+	v.anim = nil
+}
+
+// load stores content with its lines and measurements. A section's
+// memoized render hands back the same string it did last time, which is
+// already split and measured.
+func (v *Viewport) load(content string) {
+	if v.source == nil && v.lines != nil && content == v.content {
+		return
+	}
+	v.source = nil
 	v.content = content
 	v.lines = strings.Split(content, "\n")
-	v.yOffset = 0
+	v.cols = lipgloss.Width(content)
+	v.words = countWords(content)
+}
+
+// loadSource stores src, whose lines are rendered as they come into view.
+func (v *Viewport) loadSource(src LineSource) {
+	v.source = src
+	v.content, v.lines = "", nil
+	v.cols = src.Width()
+	v.words = src.Words()
+}
+
+// countWords counts whitespace-separated tokens that contain a letter or
+// digit, so dividers, bullets, and scrollbar glyphs are not read as words.
+// ANSI escapes never contain whitespace and stay attached to their word.
+func countWords(s string) int {
+	n := 0
+	for _, f := range strings.Fields(s) {
+		if strings.IndexFunc(f, func(r rune) bool {
+			return unicode.IsLetter(r) || unicode.IsDigit(r)
+		}) >= 0 {
+			n++
+		}
+	}
+	return n
 }
 
 // SetContentPreserveScroll updates content without resetting the scroll
@@ -58,12 +136,24 @@ func (v *Viewport) SetContent(content string) {
 // restored. This is intended for resize-triggered re-renders where the user's
 // reading position should be preserved.
 func (v *Viewport) SetContentPreserveScroll(content string) {
+	v.preserveScroll(func() { v.load(content) })
+}
+
+// SetSourcePreserveScroll is SetContentPreserveScroll for lines supplied
+// by src.
+func (v *Viewport) SetSourcePreserveScroll(src LineSource) {
+	v.preserveScroll(func() { v.loadSource(src) })
+}
+
+// preserveScroll runs load, which replaces the content, keeping the
+// reading position as SetContentPreserveScroll describes.
+func (v *Viewport) preserveScroll(load func()) {
 	wasAtBottom := v.AtBottom()
 	wasAtTop := v.AtTop()
 	oldPercent := v.RawScrollPercent()
+	v.anim = nil
 
-	v.content = content
-	v.lines = strings.Split(content, "\n")
+	load()
 
 	if wasAtTop {
 		v.yOffset = 0
@@ -98,23 +188,57 @@ func (v *Viewport) ContentWidth() int {
 
 // ScrollUp scrolls up by n lines.
 func (v *Viewport) ScrollUp(n int) {
+	v.anim = nil
 	v.yOffset -= n
 	v.clampOffset()
 }
 
 // ScrollDown scrolls down by n lines.
 func (v *Viewport) ScrollDown(n int) {
+	v.anim = nil
 	v.yOffset += n
 	v.clampOffset()
 }
 
+// ScrollLeft scrolls left by n columns.
+func (v *Viewport) ScrollLeft(n int) {
+	v.xOffset -= n
+	v.clampOffset()
+}
+
+// ScrollRight scrolls right by n columns. It does nothing unless some
+// line is wider than the viewport.
+func (v *Viewport) ScrollRight(n int) {
+	v.xOffset += n
+	v.clampOffset()
+}
+
+// XOffset returns how many columns the viewport is scrolled right.
+func (v *Viewport) XOffset() int {
+	return v.xOffset
+}
+
+// YOffset returns how many lines the viewport is scrolled down.
+func (v *Viewport) YOffset() int {
+	return v.yOffset
+}
+
+// SetYOffset scrolls to n lines from the top, clamped to the content.
+func (v *Viewport) SetYOffset(n int) {
+	v.anim = nil
+	v.yOffset = n
+	v.clampOffset()
+}
+
 // ScrollToTop scrolls to the very top.
 func (v *Viewport) ScrollToTop() {
+	v.anim = nil
 	v.yOffset = 0
 }
 
 // ScrollToBottom scrolls to the very bottom.
 func (v *Viewport) ScrollToBottom() {
+	v.anim = nil
 	v.yOffset = v.maxOffset()
 }
 
@@ -131,9 +255,26 @@ func (v *Viewport) AtBottom() bool {
 
 // TotalLines returns the total number of lines in the content.
 func (v *Viewport) TotalLines() int {
+	if v.source != nil {
+		return v.source.Len()
+	}
 	return len(v.lines)
 }
 
+// LineAt returns the content line on row of the view, counted from 0, as
+// ViewWithScrollbar lays it out: content that fits is centered
+// vertically. It reports false for rows with no content line.
+func (v *Viewport) LineAt(row int) (int, bool) {
+	if row < 0 || row >= v.height {
+		return 0, false
+	}
+	line := v.yOffset + row
+	if total := v.TotalLines(); total <= v.height {
+		line = row - (v.height-total)/2
+	}
+	return line, line >= 0 && line < v.TotalLines()
+}
+
 // VisibleLines returns the viewport height.
 func (v *Viewport) VisibleLines() int {
 	return v.height
@@ -173,6 +314,7 @@ func (v *Viewport) View() string {
 // When more content exists above or below the visible area, ▲/▼ arrows in the
 // accent color replace the first/last track character. If all content fits in
 // the viewport, the scrollbar is hidden and plain View() output is returned.
+// Scrollbar reports where the scrollbar is drawn.
 func (v *Viewport) ViewWithScrollbar(theme Theme) string {
 	totalLines := v.TotalLines()
 	visibleHeight := v.height
@@ -183,9 +325,9 @@ func (v *Viewport) ViewWithScrollbar(theme Theme) string {
 
 	thumbHeight, thumbStart := v.scrollbarMetrics()
 
-	trackStyle := lipgloss.NewStyle().Foreground(theme.Colors.Border)
-	thumbStyle := lipgloss.NewStyle().Foreground(theme.Colors.Muted)
-	arrowStyle := lipgloss.NewStyle().Foreground(theme.Colors.Accent)
+	trackStyle := theme.NewStyle().Foreground(theme.Colors.Border)
+	thumbStyle := theme.NewStyle().Foreground(theme.Colors.Muted)
+	arrowStyle := theme.NewStyle().Foreground(theme.Colors.Accent)
 
 	indicator := make([]string, visibleHeight)
 	for i := range visibleHeight {
@@ -206,7 +348,7 @@ func (v *Viewport) ViewWithScrollbar(theme Theme) string {
 		indicator[visibleHeight-1] = arrowStyle.Render(scrollDownArrow)
 	}
 
-	visible := v.visibleSlice()
+	visible := v.visibleColumns()
 
 	// Pad or trim to match viewport height.
 	for len(visible) < visibleHeight {
@@ -224,10 +366,14 @@ func (v *Viewport) ViewWithScrollbar(theme Theme) string {
 	var b strings.Builder
 	for i := range visibleHeight {
 		line := visible[i]
-		// Center content horizontally within the available width.
-		centered := lipgloss.PlaceHorizontal(contentWidth, lipgloss.Center, line)
-		rendered := lipgloss.NewStyle().Width(contentWidth).MaxWidth(contentWidth).Render(centered)
-		b.WriteString(rendered)
+		// Center content horizontally within the available width. MaxWidth
+		// truncates over-long lines; Width would wrap them onto extra rows
+		// and push the frame past the viewport height. A 1-cell viewport
+		// has room only for the scrollbar, and MaxWidth(0) means unlimited.
+		if contentWidth > 0 {
+			centered := lipgloss.PlaceHorizontal(contentWidth, lipgloss.Center, line)
+			b.WriteString(lipgloss.NewStyle().MaxWidth(contentWidth).Render(centered))
+		}
 		b.WriteString(indicator[i])
 		if i < visibleHeight-1 {
 			b.WriteByte('\n')
@@ -244,7 +390,7 @@ func (v *Viewport) viewCentered() string {
 		return ""
 	}
 
-	visible := v.visibleSlice()
+	visible := v.visibleColumns()
 	totalLines := len(visible)
 	fullWidth := v.width
 
@@ -262,8 +408,14 @@ func (v *Viewport) viewCentered() string {
 		if contentIdx >= 0 && contentIdx < totalLines {
 			line = visible[contentIdx]
 		}
-		// Center each line horizontally across the full width.
-		output[i] = lipgloss.PlaceHorizontal(fullWidth, lipgloss.Center, line)
+		// Center each line horizontally across the full width. Lines that
+		// are already too wide are returned as-is by PlaceHorizontal, so
+		// truncate them here.
+		placed := lipgloss.PlaceHorizontal(fullWidth, lipgloss.Center, line)
+		if fullWidth > 0 && lipgloss.Width(placed) > fullWidth {
+			placed = truncateLine(placed, fullWidth)
+		}
+		output[i] = placed
 	}
 
 	return strings.Join(output, "\n")
@@ -280,7 +432,7 @@ func (v *Viewport) ViewWithArrows(theme Theme) string {
 
 	var b strings.Builder
 
-	arrowStyle := lipgloss.NewStyle().Foreground(theme.Colors.Accent)
+	arrowStyle := theme.NewStyle().Foreground(theme.Colors.Accent)
 
 	if !v.AtTop() {
 		arrow := arrowStyle.Render(scrollUpArrow)
@@ -331,16 +483,48 @@ func (v *Viewport) scrollbarMetrics() (thumbHeight, thumbStart int) {
 	return thumbHeight, thumbStart
 }
 
+// Scrollbar returns where ViewWithScrollbar draws the scrollbar, in the
+// viewport's own coordinates. Rows is zero when the content fits and no
+// scrollbar is drawn.
+func (v *Viewport) Scrollbar() Scrollbar {
+	if v.TotalLines() <= v.height || v.width < 1 {
+		return Scrollbar{}
+	}
+	thumbHeight, thumbStart := v.scrollbarMetrics()
+	return Scrollbar{
+		Column:      v.width - 1,
+		Rows:        v.height,
+		ThumbStart:  thumbStart,
+		ThumbHeight: thumbHeight,
+		MaxOffset:   v.maxOffset(),
+	}
+}
+
+// lineWidth returns the columns each line may fill: the full width, less
+// the scrollbar when the content is taller than the viewport.
+func (v *Viewport) lineWidth() int {
+	if v.TotalLines() > v.height {
+		return max(0, v.width-1)
+	}
+	return max(0, v.width)
+}
+
+// maxXOffset returns the maximum valid xOffset value.
+func (v *Viewport) maxXOffset() int {
+	return max(0, v.cols-v.lineWidth())
+}
+
 // maxOffset returns the maximum valid yOffset value.
 func (v *Viewport) maxOffset() int {
-	max := len(v.lines) - v.height
+	max := v.TotalLines() - v.height
 	if max < 0 {
 		return 0
 	}
 	return max
 }
 
-// clampOffset ensures yOffset stays within [0, maxOffset].
+// clampOffset ensures yOffset stays within [0, maxOffset] and xOffset
+// within [0, maxXOffset].
 func (v *Viewport) clampOffset() {
 	if v.yOffset < 0 {
 		v.yOffset = 0
@@ -348,34 +532,108 @@ func (v *Viewport) clampOffset() {
 	if m := v.maxOffset(); v.yOffset > m {
 		v.yOffset = m
 	}
+	v.xOffset = max(0, min(v.xOffset, v.maxXOffset()))
 }
 
 // GetScrollInfo returns the current scroll state suitable for display in the
 // status bar. If all content fits within the viewport, Fits is true and no
-// scroll indicator is needed.
+// scroll indicator is needed. Content wider than the viewport reports its
+// columns whether or not it fits vertically.
 func (v *Viewport) GetScrollInfo() ScrollInfo {
-	if v.TotalLines() <= v.height {
-		return ScrollInfo{Fits: true, AtTop: true, AtBottom: true}
+	info := ScrollInfo{Fits: true, AtTop: true, AtBottom: true}
+	if w := v.lineWidth(); v.cols > w {
+		info.Cols, info.ColOffset, info.ColsShown = v.cols, v.xOffset, w
 	}
-	return ScrollInfo{
-		AtTop:   v.AtTop(),
-		AtBottom: v.AtBottom(),
-		Percent:  v.ScrollPercent(),
+	if v.TotalLines() <= v.height {
+		return info
+	}
+	info.Fits = false
+	info.Scrollbar = v.Scrollbar()
+	info.AtTop = v.AtTop()
+	info.AtBottom = v.AtBottom()
+	info.Percent = v.ScrollPercent()
+	info.Position = v.RawScrollPercent()
+	if v.TotalLines() > longContentScreens*v.height {
+		info.ReadTime = content.ReadTime(v.words)
+		info.Progress = v.ReadProgress()
+	}
+	return info
+}
+
+// ReadProgress returns the fraction of content lines that have been
+// scrolled into view, counting the visible window. Unlike RawScrollPercent
+// it starts above zero, since the first screen is already readable.
+func (v *Viewport) ReadProgress() float64 {
+	total := v.TotalLines()
+	if total == 0 {
+		return 1
 	}
+	seen := min(v.yOffset+v.height, total)
+	return float64(seen) / float64(total)
 }
 
 // visibleSlice returns the slice of lines currently visible.
 func (v *Viewport) visibleSlice() []string {
-	if len(v.lines) == 0 {
+	total := v.TotalLines()
+	if total == 0 {
 		return nil
 	}
 	start := v.yOffset
 	end := start + v.height
-	if end > len(v.lines) {
-		end = len(v.lines)
+	if end > total {
+		end = total
 	}
 	if start >= end {
 		return nil
 	}
+	if v.source != nil {
+		return v.source.RenderLines(start, end)
+	}
 	return v.lines[start:end]
 }
+
+// visibleColumns returns the visible lines, cut to the columns in view
+// when the content is wider than the viewport. Cut lines are padded to
+// the full width so that centering does not shift the shorter ones.
+// Narrower content is returned as is.
+func (v *Viewport) visibleColumns() []string {
+	visible := v.visibleSlice()
+	w := v.lineWidth()
+	if v.cols <= w || w == 0 {
+		return visible
+	}
+	cut := make([]string, len(visible))
+	for i, line := range visible {
+		cut[i] = padRight(cutColumns(line, v.xOffset, w), w)
+	}
+	return cut
+}
+
+// cutColumns returns width columns of line starting at column start. Every
+// escape sequence is kept, wherever it falls, so colors and hyperlinks set
+// before the cut still apply and those ending after it are still closed.
+// A wide character split by either edge is replaced by spaces.
+func cutColumns(line string, start, width int) string {
+	end := start + width
+	var b strings.Builder
+	col := 0
+	for i := 0; i < len(line); {
+		if line[i] == '\x1b' {
+			seq, _, _ := escapeAt(line[i:])
+			b.WriteString(seq)
+			i += len(seq)
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(line[i:])
+		w := lipgloss.Width(string(r))
+		switch {
+		case col >= start && col+w <= end:
+			b.WriteString(line[i : i+size])
+		case col < end && col+w > start:
+			b.WriteString(strings.Repeat(" ", min(col+w, end)-max(col, start)))
+		}
+		col += w
+		i += size
+	}
+	return b.String()
+}
@@ -0,0 +1,190 @@
+package app
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/quick"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// viewportOpKind enumerates the mutations applied in property tests.
+type viewportOpKind int
+
+const (
+	opSetContent viewportOpKind = iota
+	opSetContentPreserve
+	opSetSize
+	opScrollUp
+	opScrollDown
+	opScrollTop
+	opScrollBottom
+	opKinds
+)
+
+// viewportOp is a single randomly generated viewport mutation.
+type viewportOp struct {
+	kind    viewportOpKind
+	n       int    // scroll amount or width
+	m       int    // height
+	content string // for SetContent variants
+}
+
+// viewportScenario is a random starting size plus a sequence of operations.
+// It implements quick.Generator so testing/quick can produce instances.
+type viewportScenario struct {
+	width  int
+	height int
+	ops    []viewportOp
+}
+
+// randomContent builds multi-line content with a random number of lines of
+// random widths, including empty lines and lines wider than the viewport.
+func randomContent(r *rand.Rand) string {
+	lines := make([]string, r.Intn(120))
+	for i := range lines {
+		switch r.Intn(4) {
+		case 0:
+			lines[i] = ""
+		case 1:
+			lines[i] = strings.Repeat("x", r.Intn(200))
+		default:
+			lines[i] = strings.Repeat("word ", r.Intn(20))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// GoString keeps quick.Check failure reports readable instead of dumping
+// every generated line of content.
+func (sc viewportScenario) GoString() string {
+	kinds := make([]string, len(sc.ops))
+	for i, op := range sc.ops {
+		kinds[i] = fmt.Sprintf("%d", op.kind)
+	}
+	return fmt.Sprintf("viewportScenario{%dx%d ops=[%s]}", sc.width, sc.height, strings.Join(kinds, " "))
+}
+
+// Generate implements quick.Generator.
+func (viewportScenario) Generate(r *rand.Rand, size int) reflect.Value {
+	sc := viewportScenario{
+		width:  r.Intn(160),
+		height: r.Intn(60),
+	}
+	for range r.Intn(size + 1) {
+		op := viewportOp{kind: viewportOpKind(r.Intn(int(opKinds)))}
+		switch op.kind {
+		case opSetContent, opSetContentPreserve:
+			op.content = randomContent(r)
+		case opSetSize:
+			op.n = r.Intn(160)
+			op.m = r.Intn(60)
+		case opScrollUp, opScrollDown:
+			op.n = r.Intn(100) - 10 // include negative amounts
+		}
+		sc.ops = append(sc.ops, op)
+	}
+	return reflect.ValueOf(sc)
+}
+
+// apply runs a single operation against the viewport.
+func (op viewportOp) apply(v *Viewport) {
+	switch op.kind {
+	case opSetContent:
+		v.SetContent(op.content)
+	case opSetContentPreserve:
+		v.SetContentPreserveScroll(op.content)
+	case opSetSize:
+		v.SetSize(op.n, op.m)
+	case opScrollUp:
+		v.ScrollUp(op.n)
+	case opScrollDown:
+		v.ScrollDown(op.n)
+	case opScrollTop:
+		v.ScrollToTop()
+	case opScrollBottom:
+		v.ScrollToBottom()
+	}
+}
+
+// checkViewportInvariants returns a description of the first violated
+// invariant, or an empty string if all hold.
+func checkViewportInvariants(v *Viewport, theme Theme) string {
+	if v.yOffset < 0 || v.yOffset > v.maxOffset() {
+		return "yOffset out of [0, maxOffset]"
+	}
+
+	if v.height > 0 {
+		if got := strings.Count(v.View(), "\n") + 1; got > v.height {
+			return "View() exceeds height"
+		}
+		view := v.ViewWithScrollbar(theme)
+		if got := strings.Count(view, "\n") + 1; got > v.height {
+			return "ViewWithScrollbar() exceeds height"
+		}
+		if v.width > 0 {
+			for _, line := range strings.Split(view, "\n") {
+				if lipgloss.Width(line) > v.width {
+					return "ViewWithScrollbar() line exceeds width"
+				}
+			}
+		}
+	} else if v.View() != "" {
+		return "View() should be empty at zero height"
+	}
+
+	thumbHeight, thumbStart := v.scrollbarMetrics()
+	if v.height > 0 {
+		if thumbHeight < 1 {
+			return "thumb height below 1"
+		}
+		if thumbStart < 0 || thumbStart+thumbHeight > v.height {
+			return "thumb outside track"
+		}
+	}
+
+	pct := v.RawScrollPercent()
+	if pct < 0 || pct > 1 {
+		return "RawScrollPercent outside [0, 1]"
+	}
+	return ""
+}
+
+func TestViewportPropertyInvariants(t *testing.T) {
+	theme := DarkTheme()
+	prop := func(sc viewportScenario) bool {
+		v := NewViewport(sc.width, sc.height)
+		for i, op := range sc.ops {
+			op.apply(&v)
+			if msg := checkViewportInvariants(&v, theme); msg != "" {
+				t.Logf("after op %d (%+v) at %dx%d with %d lines: %s",
+					i, op.kind, v.width, v.height, v.TotalLines(), msg)
+				return false
+			}
+		}
+		return true
+	}
+	cfg := &quick.Config{MaxCount: 300, Rand: rand.New(rand.NewSource(1))}
+	if err := quick.Check(prop, cfg); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestViewportPropertyScrollRoundTrip(t *testing.T) {
+	// Scrolling down then up by the same amount from the top returns to the
+	// top, regardless of content length or viewport size.
+	prop := func(sc viewportScenario, n uint8) bool {
+		v := NewViewport(sc.width, sc.height)
+		v.SetContent(randomContent(rand.New(rand.NewSource(int64(n)))))
+		v.ScrollDown(int(n))
+		v.ScrollUp(int(n))
+		return v.AtTop()
+	}
+	cfg := &quick.Config{MaxCount: 200, Rand: rand.New(rand.NewSource(2))}
+	if err := quick.Check(prop, cfg); err != nil {
+		t.Error(err)
+	}
+}
@@ -1,6 +1,7 @@
 package app
 
 import (
+	"fmt"
 	"strings"
 	"testing"
 )
@@ -1075,3 +1076,176 @@ func TestHorizontalCentering(t *testing.T) {
 		t.Error("content should have leading spaces when centered in wide viewport")
 	}
 }
+
+func TestCutColumns(t *testing.T) {
+	red, reset := "\x1b[31m", "\x1b[0m"
+	tests := []struct {
+		name, line  string
+		start, cols int
+		want        string
+	}{
+		{"plain", "abcdefgh", 2, 3, "cde"},
+		{"past the end", "abc", 2, 5, "c"},
+		{"style before the cut", red + "abcdef" + reset, 3, 2, red + "de" + reset},
+		{"style inside the cut", "ab" + red + "cd" + reset + "ef", 1, 4, "b" + red + "cd" + reset + "e"},
+		{"wide character split", "a世界b", 2, 3, " 界"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cutColumns(tt.line, tt.start, tt.cols); got != tt.want {
+				t.Errorf("cutColumns(%q, %d, %d) = %q, want %q", tt.line, tt.start, tt.cols, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestViewportHorizontalScroll(t *testing.T) {
+	vp := NewViewport(10, 3)
+	vp.SetContent("\x1b[1m0123456789abcdef\x1b[0m\nshort")
+
+	if info := vp.GetScrollInfo(); info.Cols != 16 || info.ColsShown != 10 || !info.ScrollsRight() || info.ScrollsLeft() {
+		t.Errorf("scroll info = %+v, want 10 of 16 columns shown from the left", info)
+	}
+	vp.ScrollRight(4)
+	view := vp.ViewWithScrollbar(DarkTheme())
+	lines := strings.Split(view, "\n")
+	if got := stripANSI(lines[0]); got != "456789abcd" {
+		t.Errorf("wide line after scrolling 4 right = %q, want %q", got, "456789abcd")
+	}
+	if !strings.Contains(lines[0], "\x1b[1m") {
+		t.Error("the bold style set before the cut should be kept")
+	}
+	if got := stripANSI(lines[1]); got != "t         " {
+		t.Errorf("short line = %q, want it cut at the same column and padded", got)
+	}
+
+	// Scrolling stops once the last column is in view.
+	vp.ScrollRight(100)
+	if vp.XOffset() != 6 || vp.GetScrollInfo().ScrollsRight() {
+		t.Errorf("XOffset = %d after scrolling past the end, want 6", vp.XOffset())
+	}
+	vp.ScrollLeft(100)
+	if vp.XOffset() != 0 {
+		t.Errorf("XOffset = %d after scrolling back, want 0", vp.XOffset())
+	}
+
+	vp.ScrollRight(4)
+	vp.SetContent("fits")
+	if vp.XOffset() != 0 || vp.GetScrollInfo().Cols != 0 {
+		t.Error("new content that fits should reset and hide the horizontal scroll")
+	}
+	vp.ScrollRight(4)
+	if vp.XOffset() != 0 {
+		t.Errorf("XOffset = %d for content that fits, want 0", vp.XOffset())
+	}
+}
+
+func TestLineAt(t *testing.T) {
+	vp := NewViewport(20, 5)
+	vp.SetContent("a\nb\nc")
+	// Three lines in five rows start one row down.
+	for row, want := range map[int]int{1: 0, 3: 2} {
+		if got, ok := vp.LineAt(row); !ok || got != want {
+			t.Errorf("LineAt(%d) = %d, %v; want %d", row, got, ok, want)
+		}
+	}
+	for _, row := range []int{-1, 0, 4, 5} {
+		if _, ok := vp.LineAt(row); ok {
+			t.Errorf("LineAt(%d) should hold no line", row)
+		}
+	}
+
+	vp.SetContent(strings.Repeat("x\n", 19) + "x")
+	vp.ScrollDown(7)
+	if got, ok := vp.LineAt(2); !ok || got != 9 {
+		t.Errorf("LineAt(2) scrolled 7 down = %d, %v; want 9", got, ok)
+	}
+}
+
+func TestViewportScrollbarGeometry(t *testing.T) {
+	for _, total := range []int{30, 200} {
+		vp := NewViewport(20, 10)
+		vp.SetContent(strings.Repeat("line\n", total-1) + "line")
+		vp.SetYOffset(7)
+		bar := vp.Scrollbar()
+
+		// The thumb is drawn in the reported column and rows.
+		rows := strings.Split(stripANSI(vp.ViewWithScrollbar(DarkTheme())), "\n")
+		for i, row := range rows {
+			cells := []rune(row)
+			onThumb := i >= bar.ThumbStart && i < bar.ThumbStart+bar.ThumbHeight
+			if got := string(cells[bar.Column]); (got == scrollThumbChar) != onThumb {
+				t.Errorf("%d lines: row %d of the scrollbar is %q, on the thumb %v", total, i, got, onThumb)
+			}
+		}
+
+		// Every thumb position maps to an offset that draws it there.
+		for start := range bar.Track() + 1 {
+			vp.SetYOffset(bar.OffsetFor(start))
+			if got := vp.Scrollbar().ThumbStart; got != start {
+				t.Errorf("%d lines: OffsetFor(%d) = %d, which draws the thumb at %d", total, start, vp.YOffset(), got)
+			}
+		}
+	}
+
+	vp := NewViewport(20, 10)
+	vp.SetContent("short")
+	if vp.Scrollbar().Rows != 0 {
+		t.Error("content that fits should report no scrollbar")
+	}
+}
+
+// numberedLines is a LineSource of n lines, "line 0" onwards, recording
+// how many lines it has been asked to render.
+type numberedLines struct {
+	n        int
+	rendered int
+}
+
+func (l *numberedLines) Len() int   { return l.n }
+func (l *numberedLines) Width() int { return len(fmt.Sprint("line ", l.n-1)) }
+func (l *numberedLines) Words() int { return 2 * l.n }
+
+func (l *numberedLines) RenderLines(start, end int) []string {
+	l.rendered += end - start
+	lines := make([]string, 0, end-start)
+	for i := start; i < end; i++ {
+		lines = append(lines, fmt.Sprint("line ", i))
+	}
+	return lines
+}
+
+func TestViewportLineSource(t *testing.T) {
+	src := &numberedLines{n: 1000}
+	text := strings.Join(src.RenderLines(0, src.n), "\n")
+	src.rendered = 0
+
+	whole := NewViewport(20, 10)
+	whole.SetContent(text)
+	vp := NewViewport(20, 10)
+	vp.SetSource(src)
+	for _, y := range []int{0, 500, 2000} {
+		whole.SetYOffset(y)
+		vp.SetYOffset(y)
+		if got, want := vp.ViewWithScrollbar(DarkTheme()), whole.ViewWithScrollbar(DarkTheme()); got != want {
+			t.Errorf("offset %d: view from the source differs from the same lines set as content:\n%s\nwant:\n%s", y, got, want)
+		}
+		if got, want := vp.GetScrollInfo(), whole.GetScrollInfo(); got != want {
+			t.Errorf("offset %d: scroll info %+v, want %+v", y, got, want)
+		}
+	}
+	if src.rendered > 3*10 {
+		t.Errorf("three views rendered %d lines, want only the 10 shown each time", src.rendered)
+	}
+
+	// Replacing the source keeps the reader at the bottom, and content
+	// set afterwards replaces it.
+	vp.SetSourcePreserveScroll(&numberedLines{n: 1200})
+	if !vp.AtBottom() || vp.TotalLines() != 1200 {
+		t.Errorf("after a longer source: offset %d of %d lines, want the bottom", vp.YOffset(), vp.TotalLines())
+	}
+	vp.SetContent("short")
+	if vp.TotalLines() != 1 || vp.View() != "short" {
+		t.Errorf("content after a source: %d lines, view %q", vp.TotalLines(), vp.View())
+	}
+}
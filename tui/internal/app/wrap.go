@@ -0,0 +1,78 @@
+package app
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// markWrapWidth is the widest wrap that gets continuation marks. Wider
+// lines hold whole clauses, so a break rarely loses the thread.
+const markWrapWidth = 40
+
+// Continuation marks, with an ASCII stand-in for terminals without color,
+// which are also the ones most likely to lack the arrow.
+const (
+	continuationGlyph      = "↪"
+	continuationASCIIGlyph = "-"
+)
+
+// WrapMarked wraps text to width like WrapText and renders each line in
+// style. When width is at most markWrapWidth, a line that carries on a
+// sentence from the line before starts with a dim continuation mark and a
+// space, and is wrapped two columns shorter to make room for them; lines
+// after a full stop, colon, or semicolon start plainly.
+func WrapMarked(theme Theme, style lipgloss.Style, text string, width int) []string {
+	if width > markWrapWidth || width < 10 {
+		lines := WrapText(text, width)
+		for i, line := range lines {
+			lines[i] = style.Render(line)
+		}
+		return lines
+	}
+
+	glyph := continuationGlyph
+	if theme.ColorProfile() == termenv.Ascii {
+		glyph = continuationASCIIGlyph
+	}
+	mark := theme.Muted.Render(glyph) + " "
+	markWidth := lipgloss.Width(glyph) + 1
+
+	var result []string
+	for _, paragraph := range strings.Split(text, "\n") {
+		words := strings.Fields(paragraph)
+		if len(words) == 0 {
+			result = append(result, "")
+			continue
+		}
+		var line []string
+		lineLen, limit, prefix := 0, width, ""
+		flush := func() {
+			result = append(result, prefix+style.Render(strings.Join(line, " ")))
+			last := line[len(line)-1]
+			limit, prefix = width, ""
+			if !strings.ContainsAny(last[len(last)-1:], ".!?:;") {
+				limit, prefix = width-markWidth, mark
+			}
+			line, lineLen = nil, 0
+		}
+		for _, word := range words {
+			wordLen := lipgloss.Width(word)
+			if lineLen > 0 && lineLen+1+wordLen > limit {
+				flush()
+			}
+			// A word that only fits without the mark goes without it.
+			if lineLen == 0 && wordLen > limit {
+				limit, prefix = width, ""
+			}
+			if lineLen > 0 {
+				lineLen++
+			}
+			line = append(line, word)
+			lineLen += wordLen
+		}
+		flush()
+	}
+	return result
+}
@@ -0,0 +1,52 @@
+package app
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestWrapMarkedNarrow(t *testing.T) {
+	theme := plainTheme()
+	got := WrapMarked(theme, theme.Body, "Building tools for people who live in terminals. Mostly Go, some Rust.", 24)
+	want := []string{
+		"Building tools for",
+		"- people who live in",
+		"- terminals. Mostly Go,",
+		"- some Rust.",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("WrapMarked = %q, want %q", got, want)
+	}
+}
+
+func TestWrapMarkedAfterSentence(t *testing.T) {
+	theme := plainTheme()
+	got := WrapMarked(theme, theme.Body, "One two three four. Five six", 19)
+	if len(got) != 2 || got[1] != "Five six" {
+		t.Errorf("a line starting a sentence should not be marked: %q", got)
+	}
+}
+
+func TestWrapMarkedWide(t *testing.T) {
+	theme := plainTheme()
+	text := strings.Repeat("word ", 30)
+	got := WrapMarked(theme, theme.Body, text, 60)
+	if want := WrapText(text, 60); !reflect.DeepEqual(got, want) {
+		t.Errorf("wide wraps should not be marked: %q", got)
+	}
+}
+
+func TestWrapMarkedFitsWidth(t *testing.T) {
+	theme := plainTheme()
+	text := "A paragraph with enough words in it to wrap several times at narrow widths"
+	for _, width := range []int{10, 20, 40} {
+		for _, line := range WrapMarked(theme, theme.Body, text, width) {
+			if w := lipgloss.Width(line); w > width {
+				t.Errorf("width %d: %q is %d columns", width, line, w)
+			}
+		}
+	}
+}
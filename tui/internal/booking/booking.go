@@ -0,0 +1,140 @@
+// Package booking previews the owner's open meeting slots for the home
+// booking callout. One Preview per server fetches them from the
+// scheduling service and caches the result, so visitors share a request
+// per refresh instead of each causing their own.
+package booking
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/buntingszn/terminal-portfolio/tui/internal/fetch"
+)
+
+// window is how far ahead a fetch asks for slots when the URL does not
+// set its own range.
+const window = 7 * 24 * time.Hour
+
+// Preview fetches upcoming open slots from a scheduling API such as
+// Cal.com's. It is safe for concurrent use; a nil Preview has no slots.
+type Preview struct {
+	url     string
+	Timeout time.Duration // per request
+	Client  *http.Client  // nil uses a client with Timeout
+	Retry   fetch.Policy
+
+	cache *fetch.Cache[[]time.Time]
+}
+
+// NewPreview returns a Preview fetching from rawURL at most once per ttl.
+func NewPreview(rawURL string, ttl time.Duration) *Preview {
+	return &Preview{
+		url:     rawURL,
+		Timeout: 5 * time.Second,
+		Retry:   fetch.DefaultPolicy,
+		cache:   fetch.NewCache[[]time.Time](ttl),
+	}
+}
+
+// Slots returns the open slots after now, soonest first. The slots, or
+// the error, of the last fetch are reused until they expire; callers that
+// arrive during a fetch wait for it rather than starting another. Each
+// refresh retries transient failures under p.Retry before giving up.
+func (p *Preview) Slots(ctx context.Context, now time.Time) ([]time.Time, error) {
+	if p == nil {
+		return nil, nil
+	}
+	slots, err := p.cache.Get(ctx, now, func(ctx context.Context) ([]time.Time, error) {
+		return fetch.Retry(ctx, p.Retry, func(ctx context.Context) ([]time.Time, error) {
+			return p.fetch(ctx, now)
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	i := slices.IndexFunc(slots, func(t time.Time) bool { return t.After(now) })
+	if i < 0 {
+		return nil, nil
+	}
+	return slots[i:], nil
+}
+
+// fetch requests the slots from now until window ahead.
+func (p *Preview) fetch(ctx context.Context, now time.Time) ([]time.Time, error) {
+	u, err := url.Parse(p.url)
+	if err != nil {
+		return nil, err
+	}
+	q := u.Query()
+	if !q.Has("start") && !q.Has("startTime") {
+		q.Set("start", now.Format(time.DateOnly))
+		q.Set("end", now.Add(window).Format(time.DateOnly))
+		u.RawQuery = q.Encode()
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, p.Timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	client := p.Client
+	if client == nil {
+		client = &http.Client{Timeout: p.Timeout}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if err := fetch.CheckStatus(resp, http.StatusOK); err != nil {
+		return nil, fmt.Errorf("booking preview: %w", err)
+	}
+	return parseSlots(io.LimitReader(resp.Body, 1<<20))
+}
+
+// slotsResponse covers both versions of Cal.com's slots API: v2 lists the
+// open slots of each day under "data" with a "start" time, and v1 under
+// "slots" with a "time".
+type slotsResponse struct {
+	Data  map[string][]slot `json:"data"`
+	Slots map[string][]slot `json:"slots"`
+}
+
+type slot struct {
+	Start string `json:"start"`
+	Time  string `json:"time"`
+}
+
+// parseSlots decodes a slots response into a sorted list of start times.
+func parseSlots(r io.Reader) ([]time.Time, error) {
+	var resp slotsResponse
+	if err := json.NewDecoder(r).Decode(&resp); err != nil {
+		return nil, fmt.Errorf("booking preview: %w", err)
+	}
+	var slots []time.Time
+	for _, days := range []map[string][]slot{resp.Data, resp.Slots} {
+		for _, day := range days {
+			for _, s := range day {
+				v := s.Start
+				if v == "" {
+					v = s.Time
+				}
+				t, err := time.Parse(time.RFC3339, v)
+				if err != nil {
+					return nil, fmt.Errorf("booking preview: slot time %q: %w", v, err)
+				}
+				slots = append(slots, t)
+			}
+		}
+	}
+	slices.SortFunc(slots, time.Time.Compare)
+	return slices.CompactFunc(slots, time.Time.Equal), nil
+}
@@ -0,0 +1,104 @@
+package booking
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParseSlots(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want []string
+	}{
+		{
+			name: "v2",
+			body: `{"status":"success","data":{"2026-10-16":[{"start":"2026-10-16T09:00:00Z"}],"2026-10-15":[{"start":"2026-10-15T14:00:00Z"},{"start":"2026-10-15T09:30:00Z"}]}}`,
+			want: []string{"2026-10-15T09:30:00Z", "2026-10-15T14:00:00Z", "2026-10-16T09:00:00Z"},
+		},
+		{
+			name: "v1",
+			body: `{"slots":{"2026-10-15":[{"time":"2026-10-15T10:00:00+01:00"}]}}`,
+			want: []string{"2026-10-15T09:00:00Z"},
+		},
+		{name: "empty", body: `{"data":{}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slots, err := parseSlots(strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, s := range slots {
+				got = append(got, s.UTC().Format(time.RFC3339))
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("slots = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := parseSlots(strings.NewReader(`{"data":{"d":[{"start":"soon"}]}}`)); err == nil {
+		t.Error("expected an error for an unparseable slot time")
+	}
+}
+
+func TestPreviewCaches(t *testing.T) {
+	var requests atomic.Int32
+	var query atomic.Value
+	var fail atomic.Bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		query.Store(r.URL.RawQuery)
+		if fail.Load() {
+			http.Error(w, "down", http.StatusBadGateway)
+			return
+		}
+		fmt.Fprint(w, `{"data":{"2026-10-15":[{"start":"2026-10-15T09:00:00Z"},{"start":"2026-10-15T15:00:00Z"}]}}`)
+	}))
+	defer srv.Close()
+
+	p := NewPreview(srv.URL+"?username=kyle", 10*time.Minute)
+	p.Retry.Backoff = time.Millisecond
+	ctx := context.Background()
+	now := time.Date(2026, 10, 15, 8, 55, 0, 0, time.UTC)
+
+	slots, err := p.Slots(ctx, now)
+	if err != nil || len(slots) != 2 {
+		t.Fatalf("Slots = %v, %v; want two slots", slots, err)
+	}
+	if q := query.Load().(string); !strings.Contains(q, "start=2026-10-15") || !strings.Contains(q, "end=2026-10-22") || !strings.Contains(q, "username=kyle") {
+		t.Errorf("query = %q, want the configured parameters plus a week's range", q)
+	}
+
+	// Within the TTL the cached slots are reused, minus those now past.
+	slots, err = p.Slots(ctx, now.Add(6*time.Minute))
+	if err != nil || len(slots) != 1 || requests.Load() != 1 {
+		t.Errorf("cached Slots = %v, %v after %d requests; want one slot and no new request", slots, err, requests.Load())
+	}
+
+	// A failure is retried at once, then reported, and refreshed again
+	// sooner than the TTL.
+	fail.Store(true)
+	if _, err := p.Slots(ctx, now.Add(11*time.Minute)); err == nil || requests.Load() != 4 {
+		t.Errorf("failed refresh: err = %v after %d requests, want an error after three attempts", err, requests.Load())
+	}
+	fail.Store(false)
+	if _, err := p.Slots(ctx, now.Add(12*time.Minute+time.Second)); err != nil || requests.Load() != 5 {
+		t.Errorf("retry: err = %v after %d requests, want a fifth request that succeeds", err, requests.Load())
+	}
+}
+
+func TestNilPreview(t *testing.T) {
+	var p *Preview
+	if slots, err := p.Slots(context.Background(), time.Now()); slots != nil || err != nil {
+		t.Errorf("nil Preview Slots = %v, %v", slots, err)
+	}
+}
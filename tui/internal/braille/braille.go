@@ -0,0 +1,180 @@
+// Package braille converts images to Braille halftone art, the text
+// portraits shown on terminals that cannot display the photo itself. Each
+// Braille character (U+2800 to U+28FF) covers a 2×4 grid of dots, so an
+// image is scaled to twice the art's width and four times its height in
+// pixels, enhanced to keep detail at that size, dithered to black and
+// white, and read off one block at a time.
+package braille
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	_ "image/jpeg" // decode JPEG portraits
+	_ "image/png"  // decode PNG portraits
+	"os"
+	"strings"
+)
+
+// Dither is an error diffusion algorithm used to reduce the enhanced
+// image to black and white dots.
+type Dither int
+
+const (
+	Atkinson       Dither = iota // diffuses 6/8 of the error, for crisp contrast
+	FloydSteinberg               // diffuses all of it, for smoother tones
+)
+
+// String returns the dither's flag name.
+func (d Dither) String() string {
+	if d == FloydSteinberg {
+		return "floyd-steinberg"
+	}
+	return "atkinson"
+}
+
+// ParseDither returns the dither named by s ("atkinson" or
+// "floyd-steinberg"). It reports false for any other name.
+func ParseDither(s string) (Dither, bool) {
+	switch s {
+	case "atkinson":
+		return Atkinson, true
+	case "floyd-steinberg":
+		return FloydSteinberg, true
+	}
+	return Atkinson, false
+}
+
+// Options controls a conversion.
+type Options struct {
+	// Width is the art's width in characters.
+	Width int
+	// Rows, when set, is the art's height in lines: the image is
+	// center-cropped to that box, the way the photo portrait is, so the
+	// art can stand in for it. Zero keeps the image's aspect ratio and
+	// drops blank lines at the bottom.
+	Rows int
+	// Invert fills the dots for light pixels instead of dark ones, for
+	// light text on a dark background.
+	Invert bool
+	Dither Dither
+	// Contrast is the local contrast (CLAHE) clip limit; higher values
+	// bring out more detail in flat regions.
+	Contrast float64
+	// Sharpen is the unsharp mask strength, 1 for 100%. Zero skips it.
+	Sharpen float64
+	// Gamma below 1 brightens midtones and above 1 darkens them.
+	Gamma float64
+}
+
+// DefaultOptions returns the settings the built-in portrait was made
+// with, at a width of 25 characters.
+func DefaultOptions() Options {
+	return Options{
+		Width:    25,
+		Dither:   Atkinson,
+		Contrast: 1.5,
+		Sharpen:  1.5,
+		Gamma:    1,
+	}
+}
+
+// Load reads a PNG or JPEG from path and converts it. A missing file
+// returns an error satisfying errors.Is(err, os.ErrNotExist).
+func Load(path string, opts Options) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	src, _, err := image.Decode(f)
+	if err != nil {
+		return "", fmt.Errorf("decode %s: %w", path, err)
+	}
+	return Convert(src, opts)
+}
+
+// Convert returns src as Braille art, one line per row of characters.
+func Convert(src image.Image, opts Options) (string, error) {
+	if opts.Width < 1 || opts.Rows < 0 {
+		return "", errors.New("braille art must be at least one character wide")
+	}
+	if src.Bounds().Empty() {
+		return "", errors.New("image is empty")
+	}
+
+	g := grayscale(src)
+	g.autocontrast(0.005)
+
+	w := opts.Width * 2
+	var h int
+	if opts.Rows > 0 {
+		h = opts.Rows * 4
+		g = g.crop(w, h)
+	} else {
+		// Round up to whole characters.
+		h = (g.h*w/g.w + 3) / 4 * 4
+	}
+	g = g.resize(w, h)
+
+	g = g.clahe(opts.Contrast, claheGrid)
+	if opts.Sharpen > 0 {
+		g = g.unsharp(unsharpRadius, opts.Sharpen, unsharpThreshold)
+	}
+	if opts.Gamma > 0 && opts.Gamma != 1 {
+		g.gamma(opts.Gamma)
+	}
+
+	if opts.Dither == FloydSteinberg {
+		g.floydSteinberg()
+	} else {
+		g.atkinson()
+	}
+	lines := g.braille(opts.Invert)
+	if opts.Rows == 0 {
+		for len(lines) > 0 && strings.Trim(lines[len(lines)-1], blank) == "" {
+			lines = lines[:len(lines)-1]
+		}
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// blank is the Braille character with no dots raised.
+const blank = "⠀"
+
+// dotBits maps each dot of a character's 2×4 grid, by row and column, to
+// its bit in the codepoint: dots 1-3 and 4-6 run down the left and right
+// columns, and dots 7 and 8 were added below them later.
+var dotBits = [4][2]rune{
+	{0x01, 0x08},
+	{0x02, 0x10},
+	{0x04, 0x20},
+	{0x40, 0x80},
+}
+
+// braille reads the dithered image off in 2×4 blocks, raising a dot for
+// each dark pixel, or each light one when invert is set.
+func (g *gray) braille(invert bool) []string {
+	var lines []string
+	for by := 0; by < g.h; by += 4 {
+		var b strings.Builder
+		for bx := 0; bx < g.w; bx += 2 {
+			r := rune(0x2800)
+			for row := range 4 {
+				for col := range 2 {
+					x, y := bx+col, by+row
+					if x >= g.w || y >= g.h {
+						continue
+					}
+					if dark := g.at(x, y) < 127.5; dark != invert {
+						r |= dotBits[row][col]
+					}
+				}
+			}
+			b.WriteRune(r)
+		}
+		lines = append(lines, b.String())
+	}
+	return lines
+}
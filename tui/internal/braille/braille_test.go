@@ -0,0 +1,180 @@
+package braille
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// testPicture returns a w×h image, black on its left half and white on
+// its right.
+func testPicture(w, h int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := range h {
+		for x := range w {
+			c := color.RGBA{A: 255}
+			if x >= w/2 {
+				c = color.RGBA{R: 255, G: 255, B: 255, A: 255}
+			}
+			img.SetRGBA(x, y, c)
+		}
+	}
+	return img
+}
+
+func TestConvertSize(t *testing.T) {
+	opts := DefaultOptions()
+	opts.Width = 20
+	art, err := Convert(testPicture(200, 400), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(art, "\n")
+	// 40 pixels wide and 80 tall make 20 rows of 4.
+	if len(lines) != 20 {
+		t.Errorf("got %d lines, want 20", len(lines))
+	}
+	for i, line := range lines {
+		if n := utf8.RuneCountInString(line); n != 20 {
+			t.Errorf("line %d is %d characters, want 20", i, n)
+		}
+	}
+
+	// A fixed box is cropped to rather than scaled.
+	opts.Rows = 5
+	if art, _ = Convert(testPicture(200, 400), opts); strings.Count(art, "\n") != 4 {
+		t.Errorf("with Rows 5 got %d lines, want 5", strings.Count(art, "\n")+1)
+	}
+}
+
+func TestConvertDots(t *testing.T) {
+	for _, d := range []Dither{Atkinson, FloydSteinberg} {
+		opts := DefaultOptions()
+		opts.Width, opts.Dither = 8, d
+		art, err := Convert(testPicture(80, 80), opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Local contrast lifts the black half a little, so it dithers to
+		// most of its dots; the white half has none.
+		line := strings.Split(art, "\n")[1]
+		left, right := string([]rune(line)[:4]), string([]rune(line)[4:])
+		if strings.Contains(left, blank) || right != strings.Repeat(blank, 4) {
+			t.Errorf("%v: row %q, want dots on the left and blank on the right", d, line)
+		}
+
+		opts.Invert = true
+		art, _ = Convert(testPicture(80, 80), opts)
+		if line := strings.Split(art, "\n")[1]; !strings.HasSuffix(line, "⣿⣿⣿⣿") {
+			t.Errorf("%v inverted: row %q, want the white half full", d, line)
+		}
+	}
+}
+
+func TestConvertTrimsBlankRows(t *testing.T) {
+	img := testPicture(40, 80)
+	// Clear the bottom half, leaving it white.
+	for y := 40; y < 80; y++ {
+		for x := range 40 {
+			img.SetRGBA(x, y, color.RGBA{R: 255, G: 255, B: 255, A: 255})
+		}
+	}
+	opts := DefaultOptions()
+	opts.Width, opts.Sharpen = 20, 0
+	art, err := Convert(img, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Split(art, "\n"); len(lines) >= 20 || strings.Trim(lines[len(lines)-1], blank) == "" {
+		t.Errorf("got %d lines ending in %q, want the blank bottom rows dropped", len(lines), lines[len(lines)-1])
+	}
+}
+
+func TestConvertGradient(t *testing.T) {
+	// A left-to-right gradient dithers to dots thinning out across it.
+	img := image.NewGray(image.Rect(0, 0, 200, 100))
+	for y := range 100 {
+		for x := range 200 {
+			img.SetGray(x, y, color.Gray{Y: uint8(x * 255 / 199)})
+		}
+	}
+	opts := DefaultOptions()
+	opts.Width, opts.Contrast = 40, 0
+	art, err := Convert(img, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dots := func(s string) int {
+		n := 0
+		for _, r := range s {
+			for b := r - 0x2800; b != 0; b &= b - 1 {
+				n++
+			}
+		}
+		return n
+	}
+	var left, right int
+	for _, line := range strings.Split(art, "\n") {
+		runes := []rune(line)
+		left += dots(string(runes[:10]))
+		right += dots(string(runes[30:]))
+	}
+	if left <= right {
+		t.Errorf("dark end has %d dots and light end %d, want more on the dark end", left, right)
+	}
+}
+
+func TestConvertErrors(t *testing.T) {
+	if _, err := Convert(testPicture(10, 10), Options{}); err == nil {
+		t.Error("a zero width should be an error")
+	}
+	if _, err := Convert(image.NewRGBA(image.Rect(0, 0, 0, 0)), DefaultOptions()); err == nil {
+		t.Error("an empty image should be an error")
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := Load(filepath.Join(dir, "missing.png"), DefaultOptions()); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("missing file: err = %v, want os.ErrNotExist", err)
+	}
+
+	path := filepath.Join(dir, "portrait.jpg")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := jpeg.Encode(f, testPicture(60, 60), nil); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+	art, err := Load(path, DefaultOptions())
+	if err != nil || art == "" {
+		t.Errorf("Load of a JPEG = %q, %v", art, err)
+	}
+
+	bad := filepath.Join(dir, "bad.png")
+	if err := os.WriteFile(bad, []byte("not an image"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(bad, DefaultOptions()); err == nil {
+		t.Error("an undecodable file should be an error")
+	}
+}
+
+func TestParseDither(t *testing.T) {
+	for _, d := range []Dither{Atkinson, FloydSteinberg} {
+		if got, ok := ParseDither(d.String()); !ok || got != d {
+			t.Errorf("ParseDither(%q) = %v, %v", d.String(), got, ok)
+		}
+	}
+	if _, ok := ParseDither("ordered"); ok {
+		t.Error("an unknown dither should not parse")
+	}
+}
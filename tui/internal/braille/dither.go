@@ -0,0 +1,56 @@
+package braille
+
+// threshold rounds the pixel at x, y to black or white and returns the
+// error made, for diffusing to its neighbors.
+func (g *gray) threshold(x, y int) float64 {
+	old := g.at(x, y)
+	v := 0.0
+	if old > 127.5 {
+		v = 255
+	}
+	g.set(x, y, v)
+	return old - v
+}
+
+// spread adds err to the pixel at x, y if it is in the image.
+func (g *gray) spread(x, y int, err float64) {
+	if x >= 0 && x < g.w && y >= 0 && y < g.h {
+		g.pix[y*g.w+x] += err
+	}
+}
+
+// atkinson dithers the image as Bill Atkinson did for the original
+// Macintosh: an eighth of each pixel's error goes to each of six
+// neighbors, and the last quarter is dropped, which keeps the contrast
+// that portraits at this size need.
+//
+//	  X 1 1
+//	1 1 1
+//	  1
+func (g *gray) atkinson() {
+	for y := range g.h {
+		for x := range g.w {
+			err := g.threshold(x, y) / 8
+			g.spread(x+1, y, err)
+			g.spread(x+2, y, err)
+			g.spread(x-1, y+1, err)
+			g.spread(x, y+1, err)
+			g.spread(x+1, y+1, err)
+			g.spread(x, y+2, err)
+		}
+	}
+}
+
+// floydSteinberg dithers the image with classic Floyd–Steinberg error
+// diffusion, passing on all of each pixel's error.
+func (g *gray) floydSteinberg() {
+	for y := range g.h {
+		for x := range g.w {
+			err := g.threshold(x, y)
+			g.spread(x+1, y, err*7/16)
+			g.spread(x-1, y+1, err*3/16)
+			g.spread(x, y+1, err*5/16)
+			g.spread(x+1, y+1, err*1/16)
+		}
+	}
+}
@@ -0,0 +1,264 @@
+package braille
+
+import (
+	"image"
+	"math"
+)
+
+// Enhancement settings fixed for every conversion: CLAHE works on an 8×8
+// grid of tiles, and the unsharp mask blurs with a 1.5 pixel radius and
+// leaves differences under 2 levels alone so flat areas stay smooth.
+const (
+	claheGrid        = 8
+	unsharpRadius    = 1.5
+	unsharpThreshold = 2
+)
+
+// gray is a grayscale image with levels from 0 (black) to 255 (white).
+// Levels stay fractional while dithering spreads error between pixels.
+type gray struct {
+	w, h int
+	pix  []float64
+}
+
+func newGray(w, h int) *gray {
+	return &gray{w: w, h: h, pix: make([]float64, w*h)}
+}
+
+func (g *gray) at(x, y int) float64 {
+	return g.pix[y*g.w+x]
+}
+
+func (g *gray) set(x, y int, v float64) {
+	g.pix[y*g.w+x] = v
+}
+
+// level rounds v to a whole level from 0 to 255.
+func level(v float64) float64 {
+	return math.Round(max(0, min(255, v)))
+}
+
+// grayscale converts src by its luma, as seen over a white background so
+// transparent areas come out blank rather than black.
+func grayscale(src image.Image) *gray {
+	b := src.Bounds()
+	g := newGray(b.Dx(), b.Dy())
+	for y := range g.h {
+		for x := range g.w {
+			r, gr, bl, a := src.At(b.Min.X+x, b.Min.Y+y).RGBA()
+			// RGBA is premultiplied, so the white behind shows through
+			// as 0xffff-a on every channel.
+			bg := 0xffff - a
+			luma := 0.299*float64(r+bg) + 0.587*float64(gr+bg) + 0.114*float64(bl+bg)
+			g.set(x, y, level(luma/0xffff*255))
+		}
+	}
+	return g
+}
+
+// histogram counts the pixels at each level.
+func (g *gray) histogram() [256]int {
+	var hist [256]int
+	for _, v := range g.pix {
+		hist[int(level(v))]++
+	}
+	return hist
+}
+
+// autocontrast stretches the levels to the full range after ignoring the
+// darkest and lightest cutoff fraction of the pixels.
+func (g *gray) autocontrast(cutoff float64) {
+	hist := g.histogram()
+	cut := int(float64(len(g.pix)) * cutoff)
+	lo, hi := 0, 255
+	for n := 0; lo < 255; lo++ {
+		if n += hist[lo]; n > cut {
+			break
+		}
+	}
+	for n := 0; hi > 0; hi-- {
+		if n += hist[hi]; n > cut {
+			break
+		}
+	}
+	if hi <= lo {
+		return
+	}
+	scale := 255 / float64(hi-lo)
+	for i, v := range g.pix {
+		g.pix[i] = level((v - float64(lo)) * scale)
+	}
+}
+
+// crop center-crops the image to the aspect ratio of w×h. An image that
+// is too tall loses its bottom instead, keeping the top where faces sit.
+func (g *gray) crop(w, h int) *gray {
+	x0, y0, cw, ch := 0, 0, g.w, g.h
+	if g.w*h > g.h*w {
+		cw = max(1, g.h*w/h)
+		x0 = (g.w - cw) / 2
+	} else {
+		ch = max(1, g.w*h/w)
+	}
+	out := newGray(cw, ch)
+	for y := range ch {
+		copy(out.pix[y*cw:(y+1)*cw], g.pix[(y0+y)*g.w+x0:])
+	}
+	return out
+}
+
+// resize scales the image to exactly w×h, averaging the source pixels
+// behind each destination pixel.
+func (g *gray) resize(w, h int) *gray {
+	out := newGray(w, h)
+	for y := range h {
+		y0 := y * g.h / h
+		y1 := max(y0+1, (y+1)*g.h/h)
+		for x := range w {
+			x0 := x * g.w / w
+			x1 := max(x0+1, (x+1)*g.w/w)
+			var sum float64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					sum += g.at(sx, sy)
+				}
+			}
+			out.set(x, y, level(sum/float64((y1-y0)*(x1-x0))))
+		}
+	}
+	return out
+}
+
+// clahe applies contrast limited adaptive histogram equalization: each of
+// grid×grid tiles is equalized with its histogram clipped at clip times
+// the average bin, the clipped excess shared out evenly, and every pixel
+// is mapped through the tables of the four nearest tiles, weighted by
+// distance, so the tiles blend without seams.
+func (g *gray) clahe(clip float64, grid int) *gray {
+	tileW, tileH := max(g.w/grid, 1), max(g.h/grid, 1)
+	luts := make([][256]float64, grid*grid)
+	for ty := range grid {
+		for tx := range grid {
+			x0, y0 := tx*tileW, ty*tileH
+			x1, y1 := min(x0+tileW, g.w), min(y0+tileH, g.h)
+			luts[ty*grid+tx] = g.tileLUT(x0, y0, x1, y1, clip)
+		}
+	}
+
+	// nearest returns the two tiles either side of a pixel's position and
+	// the weight of the second.
+	nearest := func(pos, tile int) (int, int, float64) {
+		f := (float64(pos) - float64(tile)/2) / float64(tile)
+		t1 := max(0, min(int(math.Floor(f)), grid-1))
+		return t1, min(t1+1, grid-1), max(0, min(1, f-float64(t1)))
+	}
+
+	out := newGray(g.w, g.h)
+	for y := range g.h {
+		ty1, ty2, wy := nearest(y, tileH)
+		for x := range g.w {
+			tx1, tx2, wx := nearest(x, tileW)
+			v := int(level(g.at(x, y)))
+			top := luts[ty1*grid+tx1][v]*(1-wx) + luts[ty1*grid+tx2][v]*wx
+			bottom := luts[ty2*grid+tx1][v]*(1-wx) + luts[ty2*grid+tx2][v]*wx
+			out.set(x, y, level(top*(1-wy)+bottom*wy))
+		}
+	}
+	return out
+}
+
+// tileLUT returns the clipped equalization table for the pixels in
+// [x0,x1)×[y0,y1). A tile past the edge of a small image is left as it is.
+func (g *gray) tileLUT(x0, y0, x1, y1 int, clip float64) [256]float64 {
+	var lut [256]float64
+	n := max(0, x1-x0) * max(0, y1-y0)
+	if n == 0 {
+		for i := range lut {
+			lut[i] = float64(i)
+		}
+		return lut
+	}
+
+	var hist [256]int
+	for y := y0; y < y1; y++ {
+		for x := x0; x < x1; x++ {
+			hist[int(level(g.at(x, y)))]++
+		}
+	}
+	limit := max(int(clip*float64(n)/256), 1)
+	excess := 0
+	for i := range hist {
+		if hist[i] > limit {
+			excess += hist[i] - limit
+			hist[i] = limit
+		}
+	}
+	for i := range hist {
+		hist[i] += excess / 256
+		if i < excess%256 {
+			hist[i]++
+		}
+	}
+
+	cdf := 0
+	for i := range hist {
+		cdf += hist[i]
+		lut[i] = math.Floor(255 * float64(cdf) / float64(n))
+	}
+	return lut
+}
+
+// unsharp sharpens edges by adding back amount times the difference from
+// a Gaussian blur of radius, wherever it is at least threshold levels.
+func (g *gray) unsharp(radius, amount, threshold float64) *gray {
+	blurred := g.blur(radius)
+	out := newGray(g.w, g.h)
+	for i, v := range g.pix {
+		diff := v - blurred.pix[i]
+		if math.Abs(diff) >= threshold {
+			v += diff * amount
+		}
+		out.pix[i] = level(v)
+	}
+	return out
+}
+
+// blur returns a Gaussian blur of the image with standard deviation
+// sigma, repeating the edge pixels beyond the border.
+func (g *gray) blur(sigma float64) *gray {
+	r := int(math.Ceil(3 * sigma))
+	kernel := make([]float64, 2*r+1)
+	var sum float64
+	for i := range kernel {
+		d := float64(i - r)
+		kernel[i] = math.Exp(-d * d / (2 * sigma * sigma))
+		sum += kernel[i]
+	}
+	for i := range kernel {
+		kernel[i] /= sum
+	}
+
+	pass := func(src *gray, dx, dy int) *gray {
+		out := newGray(src.w, src.h)
+		for y := range src.h {
+			for x := range src.w {
+				var v float64
+				for i, k := range kernel {
+					sx := max(0, min(src.w-1, x+(i-r)*dx))
+					sy := max(0, min(src.h-1, y+(i-r)*dy))
+					v += k * src.at(sx, sy)
+				}
+				out.set(x, y, v)
+			}
+		}
+		return out
+	}
+	return pass(pass(g, 1, 0), 0, 1)
+}
+
+// gamma applies gamma correction to the levels.
+func (g *gray) gamma(gamma float64) {
+	for i, v := range g.pix {
+		g.pix[i] = level(255 * math.Pow(v/255, gamma))
+	}
+}
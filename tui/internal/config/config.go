@@ -2,44 +2,263 @@ package config
 
 import (
 	"fmt"
+	"log/slog"
+	"net"
+	"net/netip"
+	"net/url"
 	"os"
+	"reflect"
 	"strconv"
+	"strings"
 	"time"
 )
 
 // Config holds the application configuration.
 type Config struct {
-	SSHHost     string
-	SSHPort     int
-	DataDir     string
-	MaxSessions int
+	SSHHost string
+	SSHPort int
+	// WebAddr is the host:port cmd/webserver serves the browser terminal
+	// on.
+	WebAddr string
+	// DataDir is the data directory: a local path, or a remote source
+	// (an https:// bundle URL or a git+ repository URL, optionally pinned
+	// to a commit or tag) copied into ContentCache. Its content files
+	// override the defaults built into the binary.
+	DataDir string
+	// PartialContent keeps serving when some content files fail to load,
+	// hiding the sections built from them, instead of refusing to start.
+	// meta.json must still load.
+	PartialContent bool
+	MaxSessions    int
+	// RateLimit is how many connections one client IP may open per
+	// RateWindow. A value of 0 disables rate limiting.
+	RateLimit  int
+	RateWindow time.Duration
+	// AutoBanStrikes bans an IP once the rate limiter has refused it this
+	// many times within AutoBanWindow. Its first ban lasts AutoBanDuration
+	// and each later one twice the one before, up to AutoBanMax. Bans are
+	// kept next to the guestbook, so a restart keeps them. A value of 0
+	// disables auto-bans.
+	AutoBanStrikes  int
+	AutoBanWindow   time.Duration
+	AutoBanDuration time.Duration
+	AutoBanMax      time.Duration
 	// IdleTimeout controls how long a session can remain idle before being
 	// disconnected. A value of 0 disables idle timeout entirely.
 	IdleTimeout time.Duration
+	// ScreensaverAfter is how long a session can be idle before an
+	// animated screensaver covers it; any key returns to the portfolio.
+	// A value of 0 disables the screensaver.
+	ScreensaverAfter time.Duration
+	// DrainTimeout is how long the server keeps open sessions after a
+	// shutdown signal, showing them a countdown, before closing them. New
+	// sessions are refused meanwhile. A value of 0 shuts down at once.
+	DrainTimeout time.Duration
+	// ResumeWindow is how long a dropped session's place is kept for its
+	// SSH key, so reconnecting within it skips the intro and lands where
+	// the visitor was. A value of 0 disables resuming, and public key
+	// authentication is then left to owner keys.
+	ResumeWindow time.Duration
 	// AnalyticsFile is the path to the JSONL analytics log file.
 	// An empty string disables analytics logging.
 	AnalyticsFile string
-	Debug         bool
+	// AnalyticsMaxSizeMB rotates the analytics file once it reaches this
+	// many megabytes, and AnalyticsRotate ("daily", "weekly", or "off")
+	// when a day or week ends. Rotated files are gzipped next to it and
+	// deleted after AnalyticsRetentionDays; 0 disables each limit.
+	AnalyticsMaxSizeMB     int
+	AnalyticsRotate        string
+	AnalyticsRetentionDays int
+	// AnalyticsDSN stores analytics in a SQLite database instead of the
+	// JSONL file when set. The rotation settings do not apply to it.
+	AnalyticsDSN string
+	Debug        bool
+	// LogLevel is the least severe level logged: "debug", "info", "warn",
+	// or "error". It defaults to "debug" with Debug and "info" otherwise.
+	LogLevel string
+	// NavWrap controls whether next/prev section navigation wraps around
+	// from the last section to the first. Enabled by default.
+	NavWrap bool
+	// ReorderRTL puts right-to-left content, such as Hebrew or Arabic, in
+	// display order for terminals that draw text as it arrives. Enabled
+	// by default.
+	ReorderRTL bool
+	// ReducedMotion starts sessions without the intro boot sequence, the
+	// slide between sections, or the portrait shimmer and bio reveal.
+	// Visitors can switch with :motion.
+	ReducedMotion bool
+	// SkipIntro decides which sessions start without the intro boot
+	// sequence: "off" shows it to everyone; "returning" skips it for
+	// visitors who have connected before, remembered as RememberVisitors
+	// describes; "always" skips it for everyone. Visitors can still replay
+	// it with :intro.
+	SkipIntro string
+	// RememberVisitors greets returning visitors on the home section with
+	// when they last visited, without the bio reveal. Visitors are told
+	// apart by a salted hash of their SSH key, or of their IP when they
+	// offer none, kept next to the guestbook. Disabled by default.
+	RememberVisitors bool
+	// SlowLink decides which sessions are throttled for slow links, with
+	// reduced motion, no screensaver, and fewer frames a second: "auto"
+	// throttles sessions whose output backs up, or whose client sets
+	// SLOW=1; "on" throttles every session; "off" none.
+	SlowLink string
+	// ContentReview renders dim placeholders where optional content blocks
+	// are missing, so the owner can spot gaps. Not for public deployments.
+	ContentReview bool
+	// Bell rings the terminal bell when a broadcast, such as a restart
+	// warning, reaches a session. The status bar flashes either way.
+	Bell bool
+	// Theme is the initial color theme for sessions: "dark", "light", or
+	// "auto" to match each client's terminal background.
+	Theme string
+	// StatusBar is the status bar's mode: "hints" for the static hints, or
+	// "progress" to add the section's name and a scroll gauge. Visitors
+	// can switch with :statusbar.
+	StatusBar string
+	// Graphics selects the inline image protocol used for the portrait
+	// photo: "kitty", "sixel", "iterm2", "off", or "auto" to probe each
+	// client's terminal.
+	Graphics string
+	// ContentRefresh is how often a remote DataDir is checked for new
+	// content. A value of 0 fetches it only at startup.
+	ContentRefresh time.Duration
+	// ContentCache is the directory remote content is copied into.
+	ContentCache string
+	// ContentSHA256 pins the checksum of a remote bundle. Empty reads the
+	// checksum published next to the bundle.
+	ContentSHA256 string
+	// ContentPublicKey is a base64 ed25519 public key. When set, remote
+	// bundles are only used if signed with the matching private key.
+	ContentPublicKey string
+	// Summary schedules an analytics summary for the owner: "daily",
+	// "weekly", or "off". It is sent to SummaryWebhook and, through the
+	// SMTP server at SMTPURL, to SummaryEmail.
+	Summary        string
+	SummaryWebhook string
+	SummaryEmail   string
+	SMTPURL        string
+	// Status shows the status section, with the health of the services
+	// in StatusChecks checked every StatusInterval: "public" for every
+	// visitor, "owner" only for sessions authenticated with a key in
+	// OwnerKeys, or "off".
+	Status         string
+	StatusChecks   string
+	StatusInterval time.Duration
+	// BookingPreviewURL is a scheduling API endpoint, such as Cal.com's
+	// slots API, whose upcoming open slots the home booking callout shows.
+	// They are fetched at most once every BookingPreviewTTL.
+	BookingPreviewURL string
+	BookingPreviewTTL time.Duration
+	// GitHubToken authenticates the GitHub API requests that add stars,
+	// language, and last commit badges to work projects with a repo. They
+	// are refreshed every RepoStatsInterval; empty shows no badges.
+	GitHubToken       string
+	RepoStatsInterval time.Duration
+	// OwnerKeys is an authorized_keys file listing the owner's SSH public
+	// keys. Sessions signed in with one also see the admin section.
+	OwnerKeys string
+	// AllowIPs and DenyIPs filter SSH clients by address before their
+	// handshake: comma-separated IPs or CIDR ranges, such as
+	// "192.0.2.0/24, 2001:db8::1". Addresses in DenyIPs are refused; when
+	// AllowIPs is set, so are addresses outside it.
+	AllowIPs string
+	DenyIPs  string
+	// Auth decides who may connect, for a private deployment: "open"
+	// lets anyone in; "keys" only clients signed in with a key listed in
+	// the AuthorizedKeys file or OwnerKeys; "password" only clients that
+	// give AuthPassword, or sign in with an owner key. AuthMaxFailures
+	// failed sign-ins from one IP lock it out for AuthLockout; 0 disables
+	// the lockout.
+	Auth            string
+	AuthorizedKeys  string
+	AuthPassword    string
+	AuthMaxFailures int
+	AuthLockout     time.Duration
+	// VerifyKey is an unencrypted SSH private key that signs the
+	// statement `ssh host verify` prints, listing the owner's accounts
+	// and domains; empty leaves the command without a statement.
+	VerifyKey string
+	// Chaos flags inject faults into every session to exercise the
+	// resilience features by hand. They require Debug.
+	ChaosLatency    time.Duration // delay before each input event
+	ChaosDropFrames float64       // probability a frame is dropped
+	ChaosShrink     time.Duration // average interval between fake resizes
 }
 
 // Load reads configuration from TERMINAL_PORTFOLIO_ environment variables
-// with sensible defaults.
+// with sensible defaults. When TERMINAL_PORTFOLIO_ENV_FILE names a file of
+// KEY=VALUE lines, as systemd's EnvironmentFile reads, its variables
+// override the environment, so loading again after the file is edited
+// picks up the edits. A variable removed from the file falls back to the
+// environment the process started with.
 func Load() (*Config, error) {
+	path := os.Getenv("TERMINAL_PORTFOLIO_ENV_FILE")
+	if path == "" {
+		return load(os.LookupEnv)
+	}
+	vars, err := readEnvFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return load(func(key string) (string, bool) {
+		if v, ok := vars[key]; ok {
+			return v, true
+		}
+		return os.LookupEnv(key)
+	})
+}
+
+// load reads configuration from the variables lookup finds.
+func load(lookup func(string) (string, bool)) (*Config, error) {
+	getenv := func(key string) string {
+		v, _ := lookup(key)
+		return v
+	}
 	cfg := &Config{
-		SSHHost:       "127.0.0.1",
-		SSHPort:       2222,
-		DataDir:       "../data",
-		MaxSessions:   100,
-		IdleTimeout:   30 * time.Minute,
-		AnalyticsFile: "analytics.jsonl",
-		Debug:         false,
+		SSHHost:                "127.0.0.1",
+		SSHPort:                2222,
+		WebAddr:                "127.0.0.1:8080",
+		DataDir:                "../data",
+		MaxSessions:            100,
+		RateLimit:              10,
+		RateWindow:             time.Minute,
+		AutoBanWindow:          10 * time.Minute,
+		AutoBanDuration:        10 * time.Minute,
+		AutoBanMax:             24 * time.Hour,
+		IdleTimeout:            30 * time.Minute,
+		ScreensaverAfter:       5 * time.Minute,
+		ResumeWindow:           3 * time.Minute,
+		AnalyticsFile:          "analytics.jsonl",
+		AnalyticsMaxSizeMB:     100,
+		AnalyticsRotate:        "off",
+		AnalyticsRetentionDays: 90,
+		Debug:                  false,
+		LogLevel:               "info",
+		NavWrap:                true,
+		ReorderRTL:             true,
+		Theme:                  "auto",
+		StatusBar:              "hints",
+		Graphics:               "auto",
+		SlowLink:               "auto",
+		SkipIntro:              "off",
+		ContentRefresh:         5 * time.Minute,
+		ContentCache:           "content-cache",
+		Summary:                "off",
+		Status:                 "off",
+		StatusInterval:         time.Minute,
+		BookingPreviewTTL:      15 * time.Minute,
+		RepoStatsInterval:      time.Hour,
+		Auth:                   "open",
+		AuthMaxFailures:        5,
+		AuthLockout:            15 * time.Minute,
 	}
 
-	if v := os.Getenv("TERMINAL_PORTFOLIO_SSH_HOST"); v != "" {
+	if v := getenv("TERMINAL_PORTFOLIO_SSH_HOST"); v != "" {
 		cfg.SSHHost = v
 	}
 
-	if v := os.Getenv("TERMINAL_PORTFOLIO_SSH_PORT"); v != "" {
+	if v := getenv("TERMINAL_PORTFOLIO_SSH_PORT"); v != "" {
 		port, err := strconv.Atoi(v)
 		if err != nil {
 			return nil, fmt.Errorf("invalid SSH port: %w", err)
@@ -47,11 +266,15 @@ func Load() (*Config, error) {
 		cfg.SSHPort = port
 	}
 
-	if v := os.Getenv("TERMINAL_PORTFOLIO_DATA_DIR"); v != "" {
+	if v := getenv("TERMINAL_PORTFOLIO_WEB_ADDR"); v != "" {
+		cfg.WebAddr = v
+	}
+
+	if v := getenv("TERMINAL_PORTFOLIO_DATA_DIR"); v != "" {
 		cfg.DataDir = v
 	}
 
-	if v := os.Getenv("TERMINAL_PORTFOLIO_MAX_SESSIONS"); v != "" {
+	if v := getenv("TERMINAL_PORTFOLIO_MAX_SESSIONS"); v != "" {
 		n, err := strconv.Atoi(v)
 		if err != nil {
 			return nil, fmt.Errorf("invalid max sessions: %w", err)
@@ -59,7 +282,55 @@ func Load() (*Config, error) {
 		cfg.MaxSessions = n
 	}
 
-	if v := os.Getenv("TERMINAL_PORTFOLIO_IDLE_TIMEOUT"); v != "" {
+	if v := getenv("TERMINAL_PORTFOLIO_RATE_LIMIT"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rate limit: %w", err)
+		}
+		cfg.RateLimit = n
+	}
+
+	if v := getenv("TERMINAL_PORTFOLIO_RATE_WINDOW"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rate window: %w", err)
+		}
+		cfg.RateWindow = d
+	}
+
+	if v := getenv("TERMINAL_PORTFOLIO_AUTOBAN_STRIKES"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid auto-ban strikes: %w", err)
+		}
+		cfg.AutoBanStrikes = n
+	}
+
+	if v := getenv("TERMINAL_PORTFOLIO_AUTOBAN_WINDOW"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid auto-ban window: %w", err)
+		}
+		cfg.AutoBanWindow = d
+	}
+
+	if v := getenv("TERMINAL_PORTFOLIO_AUTOBAN_DURATION"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid auto-ban duration: %w", err)
+		}
+		cfg.AutoBanDuration = d
+	}
+
+	if v := getenv("TERMINAL_PORTFOLIO_AUTOBAN_MAX"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid auto-ban maximum: %w", err)
+		}
+		cfg.AutoBanMax = d
+	}
+
+	if v := getenv("TERMINAL_PORTFOLIO_IDLE_TIMEOUT"); v != "" {
 		d, err := time.ParseDuration(v)
 		if err != nil {
 			return nil, fmt.Errorf("invalid idle timeout: %w", err)
@@ -67,14 +338,275 @@ func Load() (*Config, error) {
 		cfg.IdleTimeout = d
 	}
 
-	if v, ok := os.LookupEnv("TERMINAL_PORTFOLIO_ANALYTICS_FILE"); ok {
+	if v := getenv("TERMINAL_PORTFOLIO_DRAIN_TIMEOUT"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid drain timeout: %w", err)
+		}
+		if d < 0 {
+			return nil, fmt.Errorf("invalid drain timeout: %s is negative", v)
+		}
+		cfg.DrainTimeout = d
+	}
+
+	if v := getenv("TERMINAL_PORTFOLIO_SCREENSAVER_AFTER"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid screensaver delay: %w", err)
+		}
+		if d < 0 {
+			return nil, fmt.Errorf("invalid screensaver delay: %s is negative", v)
+		}
+		cfg.ScreensaverAfter = d
+	}
+
+	if v := getenv("TERMINAL_PORTFOLIO_RESUME_WINDOW"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid resume window: %w", err)
+		}
+		if d < 0 {
+			return nil, fmt.Errorf("invalid resume window: %s is negative", v)
+		}
+		cfg.ResumeWindow = d
+	}
+
+	if v, ok := lookup("TERMINAL_PORTFOLIO_ANALYTICS_FILE"); ok {
 		cfg.AnalyticsFile = v
 	}
 
-	if v := os.Getenv("TERMINAL_PORTFOLIO_DEBUG"); v != "" {
+	if v := getenv("TERMINAL_PORTFOLIO_ANALYTICS_DSN"); v != "" {
+		cfg.AnalyticsDSN = v
+	}
+
+	if v := getenv("TERMINAL_PORTFOLIO_ANALYTICS_MAX_SIZE_MB"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid analytics max size: %w", err)
+		}
+		cfg.AnalyticsMaxSizeMB = n
+	}
+
+	if v := getenv("TERMINAL_PORTFOLIO_ANALYTICS_ROTATE"); v != "" {
+		cfg.AnalyticsRotate = v
+	}
+
+	if v := getenv("TERMINAL_PORTFOLIO_ANALYTICS_RETENTION_DAYS"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid analytics retention: %w", err)
+		}
+		cfg.AnalyticsRetentionDays = n
+	}
+
+	if v := getenv("TERMINAL_PORTFOLIO_DEBUG"); v != "" {
 		cfg.Debug = v == "true" || v == "1"
 	}
 
+	if v := getenv("TERMINAL_PORTFOLIO_LOG_LEVEL"); v != "" {
+		cfg.LogLevel = strings.ToLower(v)
+	} else if cfg.Debug {
+		cfg.LogLevel = "debug"
+	}
+
+	if v := getenv("TERMINAL_PORTFOLIO_NAV_WRAP"); v != "" {
+		cfg.NavWrap = v == "true" || v == "1"
+	}
+
+	if v := getenv("TERMINAL_PORTFOLIO_REORDER_RTL"); v != "" {
+		cfg.ReorderRTL = v == "true" || v == "1"
+	}
+
+	if v := getenv("TERMINAL_PORTFOLIO_REDUCED_MOTION"); v != "" {
+		cfg.ReducedMotion = v == "true" || v == "1"
+	}
+
+	if v := getenv("TERMINAL_PORTFOLIO_SLOW_LINK"); v != "" {
+		cfg.SlowLink = v
+	}
+
+	if v := getenv("TERMINAL_PORTFOLIO_SKIP_INTRO"); v != "" {
+		cfg.SkipIntro = v
+	}
+
+	if v := getenv("TERMINAL_PORTFOLIO_REMEMBER_VISITORS"); v != "" {
+		cfg.RememberVisitors = v == "true" || v == "1"
+	}
+
+	if v := getenv("TERMINAL_PORTFOLIO_PARTIAL_CONTENT"); v != "" {
+		cfg.PartialContent = v == "true" || v == "1"
+	}
+
+	if v := getenv("TERMINAL_PORTFOLIO_CONTENT_REVIEW"); v != "" {
+		cfg.ContentReview = v == "true" || v == "1"
+	}
+
+	if v := getenv("TERMINAL_PORTFOLIO_BELL"); v != "" {
+		cfg.Bell = v == "true" || v == "1"
+	}
+
+	if v := getenv("TERMINAL_PORTFOLIO_THEME"); v != "" {
+		cfg.Theme = v
+	}
+
+	if v := getenv("TERMINAL_PORTFOLIO_STATUS_BAR"); v != "" {
+		cfg.StatusBar = v
+	}
+
+	if v := getenv("TERMINAL_PORTFOLIO_GRAPHICS"); v != "" {
+		cfg.Graphics = v
+	}
+
+	if v := getenv("TERMINAL_PORTFOLIO_CONTENT_REFRESH"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid content refresh: %w", err)
+		}
+		cfg.ContentRefresh = d
+	}
+
+	if v := getenv("TERMINAL_PORTFOLIO_CONTENT_CACHE"); v != "" {
+		cfg.ContentCache = v
+	}
+
+	if v := getenv("TERMINAL_PORTFOLIO_CONTENT_SHA256"); v != "" {
+		cfg.ContentSHA256 = v
+	}
+
+	if v := getenv("TERMINAL_PORTFOLIO_CONTENT_PUBLIC_KEY"); v != "" {
+		cfg.ContentPublicKey = v
+	}
+
+	if v := getenv("TERMINAL_PORTFOLIO_SUMMARY"); v != "" {
+		cfg.Summary = v
+	}
+
+	if v := getenv("TERMINAL_PORTFOLIO_SUMMARY_WEBHOOK"); v != "" {
+		cfg.SummaryWebhook = v
+	}
+
+	if v := getenv("TERMINAL_PORTFOLIO_SUMMARY_EMAIL"); v != "" {
+		cfg.SummaryEmail = v
+	}
+
+	if v := getenv("TERMINAL_PORTFOLIO_SMTP_URL"); v != "" {
+		cfg.SMTPURL = v
+	}
+
+	if v := getenv("TERMINAL_PORTFOLIO_STATUS"); v != "" {
+		cfg.Status = v
+	}
+
+	if v := getenv("TERMINAL_PORTFOLIO_STATUS_CHECKS"); v != "" {
+		cfg.StatusChecks = v
+	}
+
+	if v := getenv("TERMINAL_PORTFOLIO_STATUS_INTERVAL"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid status interval: %w", err)
+		}
+		cfg.StatusInterval = d
+	}
+
+	if v := getenv("TERMINAL_PORTFOLIO_BOOKING_PREVIEW_URL"); v != "" {
+		cfg.BookingPreviewURL = v
+	}
+
+	if v := getenv("TERMINAL_PORTFOLIO_BOOKING_PREVIEW_TTL"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid booking preview TTL: %w", err)
+		}
+		cfg.BookingPreviewTTL = d
+	}
+
+	// GITHUB_TOKEN is the name GitHub's own tools read.
+	if v := getenv("TERMINAL_PORTFOLIO_GITHUB_TOKEN"); v != "" {
+		cfg.GitHubToken = v
+	} else if v := getenv("GITHUB_TOKEN"); v != "" {
+		cfg.GitHubToken = v
+	}
+
+	if v := getenv("TERMINAL_PORTFOLIO_REPO_STATS_INTERVAL"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid repo stats interval: %w", err)
+		}
+		cfg.RepoStatsInterval = d
+	}
+
+	// OWNER_KEYS is the variable's earlier name.
+	if v := getenv("TERMINAL_PORTFOLIO_OWNER_AUTHORIZED_KEYS"); v != "" {
+		cfg.OwnerKeys = v
+	} else if v := getenv("TERMINAL_PORTFOLIO_OWNER_KEYS"); v != "" {
+		cfg.OwnerKeys = v
+	}
+
+	if v := getenv("TERMINAL_PORTFOLIO_VERIFY_KEY"); v != "" {
+		cfg.VerifyKey = v
+	}
+
+	if v := getenv("TERMINAL_PORTFOLIO_ALLOW_IPS"); v != "" {
+		cfg.AllowIPs = v
+	}
+
+	if v := getenv("TERMINAL_PORTFOLIO_DENY_IPS"); v != "" {
+		cfg.DenyIPs = v
+	}
+
+	if v := getenv("TERMINAL_PORTFOLIO_AUTH"); v != "" {
+		cfg.Auth = v
+	}
+
+	if v := getenv("TERMINAL_PORTFOLIO_AUTHORIZED_KEYS"); v != "" {
+		cfg.AuthorizedKeys = v
+	}
+
+	if v := getenv("TERMINAL_PORTFOLIO_AUTH_PASSWORD"); v != "" {
+		cfg.AuthPassword = v
+	}
+
+	if v := getenv("TERMINAL_PORTFOLIO_AUTH_MAX_FAILURES"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid auth max failures: %w", err)
+		}
+		cfg.AuthMaxFailures = n
+	}
+
+	if v := getenv("TERMINAL_PORTFOLIO_AUTH_LOCKOUT"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid auth lockout: %w", err)
+		}
+		cfg.AuthLockout = d
+	}
+
+	if v := getenv("TERMINAL_PORTFOLIO_CHAOS_LATENCY"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid chaos latency: %w", err)
+		}
+		cfg.ChaosLatency = d
+	}
+
+	if v := getenv("TERMINAL_PORTFOLIO_CHAOS_DROP_FRAMES"); v != "" {
+		p, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid chaos drop frames: %w", err)
+		}
+		cfg.ChaosDropFrames = p
+	}
+
+	if v := getenv("TERMINAL_PORTFOLIO_CHAOS_SHRINK"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid chaos shrink: %w", err)
+		}
+		cfg.ChaosShrink = d
+	}
+
 	if err := cfg.validate(); err != nil {
 		return nil, err
 	}
@@ -86,11 +618,214 @@ func (c *Config) validate() error {
 	if c.SSHPort < 1 || c.SSHPort > 65535 {
 		return fmt.Errorf("SSH port must be between 1 and 65535, got %d", c.SSHPort)
 	}
+	if _, _, err := net.SplitHostPort(c.WebAddr); err != nil {
+		return fmt.Errorf("web address must be host:port, got %q", c.WebAddr)
+	}
 	if c.DataDir == "" {
 		return fmt.Errorf("data directory must not be empty")
 	}
+	if strings.HasPrefix(c.DataDir, "http://") {
+		return fmt.Errorf("remote data directory must use https, got %q", c.DataDir)
+	}
+	if c.ContentRefresh < 0 {
+		return fmt.Errorf("content refresh must not be negative, got %s", c.ContentRefresh)
+	}
 	if c.MaxSessions < 1 {
 		return fmt.Errorf("max sessions must be positive, got %d", c.MaxSessions)
 	}
+	if c.RateLimit < 0 {
+		return fmt.Errorf("rate limit must not be negative, got %d", c.RateLimit)
+	}
+	if c.RateLimit > 0 && c.RateWindow <= 0 {
+		return fmt.Errorf("rate window must be positive, got %s", c.RateWindow)
+	}
+	if c.AutoBanStrikes < 0 {
+		return fmt.Errorf("auto-ban strikes must not be negative, got %d", c.AutoBanStrikes)
+	}
+	if c.AutoBanStrikes > 0 && (c.AutoBanWindow <= 0 || c.AutoBanDuration <= 0 || c.AutoBanMax < c.AutoBanDuration) {
+		return fmt.Errorf("auto-ban window and duration must be positive, and the maximum no shorter than the duration")
+	}
+	if c.AnalyticsMaxSizeMB < 0 || c.AnalyticsRetentionDays < 0 {
+		return fmt.Errorf("analytics size and retention limits must not be negative")
+	}
+	switch c.AnalyticsRotate {
+	case "daily", "weekly", "off":
+	default:
+		return fmt.Errorf("analytics rotate must be daily, weekly, or off, got %q", c.AnalyticsRotate)
+	}
+	switch c.LogLevel {
+	case "debug", "info", "warn", "error":
+	default:
+		return fmt.Errorf("log level must be debug, info, warn, or error, got %q", c.LogLevel)
+	}
+	switch c.Theme {
+	case "auto", "dark", "light":
+	default:
+		return fmt.Errorf("theme must be auto, dark, or light, got %q", c.Theme)
+	}
+	switch c.StatusBar {
+	case "hints", "progress":
+	default:
+		return fmt.Errorf("status bar must be hints or progress, got %q", c.StatusBar)
+	}
+	switch c.SlowLink {
+	case "auto", "on", "off":
+	default:
+		return fmt.Errorf("slow link must be auto, on, or off, got %q", c.SlowLink)
+	}
+	switch c.SkipIntro {
+	case "off", "returning", "always":
+	default:
+		return fmt.Errorf("skip intro must be off, returning, or always, got %q", c.SkipIntro)
+	}
+	switch c.Graphics {
+	case "auto", "kitty", "sixel", "iterm2", "off":
+	default:
+		return fmt.Errorf("graphics must be auto, kitty, sixel, iterm2, or off, got %q", c.Graphics)
+	}
+	switch c.Summary {
+	case "off":
+	case "daily", "weekly":
+		if c.AnalyticsFile == "" && c.AnalyticsDSN == "" {
+			return fmt.Errorf("analytics summaries need an analytics file or DSN")
+		}
+		if c.SummaryWebhook == "" && c.SummaryEmail == "" {
+			return fmt.Errorf("analytics summaries need a webhook or an email address")
+		}
+	default:
+		return fmt.Errorf("summary must be daily, weekly, or off, got %q", c.Summary)
+	}
+	if c.SummaryEmail != "" && c.SMTPURL == "" {
+		return fmt.Errorf("summary email needs an SMTP server")
+	}
+	switch c.Status {
+	case "off":
+	case "public", "owner":
+		if c.StatusChecks == "" {
+			return fmt.Errorf("the status section needs services to check")
+		}
+		if c.StatusInterval <= 0 {
+			return fmt.Errorf("status interval must be positive, got %s", c.StatusInterval)
+		}
+		if c.Status == "owner" && c.OwnerKeys == "" {
+			return fmt.Errorf("an owner-only status section needs owner keys")
+		}
+	default:
+		return fmt.Errorf("status must be public, owner, or off, got %q", c.Status)
+	}
+	if c.BookingPreviewURL != "" {
+		if u, err := url.Parse(c.BookingPreviewURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("booking preview URL must be an http or https URL, got %q", c.BookingPreviewURL)
+		}
+		if c.BookingPreviewTTL <= 0 {
+			return fmt.Errorf("booking preview TTL must be positive, got %s", c.BookingPreviewTTL)
+		}
+	}
+	if c.GitHubToken != "" && c.RepoStatsInterval <= 0 {
+		return fmt.Errorf("repo stats interval must be positive, got %s", c.RepoStatsInterval)
+	}
+	if _, err := ParsePrefixes(c.AllowIPs); err != nil {
+		return fmt.Errorf("allow IPs: %w", err)
+	}
+	if _, err := ParsePrefixes(c.DenyIPs); err != nil {
+		return fmt.Errorf("deny IPs: %w", err)
+	}
+	switch c.Auth {
+	case "open":
+	case "keys":
+		if c.AuthorizedKeys == "" {
+			return fmt.Errorf("key authentication needs an authorized keys file")
+		}
+	case "password":
+		if c.AuthPassword == "" {
+			return fmt.Errorf("password authentication needs a password")
+		}
+	default:
+		return fmt.Errorf("auth must be open, keys, or password, got %q", c.Auth)
+	}
+	if c.AuthMaxFailures < 0 {
+		return fmt.Errorf("auth max failures must not be negative, got %d", c.AuthMaxFailures)
+	}
+	if c.AuthMaxFailures > 0 && c.AuthLockout <= 0 {
+		return fmt.Errorf("auth lockout must be positive, got %s", c.AuthLockout)
+	}
+	if c.ChaosLatency < 0 || c.ChaosShrink < 0 {
+		return fmt.Errorf("chaos durations must not be negative")
+	}
+	if c.ChaosDropFrames < 0 || c.ChaosDropFrames > 1 {
+		return fmt.Errorf("chaos drop frames must be between 0 and 1, got %g", c.ChaosDropFrames)
+	}
+	if !c.Debug && (c.ChaosLatency > 0 || c.ChaosDropFrames > 0 || c.ChaosShrink > 0) {
+		return fmt.Errorf("chaos flags require debug mode")
+	}
 	return nil
 }
+
+// Level returns LogLevel as a slog level.
+func (c *Config) Level() slog.Level {
+	switch c.LogLevel {
+	case "debug":
+		return slog.LevelDebug
+	case "warn":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// WithRuntime returns a copy of c with the settings a running server can
+// change taken from next: the idle timeout, the session and rate limits,
+// the IP filters, and the log level. The others only change on a restart,
+// since they shape the listener, the data the server opens, or its
+// background jobs.
+func (c *Config) WithRuntime(next *Config) *Config {
+	r := *c
+	r.IdleTimeout = next.IdleTimeout
+	r.MaxSessions = next.MaxSessions
+	r.RateLimit, r.RateWindow = next.RateLimit, next.RateWindow
+	r.AllowIPs, r.DenyIPs = next.AllowIPs, next.DenyIPs
+	r.LogLevel = next.LogLevel
+	return &r
+}
+
+// ParsePrefixes parses a comma-separated list of IPs and CIDR ranges, as
+// AllowIPs and DenyIPs hold, into prefixes. A bare IP is a range of one.
+func ParsePrefixes(list string) ([]netip.Prefix, error) {
+	var prefixes []netip.Prefix
+	for item := range strings.SplitSeq(list, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		if !strings.Contains(item, "/") {
+			addr, err := netip.ParseAddr(item)
+			if err != nil {
+				return nil, fmt.Errorf("%q is not an IP or CIDR range", item)
+			}
+			addr = addr.Unmap()
+			prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+			continue
+		}
+		p, err := netip.ParsePrefix(item)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not an IP or CIDR range", item)
+		}
+		prefixes = append(prefixes, p.Masked())
+	}
+	return prefixes, nil
+}
+
+// Diff returns the names of the fields whose values differ between c and
+// other, in the order Config declares them.
+func (c *Config) Diff(other *Config) []string {
+	a, b := reflect.ValueOf(c).Elem(), reflect.ValueOf(other).Elem()
+	var fields []string
+	for i := range a.NumField() {
+		if !a.Field(i).Equal(b.Field(i)) {
+			fields = append(fields, a.Type().Field(i).Name)
+		}
+	}
+	return fields
+}
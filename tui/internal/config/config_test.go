@@ -1,6 +1,10 @@
 package config
 
 import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
 	"testing"
 	"time"
 )
@@ -9,10 +13,41 @@ func TestLoadDefaults(t *testing.T) {
 	// Set env vars to empty strings so Load() falls through to defaults.
 	// t.Setenv restores original values after the test.
 	t.Setenv("TERMINAL_PORTFOLIO_SSH_PORT", "")
+	t.Setenv("TERMINAL_PORTFOLIO_WEB_ADDR", "")
 	t.Setenv("TERMINAL_PORTFOLIO_DATA_DIR", "")
 	t.Setenv("TERMINAL_PORTFOLIO_MAX_SESSIONS", "")
+	t.Setenv("TERMINAL_PORTFOLIO_RATE_LIMIT", "")
+	t.Setenv("TERMINAL_PORTFOLIO_RATE_WINDOW", "")
 	t.Setenv("TERMINAL_PORTFOLIO_IDLE_TIMEOUT", "")
 	t.Setenv("TERMINAL_PORTFOLIO_DEBUG", "")
+	t.Setenv("TERMINAL_PORTFOLIO_NAV_WRAP", "")
+	t.Setenv("TERMINAL_PORTFOLIO_REORDER_RTL", "")
+	t.Setenv("TERMINAL_PORTFOLIO_CONTENT_REVIEW", "")
+	t.Setenv("TERMINAL_PORTFOLIO_PARTIAL_CONTENT", "")
+	t.Setenv("TERMINAL_PORTFOLIO_THEME", "")
+	t.Setenv("TERMINAL_PORTFOLIO_STATUS_BAR", "")
+	t.Setenv("TERMINAL_PORTFOLIO_GRAPHICS", "")
+	t.Setenv("TERMINAL_PORTFOLIO_CONTENT_REFRESH", "")
+	t.Setenv("TERMINAL_PORTFOLIO_CONTENT_CACHE", "")
+	t.Setenv("TERMINAL_PORTFOLIO_CONTENT_SHA256", "")
+	t.Setenv("TERMINAL_PORTFOLIO_CONTENT_PUBLIC_KEY", "")
+	t.Setenv("TERMINAL_PORTFOLIO_ANALYTICS_MAX_SIZE_MB", "")
+	t.Setenv("TERMINAL_PORTFOLIO_ANALYTICS_ROTATE", "")
+	t.Setenv("TERMINAL_PORTFOLIO_ANALYTICS_RETENTION_DAYS", "")
+	t.Setenv("TERMINAL_PORTFOLIO_ANALYTICS_DSN", "")
+	t.Setenv("TERMINAL_PORTFOLIO_SUMMARY", "")
+	t.Setenv("TERMINAL_PORTFOLIO_STATUS", "")
+	t.Setenv("TERMINAL_PORTFOLIO_STATUS_INTERVAL", "")
+	t.Setenv("TERMINAL_PORTFOLIO_BOOKING_PREVIEW_URL", "")
+	t.Setenv("TERMINAL_PORTFOLIO_BOOKING_PREVIEW_TTL", "")
+	t.Setenv("TERMINAL_PORTFOLIO_GITHUB_TOKEN", "")
+	t.Setenv("GITHUB_TOKEN", "")
+	t.Setenv("TERMINAL_PORTFOLIO_REPO_STATS_INTERVAL", "")
+	t.Setenv("TERMINAL_PORTFOLIO_OWNER_AUTHORIZED_KEYS", "")
+	t.Setenv("TERMINAL_PORTFOLIO_OWNER_KEYS", "")
+	t.Setenv("TERMINAL_PORTFOLIO_CHAOS_LATENCY", "")
+	t.Setenv("TERMINAL_PORTFOLIO_CHAOS_DROP_FRAMES", "")
+	t.Setenv("TERMINAL_PORTFOLIO_CHAOS_SHRINK", "")
 
 	cfg, err := Load()
 	if err != nil {
@@ -22,6 +57,9 @@ func TestLoadDefaults(t *testing.T) {
 	if cfg.SSHPort != 2222 {
 		t.Errorf("SSHPort = %d, want 2222", cfg.SSHPort)
 	}
+	if cfg.WebAddr != "127.0.0.1:8080" {
+		t.Errorf("WebAddr = %q, want %q", cfg.WebAddr, "127.0.0.1:8080")
+	}
 	if cfg.DataDir != "../data" {
 		t.Errorf("DataDir = %q, want %q", cfg.DataDir, "../data")
 	}
@@ -31,9 +69,54 @@ func TestLoadDefaults(t *testing.T) {
 	if cfg.IdleTimeout != 30*time.Minute {
 		t.Errorf("IdleTimeout = %v, want 30m0s", cfg.IdleTimeout)
 	}
+	if cfg.RateLimit != 10 || cfg.RateWindow != time.Minute {
+		t.Errorf("RateLimit = %d per %s, want 10 per 1m0s", cfg.RateLimit, cfg.RateWindow)
+	}
 	if cfg.Debug {
 		t.Error("Debug should be false by default")
 	}
+	if !cfg.NavWrap {
+		t.Error("NavWrap should be true by default")
+	}
+	if !cfg.ReorderRTL {
+		t.Error("ReorderRTL should be true by default")
+	}
+	if cfg.ReducedMotion {
+		t.Error("ReducedMotion should be false by default")
+	}
+	if cfg.ContentReview {
+		t.Error("ContentReview should be false by default")
+	}
+	if cfg.PartialContent {
+		t.Error("PartialContent should be false by default")
+	}
+	if cfg.Theme != "auto" {
+		t.Errorf("Theme = %q, want %q", cfg.Theme, "auto")
+	}
+	if cfg.Graphics != "auto" {
+		t.Errorf("Graphics = %q, want %q", cfg.Graphics, "auto")
+	}
+	if cfg.SlowLink != "auto" {
+		t.Errorf("SlowLink = %q, want %q", cfg.SlowLink, "auto")
+	}
+	if cfg.StatusBar != "hints" {
+		t.Errorf("StatusBar = %q, want %q", cfg.StatusBar, "hints")
+	}
+	if cfg.Summary != "off" {
+		t.Errorf("Summary = %q, want %q", cfg.Summary, "off")
+	}
+	if cfg.AnalyticsMaxSizeMB != 100 || cfg.AnalyticsRotate != "off" || cfg.AnalyticsRetentionDays != 90 {
+		t.Errorf("analytics rotation defaults = %d, %q, %d", cfg.AnalyticsMaxSizeMB, cfg.AnalyticsRotate, cfg.AnalyticsRetentionDays)
+	}
+	if cfg.ContentRefresh != 5*time.Minute || cfg.ContentCache != "content-cache" || cfg.ContentSHA256 != "" || cfg.ContentPublicKey != "" {
+		t.Errorf("content source defaults = %s, %q, %q, %q", cfg.ContentRefresh, cfg.ContentCache, cfg.ContentSHA256, cfg.ContentPublicKey)
+	}
+	if cfg.BookingPreviewURL != "" || cfg.BookingPreviewTTL != 15*time.Minute {
+		t.Errorf("booking preview defaults = %q, %s", cfg.BookingPreviewURL, cfg.BookingPreviewTTL)
+	}
+	if cfg.GitHubToken != "" || cfg.RepoStatsInterval != time.Hour {
+		t.Errorf("repo stats defaults = %q, %s", cfg.GitHubToken, cfg.RepoStatsInterval)
+	}
 }
 
 func TestLoadOverrides(t *testing.T) {
@@ -65,6 +148,33 @@ func TestLoadOverrides(t *testing.T) {
 	}
 }
 
+func TestLoadWebAddr(t *testing.T) {
+	t.Setenv("TERMINAL_PORTFOLIO_WEB_ADDR", ":8443")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.WebAddr != ":8443" {
+		t.Errorf("WebAddr = %q, want %q", cfg.WebAddr, ":8443")
+	}
+
+	t.Setenv("TERMINAL_PORTFOLIO_WEB_ADDR", "8080")
+	if _, err := Load(); err == nil {
+		t.Error("expected error for a web address without a port separator")
+	}
+}
+
+func TestLoadPartialContent(t *testing.T) {
+	t.Setenv("TERMINAL_PORTFOLIO_PARTIAL_CONTENT", "true")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !cfg.PartialContent {
+		t.Error("PartialContent should be true")
+	}
+}
+
 func TestLoadDebugVariants(t *testing.T) {
 	tests := []struct {
 		value string
@@ -95,6 +205,479 @@ func TestLoadDebugVariants(t *testing.T) {
 	}
 }
 
+func TestLoadNavWrapDisabled(t *testing.T) {
+	t.Setenv("TERMINAL_PORTFOLIO_SSH_PORT", "2222")
+	t.Setenv("TERMINAL_PORTFOLIO_MAX_SESSIONS", "100")
+	t.Setenv("TERMINAL_PORTFOLIO_NAV_WRAP", "false")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.NavWrap {
+		t.Error("NavWrap should be false when TERMINAL_PORTFOLIO_NAV_WRAP=false")
+	}
+}
+
+func TestLoadReorderRTLDisabled(t *testing.T) {
+	t.Setenv("TERMINAL_PORTFOLIO_REORDER_RTL", "false")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.ReorderRTL {
+		t.Error("ReorderRTL should be false when TERMINAL_PORTFOLIO_REORDER_RTL=false")
+	}
+}
+
+func TestLoadReducedMotion(t *testing.T) {
+	t.Setenv("TERMINAL_PORTFOLIO_REDUCED_MOTION", "1")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !cfg.ReducedMotion {
+		t.Error("ReducedMotion should be true when TERMINAL_PORTFOLIO_REDUCED_MOTION=1")
+	}
+}
+
+func TestLoadSlowLink(t *testing.T) {
+	t.Setenv("TERMINAL_PORTFOLIO_SLOW_LINK", "on")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.SlowLink != "on" {
+		t.Errorf("SlowLink = %q, want %q", cfg.SlowLink, "on")
+	}
+
+	t.Setenv("TERMINAL_PORTFOLIO_SLOW_LINK", "sometimes")
+	if _, err := Load(); err == nil {
+		t.Error("expected error for unknown slow link policy")
+	}
+}
+
+func TestLoadVisitors(t *testing.T) {
+	t.Setenv("TERMINAL_PORTFOLIO_SKIP_INTRO", "returning")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.SkipIntro != "returning" {
+		t.Errorf("SkipIntro = %q, want %q", cfg.SkipIntro, "returning")
+	}
+
+	if cfg.RememberVisitors {
+		t.Error("RememberVisitors should be off by default")
+	}
+	t.Setenv("TERMINAL_PORTFOLIO_REMEMBER_VISITORS", "1")
+	if cfg, err := Load(); err != nil || !cfg.RememberVisitors {
+		t.Errorf("RememberVisitors = %v, %v; want true", cfg != nil && cfg.RememberVisitors, err)
+	}
+
+	t.Setenv("TERMINAL_PORTFOLIO_SKIP_INTRO", "true")
+	if _, err := Load(); err == nil {
+		t.Error("expected error for unknown skip intro policy")
+	}
+}
+
+func TestLoadTheme(t *testing.T) {
+	t.Setenv("TERMINAL_PORTFOLIO_SSH_PORT", "2222")
+	t.Setenv("TERMINAL_PORTFOLIO_MAX_SESSIONS", "100")
+
+	t.Setenv("TERMINAL_PORTFOLIO_THEME", "light")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Theme != "light" {
+		t.Errorf("Theme = %q, want %q", cfg.Theme, "light")
+	}
+
+	t.Setenv("TERMINAL_PORTFOLIO_THEME", "solarized")
+	if _, err := Load(); err == nil {
+		t.Error("expected error for unknown theme")
+	}
+}
+
+func TestLoadStatusBar(t *testing.T) {
+	t.Setenv("TERMINAL_PORTFOLIO_SSH_PORT", "2222")
+	t.Setenv("TERMINAL_PORTFOLIO_MAX_SESSIONS", "100")
+
+	t.Setenv("TERMINAL_PORTFOLIO_STATUS_BAR", "progress")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.StatusBar != "progress" {
+		t.Errorf("StatusBar = %q, want %q", cfg.StatusBar, "progress")
+	}
+
+	t.Setenv("TERMINAL_PORTFOLIO_STATUS_BAR", "full")
+	if _, err := Load(); err == nil {
+		t.Error("expected error for unknown status bar mode")
+	}
+}
+
+func TestLoadGraphics(t *testing.T) {
+	t.Setenv("TERMINAL_PORTFOLIO_SSH_PORT", "2222")
+	t.Setenv("TERMINAL_PORTFOLIO_MAX_SESSIONS", "100")
+
+	t.Setenv("TERMINAL_PORTFOLIO_GRAPHICS", "iterm2")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Graphics != "iterm2" {
+		t.Errorf("Graphics = %q, want %q", cfg.Graphics, "iterm2")
+	}
+
+	t.Setenv("TERMINAL_PORTFOLIO_GRAPHICS", "ascii")
+	if _, err := Load(); err == nil {
+		t.Error("expected error for unknown graphics protocol")
+	}
+}
+
+func TestLoadContentSource(t *testing.T) {
+	t.Setenv("TERMINAL_PORTFOLIO_DATA_DIR", "git+https://example.com/site.git#data")
+	t.Setenv("TERMINAL_PORTFOLIO_CONTENT_REFRESH", "90s")
+	t.Setenv("TERMINAL_PORTFOLIO_CONTENT_CACHE", "/var/cache/portfolio")
+	t.Setenv("TERMINAL_PORTFOLIO_CONTENT_PUBLIC_KEY", "key")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.ContentRefresh != 90*time.Second || cfg.ContentCache != "/var/cache/portfolio" {
+		t.Errorf("ContentRefresh = %s, ContentCache = %q", cfg.ContentRefresh, cfg.ContentCache)
+	}
+	if cfg.ContentPublicKey != "key" {
+		t.Errorf("ContentPublicKey = %q, want %q", cfg.ContentPublicKey, "key")
+	}
+
+	t.Setenv("TERMINAL_PORTFOLIO_DATA_DIR", "http://example.com/data.tar.gz")
+	if _, err := Load(); err == nil {
+		t.Error("expected error for plain http data directory")
+	}
+
+	t.Setenv("TERMINAL_PORTFOLIO_DATA_DIR", "../data")
+	t.Setenv("TERMINAL_PORTFOLIO_CONTENT_REFRESH", "-1m")
+	if _, err := Load(); err == nil {
+		t.Error("expected error for negative content refresh")
+	}
+}
+
+func TestLoadRateLimit(t *testing.T) {
+	t.Setenv("TERMINAL_PORTFOLIO_RATE_LIMIT", "3")
+	t.Setenv("TERMINAL_PORTFOLIO_RATE_WINDOW", "30s")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.RateLimit != 3 || cfg.RateWindow != 30*time.Second {
+		t.Errorf("RateLimit = %d per %s, want 3 per 30s", cfg.RateLimit, cfg.RateWindow)
+	}
+
+	t.Setenv("TERMINAL_PORTFOLIO_RATE_WINDOW", "0s")
+	if _, err := Load(); err == nil {
+		t.Error("expected error for a zero rate window")
+	}
+	t.Setenv("TERMINAL_PORTFOLIO_RATE_LIMIT", "0")
+	if _, err := Load(); err != nil {
+		t.Errorf("disabling the rate limit should ignore the window: %v", err)
+	}
+	t.Setenv("TERMINAL_PORTFOLIO_RATE_LIMIT", "-1")
+	if _, err := Load(); err == nil {
+		t.Error("expected error for a negative rate limit")
+	}
+}
+
+func TestLoadAnalyticsRotation(t *testing.T) {
+	t.Setenv("TERMINAL_PORTFOLIO_ANALYTICS_MAX_SIZE_MB", "25")
+	t.Setenv("TERMINAL_PORTFOLIO_ANALYTICS_ROTATE", "weekly")
+	t.Setenv("TERMINAL_PORTFOLIO_ANALYTICS_RETENTION_DAYS", "0")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.AnalyticsMaxSizeMB != 25 || cfg.AnalyticsRotate != "weekly" || cfg.AnalyticsRetentionDays != 0 {
+		t.Errorf("analytics rotation = %d, %q, %d", cfg.AnalyticsMaxSizeMB, cfg.AnalyticsRotate, cfg.AnalyticsRetentionDays)
+	}
+
+	t.Setenv("TERMINAL_PORTFOLIO_ANALYTICS_ROTATE", "hourly")
+	if _, err := Load(); err == nil {
+		t.Error("expected error for unknown rotation period")
+	}
+	t.Setenv("TERMINAL_PORTFOLIO_ANALYTICS_ROTATE", "off")
+	t.Setenv("TERMINAL_PORTFOLIO_ANALYTICS_RETENTION_DAYS", "-1")
+	if _, err := Load(); err == nil {
+		t.Error("expected error for negative retention")
+	}
+}
+
+func TestLoadSummary(t *testing.T) {
+	t.Setenv("TERMINAL_PORTFOLIO_SUMMARY", "weekly")
+	t.Setenv("TERMINAL_PORTFOLIO_SUMMARY_WEBHOOK", "https://hooks.example.com/x")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Summary != "weekly" || cfg.SummaryWebhook != "https://hooks.example.com/x" {
+		t.Errorf("Summary = %q, SummaryWebhook = %q", cfg.Summary, cfg.SummaryWebhook)
+	}
+
+	t.Setenv("TERMINAL_PORTFOLIO_SUMMARY_EMAIL", "me@example.com")
+	if _, err := Load(); err == nil {
+		t.Error("expected error for summary email without an SMTP server")
+	}
+	t.Setenv("TERMINAL_PORTFOLIO_SMTP_URL", "smtp://mail.example.com")
+	if _, err := Load(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	t.Setenv("TERMINAL_PORTFOLIO_SUMMARY", "hourly")
+	if _, err := Load(); err == nil {
+		t.Error("expected error for unknown summary period")
+	}
+
+	t.Setenv("TERMINAL_PORTFOLIO_SUMMARY", "daily")
+	t.Setenv("TERMINAL_PORTFOLIO_ANALYTICS_FILE", "")
+	if _, err := Load(); err == nil {
+		t.Error("expected error for summaries without analytics")
+	}
+	t.Setenv("TERMINAL_PORTFOLIO_ANALYTICS_DSN", "file:analytics.db")
+	if _, err := Load(); err != nil {
+		t.Errorf("summaries from an analytics database: unexpected error: %v", err)
+	}
+}
+
+func TestLoadStatus(t *testing.T) {
+	t.Setenv("TERMINAL_PORTFOLIO_STATUS", "public")
+	if _, err := Load(); err == nil {
+		t.Error("expected error for a status section without checks")
+	}
+
+	t.Setenv("TERMINAL_PORTFOLIO_STATUS_CHECKS", "api=https://api.example.com")
+	t.Setenv("TERMINAL_PORTFOLIO_STATUS_INTERVAL", "30s")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Status != "public" || cfg.StatusChecks != "api=https://api.example.com" || cfg.StatusInterval != 30*time.Second {
+		t.Errorf("status = %q, %q, %s", cfg.Status, cfg.StatusChecks, cfg.StatusInterval)
+	}
+
+	t.Setenv("TERMINAL_PORTFOLIO_STATUS", "owner")
+	if _, err := Load(); err == nil {
+		t.Error("expected error for an owner-only status section without owner keys")
+	}
+	t.Setenv("TERMINAL_PORTFOLIO_OWNER_KEYS", "/etc/terminal-portfolio/owner_keys")
+	if _, err := Load(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	t.Setenv("TERMINAL_PORTFOLIO_STATUS", "private")
+	if _, err := Load(); err == nil {
+		t.Error("expected error for unknown status mode")
+	}
+}
+
+func TestLoadBookingPreview(t *testing.T) {
+	t.Setenv("TERMINAL_PORTFOLIO_BOOKING_PREVIEW_URL", "https://api.cal.com/v2/slots?username=kyle")
+	t.Setenv("TERMINAL_PORTFOLIO_BOOKING_PREVIEW_TTL", "5m")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.BookingPreviewTTL != 5*time.Minute {
+		t.Errorf("BookingPreviewTTL = %s, want 5m0s", cfg.BookingPreviewTTL)
+	}
+
+	t.Setenv("TERMINAL_PORTFOLIO_BOOKING_PREVIEW_TTL", "0s")
+	if _, err := Load(); err == nil {
+		t.Error("expected error for a zero booking preview TTL")
+	}
+	t.Setenv("TERMINAL_PORTFOLIO_BOOKING_PREVIEW_TTL", "")
+	t.Setenv("TERMINAL_PORTFOLIO_BOOKING_PREVIEW_URL", "cal.com/kyle")
+	if _, err := Load(); err == nil {
+		t.Error("expected error for a booking preview URL without a scheme")
+	}
+}
+
+func TestLoadGitHubToken(t *testing.T) {
+	t.Setenv("TERMINAL_PORTFOLIO_GITHUB_TOKEN", "")
+	t.Setenv("GITHUB_TOKEN", "ghp_shared")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.GitHubToken != "ghp_shared" {
+		t.Errorf("GitHubToken = %q, want the GITHUB_TOKEN fallback", cfg.GitHubToken)
+	}
+
+	t.Setenv("TERMINAL_PORTFOLIO_GITHUB_TOKEN", "ghp_own")
+	t.Setenv("TERMINAL_PORTFOLIO_REPO_STATS_INTERVAL", "30m")
+	if cfg, err = Load(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.GitHubToken != "ghp_own" || cfg.RepoStatsInterval != 30*time.Minute {
+		t.Errorf("repo stats = %q, %s, want ghp_own, 30m0s", cfg.GitHubToken, cfg.RepoStatsInterval)
+	}
+
+	t.Setenv("TERMINAL_PORTFOLIO_REPO_STATS_INTERVAL", "0s")
+	if _, err := Load(); err == nil {
+		t.Error("expected error for a zero repo stats interval")
+	}
+}
+
+func TestLoadOwnerKeys(t *testing.T) {
+	t.Setenv("TERMINAL_PORTFOLIO_OWNER_KEYS", "/etc/terminal-portfolio/old_keys")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.OwnerKeys != "/etc/terminal-portfolio/old_keys" {
+		t.Errorf("OwnerKeys = %q, want the OWNER_KEYS path", cfg.OwnerKeys)
+	}
+
+	t.Setenv("TERMINAL_PORTFOLIO_OWNER_AUTHORIZED_KEYS", "/etc/terminal-portfolio/authorized_keys")
+	if cfg, err = Load(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.OwnerKeys != "/etc/terminal-portfolio/authorized_keys" {
+		t.Errorf("OwnerKeys = %q, want OWNER_AUTHORIZED_KEYS to win", cfg.OwnerKeys)
+	}
+}
+
+func TestLoadVerifyKey(t *testing.T) {
+	t.Setenv("TERMINAL_PORTFOLIO_VERIFY_KEY", "/etc/terminal-portfolio/verify_ed25519")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.VerifyKey != "/etc/terminal-portfolio/verify_ed25519" {
+		t.Errorf("VerifyKey = %q", cfg.VerifyKey)
+	}
+}
+
+func TestLoadIPFilters(t *testing.T) {
+	t.Setenv("TERMINAL_PORTFOLIO_ALLOW_IPS", "10.0.0.0/8, 192.0.2.7")
+	t.Setenv("TERMINAL_PORTFOLIO_DENY_IPS", "10.1.2.3/16,2001:db8::/32")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	allow, _ := ParsePrefixes(cfg.AllowIPs)
+	deny, _ := ParsePrefixes(cfg.DenyIPs)
+	want := []string{"10.0.0.0/8", "192.0.2.7/32", "10.1.0.0/16", "2001:db8::/32"}
+	var got []string
+	for _, p := range slices.Concat(allow, deny) {
+		got = append(got, p.String())
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("prefixes = %v, want %v", got, want)
+	}
+
+	for _, v := range []string{"10.0.0.0/33", "example.com", "10.0.0.1/8/8"} {
+		t.Setenv("TERMINAL_PORTFOLIO_DENY_IPS", v)
+		if _, err := Load(); err == nil {
+			t.Errorf("deny IPs %q should be an error", v)
+		}
+	}
+}
+
+func TestLoadAutoBan(t *testing.T) {
+	t.Setenv("TERMINAL_PORTFOLIO_AUTOBAN_STRIKES", "3")
+	t.Setenv("TERMINAL_PORTFOLIO_AUTOBAN_MAX", "2h")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.AutoBanStrikes != 3 || cfg.AutoBanWindow != 10*time.Minute || cfg.AutoBanDuration != 10*time.Minute || cfg.AutoBanMax != 2*time.Hour {
+		t.Errorf("auto-ban = %d, %v, %v, %v; want 3, 10m0s, 10m0s, 2h0m0s",
+			cfg.AutoBanStrikes, cfg.AutoBanWindow, cfg.AutoBanDuration, cfg.AutoBanMax)
+	}
+
+	t.Setenv("TERMINAL_PORTFOLIO_AUTOBAN_MAX", "5m")
+	if _, err := Load(); err == nil {
+		t.Error("a maximum ban shorter than the first should be an error")
+	}
+	t.Setenv("TERMINAL_PORTFOLIO_AUTOBAN_MAX", "")
+	t.Setenv("TERMINAL_PORTFOLIO_AUTOBAN_WINDOW", "soon")
+	if _, err := Load(); err == nil {
+		t.Error("an unparsable auto-ban window should be an error")
+	}
+}
+
+func TestLoadAuth(t *testing.T) {
+	cfg, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Auth != "open" || cfg.AuthMaxFailures != 5 || cfg.AuthLockout != 15*time.Minute {
+		t.Errorf("Auth defaults = %q, %d, %v; want open, 5, 15m0s", cfg.Auth, cfg.AuthMaxFailures, cfg.AuthLockout)
+	}
+
+	t.Setenv("TERMINAL_PORTFOLIO_AUTH", "keys")
+	if _, err := Load(); err == nil {
+		t.Error("key authentication without an authorized keys file should be an error")
+	}
+	t.Setenv("TERMINAL_PORTFOLIO_AUTHORIZED_KEYS", "/etc/terminal-portfolio/authorized_keys")
+	t.Setenv("TERMINAL_PORTFOLIO_AUTH_MAX_FAILURES", "3")
+	t.Setenv("TERMINAL_PORTFOLIO_AUTH_LOCKOUT", "1h")
+	if cfg, err = Load(); err != nil || cfg.AuthMaxFailures != 3 || cfg.AuthLockout != time.Hour {
+		t.Errorf("AuthMaxFailures, AuthLockout = %d, %v, %v; want 3, 1h0m0s", cfg.AuthMaxFailures, cfg.AuthLockout, err)
+	}
+
+	t.Setenv("TERMINAL_PORTFOLIO_AUTH", "password")
+	if _, err := Load(); err == nil {
+		t.Error("password authentication without a password should be an error")
+	}
+	t.Setenv("TERMINAL_PORTFOLIO_AUTH_PASSWORD", "hunter2")
+	if cfg, err = Load(); err != nil || cfg.AuthPassword != "hunter2" {
+		t.Errorf("AuthPassword = %q, %v; want hunter2", cfg.AuthPassword, err)
+	}
+
+	for key, v := range map[string]string{
+		"TERMINAL_PORTFOLIO_AUTH":              "ldap",
+		"TERMINAL_PORTFOLIO_AUTH_MAX_FAILURES": "-1",
+		"TERMINAL_PORTFOLIO_AUTH_LOCKOUT":      "0",
+	} {
+		t.Run(key, func(t *testing.T) {
+			t.Setenv(key, v)
+			if _, err := Load(); err == nil {
+				t.Errorf("%s=%s should be an error", key, v)
+			}
+		})
+	}
+}
+
+func TestLoadChaos(t *testing.T) {
+	t.Setenv("TERMINAL_PORTFOLIO_CHAOS_LATENCY", "150ms")
+	t.Setenv("TERMINAL_PORTFOLIO_CHAOS_DROP_FRAMES", "0.25")
+	t.Setenv("TERMINAL_PORTFOLIO_CHAOS_SHRINK", "10s")
+
+	t.Setenv("TERMINAL_PORTFOLIO_DEBUG", "false")
+	if _, err := Load(); err == nil {
+		t.Error("expected error for chaos flags outside debug mode")
+	}
+
+	t.Setenv("TERMINAL_PORTFOLIO_DEBUG", "true")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.ChaosLatency != 150*time.Millisecond || cfg.ChaosDropFrames != 0.25 || cfg.ChaosShrink != 10*time.Second {
+		t.Errorf("chaos = %s, %g, %s", cfg.ChaosLatency, cfg.ChaosDropFrames, cfg.ChaosShrink)
+	}
+
+	t.Setenv("TERMINAL_PORTFOLIO_CHAOS_DROP_FRAMES", "1.5")
+	if _, err := Load(); err == nil {
+		t.Error("expected error for drop probability above 1")
+	}
+}
+
 func TestValidationPortTooLow(t *testing.T) {
 	t.Setenv("TERMINAL_PORTFOLIO_SSH_PORT", "0")
 
@@ -161,6 +744,146 @@ func TestValidationInvalidTimeout(t *testing.T) {
 	}
 }
 
+func TestLoadDrainTimeout(t *testing.T) {
+	cfg, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.DrainTimeout != 0 {
+		t.Errorf("DrainTimeout default = %v, want 0", cfg.DrainTimeout)
+	}
+
+	t.Setenv("TERMINAL_PORTFOLIO_DRAIN_TIMEOUT", "2m")
+	if cfg, err = Load(); err != nil || cfg.DrainTimeout != 2*time.Minute {
+		t.Errorf("DrainTimeout = %v, %v; want 2m0s", cfg.DrainTimeout, err)
+	}
+	for _, v := range []string{"soon", "-1m"} {
+		t.Setenv("TERMINAL_PORTFOLIO_DRAIN_TIMEOUT", v)
+		if _, err := Load(); err == nil {
+			t.Errorf("drain timeout %q should be an error", v)
+		}
+	}
+}
+
+func TestLoadScreensaverAfter(t *testing.T) {
+	cfg, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.ScreensaverAfter != 5*time.Minute {
+		t.Errorf("ScreensaverAfter default = %v, want 5m0s", cfg.ScreensaverAfter)
+	}
+
+	t.Setenv("TERMINAL_PORTFOLIO_SCREENSAVER_AFTER", "0")
+	if cfg, err = Load(); err != nil || cfg.ScreensaverAfter != 0 {
+		t.Errorf("ScreensaverAfter = %v, %v; want 0 to disable", cfg.ScreensaverAfter, err)
+	}
+	for _, v := range []string{"later", "-5m"} {
+		t.Setenv("TERMINAL_PORTFOLIO_SCREENSAVER_AFTER", v)
+		if _, err := Load(); err == nil {
+			t.Errorf("screensaver delay %q should be an error", v)
+		}
+	}
+}
+
+func TestLoadResumeWindow(t *testing.T) {
+	cfg, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.ResumeWindow != 3*time.Minute {
+		t.Errorf("ResumeWindow default = %v, want 3m0s", cfg.ResumeWindow)
+	}
+
+	t.Setenv("TERMINAL_PORTFOLIO_RESUME_WINDOW", "0")
+	if cfg, err = Load(); err != nil || cfg.ResumeWindow != 0 {
+		t.Errorf("ResumeWindow = %v, %v; want 0 to disable", cfg.ResumeWindow, err)
+	}
+	t.Setenv("TERMINAL_PORTFOLIO_RESUME_WINDOW", "-1m")
+	if _, err := Load(); err == nil {
+		t.Error("a negative resume window should be an error")
+	}
+}
+
+func TestLoadLogLevel(t *testing.T) {
+	for _, tt := range []struct {
+		level, debug, want string
+	}{
+		{"", "", "info"},
+		{"", "true", "debug"},
+		{"WARN", "", "warn"},
+		{"error", "true", "error"},
+	} {
+		t.Setenv("TERMINAL_PORTFOLIO_LOG_LEVEL", tt.level)
+		t.Setenv("TERMINAL_PORTFOLIO_DEBUG", tt.debug)
+		cfg, err := Load()
+		if err != nil || cfg.LogLevel != tt.want {
+			t.Errorf("level %q with debug %q: LogLevel = %q, %v; want %q", tt.level, tt.debug, cfg.LogLevel, err, tt.want)
+		}
+	}
+	t.Setenv("TERMINAL_PORTFOLIO_LOG_LEVEL", "verbose")
+	if _, err := Load(); err == nil {
+		t.Error("an unknown log level should be an error")
+	}
+}
+
+func TestLoadEnvFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "env")
+	file := "# comment\n\nTERMINAL_PORTFOLIO_MAX_SESSIONS=7\n; another\nTERMINAL_PORTFOLIO_THEME=\"light\"\n"
+	if err := os.WriteFile(path, []byte(file), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("TERMINAL_PORTFOLIO_ENV_FILE", path)
+	t.Setenv("TERMINAL_PORTFOLIO_MAX_SESSIONS", "50")
+	t.Setenv("TERMINAL_PORTFOLIO_SSH_PORT", "2200")
+
+	// The file overrides the environment; what it leaves out falls back.
+	cfg, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.MaxSessions != 7 || cfg.Theme != "light" || cfg.SSHPort != 2200 {
+		t.Errorf("MaxSessions, Theme, SSHPort = %d, %q, %d; want 7, light, 2200", cfg.MaxSessions, cfg.Theme, cfg.SSHPort)
+	}
+
+	if err := os.WriteFile(path, []byte("TERMINAL_PORTFOLIO_MAX_SESSIONS\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(); err == nil {
+		t.Error("a line without = should be an error")
+	}
+	t.Setenv("TERMINAL_PORTFOLIO_ENV_FILE", filepath.Join(t.TempDir(), "missing"))
+	if _, err := Load(); err == nil {
+		t.Error("a missing env file should be an error")
+	}
+}
+
+func TestConfigWithRuntime(t *testing.T) {
+	running, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	next := *running
+	next.MaxSessions = 5
+	next.LogLevel = "warn"
+	next.SSHPort = 2200
+	next.Theme = "dark"
+
+	applied := running.WithRuntime(&next)
+	if got := running.Diff(applied); !slices.Equal(got, []string{"MaxSessions", "LogLevel"}) {
+		t.Errorf("applied %v, want [MaxSessions LogLevel]", got)
+	}
+	if got := applied.Diff(&next); !slices.Equal(got, []string{"SSHPort", "Theme"}) {
+		t.Errorf("left for a restart %v, want [SSHPort Theme]", got)
+	}
+	if running.MaxSessions == 5 {
+		t.Error("WithRuntime should not change the config it copies")
+	}
+	if applied.Level() != slog.LevelWarn {
+		t.Errorf("Level = %v, want WARN", applied.Level())
+	}
+}
+
 func TestValidationEmptyDataDir(t *testing.T) {
 	// DataDir can only be empty if explicitly set via env var,
 	// but the env override only triggers on non-empty string.
@@ -0,0 +1,64 @@
+package content
+
+import (
+	"strings"
+	"time"
+)
+
+// availabilityStartLayout is the date format accepted for availability.start.
+const availabilityStartLayout = "2006-01-02"
+
+// Availability says whether the owner is open to work, for which roles,
+// and from when.
+type Availability struct {
+	OpenToWork bool     `json:"openToWork"`      // Whether the owner is looking for work.
+	Roles      []string `json:"roles,omitempty"` // Roles the owner is looking for.
+	// Start is an optional YYYY-MM-DD date the owner can start from. A
+	// missing or past date means right away.
+	Start string `json:"start,omitempty" jsonschema:"format=date"`
+}
+
+// StartsAfter returns the start date and true when it falls after now,
+// or false when the owner can start right away.
+func (a Availability) StartsAfter(now time.Time) (time.Time, bool) {
+	if a.Start == "" {
+		return time.Time{}, false
+	}
+	start, err := time.ParseInLocation(availabilityStartLayout, a.Start, now.Location())
+	if err != nil || !start.After(now) {
+		return time.Time{}, false
+	}
+	return start, true
+}
+
+// Label returns the short availability state shown on the badge.
+func (a Availability) Label() string {
+	if a.OpenToWork {
+		return "Open to work"
+	}
+	return "Not looking"
+}
+
+// Details returns the roles and a future start date joined with " · ",
+// or "" when the owner is not open to work or neither is given.
+func (a Availability) Details(now time.Time) string {
+	if !a.OpenToWork {
+		return ""
+	}
+	var parts []string
+	if len(a.Roles) > 0 {
+		parts = append(parts, strings.Join(a.Roles, ", "))
+	}
+	if start, later := a.StartsAfter(now); later {
+		parts = append(parts, "from "+start.Format("2 Jan 2006"))
+	}
+	return strings.Join(parts, " · ")
+}
+
+// Summary returns the label and details as one line of plain text.
+func (a Availability) Summary(now time.Time) string {
+	if d := a.Details(now); d != "" {
+		return a.Label() + " · " + d
+	}
+	return a.Label()
+}
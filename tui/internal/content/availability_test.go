@@ -0,0 +1,28 @@
+package content
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAvailabilitySummary(t *testing.T) {
+	now := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		a    Availability
+		want string
+	}{
+		{"not looking", Availability{Roles: []string{"Staff Engineer"}}, "Not looking"},
+		{"open", Availability{OpenToWork: true}, "Open to work"},
+		{"roles", Availability{OpenToWork: true, Roles: []string{"Staff Engineer", "Tech Lead"}}, "Open to work · Staff Engineer, Tech Lead"},
+		{"future start", Availability{OpenToWork: true, Start: "2026-11-02"}, "Open to work · from 2 Nov 2026"},
+		{"past start", Availability{OpenToWork: true, Start: "2026-10-14"}, "Open to work"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.a.Summary(now); got != tt.want {
+				t.Errorf("Summary = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package content
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sync"
+	"time"
+)
+
+// assetsDir is the directory of a data directory holding files the
+// content files do not describe, such as the boot messages.
+const assetsDir = "assets"
+
+// bootMessagesFile is the optional assets file listing the intro's boot
+// sequence, which the web portfolio shows too.
+const bootMessagesFile = "boot-messages.json"
+
+// Boot message types, which set a message's color.
+const (
+	BootSystem  = "system"
+	BootInfo    = "info"
+	BootSuccess = "success"
+	BootAccent  = "accent"
+)
+
+// MaxBootDelay is the longest a boot message may wait before it appears;
+// longer pauses read as a hang.
+const MaxBootDelay = 5 * time.Second
+
+// BootMessage is one line of the boot sequence.
+type BootMessage struct {
+	Text    string `json:"text" jsonschema:"required"`                                 // Line shown.
+	Type    string `json:"type" jsonschema:"required,enum=system|info|success|accent"` // Color category.
+	DelayMs int    `json:"delayMs,omitempty"`                                          // Milliseconds to wait before showing the line; 0 keeps the usual pace.
+}
+
+// Delay returns the wait before the message appears, or 0 for the
+// intro's usual pace.
+func (m BootMessage) Delay() time.Duration {
+	return time.Duration(m.DelayMs) * time.Millisecond
+}
+
+// BootMessages holds the boot sequence from boot-messages.json.
+type BootMessages struct {
+	Messages []BootMessage `json:"messages" jsonschema:"required,minItems=1"`
+}
+
+// loadBootMessages reads assets/boot-messages.json if present.
+func loadBootMessages(fsys fs.FS) ([]BootMessage, error) {
+	name := path.Join(assetsDir, bootMessagesFile)
+	if _, err := fs.Stat(fsys, name); errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	var b BootMessages
+	if err := loadJSON(fsys, name, &b); err != nil {
+		return nil, err
+	}
+	return b.Messages, nil
+}
+
+// validateBootMessages checks that there is at least one message and
+// that each has text, a known type, and a delay from 0 to MaxBootDelay.
+func validateBootMessages(msgs []BootMessage) error {
+	if len(msgs) == 0 {
+		return errors.New("at least one message is required")
+	}
+	for i, m := range msgs {
+		if err := requireField("text", m.Text); err != nil {
+			return fmt.Errorf("message[%d]: %w", i, err)
+		}
+		switch m.Type {
+		case BootSystem, BootInfo, BootSuccess, BootAccent:
+		default:
+			return fmt.Errorf("message[%d]: unsupported type %q", i, m.Type)
+		}
+		if m.DelayMs < 0 || m.Delay() > MaxBootDelay {
+			return fmt.Errorf("message[%d]: delayMs must be from 0 to %d, got %d", i, MaxBootDelay.Milliseconds(), m.DelayMs)
+		}
+	}
+	return nil
+}
+
+// DefaultBootMessages returns the boot sequence built into the binary,
+// for content that has none of its own.
+var DefaultBootMessages = sync.OnceValue(func() []BootMessage {
+	msgs, err := loadBootMessages(Embedded().FS)
+	if err == nil {
+		err = validateBootMessages(msgs)
+	}
+	if err != nil {
+		panic(fmt.Sprintf("embedded %s: %v", bootMessagesFile, err))
+	}
+	return msgs
+})
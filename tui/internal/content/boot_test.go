@@ -0,0 +1,90 @@
+package content
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// bootDataDir returns a data directory with valid content and, when
+// boot is not empty, assets/boot-messages.json holding it.
+func bootDataDir(t *testing.T, boot string) string {
+	t.Helper()
+	tmpDir := t.TempDir()
+	contentDir := filepath.Join(tmpDir, "content")
+	assets := filepath.Join(tmpDir, "assets")
+	for _, dir := range []string{contentDir, assets} {
+		if err := os.Mkdir(dir, 0o755); err != nil {
+			t.Fatalf("creating %s: %v", dir, err)
+		}
+	}
+	writeValidContent(t, contentDir, `{"version":"1.0.0","name":"Test","title":"Dev"}`)
+	if boot != "" {
+		writeFile(t, assets, "boot-messages.json", boot)
+	}
+	return tmpDir
+}
+
+func TestLoadAllBootMessages(t *testing.T) {
+	c, err := LoadAll(bootDataDir(t, `{"messages":[{"text":"Booting","type":"system"},{"text":"Ready","type":"accent","delayMs":800}]}`))
+	if err != nil {
+		t.Fatalf("LoadAll failed: %v", err)
+	}
+	want := []BootMessage{{Text: "Booting", Type: BootSystem}, {Text: "Ready", Type: BootAccent, DelayMs: 800}}
+	if len(c.BootMessages) != len(want) || c.BootMessages[0] != want[0] || c.BootMessages[1] != want[1] {
+		t.Errorf("BootMessages = %+v, want %+v", c.BootMessages, want)
+	}
+
+	c, err = LoadAll(bootDataDir(t, ""))
+	if err != nil {
+		t.Fatalf("LoadAll without boot messages failed: %v", err)
+	}
+	if c.BootMessages != nil {
+		t.Errorf("BootMessages = %+v, want nil when boot-messages.json is absent", c.BootMessages)
+	}
+}
+
+func TestLoadAllBootMessagesInvalid(t *testing.T) {
+	tests := []struct {
+		name, boot, wantErr string
+	}{
+		{"no messages", `{"messages":[]}`, "must not be empty"},
+		{"empty text", `{"messages":[{"text":"","type":"info"}]}`, "text"},
+		{"unknown type", `{"messages":[{"text":"Hi","type":"warning"}]}`, "warning"},
+		{"negative delay", `{"messages":[{"text":"Hi","type":"info","delayMs":-1}]}`, "delayMs must be from 0 to 5000"},
+		{"long delay", `{"messages":[{"text":"Hi","type":"info","delayMs":60000}]}`, "delayMs must be from 0 to 5000"},
+		{"unknown field", `{"messages":[{"text":"Hi","type":"info","delay":100}]}`, "delay"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadAll(bootDataDir(t, tt.boot))
+			if err == nil || !strings.Contains(err.Error(), "boot-messages.json") || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want it to name boot-messages.json and contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadPartialBadBootMessages(t *testing.T) {
+	c, err := LoadPartial(bootDataDir(t, `{"messages":[{"text":"Hi","type":"info","delayMs":60000}]}`))
+	if err != nil {
+		t.Fatalf("LoadPartial failed: %v", err)
+	}
+	if c.BootMessages != nil {
+		t.Errorf("BootMessages = %+v, want nil for a bad file", c.BootMessages)
+	}
+	if _, ok := c.Unavailable["assets/boot-messages.json"]; !ok {
+		t.Errorf("Unavailable = %v, want assets/boot-messages.json listed", c.Unavailable)
+	}
+}
+
+func TestDefaultBootMessages(t *testing.T) {
+	msgs := DefaultBootMessages()
+	if len(msgs) == 0 {
+		t.Fatal("DefaultBootMessages is empty")
+	}
+	if err := validateBootMessages(msgs); err != nil {
+		t.Errorf("embedded boot messages are invalid: %v", err)
+	}
+}
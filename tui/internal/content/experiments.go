@@ -0,0 +1,130 @@
+package content
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"math/rand/v2"
+	"path"
+)
+
+// experimentsFile is the optional content file defining A/B experiments.
+const experimentsFile = "experiments.json"
+
+// Variant is one alternative value for an experiment's field.
+type Variant struct {
+	Name  string `json:"name" jsonschema:"required"`  // Variant name, as analytics reports it.
+	Value string `json:"value" jsonschema:"required"` // Text shown to sessions given this variant.
+}
+
+// Experiment swaps a single text field between variants per session so the
+// owner can compare how different copy performs.
+type Experiment struct {
+	ID       string    `json:"id" jsonschema:"required"`                                            // Unique experiment name.
+	Field    string    `json:"field" jsonschema:"required,enum=about.bio|meta.oneLiner|cv.summary"` // Text the variants replace.
+	Variants []Variant `json:"variants" jsonschema:"required,minItems=2"`
+}
+
+// Experiments holds the experiment list from experiments.json.
+type Experiments struct {
+	Experiments []Experiment `json:"experiments" jsonschema:"required"`
+}
+
+// experimentFields maps the field paths an experiment may target to the
+// string they replace in a Content.
+var experimentFields = map[string]func(*Content) *string{
+	"about.bio":     func(c *Content) *string { return &c.About.Bio },
+	"meta.oneLiner": func(c *Content) *string { return &c.Meta.OneLiner },
+	"cv.summary":    func(c *Content) *string { return &c.CV.Summary },
+}
+
+// loadExperiments reads experiments.json from the content directory. A
+// missing file means no experiments are running.
+func loadExperiments(fsys fs.FS) ([]Experiment, error) {
+	name := path.Join(contentDir, experimentsFile)
+	if _, err := fs.Stat(fsys, name); errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	var e Experiments
+	if err := loadJSON(fsys, name, &e); err != nil {
+		return nil, err
+	}
+	return e.Experiments, nil
+}
+
+// validateExperiments checks that each experiment has a unique ID, targets
+// a supported field, and defines at least two distinct, non-empty variants.
+func validateExperiments(exps []Experiment) error {
+	ids := map[string]bool{}
+	for i, e := range exps {
+		if err := requireField("id", e.ID); err != nil {
+			return fmt.Errorf("experiment[%d]: %w", i, err)
+		}
+		if ids[e.ID] {
+			return fmt.Errorf("experiment[%d]: duplicate id %q", i, e.ID)
+		}
+		ids[e.ID] = true
+		if _, ok := experimentFields[e.Field]; !ok {
+			return fmt.Errorf("experiment %q: unsupported field %q", e.ID, e.Field)
+		}
+		if len(e.Variants) < 2 {
+			return fmt.Errorf("experiment %q: needs at least two variants", e.ID)
+		}
+		names := map[string]bool{}
+		for j, v := range e.Variants {
+			if err := requireField("name", v.Name); err != nil {
+				return fmt.Errorf("experiment %q variant[%d]: %w", e.ID, j, err)
+			}
+			if names[v.Name] {
+				return fmt.Errorf("experiment %q: duplicate variant %q", e.ID, v.Name)
+			}
+			names[v.Name] = true
+			if err := requireField("value", v.Value); err != nil {
+				return fmt.Errorf("experiment %q variant %q: %w", e.ID, v.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// AssignVariants picks a variant uniformly at random for every experiment,
+// returning experiment ID → variant name. A nil r uses the global source.
+// It returns nil when there are no experiments.
+func (c *Content) AssignVariants(r *rand.Rand) map[string]string {
+	if len(c.Experiments) == 0 {
+		return nil
+	}
+	pick := rand.IntN
+	if r != nil {
+		pick = r.IntN
+	}
+	assigned := make(map[string]string, len(c.Experiments))
+	for _, e := range c.Experiments {
+		assigned[e.ID] = e.Variants[pick(len(e.Variants))].Name
+	}
+	return assigned
+}
+
+// WithVariants returns a copy of c with each assigned variant's value
+// written into its experiment's field. The receiver is not modified, so a
+// shared Content can serve sessions in different variants. Slices are
+// shared with c; only the targeted string fields differ.
+func (c *Content) WithVariants(assigned map[string]string) *Content {
+	if len(assigned) == 0 {
+		return c
+	}
+	out := *c
+	for _, e := range c.Experiments {
+		name, ok := assigned[e.ID]
+		if !ok {
+			continue
+		}
+		for _, v := range e.Variants {
+			if v.Name == name {
+				*experimentFields[e.Field](&out) = v.Value
+				break
+			}
+		}
+	}
+	return &out
+}
@@ -0,0 +1,116 @@
+package content
+
+import (
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func bioExperiment() Experiment {
+	return Experiment{
+		ID:    "bio-copy",
+		Field: "about.bio",
+		Variants: []Variant{
+			{Name: "a", Value: "Bio A"},
+			{Name: "b", Value: "Bio B"},
+		},
+	}
+}
+
+func TestLoadAllWithoutExperiments(t *testing.T) {
+	c, err := LoadAll(dataDir(t))
+	if err != nil {
+		t.Fatalf("LoadAll failed: %v", err)
+	}
+	if c.Experiments != nil {
+		t.Errorf("Experiments = %v, want nil when experiments.json is absent", c.Experiments)
+	}
+	if v := c.AssignVariants(nil); v != nil {
+		t.Errorf("AssignVariants = %v, want nil without experiments", v)
+	}
+}
+
+func TestLoadAllExperiments(t *testing.T) {
+	tmpDir := t.TempDir()
+	contentDir := filepath.Join(tmpDir, "content")
+	if err := os.Mkdir(contentDir, 0o755); err != nil {
+		t.Fatalf("creating content dir: %v", err)
+	}
+	writeValidContent(t, contentDir, `{"version":"1.0.0","name":"Test","title":"Dev"}`)
+	writeFile(t, contentDir, "experiments.json", `{"experiments":[{"id":"bio-copy","field":"about.bio","variants":[{"name":"a","value":"Bio A"},{"name":"b","value":"Bio B"}]}]}`)
+
+	c, err := LoadAll(tmpDir)
+	if err != nil {
+		t.Fatalf("LoadAll failed: %v", err)
+	}
+	if len(c.Experiments) != 1 || c.Experiments[0].ID != "bio-copy" {
+		t.Errorf("Experiments = %+v", c.Experiments)
+	}
+}
+
+func TestValidateExperiments(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(*Experiment)
+		wantErr string
+	}{
+		{"valid", func(*Experiment) {}, ""},
+		{"missing id", func(e *Experiment) { e.ID = "" }, "id is required"},
+		{"unknown field", func(e *Experiment) { e.Field = "about.email" }, "unsupported field"},
+		{"one variant", func(e *Experiment) { e.Variants = e.Variants[:1] }, "at least two variants"},
+		{"duplicate variant", func(e *Experiment) { e.Variants[1].Name = "a" }, "duplicate variant"},
+		{"empty value", func(e *Experiment) { e.Variants[1].Value = "" }, "value is required"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := bioExperiment()
+			tt.mutate(&e)
+			err := validateExperiments([]Experiment{e})
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	if err := validateExperiments([]Experiment{bioExperiment(), bioExperiment()}); err == nil {
+		t.Error("expected error for duplicate experiment ids")
+	}
+}
+
+func TestAssignVariantsCoversAll(t *testing.T) {
+	c := &Content{Experiments: []Experiment{bioExperiment()}}
+	r := rand.New(rand.NewPCG(1, 2))
+	seen := map[string]int{}
+	for range 200 {
+		seen[c.AssignVariants(r)["bio-copy"]]++
+	}
+	if seen["a"] == 0 || seen["b"] == 0 || len(seen) != 2 {
+		t.Errorf("assignment counts = %v, want both variants", seen)
+	}
+}
+
+func TestWithVariants(t *testing.T) {
+	c := &Content{
+		About:       About{Bio: "Original"},
+		Experiments: []Experiment{bioExperiment()},
+	}
+
+	b := c.WithVariants(map[string]string{"bio-copy": "b"})
+	if b.About.Bio != "Bio B" {
+		t.Errorf("variant Bio = %q, want %q", b.About.Bio, "Bio B")
+	}
+	if c.About.Bio != "Original" {
+		t.Errorf("WithVariants modified the shared content: Bio = %q", c.About.Bio)
+	}
+	if got := c.WithVariants(nil); got != c {
+		t.Error("WithVariants(nil) should return the receiver unchanged")
+	}
+}
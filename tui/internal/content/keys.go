@@ -0,0 +1,239 @@
+package content
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// keysFile is the optional content file listing the owner's public keys.
+const keysFile = "keys.json"
+
+// Key types accepted in keys.json.
+const (
+	KeyPGP = "pgp"
+	KeySSH = "ssh"
+)
+
+// PublicKey is one of the owner's public keys, given inline as Key or
+// read from File, a path relative to the data directory.
+type PublicKey struct {
+	Label string `json:"label" jsonschema:"required"`             // Key name, such as Laptop or Signing.
+	Type  string `json:"type" jsonschema:"required,enum=pgp|ssh"` // Kind of key.
+	Key   string `json:"key,omitempty"`                           // An ASCII-armored PGP key block or an authorized_keys line.
+	File  string `json:"file,omitempty"`                          // Path of a file holding the key, relative to the data directory.
+
+	// Fingerprint is computed from the key while loading: the SHA-256
+	// fingerprint of an SSH key as ssh-keygen -l prints it, or the
+	// OpenPGP fingerprint of a PGP key in groups of four hex digits.
+	Fingerprint string `json:"-"`
+}
+
+// Keys holds the key list from keys.json.
+type Keys struct {
+	Keys []PublicKey `json:"keys" jsonschema:"required"`
+}
+
+// loadKeys reads keys.json if present, pulls in the keys given as files,
+// and computes each fingerprint.
+func loadKeys(fsys fs.FS) ([]PublicKey, error) {
+	name := path.Join(contentDir, keysFile)
+	if _, err := fs.Stat(fsys, name); errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	var k Keys
+	if err := loadJSON(fsys, name, &k); err != nil {
+		return nil, err
+	}
+	for i := range k.Keys {
+		key := &k.Keys[i]
+		if err := requireField("label", key.Label); err != nil {
+			return nil, fmt.Errorf("key[%d]: %w", i, err)
+		}
+		if key.File != "" {
+			if key.Key != "" {
+				return nil, fmt.Errorf("key %q: set key or file, not both", key.Label)
+			}
+			if filepath.IsAbs(key.File) || !filepath.IsLocal(key.File) {
+				return nil, fmt.Errorf("key %q: file must be inside the data directory, got %q", key.Label, key.File)
+			}
+			data, err := fs.ReadFile(fsys, filepath.ToSlash(key.File))
+			if err != nil {
+				return nil, fmt.Errorf("key %q: %w", key.Label, err)
+			}
+			key.Key = string(data)
+		}
+		key.Key = strings.TrimSpace(key.Key)
+		if err := requireField("key", key.Key); err != nil {
+			return nil, fmt.Errorf("key %q: %w", key.Label, err)
+		}
+		fp, err := fingerprint(key.Type, key.Key)
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", key.Label, err)
+		}
+		key.Fingerprint = fp
+	}
+	return k.Keys, nil
+}
+
+// fingerprint returns the fingerprint of a key of the given type.
+func fingerprint(typ, key string) (string, error) {
+	switch typ {
+	case KeySSH:
+		pub, _, _, _, err := ssh.ParseAuthorizedKey([]byte(key))
+		if err != nil {
+			return "", fmt.Errorf("invalid SSH public key: %w", err)
+		}
+		return ssh.FingerprintSHA256(pub), nil
+	case KeyPGP:
+		return pgpFingerprint(key)
+	default:
+		return "", fmt.Errorf("type must be %s or %s, got %q", KeyPGP, KeySSH, typ)
+	}
+}
+
+// pgpFingerprint returns the fingerprint of the primary key in an
+// ASCII-armored OpenPGP public key block. Version 4 keys hash with SHA-1
+// and version 5 and 6 keys with SHA-256, as RFC 9580 specifies.
+func pgpFingerprint(armored string) (string, error) {
+	data, err := dearmor(armored)
+	if err != nil {
+		return "", err
+	}
+	body, err := firstPacket(data)
+	if err != nil {
+		return "", err
+	}
+	var sum []byte
+	switch body[0] {
+	case 4:
+		h := sha1.New()
+		h.Write([]byte{0x99, byte(len(body) >> 8), byte(len(body))})
+		h.Write(body)
+		sum = h.Sum(nil)
+	case 5, 6:
+		prefix := byte(0x9A)
+		if body[0] == 6 {
+			prefix = 0x9B
+		}
+		h := sha256.New()
+		h.Write([]byte{prefix})
+		h.Write(binary.BigEndian.AppendUint32(nil, uint32(len(body))))
+		h.Write(body)
+		sum = h.Sum(nil)
+	default:
+		return "", fmt.Errorf("unsupported PGP key version %d", body[0])
+	}
+
+	hex := fmt.Sprintf("%X", sum)
+	var groups []string
+	for i := 0; i < len(hex); i += 4 {
+		groups = append(groups, hex[i:i+4])
+	}
+	return strings.Join(groups, " "), nil
+}
+
+// dearmor decodes the body of an ASCII-armored PGP public key block.
+func dearmor(armored string) ([]byte, error) {
+	const begin = "-----BEGIN PGP PUBLIC KEY BLOCK-----"
+	_, rest, ok := strings.Cut(armored, begin)
+	if !ok {
+		return nil, errors.New("PGP key must be an ASCII-armored public key block")
+	}
+	lines := strings.Split(strings.ReplaceAll(rest, "\r\n", "\n"), "\n")
+	var b64 strings.Builder
+	inBody := false
+	for _, line := range lines[1:] {
+		line = strings.TrimSpace(line)
+		switch {
+		case !inBody:
+			// Armor headers such as "Comment: ..." end at a blank line.
+			inBody = line == "" || !strings.Contains(line, ": ")
+			if inBody && line != "" {
+				b64.WriteString(line)
+			}
+		case strings.HasPrefix(line, "=") || strings.HasPrefix(line, "-----END"):
+			data, err := base64.StdEncoding.DecodeString(b64.String())
+			if err != nil {
+				return nil, fmt.Errorf("PGP key armor: %w", err)
+			}
+			return data, nil
+		default:
+			b64.WriteString(line)
+		}
+	}
+	return nil, errors.New("PGP key armor is not terminated")
+}
+
+// firstPacket returns the body of the first packet in data, which must be
+// a public key packet.
+func firstPacket(data []byte) ([]byte, error) {
+	errTruncated := errors.New("PGP key is truncated")
+	if len(data) < 2 || data[0]&0x80 == 0 {
+		return nil, errors.New("PGP key does not start with a packet")
+	}
+	r := bytes.NewReader(data[1:])
+	var tag byte
+	var n int
+	if data[0]&0x40 != 0 {
+		tag = data[0] & 0x3f
+		l0, _ := r.ReadByte()
+		switch {
+		case l0 < 192:
+			n = int(l0)
+		case l0 < 224:
+			l1, err := r.ReadByte()
+			if err != nil {
+				return nil, errTruncated
+			}
+			n = (int(l0)-192)<<8 + int(l1) + 192
+		case l0 == 255:
+			var l uint32
+			if err := binary.Read(r, binary.BigEndian, &l); err != nil {
+				return nil, errTruncated
+			}
+			n = int(l)
+		default:
+			return nil, errors.New("PGP key packet has a partial length")
+		}
+	} else {
+		tag = (data[0] >> 2) & 0x0f
+		switch data[0] & 0x03 {
+		case 0:
+			l, _ := r.ReadByte()
+			n = int(l)
+		case 1:
+			var l uint16
+			if err := binary.Read(r, binary.BigEndian, &l); err != nil {
+				return nil, errTruncated
+			}
+			n = int(l)
+		case 2:
+			var l uint32
+			if err := binary.Read(r, binary.BigEndian, &l); err != nil {
+				return nil, errTruncated
+			}
+			n = int(l)
+		default:
+			n = r.Len()
+		}
+	}
+	if tag != 6 {
+		return nil, fmt.Errorf("PGP key starts with packet type %d, not a public key", tag)
+	}
+	if n < 1 || n > r.Len() {
+		return nil, errTruncated
+	}
+	start := len(data) - r.Len()
+	return data[start : start+n], nil
+}
@@ -0,0 +1,90 @@
+package content
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// testPGPKey is an ed25519 key exported by gpg --armor --export, with the
+// fingerprint gpg --fingerprint prints for it.
+const (
+	testPGPKey = `-----BEGIN PGP PUBLIC KEY BLOCK-----
+
+mDMEas92oBYJKwYBBAHaRw8BAQdA+MHqW93FZ65w334fWUfO9mO9OVZsDlvd7nzc
+BnQ3u6e0HEt5bGUgVGVzdCA8a3lsZUBleGFtcGxlLmNvbT6IkAQTFggAOBYhBA5c
+7gs7J9dyGXcBsoZ9+weO27lFBQJqz3agAhsBBQsJCAcCBhUKCQgLAgQWAgMBAh4B
+AheAAAoJEIZ9+weO27lFQ2YBAJipO/v4A9IM7znvYfjBYSuPxC8IkR28K2o4t0Uj
+vkKCAP43bZcFucw5CmsWbvnOdYUV5GYI3QlX8cNmWZtZt0+sDw==
+=H/m7
+-----END PGP PUBLIC KEY BLOCK-----`
+	testPGPFingerprint = "0E5C EE0B 3B27 D772 1977 01B2 867D FB07 8EDB B945"
+)
+
+// testSSHKey is an ed25519 key with the fingerprint ssh-keygen -l prints.
+const (
+	testSSHKey         = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIDh56NQO/0raVMEn98aWXy2ha55C2qstmXacUgGaw/eq kyle@laptop"
+	testSSHFingerprint = "SHA256:1i2PfStljwQyDrBpZz2XbgNBEcmeX+jOqAa59ghyXw8"
+)
+
+func TestFingerprint(t *testing.T) {
+	for _, tc := range []struct{ typ, key, want string }{
+		{KeyPGP, testPGPKey, testPGPFingerprint},
+		{KeyPGP, strings.ReplaceAll(testPGPKey, "\n", "\r\n"), testPGPFingerprint},
+		{KeySSH, testSSHKey, testSSHFingerprint},
+	} {
+		got, err := fingerprint(tc.typ, tc.key)
+		if err != nil {
+			t.Errorf("fingerprint(%s): %v", tc.typ, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("fingerprint(%s) = %q, want %q", tc.typ, got, tc.want)
+		}
+	}
+
+	for _, tc := range []struct{ typ, key string }{
+		{KeySSH, "ssh-ed25519 not-base64"},
+		{KeyPGP, testSSHKey},
+		{KeyPGP, "-----BEGIN PGP PUBLIC KEY BLOCK-----\n\nAAAA\n"},
+		{"gpg", testPGPKey},
+	} {
+		if _, err := fingerprint(tc.typ, tc.key); err == nil {
+			t.Errorf("fingerprint(%s, %q) should fail", tc.typ, tc.key)
+		}
+	}
+}
+
+func TestLoadAllKeys(t *testing.T) {
+	tmpDir := t.TempDir()
+	contentDir := filepath.Join(tmpDir, "content")
+	if err := os.Mkdir(contentDir, 0o755); err != nil {
+		t.Fatalf("creating content dir: %v", err)
+	}
+	writeValidContent(t, contentDir, `{"version":"1.0.0","name":"Test","title":"Dev"}`)
+	writeFile(t, tmpDir, "pgp.asc", testPGPKey)
+	writeFile(t, contentDir, "keys.json", `{"keys":[
+		{"label":"PGP","type":"pgp","file":"pgp.asc"},
+		{"label":"Laptop","type":"ssh","key":"`+testSSHKey+`"}
+	]}`)
+
+	c, err := LoadAll(tmpDir)
+	if err != nil {
+		t.Fatalf("LoadAll failed: %v", err)
+	}
+	if len(c.Keys) != 2 {
+		t.Fatalf("Keys = %+v, want 2 keys", c.Keys)
+	}
+	if c.Keys[0].Fingerprint != testPGPFingerprint || !strings.HasPrefix(c.Keys[0].Key, "-----BEGIN PGP") {
+		t.Errorf("PGP key from file = %+v", c.Keys[0])
+	}
+	if c.Keys[1].Fingerprint != testSSHFingerprint {
+		t.Errorf("SSH key fingerprint = %q", c.Keys[1].Fingerprint)
+	}
+
+	writeFile(t, contentDir, "keys.json", `{"keys":[{"label":"PGP","type":"pgp","file":"../pgp.asc"}]}`)
+	if _, err := LoadAll(tmpDir); err == nil {
+		t.Error("expected error for a key file outside the data directory")
+	}
+}
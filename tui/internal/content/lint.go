@@ -0,0 +1,259 @@
+package content
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/mail"
+	"net/url"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// MaxBulletLen is the longest CV bullet, in characters, that Lint accepts
+// without a warning. Longer ones run to many lines on narrow terminals.
+const MaxBulletLen = 200
+
+// Severity is how serious an Issue is.
+type Severity int
+
+const (
+	// SeverityError is content that is broken or misleading.
+	SeverityError Severity = iota
+	// SeverityWarning is content that works but reads poorly.
+	SeverityWarning
+)
+
+func (s Severity) String() string {
+	if s == SeverityWarning {
+		return "warning"
+	}
+	return "error"
+}
+
+// Issue is a problem found in a content file.
+type Issue struct {
+	Severity Severity
+	// File is the file's name in the content directory, such as cv.json
+	// or de/cv.json.
+	File string
+	// Line and Column locate the value in the file, or are 0 when it
+	// could not be found.
+	Line, Column int
+	// Path names the value as SchemaError does, such as
+	// experience[0].bullets[2]; it is empty for the whole file.
+	Path string
+	Msg  string
+}
+
+func (i Issue) String() string {
+	loc := i.File
+	if i.Line > 0 {
+		loc = fmt.Sprintf("%s:%d:%d", i.File, i.Line, i.Column)
+	}
+	if i.Path != "" {
+		return fmt.Sprintf("%s: %s: %s: %s", loc, i.Severity, i.Path, i.Msg)
+	}
+	return fmt.Sprintf("%s: %s: %s", loc, i.Severity, i.Msg)
+}
+
+// Validate loads the content in src as LoadPartial does and lints what
+// loaded, translations included, returning every problem found sorted by
+// file: the files that failed to load, then Lint's findings, located in
+// their files. It returns an error only when there is no content to check.
+func Validate(src Source) ([]Issue, error) {
+	c, err := src.LoadPartial()
+	if err != nil {
+		var fe *fileError
+		if !errors.As(err, &fe) {
+			return nil, err
+		}
+		return []Issue{loadIssue(fe.file, err)}, nil
+	}
+
+	var issues []Issue
+	for _, file := range c.UnavailableFiles() {
+		issues = append(issues, loadIssue(file, c.Unavailable[file]))
+	}
+	lintIn := func(c *Content, dir, prefix string) {
+		for _, file := range lintedFiles {
+			data, err := fs.ReadFile(src.FS, path.Join(dir, file))
+			if err != nil || !c.Available(prefix+file) {
+				continue
+			}
+			for _, is := range lintFile(c, file) {
+				is.File = prefix + file
+				is.Line, is.Column = locate(data, is.Path)
+				issues = append(issues, is)
+			}
+		}
+	}
+	lintIn(c, contentDir, "")
+	// Translations are checked only in the files they have; the rest are
+	// the base content's.
+	entries, _ := fs.ReadDir(src.FS, contentDir)
+	for _, e := range entries {
+		if l, ok := c.Locales[strings.ToLower(e.Name())]; ok && e.IsDir() {
+			lintIn(l, path.Join(contentDir, e.Name()), strings.ToLower(e.Name())+"/")
+		}
+	}
+	slices.SortStableFunc(issues, func(a, b Issue) int { return strings.Compare(a.File, b.File) })
+	return issues, nil
+}
+
+// loadIssue describes a file that failed to load, at its schema error
+// when it has one.
+func loadIssue(file string, err error) Issue {
+	var se *SchemaError
+	if errors.As(err, &se) {
+		return Issue{File: file, Line: se.Line, Column: se.Column, Path: se.Path, Msg: se.Msg}
+	}
+	// Loader errors start with the file name, which the issue shows.
+	msg := err.Error()
+	for _, prefix := range []string{"loading " + path.Base(file) + ": ", path.Base(file) + ": "} {
+		msg = strings.TrimPrefix(msg, prefix)
+	}
+	return Issue{File: file, Msg: msg}
+}
+
+// lintedFiles are the content files Lint checks.
+var lintedFiles = []string{"meta.json", "about.json", "work.json", "cv.json", "links.json", usesFile, talksFile}
+
+// Lint checks c for mistakes loading accepts: URLs that do not parse or
+// lack a host, email addresses that do not look like one, CV bullets
+// longer than MaxBulletLen, and links sharing a label. Files that did not
+// load are skipped, and so are translations; Validate checks those.
+func Lint(c *Content) []Issue {
+	var issues []Issue
+	for _, file := range lintedFiles {
+		if c.Available(file) {
+			issues = append(issues, lintFile(c, file)...)
+		}
+	}
+	return issues
+}
+
+// lintFile lints the part of c loaded from the named content file.
+func lintFile(c *Content, file string) []Issue {
+	l := linter{file: file}
+	switch file {
+	case "meta.json":
+		l.url("siteUrl", c.Meta.SiteURL)
+		l.url("sourceRepo", c.Meta.SourceRepo)
+	case "about.json":
+		l.email("email", c.About.Email)
+	case "work.json":
+		for i, p := range c.Work.Projects {
+			l.url(fmt.Sprintf("projects[%d].url", i), p.URL)
+			l.url(fmt.Sprintf("projects[%d].repo", i), p.Repo)
+		}
+	case "cv.json":
+		l.email("contact.email", c.CV.Contact.Email)
+		l.url("contact.website", c.CV.Contact.Website)
+		for i, e := range c.CV.Experience {
+			for j, b := range e.Bullets {
+				if n := utf8.RuneCountInString(b); n > MaxBulletLen {
+					l.add(SeverityWarning, fmt.Sprintf("experience[%d].bullets[%d]", i, j),
+						"bullet is %d characters; keep it under %d so it reads at a glance", n, MaxBulletLen)
+				}
+			}
+		}
+	case "links.json":
+		seen := make(map[string]int)
+		for i, link := range c.Links.Links {
+			p := fmt.Sprintf("links[%d]", i)
+			if addr, ok := strings.CutPrefix(link.URL, "mailto:"); ok {
+				l.email(p+".url", addr)
+			} else {
+				l.url(p+".url", link.URL)
+			}
+			key := strings.ToLower(strings.TrimSpace(link.Label))
+			if first, dup := seen[key]; dup {
+				l.add(SeverityError, p+".label", "label %q is also used by links[%d]", link.Label, first)
+			} else {
+				seen[key] = i
+			}
+		}
+	case usesFile:
+		for i, cat := range c.Uses {
+			for j, item := range cat.Items {
+				l.url(fmt.Sprintf("categories[%d].items[%d].url", i, j), item.URL)
+			}
+		}
+	case talksFile:
+		for i, talk := range c.Talks {
+			l.url(fmt.Sprintf("talks[%d].video", i), talk.Video)
+			l.url(fmt.Sprintf("talks[%d].slides", i), talk.Slides)
+		}
+	}
+	return l.issues
+}
+
+// linter collects the issues lintFile finds in one file.
+type linter struct {
+	file   string
+	issues []Issue
+}
+
+func (l *linter) add(sev Severity, path, format string, args ...any) {
+	l.issues = append(l.issues, Issue{Severity: sev, File: l.file, Path: path, Msg: fmt.Sprintf(format, args...)})
+}
+
+// url checks an optional URL field: it must parse, and an http or https
+// URL needs a host. One without a scheme works in some places but not as
+// a link, so it is a warning.
+func (l *linter) url(path, value string) {
+	if value == "" {
+		return
+	}
+	u, err := url.Parse(value)
+	switch {
+	case err != nil:
+		l.add(SeverityError, path, "%q is not a valid URL", value)
+	case u.Scheme == "":
+		l.add(SeverityWarning, path, "%q has no scheme, so it will not open as a link; add https://", value)
+	case (u.Scheme == "http" || u.Scheme == "https") && u.Host == "":
+		l.add(SeverityError, path, "%q has no host", value)
+	}
+}
+
+// email checks that value is a bare email address, such as hi@example.com.
+func (l *linter) email(path, value string) {
+	if value == "" {
+		return
+	}
+	addr, err := mail.ParseAddress(value)
+	if err != nil || addr.Address != value || !strings.Contains(value[strings.LastIndexByte(value, '@')+1:], ".") {
+		l.add(SeverityError, path, "%q does not look like an email address", value)
+	}
+}
+
+// locate returns the line and column of the value at path, named as
+// SchemaError names it, in the JSON data, or 0, 0 if it is not there.
+func locate(data []byte, path string) (line, col int) {
+	n, err := parseNode(data)
+	if err != nil {
+		return 0, 0
+	}
+	for _, seg := range strings.Split(path, ".") {
+		key, rest, _ := strings.Cut(seg, "[")
+		if key != "" {
+			if n = n.member(key); n == nil {
+				return 0, 0
+			}
+		}
+		for rest != "" {
+			idx, after, ok := strings.Cut(rest, "]")
+			i, err := strconv.Atoi(idx)
+			if !ok || err != nil || i < 0 || i >= len(n.items) {
+				return 0, 0
+			}
+			n = n.items[i]
+			rest = strings.TrimPrefix(after, "[")
+		}
+	}
+	return position(data, n.offset)
+}
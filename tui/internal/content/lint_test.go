@@ -0,0 +1,98 @@
+package content
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateRealData(t *testing.T) {
+	issues, err := Validate(Disk(dataDir(t)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, is := range issues {
+		if is.Severity == SeverityError {
+			t.Errorf("the shipped content should validate: %s", is)
+		}
+	}
+}
+
+func TestValidateFindsLintIssues(t *testing.T) {
+	tmpDir := t.TempDir()
+	contentDir := filepath.Join(tmpDir, "content")
+	if err := os.Mkdir(contentDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeValidContent(t, contentDir, `{"version":"1.0.0","name":"Test","title":"Dev","siteUrl":"example.com"}`)
+	writeFile(t, contentDir, "links.json", `{"links":[
+  {"label":"Mail","url":"mailto:nobody","icon":"x"},
+  {"label":"mail","url":"https:///path","icon":"x"}
+]}`)
+	writeFile(t, contentDir, "cv.json", `{"contact":{"email":"a@b.c","location":"X"},"summary":"S","experience":[{"company":"C","role":"R","start":"2020","end":"2024","bullets":["`+strings.Repeat("x", MaxBulletLen+1)+`"]}],"skills":[{"category":"C","items":["i"]}],"education":[]}`)
+
+	issues, err := Validate(Disk(tmpDir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		`cv.json:1:138: warning: experience[0].bullets[0]: bullet is 201 characters; keep it under 200 so it reads at a glance`,
+		`links.json:2:25: error: links[0].url: "nobody" does not look like an email address`,
+		`links.json:3:25: error: links[1].url: "https:///path" has no host`,
+		`links.json:3:12: error: links[1].label: label "mail" is also used by links[0]`,
+		`meta.json:1:58: warning: siteUrl: "example.com" has no scheme, so it will not open as a link; add https://`,
+	}
+	if len(issues) != len(want) {
+		t.Fatalf("got %d issues, want %d: %v", len(issues), len(want), issues)
+	}
+	for i, is := range issues {
+		if is.String() != want[i] {
+			t.Errorf("issue %d = %s, want %s", i, is, want[i])
+		}
+	}
+}
+
+func TestValidateReportsLoadErrors(t *testing.T) {
+	tmpDir := t.TempDir()
+	contentDir := filepath.Join(tmpDir, "content")
+	if err := os.MkdirAll(filepath.Join(contentDir, "de"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeValidContent(t, contentDir, `{"version":"1.0.0","name":"Test","title":"Dev"}`)
+	writeFile(t, contentDir, "work.json", `{"projects":[{"description":"D"}]}`)
+	writeFile(t, filepath.Join(contentDir, "de"), "about.json", `{"bio":"Ein Text","email":"not an address"}`)
+
+	issues, err := Validate(Disk(tmpDir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 2 {
+		t.Fatalf("got %v, want the translation's email and the missing title", issues)
+	}
+	if is := issues[0]; is.File != "de/about.json" || is.Path != "email" || is.Line != 1 {
+		t.Errorf("translation issue = %s, want de/about.json:1 email", is)
+	}
+	if is := issues[1]; is.File != "work.json" || is.Path != "projects[0]" || !strings.Contains(is.Msg, "title") || is.Severity != SeverityError {
+		t.Errorf("load issue = %s, want an error about projects[0] missing its title", is)
+	}
+}
+
+func TestLintEmail(t *testing.T) {
+	for _, tt := range []struct {
+		email string
+		ok    bool
+	}{
+		{"hi@example.com", true},
+		{"first.last+tag@sub.example.org", true},
+		{"hi@localhost", false},
+		{"Hi <hi@example.com>", false},
+		{"hi example.com", false},
+	} {
+		var l linter
+		l.email("email", tt.email)
+		if got := len(l.issues) == 0; got != tt.ok {
+			t.Errorf("email %q accepted = %v, want %v", tt.email, got, tt.ok)
+		}
+	}
+}
@@ -2,77 +2,279 @@ package content
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
-	"os"
-	"path/filepath"
+	"io/fs"
+	"net/url"
+	"path"
+	"strings"
+	"time"
 )
 
+// lastUpdatedLayout is the date format accepted for meta.json lastUpdated.
+const lastUpdatedLayout = "2006-01-02"
+
+// contentDir is the directory of a data directory holding the content
+// files.
+const contentDir = "content"
+
+// contentFiles lists the JSON files LoadAll reads from the content directory.
+var contentFiles = []string{"meta.json", "about.json", "work.json", "cv.json", "links.json"}
+
 // LoadAll reads and validates all JSON data files from the given data directory.
 // The dataDir should point to the root data/ directory containing a content/ subdirectory.
 func LoadAll(dataDir string) (*Content, error) {
-	contentDir := filepath.Join(dataDir, "content")
+	return Disk(dataDir).Load()
+}
 
-	info, err := os.Stat(contentDir)
+// LoadPartial is LoadAll for a portfolio that should stay up when some of
+// its files are broken. Only a missing content directory or a bad
+// meta.json, which every screen shows, is an error. Any other file that
+// fails to load is left empty and listed in Unavailable with the reason,
+// and a bad translation file falls back to the base content's copy.
+func LoadPartial(dataDir string) (*Content, error) {
+	return Disk(dataDir).LoadPartial()
+}
+
+func load(src Source, partial bool) (*Content, error) {
+	fsys := src.FS
+	info, err := fs.Stat(fsys, contentDir)
 	if err != nil {
 		return nil, fmt.Errorf("content directory: %w", err)
 	}
 	if !info.IsDir() {
-		return nil, fmt.Errorf("content path is not a directory: %s", contentDir)
+		return nil, fmt.Errorf("content path is not a directory: %s", src.path(contentDir))
 	}
 
 	var c Content
+	// skip records a file that failed in partial mode and reports whether
+	// loading can go on without it.
+	skip := func(file string, err error) bool {
+		if !partial {
+			return false
+		}
+		if c.Unavailable == nil {
+			c.Unavailable = make(map[string]error)
+		}
+		c.Unavailable[file] = err
+		return true
+	}
 
-	// Load meta.json
-	if err := loadJSON(filepath.Join(contentDir, "meta.json"), &c.Meta); err != nil {
-		return nil, fmt.Errorf("loading meta.json: %w", err)
+	// Load meta.json, which every section's header needs.
+	if err := loadFile(fsys, contentDir, "meta.json", &c.Meta, validateMeta); err != nil {
+		return nil, err
 	}
-	if err := validateMeta(&c.Meta); err != nil {
-		return nil, fmt.Errorf("meta.json: %w", err)
+
+	// Load about.json, work.json, cv.json, and links.json.
+	for _, err := range []error{
+		loadFile(fsys, contentDir, "about.json", &c.About, validateAbout),
+		loadFile(fsys, contentDir, "work.json", &c.Work, validateWork),
+		loadFile(fsys, contentDir, "cv.json", &c.CV, validateCV),
+		loadFile(fsys, contentDir, "links.json", &c.Links, validateLinks),
+	} {
+		var fe *fileError
+		if err != nil && (!errors.As(err, &fe) || !skip(fe.file, err)) {
+			return nil, err
+		}
+	}
+
+	// Load experiments.json (optional)
+	exps, err := loadExperiments(fsys)
+	if err != nil {
+		err = fmt.Errorf("loading %s: %w", experimentsFile, err)
+	} else if err = validateExperiments(exps); err != nil {
+		err, exps = fmt.Errorf("%s: %w", experimentsFile, err), nil
+	}
+	if err != nil && !skip(experimentsFile, err) {
+		return nil, err
+	}
+	c.Experiments = exps
+
+	// Load keys.json (optional)
+	keys, err := loadKeys(fsys)
+	if err != nil {
+		if err = fmt.Errorf("%s: %w", keysFile, err); !skip(keysFile, err) {
+			return nil, err
+		}
 	}
+	c.Keys = keys
 
-	// Load about.json
-	if err := loadJSON(filepath.Join(contentDir, "about.json"), &c.About); err != nil {
-		return nil, fmt.Errorf("loading about.json: %w", err)
+	// Load uses.json (optional)
+	uses, err := loadUses(fsys)
+	if err != nil {
+		if err = fmt.Errorf("%s: %w", usesFile, err); !skip(usesFile, err) {
+			return nil, err
+		}
 	}
-	if err := validateAbout(&c.About); err != nil {
-		return nil, fmt.Errorf("about.json: %w", err)
+	c.Uses = uses
+
+	// Load talks.json (optional)
+	talks, err := loadTalks(fsys)
+	if err != nil {
+		if err = fmt.Errorf("%s: %w", talksFile, err); !skip(talksFile, err) {
+			return nil, err
+		}
 	}
+	c.Talks = talks
 
-	// Load work.json
-	if err := loadJSON(filepath.Join(contentDir, "work.json"), &c.Work); err != nil {
-		return nil, fmt.Errorf("loading work.json: %w", err)
+	// Load assets/boot-messages.json (optional)
+	bootFile := path.Join(assetsDir, bootMessagesFile)
+	boot, err := loadBootMessages(fsys)
+	if err != nil {
+		err = fmt.Errorf("loading %s: %w", bootMessagesFile, err)
+	} else if boot != nil {
+		if err = validateBootMessages(boot); err != nil {
+			err, boot = fmt.Errorf("%s: %w", bootMessagesFile, err), nil
+		}
 	}
-	if err := validateWork(&c.Work); err != nil {
-		return nil, fmt.Errorf("work.json: %w", err)
+	if err != nil && !skip(bootFile, err) {
+		return nil, err
 	}
+	c.BootMessages = boot
 
-	// Load cv.json
-	if err := loadJSON(filepath.Join(contentDir, "cv.json"), &c.CV); err != nil {
-		return nil, fmt.Errorf("loading cv.json: %w", err)
+	// Load theme.json (optional)
+	theme, err := loadTheme(fsys)
+	if err != nil {
+		err = fmt.Errorf("loading %s: %w", themeFile, err)
+	} else if theme != nil {
+		if err = validateTheme(theme); err != nil {
+			err, theme = fmt.Errorf("%s: %w", themeFile, err), nil
+		}
 	}
-	if err := validateCV(&c.CV); err != nil {
-		return nil, fmt.Errorf("cv.json: %w", err)
+	if err != nil && !skip(themeFile, err) {
+		return nil, err
 	}
+	c.Theme = theme
 
-	// Load links.json
-	if err := loadJSON(filepath.Join(contentDir, "links.json"), &c.Links); err != nil {
-		return nil, fmt.Errorf("loading links.json: %w", err)
+	// Load notes/*.md (optional)
+	notes, err := loadNotes(fsys)
+	if err != nil {
+		if err = fmt.Errorf("loading %s: %w", notesDir, err); !skip(notesDir, err) {
+			return nil, err
+		}
 	}
-	if err := validateLinks(&c.Links); err != nil {
-		return nil, fmt.Errorf("links.json: %w", err)
+	c.Notes = notes
+
+	c.UpdatedAt = updatedAt(fsys, &c.Meta)
+	c.Dir = src.Dir
+
+	// Load <tag>/ translations (optional)
+	locales, err := loadLocales(fsys, &c, skip)
+	if err != nil {
+		return nil, err
 	}
+	c.Locales = locales
 
 	return &c, nil
 }
 
-// loadJSON reads a JSON file from disk and unmarshals it into v.
-func loadJSON(path string, v any) error {
-	data, err := os.ReadFile(path)
+// fileError is a content file that failed to load or validate.
+type fileError struct {
+	file string
+	err  error
+}
+
+func (e *fileError) Error() string { return e.err.Error() }
+func (e *fileError) Unwrap() error { return e.err }
+
+// loadFile reads name from dir into dst and validates it. dst is left
+// alone when either fails, and the error names the file.
+func loadFile[T any](fsys fs.FS, dir, name string, dst *T, validate func(*T) error) error {
+	var v T
+	if err := loadJSON(fsys, path.Join(dir, name), &v); err != nil {
+		return &fileError{name, fmt.Errorf("loading %s: %w", name, err)}
+	}
+	if err := validate(&v); err != nil {
+		return &fileError{name, fmt.Errorf("%s: %w", name, err)}
+	}
+	*dst = v
+	return nil
+}
+
+// loadLocales loads each subdirectory of contentDir as a translation of
+// base named by its language tag, such as content/de/. A translation
+// replaces the files it has, each read and validated like the base's,
+// and keeps the rest of base. Experiments are dropped, since their
+// variants are written in the base language. A file that fails to load
+// is passed to skip as <tag>/<file>; the translation keeps base's copy
+// if skip reports that loading can go on.
+func loadLocales(fsys fs.FS, base *Content, skip func(file string, err error) bool) (map[string]*Content, error) {
+	entries, err := fs.ReadDir(fsys, contentDir)
 	if err != nil {
-		return fmt.Errorf("reading %s: %w", filepath.Base(path), err)
+		return nil, fmt.Errorf("content directory: %w", err)
+	}
+	var locales map[string]*Content
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		tag, dir := strings.ToLower(e.Name()), path.Join(contentDir, e.Name())
+		l := *base
+		l.Experiments = nil
+		for _, err := range []error{
+			loadTranslated(fsys, dir, "meta.json", &l.Meta, validateMeta),
+			loadTranslated(fsys, dir, "about.json", &l.About, validateAbout),
+			loadTranslated(fsys, dir, "work.json", &l.Work, validateWork),
+			loadTranslated(fsys, dir, "cv.json", &l.CV, validateCV),
+			loadTranslated(fsys, dir, "links.json", &l.Links, validateLinks),
+		} {
+			var fe *fileError
+			if err != nil && (!errors.As(err, &fe) || !skip(tag+"/"+fe.file, err)) {
+				return nil, fmt.Errorf("%s translation: %w", tag, err)
+			}
+		}
+		if locales == nil {
+			locales = make(map[string]*Content)
+		}
+		locales[tag] = &l
+	}
+	return locales, nil
+}
+
+// loadTranslated reads name from dir into dst when the file exists,
+// leaving dst alone otherwise.
+func loadTranslated[T any](fsys fs.FS, dir, name string, dst *T, validate func(*T) error) error {
+	err := loadFile(fsys, dir, name, dst, validate)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// updatedAt returns the content freshness timestamp. An explicit
+// meta.json lastUpdated date wins; otherwise the newest file modification
+// time in the content directory is used.
+func updatedAt(fsys fs.FS, m *Meta) time.Time {
+	if m.LastUpdated != "" {
+		// Already validated by validateMeta.
+		t, _ := time.Parse(lastUpdatedLayout, m.LastUpdated)
+		return t
+	}
+	var latest time.Time
+	for _, name := range contentFiles {
+		info, err := fs.Stat(fsys, path.Join(contentDir, name))
+		if err != nil {
+			continue
+		}
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	return latest
+}
+
+// loadJSON reads the JSON file at name in fsys, checks it against the
+// schema for its base name, and unmarshals it into v.
+func loadJSON(fsys fs.FS, name string, v any) error {
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", path.Base(name), err)
+	}
+	if err := validateSchema(path.Base(name), data); err != nil {
+		return err
 	}
 	if err := json.Unmarshal(data, v); err != nil {
-		return fmt.Errorf("parsing %s: %w", filepath.Base(path), err)
+		return fmt.Errorf("parsing %s: %w", path.Base(name), err)
 	}
 	return nil
 }
@@ -95,6 +297,11 @@ func validateMeta(m *Meta) error {
 	if err := requireField("version", m.Version); err != nil {
 		return err
 	}
+	if m.LastUpdated != "" {
+		if _, err := time.Parse(lastUpdatedLayout, m.LastUpdated); err != nil {
+			return fmt.Errorf("lastUpdated must be a YYYY-MM-DD date, got %q", m.LastUpdated)
+		}
+	}
 	return nil
 }
 
@@ -105,6 +312,16 @@ func validateAbout(a *About) error {
 	if err := requireField("email", a.Email); err != nil {
 		return err
 	}
+	if av := a.Availability; av != nil && av.Start != "" {
+		if _, err := time.Parse(availabilityStartLayout, av.Start); err != nil {
+			return fmt.Errorf("availability.start must be a YYYY-MM-DD date, got %q", av.Start)
+		}
+	}
+	if a.Booking != "" {
+		if u, err := url.Parse(a.Booking); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("booking must be an http or https URL, got %q", a.Booking)
+		}
+	}
 	return nil
 }
 
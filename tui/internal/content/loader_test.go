@@ -3,7 +3,9 @@ package content
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 )
 
 // dataDir returns the path to the shared data/ directory relative to this test file.
@@ -168,7 +170,7 @@ func TestLoadAllWorkValidation(t *testing.T) {
 	// Valid meta.json
 	writeFile(t, contentDir, "meta.json", `{"version":"1.0.0","name":"Test","title":"Dev"}`)
 	// Valid about.json
-	writeFile(t, contentDir, "about.json", `{"bio":"A bio","email":"test@example.com","status":"Available"}`)
+	writeFile(t, contentDir, "about.json", `{"bio":"A bio","email":"test@example.com","availability":{"openToWork":true}}`)
 	// work.json with empty projects
 	writeFile(t, contentDir, "work.json", `{"projects":[]}`)
 
@@ -186,7 +188,7 @@ func TestLoadAllLinksValidation(t *testing.T) {
 	}
 
 	writeFile(t, contentDir, "meta.json", `{"version":"1.0.0","name":"Test","title":"Dev"}`)
-	writeFile(t, contentDir, "about.json", `{"bio":"A bio","email":"test@example.com","status":"Available"}`)
+	writeFile(t, contentDir, "about.json", `{"bio":"A bio","email":"test@example.com","availability":{"openToWork":true}}`)
 	writeFile(t, contentDir, "work.json", `{"projects":[{"title":"P","description":"D","tags":[],"url":"","repo":"","featured":false}]}`)
 	writeFile(t, contentDir, "cv.json", `{"contact":{"email":"a@b.c","location":"X","website":"https://x"},"summary":"S","experience":[{"company":"C","role":"R","start":"2020","end":"2024","bullets":["b"]}],"skills":[{"category":"C","items":["i"]}],"education":[]}`)
 	// links.json with missing label
@@ -228,3 +230,183 @@ func writeFile(t *testing.T, dir, name, content string) {
 		t.Fatalf("writing %s: %v", name, err)
 	}
 }
+
+// writeValidContent writes a minimal valid set of content files into
+// contentDir, using metaJSON for meta.json.
+func writeValidContent(t *testing.T, contentDir, metaJSON string) {
+	t.Helper()
+	writeFile(t, contentDir, "meta.json", metaJSON)
+	writeFile(t, contentDir, "about.json", `{"bio":"A bio","email":"test@example.com","availability":{"openToWork":true}}`)
+	writeFile(t, contentDir, "work.json", `{"projects":[{"title":"P","description":"D","tags":[],"url":"","repo":"","featured":false}]}`)
+	writeFile(t, contentDir, "cv.json", `{"contact":{"email":"a@b.c","location":"X"},"summary":"S","experience":[{"company":"C","role":"R","start":"2020","end":"2024","bullets":["b"]}],"skills":[{"category":"C","items":["i"]}],"education":[]}`)
+	writeFile(t, contentDir, "links.json", `{"links":[{"label":"L","url":"https://example.com","icon":"x"}]}`)
+}
+
+func TestLoadAllUpdatedAtFromModTime(t *testing.T) {
+	tmpDir := t.TempDir()
+	contentDir := filepath.Join(tmpDir, "content")
+	if err := os.Mkdir(contentDir, 0o755); err != nil {
+		t.Fatalf("creating content dir: %v", err)
+	}
+	writeValidContent(t, contentDir, `{"version":"1.0.0","name":"Test","title":"Dev"}`)
+
+	old := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	newest := time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+	for _, name := range contentFiles {
+		if err := os.Chtimes(filepath.Join(contentDir, name), old, old); err != nil {
+			t.Fatalf("chtimes %s: %v", name, err)
+		}
+	}
+	if err := os.Chtimes(filepath.Join(contentDir, "cv.json"), newest, newest); err != nil {
+		t.Fatalf("chtimes cv.json: %v", err)
+	}
+
+	c, err := LoadAll(tmpDir)
+	if err != nil {
+		t.Fatalf("LoadAll failed: %v", err)
+	}
+	if !c.UpdatedAt.Equal(newest) {
+		t.Errorf("UpdatedAt = %v, want %v (newest file mtime)", c.UpdatedAt, newest)
+	}
+}
+
+func TestLoadAllLastUpdatedOverridesModTime(t *testing.T) {
+	tmpDir := t.TempDir()
+	contentDir := filepath.Join(tmpDir, "content")
+	if err := os.Mkdir(contentDir, 0o755); err != nil {
+		t.Fatalf("creating content dir: %v", err)
+	}
+	writeValidContent(t, contentDir, `{"version":"1.0.0","name":"Test","title":"Dev","lastUpdated":"2023-06-01"}`)
+
+	c, err := LoadAll(tmpDir)
+	if err != nil {
+		t.Fatalf("LoadAll failed: %v", err)
+	}
+	want := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	if !c.UpdatedAt.Equal(want) {
+		t.Errorf("UpdatedAt = %v, want %v", c.UpdatedAt, want)
+	}
+}
+
+func TestLoadAllLastUpdatedInvalid(t *testing.T) {
+	tmpDir := t.TempDir()
+	contentDir := filepath.Join(tmpDir, "content")
+	if err := os.Mkdir(contentDir, 0o755); err != nil {
+		t.Fatalf("creating content dir: %v", err)
+	}
+	writeValidContent(t, contentDir, `{"version":"1.0.0","name":"Test","title":"Dev","lastUpdated":"last tuesday"}`)
+
+	_, err := LoadAll(tmpDir)
+	if err == nil {
+		t.Fatal("expected validation error for malformed lastUpdated")
+	}
+}
+
+func TestLoadAllAvailabilityStartInvalid(t *testing.T) {
+	tmpDir := t.TempDir()
+	contentDir := filepath.Join(tmpDir, "content")
+	if err := os.Mkdir(contentDir, 0o755); err != nil {
+		t.Fatalf("creating content dir: %v", err)
+	}
+	writeValidContent(t, contentDir, `{"version":"1.0.0","name":"Test","title":"Dev"}`)
+	writeFile(t, contentDir, "about.json", `{"bio":"A bio","email":"test@example.com","availability":{"openToWork":true,"start":"next month"}}`)
+
+	_, err := LoadAll(tmpDir)
+	if err == nil || !strings.Contains(err.Error(), "availability.start") {
+		t.Fatalf("err = %v, want an availability.start validation error", err)
+	}
+}
+
+func TestLoadAllBookingInvalid(t *testing.T) {
+	tmpDir := t.TempDir()
+	contentDir := filepath.Join(tmpDir, "content")
+	if err := os.Mkdir(contentDir, 0o755); err != nil {
+		t.Fatalf("creating content dir: %v", err)
+	}
+	writeValidContent(t, contentDir, `{"version":"1.0.0","name":"Test","title":"Dev"}`)
+	writeFile(t, contentDir, "about.json", `{"bio":"A bio","email":"test@example.com","booking":"cal.com/someone"}`)
+
+	_, err := LoadAll(tmpDir)
+	if err == nil || !strings.Contains(err.Error(), "booking") {
+		t.Fatalf("err = %v, want a booking validation error", err)
+	}
+}
+
+func TestLoadAllLocales(t *testing.T) {
+	tmpDir := t.TempDir()
+	contentDir := filepath.Join(tmpDir, "content")
+	deDir := filepath.Join(contentDir, "de")
+	if err := os.MkdirAll(deDir, 0o755); err != nil {
+		t.Fatalf("creating content dirs: %v", err)
+	}
+	writeValidContent(t, contentDir, `{"version":"1.0.0","name":"Test","title":"Dev"}`)
+	writeFile(t, contentDir, experimentsFile, `{"experiments":[{"id":"bio","field":"about.bio","variants":[{"name":"a","value":"Bio A"},{"name":"b","value":"Bio B"}]}]}`)
+	writeFile(t, deDir, "about.json", `{"bio":"Eine Bio","email":"test@example.com"}`)
+
+	c, err := LoadAll(tmpDir)
+	if err != nil {
+		t.Fatalf("LoadAll failed: %v", err)
+	}
+	de := c.Localized("de")
+	if de == c || de.About.Bio != "Eine Bio" {
+		t.Fatalf("de bio = %q, want the translation", de.About.Bio)
+	}
+	if de.Meta.Title != "Dev" || len(de.Work.Projects) != 1 {
+		t.Error("files the translation lacks should come from the base content")
+	}
+	if len(de.Experiments) != 0 || len(c.Experiments) != 1 {
+		t.Error("experiments should run on the base content only")
+	}
+	if c.About.Bio != "A bio" {
+		t.Errorf("base bio = %q, the translation should not change it", c.About.Bio)
+	}
+	if c.Localized("fr") != c {
+		t.Error("a missing translation should fall back to the base content")
+	}
+
+	writeFile(t, deDir, "links.json", `{"links":[{"label":"","url":"https://example.com"}]}`)
+	if _, err := LoadAll(tmpDir); err == nil || !strings.Contains(err.Error(), "de translation") {
+		t.Errorf("an invalid translation should fail to load, got %v", err)
+	}
+}
+
+func TestLoadPartial(t *testing.T) {
+	tmpDir := t.TempDir()
+	contentDir := filepath.Join(tmpDir, "content")
+	deDir := filepath.Join(contentDir, "de")
+	if err := os.MkdirAll(deDir, 0o755); err != nil {
+		t.Fatalf("creating content dirs: %v", err)
+	}
+	writeValidContent(t, contentDir, `{"version":"1.0.0","name":"Test","title":"Dev"}`)
+	writeFile(t, contentDir, "links.json", `{"links":[{"label":"L","url":"https://example.com",}]}`)
+	writeFile(t, contentDir, experimentsFile, `{"experiments":[{"id":"x","field":"about.bio","variants":[{"name":"a","value":"A"}]}]}`)
+	writeFile(t, deDir, "cv.json", `{"summary":""}`)
+
+	if _, err := LoadAll(tmpDir); err == nil {
+		t.Fatal("LoadAll should still fail on a broken file")
+	}
+	c, err := LoadPartial(tmpDir)
+	if err != nil {
+		t.Fatalf("LoadPartial failed: %v", err)
+	}
+	if c.Available("links.json") || len(c.Links.Links) != 0 {
+		t.Errorf("links.json should be unavailable and empty, got %d links", len(c.Links.Links))
+	}
+	if err := c.Unavailable["links.json"]; err == nil || !strings.Contains(err.Error(), "links.json:1:") {
+		t.Errorf("links.json error = %v, want its position", err)
+	}
+	if c.Available(experimentsFile) || c.Experiments != nil {
+		t.Error("an invalid experiments file should be unavailable and dropped")
+	}
+	if !c.Available("cv.json") || len(c.Work.Projects) != 1 || c.About.Bio != "A bio" {
+		t.Error("the valid files should load as usual")
+	}
+	if c.Available("de/cv.json") || c.Localized("de").CV.Summary != "S" {
+		t.Error("a broken translation file should fall back to the base content")
+	}
+
+	writeFile(t, contentDir, "meta.json", `{"version":"1.0.0","name":"","title":"Dev"}`)
+	if _, err := LoadPartial(tmpDir); err == nil {
+		t.Error("LoadPartial should fail on a broken meta.json")
+	}
+}
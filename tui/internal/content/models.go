@@ -1,90 +1,121 @@
 package content
 
+import (
+	"maps"
+	"slices"
+	"time"
+)
+
 // Meta holds site metadata from meta.json.
 type Meta struct {
-	Version    string `json:"version"`
-	Name       string `json:"name"`
-	Title      string `json:"title"`
-	OneLiner   string `json:"oneLiner"`
-	SiteURL    string `json:"siteUrl"`
-	SSHAddress string `json:"sshAddress"`
-	SourceRepo string `json:"sourceRepo"`
+	Version    string `json:"version" jsonschema:"required"` // Version of the content, such as 1.0.0.
+	Name       string `json:"name" jsonschema:"required"`    // The owner's full name.
+	Title      string `json:"title" jsonschema:"required"`   // The owner's job title.
+	OneLiner   string `json:"oneLiner"`                      // One sentence introducing the owner.
+	SiteURL    string `json:"siteUrl"`                       // URL of the web portfolio.
+	SSHAddress string `json:"sshAddress"`                    // How to reach this portfolio, such as ssh example.com.
+	SourceRepo string `json:"sourceRepo"`                    // URL of the portfolio's source code.
+	// LastUpdated is an optional YYYY-MM-DD date that overrides the file
+	// modification times when reporting content freshness.
+	LastUpdated string `json:"lastUpdated,omitempty" jsonschema:"format=date"`
 }
 
 // Education represents an education entry shared by About and CV.
 type Education struct {
-	Institution string `json:"institution"`
-	Degree      string `json:"degree"`
-	Year        string `json:"year"`
+	Institution string `json:"institution"` // Name of the school or university.
+	Degree      string `json:"degree"`      // Degree or certification earned.
+	Year        string `json:"year"`        // Graduation year.
 }
 
 // About holds bio and personal info from about.json.
 type About struct {
-	Bio       string      `json:"bio"`
-	Status    string      `json:"status"`
-	Email     string      `json:"email"`
-	CLI       string      `json:"cli"`
+	Bio string `json:"bio" jsonschema:"required"` // Short biography shown on Home.
+	// Availability is the owner's hiring status, or nil when not given.
+	Availability *Availability `json:"availability,omitempty"`
+	// Booking is an optional scheduling link, such as a Cal.com or
+	// Calendly page, offered on Home and by the :book command.
+	Booking   string      `json:"booking,omitempty"`
+	Email     string      `json:"email" jsonschema:"required"` // Contact email address.
+	CLI       string      `json:"cli"`                         // Command that opens this portfolio, such as ssh example.com.
 	Education []Education `json:"education"`
-	Interests []string    `json:"interests,omitempty"`
+	Interests []string    `json:"interests,omitempty"` // Topics the owner is interested in.
 }
 
 // WorkProject represents a single project entry.
 type WorkProject struct {
-	Title       string   `json:"title"`
-	Description string   `json:"description"`
-	Tags        []string `json:"tags"`
-	URL         string   `json:"url"`
-	Repo        string   `json:"repo"`
-	Featured    bool     `json:"featured"`
+	Title       string   `json:"title" jsonschema:"required"`       // Project name.
+	Description string   `json:"description" jsonschema:"required"` // What the project is and does.
+	Tags        []string `json:"tags"`                              // Technologies or topics, shown as tags.
+	URL         string   `json:"url"`                               // Live site or demo.
+	Repo        string   `json:"repo"`                              // Source repository.
+	Featured    bool     `json:"featured"`                          // Lists the project first.
 }
 
 // Work holds the projects list from work.json.
 type Work struct {
-	Projects []WorkProject `json:"projects"`
+	Projects []WorkProject `json:"projects" jsonschema:"required,minItems=1"`
+}
+
+// FeaturedFirst returns a copy of the projects with the featured ones
+// first, otherwise in file order. Every list of projects uses this order,
+// so a project's number is the same everywhere.
+func (w Work) FeaturedFirst() []WorkProject {
+	sorted := slices.Clone(w.Projects)
+	slices.SortStableFunc(sorted, func(a, b WorkProject) int {
+		switch {
+		case a.Featured == b.Featured:
+			return 0
+		case a.Featured:
+			return -1
+		default:
+			return 1
+		}
+	})
+	return sorted
 }
 
 // CVContact holds contact information.
 type CVContact struct {
-	Email    string `json:"email"`
-	Location string `json:"location"`
-	Website  string `json:"website,omitempty"`
+	Email    string `json:"email" jsonschema:"required"` // Contact email address.
+	Location string `json:"location"`                    // City or region.
+	Website  string `json:"website,omitempty"`           // Personal website URL.
 }
 
 // CVExperience represents a work experience entry.
 type CVExperience struct {
-	Company string   `json:"company"`
-	Role    string   `json:"role"`
-	Start   string   `json:"start"`
-	End     string   `json:"end"`
-	Bullets []string `json:"bullets"`
+	Company string   `json:"company" jsonschema:"required"` // Employer or organization.
+	Role    string   `json:"role" jsonschema:"required"`    // Job title.
+	Start   string   `json:"start"`                         // Start date, such as 2021 or 2021-03.
+	End     string   `json:"end"`                           // End date, or Present.
+	Bullets []string `json:"bullets"`                       // Accomplishments, one per bullet.
 }
 
 // CVSkill represents a skill category with its items.
 type CVSkill struct {
-	Category string   `json:"category"`
-	Items    []string `json:"items"`
+	Category string   `json:"category"` // Category name, such as Languages.
+	Items    []string `json:"items"`    // Skills in the category.
 }
 
 // CV holds the full CV data from cv.json.
 type CV struct {
-	Contact    CVContact      `json:"contact"`
-	Summary    string         `json:"summary"`
-	Experience []CVExperience `json:"experience"`
-	Skills     []CVSkill      `json:"skills"`
+	Contact    CVContact      `json:"contact" jsonschema:"required"`
+	Summary    string         `json:"summary" jsonschema:"required"` // Professional summary at the top of the CV.
+	Experience []CVExperience `json:"experience" jsonschema:"required,minItems=1"`
+	Skills     []CVSkill      `json:"skills" jsonschema:"required,minItems=1"`
 	Education  []Education    `json:"education"`
 }
 
 // Link represents an external link entry.
 type Link struct {
-	Label string `json:"label"`
-	URL   string `json:"url"`
-	Icon  string `json:"icon"`
-	Text  string `json:"text,omitempty"`
+	Label string `json:"label" jsonschema:"required"` // Link name, such as GitHub.
+	URL   string `json:"url" jsonschema:"required"`   // Where the link goes; mailto: links open email.
+	Icon  string `json:"icon"`                        // Icon name for the web portfolio.
+	Text  string `json:"text,omitempty"`              // Text shown instead of the URL.
 }
 
 // Links holds the links list from links.json.
 type Links struct {
-	Links []Link `json:"links"`
+	Links []Link `json:"links" jsonschema:"required,minItems=1"`
 }
 
 // Content holds all loaded site data.
@@ -94,4 +125,71 @@ type Content struct {
 	Work  Work
 	CV    CV
 	Links Links
+
+	// Experiments are optional A/B tests loaded from experiments.json.
+	Experiments []Experiment
+
+	// Keys are the owner's public keys from the optional keys.json, with
+	// their fingerprints computed.
+	Keys []PublicKey
+
+	// Uses is the owner's setup from the optional uses.json, by category.
+	Uses []UsesCategory
+
+	// Talks are the owner's talks from the optional talks.json, newest
+	// first.
+	Talks []Talk
+
+	// BootMessages is the intro's boot sequence from the optional
+	// assets/boot-messages.json; nil means the built-in one.
+	BootMessages []BootMessage
+
+	// Theme is the color overrides from the optional theme.json at the
+	// top of the data directory; nil keeps the built-in themes.
+	Theme *Theme
+
+	// Notes are the markdown notes from the optional data/notes/
+	// directory, newest first.
+	Notes []Note
+
+	// UpdatedAt is when the content was last changed: Meta.LastUpdated when
+	// set, otherwise the newest modification time among the content files.
+	UpdatedAt time.Time
+
+	// Dir is the data directory the content was loaded from, where
+	// optional assets such as the portrait photo are found. It is empty
+	// for content built in memory.
+	Dir string
+
+	// Locales are translations of the content by language tag, loaded
+	// from the optional content/<tag>/ directories. Each has the files its
+	// directory provides and this content for the rest.
+	Locales map[string]*Content
+
+	// Unavailable maps each file LoadPartial could not load, by its name
+	// in the content directory (notes for the notes directory, and
+	// assets/boot-messages.json and theme.json for the files beside it),
+	// to why.
+	// Those files are left empty. It is nil for content loaded by LoadAll.
+	Unavailable map[string]error
+}
+
+// Available reports whether the named content file, such as cv.json,
+// loaded.
+func (c *Content) Available(file string) bool {
+	return c.Unavailable[file] == nil
+}
+
+// UnavailableFiles returns the names in Unavailable, sorted.
+func (c *Content) UnavailableFiles() []string {
+	return slices.Sorted(maps.Keys(c.Unavailable))
+}
+
+// Localized returns the translation of c tagged tag, or c itself when
+// there is none.
+func (c *Content) Localized(tag string) *Content {
+	if l, ok := c.Locales[tag]; ok {
+		return l
+	}
+	return c
 }
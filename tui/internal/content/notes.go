@@ -0,0 +1,128 @@
+package content
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"slices"
+	"strings"
+	"time"
+)
+
+// notesDir is the optional directory of markdown notes inside the data
+// directory.
+const notesDir = "notes"
+
+// noteDateLayout is the date format accepted for a note's date.
+const noteDateLayout = "2006-01-02"
+
+// Note is one markdown file from data/notes/. Its title, date, and
+// summary come from an optional front matter block:
+//
+//	---
+//	title: Shipping a TUI over SSH
+//	date: 2026-02-01
+//	summary: What it took to serve Bubble Tea to strangers.
+//	---
+//
+// Without a title, the note's first "# " heading is used.
+type Note struct {
+	// Slug is the file name without the .md extension.
+	Slug    string
+	Title   string
+	Date    time.Time // zero when the note is undated
+	Summary string
+	// Body is the markdown after the front matter.
+	Body string
+}
+
+// loadNotes reads every .md file in the notes directory, newest first;
+// undated notes follow the dated ones, by title. A missing directory has
+// no notes.
+func loadNotes(fsys fs.FS) ([]Note, error) {
+	entries, err := fs.ReadDir(fsys, notesDir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var notes []Note
+	for _, e := range entries {
+		if e.IsDir() || path.Ext(e.Name()) != ".md" {
+			continue
+		}
+		data, err := fs.ReadFile(fsys, path.Join(notesDir, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		n, err := parseNote(strings.TrimSuffix(e.Name(), ".md"), string(data))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", e.Name(), err)
+		}
+		notes = append(notes, n)
+	}
+	slices.SortStableFunc(notes, func(a, b Note) int {
+		if c := b.Date.Compare(a.Date); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Title, b.Title)
+	})
+	return notes, nil
+}
+
+// parseNote splits a note file into its front matter and body and checks
+// that it has a title and a valid date.
+func parseNote(slug, data string) (Note, error) {
+	n := Note{Slug: slug}
+	body := strings.ReplaceAll(data, "\r\n", "\n")
+	if rest, ok := strings.CutPrefix(body, "---\n"); ok {
+		front, after, ok := strings.Cut(rest, "\n---")
+		if !ok {
+			return n, errors.New("front matter is not closed with ---")
+		}
+		body = strings.TrimPrefix(after, "\n")
+		for _, line := range strings.Split(front, "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			key, value, ok := strings.Cut(line, ":")
+			if !ok {
+				return n, fmt.Errorf("front matter line %q must look like key: value", line)
+			}
+			value = strings.Trim(strings.TrimSpace(value), `"`)
+			switch strings.TrimSpace(key) {
+			case "title":
+				n.Title = value
+			case "summary":
+				n.Summary = value
+			case "date":
+				t, err := time.Parse(noteDateLayout, value)
+				if err != nil {
+					return n, fmt.Errorf("date must be YYYY-MM-DD, got %q", value)
+				}
+				n.Date = t
+			default:
+				return n, fmt.Errorf("unknown front matter key %q", strings.TrimSpace(key))
+			}
+		}
+	}
+	n.Body = strings.TrimSpace(body)
+	if n.Title == "" {
+		for _, line := range strings.Split(n.Body, "\n") {
+			if title, ok := strings.CutPrefix(line, "# "); ok {
+				n.Title = strings.TrimSpace(title)
+				break
+			}
+		}
+	}
+	if err := requireField("title", n.Title); err != nil {
+		return n, err
+	}
+	if n.Body == "" {
+		return n, errors.New("note is empty")
+	}
+	return n, nil
+}
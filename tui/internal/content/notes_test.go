@@ -0,0 +1,73 @@
+package content
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestParseNote(t *testing.T) {
+	n, err := parseNote("ssh-tui", "---\ntitle: Shipping a TUI\ndate: 2026-02-01\nsummary: \"Over SSH.\"\n---\n\nBody text.\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Note{Slug: "ssh-tui", Title: "Shipping a TUI", Date: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), Summary: "Over SSH.", Body: "Body text."}
+	if n != want {
+		t.Errorf("parseNote = %+v, want %+v", n, want)
+	}
+
+	n, err = parseNote("plain", "Intro.\n\n# From the heading\n\nMore.")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n.Title != "From the heading" || !n.Date.IsZero() {
+		t.Errorf("parseNote without front matter = %+v", n)
+	}
+
+	for name, bad := range map[string]string{
+		"no title":       "Just text.",
+		"bad date":       "---\ntitle: T\ndate: Feb 1\n---\nBody",
+		"unclosed front": "---\ntitle: T\nBody",
+		"unknown key":    "---\ntitle: T\ntags: a\n---\nBody",
+		"empty body":     "---\ntitle: T\n---\n",
+		"not key: value": "---\ntitle\n---\nBody",
+	} {
+		if _, err := parseNote("x", bad); err == nil {
+			t.Errorf("%s: parseNote should fail", name)
+		}
+	}
+}
+
+func TestLoadAllNotes(t *testing.T) {
+	tmpDir := t.TempDir()
+	contentDir := filepath.Join(tmpDir, "content")
+	notesDir := filepath.Join(tmpDir, "notes")
+	for _, dir := range []string{contentDir, notesDir} {
+		if err := os.Mkdir(dir, 0o755); err != nil {
+			t.Fatalf("creating %s: %v", dir, err)
+		}
+	}
+	writeValidContent(t, contentDir, `{"version":"1.0.0","name":"Test","title":"Dev"}`)
+	writeFile(t, notesDir, "old.md", "---\ndate: 2025-01-01\n---\n# Old\n\nBody")
+	writeFile(t, notesDir, "new.md", "---\ndate: 2026-01-01\n---\n# New\n\nBody")
+	writeFile(t, notesDir, "undated.md", "# Undated\n\nBody")
+	writeFile(t, notesDir, "README.txt", "not a note")
+
+	c, err := LoadAll(tmpDir)
+	if err != nil {
+		t.Fatalf("LoadAll failed: %v", err)
+	}
+	var titles []string
+	for _, n := range c.Notes {
+		titles = append(titles, n.Title)
+	}
+	if len(titles) != 3 || titles[0] != "New" || titles[1] != "Old" || titles[2] != "Undated" {
+		t.Errorf("note titles = %q, want newest first and undated last", titles)
+	}
+
+	writeFile(t, notesDir, "broken.md", "no title here")
+	if _, err := LoadAll(tmpDir); err == nil {
+		t.Error("expected error for a note without a title")
+	}
+}
@@ -0,0 +1,191 @@
+package content
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// WordsPerMinute is the reading speed used for read time estimates.
+const WordsPerMinute = 200
+
+// SectionReport summarizes one navigable section's content.
+type SectionReport struct {
+	Name  string
+	Items int // entries in the section (projects, experience, links)
+	Words int
+}
+
+// ReadTime returns the estimated time to read the section's text.
+func (s SectionReport) ReadTime() time.Duration {
+	return ReadTime(s.Words)
+}
+
+// Report is a content coverage summary: per-section size and the optional
+// fields that are left empty.
+type Report struct {
+	Sections []SectionReport
+	Missing  []string
+}
+
+// TotalWords returns the word count across all sections.
+func (r Report) TotalWords() int {
+	n := 0
+	for _, s := range r.Sections {
+		n += s.Words
+	}
+	return n
+}
+
+// CountWords returns the number of whitespace-separated words across texts.
+func CountWords(texts ...string) int {
+	n := 0
+	for _, t := range texts {
+		n += len(strings.Fields(t))
+	}
+	return n
+}
+
+// ReadTime estimates how long words take to read at WordsPerMinute.
+func ReadTime(words int) time.Duration {
+	return time.Duration(words) * time.Minute / WordsPerMinute
+}
+
+// FormatReadTime renders a read time estimate as "N min", rounding up, or
+// "<1 min" for anything shorter than a minute.
+func FormatReadTime(d time.Duration) string {
+	if d < time.Minute {
+		return "<1 min"
+	}
+	mins := int((d + time.Minute - 1) / time.Minute)
+	return fmt.Sprintf("%d min", mins)
+}
+
+// HomeWords returns the word count of the text shown on the home section.
+func HomeWords(c *Content) int {
+	n := CountWords(c.Meta.OneLiner, c.About.Bio)
+	if a := c.About.Availability; a != nil {
+		n += CountWords(a.Summary(time.Now()))
+	}
+	return n
+}
+
+// WorkWords returns the word count of the text shown on the work section.
+func WorkWords(c *Content) int {
+	n := 0
+	for _, p := range c.Work.Projects {
+		n += CountWords(p.Title, p.Description) + len(p.Tags)
+	}
+	return n
+}
+
+// CVWords returns the word count of the text shown on the CV section.
+func CVWords(c *Content) int {
+	n := CountWords(c.CV.Summary)
+	for _, e := range c.CV.Experience {
+		n += CountWords(e.Role, e.Company) + CountWords(e.Bullets...)
+	}
+	for _, s := range c.CV.Skills {
+		n += CountWords(s.Category) + CountWords(s.Items...)
+	}
+	for _, e := range c.CV.Education {
+		n += CountWords(e.Degree, e.Institution)
+	}
+	return n
+}
+
+// LinksWords returns the word count of the text shown on the links section.
+func LinksWords(c *Content) int {
+	n := 0
+	for _, l := range c.Links.Links {
+		n += CountWords(l.Label, l.Text)
+	}
+	return n
+}
+
+// BuildReport computes the coverage summary for c.
+func BuildReport(c *Content) Report {
+	return Report{
+		Sections: []SectionReport{
+			{Name: "home", Items: 1, Words: HomeWords(c)},
+			{Name: "work", Items: len(c.Work.Projects), Words: WorkWords(c)},
+			{Name: "cv", Items: len(c.CV.Experience), Words: CVWords(c)},
+			{Name: "links", Items: len(c.Links.Links), Words: LinksWords(c)},
+		},
+		Missing: missingOptional(c),
+	}
+}
+
+// missingOptional lists optional fields that are empty, as JSON-style paths.
+// Required fields are enforced by LoadAll and not reported here.
+func missingOptional(c *Content) []string {
+	var missing []string
+	check := func(path, v string) {
+		if strings.TrimSpace(v) == "" {
+			missing = append(missing, path)
+		}
+	}
+
+	check("meta.oneLiner", c.Meta.OneLiner)
+	check("meta.siteUrl", c.Meta.SiteURL)
+	check("meta.sshAddress", c.Meta.SSHAddress)
+	check("meta.sourceRepo", c.Meta.SourceRepo)
+	check("meta.lastUpdated", c.Meta.LastUpdated)
+
+	if c.About.Availability == nil {
+		missing = append(missing, "about.availability")
+	}
+	check("about.booking", c.About.Booking)
+	check("about.cli", c.About.CLI)
+	if len(c.About.Interests) == 0 {
+		missing = append(missing, "about.interests")
+	}
+
+	for i, p := range c.Work.Projects {
+		if len(p.Tags) == 0 {
+			missing = append(missing, fmt.Sprintf("work.projects[%d].tags", i))
+		}
+		if p.URL == "" && p.Repo == "" {
+			missing = append(missing, fmt.Sprintf("work.projects[%d].url/repo", i))
+		}
+	}
+
+	check("cv.contact.location", c.CV.Contact.Location)
+	check("cv.contact.website", c.CV.Contact.Website)
+	if len(c.CV.Education) == 0 {
+		missing = append(missing, "cv.education")
+	}
+
+	return missing
+}
+
+// Write prints the report as an aligned table followed by the missing
+// optional fields.
+func (r Report) Write(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "SECTION\tITEMS\tWORDS\tREAD TIME")
+	for _, s := range r.Sections {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%s\n", s.Name, s.Items, s.Words, FormatReadTime(s.ReadTime()))
+	}
+	total := r.TotalWords()
+	fmt.Fprintf(tw, "total\t\t%d\t%s\n", total, FormatReadTime(ReadTime(total)))
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if len(r.Missing) == 0 {
+		_, err := fmt.Fprintln(w, "\nAll optional fields are populated.")
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "\nMissing optional fields (%d):\n", len(r.Missing)); err != nil {
+		return err
+	}
+	for _, m := range r.Missing {
+		if _, err := fmt.Fprintf(w, "  %s\n", m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
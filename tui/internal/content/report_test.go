@@ -0,0 +1,91 @@
+package content
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCountWords(t *testing.T) {
+	if got := CountWords("two words", "", "  three  more words "); got != 5 {
+		t.Errorf("CountWords = %d, want 5", got)
+	}
+}
+
+func TestFormatReadTime(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{0, "<1 min"},
+		{59 * time.Second, "<1 min"},
+		{time.Minute, "1 min"},
+		{61 * time.Second, "2 min"},
+		{ReadTime(1000), "5 min"},
+	}
+	for _, tt := range tests {
+		if got := FormatReadTime(tt.d); got != tt.want {
+			t.Errorf("FormatReadTime(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
+
+func TestBuildReport(t *testing.T) {
+	c := &Content{
+		Meta:  Meta{Name: "N", OneLiner: "builds things"},
+		About: About{Bio: "a short bio here", Availability: &Availability{}},
+		Work: Work{Projects: []WorkProject{
+			{Title: "Alpha", Description: "first project", Tags: []string{"go"}, URL: "https://a"},
+			{Title: "Beta", Description: "second"},
+		}},
+		CV: CV{
+			Summary:    "summary text",
+			Experience: []CVExperience{{Role: "Dev", Company: "Co", Bullets: []string{"did a thing"}}},
+		},
+		Links: Links{Links: []Link{{Label: "GitHub", URL: "https://github.com"}}},
+	}
+
+	r := BuildReport(c)
+	words := map[string]int{}
+	for _, s := range r.Sections {
+		words[s.Name] = s.Words
+	}
+	want := map[string]int{"home": 8, "work": 6, "cv": 7, "links": 1}
+	for name, n := range want {
+		if words[name] != n {
+			t.Errorf("%s words = %d, want %d", name, words[name], n)
+		}
+	}
+	if r.TotalWords() != 22 {
+		t.Errorf("TotalWords = %d, want 22", r.TotalWords())
+	}
+
+	missing := strings.Join(r.Missing, ",")
+	for _, path := range []string{"work.projects[1].tags", "work.projects[1].url/repo", "cv.education", "about.interests"} {
+		if !strings.Contains(missing, path) {
+			t.Errorf("Missing should include %s, got %v", path, r.Missing)
+		}
+	}
+	if strings.Contains(missing, "work.projects[0]") {
+		t.Errorf("fully populated project should not be reported, got %v", r.Missing)
+	}
+}
+
+func TestReportWrite(t *testing.T) {
+	c, err := LoadAll(dataDir(t))
+	if err != nil {
+		t.Fatalf("LoadAll failed: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := BuildReport(c).Write(&buf); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{"SECTION", "home", "work", "cv", "links", "total"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("report output missing %q:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,420 @@
+package content
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+//go:generate go run ../../cmd/schemagen . schemas ../../../data/schemas
+
+// SchemaVersion is the JSON Schema draft the content schemas follow.
+const SchemaVersion = "http://json-schema.org/draft-07/schema#"
+
+// SchemaRoots maps each content file with a schema to the type it is
+// decoded into. The schemas in schemas/ are generated from these types
+// and their doc comments by cmd/schemagen.
+var SchemaRoots = map[string]any{
+	"meta.json":      Meta{},
+	"about.json":     About{},
+	"work.json":      Work{},
+	"cv.json":        CV{},
+	"links.json":     Links{},
+	experimentsFile:  Experiments{},
+	keysFile:         Keys{},
+	usesFile:         Uses{},
+	talksFile:        Talks{},
+	bootMessagesFile: BootMessages{},
+	themeFile:        Theme{},
+}
+
+//go:embed schemas/*.schema.json
+var schemaFS embed.FS
+
+// Schema is the part of JSON Schema the content schemas use. Struct
+// fields marked `jsonschema:"required"` are required and, for strings,
+// must not be empty; minItems=N, enum=a|b, and format=date or
+// format=color, a #rgb or #rrggbb hex color, add the matching keywords.
+type Schema struct {
+	Schema      string   `json:"$schema,omitempty"`
+	Title       string   `json:"title,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Type        string   `json:"type,omitempty"`
+	Format      string   `json:"format,omitempty"`
+	Enum        []string `json:"enum,omitempty"`
+	MinLength   *int     `json:"minLength,omitempty"`
+	MinItems    *int     `json:"minItems,omitempty"`
+	Required    []string `json:"required,omitempty"`
+	// AdditionalProperties is nil for objects that allow no other
+	// properties, or the schema every other property must match.
+	AdditionalProperties *Schema            `json:"-"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+}
+
+// schemaJSON has Schema's fields plus additionalProperties, which is
+// false or a schema.
+type schemaJSON struct {
+	*schemaAlias
+	AdditionalProperties any `json:"additionalProperties,omitempty"`
+}
+
+type schemaAlias Schema
+
+// MarshalJSON writes additionalProperties as false for objects that
+// allow no other properties.
+func (s *Schema) MarshalJSON() ([]byte, error) {
+	out := schemaJSON{schemaAlias: (*schemaAlias)(s)}
+	switch {
+	case s.AdditionalProperties != nil:
+		out.AdditionalProperties = s.AdditionalProperties
+	case s.Type == "object":
+		out.AdditionalProperties = false
+	}
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON reads additionalProperties as false or a schema.
+func (s *Schema) UnmarshalJSON(data []byte) error {
+	var in struct {
+		*schemaAlias
+		AdditionalProperties json.RawMessage `json:"additionalProperties"`
+	}
+	in.schemaAlias = (*schemaAlias)(s)
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+	if len(in.AdditionalProperties) > 0 && string(in.AdditionalProperties) != "false" {
+		s.AdditionalProperties = new(Schema)
+		return json.Unmarshal(in.AdditionalProperties, s.AdditionalProperties)
+	}
+	return nil
+}
+
+// SchemaFileName returns the name of the schema for a content file, such
+// as cv.schema.json for cv.json.
+func SchemaFileName(file string) string {
+	return strings.TrimSuffix(file, ".json") + ".schema.json"
+}
+
+// SchemaFiles returns the content files that have a schema, sorted.
+func SchemaFiles() []string {
+	var files []string
+	for f := range SchemaRoots {
+		files = append(files, f)
+	}
+	slices.Sort(files)
+	return files
+}
+
+// SchemaJSON returns the embedded schema for a content file, such as
+// cv.json, or false if it has none.
+func SchemaJSON(file string) ([]byte, bool) {
+	data, err := schemaFS.ReadFile("schemas/" + SchemaFileName(file))
+	return data, err == nil
+}
+
+// schemas parses the embedded schemas once, by content file name.
+var schemas = sync.OnceValue(func() map[string]*Schema {
+	m := make(map[string]*Schema)
+	for _, f := range SchemaFiles() {
+		data, ok := SchemaJSON(f)
+		if !ok {
+			continue
+		}
+		var s Schema
+		if err := json.Unmarshal(data, &s); err != nil {
+			panic(fmt.Sprintf("embedded schema for %s: %v", f, err))
+		}
+		m[f] = &s
+	}
+	return m
+})
+
+// SchemaError is a content file that does not match its schema, at the
+// line and column of the offending value.
+type SchemaError struct {
+	File         string
+	Line, Column int
+	// Path names the value as the validators do, such as
+	// experience[0].company; it is empty for the whole file.
+	Path string
+	Msg  string
+}
+
+func (e *SchemaError) Error() string {
+	return fmt.Sprintf("%s:%d:%d: %s", e.File, e.Line, e.Column, e.Msg)
+}
+
+// validateSchema checks the JSON in data, read from a file named file,
+// against that file's schema. Files without a schema only have to parse.
+// It reports the first problem in the file.
+func validateSchema(file string, data []byte) error {
+	root, err := parseNode(data)
+	if err != nil {
+		var pe *parseError
+		if !errors.As(err, &pe) {
+			return fmt.Errorf("%s: %w", file, err)
+		}
+		line, col := position(data, pe.offset)
+		return &SchemaError{File: file, Line: line, Column: col, Msg: pe.msg}
+	}
+	s, ok := schemas()[file]
+	if !ok {
+		return nil
+	}
+	v := schemaValidator{file: file, data: data}
+	v.check(s, root, "")
+	if v.err != nil {
+		return v.err
+	}
+	return nil
+}
+
+// schemaValidator walks a parsed file alongside its schema and keeps the
+// first mismatch.
+type schemaValidator struct {
+	file string
+	data []byte
+	err  *SchemaError
+}
+
+func (v *schemaValidator) fail(n *node, path, format string, args ...any) {
+	if v.err != nil {
+		return
+	}
+	line, col := position(v.data, n.offset)
+	v.err = &SchemaError{File: v.file, Line: line, Column: col, Path: path, Msg: fmt.Sprintf(format, args...)}
+}
+
+// subject returns how messages refer to the value at path.
+func subject(path string) string {
+	if path == "" {
+		return "file"
+	}
+	return path
+}
+
+func (v *schemaValidator) check(s *Schema, n *node, path string) {
+	if got := n.kind(); s.Type != "" && got != s.Type && !(s.Type == "number" && got == "integer") {
+		v.fail(n, path, "%s must be %s, not %s", subject(path), article(s.Type), article(got))
+		return
+	}
+	switch s.Type {
+	case "object":
+		v.checkObject(s, n, path)
+	case "array":
+		if s.MinItems != nil && len(n.items) < *s.MinItems {
+			if *s.MinItems == 1 {
+				v.fail(n, path, "%s list must not be empty", subject(path))
+			} else {
+				v.fail(n, path, "%s must have at least %d items", subject(path), *s.MinItems)
+			}
+		}
+		if s.Items != nil {
+			for i, item := range n.items {
+				v.check(s.Items, item, fmt.Sprintf("%s[%d]", path, i))
+			}
+		}
+	case "string":
+		str := n.value.(string)
+		switch {
+		case s.MinLength != nil && len(str) < *s.MinLength:
+			v.fail(n, path, "%s must not be empty", subject(path))
+		case len(s.Enum) > 0 && !slices.Contains(s.Enum, str):
+			v.fail(n, path, "%s must be %s, got %q", subject(path), strings.Join(s.Enum, " or "), str)
+		case s.Format == "date" && str != "":
+			if _, err := time.Parse(time.DateOnly, str); err != nil {
+				v.fail(n, path, "%s must be a YYYY-MM-DD date, got %q", subject(path), str)
+			}
+		case s.Format == "color" && str != "" && !hexColor(str):
+			v.fail(n, path, "%s must be a hex color such as #e8536d, got %q", subject(path), str)
+		}
+	}
+}
+
+func (v *schemaValidator) checkObject(s *Schema, n *node, path string) {
+	prefix := path
+	if prefix != "" {
+		prefix += "."
+	}
+	for _, req := range s.Required {
+		if n.member(req) == nil {
+			v.fail(n, path, "%s is required", prefix+req)
+		}
+	}
+	for _, m := range n.members {
+		ps, ok := s.Properties[m.key]
+		switch {
+		case ok:
+			v.check(ps, m.value, prefix+m.key)
+		case m.key == "$schema" && path == "":
+			// Editors read it to find the schema; it is not content.
+		case s.AdditionalProperties != nil:
+			v.check(s.AdditionalProperties, m.value, prefix+m.key)
+		default:
+			v.fail(&node{offset: m.offset}, path, "unknown field %q in %s", m.key, subject(path))
+		}
+	}
+}
+
+// article names a JSON type for messages, as in "a string".
+func article(typ string) string {
+	switch typ {
+	case "object", "array", "integer":
+		return "an " + typ
+	case "null":
+		return "null"
+	default:
+		return "a " + typ
+	}
+}
+
+// node is a parsed JSON value that remembers where it starts.
+type node struct {
+	offset  int64
+	value   any // string, json.Number, bool, or nil for scalars
+	object  bool
+	members []member
+	array   bool
+	items   []*node
+}
+
+type member struct {
+	key    string
+	offset int64
+	value  *node
+}
+
+// kind returns the JSON Schema type of n.
+func (n *node) kind() string {
+	switch v := n.value.(type) {
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case json.Number:
+		if _, err := v.Int64(); err == nil {
+			return "integer"
+		}
+		return "number"
+	}
+	switch {
+	case n.object:
+		return "object"
+	case n.array:
+		return "array"
+	default:
+		return "null"
+	}
+}
+
+// member returns the value of key in an object node, or nil.
+func (n *node) member(key string) *node {
+	for _, m := range n.members {
+		if m.key == key {
+			return m.value
+		}
+	}
+	return nil
+}
+
+// parseError is malformed JSON at a byte offset.
+type parseError struct {
+	offset int64
+	msg    string
+}
+
+func (e *parseError) Error() string { return e.msg }
+
+// parseNode parses one JSON value from data into a node tree.
+func parseNode(data []byte) (*node, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	p := nodeParser{dec: dec, data: data}
+	n, err := p.value()
+	var se *json.SyntaxError
+	switch {
+	case errors.As(err, &se):
+		// The offset is just past the byte that could not be parsed.
+		return nil, &parseError{offset: max(0, se.Offset-1), msg: se.Error()}
+	case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		return nil, &parseError{offset: int64(len(data)), msg: "unexpected end of JSON input"}
+	case err != nil:
+		return nil, err
+	}
+	if off := p.start(); off < int64(len(data)) {
+		return nil, &parseError{offset: off, msg: "unexpected data after the top-level value"}
+	}
+	return n, nil
+}
+
+type nodeParser struct {
+	dec  *json.Decoder
+	data []byte
+}
+
+// start returns the offset of the next token: the decoder's position
+// past any whitespace and separators.
+func (p *nodeParser) start() int64 {
+	off := p.dec.InputOffset()
+	for off < int64(len(p.data)) && strings.IndexByte(" \t\r\n:,", p.data[off]) >= 0 {
+		off++
+	}
+	return off
+}
+
+func (p *nodeParser) value() (*node, error) {
+	off := p.start()
+	tok, err := p.dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	n := &node{offset: off}
+	switch tok {
+	case json.Delim('{'):
+		n.object = true
+		for p.dec.More() {
+			keyOff := p.start()
+			key, err := p.dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			val, err := p.value()
+			if err != nil {
+				return nil, err
+			}
+			n.members = append(n.members, member{key: key.(string), offset: keyOff, value: val})
+		}
+		_, err = p.dec.Token()
+	case json.Delim('['):
+		n.array = true
+		for p.dec.More() {
+			item, err := p.value()
+			if err != nil {
+				return nil, err
+			}
+			n.items = append(n.items, item)
+		}
+		_, err = p.dec.Token()
+	default:
+		n.value = tok
+	}
+	return n, err
+}
+
+// position returns the 1-based line and column of offset in data.
+func position(data []byte, offset int64) (line, col int) {
+	offset = min(offset, int64(len(data)))
+	before := data[:offset]
+	line = bytes.Count(before, []byte("\n")) + 1
+	col = int(offset) - bytes.LastIndexByte(before, '\n')
+	return line, col
+}
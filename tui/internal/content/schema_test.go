@@ -0,0 +1,72 @@
+package content
+
+import (
+	"errors"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestValidateSchema(t *testing.T) {
+	tests := []struct {
+		name, file, data string
+		want             string // error text; empty for none
+	}{
+		{"valid", "links.json", `{"links":[{"label":"GitHub","url":"https://github.com"}]}`, ""},
+		{"editor schema", "links.json", `{"$schema":"../schemas/links.schema.json","links":[{"label":"a","url":"b"}]}`, ""},
+		{"no schema", "portrait.json", `{"anything":1}`, ""},
+		{"wrong type", "links.json", "{\n  \"links\": [\n    {\"label\": 3, \"url\": \"b\"}\n  ]\n}",
+			"links.json:3:15: links[0].label must be a string, not an integer"},
+		{"missing", "cv.json", `{"contact":{},"summary":"s","experience":[{"company":"c","role":"r"}],"skills":[{}]}`,
+			"cv.json:1:12: contact.email is required"},
+		{"empty", "meta.json", `{"version":"1","name":"","title":"t"}`, "meta.json:1:23: name must not be empty"},
+		{"empty list", "work.json", `{"projects":[]}`, "work.json:1:13: projects list must not be empty"},
+		{"unknown field", "links.json", "{\"links\":[{\"label\":\"a\",\"url\":\"b\",\n\"lable\":\"c\"}]}",
+			`links.json:2:1: unknown field "lable" in links[0]`},
+		{"enum", "keys.json", `{"keys":[{"label":"a","type":"gpg"}]}`, `keys[0].type must be pgp or ssh, got "gpg"`},
+		{"date", "meta.json", `{"version":"1","name":"n","title":"t","lastUpdated":"May"}`, `lastUpdated must be a YYYY-MM-DD date, got "May"`},
+		{"syntax", "meta.json", "{\n  \"name\": \"n\",,\n}", "meta.json:2:15: invalid character ','"},
+		{"truncated", "meta.json", `{"name":`, "meta.json:1:9: unexpected end of JSON input"},
+		{"trailing", "meta.json", `{} {}`, "meta.json:1:4: unexpected data"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSchema(tt.file, []byte(tt.data))
+			if tt.want == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateSchemaError(t *testing.T) {
+	err := validateSchema("cv.json", []byte(`{"contact":{"email":"e"},"summary":"s","experience":[{"company":"c","role":""}],"skills":[{}]}`))
+	var se *SchemaError
+	if !errors.As(err, &se) || se.Path != "experience[0].role" || se.Line != 1 {
+		t.Errorf("error = %#v, want a SchemaError at experience[0].role", err)
+	}
+}
+
+func TestSchemasEmbedded(t *testing.T) {
+	for _, file := range SchemaFiles() {
+		if _, ok := SchemaJSON(file); !ok {
+			t.Errorf("no embedded schema for %s", file)
+		}
+	}
+	if _, ok := SchemaJSON("notes.json"); ok {
+		t.Error("notes.json should have no schema")
+	}
+
+	// The experiment field enum must list what experiments can target.
+	field := schemas()[experimentsFile].Properties["experiments"].Items.Properties["field"]
+	if want := slices.Sorted(maps.Keys(experimentFields)); !slices.Equal(slices.Sorted(slices.Values(field.Enum)), want) {
+		t.Errorf("experiment field enum = %v, want %v", field.Enum, want)
+	}
+}
@@ -0,0 +1,237 @@
+// Package schemagen generates the content JSON Schemas from the Go types
+// content files decode into. It reads the content package's source, so
+// each field's doc comment becomes its description and editors show it
+// while the owner types.
+package schemagen
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/buntingszn/terminal-portfolio/tui/internal/content"
+)
+
+// Generate returns the schema of each file in content.SchemaRoots, by
+// content file name, from the package source in dir.
+func Generate(dir string) (map[string]*content.Schema, error) {
+	types, err := parseTypes(dir)
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string]*content.Schema)
+	for file, root := range content.SchemaRoots {
+		name := reflect.TypeOf(root).Name()
+		t, ok := types[name]
+		if !ok {
+			return nil, fmt.Errorf("%s: type %s not found in %s", file, name, dir)
+		}
+		g := generator{types: types}
+		s, err := g.object(t)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		s.Schema = content.SchemaVersion
+		s.Title = file
+		s.Properties["$schema"] = &content.Schema{
+			Type:        "string",
+			Description: "The schema this file follows, for editors.",
+		}
+		out[file] = s
+	}
+	return out, nil
+}
+
+// Marshal formats a schema the way the files in schemas/ are written.
+func Marshal(s *content.Schema) ([]byte, error) {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// structType is a struct declared in the content package.
+type structType struct {
+	name string
+	doc  string
+	st   *ast.StructType
+}
+
+// parseTypes collects the struct types declared in the non-test Go files
+// of dir.
+func parseTypes(dir string) (map[string]structType, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	fset := token.NewFileSet()
+	types := make(map[string]structType)
+	for _, path := range files {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		for _, decl := range f.Decls {
+			gd, ok := decl.(*ast.GenDecl)
+			if !ok || gd.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gd.Specs {
+				ts := spec.(*ast.TypeSpec)
+				st, ok := ts.Type.(*ast.StructType)
+				if !ok {
+					continue
+				}
+				doc := ts.Doc
+				if doc == nil {
+					doc = gd.Doc
+				}
+				types[ts.Name.Name] = structType{name: ts.Name.Name, doc: docText(doc), st: st}
+			}
+		}
+	}
+	return types, nil
+}
+
+// docText flattens a doc comment into one line.
+func docText(g *ast.CommentGroup) string {
+	if g == nil {
+		return ""
+	}
+	return strings.Join(strings.Fields(g.Text()), " ")
+}
+
+type generator struct {
+	types map[string]structType
+	// seen guards against types that contain themselves.
+	seen []string
+}
+
+// object returns the schema of a struct: its JSON fields as properties,
+// with no others allowed.
+func (g *generator) object(t structType) (*content.Schema, error) {
+	for _, name := range g.seen {
+		if name == t.name {
+			return nil, fmt.Errorf("type %s contains itself", t.name)
+		}
+	}
+	g.seen = append(g.seen, t.name)
+	defer func() { g.seen = g.seen[:len(g.seen)-1] }()
+
+	s := &content.Schema{
+		Type:        "object",
+		Description: t.doc,
+		Properties:  make(map[string]*content.Schema),
+	}
+	for _, f := range t.st.Fields.List {
+		if f.Tag == nil || len(f.Names) == 0 || !f.Names[0].IsExported() {
+			continue
+		}
+		raw, err := strconv.Unquote(f.Tag.Value)
+		if err != nil {
+			return nil, err
+		}
+		tag := reflect.StructTag(raw)
+		name, _, _ := strings.Cut(tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		fs, err := g.field(f.Type)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %w", t.name, f.Names[0].Name, err)
+		}
+		// The field's own comment wins over its type's.
+		if doc := strings.TrimSpace(docText(f.Doc) + " " + docText(f.Comment)); doc != "" {
+			fs.Description = doc
+		}
+		required, err := applyTag(fs, tag.Get("jsonschema"))
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %w", t.name, f.Names[0].Name, err)
+		}
+		if required {
+			s.Required = append(s.Required, name)
+		}
+		s.Properties[name] = fs
+	}
+	return s, nil
+}
+
+// field returns the schema of a field's type.
+func (g *generator) field(expr ast.Expr) (*content.Schema, error) {
+	switch e := expr.(type) {
+	case *ast.StarExpr:
+		return g.field(e.X)
+	case *ast.ArrayType:
+		items, err := g.field(e.Elt)
+		if err != nil {
+			return nil, err
+		}
+		return &content.Schema{Type: "array", Items: items}, nil
+	case *ast.MapType:
+		if k, ok := e.Key.(*ast.Ident); !ok || k.Name != "string" {
+			return nil, fmt.Errorf("map keys must be strings")
+		}
+		val, err := g.field(e.Value)
+		if err != nil {
+			return nil, err
+		}
+		return &content.Schema{Type: "object", AdditionalProperties: val}, nil
+	case *ast.Ident:
+		switch e.Name {
+		case "string":
+			return &content.Schema{Type: "string"}, nil
+		case "bool":
+			return &content.Schema{Type: "boolean"}, nil
+		case "int", "int64", "int32", "uint", "uint64", "uint32":
+			return &content.Schema{Type: "integer"}, nil
+		case "float64", "float32":
+			return &content.Schema{Type: "number"}, nil
+		}
+		if t, ok := g.types[e.Name]; ok {
+			return g.object(t)
+		}
+	}
+	return nil, fmt.Errorf("unsupported type %T", expr)
+}
+
+// applyTag adds the keywords of a jsonschema struct tag to s and reports
+// whether the field is required.
+func applyTag(s *content.Schema, tag string) (required bool, err error) {
+	if tag == "" {
+		return false, nil
+	}
+	for _, opt := range strings.Split(tag, ",") {
+		key, val, _ := strings.Cut(opt, "=")
+		switch key {
+		case "required":
+			required = true
+			if s.Type == "string" {
+				one := 1
+				s.MinLength = &one
+			}
+		case "minItems":
+			n, err := strconv.Atoi(val)
+			if err != nil {
+				return false, fmt.Errorf("minItems %q: %w", val, err)
+			}
+			s.MinItems = &n
+		case "enum":
+			s.Enum = strings.Split(val, "|")
+		case "format":
+			s.Format = val
+		default:
+			return false, fmt.Errorf("unknown jsonschema option %q", key)
+		}
+	}
+	return required, nil
+}
@@ -0,0 +1,45 @@
+package schemagen
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/buntingszn/terminal-portfolio/tui/internal/content"
+)
+
+// TestSchemasUpToDate fails when the content types change without the
+// schemas being regenerated with go generate ./internal/content.
+func TestSchemasUpToDate(t *testing.T) {
+	schemas, err := Generate("..")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, dir := range []string{filepath.Join("..", "schemas"), filepath.Join("..", "..", "..", "..", "data", "schemas")} {
+		for file, s := range schemas {
+			want, err := Marshal(s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			path := filepath.Join(dir, content.SchemaFileName(file))
+			got, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != string(want) {
+				t.Errorf("%s is out of date; run go generate ./internal/content", path)
+			}
+		}
+	}
+}
+
+func TestApplyTag(t *testing.T) {
+	s := &content.Schema{Type: "string"}
+	required, err := applyTag(s, "required,enum=a|b,format=date")
+	if err != nil || !required || s.MinLength == nil || *s.MinLength != 1 || len(s.Enum) != 2 || s.Format != "date" {
+		t.Errorf("applyTag = %v, %v; schema %+v", required, err, s)
+	}
+	if _, err := applyTag(&content.Schema{}, "optional"); err == nil {
+		t.Error("an unknown option should be an error")
+	}
+}
@@ -0,0 +1,172 @@
+package content
+
+import (
+	"embed"
+	"errors"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+//go:generate go run ../../cmd/defaultsgen ../../../data defaults
+
+// defaultFiles is a copy of the repository's data directory, so a binary
+// can serve the portfolio with no files beside it. cmd/defaultsgen keeps
+// it in step with data/.
+//
+//go:embed defaults
+var defaultFiles embed.FS
+
+// Source is a data directory to load content from, laid out like data/:
+// content/ holds the JSON files and translations, and notes/ and key
+// files sit beside it.
+type Source struct {
+	FS fs.FS
+	// Dir is the directory on disk FS reads, where assets such as the
+	// portrait photo are found; empty when there is none.
+	Dir string
+}
+
+// Embedded returns the content built into the binary.
+func Embedded() Source {
+	fsys, err := fs.Sub(defaultFiles, "defaults")
+	if err != nil {
+		panic(err)
+	}
+	return Source{FS: fsys}
+}
+
+// Disk returns the data directory dir.
+func Disk(dir string) Source {
+	return Source{FS: os.DirFS(dir), Dir: dir}
+}
+
+// Merged returns a source that reads each file from top when it has it
+// and from base otherwise, so top's files replace base's one by one.
+// Directories list the files of both. Dir is top's, or base's if top has
+// none.
+func Merged(top, base Source) Source {
+	dir := top.Dir
+	if dir == "" {
+		dir = base.Dir
+	}
+	return Source{FS: mergedFS{top.FS, base.FS}, Dir: dir}
+}
+
+// DataSource returns the source the server loads from a data directory:
+// its files over the embedded defaults when it has a content directory.
+// Without one it returns the defaults alone and false, so a binary runs
+// with no data directory at all.
+func DataSource(dir string) (Source, bool) {
+	if dir == "" {
+		return Embedded(), false
+	}
+	if info, err := os.Stat(filepath.Join(dir, contentDir)); err != nil || !info.IsDir() {
+		return Embedded(), false
+	}
+	return Merged(Disk(dir), Embedded()), true
+}
+
+// Load reads and validates the source's content as LoadAll does.
+func (s Source) Load() (*Content, error) {
+	return load(s, false)
+}
+
+// LoadPartial reads the source's content as LoadPartial does.
+func (s Source) LoadPartial() (*Content, error) {
+	return load(s, true)
+}
+
+// DataFiles lists the files in fsys, the data directory c was loaded
+// from, that loading reads: the JSON files of the content directory and
+// its translations, the notes, the boot messages, theme.json, the key
+// files keys.json names, and the talk thumbnails.
+func DataFiles(fsys fs.FS, c *Content) ([]string, error) {
+	var files []string
+	err := fs.WalkDir(fsys, contentDir, func(name string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() && path.Ext(name) == ".json" {
+			files = append(files, name)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	notes, err := fs.Glob(fsys, notesDir+"/*.md")
+	if err != nil {
+		return nil, err
+	}
+	files = append(files, notes...)
+	for _, name := range []string{path.Join(assetsDir, bootMessagesFile), themeFile} {
+		if _, err := fs.Stat(fsys, name); err == nil {
+			files = append(files, name)
+		}
+	}
+	for _, k := range c.Keys {
+		if k.File != "" {
+			files = append(files, filepath.ToSlash(k.File))
+		}
+	}
+	for _, t := range c.Talks {
+		if t.Thumbnail == "" {
+			continue
+		}
+		if _, err := fs.Stat(fsys, filepath.ToSlash(t.Thumbnail)); err == nil {
+			files = append(files, filepath.ToSlash(t.Thumbnail))
+		}
+	}
+	slices.Sort(files)
+	return slices.Compact(files), nil
+}
+
+// path returns how messages refer to name in the source.
+func (s Source) path(name string) string {
+	if s.Dir == "" {
+		return name
+	}
+	return filepath.Join(s.Dir, filepath.FromSlash(name))
+}
+
+// mergedFS reads each name from the first file system that has it.
+type mergedFS []fs.FS
+
+func (m mergedFS) Open(name string) (fs.File, error) {
+	for _, fsys := range m {
+		f, err := fsys.Open(name)
+		if !errors.Is(err, fs.ErrNotExist) {
+			return f, err
+		}
+	}
+	return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+}
+
+// ReadDir lists the entries of name in every file system that has it,
+// sorted by name; where two have an entry of the same name, the first
+// one's is listed.
+func (m mergedFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	var entries []fs.DirEntry
+	found := false
+	for _, fsys := range m {
+		list, err := fs.ReadDir(fsys, name)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		found = true
+		for _, e := range list {
+			if !slices.ContainsFunc(entries, func(have fs.DirEntry) bool { return have.Name() == e.Name() }) {
+				entries = append(entries, e)
+			}
+		}
+	}
+	if !found {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrNotExist}
+	}
+	slices.SortFunc(entries, func(a, b fs.DirEntry) int { return strings.Compare(a.Name(), b.Name()) })
+	return entries, nil
+}
@@ -0,0 +1,134 @@
+package content
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+)
+
+// TestDefaultsUpToDate fails when data/ changes without the embedded
+// defaults being regenerated with go generate ./internal/content.
+func TestDefaultsUpToDate(t *testing.T) {
+	dir := dataDir(t)
+	c, err := LoadAll(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := DataFiles(os.DirFS(dir), c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	embedded := Embedded().FS
+	got, err := DataFiles(embedded, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("embedded files %v, want %v; run go generate ./internal/content", got, want)
+	}
+	for _, name := range want {
+		disk, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if data, err := fs.ReadFile(embedded, name); err != nil || string(data) != string(disk) {
+			t.Errorf("embedded %s is out of date; run go generate ./internal/content", name)
+		}
+	}
+}
+
+func TestEmbedded(t *testing.T) {
+	c, err := Embedded().Load()
+	if err != nil {
+		t.Fatalf("the embedded defaults should load: %v", err)
+	}
+	if c.Meta.Name == "" || c.Dir != "" {
+		t.Errorf("embedded content = %q from %q, want a name and no directory", c.Meta.Name, c.Dir)
+	}
+}
+
+func TestMerged(t *testing.T) {
+	top := fstest.MapFS{
+		"content/about.json": {Data: []byte("top")},
+		"content/de/cv.json": {Data: []byte("top de")},
+	}
+	base := fstest.MapFS{
+		"content/about.json": {Data: []byte("base")},
+		"content/cv.json":    {Data: []byte("base")},
+		"notes/a.md":         {Data: []byte("base")},
+	}
+	m := Merged(Source{FS: top, Dir: "/srv/data"}, Source{FS: base})
+	if m.Dir != "/srv/data" {
+		t.Errorf("Dir = %q, want the top source's", m.Dir)
+	}
+	for name, want := range map[string]string{
+		"content/about.json": "top",
+		"content/cv.json":    "base",
+		"content/de/cv.json": "top de",
+		"notes/a.md":         "base",
+	} {
+		if got, err := fs.ReadFile(m.FS, name); err != nil || string(got) != want {
+			t.Errorf("%s = %q, %v; want %q", name, got, err, want)
+		}
+	}
+	if _, err := fs.ReadFile(m.FS, "content/links.json"); !os.IsNotExist(err) {
+		t.Errorf("a file neither has = %v, want not found", err)
+	}
+
+	entries, err := fs.ReadDir(m.FS, "content")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	if len(names) != 3 || names[0] != "about.json" || names[1] != "cv.json" || names[2] != "de" {
+		t.Errorf("content entries = %v, want about.json, cv.json, de", names)
+	}
+	if _, err := fs.ReadDir(m.FS, "missing"); !os.IsNotExist(err) {
+		t.Errorf("ReadDir of a missing directory = %v, want not found", err)
+	}
+}
+
+// TestDataSource verifies that a data directory's files override the
+// embedded defaults and that a missing one falls back to them.
+func TestDataSource(t *testing.T) {
+	if src, ok := DataSource(filepath.Join(t.TempDir(), "missing")); ok || src.Dir != "" {
+		t.Errorf("a missing data directory = %q, %v; want the embedded defaults", src.Dir, ok)
+	}
+
+	tmpDir := t.TempDir()
+	contentDir := filepath.Join(tmpDir, "content")
+	if err := os.Mkdir(contentDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, contentDir, "meta.json", `{"version":"2.0.0","name":"Override","title":"Dev"}`)
+
+	src, ok := DataSource(tmpDir)
+	if !ok || src.Dir != tmpDir {
+		t.Fatalf("DataSource = %q, %v; want the data directory", src.Dir, ok)
+	}
+	c, err := src.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defaults, err := Embedded().Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Meta.Name != "Override" {
+		t.Errorf("name = %q, want the data directory's", c.Meta.Name)
+	}
+	if c.CV.Summary != defaults.CV.Summary || len(c.Work.Projects) != len(defaults.Work.Projects) {
+		t.Error("files the data directory lacks should come from the defaults")
+	}
+
+	// The override is checked like any other file.
+	writeFile(t, contentDir, "meta.json", `{"version":"2.0.0","name":""}`)
+	if _, err := src.Load(); err == nil {
+		t.Error("an invalid override should fail to load")
+	}
+}
@@ -0,0 +1,124 @@
+package content
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Profile is what a starter data directory is filled in with. Name and
+// Email are required; the rest are left out of the content when empty.
+type Profile struct {
+	Name     string
+	Title    string
+	Email    string
+	Location string
+	Website  string // Personal site, such as https://example.com.
+	GitHub   string // GitHub username.
+}
+
+// Starter returns complete content for a new portfolio: the profile's
+// details where it has them, and placeholders, written to be replaced,
+// for the bio, a project, and a job.
+func Starter(p Profile) *Content {
+	title := p.Title
+	if title == "" {
+		title = "Software Engineer"
+	}
+	first, _, _ := strings.Cut(p.Name, " ")
+
+	c := &Content{
+		Meta: Meta{
+			Version:  "1.0.0",
+			Name:     p.Name,
+			Title:    title,
+			OneLiner: "One sentence about what you do.",
+			SiteURL:  p.Website,
+		},
+		About: About{
+			Bio:       first + " is a " + strings.ToLower(title) + ". Replace this with a few sentences about who you are and what you work on.",
+			Email:     p.Email,
+			Education: []Education{},
+		},
+		Work: Work{Projects: []WorkProject{{
+			Title:       "Your first project",
+			Description: "What it is, who it is for, and what you did. Add a url or repo to link it.",
+			Tags:        []string{"Go"},
+			Featured:    true,
+		}}},
+		CV: CV{
+			Contact: CVContact{Email: p.Email, Location: p.Location, Website: p.Website},
+			Summary: "A short professional summary: your focus, your experience, and what you are looking for.",
+			Experience: []CVExperience{{
+				Company: "Company",
+				Role:    title,
+				Start:   "2020",
+				End:     "Present",
+				Bullets: []string{"Something you built or improved, and the difference it made."},
+			}},
+			Skills:    []CVSkill{{Category: "Languages", Items: []string{"Go"}}},
+			Education: []Education{},
+		},
+	}
+	if p.GitHub != "" {
+		c.Links.Links = append(c.Links.Links, Link{Label: "GitHub", URL: "https://github.com/" + p.GitHub, Icon: "github"})
+	}
+	if p.Website != "" {
+		c.Links.Links = append(c.Links.Links, Link{Label: "Website", URL: p.Website, Icon: "globe"})
+	}
+	c.Links.Links = append(c.Links.Links, Link{Label: "Email", URL: "mailto:" + p.Email, Icon: "mail", Text: p.Email})
+	return c
+}
+
+// WriteStarter writes Starter's content for p to the content directory
+// of the data directory dir, creating it, and returns the files written.
+// It writes nothing if any of the files exists, unless overwrite is set.
+func WriteStarter(dir string, p Profile, overwrite bool) ([]string, error) {
+	if p.Name == "" || p.Email == "" {
+		return nil, errors.New("a starter portfolio needs a name and an email address")
+	}
+	c := Starter(p)
+	files := []struct {
+		name string
+		v    any
+	}{
+		{"meta.json", c.Meta},
+		{"about.json", c.About},
+		{"work.json", c.Work},
+		{"cv.json", c.CV},
+		{"links.json", c.Links},
+	}
+
+	root := filepath.Join(dir, contentDir)
+	if !overwrite {
+		for _, f := range files {
+			if _, err := os.Stat(filepath.Join(root, f.name)); !errors.Is(err, fs.ErrNotExist) {
+				return nil, fmt.Errorf("%s already exists", filepath.Join(root, f.name))
+			}
+		}
+	}
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, err
+	}
+	var written []string
+	for _, f := range files {
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		enc.SetEscapeHTML(false)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(f.v); err != nil {
+			return written, fmt.Errorf("encoding %s: %w", f.name, err)
+		}
+		path := filepath.Join(root, f.name)
+		if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+			return written, err
+		}
+		written = append(written, path)
+	}
+	return written, nil
+}
@@ -0,0 +1,61 @@
+package content
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWriteStarter(t *testing.T) {
+	dir := t.TempDir()
+	p := Profile{Name: "Ada Lovelace", Email: "ada@example.com", Website: "https://ada.example.com", GitHub: "ada"}
+	files, err := WriteStarter(dir, p, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 5 {
+		t.Errorf("wrote %v, want the five content files", files)
+	}
+
+	c, err := LoadAll(dir)
+	if err != nil {
+		t.Fatalf("the starter content should load: %v", err)
+	}
+	if c.Meta.Name != p.Name || c.About.Email != p.Email || c.CV.Contact.Website != p.Website {
+		t.Errorf("starter content does not carry the profile: %+v", c.Meta)
+	}
+	if len(c.Links.Links) != 3 {
+		t.Errorf("links = %v, want GitHub, the website, and email", c.Links.Links)
+	}
+	issues, err := Validate(Disk(dir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) > 0 {
+		t.Errorf("the starter content should lint clean: %v", issues)
+	}
+}
+
+func TestWriteStarterKeepsExisting(t *testing.T) {
+	dir := t.TempDir()
+	p := Profile{Name: "Ada", Email: "ada@example.com"}
+	if _, err := WriteStarter(dir, p, false); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, filepath.Join(dir, "content"), "about.json", "edited")
+	if _, err := WriteStarter(dir, p, false); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("second WriteStarter error = %v, want that the files exist", err)
+	}
+	if _, err := WriteStarter(dir, p, true); err != nil {
+		t.Errorf("overwriting: %v", err)
+	}
+	if _, err := LoadAll(dir); err != nil {
+		t.Errorf("overwritten content should load: %v", err)
+	}
+}
+
+func TestWriteStarterNeedsNameAndEmail(t *testing.T) {
+	if _, err := WriteStarter(t.TempDir(), Profile{Name: "Ada"}, false); err == nil {
+		t.Error("WriteStarter without an email should fail")
+	}
+}
@@ -0,0 +1,77 @@
+package content
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// talksFile is the optional content file listing the owner's talks and
+// press appearances.
+const talksFile = "talks.json"
+
+// Talk is one talk, interview, or podcast appearance.
+type Talk struct {
+	Title  string `json:"title" jsonschema:"required"`            // Name of the talk.
+	Event  string `json:"event,omitempty"`                        // Where it was given, such as GopherCon 2025.
+	Date   string `json:"date" jsonschema:"required,format=date"` // When it was given, as YYYY-MM-DD.
+	Video  string `json:"video,omitempty"`                        // URL of the recording.
+	Slides string `json:"slides,omitempty"`                       // URL of the slides.
+	// Thumbnail is an optional PNG or JPEG, such as a frame of the video,
+	// shown as braille art beside the talk. It is a path relative to the
+	// data directory.
+	Thumbnail string `json:"thumbnail,omitempty"`
+}
+
+// Link returns the URL copied for the talk: the video, or the slides
+// when there is no recording.
+func (t Talk) Link() string {
+	if t.Video != "" {
+		return t.Video
+	}
+	return t.Slides
+}
+
+// Talks holds the talk list from talks.json.
+type Talks struct {
+	Talks []Talk `json:"talks" jsonschema:"required,minItems=1"`
+}
+
+// loadTalks reads talks.json if present, newest first; talks on the same
+// day keep their order in the file.
+func loadTalks(fsys fs.FS) ([]Talk, error) {
+	name := path.Join(contentDir, talksFile)
+	if _, err := fs.Stat(fsys, name); errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	var t Talks
+	if err := loadJSON(fsys, name, &t); err != nil {
+		return nil, err
+	}
+	if err := validateTalks(&t); err != nil {
+		return nil, err
+	}
+	// YYYY-MM-DD dates sort as strings.
+	slices.SortStableFunc(t.Talks, func(a, b Talk) int { return strings.Compare(b.Date, a.Date) })
+	return t.Talks, nil
+}
+
+func validateTalks(t *Talks) error {
+	for i, talk := range t.Talks {
+		if err := requireField("title", talk.Title); err != nil {
+			return fmt.Errorf("talks[%d]: %w", i, err)
+		}
+		if _, err := time.Parse(time.DateOnly, talk.Date); err != nil {
+			return fmt.Errorf("talk %q: date must be a YYYY-MM-DD date, got %q", talk.Title, talk.Date)
+		}
+		if talk.Thumbnail != "" && (filepath.IsAbs(talk.Thumbnail) || !filepath.IsLocal(talk.Thumbnail)) {
+			return fmt.Errorf("talk %q: thumbnail must be inside the data directory, got %q", talk.Title, talk.Thumbnail)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package content
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestLoadAllTalks(t *testing.T) {
+	tmpDir := t.TempDir()
+	contentDir := filepath.Join(tmpDir, "content")
+	if err := os.Mkdir(contentDir, 0o755); err != nil {
+		t.Fatalf("creating content dir: %v", err)
+	}
+	writeValidContent(t, contentDir, `{"version":"1.0.0","name":"Test","title":"Dev"}`)
+	writeFile(t, tmpDir, "talk.png", "not really a PNG")
+	writeFile(t, contentDir, "talks.json", `{"talks":[
+		{"title":"Old","date":"2023-04-01","slides":"https://example.com/old.pdf"},
+		{"title":"New","event":"GopherCon","date":"2025-08-27","video":"https://example.com/new","slides":"https://example.com/new.pdf","thumbnail":"talk.png"},
+		{"title":"Same day","date":"2025-08-27"}
+	]}`)
+
+	c, err := LoadAll(tmpDir)
+	if err != nil {
+		t.Fatalf("LoadAll failed: %v", err)
+	}
+	var titles []string
+	for _, talk := range c.Talks {
+		titles = append(titles, talk.Title)
+	}
+	if len(titles) != 3 || titles[0] != "New" || titles[1] != "Same day" || titles[2] != "Old" {
+		t.Errorf("talks = %v, want newest first in file order", titles)
+	}
+	if got := c.Talks[0].Link(); got != "https://example.com/new" {
+		t.Errorf("Link = %q, want the video", got)
+	}
+	if got := c.Talks[2].Link(); got != "https://example.com/old.pdf" {
+		t.Errorf("Link without a video = %q, want the slides", got)
+	}
+	files, err := DataFiles(os.DirFS(tmpDir), c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Contains(files, "talk.png") {
+		t.Errorf("DataFiles = %v, want the thumbnail", files)
+	}
+
+	for _, bad := range []string{
+		`{"talks":[{"title":"Talk","date":"August 2025"}]}`,
+		`{"talks":[{"title":"Talk","date":"2025-08-27","thumbnail":"../talk.png"}]}`,
+		`{"talks":[{"title":"Talk"}]}`,
+	} {
+		writeFile(t, contentDir, "talks.json", bad)
+		if _, err := LoadAll(tmpDir); err == nil {
+			t.Errorf("LoadAll(%s) should fail", bad)
+		}
+	}
+}
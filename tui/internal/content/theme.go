@@ -0,0 +1,82 @@
+package content
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+)
+
+// themeFile is the optional file, at the top of the data directory,
+// overriding the colors of the built-in themes.
+const themeFile = "theme.json"
+
+// ThemeColors overrides some of one theme's colors. Each is a hex color
+// such as #e8536d or #e53; an empty one keeps the built-in color.
+type ThemeColors struct {
+	Bg          string `json:"bg,omitempty" jsonschema:"format=color"`          // Background.
+	Fg          string `json:"fg,omitempty" jsonschema:"format=color"`          // Body text.
+	Accent      string `json:"accent,omitempty" jsonschema:"format=color"`      // Titles, the active section, and errors.
+	Muted       string `json:"muted,omitempty" jsonschema:"format=color"`       // Secondary text and hints.
+	Border      string `json:"border,omitempty" jsonschema:"format=color"`      // Borders and rules.
+	Success     string `json:"success,omitempty" jsonschema:"format=color"`     // Good states, such as a passing check.
+	Warning     string `json:"warning,omitempty" jsonschema:"format=color"`     // Cautionary states.
+	StatusBarBg string `json:"statusBarBg,omitempty" jsonschema:"format=color"` // Status bar background; the border color when unset.
+	StatusBarFg string `json:"statusBarFg,omitempty" jsonschema:"format=color"` // Status bar text; the muted color when unset.
+}
+
+// Theme holds the color overrides from theme.json for the dark and light
+// themes.
+type Theme struct {
+	Dark  ThemeColors `json:"dark"`  // Colors of the dark theme.
+	Light ThemeColors `json:"light"` // Colors of the light theme, for pale terminal backgrounds.
+}
+
+// loadTheme reads theme.json if present.
+func loadTheme(fsys fs.FS) (*Theme, error) {
+	if _, err := fs.Stat(fsys, themeFile); errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	var t Theme
+	if err := loadJSON(fsys, themeFile, &t); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// validateTheme checks that every color set is a hex color.
+func validateTheme(t *Theme) error {
+	for _, variant := range []struct {
+		name   string
+		colors ThemeColors
+	}{{"dark", t.Dark}, {"light", t.Light}} {
+		for _, f := range variant.colors.fields() {
+			if f.value != "" && !hexColor(f.value) {
+				return fmt.Errorf("%s.%s must be a hex color such as #e8536d, got %q", variant.name, f.name, f.value)
+			}
+		}
+	}
+	return nil
+}
+
+// fields returns the colors by their names in theme.json.
+func (c ThemeColors) fields() []struct{ name, value string } {
+	return []struct{ name, value string }{
+		{"bg", c.Bg}, {"fg", c.Fg}, {"accent", c.Accent}, {"muted", c.Muted}, {"border", c.Border},
+		{"success", c.Success}, {"warning", c.Warning}, {"statusBarBg", c.StatusBarBg}, {"statusBarFg", c.StatusBarFg},
+	}
+}
+
+// hexColor reports whether s is a #rgb or #rrggbb color.
+func hexColor(s string) bool {
+	digits, ok := strings.CutPrefix(s, "#")
+	if !ok || (len(digits) != 3 && len(digits) != 6) {
+		return false
+	}
+	for _, r := range digits {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", r) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,84 @@
+package content
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// themeDataDir returns a data directory with valid content and theme.json
+// holding theme.
+func themeDataDir(t *testing.T, theme string) string {
+	t.Helper()
+	tmpDir := t.TempDir()
+	contentDir := filepath.Join(tmpDir, "content")
+	if err := os.Mkdir(contentDir, 0o755); err != nil {
+		t.Fatalf("creating content dir: %v", err)
+	}
+	writeValidContent(t, contentDir, `{"version":"1.0.0","name":"Test","title":"Dev"}`)
+	writeFile(t, tmpDir, "theme.json", theme)
+	return tmpDir
+}
+
+func TestLoadAllTheme(t *testing.T) {
+	c, err := LoadAll(themeDataDir(t, `{"dark":{"accent":"#00ff00","statusBarBg":"#123"},"light":{"fg":"#1A2B3C"}}`))
+	if err != nil {
+		t.Fatalf("LoadAll failed: %v", err)
+	}
+	want := Theme{Dark: ThemeColors{Accent: "#00ff00", StatusBarBg: "#123"}, Light: ThemeColors{Fg: "#1A2B3C"}}
+	if c.Theme == nil || *c.Theme != want {
+		t.Errorf("Theme = %+v, want %+v", c.Theme, want)
+	}
+
+	c, err = LoadAll(dataDir(t))
+	if err != nil {
+		t.Fatalf("LoadAll failed: %v", err)
+	}
+	if c.Theme != nil {
+		t.Errorf("Theme = %+v, want nil when theme.json is absent", c.Theme)
+	}
+}
+
+func TestLoadAllThemeInvalid(t *testing.T) {
+	tests := []struct {
+		name, theme, wantErr string
+	}{
+		{"not hex", `{"dark":{"accent":"red"}}`, `dark.accent must be a hex color such as #e8536d, got "red"`},
+		{"bad digit", `{"light":{"bg":"#ggg"}}`, `light.bg must be a hex color`},
+		{"wrong length", `{"dark":{"border":"#12345"}}`, `dark.border must be a hex color`},
+		{"unknown color", `{"dark":{"highlight":"#fff"}}`, `unknown field "highlight"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadAll(themeDataDir(t, tt.theme))
+			if err == nil || !strings.Contains(err.Error(), "theme.json") || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want it to name theme.json and contain %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	c, err := LoadPartial(themeDataDir(t, `{"dark":{"accent":"red"}}`))
+	if err != nil {
+		t.Fatalf("LoadPartial failed: %v", err)
+	}
+	if c.Theme != nil || c.Unavailable["theme.json"] == nil {
+		t.Errorf("LoadPartial Theme = %+v, Unavailable = %v; want the file listed and the built-in colors kept", c.Theme, c.Unavailable)
+	}
+}
+
+func TestThemeColorsFields(t *testing.T) {
+	// validateTheme checks the colors fields lists, so it must list every
+	// field, by its JSON name.
+	typ := reflect.TypeFor[ThemeColors]()
+	fields := ThemeColors{}.fields()
+	if len(fields) != typ.NumField() {
+		t.Fatalf("fields lists %d colors, ThemeColors has %d", len(fields), typ.NumField())
+	}
+	for i, f := range fields {
+		if name, _, _ := strings.Cut(typ.Field(i).Tag.Get("json"), ","); f.name != name {
+			t.Errorf("fields()[%d] = %s, want %s", i, f.name, name)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package content
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+)
+
+// usesFile is the optional content file listing the owner's setup for the
+// uses section.
+const usesFile = "uses.json"
+
+// UsesItem is one tool in the owner's setup.
+type UsesItem struct {
+	Name        string `json:"name" jsonschema:"required"` // Tool name, such as Neovim or ThinkPad X1.
+	Description string `json:"description,omitempty"`      // What it is used for.
+	URL         string `json:"url,omitempty"`              // Where to find it; the name links here.
+}
+
+// UsesCategory groups the tools of one kind, such as Editor or Hardware.
+type UsesCategory struct {
+	Category string     `json:"category" jsonschema:"required"` // Category name, such as Terminal.
+	Items    []UsesItem `json:"items" jsonschema:"required,minItems=1"`
+}
+
+// Uses holds the setup from uses.json.
+type Uses struct {
+	Categories []UsesCategory `json:"categories" jsonschema:"required,minItems=1"`
+}
+
+// loadUses reads uses.json if present.
+func loadUses(fsys fs.FS) ([]UsesCategory, error) {
+	name := path.Join(contentDir, usesFile)
+	if _, err := fs.Stat(fsys, name); errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	var u Uses
+	if err := loadJSON(fsys, name, &u); err != nil {
+		return nil, err
+	}
+	if err := validateUses(&u); err != nil {
+		return nil, err
+	}
+	return u.Categories, nil
+}
+
+func validateUses(u *Uses) error {
+	for i, cat := range u.Categories {
+		if err := requireField("category", cat.Category); err != nil {
+			return fmt.Errorf("categories[%d]: %w", i, err)
+		}
+		for j, item := range cat.Items {
+			if err := requireField("name", item.Name); err != nil {
+				return fmt.Errorf("categories[%d].items[%d]: %w", i, j, err)
+			}
+		}
+	}
+	return nil
+}
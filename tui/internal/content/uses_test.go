@@ -0,0 +1,53 @@
+package content
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadAllUses(t *testing.T) {
+	tmpDir := t.TempDir()
+	contentDir := filepath.Join(tmpDir, "content")
+	if err := os.Mkdir(contentDir, 0o755); err != nil {
+		t.Fatalf("creating content dir: %v", err)
+	}
+	writeValidContent(t, contentDir, `{"version":"1.0.0","name":"Test","title":"Dev"}`)
+
+	c, err := LoadAll(tmpDir)
+	if err != nil {
+		t.Fatalf("LoadAll failed: %v", err)
+	}
+	if c.Uses != nil {
+		t.Errorf("Uses = %+v without uses.json, want none", c.Uses)
+	}
+
+	writeFile(t, contentDir, "uses.json", `{"categories":[
+		{"category":"Editor","items":[{"name":"Neovim","description":"with lazy.nvim","url":"https://neovim.io"}]},
+		{"category":"Hardware","items":[{"name":"ThinkPad X1"},{"name":"Keychron K2","url":"keychron.com"}]}
+	]}`)
+	c, err = LoadAll(tmpDir)
+	if err != nil {
+		t.Fatalf("LoadAll failed: %v", err)
+	}
+	if len(c.Uses) != 2 || c.Uses[0].Items[0].URL != "https://neovim.io" || len(c.Uses[1].Items) != 2 {
+		t.Fatalf("Uses = %+v, want the two categories", c.Uses)
+	}
+	issues := Lint(c)
+	if len(issues) != 1 || issues[0].File != usesFile || issues[0].Path != "categories[1].items[1].url" {
+		t.Errorf("Lint = %v, want the URL without a scheme", issues)
+	}
+
+	writeFile(t, contentDir, "uses.json", `{"categories":[{"category":"Editor","items":[]}]}`)
+	if _, err := LoadAll(tmpDir); err == nil || !strings.Contains(err.Error(), "must not be empty") {
+		t.Errorf("LoadAll with an empty category = %v, want an error", err)
+	}
+	c, err = LoadPartial(tmpDir)
+	if err != nil {
+		t.Fatalf("LoadPartial failed: %v", err)
+	}
+	if c.Available(usesFile) || c.Uses != nil {
+		t.Error("a bad uses.json should be unavailable and leave no setup")
+	}
+}
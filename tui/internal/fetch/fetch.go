@@ -0,0 +1,195 @@
+// Package fetch runs the network requests behind section data: it retries
+// transient failures with jittered backoff, tells them apart from errors
+// that retrying cannot fix, and caches results so sessions share them.
+// Sections issue fetches as tea.Cmds with Cmd.
+package fetch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Policy says how often and how patiently a fetch is retried.
+type Policy struct {
+	// Attempts is how many times the fetch is tried in all; less than
+	// one tries it once.
+	Attempts int
+	// Backoff is the delay before the first retry, doubled for each one
+	// after it up to MaxDelay, if set. Each delay is jittered by up to
+	// half either way, so sessions that failed together spread out.
+	Backoff  time.Duration
+	MaxDelay time.Duration
+	// Timeout bounds all attempts together in Cmd; zero leaves them
+	// unbounded.
+	Timeout time.Duration
+}
+
+// DefaultPolicy tries a fetch three times over about a second and a half.
+var DefaultPolicy = Policy{Attempts: 3, Backoff: 500 * time.Millisecond, MaxDelay: 4 * time.Second}
+
+// delay returns the jittered wait before retry n, counting from zero.
+func (p Policy) delay(n int) time.Duration {
+	d := p.Backoff
+	for range n {
+		if p.MaxDelay > 0 && d >= p.MaxDelay {
+			break
+		}
+		d *= 2
+	}
+	if p.MaxDelay > 0 {
+		d = min(d, p.MaxDelay)
+	}
+	if d <= 0 {
+		return 0
+	}
+	return d/2 + rand.N(d+1)
+}
+
+// Retry calls fn until it succeeds, fails with an error that is not
+// Retryable, or runs out of attempts, and returns its last result. It
+// gives up early, with the last error, once ctx is done or would be
+// before the next attempt could start.
+func Retry[T any](ctx context.Context, p Policy, fn func(context.Context) (T, error)) (T, error) {
+	attempts := max(1, p.Attempts)
+	for n := 0; ; n++ {
+		v, err := fn(ctx)
+		if err == nil || n+1 >= attempts || !Retryable(err) {
+			return v, err
+		}
+		wait := p.delay(n)
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+			return v, err
+		}
+		t := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return v, err
+		case <-t.C:
+		}
+	}
+}
+
+// StatusError is an HTTP response that was not the one asked for.
+type StatusError struct {
+	Code   int
+	Status string // as in http.Response, such as "502 Bad Gateway"
+}
+
+// CheckStatus returns a StatusError for a response whose status is not
+// want, or nil.
+func CheckStatus(resp *http.Response, want int) error {
+	if resp.StatusCode == want {
+		return nil
+	}
+	return &StatusError{Code: resp.StatusCode, Status: resp.Status}
+}
+
+func (e *StatusError) Error() string {
+	if e.Status == "" {
+		return fmt.Sprintf("HTTP %d", e.Code)
+	}
+	return e.Status
+}
+
+// permanentError marks an error that retrying will not fix.
+type permanentError struct{ err error }
+
+func (e permanentError) Error() string { return e.err.Error() }
+func (e permanentError) Unwrap() error { return e.err }
+
+// Permanent marks err as one that retrying will not fix even though it
+// looks transient, such as a timeout the caller knows will recur. It
+// returns nil for a nil err.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return permanentError{err}
+}
+
+// Retryable reports whether err looks transient: a network error or
+// timeout, a response cut short, or an HTTP 408, 429, or 5xx status other
+// than 501. Errors marked Permanent, cancellations, and anything else are
+// not retried.
+func Retryable(err error) bool {
+	if err == nil || errors.As(err, new(permanentError)) || errors.Is(err, context.Canceled) {
+		return false
+	}
+	var se *StatusError
+	if errors.As(err, &se) {
+		switch {
+		case se.Code == http.StatusRequestTimeout, se.Code == http.StatusTooManyRequests:
+			return true
+		case se.Code == http.StatusNotImplemented:
+			return false
+		default:
+			return se.Code >= 500
+		}
+	}
+	var netErr net.Error
+	return errors.Is(err, context.DeadlineExceeded) || errors.As(err, &netErr) ||
+		errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// Cache keeps the result of a fetch for a while. A success is kept for
+// the TTL and an error for the shorter of the TTL and RetryAfter, so a
+// failed fetch is tried again soon. It is safe for concurrent use;
+// callers that arrive during a fetch wait for it rather than starting
+// another.
+type Cache[T any] struct {
+	ttl        time.Duration
+	RetryAfter time.Duration
+
+	mu      sync.Mutex
+	val     T
+	err     error
+	expires time.Time
+}
+
+// NewCache returns a Cache keeping results for ttl and errors for at
+// most a minute.
+func NewCache[T any](ttl time.Duration) *Cache[T] {
+	return &Cache[T]{ttl: ttl, RetryAfter: time.Minute}
+}
+
+// Get returns the cached result as of now, calling fn to refresh it once
+// it has expired.
+func (c *Cache[T]) Get(ctx context.Context, now time.Time, fn func(context.Context) (T, error)) (T, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if now.After(c.expires) {
+		c.val, c.err = fn(ctx)
+		if c.err != nil {
+			var zero T
+			c.val = zero
+			c.expires = now.Add(min(c.ttl, c.RetryAfter))
+		} else {
+			c.expires = now.Add(c.ttl)
+		}
+	}
+	return c.val, c.err
+}
+
+// Cmd returns a command that runs fn under p, bounded by p.Timeout, and
+// hands its result to msg for the section's Update.
+func Cmd[T any](p Policy, fn func(context.Context) (T, error), msg func(T, error) tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		if p.Timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, p.Timeout)
+			defer cancel()
+		}
+		return msg(Retry(ctx, p, fn))
+	}
+}
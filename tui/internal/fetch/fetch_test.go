@@ -0,0 +1,160 @@
+package fetch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// quick retries without waiting noticeably between attempts.
+var quick = Policy{Attempts: 3, Backoff: time.Millisecond}
+
+func TestRetry(t *testing.T) {
+	transient := &StatusError{Code: http.StatusBadGateway}
+	tests := []struct {
+		name  string
+		errs  []error // returned by successive calls; nil succeeds
+		calls int
+		ok    bool
+	}{
+		{"first try", []error{nil}, 1, true},
+		{"after transient failures", []error{transient, transient, nil}, 3, true},
+		{"out of attempts", []error{transient, transient, transient, nil}, 3, false},
+		{"not found", []error{&StatusError{Code: http.StatusNotFound}, nil}, 1, false},
+		{"permanent", []error{Permanent(transient), nil}, 1, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			v, err := Retry(context.Background(), quick, func(context.Context) (int, error) {
+				err := tt.errs[calls]
+				calls++
+				if err != nil {
+					return 0, err
+				}
+				return 42, nil
+			})
+			if calls != tt.calls || (err == nil) != tt.ok || tt.ok && v != 42 {
+				t.Errorf("Retry = %d, %v after %d calls; want ok %v after %d", v, err, calls, tt.ok, tt.calls)
+			}
+		})
+	}
+}
+
+func TestRetryStopsAtDeadline(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	calls := 0
+	start := time.Now()
+	_, err := Retry(ctx, Policy{Attempts: 5, Backoff: time.Second}, func(context.Context) (int, error) {
+		calls++
+		return 0, io.ErrUnexpectedEOF
+	})
+	if !errors.Is(err, io.ErrUnexpectedEOF) || calls != 1 {
+		t.Errorf("Retry = %v after %d calls; want the first error", err, calls)
+	}
+	if elapsed := time.Since(start); elapsed > 40*time.Millisecond {
+		t.Errorf("Retry waited %v for a retry that could not finish in time", elapsed)
+	}
+}
+
+func TestPolicyDelay(t *testing.T) {
+	p := Policy{Backoff: 100 * time.Millisecond, MaxDelay: 300 * time.Millisecond}
+	for n, base := range []time.Duration{100, 200, 300, 300} {
+		base *= time.Millisecond
+		for range 20 {
+			if d := p.delay(n); d < base/2 || d > base*3/2 {
+				t.Fatalf("delay(%d) = %v, want within half of %v", n, d, base)
+			}
+		}
+	}
+}
+
+func TestRetryable(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{nil, false},
+		{&StatusError{Code: http.StatusServiceUnavailable}, true},
+		{fmt.Errorf("booking: %w", &StatusError{Code: http.StatusTooManyRequests}), true},
+		{&StatusError{Code: http.StatusNotImplemented}, false},
+		{&StatusError{Code: http.StatusForbidden}, false},
+		{&net.OpError{Op: "dial", Err: errors.New("connection refused")}, true},
+		{context.DeadlineExceeded, true},
+		{context.Canceled, false},
+		{io.ErrUnexpectedEOF, true},
+		{errors.New("invalid character"), false},
+		{Permanent(context.DeadlineExceeded), false},
+	}
+	for _, tt := range tests {
+		if got := Retryable(tt.err); got != tt.want {
+			t.Errorf("Retryable(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestCache(t *testing.T) {
+	c := NewCache[int](10 * time.Minute)
+	now := time.Date(2026, 10, 15, 9, 0, 0, 0, time.UTC)
+	calls := 0
+	fail := false
+	get := func(at time.Time) (int, error) {
+		return c.Get(context.Background(), at, func(context.Context) (int, error) {
+			calls++
+			if fail {
+				return 0, errors.New("down")
+			}
+			return calls, nil
+		})
+	}
+
+	if v, err := get(now); v != 1 || err != nil {
+		t.Fatalf("Get = %d, %v", v, err)
+	}
+	if v, _ := get(now.Add(5 * time.Minute)); v != 1 || calls != 1 {
+		t.Errorf("within the TTL Get = %d after %d calls, want the cached 1", v, calls)
+	}
+	fail = true
+	if v, err := get(now.Add(11 * time.Minute)); v != 0 || err == nil {
+		t.Errorf("failed refresh Get = %d, %v", v, err)
+	}
+	fail = false
+	if _, err := get(now.Add(11*time.Minute + 30*time.Second)); err == nil || calls != 2 {
+		t.Errorf("the error should be cached for a while, got %v after %d calls", err, calls)
+	}
+	if v, err := get(now.Add(12*time.Minute + time.Second)); v != 3 || err != nil {
+		t.Errorf("after RetryAfter Get = %d, %v; want a fresh fetch", v, err)
+	}
+}
+
+func TestCmd(t *testing.T) {
+	type resultMsg struct {
+		v   int
+		err error
+	}
+	calls := 0
+	cmd := Cmd(Policy{Attempts: 2, Backoff: time.Millisecond, Timeout: time.Second},
+		func(ctx context.Context) (int, error) {
+			if _, ok := ctx.Deadline(); !ok {
+				t.Error("Cmd should bound the fetch by the policy's timeout")
+			}
+			calls++
+			if calls == 1 {
+				return 0, &StatusError{Code: http.StatusBadGateway}
+			}
+			return 7, nil
+		},
+		func(v int, err error) tea.Msg { return resultMsg{v, err} },
+	)
+	if msg := cmd(); msg != (resultMsg{v: 7}) {
+		t.Errorf("Cmd() = %+v, want the retried result", msg)
+	}
+}
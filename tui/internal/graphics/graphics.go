@@ -0,0 +1,331 @@
+// Package graphics displays raster images in terminals that support an
+// inline image protocol. Images are prepared once for a fixed box of
+// character cells so they can stand in for text art without changing the
+// layout around them; terminals without a supported protocol keep the text.
+package graphics
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"strings"
+	"sync"
+)
+
+// PortraitFile is the optional portrait photo looked up in the data
+// directory.
+const PortraitFile = "portrait.png"
+
+// Pixel size assumed for one terminal cell when the terminal does not
+// report it, used to give prepared images the aspect ratio of their box.
+const (
+	CellWidth  = 10
+	CellHeight = 20
+)
+
+// maxRenditions bounds how many cell sizes an Image caches encodings for.
+const maxRenditions = 16
+
+// Protocol is an inline image protocol understood by a terminal.
+type Protocol int
+
+const (
+	None   Protocol = iota // no image support; callers fall back to text
+	Kitty                  // kitty graphics protocol with Unicode placeholders
+	Sixel                  // DEC sixel graphics
+	ITerm2                 // iTerm2 inline images (OSC 1337)
+)
+
+// String returns the protocol's configuration name.
+func (p Protocol) String() string {
+	switch p {
+	case Kitty:
+		return "kitty"
+	case Sixel:
+		return "sixel"
+	case ITerm2:
+		return "iterm2"
+	default:
+		return "off"
+	}
+}
+
+// ParseProtocol returns the protocol named by s ("kitty", "sixel",
+// "iterm2" or "off"). It reports false for any other name, including
+// "auto".
+func ParseProtocol(s string) (Protocol, bool) {
+	switch s {
+	case "kitty":
+		return Kitty, true
+	case "sixel":
+		return Sixel, true
+	case "iterm2":
+		return ITerm2, true
+	case "off":
+		return None, true
+	}
+	return None, false
+}
+
+// Detect guesses the image protocol of a client terminal from its TERM
+// value and the environment variables the client sent. It is the fallback
+// for terminals that do not answer Probe. SSH forwards only TERM by
+// default; TERM_PROGRAM is a bonus when sent, and iTerm2 sets LC_TERMINAL,
+// which many client configurations forward with the locale.
+func Detect(term string, environ []string) Protocol {
+	var program, lcTerminal string
+	for _, kv := range environ {
+		if v, ok := strings.CutPrefix(kv, "TERM_PROGRAM="); ok {
+			program = strings.ToLower(v)
+		}
+		if v, ok := strings.CutPrefix(kv, "LC_TERMINAL="); ok {
+			lcTerminal = strings.ToLower(v)
+		}
+	}
+
+	switch {
+	case program == "iterm.app", lcTerminal == "iterm2":
+		return ITerm2
+	case term == "xterm-kitty", term == "xterm-ghostty",
+		program == "kitty", program == "ghostty":
+		return Kitty
+	case strings.Contains(term, "sixel"),
+		strings.HasPrefix(term, "foot"),
+		strings.HasPrefix(term, "mlterm"),
+		strings.HasPrefix(term, "contour"),
+		program == "wezterm":
+		return Sixel
+	}
+	return None
+}
+
+// Capabilities describes a terminal's image support, as probed or
+// configured. Zero cell sizes mean unknown; CellWidth and CellHeight are
+// assumed instead.
+type Capabilities struct {
+	Protocol   Protocol
+	CellWidth  int // pixels
+	CellHeight int // pixels
+}
+
+// cellSize returns the capabilities' cell size in pixels, substituting the
+// defaults for unknown or implausible values.
+func (c Capabilities) cellSize() (w, h int) {
+	w, h = c.CellWidth, c.CellHeight
+	if w < 2 || w > 128 || h < 2 || h > 256 {
+		return CellWidth, CellHeight
+	}
+	return w, h
+}
+
+// Image is a picture to be shown in a fixed box of terminal cells. It is
+// cropped and scaled for each cell pixel size it is placed at, and the
+// encodings are cached so sessions with the same cell size share them. An
+// Image is safe for concurrent use.
+type Image struct {
+	Cols, Rows int
+
+	src        image.Image
+	mu         sync.Mutex
+	renditions map[[2]int]*rendition
+}
+
+// rendition is an Image fitted to one cell pixel size. Encodings are made
+// on first use since each session needs only one.
+type rendition struct {
+	fitted *image.RGBA
+
+	pngOnce sync.Once
+	png     string // base64 PNG, for kitty and iTerm2
+	pngLen  int    // decoded PNG size in bytes
+	pngErr  error
+
+	sixelOnce sync.Once
+	sixel     string // complete sixel DCS sequence
+}
+
+// Load reads a PNG (or any registered image format) from path and prepares
+// it for a cols×rows cell box. A missing file returns an error satisfying
+// errors.Is(err, os.ErrNotExist).
+func Load(path string, cols, rows int) (*Image, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	src, _, err := image.Decode(f)
+	if err != nil {
+		return nil, fmt.Errorf("decode %s: %w", path, err)
+	}
+	return New(src, cols, rows)
+}
+
+// New prepares src for a cols×rows cell box. Each placement center-crops
+// the image to the box's aspect ratio at the terminal's cell size.
+func New(src image.Image, cols, rows int) (*Image, error) {
+	if cols < 1 || rows < 1 {
+		return nil, errors.New("image box must be at least one cell")
+	}
+	if cols > len(placeholderDiacritics) || rows > len(placeholderDiacritics) {
+		return nil, fmt.Errorf("image box %d×%d exceeds %d cells", cols, rows, len(placeholderDiacritics))
+	}
+	if src.Bounds().Empty() {
+		return nil, errors.New("image is empty")
+	}
+	img := &Image{Cols: cols, Rows: rows, src: src, renditions: map[[2]int]*rendition{}}
+	// Encode once at the default cell size so a broken image fails here
+	// rather than in a session.
+	if _, _, err := img.rendition(CellWidth, CellHeight).encodedPNG(); err != nil {
+		return nil, err
+	}
+	return img, nil
+}
+
+// rendition returns the image fitted to cells of w×h pixels.
+func (img *Image) rendition(w, h int) *rendition {
+	img.mu.Lock()
+	defer img.mu.Unlock()
+	key := [2]int{w, h}
+	if r, ok := img.renditions[key]; ok {
+		return r
+	}
+	r := &rendition{fitted: fit(img.src, img.Cols*w, img.Rows*h)}
+	if len(img.renditions) < maxRenditions {
+		img.renditions[key] = r
+	}
+	return r
+}
+
+// encodedPNG returns the rendition as base64 PNG and its decoded length.
+func (r *rendition) encodedPNG() (string, int, error) {
+	r.pngOnce.Do(func() {
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, r.fitted); err != nil {
+			r.pngErr = fmt.Errorf("encode png: %w", err)
+			return
+		}
+		r.pngLen = buf.Len()
+		r.png = base64.StdEncoding.EncodeToString(buf.Bytes())
+	})
+	return r.png, r.pngLen, r.pngErr
+}
+
+// encodedSixel returns the rendition as a sixel sequence.
+func (r *rendition) encodedSixel() string {
+	r.sixelOnce.Do(func() {
+		r.sixel = encodeSixel(r.fitted)
+	})
+	return r.sixel
+}
+
+// fit center-crops src to the aspect ratio of w×h and scales it to exactly
+// that size, averaging the source pixels behind each destination pixel.
+func fit(src image.Image, w, h int) *image.RGBA {
+	b := src.Bounds()
+	crop := b
+	if b.Dx()*h > b.Dy()*w {
+		// Too wide: trim the sides.
+		cw := b.Dy() * w / h
+		crop.Min.X += (b.Dx() - cw) / 2
+		crop.Max.X = crop.Min.X + cw
+	} else {
+		// Too tall: trim the bottom, keeping the top where faces sit.
+		crop.Max.Y = crop.Min.Y + b.Dx()*h/w
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := range h {
+		y0 := crop.Min.Y + y*crop.Dy()/h
+		y1 := max(y0+1, crop.Min.Y+(y+1)*crop.Dy()/h)
+		for x := range w {
+			x0 := crop.Min.X + x*crop.Dx()/w
+			x1 := max(x0+1, crop.Min.X+(x+1)*crop.Dx()/w)
+			var r, g, bl, a, n uint32
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := src.At(sx, sy).RGBA()
+					r, g, bl, a, n = r+cr, g+cg, bl+cb, a+ca, n+1
+				}
+			}
+			dst.SetRGBA(x, y, color.RGBA{
+				R: uint8(r / n >> 8),
+				G: uint8(g / n >> 8),
+				B: uint8(bl / n >> 8),
+				A: uint8(a / n >> 8),
+			})
+		}
+	}
+	return dst
+}
+
+// Placement shows an Image in one session's terminal using a protocol.
+type Placement struct {
+	Protocol Protocol
+	image    *Image
+	r        *rendition
+	id       uint32
+}
+
+// Place returns a placement of img for a terminal with caps, fitted to its
+// cell size. id identifies the image to kitty terminals and should differ
+// between images shown in the same terminal; only its low 24 bits are
+// used. A nil image or the None protocol returns nil, meaning the caller
+// should render its text fallback.
+func (img *Image) Place(caps Capabilities, id uint32) *Placement {
+	if img == nil || caps.Protocol == None {
+		return nil
+	}
+	id &= 0xffffff
+	if id == 0 {
+		id = 1
+	}
+	w, h := caps.cellSize()
+	return &Placement{Protocol: caps.Protocol, image: img, r: img.rendition(w, h), id: id}
+}
+
+// OverFrame reports whether the image is painted over the frame at the
+// cursor rather than carried by the frame's own cells. Such images are
+// erased when the text beneath them is redrawn, so they should only be
+// shown while the frame around them is still.
+func (p *Placement) OverFrame() bool {
+	return p.Protocol != Kitty
+}
+
+// Cols returns the width of the placement in cells.
+func (p *Placement) Cols() int { return p.image.Cols }
+
+// Rows returns the height of the placement in cells.
+func (p *Placement) Rows() int { return p.image.Rows }
+
+// Setup returns the sequence to write to the terminal once, outside of
+// the rendered frame, before Cells is displayed. It is empty for protocols
+// that need no setup.
+func (p *Placement) Setup() string {
+	if p.Protocol != Kitty {
+		return ""
+	}
+	payload, _, _ := p.r.encodedPNG()
+	return kittyTransmit(payload, p.id, p.image.Cols, p.image.Rows)
+}
+
+// Cells returns Rows lines, each Cols cells wide as measured by
+// lipgloss.Width, that display the image when written to the terminal in
+// place of the text it replaces.
+func (p *Placement) Cells() string {
+	cols, rows := p.image.Cols, p.image.Rows
+	switch p.Protocol {
+	case Kitty:
+		return kittyPlaceholders(p.id, cols, rows)
+	case ITerm2:
+		payload, n, _ := p.r.encodedPNG()
+		return drawnCells(iterm2Image(payload, n, cols, rows), cols, rows)
+	default:
+		return drawnCells(p.r.encodedSixel(), cols, rows)
+	}
+}
@@ -0,0 +1,278 @@
+package graphics
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// testPicture returns a w×h image split into a red top half and a blue
+// bottom half.
+func testPicture(w, h int) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := range h {
+		for x := range w {
+			c := color.RGBA{R: 255, A: 255}
+			if y >= h/2 {
+				c = color.RGBA{B: 255, A: 255}
+			}
+			img.SetRGBA(x, y, c)
+		}
+	}
+	return img
+}
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		term    string
+		environ []string
+		want    Protocol
+	}{
+		{"xterm-kitty", nil, Kitty},
+		{"xterm-ghostty", nil, Kitty},
+		{"xterm-256color", []string{"TERM_PROGRAM=ghostty"}, Kitty},
+		{"foot", nil, Sixel},
+		{"foot-extra", nil, Sixel},
+		{"mlterm", nil, Sixel},
+		{"xterm-256color", []string{"TERM_PROGRAM=WezTerm"}, Sixel},
+		{"xterm-256color", []string{"TERM_PROGRAM=iTerm.app"}, ITerm2},
+		{"xterm-256color", []string{"LC_TERMINAL=iTerm2"}, ITerm2},
+		{"xterm-256color", nil, None},
+		{"xterm-256color", []string{"TERM_PROGRAM=Apple_Terminal"}, None},
+		{"", nil, None},
+	}
+	for _, tt := range tests {
+		if got := Detect(tt.term, tt.environ); got != tt.want {
+			t.Errorf("Detect(%q, %v) = %v, want %v", tt.term, tt.environ, got, tt.want)
+		}
+	}
+}
+
+func TestParseProtocol(t *testing.T) {
+	for _, p := range []Protocol{None, Kitty, Sixel, ITerm2} {
+		got, ok := ParseProtocol(p.String())
+		if !ok || got != p {
+			t.Errorf("ParseProtocol(%q) = %v, %v", p.String(), got, ok)
+		}
+	}
+	if _, ok := ParseProtocol("auto"); ok {
+		t.Error("auto is not a protocol")
+	}
+}
+
+func TestFitCropsToBoxAspect(t *testing.T) {
+	// A wide source loses its sides; a tall one keeps its top.
+	dst := fit(testPicture(400, 100), 20, 40)
+	if dst.Bounds().Dx() != 20 || dst.Bounds().Dy() != 40 {
+		t.Fatalf("fit size = %v, want 20×40", dst.Bounds())
+	}
+	tall := fit(testPicture(100, 1000), 10, 10)
+	if r, _, b, _ := tall.At(5, 9).RGBA(); r == 0 || b != 0 {
+		t.Error("fitting a tall image should keep the top (red) half")
+	}
+}
+
+func TestNewRejectsBadBoxes(t *testing.T) {
+	if _, err := New(testPicture(10, 10), 0, 5); err == nil {
+		t.Error("zero-width box should be rejected")
+	}
+	if _, err := New(testPicture(10, 10), 5, len(placeholderDiacritics)+1); err == nil {
+		t.Error("box taller than the diacritics table should be rejected")
+	}
+	if _, err := New(image.NewRGBA(image.Rect(0, 0, 0, 0)), 5, 5); err == nil {
+		t.Error("empty image should be rejected")
+	}
+}
+
+func TestPlacementCellsMatchBox(t *testing.T) {
+	img, err := New(testPicture(64, 64), 20, 14)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range []Protocol{Kitty, Sixel, ITerm2} {
+		cells := img.Place(Capabilities{Protocol: p}, 7).Cells()
+		lines := strings.Split(cells, "\n")
+		if len(lines) != 14 {
+			t.Errorf("%v: %d lines, want 14", p, len(lines))
+		}
+		for i, line := range lines {
+			if w := lipgloss.Width(line); w != 20 {
+				t.Errorf("%v: line %d is %d wide, want 20", p, i, w)
+			}
+		}
+	}
+	if img.Place(Capabilities{}, 7) != nil {
+		t.Error("None should produce no placement")
+	}
+	var missing *Image
+	if missing.Place(Capabilities{Protocol: Kitty}, 7) != nil {
+		t.Error("a nil image should produce no placement")
+	}
+}
+
+func TestKittyPlacement(t *testing.T) {
+	img, err := New(testPicture(300, 300), 3, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := img.Place(Capabilities{Protocol: Kitty}, 0x010203)
+
+	setup := p.Setup()
+	if !strings.HasPrefix(setup, "\x1b_Ga=T,U=1,f=100,t=d,i=66051,c=3,r=2,q=2,") {
+		t.Errorf("unexpected transmit header: %.60q", setup)
+	}
+	if !strings.HasSuffix(setup, "\x1b\\") || strings.Count(setup, "m=0;") != 1 {
+		t.Error("transmission should end with exactly one final chunk")
+	}
+
+	cells := p.Cells()
+	if !strings.HasPrefix(cells, "\x1b[38;2;1;2;3m") {
+		t.Errorf("placeholders should carry the image id as color: %.20q", cells)
+	}
+	first := strings.Split(cells, "\n")[1]
+	want := string([]rune{kittyPlaceholder, placeholderDiacritics[1], placeholderDiacritics[0]})
+	if !strings.Contains(first, want) {
+		t.Error("row 1, column 0 placeholder missing")
+	}
+}
+
+func TestKittyTransmitChunks(t *testing.T) {
+	payload := strings.Repeat("A", kittyChunkSize*2+10)
+	seq := kittyTransmit(payload, 1, 1, 1)
+	if n := strings.Count(seq, "\x1b_G"); n != 3 {
+		t.Errorf("payload should be sent in 3 chunks, got %d", n)
+	}
+	if strings.Count(seq, "m=1;") != 2 || strings.Count(seq, "m=0;") != 1 {
+		t.Error("all chunks but the last should be marked as continued")
+	}
+}
+
+func TestSixelPlacement(t *testing.T) {
+	img, err := New(testPicture(20, 20), 2, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := img.Place(Capabilities{Protocol: Sixel}, 1)
+	if p.Setup() != "" {
+		t.Error("sixel needs no setup")
+	}
+	cells := p.Cells()
+	last := cells[strings.LastIndex(cells, "\n")+1:]
+	if !strings.HasPrefix(last, "  \x1b7\x1b[1A\x1b[2D\x1bP") || !strings.HasSuffix(last, "\x1b\\\x1b8") {
+		t.Errorf("image should be drawn from the last row: %.40q", last)
+	}
+	// 20×40 pixels (2×2 cells) is seven sixel bands.
+	if n := strings.Count(img.rendition(CellWidth, CellHeight).encodedSixel(), "-"); n != 7 {
+		t.Errorf("sixel has %d bands, want 7", n)
+	}
+}
+
+func TestITerm2Placement(t *testing.T) {
+	img, err := New(testPicture(20, 20), 3, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := img.Place(Capabilities{Protocol: ITerm2}, 1)
+	if p.Setup() != "" || !p.OverFrame() {
+		t.Error("iTerm2 images are drawn in the frame without setup")
+	}
+	cells := p.Cells()
+	payload, n, _ := img.rendition(CellWidth, CellHeight).encodedPNG()
+	want := fmt.Sprintf("\x1b]1337;File=inline=1;size=%d;width=3;height=2;preserveAspectRatio=0:%s\a", n, payload)
+	if !strings.Contains(cells, want) {
+		t.Errorf("missing inline image sequence in %.60q", cells)
+	}
+}
+
+func TestPlaceUsesCellSize(t *testing.T) {
+	img, err := New(testPicture(100, 100), 2, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := img.Place(Capabilities{Protocol: Sixel, CellWidth: 8, CellHeight: 16}, 1)
+	if b := p.r.fitted.Bounds(); b.Dx() != 16 || b.Dy() != 32 {
+		t.Errorf("rendition is %v, want 16×32 for 8×16 cells", b)
+	}
+	if again := img.Place(Capabilities{Protocol: Kitty, CellWidth: 8, CellHeight: 16}, 2); again.r != p.r {
+		t.Error("placements at the same cell size should share a rendition")
+	}
+	// Implausible sizes fall back to the defaults.
+	d := img.Place(Capabilities{Protocol: Sixel, CellWidth: 1, CellHeight: 9999}, 1)
+	if b := d.r.fitted.Bounds(); b.Dx() != 2*CellWidth || b.Dy() != 2*CellHeight {
+		t.Errorf("implausible cell size should use defaults, got %v", b)
+	}
+}
+
+func TestParseProbe(t *testing.T) {
+	tests := []struct {
+		name  string
+		reply string
+		want  Capabilities
+	}{
+		{"kitty", "\x1b_Gi=31;OK\x1b\\\x1b[6;20;10t\x1b[?62;22c", Capabilities{Kitty, 10, 20}},
+		{"sixel", "\x1b[6;16;8t\x1b[?62;4;22c", Capabilities{Sixel, 8, 16}},
+		{"iterm2 points", "\x1b]1337;ReportCellSize=17.0;8.0;2.0\x1b\\\x1b[?62;4c", Capabilities{ITerm2, 16, 34}},
+		{"iterm2 pixels", "\x1b]1337;ReportCellSize=17;8\a\x1b[6;30;14t\x1b[?1;2c", Capabilities{ITerm2, 14, 30}},
+		{"none", "\x1b[?1;2c", Capabilities{}},
+	}
+	for _, tt := range tests {
+		got, end, ok := ParseProbe([]byte(tt.reply + "q"))
+		if !ok || got != tt.want {
+			t.Errorf("%s: ParseProbe = %+v, %v; want %+v", tt.name, got, ok, tt.want)
+		}
+		if end != len(tt.reply) {
+			t.Errorf("%s: end = %d, want %d (just past DA1)", tt.name, end, len(tt.reply))
+		}
+	}
+
+	if _, _, ok := ParseProbe([]byte("\x1b_Gi=31;OK\x1b\\")); ok {
+		t.Error("replies are incomplete until DA1 arrives")
+	}
+}
+
+func TestWriteSixelRuns(t *testing.T) {
+	var b strings.Builder
+	writeSixelRuns(&b, []byte("~~~~~??@"))
+	if got := b.String(); got != "!5~??@" {
+		t.Errorf("runs = %q", got)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := Load(filepath.Join(dir, PortraitFile), 4, 4); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("missing file should report not-exist, got %v", err)
+	}
+
+	path := filepath.Join(dir, PortraitFile)
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := png.Encode(f, testPicture(40, 40)); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+	img, err := Load(path, 4, 4)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if img.Cols != 4 || img.Rows != 4 {
+		t.Errorf("box = %d×%d, want 4×4", img.Cols, img.Rows)
+	}
+
+	if err := os.WriteFile(path, []byte("not an image"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path, 4, 4); err == nil {
+		t.Error("undecodable file should fail to load")
+	}
+}
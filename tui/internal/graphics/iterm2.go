@@ -0,0 +1,11 @@
+package graphics
+
+import "fmt"
+
+// iterm2Image returns the OSC 1337 sequence that draws a base64 PNG of
+// size bytes at the cursor, stretched to exactly cols×rows cells. The
+// image is already fitted to the box, so stretching does not distort it.
+func iterm2Image(payload string, size, cols, rows int) string {
+	return fmt.Sprintf("\x1b]1337;File=inline=1;size=%d;width=%d;height=%d;preserveAspectRatio=0:%s\a",
+		size, cols, rows, payload)
+}
@@ -0,0 +1,73 @@
+package graphics
+
+import (
+	"fmt"
+	"strings"
+)
+
+// kittyChunkSize is the largest base64 payload kitty accepts per escape.
+const kittyChunkSize = 4096
+
+// kittyPlaceholder is the Unicode placeholder character. Each cell written
+// with it displays one cell of a virtual placement; the foreground color
+// selects the image and combining diacritics select the row and column.
+const kittyPlaceholder = '\U0010EEEE'
+
+// placeholderDiacritics encodes row and column numbers for placeholder
+// cells, in the order defined by kitty's rowcolumn-diacritics table. The
+// first entry is 0.
+var placeholderDiacritics = []rune{
+	0x0305, 0x030D, 0x030E, 0x0310, 0x0312, 0x033D, 0x033E, 0x033F,
+	0x0346, 0x034A, 0x034B, 0x034C, 0x0350, 0x0351, 0x0352, 0x0357,
+	0x035B, 0x0363, 0x0364, 0x0365, 0x0366, 0x0367, 0x0368, 0x0369,
+	0x036A, 0x036B, 0x036C, 0x036D, 0x036E, 0x036F, 0x0483, 0x0484,
+	0x0485, 0x0486, 0x0487, 0x0592, 0x0593, 0x0594, 0x0595, 0x0597,
+	0x0598, 0x0599, 0x059C, 0x059D, 0x059E, 0x059F, 0x05A0, 0x05A1,
+	0x05A8, 0x05A9, 0x05AB, 0x05AC, 0x05AF, 0x05C4, 0x0610, 0x0611,
+	0x0612, 0x0613, 0x0614, 0x0615, 0x0616, 0x0617, 0x0657, 0x0658,
+}
+
+// kittyTransmit returns the escapes that upload a base64 PNG as image id
+// and create a virtual placement of cols×rows cells for placeholders to
+// show. Responses are suppressed so nothing arrives on the session's input.
+func kittyTransmit(payload string, id uint32, cols, rows int) string {
+	var b strings.Builder
+	first := true
+	for first || payload != "" {
+		chunk := payload[:min(len(payload), kittyChunkSize)]
+		payload = payload[len(chunk):]
+		more := 0
+		if payload != "" {
+			more = 1
+		}
+		if first {
+			fmt.Fprintf(&b, "\x1b_Ga=T,U=1,f=100,t=d,i=%d,c=%d,r=%d,q=2,m=%d;%s\x1b\\",
+				id, cols, rows, more, chunk)
+			first = false
+		} else {
+			fmt.Fprintf(&b, "\x1b_Gm=%d;%s\x1b\\", more, chunk)
+		}
+	}
+	return b.String()
+}
+
+// kittyPlaceholders returns rows lines of cols placeholder cells showing
+// image id. The id is carried in a 24-bit foreground color that is reset
+// at the end of each line.
+func kittyPlaceholders(id uint32, cols, rows int) string {
+	fg := fmt.Sprintf("\x1b[38;2;%d;%d;%dm", id>>16&0xff, id>>8&0xff, id&0xff)
+	var b strings.Builder
+	for r := range rows {
+		if r > 0 {
+			b.WriteByte('\n')
+		}
+		b.WriteString(fg)
+		for c := range cols {
+			b.WriteRune(kittyPlaceholder)
+			b.WriteRune(placeholderDiacritics[r])
+			b.WriteRune(placeholderDiacritics[c])
+		}
+		b.WriteString("\x1b[39m")
+	}
+	return b.String()
+}
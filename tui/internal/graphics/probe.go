@@ -0,0 +1,72 @@
+package graphics
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ProbeQuery asks a terminal about its image support. It queries kitty
+// graphics with a 1×1 test image, iTerm2's cell size report, and the cell
+// size in pixels, and ends with a primary device attributes request, which
+// every terminal answers and answers last, so a reader knows when the
+// replies are complete. The DA1 reply also lists sixel support.
+const ProbeQuery = "\x1b_Gi=31,s=1,v=1,a=q,t=d,f=24;AAAA\x1b\\" +
+	"\x1b]1337;ReportCellSize\a" +
+	"\x1b[16t" +
+	"\x1b[c"
+
+var (
+	probeDA1      = regexp.MustCompile(`\x1b\[\?([0-9;]*)c`)
+	probeKitty    = regexp.MustCompile(`\x1b_Gi=31;OK\x1b\\`)
+	probeITerm2   = regexp.MustCompile(`\x1b\]1337;ReportCellSize=([0-9.]+);([0-9.]+)(?:;([0-9.]+))?(?:\a|\x1b\\)`)
+	probeCellSize = regexp.MustCompile(`\x1b\[6;([0-9]+);([0-9]+)t`)
+)
+
+// ParseProbe scans b, the terminal's input since ProbeQuery was written,
+// for the replies. It reports false until the DA1 reply has arrived; then
+// it returns the capabilities and the offset just past the DA1 reply, so
+// input typed after it can be passed on.
+//
+// iTerm2 is preferred over kitty, and kitty over sixel, when a terminal
+// answers more than one way: terminals that implement iTerm2's protocol
+// alongside kitty's often lack the kitty placeholders this package uses.
+func ParseProbe(b []byte) (caps Capabilities, end int, ok bool) {
+	loc := probeDA1.FindSubmatchIndex(b)
+	if loc == nil {
+		return Capabilities{}, 0, false
+	}
+	replies := b[:loc[1]]
+
+	if m := probeCellSize.FindSubmatch(replies); m != nil {
+		caps.CellHeight, _ = strconv.Atoi(string(m[1]))
+		caps.CellWidth, _ = strconv.Atoi(string(m[2]))
+	}
+
+	sixel := false
+	for _, attr := range strings.Split(string(b[loc[2]:loc[3]]), ";") {
+		sixel = sixel || attr == "4"
+	}
+
+	switch m := probeITerm2.FindSubmatch(replies); {
+	case m != nil:
+		caps.Protocol = ITerm2
+		if caps.CellWidth == 0 {
+			// The report is in points; scale it to pixels.
+			h, _ := strconv.ParseFloat(string(m[1]), 64)
+			w, _ := strconv.ParseFloat(string(m[2]), 64)
+			scale := 1.0
+			if len(m[3]) > 0 {
+				scale, _ = strconv.ParseFloat(string(m[3]), 64)
+			}
+			caps.CellWidth = int(math.Round(w * scale))
+			caps.CellHeight = int(math.Round(h * scale))
+		}
+	case probeKitty.Match(replies):
+		caps.Protocol = Kitty
+	case sixel:
+		caps.Protocol = Sixel
+	}
+	return caps, loc[1], true
+}
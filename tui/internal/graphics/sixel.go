@@ -0,0 +1,108 @@
+package graphics
+
+import (
+	"fmt"
+	"image"
+	"image/color/palette"
+	"image/draw"
+	"strings"
+)
+
+// encodeSixel returns img as a complete sixel DCS sequence, dithered to the
+// 256-color Plan 9 palette.
+func encodeSixel(img image.Image) string {
+	b := img.Bounds()
+	pm := image.NewPaletted(image.Rect(0, 0, b.Dx(), b.Dy()), palette.Plan9)
+	draw.FloydSteinberg.Draw(pm, pm.Bounds(), img, b.Min)
+	w, h := pm.Rect.Dx(), pm.Rect.Dy()
+
+	var out strings.Builder
+	// P2=1: pixels that are not painted keep the cell background.
+	fmt.Fprintf(&out, "\x1bP0;1;0q\"1;1;%d;%d", w, h)
+
+	used := make([]bool, len(pm.Palette))
+	for _, ix := range pm.Pix {
+		used[ix] = true
+	}
+	for i, c := range pm.Palette {
+		if !used[i] {
+			continue
+		}
+		r, g, bl, _ := c.RGBA()
+		fmt.Fprintf(&out, "#%d;2;%d;%d;%d", i, r*100/0xffff, g*100/0xffff, bl*100/0xffff)
+	}
+
+	row := make([]byte, w)
+	for top := 0; top < h; top += 6 {
+		firstColor := true
+		for ci := range pm.Palette {
+			if !used[ci] {
+				continue
+			}
+			painted := false
+			for x := range w {
+				var bits byte
+				for dy := range 6 {
+					y := top + dy
+					if y < h && int(pm.Pix[y*pm.Stride+x]) == ci {
+						bits |= 1 << dy
+					}
+				}
+				row[x] = '?' + bits
+				painted = painted || bits != 0
+			}
+			if !painted {
+				continue
+			}
+			if !firstColor {
+				out.WriteByte('$') // carriage return within the band
+			}
+			firstColor = false
+			fmt.Fprintf(&out, "#%d", ci)
+			writeSixelRuns(&out, row)
+		}
+		out.WriteByte('-') // next band
+	}
+	out.WriteString("\x1b\\")
+	return out.String()
+}
+
+// writeSixelRuns writes sixel characters with runs of four or more
+// compressed to the repeat form.
+func writeSixelRuns(out *strings.Builder, row []byte) {
+	for i := 0; i < len(row); {
+		j := i + 1
+		for j < len(row) && row[j] == row[i] {
+			j++
+		}
+		if n := j - i; n >= 4 {
+			fmt.Fprintf(out, "!%d%c", n, row[i])
+		} else {
+			out.Write(row[i:j])
+		}
+		i = j
+	}
+}
+
+// drawnCells returns rows lines of blank cells, cols wide, with seq, an
+// image drawn at the cursor, written from the end of the last line. Text
+// written over image pixels erases them, so the image is painted only
+// after every row of its box has been written: the cursor is saved, moved
+// back to the top-left of the box, and restored once the image is drawn.
+// All of this is zero-width to lipgloss, so the layout is unaffected.
+func drawnCells(seq string, cols, rows int) string {
+	blank := strings.Repeat(" ", cols)
+	var b strings.Builder
+	for range rows - 1 {
+		b.WriteString(blank + "\n")
+	}
+	b.WriteString(blank)
+	b.WriteString("\x1b7")
+	if rows > 1 {
+		fmt.Fprintf(&b, "\x1b[%dA", rows-1)
+	}
+	fmt.Fprintf(&b, "\x1b[%dD", cols)
+	b.WriteString(seq)
+	b.WriteString("\x1b8")
+	return b.String()
+}
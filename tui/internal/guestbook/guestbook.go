@@ -0,0 +1,205 @@
+// Package guestbook stores short signed messages left by visitors. Entries
+// are appended to a JSON Lines file so the book survives restarts and can
+// be inspected or pruned with ordinary text tools.
+package guestbook
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// FileName is the guestbook file created inside the data directory.
+const FileName = "guestbook.jsonl"
+
+// Limits on a single entry, in runes.
+const (
+	MaxNameLen    = 32
+	MaxMessageLen = 280
+)
+
+// Anonymous is the name entries signed with a blank name are stored under.
+const Anonymous = "anonymous"
+
+// DefaultInterval is the minimum time between two entries from one IP.
+const DefaultInterval = 10 * time.Minute
+
+var (
+	// ErrEmpty is returned when a message is blank after sanitizing.
+	ErrEmpty = errors.New("message is empty")
+	// ErrRateLimited is returned when an IP signs again too soon.
+	ErrRateLimited = errors.New("already signed recently, try again later")
+	// ErrUnavailable is returned by a nil Store.
+	ErrUnavailable = errors.New("guestbook is unavailable")
+)
+
+// Entry is one guestbook message, signed with the visitor's SSH username
+// or as Anonymous.
+// The visitor's IP is used for rate limiting only and is never persisted.
+type Entry struct {
+	Time    time.Time `json:"ts"`
+	Name    string    `json:"name"`
+	Message string    `json:"message"`
+}
+
+// Store is an append-only guestbook backed by a JSONL file. It is safe for
+// concurrent use by many sessions. A nil Store is safe to use: it has no
+// entries and rejects new ones with ErrUnavailable.
+type Store struct {
+	mu       sync.Mutex
+	file     *os.File
+	entries  []Entry // oldest first, as in the file
+	lastPost map[string]time.Time
+	interval time.Duration
+	now      func() time.Time
+}
+
+// Open loads the guestbook at path, creating it if needed, and opens it for
+// appending. Each IP may sign at most once per interval. Malformed lines,
+// such as a partial write from a crash, are skipped with a warning.
+func Open(path string, interval time.Duration) (*Store, error) {
+	entries, err := readEntries(path)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &Store{
+		file:     f,
+		entries:  entries,
+		lastPost: map[string]time.Time{},
+		interval: interval,
+		now:      time.Now,
+	}, nil
+}
+
+// readEntries parses the existing guestbook file. A missing file is empty.
+func readEntries(path string) ([]Entry, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []Entry
+	sc := bufio.NewScanner(f)
+	line := 0
+	for sc.Scan() {
+		line++
+		b := sc.Bytes()
+		if len(strings.TrimSpace(string(b))) == 0 {
+			continue
+		}
+		var e Entry
+		if err := json.Unmarshal(b, &e); err != nil {
+			slog.Warn("guestbook: skipping malformed line", "path", path, "line", line, "err", err)
+			continue
+		}
+		entries = append(entries, e)
+	}
+	return entries, sc.Err()
+}
+
+// Entries returns a copy of all entries, newest first.
+func (s *Store) Entries() []Entry {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]Entry, len(s.entries))
+	for i, e := range s.entries {
+		out[len(out)-1-i] = e
+	}
+	return out
+}
+
+// Add signs the guestbook as name from ip. The name and message are
+// sanitized first; a blank name signs as Anonymous. It returns the stored
+// entry, ErrEmpty for a blank message, or ErrRateLimited if ip signed
+// within the store's interval.
+func (s *Store) Add(ip, name, message string) (Entry, error) {
+	if s == nil {
+		return Entry{}, ErrUnavailable
+	}
+	e := Entry{
+		Name:    Sanitize(name, MaxNameLen),
+		Message: Sanitize(message, MaxMessageLen),
+	}
+	if e.Message == "" {
+		return Entry{}, ErrEmpty
+	}
+	if e.Name == "" {
+		e.Name = Anonymous
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if last, ok := s.lastPost[ip]; ok && now.Sub(last) < s.interval {
+		return Entry{}, ErrRateLimited
+	}
+	e.Time = now
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		return Entry{}, err
+	}
+	if _, err := s.file.Write(append(data, '\n')); err != nil {
+		return Entry{}, err
+	}
+	s.entries = append(s.entries, e)
+	s.lastPost[ip] = now
+
+	// Forget IPs whose window has passed so the map stays small.
+	for k, t := range s.lastPost {
+		if now.Sub(t) >= s.interval {
+			delete(s.lastPost, k)
+		}
+	}
+	return e, nil
+}
+
+// Close closes the underlying file. No-op on nil Store.
+func (s *Store) Close() error {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.file.Close()
+}
+
+// Sanitize makes visitor text safe to store and render: control characters
+// (including escape sequences and newlines) become spaces, invisible
+// format characters such as bidi overrides and zero-width spaces are
+// dropped, runs of whitespace collapse to one space, and the result is
+// trimmed and cut to limit runes.
+func Sanitize(text string, limit int) string {
+	text = strings.Map(func(r rune) rune {
+		if unicode.Is(unicode.Cf, r) {
+			return -1
+		}
+		return r
+	}, text)
+	fields := strings.FieldsFunc(text, func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsControl(r)
+	})
+	out := []rune(strings.Join(fields, " "))
+	if len(out) > limit {
+		out = []rune(strings.TrimSpace(string(out[:limit])))
+	}
+	return string(out)
+}
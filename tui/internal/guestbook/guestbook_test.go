@@ -0,0 +1,156 @@
+package guestbook
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// openTemp opens a store in a temp dir with a controllable clock.
+func openTemp(t *testing.T, interval time.Duration) (*Store, string, *time.Time) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), FileName)
+	s, err := Open(path, interval)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() { _ = s.Close() })
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	s.now = func() time.Time { return now }
+	return s, path, &now
+}
+
+func TestAddAndEntriesNewestFirst(t *testing.T) {
+	s, _, now := openTemp(t, time.Minute)
+
+	if _, err := s.Add("1.1.1.1", "alice", "first"); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	*now = now.Add(time.Second)
+	if _, err := s.Add("2.2.2.2", "bob", "second"); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	entries := s.Entries()
+	if len(entries) != 2 || entries[0].Name != "bob" || entries[1].Name != "alice" {
+		t.Fatalf("Entries() = %+v, want bob then alice", entries)
+	}
+}
+
+func TestEntriesPersistAcrossOpen(t *testing.T) {
+	s, path, _ := openTemp(t, time.Minute)
+	if _, err := s.Add("1.1.1.1", "alice", "hello"); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	_ = s.Close()
+
+	reopened, err := Open(path, time.Minute)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	defer reopened.Close()
+	entries := reopened.Entries()
+	if len(entries) != 1 || entries[0].Message != "hello" {
+		t.Errorf("after reopen Entries() = %+v", entries)
+	}
+
+	data, _ := os.ReadFile(path)
+	if strings.Contains(string(data), "1.1.1.1") {
+		t.Error("visitor IP must not be persisted")
+	}
+}
+
+func TestAddRateLimitedPerIP(t *testing.T) {
+	s, _, now := openTemp(t, 10*time.Minute)
+
+	if _, err := s.Add("1.1.1.1", "alice", "one"); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if _, err := s.Add("1.1.1.1", "alice", "two"); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("second Add from same IP: err = %v, want ErrRateLimited", err)
+	}
+	if _, err := s.Add("2.2.2.2", "bob", "other ip"); err != nil {
+		t.Errorf("Add from another IP: %v", err)
+	}
+
+	*now = now.Add(10 * time.Minute)
+	if _, err := s.Add("1.1.1.1", "alice", "three"); err != nil {
+		t.Errorf("Add after interval: %v", err)
+	}
+	if got := len(s.Entries()); got != 3 {
+		t.Errorf("stored %d entries, want 3", got)
+	}
+}
+
+func TestAddSanitizes(t *testing.T) {
+	s, _, _ := openTemp(t, time.Minute)
+
+	if _, err := s.Add("1.1.1.1", "alice", " \n\t "); !errors.Is(err, ErrEmpty) {
+		t.Errorf("blank message: err = %v, want ErrEmpty", err)
+	}
+
+	e, err := s.Add("1.1.1.1", "", "hi\x1b[2J\nthere")
+	if err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if e.Name != "anonymous" {
+		t.Errorf("blank name stored as %q, want anonymous", e.Name)
+	}
+	if strings.ContainsAny(e.Message, "\x1b\n") {
+		t.Errorf("control characters survived: %q", e.Message)
+	}
+}
+
+func TestSanitize(t *testing.T) {
+	tests := []struct {
+		in   string
+		max  int
+		want string
+	}{
+		{"  hello   world  ", 50, "hello world"},
+		{"line\none", 50, "line one"},
+		{"bell\a", 50, "bell"},
+		{"abcdef", 3, "abc"},
+		{"ab cd", 3, "ab"},
+		{"héllo", 2, "hé"},
+		{"evil\u202egnp.exe", 50, "evilgnp.exe"},
+		{"zero\u200bwidth \ufeffbom", 50, "zerowidth bom"},
+	}
+	for _, tt := range tests {
+		if got := Sanitize(tt.in, tt.max); got != tt.want {
+			t.Errorf("Sanitize(%q, %d) = %q, want %q", tt.in, tt.max, got, tt.want)
+		}
+	}
+}
+
+func TestOpenSkipsMalformedLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	data := `{"ts":"2026-01-01T00:00:00Z","name":"a","message":"ok"}` + "\n" + `{"ts":` + "\n"
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	s, err := Open(path, time.Minute)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer s.Close()
+	if got := len(s.Entries()); got != 1 {
+		t.Errorf("loaded %d entries, want 1", got)
+	}
+}
+
+func TestNilStore(t *testing.T) {
+	var s *Store
+	if s.Entries() != nil {
+		t.Error("nil store should have no entries")
+	}
+	if _, err := s.Add("ip", "name", "msg"); !errors.Is(err, ErrUnavailable) {
+		t.Errorf("nil store Add: err = %v, want ErrUnavailable", err)
+	}
+	if err := s.Close(); err != nil {
+		t.Errorf("nil store Close: %v", err)
+	}
+}
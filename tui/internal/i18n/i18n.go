@@ -0,0 +1,100 @@
+// Package i18n translates the UI chrome: the help overlay, status bar
+// hints, idle messages, and the sections' key hints and feedback. Each locale is a JSON file in locales/, named
+// by its language tag, holding the language's own name and its messages
+// by ID. Messages missing from a locale fall back to English.
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+)
+
+// DefaultTag is the locale sessions use unless they pick another.
+const DefaultTag = "en"
+
+//go:embed locales/*.json
+var files embed.FS
+
+// Locale is one language's messages.
+type Locale struct {
+	// Tag is the language tag, such as "de", also used as the SSH user
+	// name that selects the locale.
+	Tag string
+	// Name is the language's name in that language, such as "Deutsch".
+	Name     string
+	messages map[string]string
+}
+
+// locales holds every embedded locale by tag.
+var locales = mustLoad()
+
+// mustLoad parses the embedded locale files. They ship with the binary,
+// so a malformed one is a build mistake caught by the tests.
+func mustLoad() map[string]*Locale {
+	entries, err := files.ReadDir("locales")
+	if err != nil {
+		panic(err)
+	}
+	out := make(map[string]*Locale, len(entries))
+	for _, e := range entries {
+		data, err := files.ReadFile(path.Join("locales", e.Name()))
+		if err != nil {
+			panic(err)
+		}
+		var f struct {
+			Name     string            `json:"name"`
+			Messages map[string]string `json:"messages"`
+		}
+		if err := json.Unmarshal(data, &f); err != nil {
+			panic(fmt.Sprintf("locale %s: %v", e.Name(), err))
+		}
+		tag := strings.TrimSuffix(e.Name(), ".json")
+		out[tag] = &Locale{Tag: tag, Name: f.Name, messages: f.Messages}
+	}
+	return out
+}
+
+// Lookup returns the locale for tag, case-insensitively, or false if
+// there is none.
+func Lookup(tag string) (*Locale, bool) {
+	l, ok := locales[strings.ToLower(tag)]
+	return l, ok
+}
+
+// Default returns the English locale.
+func Default() *Locale {
+	return locales[DefaultTag]
+}
+
+// Tags returns the tags of every locale, sorted.
+func Tags() []string {
+	tags := make([]string, 0, len(locales))
+	for tag := range locales {
+		tags = append(tags, tag)
+	}
+	slices.Sort(tags)
+	return tags
+}
+
+// T returns the message id, formatted with args when there are any. A
+// message the locale lacks comes from English, and one English lacks is
+// returned as its id. A nil Locale is English.
+func (l *Locale) T(id string, args ...any) string {
+	if l == nil {
+		l = Default()
+	}
+	msg, ok := l.messages[id]
+	if !ok {
+		if msg, ok = Default().messages[id]; !ok {
+			msg = id
+		}
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(msg, args...)
+	}
+	return msg
+}
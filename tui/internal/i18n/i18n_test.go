@@ -0,0 +1,60 @@
+package i18n
+
+import (
+	"regexp"
+	"slices"
+	"testing"
+)
+
+// verbs matches the fmt verbs in a message.
+var verbs = regexp.MustCompile(`%[a-z]`)
+
+func TestLocalesMatchEnglish(t *testing.T) {
+	en := Default()
+	if en == nil || en.Name != "English" {
+		t.Fatalf("Default() = %+v, want English", en)
+	}
+	for _, tag := range Tags() {
+		l, _ := Lookup(tag)
+		if l.Name == "" {
+			t.Errorf("%s has no name", tag)
+		}
+		for id, msg := range en.messages {
+			got, ok := l.messages[id]
+			if !ok {
+				t.Errorf("%s is missing %s", tag, id)
+				continue
+			}
+			if !slices.Equal(verbs.FindAllString(got, -1), verbs.FindAllString(msg, -1)) {
+				t.Errorf("%s %s = %q, its verbs differ from %q", tag, id, got, msg)
+			}
+		}
+		for id := range l.messages {
+			if _, ok := en.messages[id]; !ok {
+				t.Errorf("%s has %s, which English lacks", tag, id)
+			}
+		}
+	}
+}
+
+func TestT(t *testing.T) {
+	de, ok := Lookup("DE")
+	if !ok {
+		t.Fatal("Lookup should find de case-insensitively")
+	}
+	if got := de.T("lang.switched", de.Name); got != "Sprache: Deutsch" {
+		t.Errorf("T = %q", got)
+	}
+	if got := (*Locale)(nil).T("help.quit"); got != "Quit" {
+		t.Errorf("a nil locale should be English, got %q", got)
+	}
+	if got := de.T("no.such.message"); got != "no.such.message" {
+		t.Errorf("an unknown id should come back as itself, got %q", got)
+	}
+	if _, ok := Lookup("xx"); ok {
+		t.Error("Lookup should not find xx")
+	}
+	if tags := Tags(); !slices.Equal(tags, []string{"de", "en"}) {
+		t.Errorf("Tags() = %v", tags)
+	}
+}
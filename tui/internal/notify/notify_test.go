@@ -0,0 +1,88 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWebhook(t *testing.T) {
+	var got map[string]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("%s with Content-Type %q", r.Method, r.Header.Get("Content-Type"))
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Error(err)
+		}
+	}))
+	defer srv.Close()
+
+	h := &Webhook{URL: srv.URL}
+	if err := h.Notify(context.Background(), "Visitors", "Sessions: 3"); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	if got["subject"] != "Visitors" || got["body"] != "Sessions: 3" || got["text"] != "Visitors\n\nSessions: 3" {
+		t.Errorf("payload = %v", got)
+	}
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer failing.Close()
+	if err := (&Webhook{URL: failing.URL}).Notify(context.Background(), "s", "b"); err == nil {
+		t.Error("a 403 response should fail")
+	}
+}
+
+func TestParseEmail(t *testing.T) {
+	e, err := ParseEmail("smtp://me@example.com:secret@mail.example.com", "a@example.com, b@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.Addr != "mail.example.com:587" || e.From != "me@example.com" || len(e.To) != 2 || e.Auth == nil {
+		t.Errorf("ParseEmail = %+v", e)
+	}
+
+	e, err = ParseEmail("smtp://localhost:25", "a@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.Addr != "localhost:25" || e.From != "terminal-portfolio@localhost" || e.Auth != nil {
+		t.Errorf("ParseEmail without a user = %+v", e)
+	}
+
+	for _, bad := range []struct{ url, to string }{
+		{"https://mail.example.com", "a@example.com"},
+		{"smtp://mail.example.com", " , "},
+	} {
+		if _, err := ParseEmail(bad.url, bad.to); err == nil {
+			t.Errorf("ParseEmail(%q, %q) should fail", bad.url, bad.to)
+		}
+	}
+}
+
+type fakeNotifier struct {
+	calls int
+	err   error
+}
+
+func (f *fakeNotifier) Notify(context.Context, string, string) error {
+	f.calls++
+	return f.err
+}
+
+func TestAll(t *testing.T) {
+	broken := &fakeNotifier{err: errors.New("down")}
+	ok := &fakeNotifier{}
+	err := All{broken, ok}.Notify(context.Background(), "s", "b")
+	if !errors.Is(err, broken.err) {
+		t.Errorf("Notify = %v, want the failure", err)
+	}
+	if ok.calls != 1 {
+		t.Error("a failing notifier should not stop the others")
+	}
+}
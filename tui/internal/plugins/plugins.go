@@ -0,0 +1,95 @@
+// Package plugins collects custom command palette commands. A deployment
+// registers its commands from main before the server starts, and every
+// session's palette offers them after the built-in commands:
+//
+//	plugins.Register(plugins.Webhook("coffee", "Buy me a coffee",
+//		"https://hooks.example.com/coffee", "Thanks for the coffee!"))
+package plugins
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/buntingszn/terminal-portfolio/tui/internal/app"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/notify"
+)
+
+var (
+	mu       sync.Mutex
+	commands []app.PaletteCommand
+)
+
+// Register adds a palette command. Names are lower-case letters, digits,
+// and dashes, and must not repeat a built-in or registered command.
+func Register(c app.PaletteCommand) error {
+	if !validName(c.Name) {
+		return fmt.Errorf("palette command name %q must be lower-case letters, digits, or dashes", c.Name)
+	}
+	if c.Run == nil {
+		return fmt.Errorf("palette command %s has no Run func", c.Name)
+	}
+	if app.PaletteBuiltin(c.Name) {
+		return fmt.Errorf("palette command %s is built in", c.Name)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if slices.ContainsFunc(commands, func(r app.PaletteCommand) bool { return r.Name == c.Name }) {
+		return fmt.Errorf("palette command %s is already registered", c.Name)
+	}
+	commands = append(commands, c)
+	return nil
+}
+
+// Commands returns the registered commands in registration order.
+func Commands() []app.PaletteCommand {
+	mu.Lock()
+	defer mu.Unlock()
+	return slices.Clone(commands)
+}
+
+// Reset removes every registered command. It is meant for tests.
+func Reset() {
+	mu.Lock()
+	defer mu.Unlock()
+	commands = nil
+}
+
+// validName reports whether name can be typed as a palette command.
+func validName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' {
+			return false
+		}
+	}
+	return true
+}
+
+// Webhook returns a command that posts to url, as a notify.Webhook message
+// naming the command, its arguments, and the visitor's session, and then
+// shows reply.
+func Webhook(name, description, url, reply string) app.PaletteCommand {
+	hook := &notify.Webhook{URL: url}
+	return app.PaletteCommand{
+		Name:        name,
+		Description: description,
+		Run: func(ctx context.Context, inv app.PaletteInvocation) (string, error) {
+			if url == "" {
+				return "", errors.New("no webhook URL")
+			}
+			body := fmt.Sprintf("session %s from %s ran :%s", inv.SessionID, inv.IP, name)
+			if inv.Args != "" {
+				body += " " + inv.Args
+			}
+			if err := hook.Notify(ctx, ":"+name, body); err != nil {
+				return "", err
+			}
+			return reply, nil
+		},
+	}
+}
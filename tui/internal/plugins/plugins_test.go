@@ -0,0 +1,57 @@
+package plugins
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/buntingszn/terminal-portfolio/tui/internal/app"
+)
+
+func TestRegister(t *testing.T) {
+	t.Cleanup(Reset)
+	run := func(context.Context, app.PaletteInvocation) (string, error) { return "", nil }
+
+	if err := Register(app.PaletteCommand{Name: "coffee", Run: run}); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	for _, bad := range []app.PaletteCommand{
+		{Name: "coffee", Run: run},
+		{Name: "theme", Run: run},
+		{Name: "Coffee", Run: run},
+		{Name: "two words", Run: run},
+		{Name: "", Run: run},
+		{Name: "tea"},
+	} {
+		if err := Register(bad); err == nil {
+			t.Errorf("Register(%q) should fail", bad.Name)
+		}
+	}
+	if got := Commands(); len(got) != 1 || got[0].Name != "coffee" {
+		t.Errorf("Commands = %v, want only coffee", got)
+	}
+}
+
+func TestWebhook(t *testing.T) {
+	var got map[string]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&got)
+	}))
+	defer srv.Close()
+
+	c := Webhook("coffee", "Buy me a coffee", srv.URL, "Thanks!")
+	reply, err := c.Run(context.Background(), app.PaletteInvocation{SessionID: "s1", IP: "1.2.3.4", Args: "oat milk"})
+	if err != nil || reply != "Thanks!" {
+		t.Fatalf("Run = %q, %v", reply, err)
+	}
+	if got["subject"] != ":coffee" || !strings.Contains(got["body"], "s1 from 1.2.3.4 ran :coffee oat milk") {
+		t.Errorf("webhook payload = %v", got)
+	}
+
+	if _, err := Webhook("coffee", "", srv.URL+"/missing\x7f", "").Run(context.Background(), app.PaletteInvocation{}); err == nil {
+		t.Error("a bad webhook URL should fail")
+	}
+}
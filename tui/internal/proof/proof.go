@@ -0,0 +1,171 @@
+// Package proof signs a statement listing the owner's accounts and
+// domains, so `ssh host verify` can show visitors a keybase-style proof
+// that one key controls all of them. Signatures use the SSHSIG format of
+// `ssh-keygen -Y sign` and are checked with `ssh-keygen -Y verify`.
+package proof
+
+import (
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+
+	"github.com/buntingszn/terminal-portfolio/tui/internal/content"
+)
+
+// Namespace is the SSHSIG namespace statements are signed in; verifiers
+// pass it to ssh-keygen with -n.
+const Namespace = "terminal-portfolio"
+
+// hashAlgorithm is the message hash SSHSIG signatures are made over.
+const hashAlgorithm = "sha512"
+
+// Proof is a signed statement ready to be shown to visitors.
+type Proof struct {
+	Statement string
+	// Signature is the armored SSHSIG signature of Statement, as
+	// `ssh-keygen -Y sign` writes it.
+	Signature string
+	// PublicKey is the signing key in authorized_keys format, without a
+	// comment, and Fingerprint its SHA-256 fingerprint.
+	PublicKey   string
+	Fingerprint string
+}
+
+// Signer signs statements with the owner's SSH private key.
+type Signer struct {
+	signer ssh.Signer
+}
+
+// LoadSigner reads an unencrypted OpenSSH or PEM private key from path.
+func LoadSigner(path string) (*Signer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read verify key: %w", err)
+	}
+	signer, err := ssh.ParsePrivateKey(data)
+	var missing *ssh.PassphraseMissingError
+	if errors.As(err, &missing) {
+		return nil, fmt.Errorf("verify key %s is encrypted; it must not need a passphrase", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse verify key %s: %w", path, err)
+	}
+	return &Signer{signer: signer}, nil
+}
+
+// Sign builds the statement for c as of now and signs it.
+func (s *Signer) Sign(c *content.Content, now time.Time) (Proof, error) {
+	pub := s.signer.PublicKey()
+	p := Proof{
+		PublicKey:   strings.TrimSpace(string(ssh.MarshalAuthorizedKey(pub))),
+		Fingerprint: ssh.FingerprintSHA256(pub),
+	}
+	p.Statement = Statement(c, p.Fingerprint, now)
+	sig, err := s.sign([]byte(p.Statement))
+	if err != nil {
+		return Proof{}, err
+	}
+	p.Signature = sig
+	return p, nil
+}
+
+// Statement returns the text that is signed: the owner's name, each link
+// and the site and SSH addresses, and the signing key's fingerprint.
+func Statement(c *content.Content, fingerprint string, now time.Time) string {
+	type claim struct{ label, value string }
+	var claims []claim
+	for _, l := range c.Links.Links {
+		if l.URL != "" {
+			claims = append(claims, claim{l.Label, strings.TrimPrefix(l.URL, "mailto:")})
+		}
+	}
+	if c.Meta.SiteURL != "" {
+		claims = append(claims, claim{"Website", c.Meta.SiteURL})
+	}
+	if c.Meta.SSHAddress != "" {
+		claims = append(claims, claim{"SSH", c.Meta.SSHAddress})
+	}
+	width := 0
+	for _, cl := range claims {
+		width = max(width, len(cl.label))
+	}
+
+	var b strings.Builder
+	name := c.Meta.Name
+	if name == "" {
+		name = "the owner of this portfolio"
+	}
+	fmt.Fprintf(&b, "I am %s, and I control these accounts and domains:\n\n", name)
+	for _, cl := range claims {
+		fmt.Fprintf(&b, "  %-*s  %s\n", width, cl.label, cl.value)
+	}
+	fmt.Fprintf(&b, "\nThis statement is signed by the SSH key %s.\n", fingerprint)
+	fmt.Fprintf(&b, "Generated %s.\n", now.UTC().Format(time.DateOnly))
+	return b.String()
+}
+
+// sign returns the armored SSHSIG signature of message, following
+// PROTOCOL.sshsig in the OpenSSH sources.
+func (s *Signer) sign(message []byte) (string, error) {
+	h := sha512.Sum512(message)
+	signed := ssh.Marshal(struct {
+		Magic         [6]byte
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Hash          string
+	}{sigMagic(), Namespace, "", hashAlgorithm, string(h[:])})
+
+	var sig *ssh.Signature
+	var err error
+	// ssh-keygen signs with SHA-512 RSA signatures, never the SHA-1 ones
+	// a plain Sign picks for RSA keys.
+	if as, ok := s.signer.(ssh.AlgorithmSigner); ok && s.signer.PublicKey().Type() == ssh.KeyAlgoRSA {
+		sig, err = as.SignWithAlgorithm(rand.Reader, signed, ssh.KeyAlgoRSASHA512)
+	} else {
+		sig, err = s.signer.Sign(rand.Reader, signed)
+	}
+	if err != nil {
+		return "", fmt.Errorf("sign statement: %w", err)
+	}
+
+	blob := ssh.Marshal(struct {
+		Magic         [6]byte
+		Version       uint32
+		PublicKey     string
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Signature     string
+	}{sigMagic(), 1, string(s.signer.PublicKey().Marshal()), Namespace, "", hashAlgorithm, string(ssh.Marshal(sig))})
+	return armor(blob), nil
+}
+
+// sigMagic returns the "SSHSIG" preamble of signatures and signed data.
+func sigMagic() [6]byte {
+	var m [6]byte
+	copy(m[:], "SSHSIG")
+	return m
+}
+
+// armor wraps a signature blob the way ssh-keygen does: base64 in lines
+// of 70 characters between BEGIN and END markers.
+func armor(blob []byte) string {
+	enc := base64.StdEncoding.EncodeToString(blob)
+	var b strings.Builder
+	b.WriteString("-----BEGIN SSH SIGNATURE-----\n")
+	for len(enc) > 70 {
+		b.WriteString(enc[:70] + "\n")
+		enc = enc[70:]
+	}
+	b.WriteString(enc + "\n")
+	b.WriteString("-----END SSH SIGNATURE-----\n")
+	return b.String()
+}
@@ -0,0 +1,185 @@
+package proof
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/pem"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+
+	"github.com/buntingszn/terminal-portfolio/tui/internal/content"
+)
+
+// writeKey writes key to a private key file in a temp dir and returns its
+// path.
+func writeKey(t *testing.T, key any) string {
+	t.Helper()
+	block, err := ssh.MarshalPrivateKey(key, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "verify_key")
+	if err := os.WriteFile(path, pem.EncodeToMemory(block), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func testContent() *content.Content {
+	c := &content.Content{}
+	c.Meta.Name = "Ada Lovelace"
+	c.Meta.SiteURL = "https://ada.example"
+	c.Meta.SSHAddress = "ssh ada.example"
+	c.Links.Links = []content.Link{
+		{Label: "GitHub", URL: "https://github.com/ada"},
+		{Label: "Email", URL: "mailto:ada@example.com"},
+	}
+	return c
+}
+
+func TestStatement(t *testing.T) {
+	now := time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC)
+	got := Statement(testContent(), "SHA256:abc", now)
+	want := `I am Ada Lovelace, and I control these accounts and domains:
+
+  GitHub   https://github.com/ada
+  Email    ada@example.com
+  Website  https://ada.example
+  SSH      ssh ada.example
+
+This statement is signed by the SSH key SHA256:abc.
+Generated 2026-03-04.
+`
+	if got != want {
+		t.Errorf("Statement() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestSignVerifies(t *testing.T) {
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, key := range map[string]any{"ed25519": edKey, "rsa": rsaKey} {
+		t.Run(name, func(t *testing.T) {
+			s, err := LoadSigner(writeKey(t, key))
+			if err != nil {
+				t.Fatal(err)
+			}
+			p, err := s.Sign(testContent(), time.Now())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(p.Statement, p.Fingerprint) {
+				t.Errorf("statement should name the key %s:\n%s", p.Fingerprint, p.Statement)
+			}
+			verify(t, p)
+			verifyWithSSHKeygen(t, p)
+		})
+	}
+}
+
+// verify checks p's signature by hand against the SSHSIG format.
+func verify(t *testing.T, p Proof) {
+	t.Helper()
+	body := strings.TrimPrefix(p.Signature, "-----BEGIN SSH SIGNATURE-----\n")
+	body = strings.TrimSuffix(body, "-----END SSH SIGNATURE-----\n")
+	blob, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(body, "\n", ""))
+	if err != nil {
+		t.Fatalf("signature armor: %v", err)
+	}
+	var sig struct {
+		Magic         [6]byte
+		Version       uint32
+		PublicKey     string
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Signature     string
+	}
+	if err := ssh.Unmarshal(blob, &sig); err != nil {
+		t.Fatalf("signature blob: %v", err)
+	}
+	if string(sig.Magic[:]) != "SSHSIG" || sig.Version != 1 || sig.Namespace != Namespace {
+		t.Fatalf("signature header = %q v%d %q", sig.Magic, sig.Version, sig.Namespace)
+	}
+	pub, err := ssh.ParsePublicKey([]byte(sig.PublicKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.TrimSpace(string(ssh.MarshalAuthorizedKey(pub))); got != p.PublicKey {
+		t.Errorf("signature key = %s, want %s", got, p.PublicKey)
+	}
+	var inner ssh.Signature
+	if err := ssh.Unmarshal([]byte(sig.Signature), &inner); err != nil {
+		t.Fatal(err)
+	}
+	h := sha512.Sum512([]byte(p.Statement))
+	signed := ssh.Marshal(struct {
+		Magic         [6]byte
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Hash          string
+	}{sigMagic(), Namespace, "", hashAlgorithm, string(h[:])})
+	if err := pub.Verify(signed, &inner); err != nil {
+		t.Errorf("signature does not verify: %v", err)
+	}
+}
+
+// verifyWithSSHKeygen checks p the way the verify command tells visitors
+// to, when ssh-keygen is installed.
+func verifyWithSSHKeygen(t *testing.T, p Proof) {
+	t.Helper()
+	keygen, err := exec.LookPath("ssh-keygen")
+	if err != nil {
+		t.Log("ssh-keygen not found; skipping its check")
+		return
+	}
+	dir := t.TempDir()
+	signers := filepath.Join(dir, "allowed_signers")
+	sigPath := filepath.Join(dir, "statement.sig")
+	if err := os.WriteFile(signers, []byte("owner "+p.PublicKey+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(sigPath, []byte(p.Signature), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cmd := exec.Command(keygen, "-Y", "verify", "-f", signers, "-I", "owner", "-n", Namespace, "-s", sigPath)
+	cmd.Stdin = strings.NewReader(p.Statement)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Errorf("ssh-keygen -Y verify: %v\n%s", err, out)
+	}
+}
+
+func TestLoadSignerErrors(t *testing.T) {
+	if _, err := LoadSigner(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("a missing key should be an error")
+	}
+
+	_, key, _ := ed25519.GenerateKey(rand.Reader)
+	block, err := ssh.MarshalPrivateKeyWithPassphrase(key, "", []byte("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "encrypted")
+	if err := os.WriteFile(path, pem.EncodeToMemory(block), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadSigner(path); err == nil || !strings.Contains(err.Error(), "passphrase") {
+		t.Errorf("an encrypted key should ask for no passphrase, got %v", err)
+	}
+}
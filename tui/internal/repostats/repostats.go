@@ -0,0 +1,251 @@
+// Package repostats enriches work projects with live stats from GitHub.
+// One Fetcher per server refreshes every project's repository on an
+// interval and keeps the results in a cache file, so badges show straight
+// after a restart and visitors never cause API requests of their own.
+package repostats
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// FileName is the cache file created inside the state directory.
+const FileName = "repo-stats.json"
+
+// DefaultAPI is the GitHub REST API the Fetcher queries.
+const DefaultAPI = "https://api.github.com"
+
+// Stats are the live details of one repository.
+type Stats struct {
+	Stars      int       `json:"stars"`
+	Language   string    `json:"language,omitempty"`
+	LastCommit time.Time `json:"lastCommit,omitzero"`
+}
+
+// Repo returns the "owner/name" of a github.com repository URL, lower
+// cased, or false for any other URL.
+func Repo(rawURL string) (string, bool) {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") {
+		return "", false
+	}
+	if host := strings.ToLower(u.Host); host != "github.com" && host != "www.github.com" {
+		return "", false
+	}
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return "", false
+	}
+	name := strings.TrimSuffix(parts[1], ".git")
+	return strings.ToLower(parts[0] + "/" + name), true
+}
+
+// Fetcher refreshes the stats of a set of GitHub repositories on an
+// interval. It is safe for concurrent use; a nil Fetcher has no stats.
+type Fetcher struct {
+	token    string
+	path     string
+	interval time.Duration
+	API      string // base URL of the REST API
+	Timeout  time.Duration
+	Client   *http.Client // nil uses a client with Timeout
+
+	repos atomic.Pointer[[]string]
+	stats atomic.Pointer[map[string]Stats]
+}
+
+// NewFetcher returns a Fetcher that authenticates with token and caches
+// its results at path. Stats already cached there are available at once;
+// a missing or unreadable cache starts empty.
+func NewFetcher(token, path string, interval time.Duration) *Fetcher {
+	f := &Fetcher{token: token, path: path, interval: interval, API: DefaultAPI, Timeout: 10 * time.Second}
+	stats, err := readCache(path)
+	if err != nil {
+		slog.Warn("repo stats: ignoring cache", "path", path, "err", err)
+	}
+	f.stats.Store(&stats)
+	f.repos.Store(&[]string{})
+	return f
+}
+
+// readCache loads the stats saved by an earlier run. A missing file is
+// empty.
+func readCache(path string) (map[string]Stats, error) {
+	stats := map[string]Stats{}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return stats, nil
+	}
+	if err != nil {
+		return stats, err
+	}
+	if err := json.Unmarshal(data, &stats); err != nil {
+		return map[string]Stats{}, err
+	}
+	return stats, nil
+}
+
+// SetRepos replaces the repositories to refresh with the github.com URLs
+// among urls; others are ignored. They are fetched on the next round.
+func (f *Fetcher) SetRepos(urls []string) {
+	if f == nil {
+		return
+	}
+	var repos []string
+	seen := map[string]bool{}
+	for _, u := range urls {
+		if r, ok := Repo(u); ok && !seen[r] {
+			seen[r] = true
+			repos = append(repos, r)
+		}
+	}
+	f.repos.Store(&repos)
+}
+
+// Stats returns the latest stats for the repository at repoURL, or false
+// until it has been fetched.
+func (f *Fetcher) Stats(repoURL string) (Stats, bool) {
+	if f == nil {
+		return Stats{}, false
+	}
+	r, ok := Repo(repoURL)
+	if !ok {
+		return Stats{}, false
+	}
+	s, ok := (*f.stats.Load())[r]
+	return s, ok
+}
+
+// Run refreshes every repository immediately and then every interval,
+// until ctx is done.
+func (f *Fetcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(f.interval)
+	defer ticker.Stop()
+	for {
+		f.FetchAll(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// FetchAll refreshes every repository, publishes the results, and saves
+// them to the cache. A repository that fails keeps its previous stats.
+func (f *Fetcher) FetchAll(ctx context.Context) {
+	old := *f.stats.Load()
+	stats := make(map[string]Stats, len(old))
+	for _, repo := range *f.repos.Load() {
+		s, err := f.fetch(ctx, repo)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			slog.Warn("repo stats: fetch failed", "repo", repo, "err", err)
+			if prev, ok := old[repo]; ok {
+				stats[repo] = prev
+			}
+			continue
+		}
+		stats[repo] = s
+	}
+	f.stats.Store(&stats)
+	if err := writeCache(f.path, stats); err != nil {
+		slog.Warn("repo stats: saving cache", "path", f.path, "err", err)
+	}
+}
+
+// writeCache saves stats through a temporary file, so a crash mid-write
+// never leaves a truncated cache behind.
+func writeCache(path string, stats map[string]Stats) error {
+	data, err := json.MarshalIndent(stats, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".repo-stats-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// repoResponse holds the fields used from GET /repos/{owner}/{repo}.
+type repoResponse struct {
+	Stars    int    `json:"stargazers_count"`
+	Language string `json:"language"`
+}
+
+// commitResponse holds the fields used from each entry of
+// GET /repos/{owner}/{repo}/commits.
+type commitResponse struct {
+	Commit struct {
+		Committer struct {
+			Date time.Time `json:"date"`
+		} `json:"committer"`
+	} `json:"commit"`
+}
+
+// fetch requests the stats of one "owner/name" repository.
+func (f *Fetcher) fetch(ctx context.Context, repo string) (Stats, error) {
+	var info repoResponse
+	if err := f.get(ctx, "/repos/"+repo, &info); err != nil {
+		return Stats{}, err
+	}
+	var commits []commitResponse
+	if err := f.get(ctx, "/repos/"+repo+"/commits?per_page=1", &commits); err != nil {
+		return Stats{}, err
+	}
+	s := Stats{Stars: info.Stars, Language: info.Language}
+	if len(commits) > 0 {
+		s.LastCommit = commits[0].Commit.Committer.Date
+	}
+	return s, nil
+}
+
+// get decodes the JSON response to an API request for path into v.
+func (f *Fetcher) get(ctx context.Context, path string, v any) error {
+	ctx, cancel := context.WithTimeout(ctx, f.Timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(f.API, "/")+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if f.token != "" {
+		req.Header.Set("Authorization", "Bearer "+f.token)
+	}
+	client := f.Client
+	if client == nil {
+		client = &http.Client{Timeout: f.Timeout}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("github %s: %s", path, resp.Status)
+	}
+	return json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(v)
+}
@@ -0,0 +1,83 @@
+package repostats
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRepo(t *testing.T) {
+	for in, want := range map[string]string{
+		"https://github.com/buntingszn/Holler":      "buntingszn/holler",
+		"https://github.com/buntingszn/holler.git/": "buntingszn/holler",
+		"https://www.github.com/a/b/tree/main":      "a/b",
+	} {
+		if got, ok := Repo(in); !ok || got != want {
+			t.Errorf("Repo(%q) = %q, %v, want %q", in, got, ok, want)
+		}
+	}
+	for _, bad := range []string{"", "https://gitlab.com/a/b", "https://github.com/a", "git@github.com:a/b.git"} {
+		if got, ok := Repo(bad); ok {
+			t.Errorf("Repo(%q) = %q, want no repo", bad, got)
+		}
+	}
+}
+
+func TestFetcherFetchAll(t *testing.T) {
+	commit := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	broken := false
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/a/b", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			t.Errorf("Authorization = %q", r.Header.Get("Authorization"))
+		}
+		if broken {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.Write([]byte(`{"stargazers_count": 42, "language": "Go"}`))
+	})
+	mux.HandleFunc("/repos/a/b/commits", func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte(`[{"commit": {"committer": {"date": "2026-03-01T12:00:00Z"}}}]`))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), FileName)
+	f := NewFetcher("secret", path, time.Hour)
+	f.API = srv.URL
+	f.SetRepos([]string{"https://github.com/a/b", "https://github.com/A/b", "https://example.com"})
+	if _, ok := f.Stats("https://github.com/a/b"); ok {
+		t.Fatal("stats before the first fetch")
+	}
+
+	f.FetchAll(context.Background())
+	want := Stats{Stars: 42, Language: "Go", LastCommit: commit}
+	if got, ok := f.Stats("https://github.com/a/b"); !ok || got != want {
+		t.Fatalf("Stats = %+v, %v, want %+v", got, ok, want)
+	}
+
+	// A failed refresh keeps the last stats rather than dropping them.
+	broken = true
+	f.FetchAll(context.Background())
+	if got, ok := f.Stats("https://github.com/a/b"); !ok || got != want {
+		t.Errorf("after a failed fetch Stats = %+v, %v, want %+v", got, ok, want)
+	}
+
+	// A new fetcher starts from the cache.
+	cached := NewFetcher("secret", path, time.Hour)
+	if got, ok := cached.Stats("https://github.com/a/b"); !ok || got != want {
+		t.Errorf("cached Stats = %+v, %v, want %+v", got, ok, want)
+	}
+}
+
+func TestNilFetcher(t *testing.T) {
+	var f *Fetcher
+	f.SetRepos([]string{"https://github.com/a/b"})
+	if _, ok := f.Stats("https://github.com/a/b"); ok {
+		t.Error("a nil Fetcher should have no stats")
+	}
+}
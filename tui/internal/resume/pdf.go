@@ -0,0 +1,257 @@
+// Package resume renders the CV as a PDF document that visitors can take
+// with them. The PDF is written directly, using only the standard Helvetica
+// fonts every viewer provides, so no fonts are embedded and no renderer is
+// needed on the server.
+package resume
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/buntingszn/terminal-portfolio/tui/internal/content"
+)
+
+// FileName is the name the PDF is offered under.
+const FileName = "resume.pdf"
+
+// US Letter page geometry, in points.
+const (
+	pageWidth   = 612
+	pageHeight  = 792
+	margin      = 54
+	columnWidth = pageWidth - 2*margin
+)
+
+// Fonts, named as in each page's resource dictionary.
+const (
+	regular = "F1"
+	bold    = "F2"
+)
+
+// Gray levels for text.
+const (
+	ink   = 0.0
+	muted = 0.4
+)
+
+// layout places text top to bottom, starting new pages as it fills up.
+type layout struct {
+	pages []*bytes.Buffer
+	y     float64 // baseline of the last line drawn
+}
+
+func newLayout() *layout {
+	p := &layout{}
+	p.add()
+	return p
+}
+
+func (p *layout) add() {
+	p.pages = append(p.pages, &bytes.Buffer{})
+	p.y = pageHeight - margin
+}
+
+func (p *layout) cur() *bytes.Buffer { return p.pages[len(p.pages)-1] }
+
+// line advances one line of the given size, breaking the page when the line
+// would fall into the bottom margin, and returns its baseline.
+func (p *layout) line(size float64) float64 {
+	lead := size * 1.3
+	if p.y-lead < margin {
+		p.add()
+	}
+	p.y -= lead
+	return p.y
+}
+
+// space adds vertical space, dropped at the top of a page.
+func (p *layout) space(pt float64) {
+	if p.y < pageHeight-margin {
+		p.y -= pt
+	}
+}
+
+// text draws s with its baseline at y.
+func (p *layout) text(font string, size, gray, x, y float64, s string) {
+	fmt.Fprintf(p.cur(), "BT /%s %.1f Tf %.2f g %.2f %.2f Td %s Tj ET\n", font, size, gray, x, y, pdfString(s))
+}
+
+// rule draws a hairline across the text column just below y.
+func (p *layout) rule(y float64) {
+	fmt.Fprintf(p.cur(), "0.75 G 0.5 w %d %.2f m %d %.2f l S\n", margin, y-4, pageWidth-margin, y-4)
+}
+
+// paragraph draws s wrapped to width starting at x.
+func (p *layout) paragraph(size, gray, x, width float64, s string) {
+	for _, l := range wrap(s, size, width) {
+		p.text(regular, size, gray, x, p.line(size), l)
+	}
+}
+
+// wrap breaks s into lines no wider than width at size. A word too long for
+// a line gets a line of its own.
+func wrap(s string, size, width float64) []string {
+	var lines []string
+	var cur string
+	for _, word := range strings.Fields(s) {
+		next := word
+		if cur != "" {
+			next = cur + " " + word
+		}
+		if cur != "" && textWidth(next, size) > width {
+			lines = append(lines, cur)
+			next = word
+		}
+		cur = next
+	}
+	if cur != "" {
+		lines = append(lines, cur)
+	}
+	return lines
+}
+
+// PDF renders the CV in c as a PDF document.
+func PDF(c *content.Content) []byte {
+	cv := c.CV
+	p := newLayout()
+	const left = margin
+
+	p.text(bold, 20, ink, left, p.line(20), c.Meta.Name)
+	if c.Meta.Title != "" {
+		p.text(regular, 11, muted, left, p.line(11), c.Meta.Title)
+	}
+	var contact []string
+	for _, v := range []string{cv.Contact.Email, cv.Contact.Location, cv.Contact.Website} {
+		if v != "" {
+			contact = append(contact, v)
+		}
+	}
+	if len(contact) > 0 {
+		p.text(regular, 9.5, muted, left, p.line(9.5), strings.Join(contact, "  ·  "))
+	}
+	if cv.Summary != "" {
+		p.space(8)
+		p.paragraph(10, ink, left, columnWidth, cv.Summary)
+	}
+
+	heading := func(title string) {
+		p.space(14)
+		y := p.line(11)
+		p.text(bold, 11, ink, left, y, title)
+		p.rule(y)
+		p.space(4)
+	}
+	// dated draws a bold title with a date set flush right on the same line.
+	dated := func(title, date string) {
+		y := p.line(10.5)
+		p.text(bold, 10.5, ink, left, y, title)
+		if date != "" {
+			p.text(regular, 9.5, muted, pageWidth-margin-textWidth(date, 9.5), y, date)
+		}
+	}
+
+	if len(cv.Experience) > 0 {
+		heading("EXPERIENCE")
+		for i, exp := range cv.Experience {
+			if i > 0 {
+				p.space(6)
+			}
+			dates := exp.Start
+			if exp.End != "" {
+				dates += " – " + exp.End
+			}
+			dated(exp.Role+"  ·  "+exp.Company, dates)
+			for _, bullet := range exp.Bullets {
+				for j, l := range wrap(bullet, 10, columnWidth-18) {
+					y := p.line(10)
+					if j == 0 {
+						p.text(regular, 10, ink, left+6, y, "•")
+					}
+					p.text(regular, 10, ink, left+18, y, l)
+				}
+			}
+		}
+	}
+
+	if len(cv.Skills) > 0 {
+		heading("SKILLS")
+		col := 0.0
+		for _, sk := range cv.Skills {
+			col = max(col, textWidth(sk.Category, 10)*boldFactor)
+		}
+		col = min(col+12, columnWidth/3)
+		for _, sk := range cv.Skills {
+			lines := wrap(strings.Join(sk.Items, ", "), 10, columnWidth-col)
+			for j, l := range lines {
+				y := p.line(10)
+				if j == 0 {
+					p.text(bold, 10, ink, left, y, sk.Category)
+				}
+				p.text(regular, 10, ink, left+col, y, l)
+			}
+		}
+	}
+
+	if len(cv.Education) > 0 {
+		heading("EDUCATION")
+		for _, edu := range cv.Education {
+			dated(edu.Degree, edu.Year)
+			p.text(regular, 10, muted, left, p.line(10), edu.Institution)
+		}
+	}
+
+	return p.document(c.Meta.Name)
+}
+
+// document assembles the pages into a complete PDF file titled title.
+func (p *layout) document(title string) []byte {
+	var out bytes.Buffer
+	var offsets []int
+	obj := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	// Objects 1-4 are fixed; each page then takes a page object and a
+	// content stream.
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	obj("<< /Type /Catalog /Pages 2 0 R >>")
+	kids := make([]string, len(p.pages))
+	for i := range p.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 6+2*i)
+	}
+	obj(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(p.pages)))
+	obj("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	obj("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	obj(fmt.Sprintf("<< /Title %s >>", pdfString(title)))
+	for i, content := range p.pages {
+		obj(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] "+
+			"/Resources << /Font << /%s 3 0 R /%s 4 0 R >> >> /Contents %d 0 R >>",
+			pageWidth, pageHeight, regular, bold, 7+2*i))
+		obj(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", content.Len(), content.Bytes()))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R /Info 5 0 R >>\nstartxref\n%d\n%%%%EOF\n",
+		len(offsets)+1, xref)
+	return out.Bytes()
+}
+
+// pdfString returns s as a PDF literal string.
+func pdfString(s string) string {
+	var b strings.Builder
+	b.WriteByte('(')
+	for _, c := range encode(s) {
+		if c == '(' || c == ')' || c == '\\' {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(c)
+	}
+	b.WriteByte(')')
+	return b.String()
+}
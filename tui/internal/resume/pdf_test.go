@@ -0,0 +1,94 @@
+package resume
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/buntingszn/terminal-portfolio/tui/internal/testutil"
+)
+
+// checkXref verifies that every cross-reference entry points at the start
+// of its object and that startxref points at the table.
+func checkXref(t *testing.T, doc []byte) {
+	t.Helper()
+	m := regexp.MustCompile(`startxref\n(\d+)\n%%EOF\n$`).FindSubmatch(doc)
+	if m == nil {
+		t.Fatal("missing startxref trailer")
+	}
+	xref, _ := strconv.Atoi(string(m[1]))
+	if !bytes.HasPrefix(doc[xref:], []byte("xref\n")) {
+		t.Fatalf("startxref %d does not point at the xref table", xref)
+	}
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(doc[xref:], -1)
+	if len(entries) == 0 {
+		t.Fatal("xref table has no entries")
+	}
+	for i, e := range entries {
+		off, _ := strconv.Atoi(string(e[1]))
+		want := fmt.Sprintf("%d 0 obj\n", i+1)
+		if !bytes.HasPrefix(doc[off:], []byte(want)) {
+			t.Errorf("xref entry %d points at %q", i+1, doc[off:min(off+12, len(doc))])
+		}
+	}
+}
+
+func TestPDF(t *testing.T) {
+	c := testutil.FixtureContent()
+	doc := PDF(c)
+	if !bytes.HasPrefix(doc, []byte("%PDF-1.4\n")) {
+		t.Fatalf("missing PDF header: %q", doc[:min(16, len(doc))])
+	}
+	checkXref(t, doc)
+
+	for _, want := range []string{"(" + c.Meta.Name + ")", "(EXPERIENCE)", "(SKILLS)", "(EDUCATION)"} {
+		if !bytes.Contains(doc, []byte(want)) {
+			t.Errorf("PDF missing %s", want)
+		}
+	}
+	if !bytes.Equal(doc, PDF(c)) {
+		t.Error("rendering the same content twice should give the same bytes")
+	}
+}
+
+func TestPDFBreaksPages(t *testing.T) {
+	c := testutil.FixtureContent()
+	exp := c.CV.Experience[0]
+	exp.Bullets = []string{strings.Repeat("a long accomplishment ", 40)}
+	for range 30 {
+		c.CV.Experience = append(c.CV.Experience, exp)
+	}
+	doc := PDF(c)
+	checkXref(t, doc)
+	n := bytes.Count(doc, []byte("/Type /Page "))
+	if n < 2 {
+		t.Fatalf("long CV should span several pages, got %d", n)
+	}
+	if !bytes.Contains(doc, []byte(fmt.Sprintf("/Count %d", n))) {
+		t.Error("page tree count does not match the pages written")
+	}
+}
+
+func TestWrap(t *testing.T) {
+	lines := wrap("the quick brown fox jumps over the lazy dog", 10, 60)
+	if len(lines) < 2 {
+		t.Fatalf("text should wrap, got %q", lines)
+	}
+	for _, l := range lines {
+		if textWidth(l, 10) > 60 && strings.Contains(l, " ") {
+			t.Errorf("line %q is wider than 60pt", l)
+		}
+	}
+	if got := strings.Join(lines, " "); got != "the quick brown fox jumps over the lazy dog" {
+		t.Errorf("wrapping lost words: %q", got)
+	}
+}
+
+func TestPDFStringEscapes(t *testing.T) {
+	if got := pdfString(`a (b) \ c – ☃`); got != "(a \\(b\\) \\\\ c \x96 ?)" {
+		t.Errorf("pdfString = %q", got)
+	}
+}
@@ -0,0 +1,111 @@
+package server
+
+import (
+	"io"
+	"sync"
+
+	"github.com/charmbracelet/ssh"
+	"github.com/charmbracelet/wish"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/app"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/i18n"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/textmode"
+)
+
+// A11yUser is the SSH user name that starts a session in accessible text
+// mode, as in ssh a11y@host.
+const A11yUser = app.A11yUser
+
+// a11ySwitchKey is the session context key of a TUI session's a11ySwitch.
+type a11ySwitchKey struct{}
+
+// a11ySwitch lets a TUI session hand itself over to accessible text mode
+// with :a11y. The program reads the session through it, so that input
+// typed after the switch reaches the text mode rather than the program's
+// last pending read.
+//
+// The TUI session records its variants on the switch, and start records
+// the locale it was in, so the text mode carries on with the same copy.
+type a11ySwitch struct {
+	in   *sessionInput
+	once sync.Once
+	done chan struct{} // closed once the visitor switches
+
+	locale   string
+	variants map[string]string
+}
+
+func newA11ySwitch(in *sessionInput) *a11ySwitch {
+	return &a11ySwitch{in: in, done: make(chan struct{})}
+}
+
+// start switches the session in the locale tagged locale; the program's
+// reads end at once.
+func (a *a11ySwitch) start(locale string) {
+	a.once.Do(func() {
+		a.locale = locale
+		close(a.done)
+	})
+}
+
+// started reports whether the visitor switched.
+func (a *a11ySwitch) started() bool {
+	select {
+	case <-a.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// Read implements io.Reader for the program.
+func (a *a11ySwitch) Read(p []byte) (int, error) {
+	return a.in.readUntil(p, a.done)
+}
+
+// a11yMiddleware serves ssh a11y@host sessions, and TUI sessions once the
+// visitor picks :a11y, with textmode.Browse: linear plain text with
+// prompts, for screen readers and braille terminals. The TUI has left
+// the alternate screen and turned off the mouse by the time it starts.
+func (s *SSHServer) a11yMiddleware() wish.Middleware {
+	return func(next ssh.Handler) ssh.Handler {
+		return func(sess ssh.Session) {
+			in := newSessionInput(sess.Context(), sess)
+			tag, variants := i18n.DefaultTag, map[string]string(nil)
+			if sess.User() != A11yUser {
+				sw := newA11ySwitch(in)
+				sess.Context().SetValue(a11ySwitchKey{}, sw)
+				next(sess)
+				if !sw.started() {
+					return
+				}
+				tag, variants = sw.locale, sw.variants
+			}
+			s.browse(sess, in, tag, variants)
+		}
+	}
+}
+
+// browse runs textmode.Browse on the session until the visitor leaves,
+// showing the content in the locale tagged tag with the given variants.
+// Without variants the session is assigned its own.
+func (s *SSHServer) browse(sess ssh.Session, in io.Reader, tag string, variants map[string]string) {
+	var out io.Writer = sess
+	_, _, echo := sess.Pty()
+	if echo {
+		// As for commands, a terminal gets no newline translation.
+		out = crlfWriter{sess}
+	}
+	snap := s.current.Load()
+	if variants == nil {
+		variants = sessionVariants(snap.content, tag)
+	}
+	err := textmode.Browse(in, out, textmode.Source{
+		Content:   snap.content.Localized(tag).WithVariants(variants),
+		Guestbook: s.guestbook,
+		Proof:     snap.proof,
+	}, echo)
+	if err != nil && sess.Context().Err() == nil {
+		s.sessionLog(sess).logger.Warn("accessible session ended", "err", err)
+	}
+	_ = sess.Exit(0)
+}
@@ -0,0 +1,180 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/ssh"
+
+	"github.com/buntingszn/terminal-portfolio/tui/internal/analytics"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/app/sections"
+)
+
+// adminDays is how many daily summaries the admin section shows, today
+// included.
+const adminDays = 7
+
+// trackSession lists sess as live until the returned func is called.
+func (s *SSHServer) trackSession(sess ssh.Session) (untrack func()) {
+	s.liveMu.Lock()
+	defer s.liveMu.Unlock()
+	if s.live == nil {
+		s.live = map[ssh.Session]sections.AdminSession{}
+	}
+	s.live[sess] = sections.AdminSession{User: sess.User(), IP: clientIP(sess), Started: time.Now()}
+	return func() {
+		s.liveMu.Lock()
+		defer s.liveMu.Unlock()
+		delete(s.live, sess)
+	}
+}
+
+// adminSource implements sections.AdminSource for owner sessions.
+type adminSource struct {
+	s *SSHServer
+}
+
+// Sessions implements sections.AdminSource.
+func (a adminSource) Sessions() []sections.AdminSession {
+	a.s.liveMu.Lock()
+	live := make([]sections.AdminSession, 0, len(a.s.live))
+	for _, sess := range a.s.live {
+		live = append(live, sess)
+	}
+	a.s.liveMu.Unlock()
+	slices.SortFunc(live, func(x, y sections.AdminSession) int { return x.Started.Compare(y.Started) })
+	return live
+}
+
+// Analytics implements sections.AdminSource, reading the events once for
+// both the summaries and the journeys. It returns nil for both when
+// analytics are disabled.
+func (a adminSource) Analytics(now time.Time) ([]analytics.Summary, []analytics.Journey, error) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	first := today.AddDate(0, 0, 1-adminDays)
+	events, ok, err := a.events(first)
+	if !ok || err != nil {
+		return nil, nil, err
+	}
+	summaries := make([]analytics.Summary, 0, adminDays)
+	for day := today; !day.Before(first); day = day.AddDate(0, 0, -1) {
+		summaries = append(summaries, analytics.Summarize(events, day, day.AddDate(0, 0, 1)))
+	}
+	return summaries, analytics.Journeys(events), nil
+}
+
+// events reads the analytics events logged from first on. ok is false
+// when analytics are disabled.
+func (a adminSource) events(first time.Time) (events []analytics.Event, ok bool, err error) {
+	switch sink := a.s.analytics.(type) {
+	case *analytics.Store:
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		events, err = sink.Events(ctx, first, time.Time{})
+		return events, true, err
+	case *analytics.Logger:
+		if events, err = analytics.ReadLog(a.s.cfg.Load().AnalyticsFile); err != nil {
+			return nil, true, err
+		}
+		return slices.DeleteFunc(events, func(e analytics.Event) bool {
+			return e.Timestamp.Before(first)
+		}), true, nil
+	}
+	return nil, false, nil
+}
+
+// Settings implements sections.AdminSource. Webhook URLs and SMTP
+// credentials are secrets, so only whether they are set is shown.
+func (a adminSource) Settings() []sections.AdminSetting {
+	cfg := a.s.cfg.Load()
+	onOff := func(on bool) string {
+		if on {
+			return "on"
+		}
+		return "off"
+	}
+	set := func(v string) string {
+		if v == "" {
+			return "not set"
+		}
+		return "set"
+	}
+
+	analyticsTo := "off"
+	switch {
+	case cfg.AnalyticsDSN != "":
+		analyticsTo = "sqlite " + redacted(cfg.AnalyticsDSN)
+	case cfg.AnalyticsFile != "":
+		analyticsTo = fmt.Sprintf("%s (rotate %s, %d MB, keep %d days)", cfg.AnalyticsFile,
+			cfg.AnalyticsRotate, cfg.AnalyticsMaxSizeMB, cfg.AnalyticsRetentionDays)
+	}
+	unavailable := "none"
+	if files := a.s.current.Load().content.UnavailableFiles(); len(files) > 0 {
+		unavailable = strings.Join(files, ", ")
+	}
+	rateLimit := "off"
+	if cfg.RateLimit > 0 {
+		rateLimit = fmt.Sprintf("%d per %s", cfg.RateLimit, cfg.RateWindow)
+	}
+	autoBan := "off"
+	if a.s.bans != nil {
+		autoBan = fmt.Sprintf("after %d refusals in %s, %d banned", cfg.AutoBanStrikes, cfg.AutoBanWindow, a.s.bans.count(time.Now()))
+	}
+	email := "not set"
+	if cfg.SummaryEmail != "" {
+		email = cfg.SummaryEmail
+		if u, err := url.Parse(cfg.SMTPURL); err == nil && u.Host != "" {
+			email += " via " + u.Host
+		}
+	}
+
+	return []sections.AdminSetting{
+		{Name: "Address", Value: fmt.Sprintf("%s:%d", cfg.SSHHost, cfg.SSHPort)},
+		{Name: "Data", Value: redacted(cfg.DataDir)},
+		{Name: "Unavailable content", Value: unavailable},
+		{Name: "Sessions", Value: fmt.Sprintf("%d of %d", a.s.ActiveSessions(), cfg.MaxSessions)},
+		{Name: "Rate limit", Value: rateLimit},
+		{Name: "IP filter", Value: a.s.filter.Load().String()},
+		{Name: "Refused connections", Value: a.s.refused.String()},
+		{Name: "Auto-ban", Value: autoBan},
+		{Name: "Idle timeout", Value: cfg.IdleTimeout.String()},
+		{Name: "Screensaver after", Value: cfg.ScreensaverAfter.String()},
+		{Name: "Drain timeout", Value: cfg.DrainTimeout.String()},
+		{Name: "Resume window", Value: cfg.ResumeWindow.String()},
+		{Name: "Analytics", Value: analyticsTo},
+		{Name: "Summary", Value: cfg.Summary},
+		{Name: "Summary webhook", Value: set(cfg.SummaryWebhook)},
+		{Name: "Summary email", Value: email},
+		{Name: "Status", Value: cfg.Status},
+		{Name: "Booking preview", Value: set(cfg.BookingPreviewURL)},
+		{Name: "GitHub token", Value: set(cfg.GitHubToken)},
+		{Name: "Auth", Value: cfg.Auth},
+		{Name: "Owner keys", Value: strconv.Itoa(len(a.s.ownerKeys))},
+		{Name: "Verify key", Value: set(cfg.VerifyKey)},
+		{Name: "Theme", Value: cfg.Theme},
+		{Name: "Graphics", Value: cfg.Graphics},
+		{Name: "Nav wrap", Value: onOff(cfg.NavWrap)},
+		{Name: "Reorder RTL", Value: onOff(cfg.ReorderRTL)},
+		{Name: "Content review", Value: onOff(cfg.ContentReview)},
+		{Name: "Bell", Value: onOff(cfg.Bell)},
+		{Name: "Debug", Value: onOff(cfg.Debug)},
+		{Name: "Log level", Value: cfg.LogLevel},
+	}
+}
+
+// redacted drops the credentials from a URL-shaped setting such as a
+// remote data source, since a token may stand in for the user name.
+// Anything else is returned unchanged.
+func redacted(v string) string {
+	u, err := url.Parse(v)
+	if err != nil || u.User == nil {
+		return v
+	}
+	u.User = nil
+	return u.String()
+}
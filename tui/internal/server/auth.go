@@ -0,0 +1,176 @@
+package server
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+	"log/slog"
+	"net"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/ssh"
+	"github.com/charmbracelet/wish"
+	gossh "golang.org/x/crypto/ssh"
+
+	"github.com/buntingszn/terminal-portfolio/tui/internal/config"
+)
+
+// privateAuth returns the server options for the "keys" and "password"
+// auth modes, which let in only clients with an authorized key or the
+// shared password. Owner keys always pass. With the password mode, other
+// keys are turned down quietly, since clients offer every key they have
+// before the password.
+//
+// The key handler also answers clients asking whether a key would do,
+// before they prove they hold it, so a key's failures are only forgotten
+// once its session starts; see authSucceeded.
+func (s *SSHServer) privateAuth(cfg *config.Config) ([]ssh.Option, error) {
+	keys := s.ownerKeys
+	if cfg.Auth == "keys" {
+		authorized, err := loadKeys("authorized keys", cfg.AuthorizedKeys)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(slices.Clone(s.ownerKeys), authorized...)
+	}
+	if cfg.AuthMaxFailures > 0 {
+		s.lockout = newAuthLockout(cfg.AuthMaxFailures, cfg.AuthLockout)
+	}
+
+	keyLevel := slog.LevelWarn
+	if cfg.Auth == "password" {
+		keyLevel = slog.LevelDebug
+	}
+	opts := []ssh.Option{
+		wish.WithPublicKeyAuth(func(ctx ssh.Context, key ssh.PublicKey) bool {
+			if ownerKey(keys, key) {
+				return true
+			}
+			s.authFailed(ctx, keyLevel, "publickey", "fingerprint", gossh.FingerprintSHA256(key))
+			return false
+		}),
+		func(srv *ssh.Server) error {
+			srv.ConnectionFailedCallback = s.connFailed
+			return nil
+		},
+	}
+	if cfg.Auth == "password" {
+		want := sha256.Sum256([]byte(cfg.AuthPassword))
+		check := func(ctx ssh.Context, password string) bool {
+			// Comparing digests takes the same time whatever the length
+			// of the guess.
+			got := sha256.Sum256([]byte(password))
+			if subtle.ConstantTimeCompare(got[:], want[:]) == 1 {
+				s.authSucceeded(ctx)
+				return true
+			}
+			s.authFailed(ctx, slog.LevelWarn, "password")
+			return false
+		}
+		opts = append(opts,
+			wish.WithPasswordAuth(check),
+			wish.WithKeyboardInteractiveAuth(func(ctx ssh.Context, challenge gossh.KeyboardInteractiveChallenge) bool {
+				answers, err := challenge("", "", []string{"Password: "}, []bool{false})
+				return err == nil && len(answers) == 1 && check(ctx, answers[0])
+			}),
+		)
+	}
+	return opts, nil
+}
+
+// authFailed logs a rejected sign-in attempt.
+func (s *SSHServer) authFailed(ctx ssh.Context, level slog.Level, method string, args ...any) {
+	args = append([]any{
+		"method", method,
+		"user", ctx.User(),
+		"remote_addr", ctx.RemoteAddr().String(),
+		"ip", addrIP(ctx.RemoteAddr().String()),
+	}, args...)
+	s.logger.Log(ctx, level, "SSH authentication failed", args...)
+}
+
+// authSucceeded forgets the client IP's failed sign-ins. It is called once
+// the client has signed in: for a password as it is checked, and for a key
+// as its session starts, when the key's signature has been verified.
+func (s *SSHServer) authSucceeded(ctx ssh.Context) {
+	if s.lockout != nil {
+		s.lockout.succeed(addrIP(ctx.RemoteAddr().String()))
+	}
+}
+
+// connFailed counts a connection that never signed in against its IP,
+// locking the IP out once it has failed too often.
+func (s *SSHServer) connFailed(conn net.Conn, err error) {
+	var authErr *gossh.ServerAuthError
+	if s.lockout == nil || !errors.As(err, &authErr) {
+		return
+	}
+	ip := addrIP(conn.RemoteAddr().String())
+	if n, locked := s.lockout.fail(ip, time.Now()); locked {
+		s.logger.Warn("SSH client locked out", "ip", ip, "failures", n, "lockout", s.lockout.period)
+	}
+}
+
+// authLockout counts the failed sign-ins of each client IP and locks an
+// IP out once it reaches max, until period has passed since its last
+// failure. It is safe for concurrent use.
+type authLockout struct {
+	mu     sync.Mutex
+	max    int
+	period time.Duration
+	ips    map[string]*authFailures
+}
+
+// authFailures are the failed sign-ins of one IP.
+type authFailures struct {
+	count int
+	last  time.Time
+}
+
+// lockoutPrune is how many IPs authLockout tracks before it forgets those
+// whose failures have expired.
+const lockoutPrune = 1024
+
+func newAuthLockout(max int, period time.Duration) *authLockout {
+	return &authLockout{max: max, period: period, ips: make(map[string]*authFailures)}
+}
+
+// locked reports whether ip is locked out at now.
+func (l *authLockout) locked(ip string, now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	f, ok := l.ips[ip]
+	return ok && f.count >= l.max && now.Sub(f.last) < l.period
+}
+
+// fail records a failed sign-in from ip at now, and returns the IP's
+// failures and whether this one locked it out. Failures more than period
+// apart start the count again.
+func (l *authLockout) fail(ip string, now time.Time) (int, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(l.ips) >= lockoutPrune {
+		for k, f := range l.ips {
+			if now.Sub(f.last) >= l.period {
+				delete(l.ips, k)
+			}
+		}
+	}
+	f, ok := l.ips[ip]
+	if !ok || now.Sub(f.last) >= l.period {
+		f = &authFailures{}
+		l.ips[ip] = f
+	}
+	f.count++
+	f.last = now
+	return f.count, f.count == l.max
+}
+
+// succeed forgets ip's failed sign-ins.
+func (l *authLockout) succeed(ip string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.ips, ip)
+}
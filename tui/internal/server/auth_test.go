@@ -0,0 +1,211 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	gossh "golang.org/x/crypto/ssh"
+
+	"github.com/buntingszn/terminal-portfolio/tui/internal/config"
+)
+
+// signIn runs the links command with auth and reports whether the server
+// let the client in.
+func signIn(t *testing.T, port int, auth ...gossh.AuthMethod) bool {
+	t.Helper()
+	cfg := sshClientConfig()
+	cfg.Auth = auth
+	client, err := gossh.Dial("tcp", fmt.Sprintf("127.0.0.1:%d", port), cfg)
+	if err != nil {
+		return false
+	}
+	defer func() { _ = client.Close() }()
+	sess, err := client.NewSession()
+	if err != nil {
+		t.Fatalf("failed to open session: %v", err)
+	}
+	defer func() { _ = sess.Close() }()
+	if _, err := sess.Output("links"); err != nil {
+		t.Fatalf("links failed after signing in: %v", err)
+	}
+	return true
+}
+
+// answer is a keyboard-interactive client that answers every question
+// with password.
+func answer(password string) gossh.AuthMethod {
+	return gossh.KeyboardInteractive(func(_, _ string, questions []string, _ []bool) ([]string, error) {
+		answers := make([]string, len(questions))
+		for i := range answers {
+			answers[i] = password
+		}
+		return answers, nil
+	})
+}
+
+func TestSSHServer_AuthKeys(t *testing.T) {
+	allowedSigner, allowedKey := testSigner(t)
+	ownerSigner, ownerKey := testSigner(t)
+	otherSigner, _ := testSigner(t)
+	dir := t.TempDir()
+	keysPath := filepath.Join(dir, "authorized_keys")
+	ownerPath := filepath.Join(dir, "owner_keys")
+	if err := os.WriteFile(keysPath, gossh.MarshalAuthorizedKey(allowedKey), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(ownerPath, gossh.MarshalAuthorizedKey(ownerKey), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	_, port := startConfiguredServer(t, 10, func(cfg *config.Config) {
+		cfg.Auth = "keys"
+		cfg.AuthorizedKeys = keysPath
+		cfg.OwnerKeys = ownerPath
+	})
+
+	tests := []struct {
+		name string
+		auth []gossh.AuthMethod
+		want bool
+	}{
+		{"authorized key", []gossh.AuthMethod{gossh.PublicKeys(otherSigner, allowedSigner)}, true},
+		{"owner key", []gossh.AuthMethod{gossh.PublicKeys(ownerSigner)}, true},
+		{"other key", []gossh.AuthMethod{gossh.PublicKeys(otherSigner), gossh.Password("")}, false},
+		{"no key", []gossh.AuthMethod{gossh.Password(""), answer("")}, false},
+	}
+	for _, tt := range tests {
+		if got := signIn(t, port, tt.auth...); got != tt.want {
+			t.Errorf("%s: signed in = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSSHServer_AuthPassword(t *testing.T) {
+	otherSigner, _ := testSigner(t)
+	srv, port := startConfiguredServer(t, 10, func(cfg *config.Config) {
+		cfg.Auth = "password"
+		cfg.AuthPassword = "hunter2"
+		cfg.AuthMaxFailures = 2
+		cfg.AuthLockout = time.Hour
+	})
+
+	if !signIn(t, port, gossh.PublicKeys(otherSigner), gossh.Password("hunter2")) {
+		t.Error("the password should sign in after an unknown key")
+	}
+	if !signIn(t, port, answer("hunter2")) {
+		t.Error("the password should sign in through keyboard-interactive")
+	}
+
+	// Two failed sign-ins lock the IP out, even for the right password.
+	// The server counts a failure once the client hangs up.
+	for i := range 2 {
+		if signIn(t, port, gossh.Password("hunter3")) {
+			t.Fatalf("attempt %d: a wrong password signed in", i+1)
+		}
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for !srv.lockout.locked("127.0.0.1", time.Now()) {
+		if time.Now().After(deadline) {
+			t.Fatal("two failed sign-ins should lock the IP out")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if signIn(t, port, gossh.Password("hunter2")) {
+		t.Error("a locked out IP should be turned away")
+	}
+}
+
+// TestSSHServer_AuthKeyQuery verifies that offering an owner key without
+// signing with it, as anyone can since owner keys are public, does not
+// forget the IP's failed passwords.
+func TestSSHServer_AuthKeyQuery(t *testing.T) {
+	ownerSigner, ownerKey := testSigner(t)
+	ownerPath := filepath.Join(t.TempDir(), "owner_keys")
+	if err := os.WriteFile(ownerPath, gossh.MarshalAuthorizedKey(ownerKey), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	srv, port := startConfiguredServer(t, 10, func(cfg *config.Config) {
+		cfg.Auth = "password"
+		cfg.AuthPassword = "hunter2"
+		cfg.OwnerKeys = ownerPath
+		cfg.AuthMaxFailures = 2
+		cfg.AuthLockout = time.Hour
+	})
+	failures := func() int {
+		srv.lockout.mu.Lock()
+		defer srv.lockout.mu.Unlock()
+		if f, ok := srv.lockout.ips["127.0.0.1"]; ok {
+			return f.count
+		}
+		return 0
+	}
+	// failPassword signs in with a wrong password and waits for the server
+	// to count it, which it does once the client hangs up.
+	failPassword := func(want int) {
+		t.Helper()
+		if signIn(t, port, gossh.Password("hunter3")) {
+			t.Fatal("a wrong password signed in")
+		}
+		deadline := time.Now().Add(5 * time.Second)
+		for failures() < want {
+			if time.Now().After(deadline) {
+				t.Fatalf("failures = %d, want %d", failures(), want)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	failPassword(1)
+	// The query a client sends before signing with a key.
+	ctx := &webContext{Context: context.Background(), remote: webAddr("127.0.0.1:40000"), values: map[any]any{}}
+	if !srv.server.PublicKeyHandler(ctx, ownerKey) {
+		t.Fatal("the owner key should be accepted")
+	}
+	failPassword(2)
+	if !srv.lockout.locked("127.0.0.1", time.Now()) {
+		t.Error("a key query between wrong passwords should not stop the lockout")
+	}
+
+	// Signing in with the key does forget the failures.
+	srv.lockout.succeed("127.0.0.1")
+	failPassword(1)
+	if !signIn(t, port, gossh.PublicKeys(ownerSigner)) {
+		t.Fatal("the owner key should sign in")
+	}
+	if n := failures(); n != 0 {
+		t.Errorf("failures after a key sign-in = %d, want 0", n)
+	}
+}
+
+func TestAuthLockout(t *testing.T) {
+	l := newAuthLockout(3, time.Minute)
+	now := time.Now()
+
+	for i := range 2 {
+		if _, locked := l.fail("1.1.1.1", now); locked {
+			t.Fatalf("failure %d should not lock out", i+1)
+		}
+	}
+	l.succeed("1.1.1.1")
+	l.fail("1.1.1.1", now)
+	if l.locked("1.1.1.1", now) {
+		t.Error("signing in should forget earlier failures")
+	}
+
+	l.fail("1.1.1.1", now)
+	if n, locked := l.fail("1.1.1.1", now); !locked || n != 3 {
+		t.Errorf("third failure = %d, %v; want a lockout", n, locked)
+	}
+	if !l.locked("1.1.1.1", now.Add(59*time.Second)) || l.locked("2.2.2.2", now) {
+		t.Error("only the failing IP should be locked out")
+	}
+	if l.locked("1.1.1.1", now.Add(time.Minute)) {
+		t.Error("the lockout should end after its period")
+	}
+	if n, _ := l.fail("1.1.1.1", now.Add(2*time.Minute)); n != 1 {
+		t.Errorf("a failure after the period counts %d, want a fresh count", n)
+	}
+}
@@ -0,0 +1,255 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/buntingszn/terminal-portfolio/tui/internal/config"
+)
+
+// bansFileName is the file in the state directory that keeps auto-bans
+// across restarts.
+const bansFileName = "bans.json"
+
+// banMemory is how long an IP's past bans are remembered after the last
+// one ends, so an IP that comes back to hammer the server is banned for
+// longer still.
+const banMemory = 7 * 24 * time.Hour
+
+// banStore bans the IPs the rate limiter keeps refusing. An IP refused
+// strikes times within window is banned for base, and each later ban
+// lasts twice the one before, up to max. Bans are saved to path as they
+// change, while the refusals counting towards one are not. It is safe for
+// concurrent use.
+type banStore struct {
+	mu                sync.Mutex
+	path              string
+	strikes           int
+	window, base, max time.Duration
+	hits              map[string][]time.Time // recent refusals by IP
+	bans              map[string]*ban
+}
+
+// ban is the saved record of an IP's bans.
+type ban struct {
+	Until time.Time `json:"until"`
+	Count int       `json:"count"`
+}
+
+// bannedIP is a ban in effect, as listed to the owner.
+type bannedIP struct {
+	IP    string
+	Until time.Time
+	Count int
+}
+
+// openBanStore returns the ban store cfg sets up, with the bans saved at
+// path, or nil when auto-bans are off. A missing file starts with no
+// bans.
+func openBanStore(path string, cfg *config.Config) (*banStore, error) {
+	if cfg.AutoBanStrikes <= 0 {
+		return nil, nil
+	}
+	b := &banStore{
+		path:    path,
+		strikes: cfg.AutoBanStrikes,
+		window:  cfg.AutoBanWindow,
+		base:    cfg.AutoBanDuration,
+		max:     cfg.AutoBanMax,
+		hits:    make(map[string][]time.Time),
+		bans:    make(map[string]*ban),
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return b, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read bans: %w", err)
+	}
+	if err := json.Unmarshal(data, &b.bans); err != nil {
+		return nil, fmt.Errorf("parse bans %s: %w", path, err)
+	}
+	b.forget(time.Now())
+	return b, nil
+}
+
+// banned reports whether ip is banned at now. A nil store bans no one.
+func (b *banStore) banned(ip string, now time.Time) bool {
+	if b == nil {
+		return false
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	r, ok := b.bans[ip]
+	return ok && now.Before(r.Until)
+}
+
+// strike records a rate limit refusal of ip at now. When it bans the IP,
+// it returns the ban and the error saving it, if any.
+func (b *banStore) strike(ip string, now time.Time) (*bannedIP, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if len(b.hits) >= lockoutPrune {
+		for k, hits := range b.hits {
+			if now.Sub(hits[len(hits)-1]) >= b.window {
+				delete(b.hits, k)
+			}
+		}
+	}
+	hits := slices.DeleteFunc(b.hits[ip], func(t time.Time) bool { return now.Sub(t) >= b.window })
+	hits = append(hits, now)
+	if len(hits) < b.strikes {
+		b.hits[ip] = hits
+		return nil, nil
+	}
+	delete(b.hits, ip)
+
+	r, ok := b.bans[ip]
+	if !ok || now.Sub(r.Until) >= banMemory {
+		r = &ban{}
+		b.bans[ip] = r
+	}
+	r.Count++
+	r.Until = now.Add(b.length(r.Count))
+	return &bannedIP{IP: ip, Until: r.Until, Count: r.Count}, b.save(now)
+}
+
+// length returns how long the nth ban of an IP lasts.
+func (b *banStore) length(n int) time.Duration {
+	d := b.base
+	for i := 1; i < n && d < b.max; i++ {
+		d *= 2
+	}
+	return min(d, b.max)
+}
+
+// list returns the bans in effect at now, ending soonest first.
+func (b *banStore) list(now time.Time) []bannedIP {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var list []bannedIP
+	for ip, r := range b.bans {
+		if now.Before(r.Until) {
+			list = append(list, bannedIP{IP: ip, Until: r.Until, Count: r.Count})
+		}
+	}
+	slices.SortFunc(list, func(a, b bannedIP) int { return a.Until.Compare(b.Until) })
+	return list
+}
+
+// unban lifts ip's ban and forgets its past ones, reporting whether it
+// was banned.
+func (b *banStore) unban(ip string, now time.Time) (bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if r, ok := b.bans[ip]; !ok || !now.Before(r.Until) {
+		return false, nil
+	}
+	delete(b.bans, ip)
+	delete(b.hits, ip)
+	return true, b.save(now)
+}
+
+// count returns how many bans are in effect at now, for the admin
+// section.
+func (b *banStore) count(now time.Time) int {
+	return len(b.list(now))
+}
+
+// forget drops the records of IPs whose last ban ended more than
+// banMemory before now. The caller holds b.mu or has the only reference.
+func (b *banStore) forget(now time.Time) {
+	for ip, r := range b.bans {
+		if now.Sub(r.Until) >= banMemory {
+			delete(b.bans, ip)
+		}
+	}
+}
+
+// save writes the bans out. The caller holds b.mu.
+func (b *banStore) save(now time.Time) error {
+	b.forget(now)
+	return saveJSON(b.path, b.bans)
+}
+
+// saveJSON writes v to path as JSON through a temporary file, so a crash
+// mid-write never leaves a truncated file behind.
+func saveJSON(path string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+"-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// banRefused counts a rate limit refusal of ip towards a ban, and logs
+// the ban it brings.
+func (s *SSHServer) banRefused(ip string) {
+	if s.bans == nil {
+		return
+	}
+	r, err := s.bans.strike(ip, time.Now())
+	if r == nil {
+		return
+	}
+	s.logger.Warn("SSH client auto-banned", "ip", ip, "until", r.Until.Format(time.RFC3339), "bans", r.Count)
+	if err != nil {
+		s.logger.Error("failed to save bans", "path", s.bans.path, "err", err)
+	}
+}
+
+// banCommands are the owner's commands for auto-bans: `ssh host bans`
+// lists the bans in effect and `ssh host unban <ip>` lifts one.
+var banCommands = map[string]bool{"bans": true, "unban": true}
+
+// runBanCommand runs one of banCommands for the owner.
+func (s *SSHServer) runBanCommand(w io.Writer, args []string) error {
+	now := time.Now()
+	if args[0] == "bans" {
+		list := s.bans.list(now)
+		if len(list) == 0 {
+			_, err := fmt.Fprintln(w, "No IPs are banned.")
+			return err
+		}
+		for _, r := range list {
+			if _, err := fmt.Fprintf(w, "%-39s  until %s  (ban %d)\n", r.IP, r.Until.Format(time.RFC3339), r.Count); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if len(args) != 2 {
+		return errors.New("usage: unban <ip>")
+	}
+	ok, err := s.bans.unban(args[1], now)
+	if err != nil {
+		return fmt.Errorf("save bans: %w", err)
+	}
+	if !ok {
+		return fmt.Errorf("%s is not banned", args[1])
+	}
+	s.logger.Info("SSH client unbanned", "ip", args[1])
+	_, err = fmt.Fprintf(w, "Unbanned %s.\n", args[1])
+	return err
+}
@@ -0,0 +1,153 @@
+package server
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	gossh "golang.org/x/crypto/ssh"
+
+	"github.com/buntingszn/terminal-portfolio/tui/internal/config"
+)
+
+// runCommand runs command over a new connection signed in with auth, and
+// returns its output and error.
+func runCommand(t *testing.T, port int, command string, auth ...gossh.AuthMethod) (string, error) {
+	t.Helper()
+	cfg := sshClientConfig()
+	if len(auth) > 0 {
+		cfg.Auth = auth
+	}
+	client, err := gossh.Dial("tcp", fmt.Sprintf("127.0.0.1:%d", port), cfg)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = client.Close() }()
+	sess, err := client.NewSession()
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = sess.Close() }()
+	out, err := sess.CombinedOutput(command)
+	return string(out), err
+}
+
+func TestBanStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), bansFileName)
+	cfg := &config.Config{AutoBanStrikes: 2, AutoBanWindow: time.Minute, AutoBanDuration: time.Hour, AutoBanMax: 3 * time.Hour}
+	b, err := openBanStore(path, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+
+	// Refusals further apart than the window never add up to a ban.
+	for i := range 3 {
+		if r, _ := b.strike("1.1.1.1", now.Add(time.Duration(i)*time.Minute)); r != nil {
+			t.Fatalf("refusal %d a window apart banned the IP", i+1)
+		}
+	}
+
+	// Each ban lasts twice the last, up to the maximum.
+	for i, want := range []time.Duration{time.Hour, 2 * time.Hour, 3 * time.Hour, 3 * time.Hour} {
+		b.strike("2.2.2.2", now)
+		r, err := b.strike("2.2.2.2", now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if r == nil || r.Count != i+1 || r.Until.Sub(now) != want {
+			t.Fatalf("ban %d = %+v, want one lasting %v", i+1, r, want)
+		}
+	}
+	if !b.banned("2.2.2.2", now.Add(3*time.Hour-time.Second)) || b.banned("2.2.2.2", now.Add(3*time.Hour)) {
+		t.Error("the ban should last until its end")
+	}
+
+	// The bans outlive a restart.
+	b.strike("3.3.3.3", now)
+	b.strike("3.3.3.3", now)
+	b, err = openBanStore(path, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	list := b.list(now)
+	if len(list) != 2 || list[0].IP != "3.3.3.3" || list[1].IP != "2.2.2.2" || list[1].Count != 4 {
+		t.Errorf("reopened bans = %+v, want 3.3.3.3 then 2.2.2.2 with 4 bans", list)
+	}
+
+	if ok, err := b.unban("2.2.2.2", now); !ok || err != nil {
+		t.Fatalf("unban = %v, %v; want the ban lifted", ok, err)
+	}
+	if ok, _ := b.unban("2.2.2.2", now); ok {
+		t.Error("unbanning an IP twice should find no ban")
+	}
+	b.strike("2.2.2.2", now)
+	if r, _ := b.strike("2.2.2.2", now); r == nil || r.Count != 1 {
+		t.Errorf("ban after unban = %+v, want a first ban", r)
+	}
+
+	// A week after its ban ends, an IP starts over.
+	later := now.Add(time.Hour + banMemory)
+	b.strike("3.3.3.3", later)
+	if r, _ := b.strike("3.3.3.3", later); r == nil || r.Count != 1 {
+		t.Errorf("ban after a quiet week = %+v, want a first ban", r)
+	}
+}
+
+func TestSSHServer_AutoBan(t *testing.T) {
+	ownerSigner, ownerKey := testSigner(t)
+	dir := t.TempDir()
+	ownerPath := filepath.Join(dir, "owner_keys")
+	if err := os.WriteFile(ownerPath, gossh.MarshalAuthorizedKey(ownerKey), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	srv, port := startConfiguredServer(t, 10, func(cfg *config.Config) {
+		cfg.DataDir = dir
+		cfg.OwnerKeys = ownerPath
+		cfg.RateLimit = 3
+		cfg.RateWindow = time.Hour
+		cfg.AutoBanStrikes = 2
+		cfg.AutoBanWindow = time.Hour
+		cfg.AutoBanDuration = time.Hour
+		cfg.AutoBanMax = time.Hour
+	})
+	owner := gossh.PublicKeys(ownerSigner)
+
+	if out, err := runCommand(t, port, "bans", owner); err != nil || !strings.Contains(out, "No IPs are banned") {
+		t.Errorf("bans = %q, %v; want none", out, err)
+	}
+	if _, err := runCommand(t, port, "bans"); err == nil {
+		t.Error("bans should be an unknown command for visitors")
+	}
+
+	// Two refusals past the rate limit ban the IP.
+	for range 3 {
+		_, _ = runCommand(t, port, "links")
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for !srv.bans.banned("127.0.0.1", time.Now()) {
+		if time.Now().After(deadline) {
+			t.Fatal("two refusals should ban the IP")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if _, err := runCommand(t, port, "links", owner); err == nil {
+		t.Error("a banned IP should be turned away")
+	}
+	if got := srv.refused.String(); got != "banned 1" {
+		t.Errorf("refused = %q, want banned 1", got)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, bansFileName)); err != nil {
+		t.Errorf("the ban should be saved: %v", err)
+	}
+	if err := srv.runBanCommand(&strings.Builder{}, []string{"unban", "127.0.0.1"}); err != nil {
+		t.Fatalf("unban failed: %v", err)
+	}
+	if srv.bans.banned("127.0.0.1", time.Now()) {
+		t.Error("unban should lift the ban")
+	}
+}
@@ -0,0 +1,59 @@
+package server
+
+import (
+	"slices"
+
+	"github.com/charmbracelet/ssh"
+	"github.com/charmbracelet/wish"
+)
+
+// Names of the stages in the default middleware chain.
+const (
+	StageRecovery  = "recovery"
+	StageRateLimit = "ratelimit"
+	StageSessions  = "sessions"
+	StageCommand   = "command"
+	StageA11y      = "a11y"
+	StageTUI       = "tui"
+)
+
+// Stage is one named step of the session middleware chain.
+type Stage struct {
+	Name       string
+	Middleware wish.Middleware
+}
+
+// Chain is the session middleware chain, outermost first: the first stage
+// sees each session before any other and the last one serves it. Edit it
+// with the slices package, using Index to find a stage; for example, to
+// run custom middleware once a session has passed the limits:
+//
+//	func(c server.Chain) server.Chain {
+//		return slices.Insert(c, c.Index(server.StageCommand), server.Stage{Name: "audit", Middleware: audit})
+//	}
+type Chain []Stage
+
+// Index returns the position of the stage called name, or -1.
+func (c Chain) Index(name string) int {
+	return slices.IndexFunc(c, func(s Stage) bool { return s.Name == name })
+}
+
+// middleware returns the chain in the order wish.WithMiddleware expects:
+// Wish runs the last middleware it is given first.
+func (c Chain) middleware() []wish.Middleware {
+	mw := make([]wish.Middleware, len(c))
+	for i, s := range c {
+		mw[len(c)-1-i] = s.Middleware
+	}
+	return mw
+}
+
+// handler composes the chain into one session handler, as Wish does for
+// SSH sessions, so other transports can serve sessions through it.
+func (c Chain) handler() ssh.Handler {
+	h := func(ssh.Session) {}
+	for _, m := range c.middleware() {
+		h = m(h)
+	}
+	return h
+}
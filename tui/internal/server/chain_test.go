@@ -0,0 +1,37 @@
+package server
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/charmbracelet/ssh"
+	"github.com/charmbracelet/wish"
+)
+
+func TestChain(t *testing.T) {
+	var order []string
+	stage := func(name string) Stage {
+		return Stage{Name: name, Middleware: func(next ssh.Handler) ssh.Handler {
+			return func(sess ssh.Session) {
+				order = append(order, name)
+				next(sess)
+			}
+		}}
+	}
+	c := Chain{stage("outer"), stage("inner")}
+	c = slices.Insert(c, c.Index("inner"), stage("middle"))
+	if c.Index("missing") != -1 {
+		t.Error("Index of a missing stage should be -1")
+	}
+
+	// Compose the way Wish does: the first middleware given wraps the
+	// handler innermost.
+	h := ssh.Handler(func(ssh.Session) { order = append(order, "handler") })
+	for _, mw := range c.middleware() {
+		h = wish.Middleware(mw)(h)
+	}
+	h(nil)
+	if want := []string{"outer", "middle", "inner", "handler"}; !slices.Equal(order, want) {
+		t.Errorf("ran %v, want %v", order, want)
+	}
+}
@@ -0,0 +1,52 @@
+package server
+
+import (
+	"io"
+
+	"github.com/charmbracelet/ssh"
+)
+
+// frameSink takes what a session's TUI program writes, the frames it
+// renders and the clipboard and image sequences sent between them, and
+// delivers it to the visitor's terminal. Each kind of session has its own:
+// SSH sessions write to their channel and browser sessions to their
+// WebSocket. Text-mode output, from commands and accessible mode, has no
+// frames and is written to the session directly.
+type frameSink interface {
+	io.Writer
+	// slowLink returns a channel closed once the sink finds its link too
+	// slow for the frames written to it, or nil if it does not watch.
+	slowLink() <-chan struct{}
+}
+
+// frameSinker is implemented by sessions that deliver frames some other
+// way than through their Write method.
+type frameSinker interface {
+	// frameSink returns the session's sink under the slow-link policy.
+	frameSink(policy string) frameSink
+}
+
+// sessionSink returns the sink sess's program writes to under the
+// slow-link policy, serialized so frames and out-of-band writes from other
+// goroutines do not interleave.
+func sessionSink(sess ssh.Session, policy string) *syncWriter {
+	if fs, ok := sess.(frameSinker); ok {
+		return &syncWriter{w: fs.frameSink(policy)}
+	}
+	return &syncWriter{w: linkSink(sess, policy, sess.Environ())}
+}
+
+// linkSink returns a sink writing to w. Under the "auto" policy it watches
+// for a slow link, unless the client environment environ already marks it
+// slow from the start.
+func linkSink(w io.Writer, policy string, environ []string) frameSink {
+	if policy == "auto" && !startsSlow(policy, environ) {
+		return newLinkMonitor(w, linkStall, linkStalls)
+	}
+	return plainSink{w}
+}
+
+// plainSink writes frames to its writer without watching the link.
+type plainSink struct{ io.Writer }
+
+func (plainSink) slowLink() <-chan struct{} { return nil }
@@ -0,0 +1,28 @@
+package server
+
+import (
+	"io"
+	"testing"
+)
+
+func TestLinkSink(t *testing.T) {
+	tests := []struct {
+		policy  string
+		environ []string
+		watched bool
+	}{
+		{"auto", nil, true},
+		{"auto", []string{"SLOW=1"}, false},
+		{"on", nil, false},
+		{"off", nil, false},
+	}
+	for _, tt := range tests {
+		sink := linkSink(io.Discard, tt.policy, tt.environ)
+		if watched := sink.slowLink() != nil; watched != tt.watched {
+			t.Errorf("linkSink(%q, %q) watches the link = %v, want %v", tt.policy, tt.environ, watched, tt.watched)
+		}
+		if n, err := sink.Write([]byte("frame")); n != 5 || err != nil {
+			t.Errorf("linkSink(%q, %q).Write = %d, %v", tt.policy, tt.environ, n, err)
+		}
+	}
+}
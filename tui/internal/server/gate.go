@@ -0,0 +1,144 @@
+package server
+
+import (
+	"fmt"
+	"maps"
+	"net"
+	"net/netip"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/ssh"
+
+	"github.com/buntingszn/terminal-portfolio/tui/internal/config"
+)
+
+// Reasons a connection is turned away before its handshake, as logged
+// and counted.
+const (
+	refusedDenied    = "deny list"
+	refusedNotListed = "allow list"
+	refusedLockedOut = "locked out"
+	refusedBanned    = "banned"
+)
+
+// acceptConn closes connections from IPs the filter, an auto-ban or the
+// sign-in lockout turns away before their handshake, and applies the idle
+// timeout to the others.
+func (s *SSHServer) acceptConn(ctx ssh.Context, conn net.Conn) net.Conn {
+	ip := addrIP(conn.RemoteAddr().String())
+	if reason, rule := s.refuseIP(ip, time.Now()); reason != "" {
+		s.rejected("SSH", ip, reason, rule)
+		return nil
+	}
+	return s.idleConn(ctx, conn)
+}
+
+// refuseIP returns why ip may not connect at now, by the filter, an
+// auto-ban or the sign-in lockout, with the deny rule it matched, or an
+// empty reason when it may.
+func (s *SSHServer) refuseIP(ip string, now time.Time) (reason, rule string) {
+	reason, rule = s.filter.Load().refuse(ip)
+	switch {
+	case reason != "":
+	case s.bans.banned(ip, now):
+		reason = refusedBanned
+	case s.lockout != nil && s.lockout.locked(ip, now):
+		reason = refusedLockedOut
+	}
+	return reason, rule
+}
+
+// rejected counts and logs a connection of the given kind, SSH or web,
+// turned away for reason.
+func (s *SSHServer) rejected(kind, ip, reason, rule string) {
+	s.refused.add(reason)
+	args := []any{"ip", ip}
+	if rule != "" {
+		args = append(args, "rule", rule)
+	}
+	s.logger.Info(kind+" connection rejected: "+reason, args...)
+}
+
+// ipFilter decides which client IPs may connect, from the AllowIPs and
+// DenyIPs settings.
+type ipFilter struct {
+	allow, deny []netip.Prefix
+}
+
+// newIPFilter returns the filter cfg sets up, or nil when it lists no
+// addresses.
+func newIPFilter(cfg *config.Config) *ipFilter {
+	// Load has already checked both lists.
+	allow, _ := config.ParsePrefixes(cfg.AllowIPs)
+	deny, _ := config.ParsePrefixes(cfg.DenyIPs)
+	if len(allow) == 0 && len(deny) == 0 {
+		return nil
+	}
+	return &ipFilter{allow: allow, deny: deny}
+}
+
+// refuse returns why ip may not connect, with the deny rule it matched, or
+// an empty reason when it may. A nil filter lets every IP in.
+func (f *ipFilter) refuse(ip string) (reason, rule string) {
+	if f == nil {
+		return "", ""
+	}
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		if len(f.allow) > 0 {
+			return refusedNotListed, ""
+		}
+		return "", ""
+	}
+	addr = addr.Unmap()
+	for _, p := range f.deny {
+		if p.Contains(addr) {
+			return refusedDenied, p.String()
+		}
+	}
+	if len(f.allow) > 0 && !slices.ContainsFunc(f.allow, func(p netip.Prefix) bool { return p.Contains(addr) }) {
+		return refusedNotListed, ""
+	}
+	return "", ""
+}
+
+// String describes the filter for the admin section.
+func (f *ipFilter) String() string {
+	if f == nil {
+		return "off"
+	}
+	return fmt.Sprintf("allow %d, deny %d", len(f.allow), len(f.deny))
+}
+
+// refusals counts the connections turned away before their handshake, by
+// reason. It is safe for concurrent use.
+type refusals struct {
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+func (r *refusals) add(reason string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.counts == nil {
+		r.counts = make(map[string]int)
+	}
+	r.counts[reason]++
+}
+
+// String lists the counts by reason, for the admin section.
+func (r *refusals) String() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.counts) == 0 {
+		return "none"
+	}
+	parts := make([]string, 0, len(r.counts))
+	for _, reason := range slices.Sorted(maps.Keys(r.counts)) {
+		parts = append(parts, fmt.Sprintf("%s %d", reason, r.counts[reason]))
+	}
+	return strings.Join(parts, ", ")
+}
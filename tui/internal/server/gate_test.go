@@ -0,0 +1,65 @@
+package server
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	gossh "golang.org/x/crypto/ssh"
+
+	"github.com/buntingszn/terminal-portfolio/tui/internal/config"
+)
+
+func TestIPFilter(t *testing.T) {
+	f := newIPFilter(&config.Config{AllowIPs: "10.0.0.0/8, 2001:db8::/32", DenyIPs: "10.6.6.0/24"})
+	tests := []struct {
+		ip, reason, rule string
+	}{
+		{"10.1.2.3", "", ""},
+		{"::ffff:10.1.2.3", "", ""},
+		{"2001:db8::7", "", ""},
+		{"10.6.6.6", refusedDenied, "10.6.6.0/24"},
+		{"192.0.2.1", refusedNotListed, ""},
+		{"pipe", refusedNotListed, ""},
+	}
+	for _, tt := range tests {
+		if reason, rule := f.refuse(tt.ip); reason != tt.reason || rule != tt.rule {
+			t.Errorf("refuse(%s) = %q, %q; want %q, %q", tt.ip, reason, rule, tt.reason, tt.rule)
+		}
+	}
+
+	if f := newIPFilter(&config.Config{}); f != nil {
+		t.Errorf("empty lists = %v, want no filter", f)
+	}
+	var none *ipFilter
+	if reason, _ := none.refuse("192.0.2.1"); reason != "" || none.String() != "off" {
+		t.Errorf("a nil filter refused %q", reason)
+	}
+}
+
+// TestSSHServer_IPFilter verifies that denied IPs are turned away before
+// the handshake, and that a reload can lift the ban.
+func TestSSHServer_IPFilter(t *testing.T) {
+	srv, port := startConfiguredServer(t, 10, func(cfg *config.Config) {
+		cfg.DenyIPs = "127.0.0.0/8"
+	})
+	addr := fmt.Sprintf("127.0.0.1:%d", port)
+
+	if client, err := gossh.Dial("tcp", addr, sshClientConfig()); err == nil {
+		_ = client.Close()
+		t.Fatal("a denied IP should not complete the handshake")
+	}
+	// The readiness check's connection may be counted too.
+	if got := srv.refused.String(); !strings.HasPrefix(got, "deny list ") {
+		t.Errorf("refused = %q, want deny list refusals", got)
+	}
+
+	next := *srv.cfg.Load()
+	next.DenyIPs, next.AllowIPs = "", "127.0.0.1"
+	srv.Reload(&next)
+	client, err := gossh.Dial("tcp", addr, sshClientConfig())
+	if err != nil {
+		t.Fatalf("an allowed IP should connect after the reload: %v", err)
+	}
+	_ = client.Close()
+}
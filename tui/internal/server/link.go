@@ -0,0 +1,84 @@
+package server
+
+import (
+	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// slowLinkFPS caps the frame rate of sessions known to be on a slow
+	// link from the start. Frames drawn in between are merged, so only
+	// the lines that changed since the last one are sent.
+	slowLinkFPS = 15
+
+	// linkStall is how long a write must block for the link to count as
+	// backed up. SSH writes only block once the client's window and the
+	// TCP buffers are full, so anything this long is the link, not us.
+	linkStall = 150 * time.Millisecond
+
+	// linkStalls is how many stalled writes mark a link as slow, so a
+	// single hiccup on a good connection does not.
+	linkStalls = 3
+)
+
+// linkMonitor passes a session's output through, watching for writes that
+// block on backpressure from a link too slow for the frames sent to it.
+type linkMonitor struct {
+	w      io.Writer
+	stall  time.Duration
+	limit  int32
+	stalls atomic.Int32
+	slow   chan struct{} // closed once limit writes have stalled
+}
+
+func newLinkMonitor(w io.Writer, stall time.Duration, limit int) *linkMonitor {
+	return &linkMonitor{w: w, stall: stall, limit: int32(limit), slow: make(chan struct{})}
+}
+
+func (l *linkMonitor) Write(p []byte) (int, error) {
+	start := time.Now()
+	n, err := l.w.Write(p)
+	if time.Since(start) >= l.stall && l.stalls.Add(1) == l.limit {
+		close(l.slow)
+	}
+	return n, err
+}
+
+func (l *linkMonitor) slowLink() <-chan struct{} { return l.slow }
+
+// syncWriter serializes writes to a session's sink. The renderer flushes frames
+// from its own goroutine while commands write clipboard and image
+// sequences from theirs, and an SSH channel must not be written from two
+// goroutines at once.
+type syncWriter struct {
+	mu sync.Mutex
+	w  frameSink
+}
+
+func (s *syncWriter) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.w.Write(p)
+}
+
+func (s *syncWriter) slowLink() <-chan struct{} { return s.w.slowLink() }
+
+// startsSlow reports whether a session with the client environment
+// environ is throttled from the start under policy: always with "on", and
+// with "auto" when the client sets SLOW=1, as with ssh -o SetEnv=SLOW=1.
+func startsSlow(policy string, environ []string) bool {
+	switch policy {
+	case "on":
+		return true
+	case "auto":
+		for _, kv := range environ {
+			if v, ok := strings.CutPrefix(kv, "SLOW="); ok {
+				return v == "1" || v == "true"
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,102 @@
+package server
+
+import (
+	"io"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// stallWriter discards writes, taking delay over each.
+type stallWriter struct {
+	delay time.Duration
+}
+
+func (w stallWriter) Write(p []byte) (int, error) {
+	time.Sleep(w.delay)
+	return len(p), nil
+}
+
+func TestLinkMonitor(t *testing.T) {
+	slow := func(l *linkMonitor) bool {
+		select {
+		case <-l.slow:
+			return true
+		default:
+			return false
+		}
+	}
+
+	fast := newLinkMonitor(io.Discard, 5*time.Millisecond, 2)
+	for range 10 {
+		_, _ = fast.Write([]byte("frame"))
+	}
+	if slow(fast) {
+		t.Error("writes that never block should not mark the link slow")
+	}
+
+	stalled := newLinkMonitor(stallWriter{10 * time.Millisecond}, 5*time.Millisecond, 2)
+	if _, _ = stalled.Write([]byte("frame")); slow(stalled) {
+		t.Error("one stall should not mark the link slow")
+	}
+	for range 3 {
+		if n, err := stalled.Write([]byte("frame")); n != 5 || err != nil {
+			t.Fatalf("Write = %d, %v", n, err)
+		}
+	}
+	if !slow(stalled) {
+		t.Error("repeated stalls should mark the link slow")
+	}
+}
+
+// overlapWriter counts writes that start while another is still going.
+type overlapWriter struct {
+	active, overlaps atomic.Int32
+}
+
+func (w *overlapWriter) Write(p []byte) (int, error) {
+	if w.active.Add(1) > 1 {
+		w.overlaps.Add(1)
+	}
+	time.Sleep(time.Millisecond)
+	w.active.Add(-1)
+	return len(p), nil
+}
+
+func TestSyncWriter(t *testing.T) {
+	var dst overlapWriter
+	out := &syncWriter{w: plainSink{&dst}}
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Go(func() {
+			for range 10 {
+				_, _ = out.Write([]byte("frame"))
+			}
+		})
+	}
+	wg.Wait()
+	if n := dst.overlaps.Load(); n != 0 {
+		t.Errorf("%d writes overlapped", n)
+	}
+}
+
+func TestStartsSlow(t *testing.T) {
+	tests := []struct {
+		policy  string
+		environ []string
+		want    bool
+	}{
+		{"on", nil, true},
+		{"auto", nil, false},
+		{"auto", []string{"TERM=xterm", "SLOW=1"}, true},
+		{"auto", []string{"SLOW=true"}, true},
+		{"auto", []string{"SLOW=0"}, false},
+		{"off", []string{"SLOW=1"}, false},
+	}
+	for _, tt := range tests {
+		if got := startsSlow(tt.policy, tt.environ); got != tt.want {
+			t.Errorf("startsSlow(%q, %q) = %v, want %v", tt.policy, tt.environ, got, tt.want)
+		}
+	}
+}
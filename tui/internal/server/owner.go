@@ -0,0 +1,67 @@
+package server
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/charmbracelet/ssh"
+	"github.com/charmbracelet/wish"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// loadKeys reads the public keys in an authorized_keys file, named what
+// in errors. Blank lines and comments are skipped; options on a key line
+// are ignored.
+func loadKeys(what, path string) ([]ssh.PublicKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", what, err)
+	}
+	var keys []ssh.PublicKey
+	for rest := data; len(bytes.TrimSpace(rest)) > 0; {
+		var key ssh.PublicKey
+		key, _, _, rest, err = gossh.ParseAuthorizedKey(rest)
+		if err != nil {
+			return nil, fmt.Errorf("parse %s %s: %w", what, path, err)
+		}
+		keys = append(keys, key)
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("%s %s lists no keys", what, path)
+	}
+	return keys, nil
+}
+
+// ownerAuth returns the server options that let the owner sign in with
+// one of keys while everyone else connects as before. Unless anyKey is
+// set, only owner keys pass public key authentication; other clients fall
+// through to keyboard-interactive or password authentication, which
+// accept anyone. With anyKey every key passes, so sessions can be told
+// apart by key; isOwner still checks it against keys.
+func ownerAuth(keys []ssh.PublicKey, anyKey bool) []ssh.Option {
+	return []ssh.Option{
+		wish.WithPublicKeyAuth(func(_ ssh.Context, key ssh.PublicKey) bool {
+			return anyKey || ownerKey(keys, key)
+		}),
+		wish.WithKeyboardInteractiveAuth(func(ssh.Context, gossh.KeyboardInteractiveChallenge) bool {
+			return true
+		}),
+		wish.WithPasswordAuth(func(ssh.Context, string) bool {
+			return true
+		}),
+	}
+}
+
+// ownerKey reports whether key is one of keys.
+func ownerKey(keys []ssh.PublicKey, key ssh.PublicKey) bool {
+	return key != nil && slices.ContainsFunc(keys, func(k ssh.PublicKey) bool {
+		return ssh.KeysEqual(k, key)
+	})
+}
+
+// isOwner reports whether sess authenticated with one of the owner's keys.
+func (s *SSHServer) isOwner(sess ssh.Session) bool {
+	return ownerKey(s.ownerKeys, sess.PublicKey())
+}
@@ -0,0 +1,177 @@
+package server
+
+import (
+	"context"
+	"io"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/lucasb-eyer/go-colorful"
+
+	"github.com/buntingszn/terminal-portfolio/tui/internal/graphics"
+)
+
+// probeTimeout bounds how long a session waits for the terminal to answer
+// a probe. Terminals answer DA1 within a round trip, so this
+// only delays clients whose replies are lost along the way.
+const probeTimeout = time.Second
+
+// backgroundQuery asks the terminal for its background color with OSC 11,
+// then for its device attributes, which every terminal answers, so one
+// that ignores OSC 11 does not leave the session waiting out the timeout.
+const backgroundQuery = "\x1b]11;?\a\x1b[c"
+
+var (
+	backgroundDA1   = regexp.MustCompile(`\x1b\[\?[0-9;]*c`)
+	backgroundReply = regexp.MustCompile(`\x1b\]11;rgb:([0-9a-fA-F]{1,4})/([0-9a-fA-F]{1,4})/([0-9a-fA-F]{1,4})(?:\a|\x1b\\)`)
+)
+
+// sessionInput reads a session's input on a single goroutine so the
+// terminal probes can wait for the terminal's replies with a timeout, then
+// hand whatever it did not consume to the Bubble Tea program.
+type sessionInput struct {
+	chunks <-chan []byte
+	buf    []byte // bytes already received but not yet read
+	err    error  // set before chunks is closed
+}
+
+// newSessionInput starts reading r until it fails or ctx is done.
+func newSessionInput(ctx context.Context, r io.Reader) *sessionInput {
+	ch := make(chan []byte)
+	in := &sessionInput{chunks: ch}
+	go func() {
+		defer close(ch)
+		for {
+			p := make([]byte, 1024)
+			n, err := r.Read(p)
+			if n > 0 {
+				select {
+				case ch <- p[:n]:
+				case <-ctx.Done():
+					in.err = ctx.Err()
+					return
+				}
+			}
+			if err != nil {
+				in.err = err
+				return
+			}
+		}
+	}()
+	return in
+}
+
+func (in *sessionInput) Read(p []byte) (int, error) {
+	return in.readUntil(p, nil)
+}
+
+// readUntil reads like Read, but returns io.EOF without taking any input
+// once done is closed, leaving the rest for the next reader.
+func (in *sessionInput) readUntil(p []byte, done <-chan struct{}) (int, error) {
+	if len(in.buf) == 0 {
+		select {
+		case chunk, ok := <-in.chunks:
+			if !ok {
+				return 0, in.err
+			}
+			in.buf = chunk
+		case <-done:
+			return 0, io.EOF
+		}
+	}
+	n := copy(p, in.buf)
+	in.buf = in.buf[n:]
+	return n, nil
+}
+
+// probeGraphics writes graphics.ProbeQuery to out and waits up to timeout
+// for the replies on in. Input after the replies is left in in for the
+// program; if the terminal does not finish answering in time, everything
+// received is left there and probeGraphics reports false.
+func probeGraphics(in *sessionInput, out io.Writer, timeout time.Duration) (graphics.Capabilities, bool) {
+	var caps graphics.Capabilities
+	ok := probe(in, out, graphics.ProbeQuery, timeout, func(got []byte) (end int, ok bool) {
+		caps, end, ok = graphics.ParseProbe(got)
+		return end, ok
+	})
+	return caps, ok
+}
+
+// probeBackground writes backgroundQuery to out and waits up to timeout
+// for the replies on in, as probeGraphics does. It reports whether the
+// terminal's background is dark, and false for ok when the terminal did
+// not say.
+func probeBackground(in *sessionInput, out io.Writer, timeout time.Duration) (dark, ok bool) {
+	var replies []byte
+	if !probe(in, out, backgroundQuery, timeout, func(got []byte) (int, bool) {
+		loc := backgroundDA1.FindIndex(got)
+		if loc == nil {
+			return 0, false
+		}
+		replies = got[:loc[1]]
+		return loc[1], true
+	}) {
+		return false, false
+	}
+	m := backgroundReply.FindSubmatch(replies)
+	if m == nil {
+		return false, false
+	}
+	var rgb [3]float64
+	for i, hex := range m[1:] {
+		// Each component has one to four hex digits, scaled to [0, 1].
+		v, _ := strconv.ParseUint(string(hex), 16, 16)
+		rgb[i] = float64(v) / float64(uint64(1)<<(4*len(hex))-1)
+	}
+	_, _, l := colorful.Color{R: rgb[0], G: rgb[1], B: rgb[2]}.Hsl()
+	return l < 0.5, true
+}
+
+// probe writes query to out and reads the terminal's replies from in until
+// parse finds them complete, returning the offset just past them, or
+// until timeout. Input after the replies is left in in for the program; if
+// the terminal does not finish answering in time, everything received is
+// left there and probe reports false.
+func probe(in *sessionInput, out io.Writer, query string, timeout time.Duration, parse func([]byte) (end int, ok bool)) bool {
+	if _, err := io.WriteString(out, query); err != nil {
+		return false
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	// Input left over from an earlier probe stays ahead of what follows.
+	pending := in.buf
+	var got []byte
+	for {
+		select {
+		case chunk, ok := <-in.chunks:
+			if !ok {
+				in.buf = append(pending, got...)
+				return false
+			}
+			got = append(got, chunk...)
+			if end, ok := parse(got); ok {
+				in.buf = append(pending, got[end:]...)
+				return true
+			}
+		case <-timer.C:
+			in.buf = append(pending, got...)
+			return false
+		}
+	}
+}
+
+// sessionGraphics resolves the configured graphics setting for one
+// session. A named protocol is used as is; "auto" calls probe and, when the
+// terminal does not answer, detects the protocol from its TERM and
+// environment.
+func sessionGraphics(setting string, probe func() (graphics.Capabilities, bool), term string, environ []string) graphics.Capabilities {
+	if p, ok := graphics.ParseProtocol(setting); ok {
+		return graphics.Capabilities{Protocol: p}
+	}
+	if caps, ok := probe(); ok {
+		return caps
+	}
+	return graphics.Capabilities{Protocol: graphics.Detect(term, environ)}
+}
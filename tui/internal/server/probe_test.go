@@ -0,0 +1,150 @@
+package server
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/buntingszn/terminal-portfolio/tui/internal/graphics"
+)
+
+// probeTerminal returns session input fed by a fake terminal and the
+// writer the terminal answers through.
+func probeTerminal(t *testing.T) (*sessionInput, *io.PipeWriter) {
+	t.Helper()
+	r, w := io.Pipe()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(func() {
+		cancel()
+		_ = w.Close()
+	})
+	return newSessionInput(ctx, r), w
+}
+
+func TestProbeGraphics(t *testing.T) {
+	in, term := probeTerminal(t)
+	go func() {
+		// The reply arrives split, followed by a typed key.
+		_, _ = io.WriteString(term, "\x1b_Gi=31;OK\x1b\\\x1b[6;18")
+		_, _ = io.WriteString(term, ";9t\x1b[?62;22cj")
+	}()
+
+	var query strings.Builder
+	caps, ok := probeGraphics(in, &query, time.Second)
+	if !ok || caps != (graphics.Capabilities{Protocol: graphics.Kitty, CellWidth: 9, CellHeight: 18}) {
+		t.Fatalf("probeGraphics = %+v, %v", caps, ok)
+	}
+	if query.String() != graphics.ProbeQuery {
+		t.Errorf("probe wrote %q", query.String())
+	}
+
+	p := make([]byte, 8)
+	n, err := in.Read(p)
+	if err != nil || string(p[:n]) != "j" {
+		t.Errorf("input after the reply = %q, %v; want \"j\"", p[:n], err)
+	}
+}
+
+func TestProbeGraphicsTimeout(t *testing.T) {
+	in, term := probeTerminal(t)
+	go func() { _, _ = io.WriteString(term, "q") }()
+
+	if _, ok := probeGraphics(in, io.Discard, 50*time.Millisecond); ok {
+		t.Fatal("a terminal that never answers should time out")
+	}
+	p := make([]byte, 8)
+	if n, _ := in.Read(p); string(p[:n]) != "q" {
+		t.Errorf("input received before the timeout was lost: %q", p[:n])
+	}
+
+	_ = term.Close()
+	if _, err := in.Read(p); err != io.EOF {
+		t.Errorf("closed input should read EOF, got %v", err)
+	}
+}
+
+func TestProbeBackground(t *testing.T) {
+	tests := []struct {
+		reply    string
+		dark, ok bool
+	}{
+		{"\x1b]11;rgb:ffff/ffff/ffff\x1b\\\x1b[?62;22c", false, true},
+		{"\x1b]11;rgb:1e/1e/2e\a\x1b[?62;22c", true, true},
+		// A terminal that ignores OSC 11 still answers DA1.
+		{"\x1b[?1;2c", false, false},
+	}
+	for _, tt := range tests {
+		in, term := probeTerminal(t)
+		go func() { _, _ = io.WriteString(term, tt.reply+"j") }()
+
+		var query strings.Builder
+		dark, ok := probeBackground(in, &query, time.Second)
+		if dark != tt.dark || ok != tt.ok {
+			t.Errorf("probeBackground(%q) = %v, %v; want %v, %v", tt.reply, dark, ok, tt.dark, tt.ok)
+		}
+		if query.String() != backgroundQuery {
+			t.Errorf("probe wrote %q", query.String())
+		}
+		p := make([]byte, 8)
+		if n, err := in.Read(p); err != nil || string(p[:n]) != "j" {
+			t.Errorf("input after %q = %q, %v; want \"j\"", tt.reply, p[:n], err)
+		}
+	}
+}
+
+func TestProbesKeepEarlierInput(t *testing.T) {
+	in, term := probeTerminal(t)
+	go func() {
+		_, _ = io.WriteString(term, "\x1b]11;rgb:0/0/0\a\x1b[?62c"+"a")
+		_, _ = io.WriteString(term, "\x1b[?62;4c"+"b")
+	}()
+	if _, ok := probeBackground(in, io.Discard, time.Second); !ok {
+		t.Fatal("the background probe was not answered")
+	}
+	if caps, ok := probeGraphics(in, io.Discard, time.Second); !ok || caps.Protocol != graphics.Sixel {
+		t.Fatalf("probeGraphics = %+v, %v", caps, ok)
+	}
+	p := make([]byte, 8)
+	n, _ := in.Read(p)
+	if got := string(p[:n]); got != "ab" {
+		t.Errorf("input typed between the probes = %q, want \"ab\"", got)
+	}
+}
+
+func TestSessionGraphics(t *testing.T) {
+	answered := func() (graphics.Capabilities, bool) {
+		return graphics.Capabilities{Protocol: graphics.ITerm2, CellWidth: 16, CellHeight: 34}, true
+	}
+	silent := func() (graphics.Capabilities, bool) { return graphics.Capabilities{}, false }
+
+	tests := []struct {
+		setting string
+		probe   func() (graphics.Capabilities, bool)
+		term    string
+		want    graphics.Protocol
+	}{
+		{"auto", answered, "xterm-256color", graphics.ITerm2},
+		{"auto", silent, "xterm-kitty", graphics.Kitty},
+		{"auto", silent, "foot", graphics.Sixel},
+		{"auto", silent, "xterm-256color", graphics.None},
+		{"kitty", answered, "xterm-256color", graphics.Kitty},
+		{"iterm2", silent, "xterm-256color", graphics.ITerm2},
+		{"off", answered, "xterm-kitty", graphics.None},
+	}
+	for _, tt := range tests {
+		probed := false
+		probe := func() (graphics.Capabilities, bool) {
+			probed = true
+			return tt.probe()
+		}
+		got := sessionGraphics(tt.setting, probe, tt.term, nil)
+		if got.Protocol != tt.want {
+			t.Errorf("sessionGraphics(%q, %q) = %v, want %v", tt.setting, tt.term, got.Protocol, tt.want)
+		}
+		if probed != (tt.setting == "auto") {
+			t.Errorf("sessionGraphics(%q) probed the terminal = %v", tt.setting, probed)
+		}
+	}
+}
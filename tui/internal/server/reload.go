@@ -0,0 +1,76 @@
+package server
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/charmbracelet/ssh"
+
+	"github.com/buntingszn/terminal-portfolio/tui/internal/config"
+)
+
+// Reload applies the settings in cfg that a running server can change,
+// as config.WithRuntime picks them, and returns the configuration now in
+// effect. Connections opened afterwards are checked against the new IP
+// filters, and their sessions get the new idle timeout and count against
+// the new limits; open sessions carry on as they were. A changed rate
+// limit starts every client on a fresh window.
+func (s *SSHServer) Reload(cfg *config.Config) *config.Config {
+	s.reloadMu.Lock()
+	defer s.reloadMu.Unlock()
+
+	old := s.cfg.Load()
+	next := old.WithRuntime(cfg)
+	if next.RateLimit != old.RateLimit || next.RateWindow != old.RateWindow {
+		s.stopCleanup()
+		s.setRateLimit(next.RateLimit, next.RateWindow)
+	}
+	s.filter.Store(newIPFilter(next))
+	s.cfg.Store(next)
+	return next
+}
+
+// setRateLimit installs a rate limiter allowing limit connections per
+// window from each IP, with its periodic cleanup, or none when limit is
+// 0. The caller stops the cleanup of the limiter it replaces.
+func (s *SSHServer) setRateLimit(limit int, window time.Duration) {
+	if limit <= 0 {
+		s.limiter.Store(nil)
+		s.stopCleanup = func() {}
+		return
+	}
+	limiter := NewRateLimiter(limit, window)
+	var ctx context.Context
+	ctx, s.stopCleanup = context.WithCancel(context.Background())
+	go cleanupLimiter(ctx, limiter, window)
+	s.limiter.Store(limiter)
+}
+
+// idleConn wraps each new connection to close it once it has neither
+// read nor written for the idle timeout in effect when it opened. A
+// timeout of 0 leaves the connection open however long it idles.
+func (s *SSHServer) idleConn(_ ssh.Context, conn net.Conn) net.Conn {
+	if d := s.cfg.Load().IdleTimeout; d > 0 {
+		return &idleConn{Conn: conn, timeout: d}
+	}
+	return conn
+}
+
+// idleConn pushes its deadline back on every read and write, as the SSH
+// server's own IdleTimeout does. The server closes the connection when a
+// deadline passes.
+type idleConn struct {
+	net.Conn
+	timeout time.Duration
+}
+
+func (c *idleConn) Read(b []byte) (int, error) {
+	_ = c.Conn.SetDeadline(time.Now().Add(c.timeout))
+	return c.Conn.Read(b)
+}
+
+func (c *idleConn) Write(b []byte) (int, error) {
+	_ = c.Conn.SetDeadline(time.Now().Add(c.timeout))
+	return c.Conn.Write(b)
+}
@@ -0,0 +1,99 @@
+package server
+
+import (
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/ssh"
+	gossh "golang.org/x/crypto/ssh"
+
+	"github.com/buntingszn/terminal-portfolio/tui/internal/app"
+)
+
+// resumeStore holds where dropped sessions were, by the fingerprint of
+// the key they signed in with, for window after they end.
+type resumeStore struct {
+	window time.Duration
+
+	mu     sync.Mutex
+	states map[string]resumeEntry
+}
+
+type resumeEntry struct {
+	state app.ResumeState
+	saved time.Time
+}
+
+func newResumeStore(window time.Duration) *resumeStore {
+	return &resumeStore{window: window, states: map[string]resumeEntry{}}
+}
+
+// save records st for fingerprint, dropping entries that have expired.
+func (r *resumeStore) save(fingerprint string, st app.ResumeState, now time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for fp, e := range r.states {
+		if now.Sub(e.saved) > r.window {
+			delete(r.states, fp)
+		}
+	}
+	r.states[fingerprint] = resumeEntry{state: st, saved: now}
+}
+
+// take returns and forgets the state saved for fingerprint, if it is
+// recent enough to resume.
+func (r *resumeStore) take(fingerprint string, now time.Time) (app.ResumeState, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	e, ok := r.states[fingerprint]
+	delete(r.states, fingerprint)
+	if !ok || now.Sub(e.saved) > r.window {
+		return app.ResumeState{}, false
+	}
+	return e.state, true
+}
+
+// resumable resumes m from the state saved for the session's key, if any
+// and restore is set, and returns it wrapped so that its place is saved
+// when the session ends. Sessions that asked for a section by SSH user
+// name pass restore false, as the section they asked for wins.
+func (s *SSHServer) resumable(sess ssh.Session, m app.Model, restore bool) tea.Model {
+	fp := gossh.FingerprintSHA256(sess.PublicKey())
+	if restore {
+		if st, ok := s.resume.take(fp, time.Now()); ok {
+			m = m.Resume(st)
+		}
+	}
+	rm := &resumeModel{Model: m}
+	go func() {
+		<-sess.Context().Done()
+		if st, ok := rm.state(); ok {
+			s.resume.save(fp, st, time.Now())
+		}
+	}()
+	return rm
+}
+
+// resumeModel wraps a session's model to keep hold of its latest state,
+// which the program otherwise only hands back once it exits.
+type resumeModel struct {
+	mu sync.Mutex
+	app.Model
+}
+
+// Update implements tea.Model.
+func (r *resumeModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	next, cmd := r.Model.Update(msg)
+	r.mu.Lock()
+	r.Model = next.(app.Model)
+	r.mu.Unlock()
+	return r, cmd
+}
+
+// state returns the latest model's place, as app.Model.ResumeState does.
+func (r *resumeModel) state() (app.ResumeState, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.Model.ResumeState()
+}
@@ -0,0 +1,34 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/buntingszn/terminal-portfolio/tui/internal/app"
+)
+
+func TestResumeStore(t *testing.T) {
+	r := newResumeStore(time.Minute)
+	now := time.Now()
+	st := app.ResumeState{Section: app.SectionWork, Position: 2}
+
+	r.save("a", st, now)
+	if got, ok := r.take("a", now.Add(30*time.Second)); !ok || got != st {
+		t.Errorf("take within the window = %+v, %v", got, ok)
+	}
+	if _, ok := r.take("a", now); ok {
+		t.Error("a state should only be resumed once")
+	}
+
+	r.save("b", st, now)
+	if _, ok := r.take("b", now.Add(2*time.Minute)); ok {
+		t.Error("a state past the window should not be resumed")
+	}
+
+	// Saving prunes expired entries, so dropped visitors don't pile up.
+	r.save("c", st, now)
+	r.save("d", st, now.Add(2*time.Minute))
+	if _, ok := r.states["c"]; ok {
+		t.Error("an expired entry should be pruned on save")
+	}
+}
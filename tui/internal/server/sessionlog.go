@@ -0,0 +1,58 @@
+package server
+
+import (
+	"log/slog"
+	"math/rand/v2"
+	"strconv"
+	"time"
+
+	"github.com/charmbracelet/ssh"
+)
+
+// sessionLogKey is the session context key of a session's sessionLog.
+type sessionLogKey struct{}
+
+// sessionLog identifies one visitor's session in the server log. Every
+// line logged for the session, from the connection limits to the TUI's
+// navigations and palette commands, carries its session_id, which its
+// analytics events share.
+type sessionLog struct {
+	id     string
+	logger *slog.Logger
+}
+
+// newSessionID returns a short ID for a session: its start time in base
+// 36, then a random part, so sessions starting on the same millisecond
+// are still told apart.
+func newSessionID() string {
+	return strconv.FormatInt(time.Now().UnixMilli(), 36) + "-" + strconv.FormatUint(uint64(rand.Uint32()), 36)
+}
+
+// startSessionLog creates sess's session log, with the visitor's address,
+// user name, and terminal attached, and keeps it in the session context
+// for the stages after the one that starts it.
+func (s *SSHServer) startSessionLog(sess ssh.Session) *sessionLog {
+	term := ""
+	if pty, _, ok := sess.Pty(); ok {
+		term = pty.Term
+	}
+	l := &sessionLog{id: newSessionID()}
+	l.logger = s.logger.With(
+		"session_id", l.id,
+		"remote_addr", sess.RemoteAddr().String(),
+		"user", sess.User(),
+		"ip", clientIP(sess),
+		"term", term,
+	)
+	sess.Context().SetValue(sessionLogKey{}, l)
+	return l
+}
+
+// sessionLog returns sess's session log, starting one if the session
+// skipped the sessions stage, as a chain edited without it does.
+func (s *SSHServer) sessionLog(sess ssh.Session) *sessionLog {
+	if l, ok := sess.Context().Value(sessionLogKey{}).(*sessionLog); ok {
+		return l
+	}
+	return s.startSessionLog(sess)
+}
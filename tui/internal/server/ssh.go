@@ -1,15 +1,22 @@
 package server
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"log/slog"
+	"math/rand/v2"
 	"net"
-	"strconv"
+	"os"
+	"path/filepath"
+	"sync"
 	"sync/atomic"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/ssh"
 	"github.com/charmbracelet/wish"
 	bm "github.com/charmbracelet/wish/bubbletea"
@@ -18,64 +25,244 @@ import (
 	"github.com/buntingszn/terminal-portfolio/tui/internal/analytics"
 	"github.com/buntingszn/terminal-portfolio/tui/internal/app"
 	"github.com/buntingszn/terminal-portfolio/tui/internal/app/sections"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/booking"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/braille"
 	"github.com/buntingszn/terminal-portfolio/tui/internal/config"
 	"github.com/buntingszn/terminal-portfolio/tui/internal/content"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/graphics"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/guestbook"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/i18n"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/plugins"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/proof"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/repostats"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/resume"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/source"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/status"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/textmode"
 )
 
 // SSHServer wraps a Wish SSH server that serves the Bubble Tea TUI.
 type SSHServer struct {
-	server      *ssh.Server
-	logger      *slog.Logger
-	content     *content.Content
-	cfg         *config.Config
-	analytics   *analytics.Logger
-	maxSessions int64
-	active      atomic.Int64
-}
-
-// New creates a new SSH server configured with Wish and Bubble Tea middleware.
-func New(cfg *config.Config, c *content.Content) (*SSHServer, error) {
-	al, err := analytics.NewLogger(cfg.AnalyticsFile)
+	server    *ssh.Server
+	logger    *slog.Logger
+	current   atomic.Pointer[snapshot]
+	analytics analytics.Sink // nil when analytics are disabled
+	guestbook *guestbook.Store
+	active    atomic.Int64
+
+	// cfg is the configuration in effect, replaced by Reload. Each
+	// session reads it once, as it starts.
+	cfg atomic.Pointer[config.Config]
+
+	// draining is set by Drain; new sessions are refused while it is.
+	draining atomic.Bool
+
+	// ln is the listener Start serves, closed early by StopListening,
+	// which sets lnClosed.
+	lnMu     sync.Mutex
+	ln       net.Listener
+	lnClosed bool
+
+	// limiter caps connections per client IP; nil when rate limiting is
+	// disabled. stopCleanup ends its periodic cleanup. reloadMu orders
+	// the reloads that replace them.
+	limiter     atomic.Pointer[RateLimiter]
+	stopCleanup context.CancelFunc
+	reloadMu    sync.Mutex
+
+	// monitor checks the owner's services for the status section; nil
+	// when the section is off. stopMonitor ends its checks.
+	monitor     *status.Monitor
+	stopMonitor context.CancelFunc
+
+	// booking previews the owner's open slots on the home section; nil
+	// when no preview URL is configured.
+	booking *booking.Preview
+
+	// repoStats adds live GitHub stats to work projects; nil without a
+	// GitHub token. stopRepoStats ends its refreshes.
+	repoStats     *repostats.Fetcher
+	stopRepoStats context.CancelFunc
+
+	// ownerKeys are the keys whose sessions count as the owner's.
+	ownerKeys []ssh.PublicKey
+
+	// lockout turns away IPs that failed to sign in too often; nil unless
+	// a private auth mode is on with a failure limit.
+	lockout *authLockout
+
+	// filter turns away IPs by the allow and deny lists; nil when both
+	// are empty. refused counts the connections turned away.
+	filter  atomic.Pointer[ipFilter]
+	refused refusals
+
+	// bans turns away IPs the rate limiter kept refusing; nil when
+	// auto-bans are off.
+	bans *banStore
+
+	// resume keeps the place of dropped sessions by key fingerprint; nil
+	// when resuming is disabled.
+	resume *resumeStore
+
+	// visitors remembers who has connected before, to greet returning
+	// visitors or skip their intro; nil unless RememberVisitors is set or
+	// SkipIntro is "returning".
+	visitors *visitorStore
+
+	// verifier signs the identity statement served by `ssh host verify`;
+	// nil without a verify key.
+	verifier *proof.Signer
+
+	// live lists the sessions past the connection limits, for the admin
+	// section, and programs the TUI of each, for broadcasts.
+	liveMu   sync.Mutex
+	live     map[ssh.Session]sections.AdminSession
+	programs map[ssh.Session]*tea.Program
+
+	// handler serves a session through the middleware chain, for the
+	// browser sessions WebHandler accepts. webCtx parents their contexts
+	// and stopWeb, called on shutdown, ends them; webWG counts those
+	// still open.
+	handler ssh.Handler
+	webCtx  context.Context
+	stopWeb context.CancelFunc
+	webWG   sync.WaitGroup
+}
+
+// contentSections are the sections built from each content file, hidden
+// while the file is unavailable. Home stays, showing what it can.
+var contentSections = map[string]app.Section{
+	"work.json":  app.SectionWork,
+	"cv.json":    app.SectionCV,
+	"links.json": app.SectionLinks,
+}
+
+// restartNotice is broadcast to open sessions when the server shuts down.
+const restartNotice = "Server restarting \u2014 your session will end shortly"
+
+// snapshot is the content new sessions are built from, replaced as a whole
+// by SetContent.
+type snapshot struct {
+	content  *content.Content
+	portrait *graphics.Image // nil keeps the braille portrait
+	art      string          // braille made from the photo; empty keeps the built-in art
+	keys     app.KeyMap      // the data directory's key bindings, or the defaults
+	proof    *proof.Proof    // nil when there is no verify key
+	// thumbnails is braille made from the talk thumbnails, by path.
+	thumbnails map[string]string
+}
+
+// New creates a new SSH server configured with Wish and Bubble Tea
+// middleware. Each edit adjusts the middleware chain in turn, starting
+// from DefaultChain, so callers can insert their own middleware.
+func New(cfg *config.Config, c *content.Content, edits ...func(Chain) Chain) (*SSHServer, error) {
+	al, err := openAnalytics(cfg)
 	if err != nil {
-		return nil, fmt.Errorf("create analytics logger: %w", err)
+		return nil, err
+	}
+
+	// The guestbook is optional: if its file cannot be opened (for example
+	// a read-only data directory), the section shows as unavailable. Remote
+	// data is replaced on every update, so its guestbook lives in the cache.
+	stateDir := cfg.DataDir
+	if source.Remote(cfg.DataDir) {
+		stateDir = cfg.ContentCache
+	}
+	gbPath := filepath.Join(stateDir, guestbook.FileName)
+	gb, err := guestbook.Open(gbPath, guestbook.DefaultInterval)
+	if err != nil {
+		slog.Warn("guestbook disabled", "path", gbPath, "err", err)
 	}
 
 	s := &SSHServer{
-		logger:      slog.Default(),
-		content:     c,
-		cfg:         cfg,
-		analytics:   al,
-		maxSessions: int64(cfg.MaxSessions),
+		logger:    slog.Default(),
+		analytics: al,
+		guestbook: gb,
+	}
+	s.cfg.Store(cfg)
+	s.filter.Store(newIPFilter(cfg))
+	if s.bans, err = openBanStore(filepath.Join(stateDir, bansFileName), cfg); err != nil {
+		return nil, err
+	}
+	if cfg.VerifyKey != "" {
+		if s.verifier, err = proof.LoadSigner(cfg.VerifyKey); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.GitHubToken != "" {
+		s.repoStats = repostats.NewFetcher(cfg.GitHubToken, filepath.Join(stateDir, repostats.FileName), cfg.RepoStatsInterval)
+	}
+	s.SetContent(c)
+	if s.repoStats != nil {
+		var ctx context.Context
+		ctx, s.stopRepoStats = context.WithCancel(context.Background())
+		go s.repoStats.Run(ctx)
+	}
+	if cfg.OwnerKeys != "" {
+		if s.ownerKeys, err = loadKeys("owner keys", cfg.OwnerKeys); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.Status != "" && cfg.Status != "off" {
+		checks, err := status.ParseChecks(cfg.StatusChecks)
+		if err != nil {
+			return nil, err
+		}
+		s.monitor = status.NewMonitor(checks, cfg.StatusInterval)
+		var ctx context.Context
+		ctx, s.stopMonitor = context.WithCancel(context.Background())
+		go s.monitor.Run(ctx)
+	}
+	if cfg.ResumeWindow > 0 {
+		s.resume = newResumeStore(cfg.ResumeWindow)
+	}
+	if cfg.RememberVisitors || cfg.SkipIntro == "returning" {
+		// Like the guestbook, a store that cannot be opened only costs
+		// returning visitors the skip.
+		path := filepath.Join(stateDir, visitorsFileName)
+		if s.visitors, err = openVisitorStore(path); err != nil {
+			slog.Warn("returning visitors not remembered", "path", path, "err", err)
+		}
+	}
+	if cfg.BookingPreviewURL != "" {
+		s.booking = booking.NewPreview(cfg.BookingPreviewURL, cfg.BookingPreviewTTL)
 	}
+	s.setRateLimit(cfg.RateLimit, cfg.RateWindow)
 
 	var srv *ssh.Server
 
 	addr := fmt.Sprintf("%s:%d", cfg.SSHHost, cfg.SSHPort)
 
-	if cfg.IdleTimeout > 0 {
-		// Apply SSH-level idle timeout alongside the standard options.
-		srv, err = wish.NewServer(
-			wish.WithAddress(addr),
-			wish.WithHostKeyPath(".ssh/terminal_portfolio_ed25519"),
-			wish.WithIdleTimeout(cfg.IdleTimeout),
-			wish.WithMiddleware(
-				s.recoveryMiddleware(),
-				s.sessionMiddleware(),
-				bm.MiddlewareWithColorProfile(s.teaHandler, termenv.TrueColor),
-			),
-		)
-	} else {
-		// Idle timeout disabled (0); omit WithIdleTimeout entirely.
-		srv, err = wish.NewServer(
-			wish.WithAddress(addr),
-			wish.WithHostKeyPath(".ssh/terminal_portfolio_ed25519"),
-			wish.WithMiddleware(
-				s.recoveryMiddleware(),
-				s.sessionMiddleware(),
-				bm.MiddlewareWithColorProfile(s.teaHandler, termenv.TrueColor),
-			),
-		)
+	chain := s.DefaultChain()
+	for _, edit := range edits {
+		chain = edit(chain)
+	}
+	middleware := chain.middleware()
+	s.handler = chain.handler()
+	s.webCtx, s.stopWeb = context.WithCancel(context.Background())
+
+	opts := []ssh.Option{
+		wish.WithAddress(addr),
+		wish.WithHostKeyPath(".ssh/terminal_portfolio_ed25519"),
+		wish.WithMiddleware(middleware...),
+		// acceptConn turns away filtered, banned and locked out IPs, and applies
+		// the idle timeout per connection, rather than with
+		// WithIdleTimeout, so a reload can change it.
+		ssh.WrapConn(s.acceptConn),
 	}
+	switch {
+	case cfg.Auth == "keys" || cfg.Auth == "password":
+		auth, err := s.privateAuth(cfg)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, auth...)
+	case len(s.ownerKeys) > 0 || s.resume != nil || s.visitors != nil:
+		// Without auth handlers the server accepts every client with no
+		// authentication at all, which leaves no key to recognize.
+		opts = append(opts, ownerAuth(s.ownerKeys, s.resume != nil || s.visitors != nil)...)
+	}
+	srv, err = wish.NewServer(opts...)
 	if err != nil {
 		return nil, fmt.Errorf("create SSH server: %w", err)
 	}
@@ -84,40 +271,291 @@ func New(cfg *config.Config, c *content.Content) (*SSHServer, error) {
 	return s, nil
 }
 
-// teaHandler returns a new Bubble Tea model for each SSH session.
-func (s *SSHServer) teaHandler(sess ssh.Session) (tea.Model, []tea.ProgramOption) {
-	theme := app.DarkTheme()
-	m := app.New(s.content,
-		sections.NewHomeSection(s.content, theme),
-		sections.NewWorkSection(s.content, theme),
-		sections.NewCVSection(s.content, theme),
-		sections.NewLinksSection(s.content, theme),
+// DefaultChain returns the server's middleware chain: panics are
+// recovered around everything, and clients over the rate or session
+// limits are refused before a command or the TUI starts.
+func (s *SSHServer) DefaultChain() Chain {
+	return Chain{
+		{StageRecovery, s.recoveryMiddleware()},
+		{StageRateLimit, s.rateLimitMiddleware()},
+		{StageSessions, s.sessionMiddleware()},
+		{StageCommand, s.commandMiddleware()},
+		{StageA11y, s.a11yMiddleware()},
+		{StageTUI, bm.MiddlewareWithProgramHandler(s.programHandler, termenv.Ascii)},
+	}
+}
+
+// programHandler creates the Bubble Tea program for a session, as
+// bm.Middleware would, and registers it for broadcasts until the session
+// ends. teaHandler's options already start from bm.MakeOptions, so the
+// input it picks is not replaced by the session's own. With the "auto"
+// slow link policy, the program is throttled once its output stalls.
+// Frames and the model's out-of-band sequences go through the session's
+// frame sink, so they reach it one whole write at a time and the link
+// monitor sees them all.
+func (s *SSHServer) programHandler(sess ssh.Session) *tea.Program {
+	sink := sessionSink(sess, s.cfg.Load().SlowLink)
+	m, opts := s.teaHandler(sess, sink)
+	opts = append(opts, tea.WithOutput(sink))
+	p := tea.NewProgram(m, opts...)
+	if slow := sink.slowLink(); slow != nil {
+		go func() {
+			select {
+			case <-slow:
+				s.sessionLog(sess).logger.Info("slow link, throttling session")
+				p.Send(app.SlowLinkMsg{})
+			case <-sess.Context().Done():
+			}
+		}()
+	}
+
+	s.liveMu.Lock()
+	if s.programs == nil {
+		s.programs = map[ssh.Session]*tea.Program{}
+	}
+	s.programs[sess] = p
+	s.liveMu.Unlock()
+	go func() {
+		<-sess.Context().Done()
+		s.liveMu.Lock()
+		delete(s.programs, sess)
+		s.liveMu.Unlock()
+	}()
+	return p
+}
+
+// Broadcast shows text in the status bar of every open TUI session.
+func (s *SSHServer) Broadcast(text string) {
+	s.send(app.BroadcastMsg{Text: text})
+}
+
+// send delivers msg to every open TUI session.
+func (s *SSHServer) send(msg tea.Msg) {
+	s.liveMu.Lock()
+	defer s.liveMu.Unlock()
+	for _, p := range s.programs {
+		// Send blocks until the program reads it, so a session still
+		// starting up cannot hold up the others.
+		go p.Send(msg)
+	}
+}
+
+// drainInterval is how often Drain updates the countdown in sessions.
+const drainInterval = time.Second
+
+// Drain refuses new sessions and shows open ones a countdown to restart,
+// updated every drainInterval, until d has passed, the last session has
+// ended, or ctx is done. Call Shutdown afterwards to close the sessions.
+func (s *SSHServer) Drain(ctx context.Context, d time.Duration) {
+	s.draining.Store(true)
+	s.logger.Info("draining sessions", "timeout", d, "active_sessions", s.ActiveSessions())
+
+	deadline := time.Now().Add(d)
+	for {
+		if s.ActiveSessions() == 0 {
+			s.logger.Info("drained: no sessions left")
+			return
+		}
+		remaining := time.Until(deadline)
+		s.send(app.ShutdownCountdownMsg{Remaining: remaining})
+		if remaining <= 0 {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(min(drainInterval, remaining)):
+		}
+	}
+}
+
+// teaHandler returns a new Bubble Tea model for each SSH session, writing
+// its out-of-band sequences to out.
+func (s *SSHServer) teaHandler(sess ssh.Session, out io.Writer) (tea.Model, []tea.ProgramOption) {
+	// Render with the colors this client advertises rather than forcing
+	// true color, which 256- and 16-color terminals garble.
+	term := ""
+	if pty, _, ok := sess.Pty(); ok {
+		term = pty.Term
+	}
+	// The content's theme.json, if any, recolors the built-in themes.
+	snap := s.current.Load()
+	cfg := s.cfg.Load()
+	renderer := lipgloss.NewRenderer(sess)
+	renderer.SetColorProfile(app.ColorProfile(term, sess.Environ()))
+
+	opts := bm.MakeOptions(sess)
+	opts = append(opts, tea.WithAltScreen(), tea.WithMouseCellMotion())
+	// The terminal probes read the replies from the session, so the
+	// program must read its input through the same reader afterwards. With
+	// the accessible mode in the chain that is its switch's.
+	var in *sessionInput
+	sw, _ := sess.Context().Value(a11ySwitchKey{}).(*a11ySwitch)
+	if sw != nil {
+		in = sw.in
+		opts = append(opts, tea.WithInput(sw))
+	}
+	input := func() *sessionInput {
+		if in == nil {
+			in = newSessionInput(sess.Context(), sess)
+			opts = append(opts, tea.WithInput(in))
+		}
+		return in
+	}
+
+	theme := sessionTheme(cfg.Theme, func() bool {
+		if _, _, ok := sess.Pty(); !ok {
+			return true
+		}
+		// Terminals that do not say are assumed dark, as by termenv.
+		dark, ok := probeBackground(input(), sess, probeTimeout)
+		return dark || !ok
+	}).ForRenderer(renderer).WithOverrides(snap.content.Theme)
+
+	// Assign this session to A/B experiment variants and build its content
+	// view with the chosen copy swapped in. Logging in as a language tag,
+	// as in ssh de@host, picks a locale.
+	locale, ok := i18n.Lookup(sess.User())
+	if !ok {
+		locale = i18n.Default()
+	}
+	variants := sessionVariants(snap.content, locale.Tag)
+	if sw != nil {
+		sw.variants = variants
+	}
+	localized := func(tag string) *content.Content {
+		return snap.content.Localized(tag).WithVariants(variants)
+	}
+	c := localized(locale.Tag)
+
+	// The session's ID tags its analytics events as well as its log.
+	sl := s.sessionLog(sess)
+	sid := sl.id
+	ip := clientIP(sess)
+	lastVisit := s.lastVisit(sess)
+
+	home := sections.NewHomeSection(c, theme)
+	home.SetPortraitArt(snap.art)
+	if cfg.RememberVisitors {
+		home.SetLastVisit(lastVisit)
+	}
+	if s.booking != nil {
+		home.SetBookingSlots(s.booking)
+	}
+	work := sections.NewWorkSection(c, theme)
+	if s.repoStats != nil {
+		work.SetRepoStats(s.repoStats)
+	}
+	talks := sections.NewTalksSection(c, theme)
+	talks.SetThumbnails(snap.thumbnails)
+	if pty, _, ok := sess.Pty(); ok && snap.portrait != nil {
+		caps := sessionGraphics(cfg.Graphics, func() (graphics.Capabilities, bool) {
+			return probeGraphics(input(), sess, probeTimeout)
+		}, pty.Term, sess.Environ())
+		home.SetPortraitImage(snap.portrait.Place(caps, rand.Uint32()))
+	}
+
+	m := app.New(c,
+		home,
+		work,
+		sections.NewCVSection(c, theme),
+		sections.NewLinksSection(c, theme),
+		sections.NewGuestbookSection(s.guestbook, guestbookAuthor(sess.User()), ip, theme),
+		sections.NewStatusSection(s.monitor, theme),
+		sections.NewAdminSection(s.adminSource(sess), theme),
+		sections.NewNotesSection(c, theme),
+		sections.NewUsesSection(c, theme),
+		talks,
 	)
+	m = m.SetSectionHidden(app.SectionStatus, !s.showStatus(sess))
+	for file, sec := range contentSections {
+		if !c.Available(file) {
+			m = m.SetSectionHidden(sec, true)
+		}
+	}
+	m = m.SetSectionHidden(app.SectionAdmin, !s.isOwner(sess))
+	m = m.SetTheme(theme)
+	m = m.SetLocale(locale).SetContentLocales(localized)
 	// Wire idle timeout warning into the Bubbletea model so users
 	// receive a 1-minute warning before the SSH idle disconnect.
-	m = m.SetIdleTimeout(s.cfg.IdleTimeout)
+	m = m.SetIdleTimeout(cfg.IdleTimeout)
+	m = m.SetScreensaver(cfg.ScreensaverAfter)
+	m = m.SetNavWrap(cfg.NavWrap)
+	m = m.SetStatusBarMode(cfg.StatusBar)
+	m = m.SetKeyMap(snap.keys)
+	m = m.SetReorderRTL(cfg.ReorderRTL)
+	m = m.SetReducedMotion(cfg.ReducedMotion)
+	if startsSlow(cfg.SlowLink, sess.Environ()) {
+		m = m.SetSlowLink(true)
+		opts = append(opts, tea.WithFPS(slowLinkFPS))
+	}
+	m = m.SetContentReview(cfg.ContentReview)
+	m = m.SetBell(cfg.Bell)
+	m = m.SetFrameCheck(cfg.Debug)
+	if cfg.Debug {
+		m = m.SetChaos(app.Chaos{
+			Latency:    cfg.ChaosLatency,
+			DropFrames: cfg.ChaosDropFrames,
+			Shrink:     cfg.ChaosShrink,
+		})
+	}
+	m = m.SetOutput(out)
+	m = m.SetLogger(sl.logger)
+	m = m.SetPaletteCommands(plugins.Commands())
 
-	// Generate a short session ID and extract the visitor's IP for analytics.
-	sid := strconv.FormatInt(time.Now().UnixMilli(), 36)
-	remoteAddr := sess.RemoteAddr().String()
-	ip, _, err := net.SplitHostPort(remoteAddr)
-	if err != nil {
-		ip = remoteAddr
+	if s.analytics != nil {
+		s.analytics.Log(analytics.Event{
+			Timestamp: time.Now(),
+			SessionID: sid,
+			Type:      analytics.EventSessionStart,
+			IP:        ip,
+			Variants:  variants,
+		})
+		m = m.SetAnalytics(s.analytics, sid, ip)
+	}
+	m = m.SetVariants(variants)
+	if sw != nil {
+		m = m.SetA11ySwitch(sw.start)
 	}
 
-	s.analytics.Log(analytics.Event{
-		Timestamp: time.Now(),
-		SessionID: sid,
-		Type:      analytics.EventSessionStart,
-		IP:        ip,
-	})
-	m = m.SetAnalytics(s.analytics, sid, ip)
+	if cfg.SkipIntro == "always" || cfg.SkipIntro == "returning" && !lastVisit.IsZero() {
+		m = m.SkipIntro()
+	}
 
-	opts := bm.MakeOptions(sess)
-	opts = append(opts, tea.WithAltScreen(), tea.WithMouseCellMotion())
+	// ssh cv@host opens the CV; any other user name starts at home.
+	sec, deepLink := app.SectionByName(sess.User())
+	if deepLink {
+		m = m.StartAt(sec)
+	}
+
+	if s.resume != nil && sess.PublicKey() != nil {
+		return s.resumable(sess, m, !deepLink), opts
+	}
 	return m, opts
 }
 
+// sessionVariants assigns a session in the locale tagged tag to the A/B
+// experiments of its content. Translations carry no experiments, so
+// sessions in one are assigned none, keep the translated copy, and log no
+// variants.
+func sessionVariants(c *content.Content, tag string) map[string]string {
+	return c.Localized(tag).AssignVariants(nil)
+}
+
+// guestbookAuthor returns the name a session logged in as user signs the
+// guestbook with. A user name that only routes the session, to a section
+// as in ssh cv@host, a locale as in ssh de@host, or text mode as in ssh
+// a11y@host, says nothing about the visitor, so those sign anonymously.
+func guestbookAuthor(user string) string {
+	if _, ok := app.SectionByName(user); ok {
+		return ""
+	}
+	if _, ok := i18n.Lookup(user); ok || user == A11yUser {
+		return ""
+	}
+	return user
+}
+
 // recoveryMiddleware catches panics in SSH session handlers, logs them,
 // and sends a user-friendly error message before closing the session.
 func (s *SSHServer) recoveryMiddleware() wish.Middleware {
@@ -138,31 +576,90 @@ func (s *SSHServer) recoveryMiddleware() wish.Middleware {
 	}
 }
 
+// commandMiddleware serves sessions that ask for a command, such as
+// `ssh host cv`, as plain text and exits without starting the TUI.
+// Sessions without a command fall through to the next handler.
+func (s *SSHServer) commandMiddleware() wish.Middleware {
+	return func(next ssh.Handler) ssh.Handler {
+		return func(sess ssh.Session) {
+			args := sess.Command()
+			if len(args) == 0 {
+				next(sess)
+				return
+			}
+
+			var out io.Writer = sess
+			if _, _, ok := sess.Pty(); ok {
+				if textmode.Binary(args[0]) {
+					_, _ = fmt.Fprintf(sess.Stderr(), "%s writes a file; run it without -t and redirect it, e.g. ssh <host> %s > %s\r\n",
+						args[0], args[0], resume.FileName)
+					_ = sess.Exit(1)
+					return
+				}
+				// `ssh -t` sessions have no line discipline translating
+				// newlines, so supply the carriage returns ourselves.
+				out = crlfWriter{sess}
+			}
+			var err error
+			if s.bans != nil && banCommands[args[0]] && s.isOwner(sess) {
+				err = s.runBanCommand(out, args)
+			} else {
+				snap := s.current.Load()
+				c := snap.content
+				err = textmode.Run(out, args[0], textmode.Source{
+					Content:   c.WithVariants(c.AssignVariants(nil)),
+					Guestbook: s.guestbook,
+					Proof:     snap.proof,
+					Args:      args[1:],
+				})
+			}
+			logger := s.sessionLog(sess).logger
+			if err != nil {
+				logger.Info("command failed", "command", args[0], "err", err)
+				_, _ = fmt.Fprintln(sess.Stderr(), err)
+				_ = sess.Exit(1)
+				return
+			}
+			logger.Info("command served", "command", args[0])
+			_ = sess.Exit(0)
+		}
+	}
+}
+
+// crlfWriter converts "\n" to "\r\n" on the way to a terminal.
+type crlfWriter struct{ w io.Writer }
+
+func (c crlfWriter) Write(p []byte) (int, error) {
+	if _, err := c.w.Write(bytes.ReplaceAll(p, []byte("\n"), []byte("\r\n"))); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
 // sessionMiddleware returns Wish middleware that handles connection limits
 // and session lifecycle logging.
 func (s *SSHServer) sessionMiddleware() wish.Middleware {
 	return func(next ssh.Handler) ssh.Handler {
 		return func(sess ssh.Session) {
-			remoteAddr := sess.RemoteAddr().String()
-			ip, _, err := net.SplitHostPort(remoteAddr)
-			if err != nil {
-				ip = remoteAddr
+			logger := s.startSessionLog(sess).logger
+			if sess.PublicKey() != nil {
+				s.authSucceeded(sess.Context())
 			}
 
-			logger := s.logger.With(
-				"remote_addr", remoteAddr,
-				"user", sess.User(),
-				"ip", ip,
-			)
-
 			// Check global connection limit.
 			current := s.active.Add(1)
 			defer s.active.Add(-1)
 
-			if current > s.maxSessions {
+			if s.draining.Load() {
+				logger.Info("SSH connection rejected: draining")
+				_, _ = fmt.Fprintln(sess, "Server is restarting. Please try again in a minute.")
+				_ = sess.Exit(1)
+				return
+			}
+			if max := int64(s.cfg.Load().MaxSessions); current > max {
 				logger.Warn("SSH connection rejected: at capacity",
 					"active", current,
-					"max", s.maxSessions,
+					"max", max,
 				)
 				_, _ = fmt.Fprintln(sess, "Server is at capacity. Please try again later.")
 				_ = sess.Exit(1)
@@ -170,6 +667,7 @@ func (s *SSHServer) sessionMiddleware() wish.Middleware {
 			}
 
 			logger.Info("SSH session started", "active_sessions", current)
+			defer s.trackSession(sess)()
 
 			// Run the next handler (Bubble Tea).
 			next(sess)
@@ -179,25 +677,271 @@ func (s *SSHServer) sessionMiddleware() wish.Middleware {
 	}
 }
 
+// rateLimitMiddleware returns Wish middleware that rejects clients opening
+// more than the configured number of connections per window from one IP.
+// It passes every session through when rate limiting is disabled.
+func (s *SSHServer) rateLimitMiddleware() wish.Middleware {
+	return func(next ssh.Handler) ssh.Handler {
+		return func(sess ssh.Session) {
+			limiter := s.limiter.Load()
+			if limiter == nil {
+				next(sess)
+				return
+			}
+			ip := clientIP(sess)
+			if !limiter.Allow(ip) {
+				s.logger.Warn("SSH connection rejected: rate limited",
+					"remote_addr", sess.RemoteAddr().String(),
+					"ip", ip,
+				)
+				_, _ = fmt.Fprintln(sess, "Too many connections. Please try again later.")
+				_ = sess.Exit(1)
+				s.banRefused(ip)
+				return
+			}
+			defer limiter.Release(ip)
+			next(sess)
+		}
+	}
+}
+
+// cleanupLimiter forgets limiter's idle IPs every window until ctx is
+// done.
+func cleanupLimiter(ctx context.Context, limiter *RateLimiter, window time.Duration) {
+	ticker := time.NewTicker(window)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			limiter.Cleanup()
+		}
+	}
+}
+
+// clientIP returns the IP address of the session's client.
+func clientIP(sess ssh.Session) string {
+	return addrIP(sess.RemoteAddr().String())
+}
+
+// addrIP returns the IP address in a host:port remote address.
+func addrIP(remoteAddr string) string {
+	ip, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		return remoteAddr
+	}
+	return ip
+}
+
+// SetContent replaces the content that new sessions are served, along
+// with the portrait photo found in its data directory. Sessions already
+// open keep the content they started with. Files the content could not
+// load are logged, since their sections are hidden from visitors.
+func (s *SSHServer) SetContent(c *content.Content) {
+	snap := &snapshot{content: c}
+	for _, file := range c.UnavailableFiles() {
+		s.logger.Warn("content file unavailable; its section is hidden", "file", file, "err", c.Unavailable[file])
+	}
+	// The portrait photo is optional; without it every session keeps the
+	// built-in braille portrait. With it, terminals that cannot show the
+	// photo get braille made from it, in the same box.
+	if c.Dir != "" {
+		cols, rows := sections.PortraitSize()
+		path := filepath.Join(c.Dir, graphics.PortraitFile)
+		opts := braille.DefaultOptions()
+		opts.Width, opts.Rows = cols, rows
+		art, err := braille.Load(path, opts)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			s.logger.Warn("portrait braille disabled", "path", path, "err", err)
+		}
+		snap.art = art
+		if s.cfg.Load().Graphics != "off" && err == nil {
+			img, err := graphics.Load(path, cols, rows)
+			if err != nil {
+				s.logger.Warn("portrait photo disabled", "path", path, "err", err)
+			}
+			snap.portrait = img
+		}
+
+		snap.thumbnails = s.talkThumbnails(c)
+
+		// The key map is optional too; a broken one is logged and the
+		// defaults kept, so a typo cannot lock visitors out.
+		km, err := app.LoadKeyMap(filepath.Join(c.Dir, app.KeyMapFile))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			s.logger.Warn("key map ignored", "err", err)
+		}
+		snap.keys = km
+	}
+	// The statement is signed once per content version, since every
+	// visitor sees the same one.
+	if s.verifier != nil {
+		p, err := s.verifier.Sign(c, time.Now())
+		if err != nil {
+			s.logger.Warn("verify statement disabled", "err", err)
+		} else {
+			snap.proof = &p
+		}
+	}
+	repos := make([]string, 0, len(c.Work.Projects))
+	for _, p := range c.Work.Projects {
+		repos = append(repos, p.Repo)
+	}
+	s.repoStats.SetRepos(repos)
+	s.current.Store(snap)
+}
+
+// talkThumbnails converts the thumbnail of each talk in c to braille, by
+// its path. A thumbnail that is missing or does not decode is logged and
+// left out, and its talk shows without one.
+func (s *SSHServer) talkThumbnails(c *content.Content) map[string]string {
+	var art map[string]string
+	opts := braille.DefaultOptions()
+	opts.Width, opts.Rows = sections.ThumbnailSize()
+	for _, t := range c.Talks {
+		if t.Thumbnail == "" {
+			continue
+		}
+		path := filepath.Join(c.Dir, t.Thumbnail)
+		a, err := braille.Load(path, opts)
+		if err != nil {
+			s.logger.Warn("talk thumbnail disabled", "path", path, "err", err)
+			continue
+		}
+		if art == nil {
+			art = make(map[string]string)
+		}
+		art[t.Thumbnail] = a
+	}
+	return art
+}
+
 // Start begins listening for SSH connections. This method blocks until
-// the server is shut down or an error occurs.
+// the server is shut down, StopListening is called, or an error occurs;
+// it returns nil in the first two cases.
 func (s *SSHServer) Start() error {
+	s.lnMu.Lock()
+	if s.lnClosed {
+		s.lnMu.Unlock()
+		return nil
+	}
 	ln, err := net.Listen("tcp", s.server.Addr)
 	if err != nil {
+		s.lnMu.Unlock()
 		return fmt.Errorf("listen %s: %w", s.server.Addr, err)
 	}
+	s.ln = ln
+	s.lnMu.Unlock()
+
 	s.logger.Info("SSH server listening", "addr", ln.Addr().String())
-	return s.server.Serve(ln)
+	err = s.server.Serve(ln)
+	s.lnMu.Lock()
+	defer s.lnMu.Unlock()
+	if errors.Is(err, ssh.ErrServerClosed) || s.lnClosed {
+		return nil
+	}
+	return err
+}
+
+// StopListening closes the SSH listener, so new connections are refused
+// and the port is free for the server replacing this one, while open
+// sessions carry on until Shutdown.
+func (s *SSHServer) StopListening() {
+	s.lnMu.Lock()
+	defer s.lnMu.Unlock()
+	s.lnClosed = true
+	if s.ln != nil {
+		_ = s.ln.Close()
+		s.logger.Info("SSH server stopped listening", "addr", s.ln.Addr().String())
+	}
 }
 
 // Shutdown gracefully shuts down the SSH server.
 func (s *SSHServer) Shutdown(ctx context.Context) error {
+	s.Broadcast(restartNotice)
 	err := s.server.Shutdown(ctx)
-	_ = s.analytics.Close()
+	s.stopWeb()
+	s.waitWeb(ctx)
+	s.reloadMu.Lock()
+	s.stopCleanup()
+	s.reloadMu.Unlock()
+	if s.stopMonitor != nil {
+		s.stopMonitor()
+	}
+	if s.stopRepoStats != nil {
+		s.stopRepoStats()
+	}
+	if s.analytics != nil {
+		_ = s.analytics.Close()
+	}
+	_ = s.guestbook.Close()
 	return err
 }
 
+// openAnalytics opens the analytics sink cfg selects: the SQLite store at
+// AnalyticsDSN if set, otherwise the rotating JSONL file. It returns nil
+// when both are empty.
+func openAnalytics(cfg *config.Config) (analytics.Sink, error) {
+	if cfg.AnalyticsDSN != "" {
+		st, err := analytics.OpenStore(cfg.AnalyticsDSN)
+		if err != nil {
+			return nil, fmt.Errorf("open analytics store: %w", err)
+		}
+		return st, nil
+	}
+	rotation := analytics.Rotation{
+		MaxSize:   int64(cfg.AnalyticsMaxSizeMB) << 20,
+		Retention: time.Duration(cfg.AnalyticsRetentionDays) * 24 * time.Hour,
+	}
+	if cfg.AnalyticsRotate != "off" {
+		rotation.Every = analytics.Period(cfg.AnalyticsRotate)
+	}
+	al, err := analytics.NewRotatingLogger(cfg.AnalyticsFile, rotation)
+	if err != nil {
+		return nil, fmt.Errorf("create analytics logger: %w", err)
+	}
+	if al == nil {
+		return nil, nil
+	}
+	return al, nil
+}
+
+// adminSource returns the admin section's data for the owner's sessions,
+// and nil for everyone else's.
+func (s *SSHServer) adminSource(sess ssh.Session) sections.AdminSource {
+	if !s.isOwner(sess) {
+		return nil
+	}
+	return adminSource{s}
+}
+
+// showStatus reports whether sess sees the status section.
+func (s *SSHServer) showStatus(sess ssh.Session) bool {
+	switch s.cfg.Load().Status {
+	case "public":
+		return true
+	case "owner":
+		return s.isOwner(sess)
+	default:
+		return false
+	}
+}
+
 // ActiveSessions returns the number of currently active sessions.
 func (s *SSHServer) ActiveSessions() int64 {
 	return s.active.Load()
 }
+
+// sessionTheme resolves the configured theme setting for one session. A
+// named theme is used as is; "auto" calls darkBackground, which queries the
+// client terminal, and picks the light theme for pale backgrounds.
+func sessionTheme(setting string, darkBackground func() bool) app.Theme {
+	if t, ok := app.ThemeByName(setting); ok {
+		return t
+	}
+	if darkBackground() {
+		return app.DarkTheme()
+	}
+	return app.LightTheme()
+}
@@ -0,0 +1,116 @@
+package server
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/ssh"
+)
+
+// visitorsFileName is the file in the state directory that remembers
+// returning visitors, for the RememberVisitors and "returning" SkipIntro
+// settings.
+const visitorsFileName = "visitors.json"
+
+// visitorMemory is how long a visitor is remembered after their last
+// visit.
+const visitorMemory = 180 * 24 * time.Hour
+
+// visitorStore remembers when each visitor first and last connected, by
+// their SSH key or, without one, their IP. Both are kept as hashes salted
+// per server, so the file can be matched neither against keys published
+// elsewhere nor against the addresses in logs. It is safe for concurrent
+// use.
+type visitorStore struct {
+	mu   sync.Mutex
+	path string
+	file visitorsFile
+}
+
+// visitorsFile is the saved form of a visitorStore.
+type visitorsFile struct {
+	Salt     string              `json:"salt"`
+	Visitors map[string]*visitor `json:"visitors"` // by hash
+}
+
+// visitor is what is remembered of one visitor.
+type visitor struct {
+	First time.Time `json:"first"`
+	Last  time.Time `json:"last"`
+}
+
+// openVisitorStore opens the visitors saved at path. A missing file
+// starts with none, under a new salt.
+func openVisitorStore(path string) (*visitorStore, error) {
+	v := &visitorStore{path: path}
+	data, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		salt := make([]byte, 16)
+		_, _ = rand.Read(salt)
+		v.file.Salt = hex.EncodeToString(salt)
+	case err != nil:
+		return nil, fmt.Errorf("read visitors: %w", err)
+	default:
+		if err := json.Unmarshal(data, &v.file); err != nil {
+			return nil, fmt.Errorf("parse visitors %s: %w", path, err)
+		}
+	}
+	if v.file.Visitors == nil {
+		v.file.Visitors = make(map[string]*visitor)
+	}
+	return v, nil
+}
+
+// visit records the visitor marked by marker, their key or IP, connecting
+// at now. For a returning visitor it returns when they last connected;
+// for a new one, the zero time.
+func (v *visitorStore) visit(marker []byte, now time.Time) (time.Time, error) {
+	sum := sha256.Sum256(append([]byte(v.file.Salt), marker...))
+	id := hex.EncodeToString(sum[:])
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	for id, r := range v.file.Visitors {
+		if now.Sub(r.Last) > visitorMemory {
+			delete(v.file.Visitors, id)
+		}
+	}
+	var last time.Time
+	r, ok := v.file.Visitors[id]
+	if ok {
+		last = r.Last
+	} else {
+		r = &visitor{First: now}
+		v.file.Visitors[id] = r
+	}
+	r.Last = now
+	return last, saveJSON(v.path, v.file)
+}
+
+// lastVisit records the session's visit and returns when its visitor was
+// last here, or the zero time for a new visitor or when visitors are not
+// remembered. Visitors are told apart by key, or by IP for sessions
+// without one.
+func (s *SSHServer) lastVisit(sess ssh.Session) time.Time {
+	if s.visitors == nil {
+		return time.Time{}
+	}
+	marker := []byte("ip " + clientIP(sess))
+	if key := sess.PublicKey(); key != nil {
+		marker = key.Marshal()
+	}
+	last, err := s.visitors.visit(marker, time.Now())
+	if err != nil {
+		s.sessionLog(sess).logger.Warn("failed to save visitors", "path", s.visitors.path, "err", err)
+	}
+	return last
+}
@@ -0,0 +1,58 @@
+package server
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestVisitorStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), visitorsFileName)
+	v, err := openVisitorStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, key := testSigner(t)
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	if last, err := v.visit(key.Marshal(), now); !last.IsZero() || err != nil {
+		t.Fatalf("first visit = %v, %v; want a new visitor", last, err)
+	}
+	if last, _ := v.visit(key.Marshal(), now.Add(time.Hour)); !last.Equal(now) {
+		t.Errorf("second visit's last visit = %v, want %v", last, now)
+	}
+
+	// Visitors outlive a restart, under the same salt.
+	v, err = openVisitorStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	later := now.Add(3 * 24 * time.Hour)
+	if last, _ := v.visit(key.Marshal(), later); !last.Equal(now.Add(time.Hour)) {
+		t.Errorf("last visit after a restart = %v, want %v", last, now.Add(time.Hour))
+	}
+	if last, _ := v.visit([]byte("ip 192.0.2.1"), later); !last.IsZero() {
+		t.Error("another visitor should be new")
+	}
+	if r := v.file.Visitors; len(r) != 2 {
+		t.Errorf("remembered %d visitors, want 2", len(r))
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "192.0.2.1") {
+		t.Error("the file should not hold IPs")
+	}
+
+	// A visitor away for longer than visitorMemory is forgotten.
+	gone := later.Add(visitorMemory + time.Hour)
+	if last, _ := v.visit(key.Marshal(), gone); !last.IsZero() {
+		t.Error("a visitor gone for longer than visitorMemory should be new again")
+	}
+	if r := v.file.Visitors; len(r) != 1 {
+		t.Errorf("remembered %d visitors, want only the one back", len(r))
+	}
+}
@@ -0,0 +1,306 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"embed"
+	"fmt"
+	"io"
+	"io/fs"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/ssh"
+	gossh "golang.org/x/crypto/ssh"
+	"golang.org/x/net/websocket"
+)
+
+// webReadyTimeout is how long a browser has, once connected, to report
+// its terminal size; the session cannot start without it.
+const webReadyTimeout = 10 * time.Second
+
+// webFiles holds the page that runs xterm.js and connects it to /ws.
+//
+//go:embed web
+var webFiles embed.FS
+
+// WebHandler returns an HTTP handler that serves the TUI to browsers: a
+// terminal page at / and, at /ws, the WebSocket it connects to. Each
+// connection runs as a session through the server's middleware chain, so
+// browser visitors get the same limits, logging, and analytics as SSH
+// ones. Opening /?user=cv starts on a section, as ssh cv@host does.
+func (s *SSHServer) WebHandler() http.Handler {
+	static, err := fs.Sub(webFiles, "web")
+	if err != nil {
+		panic(err)
+	}
+	mux := http.NewServeMux()
+	mux.Handle("GET /", http.FileServerFS(static))
+	mux.Handle("GET /ws", websocket.Server{Handshake: s.webHandshake, Handler: s.serveWeb})
+	return mux
+}
+
+// sameOrigin refuses WebSocket connections opened by pages on other
+// sites, which could otherwise run sessions from their visitors'
+// browsers. Clients that send no Origin are not browsers and are allowed.
+func sameOrigin(_ *websocket.Config, req *http.Request) error {
+	origin := req.Header.Get("Origin")
+	if origin == "" {
+		return nil
+	}
+	if u, err := url.Parse(origin); err != nil || u.Host != req.Host {
+		return fmt.Errorf("origin %q not allowed", origin)
+	}
+	return nil
+}
+
+// webHandshake refuses connections sameOrigin does, and those from IPs the
+// filter, an auto-ban or the sign-in lockout turns away, before the
+// WebSocket is upgraded.
+func (s *SSHServer) webHandshake(cfg *websocket.Config, req *http.Request) error {
+	if err := sameOrigin(cfg, req); err != nil {
+		return err
+	}
+	ip := addrIP(req.RemoteAddr)
+	if reason, rule := s.refuseIP(ip, time.Now()); reason != "" {
+		s.rejected("Web", ip, reason, rule)
+		return fmt.Errorf("connection from %s refused: %s", ip, reason)
+	}
+	return nil
+}
+
+// webMsg is a message from the page: keystrokes typed, or the terminal's
+// size after it changed.
+type webMsg struct {
+	Type string `json:"type"` // "input" or "resize"
+	Data string `json:"data,omitempty"`
+	Cols int    `json:"cols,omitempty"`
+	Rows int    `json:"rows,omitempty"`
+}
+
+// serveWeb runs one browser connection as a session. The page sends its
+// size first, so the TUI lays out for it from the first frame.
+func (s *SSHServer) serveWeb(ws *websocket.Conn) {
+	defer ws.Close()
+	s.liveMu.Lock()
+	if s.webCtx.Err() != nil {
+		s.liveMu.Unlock()
+		return
+	}
+	s.webWG.Add(1)
+	s.liveMu.Unlock()
+	defer s.webWG.Done()
+
+	ws.PayloadType = websocket.BinaryFrame
+	_ = ws.SetReadDeadline(time.Now().Add(webReadyTimeout))
+	var first webMsg
+	if err := websocket.JSON.Receive(ws, &first); err != nil || first.Type != "resize" || first.Cols < 1 || first.Rows < 1 {
+		s.logger.Debug("web connection closed before its terminal size", "remote_addr", ws.Request().RemoteAddr)
+		return
+	}
+
+	sess := newWebSession(s.webCtx, ws, ssh.Window{Width: first.Cols, Height: first.Rows})
+	defer sess.Close()
+	go sess.readInput(s.cfg.Load().IdleTimeout)
+	s.handler(sess)
+}
+
+// waitWeb waits for the browser sessions to end, or for ctx to be done.
+func (s *SSHServer) waitWeb(ctx context.Context) {
+	done := make(chan struct{})
+	go func() {
+		s.webWG.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+	}
+}
+
+// webSession is a browser terminal posing as an SSH session with an
+// emulated PTY, as the middleware chain expects. Its user name comes from
+// the page's user query parameter, and it never has a public key, so
+// browser visitors are neither the owner nor resumed.
+type webSession struct {
+	ws    *websocket.Conn
+	ctx   *webContext
+	out   io.Writer
+	in    *io.PipeReader
+	inW   *io.PipeWriter
+	pty   ssh.Pty
+	winch chan ssh.Window
+	once  sync.Once
+}
+
+var (
+	_ ssh.Session = (*webSession)(nil)
+	_ frameSinker = (*webSession)(nil)
+)
+
+func newWebSession(parent context.Context, ws *websocket.Conn, win ssh.Window) *webSession {
+	req := ws.Request()
+	ctx, cancel := context.WithCancel(parent)
+	local, _ := req.Context().Value(http.LocalAddrContextKey).(net.Addr)
+	in, inW := io.Pipe()
+	w := &webSession{
+		ws: ws,
+		ctx: &webContext{
+			Context: ctx,
+			cancel:  cancel,
+			user:    req.URL.Query().Get("user"),
+			id:      rand.Text(),
+			remote:  webAddr(req.RemoteAddr),
+			local:   local,
+			values:  make(map[any]any),
+		},
+		// Like SSH clients with a PTY, the page expects "\r\n" line ends.
+		out:   ssh.NewPtyWriter(frameWriter{ws}),
+		in:    in,
+		inW:   inW,
+		pty:   ssh.Pty{Term: "xterm-256color", Window: win},
+		winch: make(chan ssh.Window, 1),
+	}
+	w.winch <- win
+	return w
+}
+
+// readInput forwards the page's keystrokes to the session and its
+// resizes to the TUI until the page goes away, or sends nothing for
+// longer than idle if that is set, then closes the session.
+func (w *webSession) readInput(idle time.Duration) {
+	defer w.Close()
+	for {
+		deadline := time.Time{}
+		if idle > 0 {
+			deadline = time.Now().Add(idle)
+		}
+		_ = w.ws.SetReadDeadline(deadline)
+		var msg webMsg
+		if err := websocket.JSON.Receive(w.ws, &msg); err != nil {
+			return
+		}
+		switch msg.Type {
+		case "input":
+			if _, err := io.WriteString(w.inW, msg.Data); err != nil {
+				return
+			}
+		case "resize":
+			if msg.Cols < 1 || msg.Rows < 1 {
+				continue
+			}
+			select {
+			case w.winch <- ssh.Window{Width: msg.Cols, Height: msg.Rows}:
+			case <-w.ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// frameWriter sends each write to the page as one binary message.
+type frameWriter struct{ ws *websocket.Conn }
+
+func (f frameWriter) Write(p []byte) (int, error) {
+	if err := websocket.Message.Send(f.ws, p); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// frameSink implements frameSinker. Frames go straight to the WebSocket,
+// one message per write, watched for a slow link as SSH channels are.
+func (w *webSession) frameSink(policy string) frameSink {
+	return linkSink(w.out, policy, w.Environ())
+}
+
+func (w *webSession) Read(p []byte) (int, error)  { return w.in.Read(p) }
+func (w *webSession) Write(p []byte) (int, error) { return w.out.Write(p) }
+
+// Close ends the session: the TUI quits and the page is disconnected.
+func (w *webSession) Close() error {
+	var err error
+	w.once.Do(func() {
+		w.ctx.cancel()
+		_ = w.in.Close()
+		err = w.ws.Close()
+	})
+	return err
+}
+
+func (w *webSession) CloseWrite() error { return w.Close() }
+
+func (w *webSession) SendRequest(string, bool, []byte) (bool, error) { return false, nil }
+func (w *webSession) Stderr() io.ReadWriter                          { return w }
+func (w *webSession) User() string                                   { return w.ctx.user }
+func (w *webSession) RemoteAddr() net.Addr                           { return w.ctx.remote }
+func (w *webSession) LocalAddr() net.Addr                            { return w.ctx.local }
+func (w *webSession) Environ() []string                              { return []string{"COLORTERM=truecolor"} }
+func (w *webSession) Exit(int) error                                 { return w.Close() }
+func (w *webSession) Command() []string                              { return nil }
+func (w *webSession) RawCommand() string                             { return "" }
+func (w *webSession) Subsystem() string                              { return "" }
+func (w *webSession) PublicKey() ssh.PublicKey                       { return nil }
+func (w *webSession) Context() ssh.Context                           { return w.ctx }
+func (w *webSession) Permissions() ssh.Permissions                   { return *w.ctx.Permissions() }
+func (w *webSession) EmulatedPty() bool                              { return true }
+func (w *webSession) Signals(chan<- ssh.Signal)                      {}
+func (w *webSession) Break(chan<- bool)                              {}
+
+func (w *webSession) Pty() (ssh.Pty, <-chan ssh.Window, bool) {
+	return w.pty, w.winch, true
+}
+
+// webContext is the ssh.Context of a webSession. It is canceled when the
+// session closes.
+type webContext struct {
+	context.Context
+	sync.Mutex
+	cancel context.CancelFunc
+
+	user   string
+	id     string
+	remote net.Addr
+	local  net.Addr
+
+	valuesMu sync.Mutex
+	values   map[any]any
+}
+
+var _ ssh.Context = (*webContext)(nil)
+
+func (c *webContext) User() string          { return c.user }
+func (c *webContext) SessionID() string     { return c.id }
+func (c *webContext) ClientVersion() string { return "websocket" }
+func (c *webContext) ServerVersion() string { return "" }
+func (c *webContext) RemoteAddr() net.Addr  { return c.remote }
+func (c *webContext) LocalAddr() net.Addr   { return c.local }
+
+func (c *webContext) Permissions() *ssh.Permissions {
+	return &ssh.Permissions{Permissions: &gossh.Permissions{}}
+}
+
+func (c *webContext) SetValue(key, value any) {
+	c.valuesMu.Lock()
+	defer c.valuesMu.Unlock()
+	c.values[key] = value
+}
+
+func (c *webContext) Value(key any) any {
+	c.valuesMu.Lock()
+	v, ok := c.values[key]
+	c.valuesMu.Unlock()
+	if ok {
+		return v
+	}
+	return c.Context.Value(key)
+}
+
+// webAddr is a browser's address as the HTTP server reported it.
+type webAddr string
+
+func (a webAddr) Network() string { return "tcp" }
+func (a webAddr) String() string  { return string(a) }
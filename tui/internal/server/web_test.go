@@ -0,0 +1,156 @@
+package server
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/net/websocket"
+
+	"github.com/buntingszn/terminal-portfolio/tui/internal/config"
+)
+
+// dialWeb opens the WebSocket of a web handler served at base, as its own
+// page would.
+func dialWeb(t *testing.T, base, query string) (*websocket.Conn, error) {
+	t.Helper()
+	return websocket.Dial("ws"+strings.TrimPrefix(base, "http")+"/ws"+query, "", base)
+}
+
+// serveWebFrom serves the web handler as if every client were at remote,
+// which leaves the test server's own SSH connections from loopback alone.
+func serveWebFrom(srv *SSHServer, remote string) *httptest.Server {
+	handler := srv.WebHandler()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.RemoteAddr = remote
+		handler.ServeHTTP(w, r)
+	}))
+}
+
+func TestWebHandler_ServesPage(t *testing.T) {
+	srv, _ := startTestServer(t, 10)
+	web := httptest.NewServer(srv.WebHandler())
+	defer web.Close()
+
+	resp, err := http.Get(web.URL + "/")
+	if err != nil {
+		t.Fatalf("GET /: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK || !strings.Contains(string(body), "xterm") {
+		t.Errorf("GET / = %d, want the terminal page", resp.StatusCode)
+	}
+}
+
+// TestWebHandler_Session verifies that a browser session runs through the
+// session middleware and honors the user name as SSH does.
+func TestWebHandler_Session(t *testing.T) {
+	srv, _ := startTestServer(t, 10)
+	web := httptest.NewServer(srv.WebHandler())
+	defer web.Close()
+
+	ws, err := dialWeb(t, web.URL, "?user=cv")
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer func() { _ = ws.Close() }()
+	if err := websocket.JSON.Send(ws, webMsg{Type: "resize", Cols: 80, Rows: 24}); err != nil {
+		t.Fatalf("send size: %v", err)
+	}
+
+	var out strings.Builder
+	_ = ws.SetReadDeadline(time.Now().Add(5 * time.Second))
+	for !strings.Contains(out.String(), "EXPERIENCE") {
+		var frame []byte
+		if err := websocket.Message.Receive(ws, &frame); err != nil {
+			t.Fatalf("timed out waiting for the CV: %v", err)
+		}
+		out.Write(frame)
+	}
+	if n := srv.ActiveSessions(); n != 1 {
+		t.Errorf("ActiveSessions() = %d, want 1", n)
+	}
+}
+
+func TestWebHandler_RejectsOtherOrigin(t *testing.T) {
+	srv, _ := startTestServer(t, 10)
+	web := httptest.NewServer(srv.WebHandler())
+	defer web.Close()
+
+	url := "ws" + strings.TrimPrefix(web.URL, "http") + "/ws"
+	if ws, err := websocket.Dial(url, "", "https://elsewhere.example"); err == nil {
+		_ = ws.Close()
+		t.Error("expected a connection from another origin to be refused")
+	}
+}
+
+func TestWebHandler_IPFilter(t *testing.T) {
+	srv, _ := startConfiguredServer(t, 10, func(cfg *config.Config) {
+		cfg.DenyIPs = "192.0.2.0/24"
+	})
+	web := serveWebFrom(srv, "192.0.2.7:40000")
+	defer web.Close()
+
+	if ws, err := dialWeb(t, web.URL, ""); err == nil {
+		_ = ws.Close()
+		t.Fatal("a denied IP should not open the WebSocket")
+	}
+	if got := srv.refused.String(); got != "deny list 1" {
+		t.Errorf("refused = %q, want deny list 1", got)
+	}
+}
+
+func TestWebHandler_BansAndLockout(t *testing.T) {
+	srv, _ := startConfiguredServer(t, 10, func(cfg *config.Config) {
+		cfg.DataDir = t.TempDir()
+		cfg.Auth = "password"
+		cfg.AuthPassword = "hunter2"
+		cfg.AuthMaxFailures = 1
+		cfg.AuthLockout = time.Hour
+		cfg.AutoBanStrikes = 1
+		cfg.AutoBanWindow = time.Hour
+		cfg.AutoBanDuration = time.Hour
+		cfg.AutoBanMax = time.Hour
+	})
+	now := time.Now()
+	if _, err := srv.bans.strike("192.0.2.7", now); err != nil {
+		t.Fatal(err)
+	}
+	srv.lockout.fail("192.0.2.8", now)
+
+	for _, remote := range []string{"192.0.2.7:40000", "192.0.2.8:40000"} {
+		web := serveWebFrom(srv, remote)
+		if ws, err := dialWeb(t, web.URL, ""); err == nil {
+			_ = ws.Close()
+			t.Errorf("%s should not open the WebSocket", remote)
+		}
+		web.Close()
+	}
+	if got := srv.refused.String(); got != "banned 1, locked out 1" {
+		t.Errorf("refused = %q, want banned 1, locked out 1", got)
+	}
+}
+
+func TestWebHandler_ClosesWithoutSize(t *testing.T) {
+	srv, _ := startTestServer(t, 10)
+	web := httptest.NewServer(srv.WebHandler())
+	defer web.Close()
+
+	ws, err := dialWeb(t, web.URL, "")
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer func() { _ = ws.Close() }()
+	if err := websocket.JSON.Send(ws, webMsg{Type: "input", Data: " "}); err != nil {
+		t.Fatalf("send: %v", err)
+	}
+	_ = ws.SetReadDeadline(time.Now().Add(5 * time.Second))
+	var frame []byte
+	if err := websocket.Message.Receive(ws, &frame); err == nil {
+		t.Errorf("got %q, want the connection closed before a session starts", frame)
+	}
+}
@@ -0,0 +1,91 @@
+package source
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/buntingszn/terminal-portfolio/tui/internal/config"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/content"
+)
+
+// Loader keeps a server's content in step with its data directory. Open
+// fetches the directory and loads it for startup; Poll and Reload load
+// the copies that follow. The SSH and web servers both load content
+// through it.
+type Loader struct {
+	src     *Shared
+	remote  bool
+	refresh time.Duration
+	load    func(content.Source) (*content.Content, error)
+}
+
+// Open fetches the data directory cfg names with New and SyncOnce, and
+// loads the content in it. With partial content, files that fail to load
+// are left out and logged rather than failing. A data directory without
+// content serves the built-in defaults, with a warning.
+func Open(ctx context.Context, cfg *config.Config) (*Loader, *content.Content, error) {
+	src, err := New(cfg.DataDir, cfg.ContentCache, cfg.ContentSHA256, cfg.ContentPublicKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid data directory: %w", err)
+	}
+	l := &Loader{
+		src:     NewShared(src),
+		remote:  Remote(cfg.DataDir),
+		refresh: cfg.ContentRefresh,
+		load:    content.Source.Load,
+	}
+	if cfg.PartialContent {
+		l.load = content.Source.LoadPartial
+	}
+	dir, err := SyncOnce(ctx, l.src)
+	if err != nil {
+		return nil, nil, fmt.Errorf("fetch content: %w", err)
+	}
+	data, onDisk := content.DataSource(dir)
+	if !onDisk {
+		slog.Warn("no content in the data directory; serving the built-in defaults", "data_dir", dir)
+	}
+	c, err := l.load(data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("load content: %w", err)
+	}
+	return l, c, nil
+}
+
+// Poll keeps remote content up to date until ctx is done, passing each
+// new copy that loads to serve. It returns at once for a local data
+// directory or when the refresh interval is off.
+func (l *Loader) Poll(ctx context.Context, serve func(*content.Content)) {
+	if !l.remote || l.refresh <= 0 {
+		return
+	}
+	l.src.Poll(ctx, l.refresh, l.update(serve))
+}
+
+// Reload syncs the data directory and passes its content to serve, even
+// when the copy has not changed. A poll in progress finishes first.
+func (l *Loader) Reload(ctx context.Context, serve func(*content.Content)) error {
+	return l.src.Update(ctx, l.update(serve))
+}
+
+// update returns the update function loading the content in a synced
+// directory. Content that fails to load is skipped, leaving sessions on
+// the last good copy.
+func (l *Loader) update(serve func(*content.Content)) func(dir string) {
+	return func(dir string) {
+		data, ok := content.DataSource(dir)
+		if !ok {
+			slog.Error("updated content rejected", "err", "no content directory")
+			return
+		}
+		c, err := l.load(data)
+		if err != nil {
+			slog.Error("updated content rejected", "err", err)
+			return
+		}
+		serve(c)
+		slog.Info("content updated", "name", c.Meta.Name)
+	}
+}
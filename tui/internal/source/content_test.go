@@ -0,0 +1,55 @@
+package source
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/buntingszn/terminal-portfolio/tui/internal/config"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/content"
+)
+
+func TestOpen(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+	loader, c, err := Open(ctx, &config.Config{DataDir: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// An empty data directory serves the built-in defaults.
+	defaults, err := content.Embedded().Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Meta.Name != defaults.Meta.Name {
+		t.Errorf("content for an empty directory = %q, want the defaults' %q", c.Meta.Name, defaults.Meta.Name)
+	}
+
+	// A reload with no content directory keeps the last good copy.
+	var served []*content.Content
+	serve := func(c *content.Content) { served = append(served, c) }
+	if err := loader.Reload(ctx, serve); err != nil {
+		t.Fatal(err)
+	}
+	if len(served) != 0 {
+		t.Errorf("a reload without content served %d copies", len(served))
+	}
+
+	if err := os.CopyFS(dir, os.DirFS(filepath.Join("..", "testutil", "testdata"))); err != nil {
+		t.Fatal(err)
+	}
+	if err := loader.Reload(ctx, serve); err != nil {
+		t.Fatal(err)
+	}
+	if len(served) != 1 || served[0].Meta.Name == "" {
+		t.Fatalf("a reload with content served %v", served)
+	}
+
+	// A local directory is not polled, so Poll returns at once.
+	loader.Poll(ctx, serve)
+
+	if _, _, err := Open(ctx, &config.Config{DataDir: "https://example.com/d.tar.gz"}); err == nil {
+		t.Error("a remote source without a cache should fail")
+	}
+}
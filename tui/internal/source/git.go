@@ -0,0 +1,88 @@
+package source
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Git keeps a shallow clone of a repository using the git command. Git's
+// content addressing already guards the copy against corruption in
+// transit. Without a Ref the clone follows the remote's default branch, so
+// whoever can push to it changes the content; pinning Ref to a full commit
+// hash fixes the content to exactly that commit.
+type Git struct {
+	URL    string
+	Subdir string // data directory inside the repository
+	Ref    string // commit or tag to check out; empty follows the default branch
+	Cache  string // the clone is kept in Cache/git
+
+	head string // commit of the synced copy
+}
+
+// Sync implements Source. The first Sync clones the repository; later ones
+// fetch the remote's default branch, or Ref, and reset the clone to it,
+// discarding anything else in the working tree. Once a commit pinned by
+// Ref is checked out, Sync leaves it be.
+func (g *Git) Sync(ctx context.Context) (string, bool, error) {
+	dir := filepath.Join(g.Cache, "git")
+	data := filepath.Join(dir, g.Subdir)
+	if g.Ref != "" && g.head == g.Ref {
+		return data, false, nil
+	}
+	cloned := false
+	if _, err := os.Stat(filepath.Join(dir, ".git")); err != nil {
+		if err := os.RemoveAll(dir); err != nil {
+			return "", false, err
+		}
+		if err := os.MkdirAll(g.Cache, 0o755); err != nil {
+			return "", false, err
+		}
+		if _, err := runGit(ctx, "", "clone", "--quiet", "--depth", "1", "--", g.URL, dir); err != nil {
+			return "", false, err
+		}
+		cloned = true
+	}
+	if !cloned || g.Ref != "" {
+		target := "HEAD"
+		if g.Ref != "" {
+			target = g.Ref
+		}
+		for _, args := range [][]string{
+			{"fetch", "--quiet", "--depth", "1", "origin", target},
+			{"reset", "--quiet", "--hard", "FETCH_HEAD"},
+			{"clean", "--quiet", "-ffdx"},
+		} {
+			if _, err := runGit(ctx, dir, args...); err != nil {
+				return data, false, err
+			}
+		}
+	}
+
+	head, err := runGit(ctx, dir, "rev-parse", "HEAD")
+	if err != nil {
+		return data, false, err
+	}
+	changed := head != g.head
+	g.head = head
+	return data, changed, nil
+}
+
+// runGit runs git with args in dir and returns its trimmed output. Git is
+// never allowed to prompt, since there is no one to answer.
+func runGit(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(string(out)), nil
+}
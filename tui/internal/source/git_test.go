@@ -0,0 +1,134 @@
+package source
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// gitRepo creates a repository with one commit holding data/content/meta.json
+// and returns its path and a function committing new contents.
+func gitRepo(t *testing.T) (string, func(meta string)) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	repo := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repo
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	commit := func(meta string) {
+		t.Helper()
+		path := filepath.Join(repo, "data", "content", "meta.json")
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(meta), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		git("add", "-A")
+		git("commit", "--quiet", "-m", meta)
+	}
+	git("init", "--quiet")
+	commit("v1")
+	return repo, commit
+}
+
+func TestGitSync(t *testing.T) {
+	repo, commit := gitRepo(t)
+	g := &Git{URL: "file://" + repo, Subdir: "data", Cache: t.TempDir()}
+	ctx := context.Background()
+
+	dir, changed, err := g.Sync(ctx)
+	if err != nil || !changed {
+		t.Fatalf("first Sync = %q, %v, %v", dir, changed, err)
+	}
+	meta := filepath.Join(dir, "content", "meta.json")
+	if b, _ := os.ReadFile(meta); string(b) != "v1" {
+		t.Errorf("cloned meta.json = %q", b)
+	}
+
+	if _, changed, err := g.Sync(ctx); err != nil || changed {
+		t.Errorf("unchanged Sync reported %v, %v", changed, err)
+	}
+
+	commit("v2")
+	if err := os.WriteFile(filepath.Join(dir, "stray.txt"), []byte("x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, changed, err := g.Sync(ctx); err != nil || !changed {
+		t.Fatalf("updated Sync reported %v, %v", changed, err)
+	}
+	if b, _ := os.ReadFile(meta); string(b) != "v2" {
+		t.Errorf("pulled meta.json = %q", b)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "stray.txt")); err == nil {
+		t.Error("files not in the repository should be removed")
+	}
+}
+
+func TestGitSyncPinned(t *testing.T) {
+	repo, commit := gitRepo(t)
+	rev := func() string {
+		t.Helper()
+		out, err := exec.Command("git", "-C", repo, "rev-parse", "HEAD").Output()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return strings.TrimSpace(string(out))
+	}
+	v1 := rev()
+	commit("v2")
+	g := &Git{URL: "file://" + repo, Subdir: "data", Ref: v1, Cache: t.TempDir()}
+	ctx := context.Background()
+
+	dir, changed, err := g.Sync(ctx)
+	if err != nil || !changed {
+		t.Fatalf("first Sync = %q, %v, %v", dir, changed, err)
+	}
+	meta := filepath.Join(dir, "content", "meta.json")
+	if b, _ := os.ReadFile(meta); string(b) != "v1" {
+		t.Errorf("pinned meta.json = %q, want the pinned commit's", b)
+	}
+	// New commits on the branch do not move a pinned copy.
+	commit("v3")
+	if _, changed, err := g.Sync(ctx); err != nil || changed {
+		t.Errorf("pinned Sync after a push reported %v, %v", changed, err)
+	}
+	if b, _ := os.ReadFile(meta); string(b) != "v1" {
+		t.Errorf("pinned meta.json after a push = %q", b)
+	}
+
+	// Moving the pin to a tag checks it out.
+	if out, err := exec.Command("git", "-C", repo, "tag", "v2.0", "HEAD~1").CombinedOutput(); err != nil {
+		t.Fatalf("git tag: %v\n%s", err, out)
+	}
+	g.Ref = "v2.0"
+	if _, changed, err := g.Sync(ctx); err != nil || !changed {
+		t.Fatalf("Sync to a tag reported %v, %v", changed, err)
+	}
+	if b, _ := os.ReadFile(meta); string(b) != "v2" {
+		t.Errorf("tagged meta.json = %q, want v2", b)
+	}
+}
+
+func TestGitSyncFailure(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	g := &Git{URL: "file://" + filepath.Join(t.TempDir(), "missing"), Cache: t.TempDir()}
+	if _, _, err := g.Sync(context.Background()); err == nil {
+		t.Error("cloning a missing repository should fail")
+	}
+}
@@ -0,0 +1,273 @@
+package source
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// maxBundleSize bounds both a downloaded bundle and its unpacked contents.
+const maxBundleSize = 64 << 20
+
+// bundlePrefix names the cache directories bundles are unpacked into.
+const bundlePrefix = "bundle-"
+
+// ErrChecksum is returned when a bundle does not match its checksum.
+var ErrChecksum = errors.New("bundle checksum mismatch")
+
+// ErrSignature is returned when a bundle's signature does not verify
+// against the configured public key.
+var ErrSignature = errors.New("bundle signature invalid")
+
+// HTTPS fetches the data directory as a gzipped tar bundle whose root is
+// the data directory (holding content/ and, optionally, portrait.png).
+// Every bundle is verified before it is unpacked. With a PublicKey, the
+// file at URL with ".sig" appended must hold an ed25519 signature of the
+// bundle, raw or base64. Sum, when set, pins the bundle's SHA-256. With
+// neither, the bundle is checked against the sha256sum-style file at URL
+// with ".sha256" appended, which guards against corruption but not
+// against a compromised host.
+type HTTPS struct {
+	URL       string
+	Sum       string            // pinned hex SHA-256
+	PublicKey ed25519.PublicKey // key bundles must be signed with
+	Subdir    string            // data directory inside the bundle
+	Cache     string            // bundles are unpacked into directories here
+	Client    *http.Client      // nil uses a client with a 30 second timeout
+
+	etag string // of the bundle in use, for conditional requests
+	sum  string // checksum of the bundle in use
+	dir  string // where the bundle in use is unpacked
+}
+
+// Sync implements Source. An unchanged bundle, by ETag or by checksum, is
+// not unpacked again.
+func (h *HTTPS) Sync(ctx context.Context) (string, bool, error) {
+	body, etag, err := h.get(ctx, h.URL, h.etag)
+	if err != nil {
+		return h.current(), false, err
+	}
+	if body == nil {
+		return h.current(), false, nil
+	}
+
+	if err := h.verify(ctx, body); err != nil {
+		return h.current(), false, err
+	}
+	digest := sha256.Sum256(body)
+	got := hex.EncodeToString(digest[:])
+	if got == h.sum {
+		h.etag = etag
+		return h.current(), false, nil
+	}
+
+	dir := filepath.Join(h.Cache, bundlePrefix+got[:16])
+	if err := unpack(body, h.Cache, dir); err != nil {
+		return h.current(), false, fmt.Errorf("unpack bundle: %w", err)
+	}
+	h.etag, h.sum, h.dir = etag, got, dir
+	prune(h.Cache, dir)
+	return h.current(), true, nil
+}
+
+// verify checks body against the signature and checksums configured for
+// h, fetching the published signature or checksum as needed.
+func (h *HTTPS) verify(ctx context.Context, body []byte) error {
+	if h.PublicKey != nil {
+		file, _, err := h.get(ctx, h.URL+".sig", "")
+		if err != nil {
+			return fmt.Errorf("fetch signature: %w", err)
+		}
+		sig, err := decodeSignature(file)
+		if err != nil {
+			return fmt.Errorf("%s.sig: %w", h.URL, err)
+		}
+		if !ed25519.Verify(h.PublicKey, body, sig) {
+			return ErrSignature
+		}
+	}
+
+	want := h.Sum
+	if want == "" {
+		if h.PublicKey != nil {
+			return nil
+		}
+		file, _, err := h.get(ctx, h.URL+".sha256", "")
+		if err != nil {
+			return fmt.Errorf("fetch checksum: %w", err)
+		}
+		want, _, _ = strings.Cut(strings.TrimSpace(string(file)), " ")
+		if !validSum(want) {
+			return fmt.Errorf("%s.sha256 does not hold a SHA-256 checksum", h.URL)
+		}
+	}
+	digest := sha256.Sum256(body)
+	if got := hex.EncodeToString(digest[:]); !strings.EqualFold(got, want) {
+		return fmt.Errorf("%w: got %s, want %s", ErrChecksum, got, strings.ToLower(want))
+	}
+	return nil
+}
+
+// decodeSignature accepts an ed25519 signature as the raw 64 bytes written
+// by tools such as openssl, or as base64 text.
+func decodeSignature(file []byte) ([]byte, error) {
+	if len(file) == ed25519.SignatureSize {
+		return file, nil
+	}
+	sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(file)))
+	if err != nil || len(sig) != ed25519.SignatureSize {
+		return nil, errors.New("not an ed25519 signature")
+	}
+	return sig, nil
+}
+
+// current returns the data directory of the bundle in use, or "" before
+// the first successful Sync.
+func (h *HTTPS) current() string {
+	if h.dir == "" {
+		return ""
+	}
+	return filepath.Join(h.dir, h.Subdir)
+}
+
+// get fetches url. When etag matches the server's copy, it returns a nil
+// body.
+func (h *HTTPS) get(ctx context.Context, url, etag string) ([]byte, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	client := h.Client
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && etag != "":
+		return nil, etag, nil
+	case resp.StatusCode != http.StatusOK:
+		return nil, "", fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBundleSize+1))
+	if err != nil {
+		return nil, "", fmt.Errorf("GET %s: %w", url, err)
+	}
+	if len(body) > maxBundleSize {
+		return nil, "", fmt.Errorf("GET %s: larger than %d bytes", url, maxBundleSize)
+	}
+	return body, resp.Header.Get("ETag"), nil
+}
+
+// unpack extracts the gzipped tar bundle into dir, by way of a temporary
+// directory in cache so a failed extraction leaves nothing behind. Only
+// regular files and directories are extracted, and entries that would
+// land outside dir are rejected.
+func unpack(bundle []byte, cache, dir string) error {
+	if err := os.MkdirAll(cache, 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.MkdirTemp(cache, ".unpack-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+
+	gz, err := gzip.NewReader(bytes.NewReader(bundle))
+	if err != nil {
+		return err
+	}
+	tr := tar.NewReader(gz)
+	budget := int64(maxBundleSize)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		name := filepath.FromSlash(strings.TrimPrefix(hdr.Name, "./"))
+		if name == "" || name == "." {
+			continue
+		}
+		if !filepath.IsLocal(name) {
+			return fmt.Errorf("entry %q escapes the bundle", hdr.Name)
+		}
+		path := filepath.Join(tmp, name)
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(path, 0o755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if budget -= hdr.Size; budget < 0 {
+				return fmt.Errorf("unpacked bundle is larger than %d bytes", maxBundleSize)
+			}
+			if err := writeFile(path, tr); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := os.RemoveAll(dir); err != nil {
+		return err
+	}
+	return os.Rename(tmp, dir)
+}
+
+func writeFile(path string, r io.Reader) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// prune removes unpacked bundles in cache other than keep. Content is held
+// in memory once loaded, so older copies are no longer needed.
+func prune(cache, keep string) {
+	entries, err := os.ReadDir(cache)
+	if err != nil {
+		return
+	}
+	for _, e := range entries {
+		path := filepath.Join(cache, e.Name())
+		if e.IsDir() && strings.HasPrefix(e.Name(), bundlePrefix) && path != keep {
+			_ = os.RemoveAll(path)
+		}
+	}
+}
+
+// validSum reports whether s is a hex-encoded SHA-256 digest.
+func validSum(s string) bool {
+	b, err := hex.DecodeString(s)
+	return err == nil && len(b) == sha256.Size
+}
@@ -0,0 +1,227 @@
+package source
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// bundle returns a gzipped tar holding files, keyed by slash path.
+func bundle(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, body := range files {
+		hdr := &tar.Header{Name: name, Mode: 0o644, Size: int64(len(body)), Typeflag: tar.TypeReg}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(body)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func sum(b []byte) string {
+	d := sha256.Sum256(b)
+	return hex.EncodeToString(d[:])
+}
+
+// bundleServer serves a bundle at /data.tar.gz with an ETag and its
+// checksum file next to it. The bundle and checksum can be swapped.
+type bundleServer struct {
+	*httptest.Server
+	body     atomic.Pointer[[]byte]
+	checksum atomic.Pointer[string]
+	sig      atomic.Pointer[[]byte]
+	fetches  atomic.Int32
+}
+
+func newBundleServer(t *testing.T, body []byte) *bundleServer {
+	t.Helper()
+	bs := &bundleServer{}
+	bs.set(body)
+	bs.Server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := *bs.body.Load()
+		switch r.URL.Path {
+		case "/data.tar.gz":
+			etag := `"` + sum(body)[:8] + `"`
+			if r.Header.Get("If-None-Match") == etag {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			bs.fetches.Add(1)
+			w.Header().Set("ETag", etag)
+			_, _ = w.Write(body)
+		case "/data.tar.gz.sha256":
+			_, _ = w.Write([]byte(*bs.checksum.Load() + "  data.tar.gz\n"))
+		case "/data.tar.gz.sig":
+			sig := bs.sig.Load()
+			if sig == nil {
+				http.NotFound(w, r)
+				return
+			}
+			_, _ = w.Write(*sig)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(bs.Close)
+	return bs
+}
+
+func (bs *bundleServer) set(body []byte) {
+	s := sum(body)
+	bs.body.Store(&body)
+	bs.checksum.Store(&s)
+}
+
+func (bs *bundleServer) source(t *testing.T) *HTTPS {
+	return &HTTPS{URL: bs.URL + "/data.tar.gz", Cache: t.TempDir(), Client: bs.Client()}
+}
+
+func TestHTTPSSync(t *testing.T) {
+	bs := newBundleServer(t, bundle(t, map[string]string{"content/meta.json": "v1"}))
+	h := bs.source(t)
+	ctx := context.Background()
+
+	dir, changed, err := h.Sync(ctx)
+	if err != nil || !changed {
+		t.Fatalf("first Sync = %q, %v, %v", dir, changed, err)
+	}
+	if b, _ := os.ReadFile(filepath.Join(dir, "content", "meta.json")); string(b) != "v1" {
+		t.Errorf("unpacked meta.json = %q", b)
+	}
+
+	// An unchanged bundle is answered by ETag and not downloaded again.
+	if again, changed, err := h.Sync(ctx); err != nil || changed || again != dir {
+		t.Errorf("unchanged Sync = %q, %v, %v", again, changed, err)
+	}
+	if n := bs.fetches.Load(); n != 1 {
+		t.Errorf("bundle downloaded %d times, want 1", n)
+	}
+
+	bs.set(bundle(t, map[string]string{"content/meta.json": "v2"}))
+	next, changed, err := h.Sync(ctx)
+	if err != nil || !changed || next == dir {
+		t.Fatalf("updated Sync = %q, %v, %v", next, changed, err)
+	}
+	if b, _ := os.ReadFile(filepath.Join(next, "content", "meta.json")); string(b) != "v2" {
+		t.Errorf("updated meta.json = %q", b)
+	}
+	if _, err := os.Stat(dir); !errors.Is(err, os.ErrNotExist) {
+		t.Error("the previous bundle should be pruned")
+	}
+}
+
+func TestHTTPSChecksumMismatch(t *testing.T) {
+	bs := newBundleServer(t, bundle(t, map[string]string{"content/meta.json": "v1"}))
+	h := bs.source(t)
+	good, _, err := h.Sync(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A tampered bundle with a stale checksum is refused and the good copy
+	// stays in use.
+	stale := *bs.checksum.Load()
+	bs.set(bundle(t, map[string]string{"content/meta.json": "evil"}))
+	bs.checksum.Store(&stale)
+	dir, changed, err := h.Sync(context.Background())
+	if !errors.Is(err, ErrChecksum) || changed || dir != good {
+		t.Errorf("tampered Sync = %q, %v, %v; want ErrChecksum and the old copy", dir, changed, err)
+	}
+
+	pinned := bs.source(t)
+	pinned.Sum = strings.Repeat("0", 64)
+	if _, _, err := pinned.Sync(context.Background()); !errors.Is(err, ErrChecksum) {
+		t.Errorf("pinned checksum mismatch = %v, want ErrChecksum", err)
+	}
+}
+
+func TestHTTPSSignature(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signed := bundle(t, map[string]string{"content/meta.json": "v1"})
+	bs := newBundleServer(t, signed)
+	h := bs.source(t)
+	h.PublicKey = pub
+
+	if _, _, err := h.Sync(context.Background()); err == nil {
+		t.Fatal("an unsigned bundle should be refused")
+	}
+
+	// Signatures are accepted raw or base64-encoded.
+	sig := ed25519.Sign(priv, signed)
+	bs.sig.Store(&sig)
+	good, changed, err := h.Sync(context.Background())
+	if err != nil || !changed {
+		t.Fatalf("signed Sync = %q, %v, %v", good, changed, err)
+	}
+	encoded := []byte(base64.StdEncoding.EncodeToString(sig) + "\n")
+	bs.sig.Store(&encoded)
+	if _, _, err := bs.source(t).Sync(context.Background()); err != nil {
+		t.Errorf("base64 signature: %v", err)
+	}
+
+	// A host that swaps the bundle and its checksum cannot forge the
+	// signature, so the signed copy stays in use.
+	bs.set(bundle(t, map[string]string{"content/meta.json": "evil"}))
+	dir, changed, err := h.Sync(context.Background())
+	if !errors.Is(err, ErrSignature) || changed || dir != good {
+		t.Errorf("forged Sync = %q, %v, %v; want ErrSignature and the old copy", dir, changed, err)
+	}
+}
+
+func TestHTTPSSubdir(t *testing.T) {
+	bs := newBundleServer(t, bundle(t, map[string]string{"./site/data/content/meta.json": "v1"}))
+	h := bs.source(t)
+	h.Subdir = "site/data"
+	dir, _, err := h.Sync(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "content", "meta.json")); err != nil {
+		t.Errorf("data directory %q missing content: %v", dir, err)
+	}
+}
+
+func TestUnpackRejectsEscapes(t *testing.T) {
+	cache := t.TempDir()
+	for _, name := range []string{"../evil", "/etc/evil", "content/../../evil"} {
+		err := unpack(bundle(t, map[string]string{name: "x"}), cache, filepath.Join(cache, "out"))
+		if err == nil {
+			t.Errorf("entry %q should be rejected", name)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(filepath.Dir(cache), "evil")); err == nil {
+		t.Error("an escaping entry was written")
+	}
+	entries, _ := os.ReadDir(cache)
+	if len(entries) != 0 {
+		t.Errorf("failed unpacks left %d entries behind", len(entries))
+	}
+}
@@ -0,0 +1,195 @@
+// Package source fetches the data directory that portfolio content is
+// loaded from. Besides a local directory, the data can come from an HTTPS
+// URL serving a bundle or from a git repository. Remote data is copied
+// into a local cache and can be refreshed on an interval, so content
+// changes without anyone touching the server's filesystem.
+package source
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// syncTimeout bounds a single Sync of a remote source.
+const syncTimeout = 2 * time.Minute
+
+// Source provides a local copy of a data directory.
+type Source interface {
+	// Sync brings the local copy up to date. It returns the directory
+	// holding the copy and whether the copy changed since the previous
+	// Sync. On error the previous copy, if any, is left in place.
+	Sync(ctx context.Context) (dir string, changed bool, err error)
+}
+
+// Remote reports whether location names a remote source: an https:// URL
+// or a git repository prefixed with "git+".
+func Remote(location string) bool {
+	return strings.HasPrefix(location, "https://") || strings.HasPrefix(location, "git+")
+}
+
+// New returns the source for location. A local path is used as is. An
+// https:// URL is fetched as a gzipped tar bundle, checked against sum
+// (hex SHA-256) when set or against the checksum file published next to
+// it otherwise. When publicKey (base64 ed25519) is set, bundles must also
+// carry a valid signature; see HTTPS. A "git+" location is cloned with
+// git; for example "git+https://github.com/user/site.git". Remote
+// locations may end in "#subdir" to name the data directory inside the
+// bundle or repository. A git location's fragment may also end in "@ref"
+// to pin the copy to a commit or tag, as in
+// "git+https://github.com/user/site.git#data@v1.2.0"; see Git. Remote
+// copies are kept in cacheDir.
+func New(location, cacheDir, sum, publicKey string) (Source, error) {
+	if !Remote(location) {
+		return Dir(location), nil
+	}
+	if cacheDir == "" {
+		return nil, errors.New("remote content needs a cache directory")
+	}
+	loc, subdir, _ := strings.Cut(location, "#")
+	git, isGit := strings.CutPrefix(loc, "git+")
+	var ref string
+	if isGit {
+		var pinned bool
+		subdir, ref, pinned = strings.Cut(subdir, "@")
+		if pinned && (ref == "" || strings.HasPrefix(ref, "-")) {
+			return nil, fmt.Errorf("git ref %q must name a commit or tag", ref)
+		}
+	}
+	if subdir != "" && !filepath.IsLocal(subdir) {
+		return nil, fmt.Errorf("data subdirectory %q must be relative and inside the source", subdir)
+	}
+	if isGit {
+		if publicKey != "" {
+			return nil, errors.New("signed content is only supported for https bundles")
+		}
+		return &Git{URL: git, Subdir: subdir, Ref: ref, Cache: cacheDir}, nil
+	}
+	if sum != "" && !validSum(sum) {
+		return nil, fmt.Errorf("checksum %q is not a hex SHA-256", sum)
+	}
+	h := &HTTPS{URL: loc, Sum: sum, Subdir: subdir, Cache: cacheDir}
+	if publicKey != "" {
+		key, err := ParsePublicKey(publicKey)
+		if err != nil {
+			return nil, err
+		}
+		h.PublicKey = key
+	}
+	return h, nil
+}
+
+// ParsePublicKey decodes a base64 ed25519 public key.
+func ParsePublicKey(s string) (ed25519.PublicKey, error) {
+	b, err := base64.StdEncoding.DecodeString(strings.TrimSpace(s))
+	if err != nil || len(b) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("public key %q is not a base64 ed25519 key", s)
+	}
+	return ed25519.PublicKey(b), nil
+}
+
+// Dir is a local data directory. It never reports changes; edits on disk
+// are picked up when the server restarts.
+type Dir string
+
+// Sync implements Source.
+func (d Dir) Sync(context.Context) (string, bool, error) {
+	return string(d), false, nil
+}
+
+// Poll calls src.Sync every interval until ctx is done, and calls update
+// with the directory whenever the copy changes. Failed syncs are logged
+// and leave the previous copy in use.
+func Poll(ctx context.Context, src Source, interval time.Duration, update func(dir string)) {
+	poll(ctx, interval, func(ctx context.Context) error {
+		return refresh(ctx, src, false, update)
+	})
+}
+
+// poll calls refresh every interval until ctx is done, logging its
+// failures.
+func poll(ctx context.Context, interval time.Duration, refresh func(context.Context) error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if err := refresh(ctx); err != nil {
+			slog.Warn("content sync failed", "err", err)
+		}
+	}
+}
+
+// refresh syncs src and calls update with the directory when the copy
+// changed, or in any case when force is set.
+func refresh(ctx context.Context, src Source, force bool, update func(dir string)) error {
+	ctx, cancel := context.WithTimeout(ctx, syncTimeout)
+	defer cancel()
+	dir, changed, err := src.Sync(ctx)
+	if err != nil {
+		return err
+	}
+	if changed || force {
+		update(dir)
+	}
+	return nil
+}
+
+// Shared is a source synced by more than one goroutine, such as a content
+// poller and reloads. Each of its syncs holds one lock across the sync and
+// the update that follows, so the copy is not replaced while an update
+// still reads it.
+type Shared struct {
+	mu  sync.Mutex
+	src Source
+}
+
+// NewShared returns src shared between goroutines.
+func NewShared(src Source) *Shared {
+	return &Shared{src: src}
+}
+
+// Sync implements Source.
+func (s *Shared) Sync(ctx context.Context) (string, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.src.Sync(ctx)
+}
+
+// Poll is like the package's Poll, holding the lock across each sync and
+// its update.
+func (s *Shared) Poll(ctx context.Context, interval time.Duration, update func(dir string)) {
+	poll(ctx, interval, func(ctx context.Context) error {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return refresh(ctx, s.src, false, update)
+	})
+}
+
+// Update syncs the source and calls update with the directory whether or
+// not the copy changed, so a local directory edited in place is picked up.
+// It holds the lock across both.
+func (s *Shared) Update(ctx context.Context, update func(dir string)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return refresh(ctx, s.src, true, update)
+}
+
+// SyncOnce performs the first Sync of src, bounded by the same timeout as
+// a polled sync.
+func SyncOnce(ctx context.Context, src Source) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, syncTimeout)
+	defer cancel()
+	dir, _, err := src.Sync(ctx)
+	return dir, err
+}
@@ -0,0 +1,174 @@
+package source
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		location string
+		want     Source
+	}{
+		{"../data", Dir("../data")},
+		{"https://example.com/data.tar.gz", &HTTPS{URL: "https://example.com/data.tar.gz", Cache: "cache"}},
+		{"https://example.com/site.tar.gz#data", &HTTPS{URL: "https://example.com/site.tar.gz", Subdir: "data", Cache: "cache"}},
+		{"git+https://example.com/site.git#data", &Git{URL: "https://example.com/site.git", Subdir: "data", Cache: "cache"}},
+		{"git+ssh://git@example.com/site.git", &Git{URL: "ssh://git@example.com/site.git", Cache: "cache"}},
+		{"git+https://example.com/site.git#data@v1.2.0", &Git{URL: "https://example.com/site.git", Subdir: "data", Ref: "v1.2.0", Cache: "cache"}},
+		{"git+https://example.com/site.git#@v1.2.0", &Git{URL: "https://example.com/site.git", Ref: "v1.2.0", Cache: "cache"}},
+	}
+	for _, tt := range tests {
+		got, err := New(tt.location, "cache", "", "")
+		if err != nil {
+			t.Errorf("New(%q): %v", tt.location, err)
+			continue
+		}
+		switch want := tt.want.(type) {
+		case *HTTPS:
+			if h, ok := got.(*HTTPS); !ok || !reflect.DeepEqual(h, want) {
+				t.Errorf("New(%q) = %#v, want %#v", tt.location, got, want)
+			}
+		case *Git:
+			if g, ok := got.(*Git); !ok || *g != *want {
+				t.Errorf("New(%q) = %#v, want %#v", tt.location, got, want)
+			}
+		default:
+			if got != tt.want {
+				t.Errorf("New(%q) = %#v, want %#v", tt.location, got, want)
+			}
+		}
+	}
+
+	key := base64.StdEncoding.EncodeToString(make([]byte, ed25519.PublicKeySize))
+	for _, bad := range []struct{ location, cache, sum, key string }{
+		{"https://example.com/d.tar.gz", "", "", ""},
+		{"https://example.com/d.tar.gz", "cache", "abc", ""},
+		{"https://example.com/d.tar.gz", "cache", "", "c2hvcnQ="},
+		{"git+https://example.com/site.git#../etc", "cache", "", ""},
+		{"git+https://example.com/site.git", "cache", "", key},
+		{"git+https://example.com/site.git#data@", "cache", "", ""},
+		{"git+https://example.com/site.git#@--upload-pack=evil", "cache", "", ""},
+	} {
+		if _, err := New(bad.location, bad.cache, bad.sum, bad.key); err == nil {
+			t.Errorf("New(%q, %q, %q, %q) should fail", bad.location, bad.cache, bad.sum, bad.key)
+		}
+	}
+	if _, err := New("https://example.com/d.tar.gz", "cache", strings.Repeat("ab", 32), ""); err != nil {
+		t.Errorf("a hex SHA-256 should be accepted: %v", err)
+	}
+	src, err := New("https://example.com/d.tar.gz", "cache", "", key)
+	if err != nil {
+		t.Fatalf("a base64 ed25519 key should be accepted: %v", err)
+	}
+	if h := src.(*HTTPS); len(h.PublicKey) != ed25519.PublicKeySize {
+		t.Errorf("PublicKey = %x", h.PublicKey)
+	}
+}
+
+// fakeSource reports a change on every other Sync and fails on the rest.
+type fakeSource struct{ n int }
+
+func (f *fakeSource) Sync(context.Context) (string, bool, error) {
+	f.n++
+	if f.n%2 == 0 {
+		return "", false, errors.New("unreachable")
+	}
+	return "dir", true, nil
+}
+
+func TestPoll(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	updates := make(chan string, 8)
+	done := make(chan struct{})
+	go func() {
+		Poll(ctx, &fakeSource{}, time.Millisecond, func(dir string) {
+			select {
+			case updates <- dir:
+			default:
+			}
+		})
+		close(done)
+	}()
+	for range 2 {
+		select {
+		case dir := <-updates:
+			if dir != "dir" {
+				t.Errorf("update(%q), want dir", dir)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("Poll did not report changes across failed syncs")
+		}
+	}
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Poll did not return after cancellation")
+	}
+}
+
+// blockingSource is a source whose Sync reports when it starts and waits to
+// be released.
+type blockingSource struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (b *blockingSource) Sync(context.Context) (string, bool, error) {
+	b.started <- struct{}{}
+	<-b.release
+	return "dir", true, nil
+}
+
+func TestShared(t *testing.T) {
+	src := &blockingSource{started: make(chan struct{}), release: make(chan struct{})}
+	shared := NewShared(src)
+
+	// An update holds the lock until it returns, so a Sync started
+	// meanwhile waits for it.
+	updating := make(chan struct{})
+	finish := make(chan struct{})
+	go func() {
+		_ = shared.Update(context.Background(), func(dir string) {
+			close(updating)
+			<-finish
+		})
+	}()
+	<-src.started
+	src.release <- struct{}{}
+	<-updating
+
+	synced := make(chan struct{})
+	go func() {
+		_, _, _ = shared.Sync(context.Background())
+		close(synced)
+	}()
+	select {
+	case <-src.started:
+		t.Fatal("a Sync ran while an update was still reading the copy")
+	case <-time.After(20 * time.Millisecond):
+	}
+	close(finish)
+	<-src.started
+	src.release <- struct{}{}
+	<-synced
+}
+
+func TestSharedUpdate(t *testing.T) {
+	var got []string
+	shared := NewShared(Dir("data"))
+	if err := shared.Update(context.Background(), func(dir string) { got = append(got, dir) }); err != nil {
+		t.Fatal(err)
+	}
+	// A local directory never reports changes, but Update applies it anyway.
+	if len(got) != 1 || got[0] != "data" {
+		t.Errorf("updates = %q, want data once", got)
+	}
+}
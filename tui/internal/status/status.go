@@ -0,0 +1,237 @@
+// Package status checks the health of the owner's other services for the
+// status section. One Monitor per server checks every service on an
+// interval and sessions read its latest results, so visitors never cause
+// requests of their own.
+package status
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// State is the health of a service as of its last check.
+type State int
+
+const (
+	// Unknown means the service has not been checked yet.
+	Unknown State = iota
+	// Up means the service answered promptly.
+	Up
+	// Degraded means the service answered slowly or with a client error.
+	Degraded
+	// Down means the service did not answer or failed.
+	Down
+)
+
+// String returns the state in lower case, as shown in the section.
+func (s State) String() string {
+	switch s {
+	case Up:
+		return "up"
+	case Degraded:
+		return "degraded"
+	case Down:
+		return "down"
+	default:
+		return "checking"
+	}
+}
+
+// Check names a service and where to reach it: an http:// or https:// URL,
+// which must answer GET below status 400, or tcp://host:port, which must
+// accept a connection.
+type Check struct {
+	Name   string
+	Target string
+}
+
+// ParseChecks parses a comma-separated list of name=target pairs, such as
+// "api=https://api.example.com/health,db=tcp://db.example.com:5432".
+func ParseChecks(s string) ([]Check, error) {
+	var checks []Check
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		name, target, ok := strings.Cut(item, "=")
+		name, target = strings.TrimSpace(name), strings.TrimSpace(target)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("status check %q must look like name=target", item)
+		}
+		u, err := url.Parse(target)
+		if err != nil || u.Host == "" {
+			return nil, fmt.Errorf("status check %s: invalid target %q", name, target)
+		}
+		switch u.Scheme {
+		case "http", "https":
+		case "tcp":
+			if u.Port() == "" {
+				return nil, fmt.Errorf("status check %s: %q needs a port", name, target)
+			}
+		default:
+			return nil, fmt.Errorf("status check %s: target must be http, https, or tcp, got %q", name, target)
+		}
+		checks = append(checks, Check{Name: name, Target: target})
+	}
+	return checks, nil
+}
+
+// Result is the outcome of the latest check of a service.
+type Result struct {
+	Check
+	State   State
+	Latency time.Duration
+	// Detail explains a degraded or down state, such as "HTTP 503".
+	Detail  string
+	Checked time.Time
+}
+
+// Monitor checks a fixed list of services on an interval. It is safe for
+// concurrent use; a nil Monitor has no results.
+type Monitor struct {
+	checks   []Check
+	interval time.Duration
+	// Slow is the latency at which a service counts as degraded.
+	Slow    time.Duration
+	Timeout time.Duration
+	Client  *http.Client // nil uses a client with Timeout
+
+	results atomic.Pointer[[]Result]
+}
+
+// NewMonitor returns a Monitor for checks that reports every service as
+// Unknown until Run completes its first round.
+func NewMonitor(checks []Check, interval time.Duration) *Monitor {
+	m := &Monitor{checks: checks, interval: interval, Slow: time.Second, Timeout: 5 * time.Second}
+	results := make([]Result, len(checks))
+	for i, c := range checks {
+		results[i] = Result{Check: c}
+	}
+	m.results.Store(&results)
+	return m
+}
+
+// Results returns the latest result for each service, in the order the
+// checks were given.
+func (m *Monitor) Results() []Result {
+	if m == nil {
+		return nil
+	}
+	return *m.results.Load()
+}
+
+// Run checks every service immediately and then every interval, until ctx
+// is done.
+func (m *Monitor) Run(ctx context.Context) {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+	for {
+		m.CheckAll(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// CheckAll checks every service concurrently and publishes the results.
+func (m *Monitor) CheckAll(ctx context.Context) {
+	results := make([]Result, len(m.checks))
+	done := make(chan struct{})
+	for i, c := range m.checks {
+		go func() {
+			results[i] = m.check(ctx, c)
+			done <- struct{}{}
+		}()
+	}
+	for range m.checks {
+		<-done
+	}
+	m.results.Store(&results)
+}
+
+// check probes one service.
+func (m *Monitor) check(ctx context.Context, c Check) Result {
+	ctx, cancel := context.WithTimeout(ctx, m.Timeout)
+	defer cancel()
+
+	r := Result{Check: c}
+	start := time.Now()
+	var err error
+	if host, ok := strings.CutPrefix(c.Target, "tcp://"); ok {
+		var conn net.Conn
+		conn, err = (&net.Dialer{}).DialContext(ctx, "tcp", host)
+		if err == nil {
+			conn.Close()
+		}
+	} else {
+		err = m.get(ctx, c.Target, &r)
+	}
+	r.Latency = time.Since(start)
+	r.Checked = time.Now()
+
+	switch {
+	case err != nil:
+		r.State = Down
+		if r.Detail == "" {
+			r.Detail = describe(err)
+		}
+	case r.Detail != "":
+		// get recorded a client error.
+		r.State = Degraded
+	case r.Latency >= m.Slow:
+		r.State = Degraded
+		r.Detail = "slow"
+	default:
+		r.State = Up
+	}
+	return r
+}
+
+// get requests target, recording a 4xx status in r.Detail and returning an
+// error for a 5xx status.
+func (m *Monitor) get(ctx context.Context, target string, r *Result) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return err
+	}
+	client := m.Client
+	if client == nil {
+		client = &http.Client{Timeout: m.Timeout}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	switch {
+	case resp.StatusCode >= 500:
+		r.Detail = fmt.Sprintf("HTTP %d", resp.StatusCode)
+		return fmt.Errorf("%s: %s", target, resp.Status)
+	case resp.StatusCode >= 400:
+		r.Detail = fmt.Sprintf("HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// describe shortens a network error for display.
+func describe(err error) string {
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || errors.As(err, &netErr) && netErr.Timeout() {
+		return "timeout"
+	}
+	var opErr *net.OpError
+	if errors.As(err, &opErr) {
+		return opErr.Op + " failed"
+	}
+	return "unreachable"
+}
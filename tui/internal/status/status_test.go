@@ -0,0 +1,112 @@
+package status
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestParseChecks(t *testing.T) {
+	checks, err := ParseChecks(" api = https://api.example.com/health, db=tcp://db.example.com:5432,")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Check{
+		{"api", "https://api.example.com/health"},
+		{"db", "tcp://db.example.com:5432"},
+	}
+	if len(checks) != len(want) || checks[0] != want[0] || checks[1] != want[1] {
+		t.Errorf("ParseChecks = %v, want %v", checks, want)
+	}
+
+	for _, bad := range []string{
+		"https://example.com",
+		"=https://example.com",
+		"db=tcp://db.example.com",
+		"ftp=ftp://example.com",
+		"x=not a url",
+	} {
+		if _, err := ParseChecks(bad); err == nil {
+			t.Errorf("ParseChecks(%q) should fail", bad)
+		}
+	}
+}
+
+func TestMonitorCheckAll(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ok", func(http.ResponseWriter, *http.Request) {})
+	mux.HandleFunc("/missing", http.NotFound)
+	mux.HandleFunc("/broken", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	mux.HandleFunc("/slow", func(http.ResponseWriter, *http.Request) {
+		time.Sleep(50 * time.Millisecond)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	closed := "tcp://" + ln.Addr().String()
+	ln.Close() // nothing listens, so the dial is refused
+
+	m := NewMonitor([]Check{
+		{"ok", srv.URL + "/ok"},
+		{"missing", srv.URL + "/missing"},
+		{"broken", srv.URL + "/broken"},
+		{"slow", srv.URL + "/slow"},
+		{"tcp", "tcp://" + srv.Listener.Addr().String()},
+		{"closed", closed},
+	}, time.Minute)
+	m.Slow = 40 * time.Millisecond
+	if r := m.Results(); len(r) != 6 || r[0].State != Unknown {
+		t.Fatalf("before the first check: %+v", r)
+	}
+
+	m.CheckAll(context.Background())
+	want := []struct {
+		state  State
+		detail string
+	}{
+		{Up, ""},
+		{Degraded, "HTTP 404"},
+		{Down, "HTTP 503"},
+		{Degraded, "slow"},
+		{Up, ""},
+		{Down, "dial failed"},
+	}
+	for i, r := range m.Results() {
+		if r.State != want[i].state || r.Detail != want[i].detail {
+			t.Errorf("%s = %s %q, want %s %q", r.Name, r.State, r.Detail, want[i].state, want[i].detail)
+		}
+		if r.Checked.IsZero() {
+			t.Errorf("%s has no check time", r.Name)
+		}
+	}
+}
+
+func TestMonitorTimeout(t *testing.T) {
+	block := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) { <-block }))
+	defer srv.Close()
+	defer close(block)
+
+	m := NewMonitor([]Check{{"hung", srv.URL}}, time.Minute)
+	m.Timeout = 20 * time.Millisecond
+	m.CheckAll(context.Background())
+	if r := m.Results()[0]; r.State != Down || r.Detail != "timeout" {
+		t.Errorf("hung service = %s %q, want down timeout", r.State, r.Detail)
+	}
+}
+
+func TestNilMonitor(t *testing.T) {
+	var m *Monitor
+	if r := m.Results(); r != nil {
+		t.Errorf("nil Monitor results = %v", r)
+	}
+}
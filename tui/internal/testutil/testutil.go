@@ -2,8 +2,12 @@
 package testutil
 
 import (
+	"maps"
 	"path/filepath"
 	"runtime"
+	"slices"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/buntingszn/terminal-portfolio/tui/internal/app"
@@ -16,16 +20,155 @@ func fixtureDataDir() string {
 	return filepath.Join(filepath.Dir(thisFile), "testdata")
 }
 
+var (
+	fixtureOnce sync.Once
+	fixtureBase *content.Content
+)
+
 // FixtureContent returns a fully-populated Content struct loaded from
-// testdata/content/*.json. Panics on load failure so tests fail fast.
+// testdata/content/*.json. The JSON is parsed once per test binary and each
+// call returns an independent deep copy, so tests may mutate the result
+// freely. Panics on load failure so tests fail fast.
 func FixtureContent() *content.Content {
-	c, err := content.LoadAll(fixtureDataDir())
-	if err != nil {
-		panic("testutil: failed to load fixture content: " + err.Error())
+	fixtureOnce.Do(func() {
+		c, err := content.LoadAll(fixtureDataDir())
+		if err != nil {
+			panic("testutil: failed to load fixture content: " + err.Error())
+		}
+		fixtureBase = c
+	})
+	return CloneContent(fixtureBase)
+}
+
+// ContentMutator edits a fixture copy to set up an edge case.
+type ContentMutator func(*content.Content)
+
+// FixtureContentWith returns a fresh fixture copy with each mutator applied
+// in order, e.g. FixtureContentWith(WithoutProjects(), WithLongText(500)).
+func FixtureContentWith(mutators ...ContentMutator) *content.Content {
+	c := FixtureContent()
+	for _, mut := range mutators {
+		mut(c)
 	}
 	return c
 }
 
+// CloneContent returns a deep copy of c so that slices, maps and pointed-to
+// values in the copy can be modified without affecting the original.
+func CloneContent(c *content.Content) *content.Content {
+	if c == nil {
+		return nil
+	}
+	clone := *c
+	clone.About.Availability = clonePtr(c.About.Availability)
+	if a := clone.About.Availability; a != nil {
+		a.Roles = slices.Clone(a.Roles)
+	}
+	clone.About.Education = slices.Clone(c.About.Education)
+	clone.About.Interests = slices.Clone(c.About.Interests)
+	clone.Work.Projects = slices.Clone(c.Work.Projects)
+	for i := range clone.Work.Projects {
+		clone.Work.Projects[i].Tags = slices.Clone(clone.Work.Projects[i].Tags)
+	}
+	clone.CV.Experience = slices.Clone(c.CV.Experience)
+	for i := range clone.CV.Experience {
+		clone.CV.Experience[i].Bullets = slices.Clone(clone.CV.Experience[i].Bullets)
+	}
+	clone.CV.Skills = slices.Clone(c.CV.Skills)
+	for i := range clone.CV.Skills {
+		clone.CV.Skills[i].Items = slices.Clone(clone.CV.Skills[i].Items)
+	}
+	clone.CV.Education = slices.Clone(c.CV.Education)
+	clone.Links.Links = slices.Clone(c.Links.Links)
+	clone.Experiments = slices.Clone(c.Experiments)
+	for i := range clone.Experiments {
+		clone.Experiments[i].Variants = slices.Clone(clone.Experiments[i].Variants)
+	}
+	clone.Keys = slices.Clone(c.Keys)
+	clone.Uses = slices.Clone(c.Uses)
+	for i := range clone.Uses {
+		clone.Uses[i].Items = slices.Clone(clone.Uses[i].Items)
+	}
+	clone.Talks = slices.Clone(c.Talks)
+	clone.BootMessages = slices.Clone(c.BootMessages)
+	clone.Theme = clonePtr(c.Theme)
+	clone.Notes = slices.Clone(c.Notes)
+	if c.Locales != nil {
+		clone.Locales = make(map[string]*content.Content, len(c.Locales))
+		for tag, l := range c.Locales {
+			clone.Locales[tag] = CloneContent(l)
+		}
+	}
+	// Errors are not modified in place, so the map alone is copied.
+	clone.Unavailable = maps.Clone(c.Unavailable)
+	return &clone
+}
+
+// clonePtr returns a pointer to a copy of *p, or nil when p is.
+func clonePtr[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
+
+// WithoutProjects empties the work projects list.
+func WithoutProjects() ContentMutator {
+	return func(c *content.Content) { c.Work.Projects = nil }
+}
+
+// WithoutLinks empties the links list.
+func WithoutLinks() ContentMutator {
+	return func(c *content.Content) { c.Links.Links = nil }
+}
+
+// WithoutEducation removes education entries from both About and CV.
+func WithoutEducation() ContentMutator {
+	return func(c *content.Content) {
+		c.About.Education = nil
+		c.CV.Education = nil
+	}
+}
+
+// WithLongText replaces every free-text field (bio, summary, titles,
+// descriptions, bullets, labels) with a string of n visible characters made
+// of short words, exercising wrapping and truncation paths.
+func WithLongText(n int) ContentMutator {
+	return func(c *content.Content) {
+		long := LongText(n)
+		c.About.Bio = long
+		if c.About.Availability != nil {
+			c.About.Availability.Roles = []string{long}
+		}
+		c.CV.Summary = long
+		for i := range c.Work.Projects {
+			c.Work.Projects[i].Title = long
+			c.Work.Projects[i].Description = long
+		}
+		for i := range c.CV.Experience {
+			c.CV.Experience[i].Role = long
+			for j := range c.CV.Experience[i].Bullets {
+				c.CV.Experience[i].Bullets[j] = long
+			}
+		}
+		for i := range c.Links.Links {
+			c.Links.Links[i].Label = long
+			c.Links.Links[i].Text = long
+		}
+	}
+}
+
+// LongText returns a string of exactly n characters built from repeated
+// "lorem " words.
+func LongText(n int) string {
+	if n <= 0 {
+		return ""
+	}
+	s := strings.Repeat("lorem ", n/6+1)
+	return s[:n]
+}
+
 // FixtureTheme returns the default dark theme for testing.
 func FixtureTheme() app.Theme {
 	return app.DarkTheme()
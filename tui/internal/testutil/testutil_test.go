@@ -1,7 +1,13 @@
 package testutil
 
 import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
 	"testing"
+
+	"github.com/buntingszn/terminal-portfolio/tui/internal/content"
 )
 
 func TestFixtureContent_NonNil(t *testing.T) {
@@ -27,7 +33,9 @@ func TestFixtureContent_AboutPopulated(t *testing.T) {
 	c := FixtureContent()
 	RequireNotEmpty(t, c.About.Bio)
 	RequireNotEmpty(t, c.About.Email)
-	RequireNotEmpty(t, c.About.Status)
+	if c.About.Availability == nil || len(c.About.Availability.Roles) == 0 {
+		t.Error("fixture about.json should set availability roles")
+	}
 
 	if len(c.About.Education) == 0 {
 		t.Fatal("expected at least one education entry in About")
@@ -157,3 +165,131 @@ func TestRequireNotEmpty_Fail(t *testing.T) {
 		t.Error("expected RequireNotEmpty to fail on empty string")
 	}
 }
+
+func TestFixtureContent_ReturnsIndependentCopies(t *testing.T) {
+	a := FixtureContent()
+	a.Work.Projects[0].Title = "mutated"
+	a.Links.Links = nil
+
+	b := FixtureContent()
+	if b.Work.Projects[0].Title == "mutated" {
+		t.Error("mutating one fixture copy leaked into the next")
+	}
+	if len(b.Links.Links) == 0 {
+		t.Error("expected links in a fresh fixture copy")
+	}
+}
+
+func TestCloneContent(t *testing.T) {
+	c := FixtureContent()
+	c.Keys = []content.PublicKey{{Label: "Laptop", Type: "ssh", Fingerprint: "SHA256:abc"}}
+	c.Unavailable = map[string]error{"uses.json": errors.New("bad json")}
+	c.Locales = map[string]*content.Content{"de": FixtureContent()}
+
+	clone := CloneContent(c)
+	clone.Work.Projects[0].Tags[0] = "mutated"
+	clone.CV.Experience[0].Bullets[0] = "mutated"
+	clone.About.Availability.Roles[0] = "mutated"
+	clone.Unavailable["cv.json"] = errors.New("missing")
+	clone.Locales["de"].Meta.Name = "mutated"
+	if c.Work.Projects[0].Tags[0] == "mutated" || c.CV.Experience[0].Bullets[0] == "mutated" || c.About.Availability.Roles[0] == "mutated" {
+		t.Error("mutating the clone's nested slices changed the original")
+	}
+	if len(c.Unavailable) != 1 || c.Locales["de"].Meta.Name == "mutated" {
+		t.Error("mutating the clone's maps changed the original")
+	}
+	if got := clone.Keys[0].Fingerprint; got != "SHA256:abc" {
+		t.Errorf("clone fingerprint = %q, want it kept", got)
+	}
+	if !errors.Is(clone.Unavailable["uses.json"], c.Unavailable["uses.json"]) {
+		t.Error("clone lost an unavailable file's error")
+	}
+}
+
+// TestFixtureContent_CoversData verifies that the fixtures keep up with the
+// real content in data/content: every file there has a fixture, and every
+// field the real content sets is set somewhere in its fixture.
+func TestFixtureContent_CoversData(t *testing.T) {
+	dataDir := filepath.Join(fixtureDataDir(), "..", "..", "..", "..", "data", "content")
+	files, err := filepath.Glob(filepath.Join(dataDir, "*.json"))
+	if err != nil || len(files) == 0 {
+		t.Fatalf("no content in %s: %v", dataDir, err)
+	}
+	for _, file := range files {
+		name := filepath.Base(file)
+		want := jsonFields(t, file)
+		got := jsonFields(t, filepath.Join(fixtureDataDir(), "content", name))
+		for field := range want {
+			if !got[field] {
+				t.Errorf("%s: fixture does not set %s, which data/content does", name, field)
+			}
+		}
+	}
+}
+
+// jsonFields returns the paths of the object fields set in the JSON file,
+// such as .links[].url.
+func jsonFields(t *testing.T, path string) map[string]bool {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		t.Fatalf("%s: %v", path, err)
+	}
+	fields := make(map[string]bool)
+	var walk func(v any, prefix string)
+	walk = func(v any, prefix string) {
+		switch v := v.(type) {
+		case map[string]any:
+			for k, x := range v {
+				fields[prefix+"."+k] = true
+				walk(x, prefix+"."+k)
+			}
+		case []any:
+			for _, x := range v {
+				walk(x, prefix+"[]")
+			}
+		}
+	}
+	walk(v, "")
+	return fields
+}
+
+func TestFixtureContentWith_AppliesMutators(t *testing.T) {
+	c := FixtureContentWith(WithoutProjects(), WithoutLinks(), WithoutEducation())
+	if len(c.Work.Projects) != 0 {
+		t.Errorf("expected no projects, got %d", len(c.Work.Projects))
+	}
+	if len(c.Links.Links) != 0 {
+		t.Errorf("expected no links, got %d", len(c.Links.Links))
+	}
+	if len(c.About.Education) != 0 || len(c.CV.Education) != 0 {
+		t.Error("expected education removed from About and CV")
+	}
+	// Untouched fields keep fixture values.
+	RequireContains(t, c.Meta.Name, "Kyle McCormick")
+}
+
+func TestWithLongText(t *testing.T) {
+	c := FixtureContentWith(WithLongText(500))
+	if got := len(c.About.Bio); got != 500 {
+		t.Errorf("len(Bio) = %d, want 500", got)
+	}
+	if got := len(c.Work.Projects[0].Description); got != 500 {
+		t.Errorf("len(Description) = %d, want 500", got)
+	}
+	if got := len(c.CV.Experience[0].Bullets[0]); got != 500 {
+		t.Errorf("len(Bullets[0]) = %d, want 500", got)
+	}
+}
+
+func TestLongText(t *testing.T) {
+	for _, n := range []int{0, 1, 6, 7, 100} {
+		if got := len(LongText(n)); got != n {
+			t.Errorf("len(LongText(%d)) = %d", n, got)
+		}
+	}
+}
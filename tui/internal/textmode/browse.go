@@ -0,0 +1,176 @@
+package textmode
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+
+	"github.com/buntingszn/terminal-portfolio/tui/internal/resume"
+)
+
+// Browse serves the portfolio to screen readers and braille terminals as
+// a plain conversation: it writes the home section, then asks which
+// section to read next until the visitor quits or the input ends. Output
+// only ever grows downwards, with no colors, box drawing, or redraws, so
+// it reads in order.
+//
+// With echo set, as for sessions with a terminal, typed characters are
+// echoed and backspace edits the line; otherwise lines are read as the
+// client sends them. A read error other than io.EOF is returned.
+func Browse(in io.Reader, out io.Writer, s Source, echo bool) error {
+	lines := &lineReader{r: bufio.NewReader(in), w: out, echo: echo}
+	intro := "Accessible text mode. Each section is printed in full, then you are asked where to go next.\n\n"
+	if _, err := io.WriteString(out, intro); err != nil {
+		return err
+	}
+	name := "home"
+	for {
+		if err := browseSection(out, name, s); err != nil {
+			return err
+		}
+		if _, err := io.WriteString(out, browsePrompt()); err != nil {
+			return err
+		}
+		answer, err := lines.readLine()
+		if errors.Is(err, io.EOF) {
+			_, err = io.WriteString(out, "\nGoodbye.\n")
+			return err
+		}
+		if err != nil {
+			return err
+		}
+		switch name = strings.ToLower(strings.TrimSpace(answer)); name {
+		case "quit", "q", "exit":
+			_, err := io.WriteString(out, "Goodbye.\n")
+			return err
+		case "":
+			name = "help"
+		}
+	}
+}
+
+// browseSection writes the section called name, or says why it cannot.
+func browseSection(out io.Writer, name string, s Source) error {
+	if name == "help" {
+		var b strings.Builder
+		b.WriteString("Sections:\n\n")
+		for _, c := range browseCommands() {
+			fmt.Fprintf(&b, "  %-10s %s\n", c.name, c.summary)
+		}
+		_, err := io.WriteString(out, b.String())
+		return err
+	}
+	if Binary(name) {
+		_, err := fmt.Fprintf(out, "%s writes a file, which this mode cannot show; run ssh <host> %s > %s instead.\n",
+			name, name, resume.FileName)
+		return err
+	}
+	var b strings.Builder
+	switch err := Run(&b, name, s); {
+	case errors.Is(err, ErrUnknownCommand):
+		fmt.Fprintf(&b, "There is no section called %q.\n", name)
+	case err != nil:
+		// The section's content is unavailable; say so and carry on.
+		b.WriteString(err.Error() + "\n")
+	}
+	_, err := io.WriteString(out, b.String())
+	return err
+}
+
+// browseCommands returns the commands Browse offers: the text sections.
+func browseCommands() []command {
+	var cmds []command
+	for _, c := range commands {
+		if !c.binary && c.render != nil {
+			cmds = append(cmds, c)
+		}
+	}
+	return cmds
+}
+
+// browsePrompt asks where to go next, naming every section so a screen
+// reader announces the choices.
+func browsePrompt() string {
+	names := make([]string, 0, len(commands))
+	for _, c := range browseCommands() {
+		names = append(names, c.name)
+	}
+	return "\n---\nSections: " + strings.Join(names, ", ") +
+		".\nType a section name, help, or quit, then press Enter.\n> "
+}
+
+// lineReader reads the visitor's answers a line at a time.
+type lineReader struct {
+	r    *bufio.Reader
+	w    io.Writer
+	echo bool
+	cr   bool // the last line ended in a carriage return
+}
+
+// readLine returns the next line without its ending. Ctrl+C and Ctrl+D
+// end the input like io.EOF, and arrow keys and other escape sequences
+// are ignored rather than typed.
+func (l *lineReader) readLine() (string, error) {
+	var line []rune
+	for {
+		r, _, err := l.r.ReadRune()
+		if err != nil {
+			if errors.Is(err, io.EOF) && len(line) > 0 {
+				return string(line), nil
+			}
+			return "", err
+		}
+		// A newline straight after a carriage return ends the same line.
+		if r == '\n' && l.cr && len(line) == 0 {
+			l.cr = false
+			continue
+		}
+		l.cr = false
+		switch {
+		case r == '\r' || r == '\n':
+			l.cr = r == '\r'
+			l.write("\n")
+			return string(line), nil
+		case r == 3 || r == 4:
+			return "", io.EOF
+		case r == 0x7f || r == '\b':
+			if len(line) > 0 {
+				line = line[:len(line)-1]
+				l.write("\b \b")
+			}
+		case r == 0x1b:
+			l.skipEscape()
+		case unicode.IsPrint(r):
+			line = append(line, r)
+			l.write(string(r))
+		}
+	}
+}
+
+// skipEscape consumes the rest of an escape sequence, such as the "[A"
+// an up arrow sends after ESC.
+func (l *lineReader) skipEscape() {
+	next, _, err := l.r.ReadRune()
+	if err != nil || (next != '[' && next != 'O') {
+		if err == nil {
+			_ = l.r.UnreadRune()
+		}
+		return
+	}
+	for {
+		r, _, err := l.r.ReadRune()
+		if err != nil || (r >= 0x40 && r <= 0x7e) {
+			return
+		}
+	}
+}
+
+// write echoes s when echoing is on.
+func (l *lineReader) write(s string) {
+	if l.echo {
+		_, _ = io.WriteString(l.w, s)
+	}
+}
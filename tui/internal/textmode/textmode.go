@@ -0,0 +1,337 @@
+// Package textmode renders portfolio sections as plain text for
+// non-interactive SSH sessions, such as `ssh host cv`, so visitors can read
+// or pipe a section without starting the TUI.
+package textmode
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/buntingszn/terminal-portfolio/tui/internal/app"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/content"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/guestbook"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/proof"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/resume"
+)
+
+// Width is the column width text is wrapped to.
+const Width = 80
+
+// ErrUnknownCommand is returned by Run for a command it does not serve.
+var ErrUnknownCommand = errors.New("unknown command")
+
+// command is one section served in text mode.
+type command struct {
+	name    string
+	aliases []string
+	summary string
+	render  func(b *strings.Builder, s Source)
+	binary  bool // output is not text and should not go to a terminal
+}
+
+// Source is the data a command renders from.
+type Source struct {
+	Content   *content.Content
+	Guestbook *guestbook.Store // nil shows the guestbook as unavailable
+	Proof     *proof.Proof     // nil when no verify key is configured
+	Now       time.Time
+	// Args are the words after the command, such as "signature" in
+	// `ssh host verify signature`.
+	Args []string
+}
+
+// commands lists the served commands in the order help shows them.
+var commands = []command{
+	{"home", []string{"about"}, "bio and contact details", renderHome, false},
+	{"work", []string{"projects"}, "selected projects", renderWork, false},
+	{"cv", []string{"resume"}, "experience, skills, and education", renderCV, false},
+	{"pdf", nil, "the CV as a PDF; redirect it to a file", renderPDF, true},
+	{"links", nil, "where to find me elsewhere", renderLinks, false},
+	{"guestbook", []string{"gb"}, "recent guestbook entries", renderGuestbook, false},
+	{"verify", nil, "a signed statement of my accounts and domains", renderVerify, false},
+	{"help", nil, "this list", nil, false},
+}
+
+// commandFiles are the content files commands render from. A command
+// whose file did not load is refused, as its TUI section is hidden.
+var commandFiles = map[string]string{
+	"work":  "work.json",
+	"cv":    "cv.json",
+	"pdf":   "cv.json",
+	"links": "links.json",
+}
+
+// lookup returns the command called name or one of its aliases.
+func lookup(name string) (command, bool) {
+	for _, c := range commands {
+		if c.name == name {
+			return c, true
+		}
+		for _, a := range c.aliases {
+			if a == name {
+				return c, true
+			}
+		}
+	}
+	return command{}, false
+}
+
+// Binary reports whether the named command writes binary output, which
+// callers should refuse to send to an interactive terminal.
+func Binary(name string) bool {
+	cmd, ok := lookup(strings.ToLower(name))
+	return ok && cmd.binary
+}
+
+// Run writes the plain-text rendering of the named section to w. An unknown
+// name writes nothing and returns an error wrapping ErrUnknownCommand.
+func Run(w io.Writer, name string, s Source) error {
+	cmd, ok := lookup(strings.ToLower(name))
+	if !ok {
+		return fmt.Errorf("%w %q; try \"help\"", ErrUnknownCommand, name)
+	}
+	if file, ok := commandFiles[cmd.name]; ok && !s.Content.Available(file) {
+		return fmt.Errorf("%s is unavailable right now; try again later", cmd.name)
+	}
+	if s.Now.IsZero() {
+		s.Now = time.Now()
+	}
+
+	var b strings.Builder
+	if cmd.render == nil {
+		renderHelp(&b)
+	} else {
+		cmd.render(&b, s)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// renderHelp lists the available commands.
+func renderHelp(b *strings.Builder) {
+	b.WriteString("Usage: ssh <host> [command]\n\n")
+	b.WriteString("Without a command the interactive portfolio starts. Commands:\n\n")
+	for _, c := range commands {
+		name := c.name
+		if len(c.aliases) > 0 {
+			name += " (" + strings.Join(c.aliases, ", ") + ")"
+		}
+		fmt.Fprintf(b, "  %-22s %s\n", name, c.summary)
+	}
+}
+
+// heading writes an underlined section heading.
+func heading(b *strings.Builder, title string) {
+	fmt.Fprintf(b, "%s\n%s\n\n", title, strings.Repeat("=", len(title)))
+}
+
+// wrapped writes text wrapped to Width, with every line indented.
+func wrapped(b *strings.Builder, text, indent string) {
+	for _, line := range app.WrapText(text, Width-len(indent)) {
+		b.WriteString(indent + line + "\n")
+	}
+}
+
+// field writes a "Label  value" line when value is set.
+func field(b *strings.Builder, label, value string) {
+	if value != "" {
+		fmt.Fprintf(b, "%-8s %s\n", label, value)
+	}
+}
+
+func renderHome(b *strings.Builder, s Source) {
+	c := s.Content
+	heading(b, c.Meta.Name)
+	if c.Meta.Title != "" {
+		b.WriteString(c.Meta.Title + "\n\n")
+	}
+	if c.About.Bio != "" {
+		wrapped(b, c.About.Bio, "")
+		b.WriteByte('\n')
+	}
+	if a := c.About.Availability; a != nil {
+		field(b, "Status", a.Summary(s.Now))
+	}
+	field(b, "Book", c.About.Booking)
+	field(b, "Email", c.About.Email)
+	field(b, "Web", c.Meta.SiteURL)
+	if rel := app.RelativeTime(c.UpdatedAt, s.Now); rel != "" {
+		b.WriteString("\ncontent updated " + rel + "\n")
+	}
+}
+
+func renderWork(b *strings.Builder, s Source) {
+	heading(b, "Work")
+	projects := s.Content.Work.FeaturedFirst()
+	if len(projects) == 0 {
+		b.WriteString("No projects to display.\n")
+	}
+	for i, p := range projects {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		b.WriteString(p.Title + "\n")
+		if p.Description != "" {
+			wrapped(b, p.Description, "    ")
+		}
+		if len(p.Tags) > 0 {
+			b.WriteString("    " + strings.Join(p.Tags, " · ") + "\n")
+		}
+		if p.URL != "" {
+			b.WriteString("    " + p.URL + "\n")
+		}
+		if p.Repo != "" && p.Repo != p.URL {
+			b.WriteString("    " + p.Repo + "\n")
+		}
+	}
+}
+
+func renderCV(b *strings.Builder, s Source) {
+	c := s.Content
+	cv := c.CV
+	heading(b, c.Meta.Name)
+	var contact []string
+	for _, v := range []string{cv.Contact.Email, cv.Contact.Location, cv.Contact.Website} {
+		if v != "" {
+			contact = append(contact, v)
+		}
+	}
+	if len(contact) > 0 {
+		b.WriteString(strings.Join(contact, " · ") + "\n\n")
+	}
+	if cv.Summary != "" {
+		wrapped(b, cv.Summary, "")
+		b.WriteByte('\n')
+	}
+
+	if len(cv.Experience) > 0 {
+		b.WriteString("EXPERIENCE\n\n")
+		for i, exp := range cv.Experience {
+			if i > 0 {
+				b.WriteByte('\n')
+			}
+			dates := exp.Start
+			if exp.End != "" {
+				dates += " - " + exp.End
+			}
+			fmt.Fprintf(b, "  %s @ %s  %s\n", exp.Role, exp.Company, dates)
+			for _, bullet := range exp.Bullets {
+				for j, line := range app.WrapText(bullet, Width-6) {
+					if j == 0 {
+						b.WriteString("    - " + line + "\n")
+					} else {
+						b.WriteString("      " + line + "\n")
+					}
+				}
+			}
+		}
+		b.WriteByte('\n')
+	}
+
+	if len(cv.Skills) > 0 {
+		b.WriteString("SKILLS\n\n")
+		catWidth := 0
+		for _, sk := range cv.Skills {
+			catWidth = max(catWidth, len(sk.Category))
+		}
+		for _, sk := range cv.Skills {
+			indent := strings.Repeat(" ", catWidth+4)
+			lines := app.WrapText(strings.Join(sk.Items, ", "), Width-len(indent))
+			for j, line := range lines {
+				if j == 0 {
+					fmt.Fprintf(b, "  %-*s  %s\n", catWidth, sk.Category, line)
+				} else {
+					b.WriteString(indent + line + "\n")
+				}
+			}
+		}
+		b.WriteByte('\n')
+	}
+
+	if len(cv.Education) > 0 {
+		b.WriteString("EDUCATION\n\n")
+		for _, edu := range cv.Education {
+			fmt.Fprintf(b, "  %s @ %s  %s\n", edu.Degree, edu.Institution, edu.Year)
+		}
+	}
+}
+
+func renderPDF(b *strings.Builder, s Source) {
+	b.Write(resume.PDF(s.Content))
+}
+
+func renderLinks(b *strings.Builder, s Source) {
+	heading(b, "Links")
+	links := s.Content.Links.Links
+	if len(links) == 0 {
+		b.WriteString("No links to display.\n")
+	}
+	labelWidth := 0
+	for _, l := range links {
+		labelWidth = max(labelWidth, len(l.Label))
+	}
+	for _, l := range links {
+		fmt.Fprintf(b, "  %-*s  %s\n", labelWidth, l.Label, l.URL)
+	}
+}
+
+func renderGuestbook(b *strings.Builder, s Source) {
+	heading(b, "Guestbook")
+	if s.Guestbook == nil {
+		b.WriteString("The guestbook is unavailable right now.\n")
+		return
+	}
+	entries := s.Guestbook.Entries()
+	if len(entries) == 0 {
+		b.WriteString("No entries yet. Connect without a command to sign it!\n")
+	}
+	for i, e := range entries {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		b.WriteString(e.Name + " · " + app.RelativeTime(e.Time, s.Now) + "\n")
+		wrapped(b, e.Message, "  ")
+	}
+}
+
+// renderVerify writes the signed identity statement with the commands to
+// check it. "verify statement" and "verify signature" write just that
+// part, so each can be redirected to the file ssh-keygen reads.
+func renderVerify(b *strings.Builder, s Source) {
+	p := s.Proof
+	if p == nil {
+		b.WriteString("No signed statement is configured.\n")
+		return
+	}
+	part := ""
+	if len(s.Args) > 0 {
+		part = strings.ToLower(s.Args[0])
+	}
+	switch part {
+	case "statement":
+		b.WriteString(p.Statement)
+		return
+	case "signature", "sig":
+		b.WriteString(p.Signature)
+		return
+	case "":
+	default:
+		fmt.Fprintf(b, "Unknown part %q; use \"verify statement\" or \"verify signature\".\n", s.Args[0])
+		return
+	}
+
+	heading(b, "Verify")
+	b.WriteString(p.Statement)
+	b.WriteByte('\n')
+	b.WriteString(p.Signature)
+	b.WriteString("\nTo check the signature, save both parts and run ssh-keygen:\n\n")
+	b.WriteString("  ssh <host> verify statement > statement.txt\n")
+	b.WriteString("  ssh <host> verify signature > statement.sig\n")
+	fmt.Fprintf(b, "  echo 'owner %s' > allowed_signers\n", p.PublicKey)
+	fmt.Fprintf(b, "  ssh-keygen -Y verify -f allowed_signers -I owner -n %s -s statement.sig < statement.txt\n\n", proof.Namespace)
+	wrapped(b, "The signature proves only that the statement was signed by "+p.Fingerprint+
+		". Check that fingerprint against a key published on one of the accounts listed, such as github.com/<user>.keys.", "")
+}
@@ -0,0 +1,206 @@
+package textmode
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/buntingszn/terminal-portfolio/tui/internal/guestbook"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/proof"
+	"github.com/buntingszn/terminal-portfolio/tui/internal/testutil"
+)
+
+// run renders the named command from the fixture content.
+func run(t *testing.T, name string, s Source) string {
+	t.Helper()
+	if s.Content == nil {
+		s.Content = testutil.FixtureContent()
+	}
+	var b strings.Builder
+	if err := Run(&b, name, s); err != nil {
+		t.Fatalf("Run(%q): %v", name, err)
+	}
+	return b.String()
+}
+
+func TestRunSections(t *testing.T) {
+	tests := []struct {
+		name string
+		want []string
+	}{
+		{"home", []string{"software engineer", "Status", "Email"}},
+		{"work", []string{"Work\n===="}},
+		{"cv", []string{"EXPERIENCE", "SKILLS", "Software Engineer @ Independent  2021 - Present", "Nashville, TN"}},
+		{"links", []string{"https://github.com/buntingszn"}},
+		{"help", []string{"Usage: ssh <host> [command]", "cv (resume)"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := run(t, tt.name, Source{})
+			for _, want := range tt.want {
+				if !strings.Contains(out, want) {
+					t.Errorf("%s output missing %q:\n%s", tt.name, want, out)
+				}
+			}
+			for i, line := range strings.Split(out, "\n") {
+				if len([]rune(line)) > Width {
+					t.Errorf("%s line %d is wider than %d: %q", tt.name, i, Width, line)
+				}
+				if strings.Contains(line, "\x1b") {
+					t.Errorf("%s line %d contains an escape sequence: %q", tt.name, i, line)
+				}
+			}
+		})
+	}
+}
+
+func TestRunAliasesAndCase(t *testing.T) {
+	if run(t, "resume", Source{}) != run(t, "cv", Source{}) {
+		t.Error("resume should be an alias for cv")
+	}
+	if run(t, "LINKS", Source{}) != run(t, "links", Source{}) {
+		t.Error("command names should be case-insensitive")
+	}
+}
+
+func TestRunUnknownCommand(t *testing.T) {
+	var b strings.Builder
+	err := Run(&b, "rm", Source{Content: testutil.FixtureContent()})
+	if !errors.Is(err, ErrUnknownCommand) {
+		t.Fatalf("expected ErrUnknownCommand, got %v", err)
+	}
+	if b.Len() != 0 {
+		t.Errorf("unknown command should write nothing, got %q", b.String())
+	}
+}
+
+func TestRunUnavailable(t *testing.T) {
+	c := testutil.FixtureContent()
+	c.Unavailable = map[string]error{"cv.json": errors.New("broken")}
+	for _, name := range []string{"cv", "resume", "pdf"} {
+		var b strings.Builder
+		if err := Run(&b, name, Source{Content: c}); err == nil || b.Len() != 0 {
+			t.Errorf("Run(%q) = %v, %q; want an error and no output", name, err, b.String())
+		}
+	}
+	if out := run(t, "work", Source{Content: c}); out == "" {
+		t.Error("commands whose files loaded should still run")
+	}
+}
+
+func TestRunPDF(t *testing.T) {
+	out := run(t, "pdf", Source{})
+	if !strings.HasPrefix(out, "%PDF-") {
+		t.Errorf("pdf output should be a PDF document: %.20q", out)
+	}
+	if !Binary("pdf") || !Binary("PDF") || Binary("cv") || Binary("nope") {
+		t.Error("only pdf should be reported as binary")
+	}
+}
+
+func TestRunWorkFeaturedFirst(t *testing.T) {
+	c := testutil.FixtureContent()
+	c.Work.Projects[len(c.Work.Projects)-1].Featured = true
+	for i := range len(c.Work.Projects) - 1 {
+		c.Work.Projects[i].Featured = false
+	}
+	last := c.Work.Projects[len(c.Work.Projects)-1].Title
+	out := run(t, "work", Source{Content: c})
+	if !strings.HasPrefix(strings.SplitN(out, "\n\n", 2)[1], last+"\n") {
+		t.Errorf("featured project %q should be listed first:\n%s", last, out)
+	}
+}
+
+func TestRunGuestbook(t *testing.T) {
+	if out := run(t, "guestbook", Source{}); !strings.Contains(out, "unavailable") {
+		t.Errorf("nil store should be reported unavailable: %q", out)
+	}
+
+	store, err := guestbook.Open(filepath.Join(t.TempDir(), guestbook.FileName), time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	if _, err := store.Add("10.0.0.1", "ada", "hello from the shell"); err != nil {
+		t.Fatal(err)
+	}
+	out := run(t, "gb", Source{Guestbook: store})
+	if !strings.Contains(out, "ada · ") || !strings.Contains(out, "  hello from the shell") {
+		t.Errorf("guestbook entry missing:\n%s", out)
+	}
+}
+
+func TestRunVerify(t *testing.T) {
+	if out := run(t, "verify", Source{}); !strings.Contains(out, "No signed statement") {
+		t.Errorf("verify without a proof should say so:\n%s", out)
+	}
+
+	p := &proof.Proof{
+		Statement:   "I am Kyle.\n",
+		Signature:   "-----BEGIN SSH SIGNATURE-----\nAAAA\n-----END SSH SIGNATURE-----\n",
+		PublicKey:   "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5",
+		Fingerprint: "SHA256:abc",
+	}
+	out := run(t, "verify", Source{Proof: p})
+	for _, want := range []string{p.Statement, p.Signature, "echo 'owner ssh-ed25519 AAAAC3NzaC1lZDI1NTE5' > allowed_signers", "-n " + proof.Namespace, "SHA256:abc"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("verify output missing %q:\n%s", want, out)
+		}
+	}
+	if got := run(t, "verify", Source{Proof: p, Args: []string{"statement"}}); got != p.Statement {
+		t.Errorf("verify statement = %q, want the statement alone", got)
+	}
+	if got := run(t, "verify", Source{Proof: p, Args: []string{"Signature"}}); got != p.Signature {
+		t.Errorf("verify signature = %q, want the signature alone", got)
+	}
+}
+
+func TestBrowse(t *testing.T) {
+	s := Source{Content: testutil.FixtureContent()}
+	var b strings.Builder
+	if err := Browse(strings.NewReader("CV\r\nnope\n\npdf\nquit\n"), &b, s, false); err != nil {
+		t.Fatal(err)
+	}
+	out := b.String()
+	for _, want := range []string{
+		"Accessible text mode",
+		run(t, "home", s),
+		run(t, "cv", s),
+		`There is no section called "nope".`,
+		"Sections:\n",
+		"pdf writes a file",
+		"Goodbye.",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output lacks %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "\x1b") {
+		t.Error("output should have no escape sequences")
+	}
+	// The CR LF after CV is one line, so it prompts once for it.
+	if got := strings.Count(out, "press Enter.\n> "); got != 5 {
+		t.Errorf("prompted %d times, want 5", got)
+	}
+}
+
+func TestBrowseEcho(t *testing.T) {
+	s := Source{Content: testutil.FixtureContent()}
+	var b strings.Builder
+	// A typo fixed with backspace, and an arrow key, which is ignored.
+	if err := Browse(strings.NewReader("linkx\x7fs\x1b[A\r"), &b, s, true); err != nil {
+		t.Fatal(err)
+	}
+	out := b.String()
+	if !strings.Contains(out, "linkx\b \bs\n") {
+		t.Errorf("typing should be echoed and edited: %q", out)
+	}
+	if !strings.Contains(out, run(t, "links", s)) {
+		t.Errorf("links should be shown:\n%s", out)
+	}
+	if !strings.HasSuffix(out, "\nGoodbye.\n") {
+		t.Errorf("the end of input should say goodbye: %q", out)
+	}
+}